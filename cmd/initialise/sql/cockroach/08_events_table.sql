@@ -6,6 +6,7 @@ CREATE TABLE IF NOT EXISTS eventstore.events (
 	, aggregate_type TEXT NOT NULL
 	, aggregate_id TEXT NOT NULL
 	, aggregate_version TEXT NOT NULL
+	, event_schema_version INT2 NOT NULL DEFAULT 0
 	, event_sequence BIGINT NOT NULL
 	, previous_aggregate_sequence BIGINT
 	, previous_aggregate_type_sequence INT8
@@ -0,0 +1,81 @@
+package projections
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/zitadel/zitadel/cmd/key"
+	"github.com/zitadel/zitadel/internal/crypto"
+	cryptoDB "github.com/zitadel/zitadel/internal/crypto/database"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/query/projection"
+)
+
+func newRebuild() *cobra.Command {
+	return &cobra.Command{
+		Use:   "rebuild [projection]",
+		Short: "rebuilds a projection without downtime",
+		Long: `rebuilds the projection from sequence zero into shadow tables.
+The projection keeps serving its current state during the rebuild.
+As soon as the shadow tables caught up, they are swapped into place
+and the replaced tables are dropped after the swap is verified.
+Requirements:
+- cockroachdb`,
+		Example: `rebuild projections.users8`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config := MustNewConfig(viper.GetViper())
+			masterKey, err := key.MasterKey(cmd)
+			if err != nil {
+				return err
+			}
+			return Rebuild(cmd.Context(), config, masterKey, args[0], cmd.OutOrStdout())
+		},
+	}
+}
+
+// Rebuild rebuilds the projection and prints the progress to out
+func Rebuild(ctx context.Context, config *Config, masterKey, projectionName string, out io.Writer) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	dbClient, err := database.Connect(config.Database, false)
+	if err != nil {
+		return fmt.Errorf("unable to connect to database: %w", err)
+	}
+	keyStorage, err := cryptoDB.NewKeyStorage(dbClient.DB, masterKey)
+	if err != nil {
+		return fmt.Errorf("cannot start key storage: %w", err)
+	}
+	keyEncryption, err := crypto.NewAESCrypto(config.EncryptionKeys.OIDC, keyStorage)
+	if err != nil {
+		return err
+	}
+	certEncryption, err := crypto.NewAESCrypto(config.EncryptionKeys.SAML, keyStorage)
+	if err != nil {
+		return err
+	}
+	es, err := eventstore.Start(&eventstore.Config{Client: dbClient})
+	if err != nil {
+		return fmt.Errorf("unable to start eventstore: %w", err)
+	}
+	query.RegisterEventMappers(es)
+	if err = projection.Create(ctx, dbClient, es, config.Projections, keyEncryption, certEncryption); err != nil {
+		return fmt.Errorf("unable to create projections: %w", err)
+	}
+
+	err = projection.Rebuild(ctx, projectionName, func(progress crdb.RebuildProgress) {
+		fmt.Fprintf(out, "%s: %s, instances %d/%d, events %d\n", progress.Projection, progress.Phase, progress.InstancesDone, progress.Instances, progress.Events)
+	})
+	if err != nil {
+		return fmt.Errorf("rebuild of %s failed: %w", projectionName, err)
+	}
+	return nil
+}
@@ -0,0 +1,25 @@
+package setup
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+)
+
+var (
+	//go:embed 11.sql
+	tokenConfirmationStmts string
+)
+
+type TokenConfirmationColumns struct {
+	dbClient *sql.DB
+}
+
+func (mig *TokenConfirmationColumns) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, tokenConfirmationStmts)
+	return err
+}
+
+func (mig *TokenConfirmationColumns) String() string {
+	return "11_token_confirmation_columns"
+}
@@ -0,0 +1,2 @@
+ALTER TABLE auth.tokens ADD COLUMN IF NOT EXISTS confirmation_jkt TEXT;
+ALTER TABLE auth.tokens ADD COLUMN IF NOT EXISTS confirmation_x5t TEXT;
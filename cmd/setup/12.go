@@ -0,0 +1,25 @@
+package setup
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+)
+
+var (
+	//go:embed 12.sql
+	otpCodeFactorsStmts string
+)
+
+type OTPCodeFactorsColumns struct {
+	dbClient *sql.DB
+}
+
+func (mig *OTPCodeFactorsColumns) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, otpCodeFactorsStmts)
+	return err
+}
+
+func (mig *OTPCodeFactorsColumns) String() string {
+	return "12_otp_code_factors_columns"
+}
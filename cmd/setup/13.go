@@ -0,0 +1,25 @@
+package setup
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+)
+
+var (
+	//go:embed 13.sql
+	recoveryCodesStmts string
+)
+
+type RecoveryCodesColumns struct {
+	dbClient *sql.DB
+}
+
+func (mig *RecoveryCodesColumns) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, recoveryCodesStmts)
+	return err
+}
+
+func (mig *RecoveryCodesColumns) String() string {
+	return "13_recovery_codes_columns"
+}
@@ -0,0 +1 @@
+ALTER TABLE auth.users2 ADD COLUMN IF NOT EXISTS recovery_codes_remaining INT2;
@@ -0,0 +1,25 @@
+package setup
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+)
+
+var (
+	//go:embed 14.sql
+	userSessionBrowserInfoStmts string
+)
+
+type UserSessionBrowserInfoColumns struct {
+	dbClient *sql.DB
+}
+
+func (mig *UserSessionBrowserInfoColumns) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, userSessionBrowserInfoStmts)
+	return err
+}
+
+func (mig *UserSessionBrowserInfoColumns) String() string {
+	return "14_user_session_browser_info_columns"
+}
@@ -0,0 +1,2 @@
+ALTER TABLE auth.user_sessions ADD COLUMN IF NOT EXISTS user_agent TEXT;
+ALTER TABLE auth.user_sessions ADD COLUMN IF NOT EXISTS remote_ip TEXT;
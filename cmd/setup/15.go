@@ -0,0 +1,40 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+)
+
+var (
+	//go:embed 15_extension.sql
+	userSearchExtension15 string
+	//go:embed 15_indexes.sql
+	userSearchIndexes15 string
+)
+
+const userSearchTableExists15 = "SELECT EXISTS (SELECT 1 FROM information_schema.tables WHERE table_schema = 'projections' AND table_name = 'users8_humans')"
+
+type UserSearchIndexes struct {
+	dbClient *database.DB
+}
+
+func (mig *UserSearchIndexes) Execute(ctx context.Context) error {
+	if mig.dbClient.Type() == "postgres" {
+		if _, err := mig.dbClient.ExecContext(ctx, userSearchExtension15); err != nil {
+			return err
+		}
+	}
+	// the indexes of new projection tables are created with the tables
+	var exists bool
+	if err := mig.dbClient.QueryRowContext(ctx, userSearchTableExists15).Scan(&exists); err != nil || !exists {
+		return err
+	}
+	_, err := mig.dbClient.ExecContext(ctx, userSearchIndexes15)
+	return err
+}
+
+func (mig *UserSearchIndexes) String() string {
+	return "15_user_search_indexes"
+}
@@ -0,0 +1,7 @@
+CREATE INDEX IF NOT EXISTS users8_username_trgm_idx ON projections.users8 USING GIN (username gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS users8_humans_first_name_trgm_idx ON projections.users8_humans USING GIN (first_name gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS users8_humans_last_name_trgm_idx ON projections.users8_humans USING GIN (last_name gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS users8_humans_nick_name_trgm_idx ON projections.users8_humans USING GIN (nick_name gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS users8_humans_display_name_trgm_idx ON projections.users8_humans USING GIN (display_name gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS users8_humans_email_trgm_idx ON projections.users8_humans USING GIN (email gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS users8_humans_phone_trgm_idx ON projections.users8_humans USING GIN (phone gin_trgm_ops);
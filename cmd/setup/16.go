@@ -0,0 +1,25 @@
+package setup
+
+import (
+	"embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+)
+
+var (
+	//go:embed 16/cockroach/index.sql
+	//go:embed 16/postgres/index.sql
+	stmts16 embed.FS
+)
+
+// New16 stores the schema version of the events in the indexes of the events,
+// the column itself is added by addEventSchemaVersion before the migrations
+func New16(db *database.DB) *EventstoreIndexesNew {
+	return &EventstoreIndexesNew{
+		dbClient: db,
+		name:     "16_event_schema_version_indexes",
+		step:     "16",
+		fileName: "index.sql",
+		stmts:    stmts16,
+	}
+}
@@ -0,0 +1,2 @@
+-- the indexes of postgres do not store the columns of the events, the column is added before the migrations
+ALTER TABLE eventstore.events ADD COLUMN IF NOT EXISTS event_schema_version INT2 NOT NULL DEFAULT 0;
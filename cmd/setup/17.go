@@ -0,0 +1,26 @@
+package setup
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+)
+
+var (
+	//go:embed 17.sql
+	snapshotsTableStmt string
+)
+
+// SnapshotsTable creates the table for the snapshots of write models
+type SnapshotsTable struct {
+	dbClient *sql.DB
+}
+
+func (mig *SnapshotsTable) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, snapshotsTableStmt)
+	return err
+}
+
+func (mig *SnapshotsTable) String() string {
+	return "17_snapshots_table"
+}
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS eventstore.snapshots (
+    instance_id TEXT NOT NULL,
+    aggregate_type TEXT NOT NULL,
+    aggregate_id TEXT NOT NULL,
+    model TEXT NOT NULL,
+    version TEXT NOT NULL,
+    sequence BIGINT NOT NULL,
+    resource_owner TEXT NOT NULL,
+    change_date TIMESTAMPTZ NOT NULL,
+    data JSONB NOT NULL,
+
+    PRIMARY KEY (instance_id, aggregate_type, aggregate_id, model)
+);
@@ -0,0 +1,50 @@
+package setup
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+)
+
+var (
+	//go:embed 18.sql
+	dropAuthViewsStmts string
+)
+
+// DropAuthViews removes the user sessions, tokens and refresh tokens views of the auth spooler,
+// they are computed by the corresponding projections.
+// The instances of the previous version still use the views during a rolling upgrade,
+// therefore the first run only records the version and the views are dropped
+// by the setup of the next version.
+type DropAuthViews struct {
+	dbClient       *sql.DB
+	currentVersion string
+	dropped        bool
+
+	Version string `json:"version"`
+	Dropped bool   `json:"dropped"`
+}
+
+func (mig *DropAuthViews) SetLastExecution(lastRun map[string]interface{}) {
+	mig.currentVersion, _ = lastRun["version"].(string)
+	mig.dropped, _ = lastRun["dropped"].(bool)
+}
+
+func (mig *DropAuthViews) Check() bool {
+	return !mig.dropped && mig.currentVersion != mig.Version
+}
+
+func (mig *DropAuthViews) Execute(ctx context.Context) error {
+	if mig.currentVersion == "" {
+		return nil
+	}
+	if _, err := mig.dbClient.ExecContext(ctx, dropAuthViewsStmts); err != nil {
+		return err
+	}
+	mig.Dropped = true
+	return nil
+}
+
+func (mig *DropAuthViews) String() string {
+	return "18_drop_auth_views"
+}
@@ -0,0 +1,5 @@
+DELETE FROM auth.current_sequences WHERE view_name IN ('auth.user_sessions', 'auth.tokens', 'auth.refresh_tokens');
+DELETE FROM auth.failed_events WHERE view_name IN ('auth.user_sessions', 'auth.tokens', 'auth.refresh_tokens');
+DROP TABLE IF EXISTS auth.user_sessions;
+DROP TABLE IF EXISTS auth.tokens;
+DROP TABLE IF EXISTS auth.refresh_tokens;
@@ -56,16 +56,25 @@ func MustNewConfig(v *viper.Viper) *Config {
 }
 
 type Steps struct {
-	s1ProjectionTable    *ProjectionTable
-	s2AssetsTable        *AssetTable
-	FirstInstance        *FirstInstance
-	s4EventstoreIndexes  *EventstoreIndexesNew
-	s5LastFailed         *LastFailed
-	s6OwnerRemoveColumns *OwnerRemoveColumns
-	s7LogstoreTables     *LogstoreTables
-	s8AuthTokens         *AuthTokenIndexes
-	s9EventstoreIndexes2 *EventstoreIndexesNew
-	CorrectCreationDate  *CorrectCreationDate
+	s1ProjectionTable     *ProjectionTable
+	s2AssetsTable         *AssetTable
+	FirstInstance         *FirstInstance
+	s4EventstoreIndexes   *EventstoreIndexesNew
+	s5LastFailed          *LastFailed
+	s6OwnerRemoveColumns  *OwnerRemoveColumns
+	s7LogstoreTables      *LogstoreTables
+	s8AuthTokens          *AuthTokenIndexes
+	s9EventstoreIndexes2  *EventstoreIndexesNew
+	CorrectCreationDate   *CorrectCreationDate
+	s11TokenConfirmation  *TokenConfirmationColumns
+	s12OTPCodeFactors     *OTPCodeFactorsColumns
+	s13RecoveryCodes      *RecoveryCodesColumns
+	s14UserSessionInfo    *UserSessionBrowserInfoColumns
+	s15UserSearchIndexes  *UserSearchIndexes
+	s16EventSchemaVersion *EventstoreIndexesNew
+	s17SnapshotsTable     *SnapshotsTable
+	s18AuthViews          *DropAuthViews
+	UpcastEvents          *UpcastEvents
 }
 
 type encryptionKeyConfig struct {
@@ -0,0 +1,20 @@
+package setup
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+)
+
+var (
+	//go:embed event_schema_version.sql
+	addEventSchemaVersionStmt string
+)
+
+// addEventSchemaVersion adds the schema version of the event payloads, which is increased by the upcasters.
+// It's executed before the migrations, because the migrations read their state from the events,
+// which are queried including the schema version
+func addEventSchemaVersion(ctx context.Context, client *sql.DB) error {
+	_, err := client.ExecContext(ctx, addEventSchemaVersionStmt)
+	return err
+}
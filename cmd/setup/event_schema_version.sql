@@ -0,0 +1 @@
+ALTER TABLE eventstore.events ADD COLUMN IF NOT EXISTS event_schema_version INT2 NOT NULL DEFAULT 0;
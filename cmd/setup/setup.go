@@ -15,6 +15,7 @@ import (
 	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/migration"
+	"github.com/zitadel/zitadel/internal/query"
 	"github.com/zitadel/zitadel/internal/query/projection"
 )
 
@@ -64,10 +65,13 @@ func Setup(config *Config, steps *Steps, masterKey string) {
 
 	dbClient, err := database.Connect(config.Database, false)
 	logging.OnError(err).Fatal("unable to connect to database")
+	err = addEventSchemaVersion(ctx, dbClient.DB)
+	logging.OnError(err).Fatal("unable to add event schema version")
 
 	eventstoreClient, err := eventstore.Start(&eventstore.Config{Client: dbClient})
 	logging.OnError(err).Fatal("unable to start eventstore")
 	migration.RegisterMappers(eventstoreClient)
+	query.RegisterEventMappers(eventstoreClient)
 
 	steps.s1ProjectionTable = &ProjectionTable{dbClient: dbClient.DB}
 	steps.s2AssetsTable = &AssetTable{dbClient: dbClient.DB}
@@ -91,6 +95,19 @@ func Setup(config *Config, steps *Steps, masterKey string) {
 	steps.s8AuthTokens = &AuthTokenIndexes{dbClient: dbClient}
 	steps.s9EventstoreIndexes2 = New09(dbClient)
 	steps.CorrectCreationDate.dbClient = dbClient
+	steps.s11TokenConfirmation = &TokenConfirmationColumns{dbClient: dbClient.DB}
+	steps.s12OTPCodeFactors = &OTPCodeFactorsColumns{dbClient: dbClient.DB}
+	steps.s13RecoveryCodes = &RecoveryCodesColumns{dbClient: dbClient.DB}
+	steps.s14UserSessionInfo = &UserSessionBrowserInfoColumns{dbClient: dbClient.DB}
+	steps.s15UserSearchIndexes = &UserSearchIndexes{dbClient: dbClient}
+	steps.s16EventSchemaVersion = New16(dbClient)
+	steps.s17SnapshotsTable = &SnapshotsTable{dbClient: dbClient.DB}
+	steps.s18AuthViews = &DropAuthViews{dbClient: dbClient.DB, Version: build.Version()}
+	if steps.UpcastEvents == nil {
+		steps.UpcastEvents = new(UpcastEvents)
+	}
+	steps.UpcastEvents.es = eventstoreClient
+	steps.UpcastEvents.Upcasters = upcastersVersion(eventstoreClient)
 
 	err = projection.Create(ctx, dbClient, eventstoreClient, config.Projections, nil, nil)
 	logging.OnError(err).Fatal("unable to start projections")
@@ -106,6 +123,7 @@ func Setup(config *Config, steps *Steps, masterKey string) {
 			es:      eventstoreClient,
 			Version: build.Version(),
 		},
+		steps.UpcastEvents,
 	}
 
 	err = migration.Migrate(ctx, eventstoreClient, steps.s1ProjectionTable)
@@ -128,6 +146,22 @@ func Setup(config *Config, steps *Steps, masterKey string) {
 	logging.OnError(err).Fatal("unable to migrate step 9")
 	err = migration.Migrate(ctx, eventstoreClient, steps.CorrectCreationDate)
 	logging.OnError(err).Fatal("unable to migrate step 10")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s11TokenConfirmation)
+	logging.OnError(err).Fatal("unable to migrate step 11")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s12OTPCodeFactors)
+	logging.OnError(err).Fatal("unable to migrate step 12")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s13RecoveryCodes)
+	logging.OnError(err).Fatal("unable to migrate step 13")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s14UserSessionInfo)
+	logging.OnError(err).Fatal("unable to migrate step 14")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s15UserSearchIndexes)
+	logging.OnError(err).Fatal("unable to migrate step 15")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s16EventSchemaVersion)
+	logging.OnError(err).Fatal("unable to migrate step 16")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s17SnapshotsTable)
+	logging.OnError(err).Fatal("unable to migrate step 17")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s18AuthViews)
+	logging.OnError(err).Fatal("unable to migrate step 18")
 
 	for _, repeatableStep := range repeatableSteps {
 		err = migration.Migrate(ctx, eventstoreClient, repeatableStep)
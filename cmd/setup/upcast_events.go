@@ -0,0 +1,44 @@
+package setup
+
+import (
+	"context"
+	"strings"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+// UpcastEvents rewrites the stored events to the latest versions of the registered upcasters.
+// Events are upcasted on read anyway, rewriting them removes the overhead.
+// The step is executed if enabled and the registered upcasters changed since the last execution
+type UpcastEvents struct {
+	Enabled bool `json:"-"`
+
+	es            *eventstore.Eventstore
+	lastUpcasters string
+
+	Upcasters string `json:"upcasters"`
+}
+
+func (mig *UpcastEvents) SetLastExecution(lastRun map[string]interface{}) {
+	mig.lastUpcasters, _ = lastRun["upcasters"].(string)
+}
+
+func (mig *UpcastEvents) Check() bool {
+	return mig.Enabled && mig.lastUpcasters != mig.Upcasters
+}
+
+func (mig *UpcastEvents) Execute(ctx context.Context) error {
+	upcasted, err := mig.es.UpcastStoredEvents(ctx)
+	logging.WithFields("upcasted", upcasted).Info("events upcasted")
+	return err
+}
+
+func (mig *UpcastEvents) String() string {
+	return "upcast_events"
+}
+
+func upcastersVersion(es *eventstore.Eventstore) string {
+	return strings.Join(es.Upcasters(), ",")
+}
@@ -44,6 +44,7 @@ import (
 	"github.com/zitadel/zitadel/internal/api/ui/console"
 	"github.com/zitadel/zitadel/internal/api/ui/login"
 	auth_es "github.com/zitadel/zitadel/internal/auth/repository/eventsourcing"
+	auth_request_cache "github.com/zitadel/zitadel/internal/auth_request/repository/cache"
 	"github.com/zitadel/zitadel/internal/authz"
 	authz_repo "github.com/zitadel/zitadel/internal/authz/repository"
 	"github.com/zitadel/zitadel/internal/command"
@@ -53,11 +54,13 @@ import (
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/id"
+	"github.com/zitadel/zitadel/internal/idpsync"
 	"github.com/zitadel/zitadel/internal/logstore"
 	"github.com/zitadel/zitadel/internal/logstore/emitters/access"
 	"github.com/zitadel/zitadel/internal/logstore/emitters/execution"
 	"github.com/zitadel/zitadel/internal/logstore/emitters/stdout"
 	"github.com/zitadel/zitadel/internal/notification"
+	"github.com/zitadel/zitadel/internal/purge"
 	"github.com/zitadel/zitadel/internal/query"
 	"github.com/zitadel/zitadel/internal/static"
 	"github.com/zitadel/zitadel/internal/webauthn"
@@ -149,7 +152,7 @@ func startZitadel(config *Config, masterKey string, server chan<- *Server) error
 		return fmt.Errorf("cannot start queries: %w", err)
 	}
 
-	authZRepo, err := authz.Start(queries, dbClient, keys.OIDC, config.ExternalSecure, config.Eventstore.AllowOrderByCreationDate)
+	authZRepo, err := authz.Start(queries, dbClient, eventstoreClient, keys.OIDC, config.ExternalSecure, config.Eventstore.AllowOrderByCreationDate)
 	if err != nil {
 		return fmt.Errorf("error starting authz repo: %w", err)
 	}
@@ -207,7 +210,12 @@ func startZitadel(config *Config, masterKey string, server chan<- *Server) error
 	}
 	actions.SetLogstoreService(actionsLogstoreSvc)
 
-	notification.Start(ctx, config.Projections.Customizations["notifications"], config.Projections.Customizations["notificationsquotas"], config.ExternalPort, config.ExternalSecure, commands, queries, eventstoreClient, assets.AssetAPIFromDomain(config.ExternalSecure, config.ExternalPort), config.SystemDefaults.Notifications.FileSystemPath, keys.User, keys.SMTP, keys.SMS)
+	notification.Start(ctx, config.Projections.Customizations["notifications"], config.Projections.Customizations["notificationsquotas"], config.Projections.Customizations["notificationsbackchannellogout"], config.Projections.Customizations["notificationsoutbox"], config.ExternalPort, config.ExternalSecure, commands, queries, eventstoreClient, assets.AssetAPIFromDomain(config.ExternalSecure, config.ExternalPort), config.SystemDefaults.Notifications.FileSystemPath, keys.User, keys.SMTP, keys.SMS, keys.OIDC)
+	ldapSyncer := idpsync.NewSyncer(commands, queries, keys.IDPConfig, keys.User, config.IDPSync.PageSize)
+	idpsync.Start(ctx, config.Projections.Customizations["idpsyncs"], ldapSyncer)
+	purge.Start(ctx, config.Purge, config.Projections.Customizations["purge"], purge.NewPurger(eventstoreClient, dbClient, storage, config.Purge.GracePeriod))
+	authRequests := auth_request_cache.Start(dbClient, config.Auth.AuthRequest)
+	auth_request_cache.StartCleanup(ctx, config.Auth.AuthRequest, config.Projections.Customizations["auth_requests_cleanup"], authRequests)
 
 	router := mux.NewRouter()
 	tlsConfig, err := config.TLS.Config()
@@ -229,6 +237,8 @@ func startZitadel(config *Config, masterKey string, server chan<- *Server) error
 		queries,
 		usageReporter,
 		permissionCheck,
+		ldapSyncer,
+		authRequests,
 	)
 	if err != nil {
 		return err
@@ -274,6 +284,8 @@ func startAPIs(
 	quotaQuerier logstore.QuotaQuerier,
 	usageReporter logstore.UsageReporter,
 	permissionCheck domain.PermissionCheck,
+	ldapSyncer *idpsync.Syncer,
+	authRequests *auth_request_cache.AuthRequestCache,
 ) error {
 	repo := struct {
 		authz_repo.Repository
@@ -322,10 +334,10 @@ func startAPIs(
 	if err := apis.RegisterServer(ctx, system.CreateServer(commands, queries, adminRepo, config.Database.DatabaseName(), config.DefaultInstance, config.ExternalDomain)); err != nil {
 		return err
 	}
-	if err := apis.RegisterServer(ctx, admin.CreateServer(config.Database.DatabaseName(), commands, queries, config.SystemDefaults, adminRepo, config.ExternalSecure, keys.User, config.AuditLogRetention)); err != nil {
+	if err := apis.RegisterServer(ctx, admin.CreateServer(config.Database.DatabaseName(), commands, queries, config.SystemDefaults, adminRepo, config.ExternalSecure, keys.User, keys.SMTP, config.AuditLogRetention, ldapSyncer, authRequests)); err != nil {
 		return err
 	}
-	if err := apis.RegisterServer(ctx, management.CreateServer(commands, queries, config.SystemDefaults, keys.User, config.ExternalSecure, config.AuditLogRetention)); err != nil {
+	if err := apis.RegisterServer(ctx, management.CreateServer(commands, queries, config.SystemDefaults, keys.User, config.ExternalSecure, config.AuditLogRetention, ldapSyncer)); err != nil {
 		return err
 	}
 	if err := apis.RegisterServer(ctx, auth.CreateServer(commands, queries, authRepo, config.SystemDefaults, keys.User, config.ExternalSecure, config.AuditLogRetention)); err != nil {
@@ -367,6 +379,7 @@ func startAPIs(
 	if err != nil {
 		return fmt.Errorf("unable to start oidc provider: %w", err)
 	}
+	apis.RegisterHandlerPrefixes(oidc.NewClientRegistrationHandler(commands, queries, config.ExternalSecure, instanceInterceptor.Handler, limitingAccessInterceptor.Handle), oidc.ClientRegistrationPath)
 	apis.RegisterHandlerPrefixes(oidcProvider.HttpHandler(), "/.well-known/openid-configuration", "/oidc/v1", "/oauth/v2")
 
 	samlProvider, err := saml.NewProvider(config.SAML, config.ExternalSecure, commands, queries, authRepo, keys.OIDC, keys.SAML, eventstore, dbClient, instanceInterceptor.Handler, userAgentInterceptor, limitingAccessInterceptor.Handle)
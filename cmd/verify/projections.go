@@ -0,0 +1,72 @@
+package verify
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/verify"
+)
+
+func newProjections() *cobra.Command {
+	q := new(verify.Query)
+	cmd := &cobra.Command{
+		Use:   "projections",
+		Short: "compares projections with their events",
+		Long: `replays the events of each aggregate into an in-memory read model
+and compares it with the rows of the projections per instance.
+Missing rows, unexpected rows and differing columns are reported.
+With --repair the rows of the affected aggregates are rebuilt from their events.
+Supported projections: ` + strings.Join(verify.Projections(), ", "),
+		Example: `verify projections --projection users --instance 123 --aggregate 456 --repair`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config := MustNewConfig(viper.GetViper())
+			return Projections(cmd.Context(), config, q, cmd.OutOrStdout())
+		},
+	}
+	cmd.Flags().StringArrayVar(&q.Projections, "projection", nil, "projection to verify, all supported projections if not set")
+	cmd.Flags().StringArrayVar(&q.InstanceIDs, "instance", nil, "id of the instance to verify, all instances if not set")
+	cmd.Flags().StringVar(&q.AggregateID, "aggregate", "", "limits the verification to the aggregate with the id")
+	cmd.Flags().BoolVar(&q.Repair, "repair", false, "rebuilds the rows of the aggregates with differences")
+	return cmd
+}
+
+// Projections verifies the projections and prints the differences and a summary per instance to out
+func Projections(ctx context.Context, config *Config, q *verify.Query, out io.Writer) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	dbClient, err := database.Connect(config.Database, false)
+	if err != nil {
+		return fmt.Errorf("unable to connect to database: %w", err)
+	}
+	es, err := eventstore.Start(&eventstore.Config{Client: dbClient})
+	if err != nil {
+		return fmt.Errorf("unable to start eventstore: %w", err)
+	}
+	query.RegisterEventMappers(es)
+	if q.Repair {
+		if err = projection.Create(ctx, dbClient, es, config.Projections, nil, nil); err != nil {
+			return fmt.Errorf("unable to create projections: %w", err)
+		}
+	}
+
+	results, err := verify.NewVerifier(es, dbClient).Verify(ctx, q, func(difference *verify.Difference) {
+		fmt.Fprintln(out, difference)
+	})
+	for _, result := range results {
+		fmt.Fprintf(out, "%s instance %s: aggregates %d, differences %d, repaired %d\n", result.Projection, result.InstanceID, result.Aggregates, result.Differences, result.Repaired)
+	}
+	if err != nil {
+		return fmt.Errorf("verification failed: %w", err)
+	}
+	return nil
+}
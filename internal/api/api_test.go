@@ -0,0 +1,61 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/api/oidc"
+)
+
+func TestAPI_RegisterHandlerPrefixes_clientRegistration(t *testing.T) {
+	passThrough := func(next http.Handler) http.Handler { return next }
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		wantStatus int
+	}{
+		{
+			name:       "register",
+			method:     http.MethodPost,
+			path:       oidc.ClientRegistrationPath,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "register with trailing slash",
+			method:     http.MethodPost,
+			path:       oidc.ClientRegistrationPath + "/",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "register wrong method",
+			method:     http.MethodGet,
+			path:       oidc.ClientRegistrationPath,
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name:       "oidc provider",
+			method:     http.MethodPost,
+			path:       "/oauth/v2/token",
+			wantStatus: http.StatusTeapot,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &API{router: mux.NewRouter()}
+			a.RegisterHandlerPrefixes(oidc.NewClientRegistrationHandler(nil, nil, false, passThrough, passThrough), oidc.ClientRegistrationPath)
+			a.RegisterHandlerPrefixes(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusTeapot)
+			}), "/oauth/v2")
+
+			recorder := httptest.NewRecorder()
+			a.router.ServeHTTP(recorder, httptest.NewRequest(tt.method, tt.path, strings.NewReader("{}")))
+			assert.Equal(t, tt.wantStatus, recorder.Code)
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package admin
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/auth_request/repository/cache"
+	"github.com/zitadel/zitadel/internal/domain"
+	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
+)
+
+func (s *Server) ListAuthRequests(ctx context.Context, req *admin_pb.ListAuthRequestsRequest) (*admin_pb.ListAuthRequestsResponse, error) {
+	result, err := s.authRequests.SearchAuthRequests(ctx, listAuthRequestsToQuery(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ListAuthRequestsResponse{
+		Details: object.ToListDetails(result.Count, 0, time.Time{}),
+		Result:  authRequestsToPb(result.Requests),
+	}, nil
+}
+
+func (s *Server) RemoveAuthRequest(ctx context.Context, req *admin_pb.RemoveAuthRequestRequest) (*admin_pb.RemoveAuthRequestResponse, error) {
+	if _, err := s.authRequests.GetAuthRequestByID(ctx, req.Id); err != nil {
+		return nil, err
+	}
+	if err := s.authRequests.DeleteAuthRequest(ctx, req.Id); err != nil {
+		return nil, err
+	}
+	return &admin_pb.RemoveAuthRequestResponse{}, nil
+}
+
+func listAuthRequestsToQuery(req *admin_pb.ListAuthRequestsRequest) *cache.AuthRequestSearchQuery {
+	offset, limit, _ := object.ListQueryToModel(req.Query)
+	return &cache.AuthRequestSearchQuery{
+		Offset:        offset,
+		Limit:         limit,
+		UserID:        req.UserId,
+		ApplicationID: req.ClientId,
+	}
+}
+
+func authRequestsToPb(requests []*domain.AuthRequest) []*admin_pb.AuthRequest {
+	result := make([]*admin_pb.AuthRequest, len(requests))
+	for i, request := range requests {
+		result[i] = authRequestToPb(request)
+	}
+	return result
+}
+
+func authRequestToPb(request *domain.AuthRequest) *admin_pb.AuthRequest {
+	return &admin_pb.AuthRequest{
+		Id:           request.ID,
+		CreationDate: timestamppb.New(request.CreationDate),
+		ChangeDate:   timestamppb.New(request.ChangeDate),
+		Type:         authRequestTypeToPb(request.Request),
+		ClientId:     request.ApplicationID,
+		UserId:       request.UserID,
+		LoginName:    request.LoginName,
+		UserAgentId:  request.AgentID,
+		CurrentStep:  authRequestStepToPb(request.CurrentStep()),
+	}
+}
+
+func authRequestTypeToPb(request domain.Request) admin_pb.AuthRequestType {
+	if request == nil {
+		return admin_pb.AuthRequestType_AUTH_REQUEST_TYPE_UNSPECIFIED
+	}
+	switch request.Type() {
+	case domain.AuthRequestTypeOIDC:
+		return admin_pb.AuthRequestType_AUTH_REQUEST_TYPE_OIDC
+	case domain.AuthRequestTypeSAML:
+		return admin_pb.AuthRequestType_AUTH_REQUEST_TYPE_SAML
+	case domain.AuthRequestTypeDevice:
+		return admin_pb.AuthRequestType_AUTH_REQUEST_TYPE_DEVICE
+	default:
+		return admin_pb.AuthRequestType_AUTH_REQUEST_TYPE_UNSPECIFIED
+	}
+}
+
+func authRequestStepToPb(step domain.AuthRequestStep) admin_pb.AuthRequestStep {
+	switch step {
+	case domain.AuthRequestStepUserSelection:
+		return admin_pb.AuthRequestStep_AUTH_REQUEST_STEP_USER_SELECTION
+	case domain.AuthRequestStepFirstFactor:
+		return admin_pb.AuthRequestStep_AUTH_REQUEST_STEP_FIRST_FACTOR
+	case domain.AuthRequestStepSecondFactor:
+		return admin_pb.AuthRequestStep_AUTH_REQUEST_STEP_SECOND_FACTOR
+	case domain.AuthRequestStepAuthenticated:
+		return admin_pb.AuthRequestStep_AUTH_REQUEST_STEP_AUTHENTICATED
+	case domain.AuthRequestStepCodeIssued:
+		return admin_pb.AuthRequestStep_AUTH_REQUEST_STEP_CODE_ISSUED
+	default:
+		return admin_pb.AuthRequestStep_AUTH_REQUEST_STEP_UNSPECIFIED
+	}
+}
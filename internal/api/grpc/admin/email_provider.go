@@ -0,0 +1,103 @@
+package admin
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/notification"
+	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
+)
+
+func (s *Server) ListEmailProviders(ctx context.Context, req *admin_pb.ListEmailProvidersRequest) (*admin_pb.ListEmailProvidersResponse, error) {
+	queries, err := listEmailProvidersToModel(req)
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.query.SearchEmailProviders(ctx, queries)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ListEmailProvidersResponse{
+		Details: object.ToListDetails(result.Count, result.Sequence, result.Timestamp),
+		Result:  EmailProvidersToPb(result.Providers),
+	}, nil
+}
+
+func (s *Server) GetEmailProvider(ctx context.Context, req *admin_pb.GetEmailProviderRequest) (*admin_pb.GetEmailProviderResponse, error) {
+	result, err := s.query.EmailProviderByID(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetEmailProviderResponse{
+		Config: EmailProviderToPb(result),
+	}, nil
+}
+
+func (s *Server) AddEmailProviderHTTP(ctx context.Context, req *admin_pb.AddEmailProviderHTTPRequest) (*admin_pb.AddEmailProviderHTTPResponse, error) {
+	id, result, err := s.command.AddEmailProviderHTTP(ctx, authz.GetInstance(ctx).InstanceID(), AddEmailProviderHTTPToConfig(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.AddEmailProviderHTTPResponse{
+		Details: object.DomainToAddDetailsPb(result),
+		Id:      id,
+	}, nil
+}
+
+func (s *Server) UpdateEmailProviderHTTP(ctx context.Context, req *admin_pb.UpdateEmailProviderHTTPRequest) (*admin_pb.UpdateEmailProviderHTTPResponse, error) {
+	result, err := s.command.ChangeEmailProviderHTTP(ctx, authz.GetInstance(ctx).InstanceID(), req.Id, UpdateEmailProviderHTTPToConfig(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.UpdateEmailProviderHTTPResponse{
+		Details: object.DomainToChangeDetailsPb(result),
+	}, nil
+}
+
+func (s *Server) UpdateEmailProviderHTTPHeaders(ctx context.Context, req *admin_pb.UpdateEmailProviderHTTPHeadersRequest) (*admin_pb.UpdateEmailProviderHTTPHeadersResponse, error) {
+	result, err := s.command.ChangeEmailProviderHTTPHeaders(ctx, authz.GetInstance(ctx).InstanceID(), req.Id, req.Headers)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.UpdateEmailProviderHTTPHeadersResponse{
+		Details: object.DomainToChangeDetailsPb(result),
+	}, nil
+}
+
+func (s *Server) ActivateEmailProvider(ctx context.Context, req *admin_pb.ActivateEmailProviderRequest) (*admin_pb.ActivateEmailProviderResponse, error) {
+	result, err := s.command.ActivateEmailProvider(ctx, authz.GetInstance(ctx).InstanceID(), req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ActivateEmailProviderResponse{
+		Details: object.DomainToChangeDetailsPb(result),
+	}, nil
+}
+
+func (s *Server) DeactivateEmailProvider(ctx context.Context, req *admin_pb.DeactivateEmailProviderRequest) (*admin_pb.DeactivateEmailProviderResponse, error) {
+	result, err := s.command.DeactivateEmailProvider(ctx, authz.GetInstance(ctx).InstanceID(), req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.DeactivateEmailProviderResponse{
+		Details: object.DomainToChangeDetailsPb(result),
+	}, nil
+}
+
+func (s *Server) RemoveEmailProvider(ctx context.Context, req *admin_pb.RemoveEmailProviderRequest) (*admin_pb.RemoveEmailProviderResponse, error) {
+	result, err := s.command.RemoveEmailProvider(ctx, authz.GetInstance(ctx).InstanceID(), req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.RemoveEmailProviderResponse{
+		Details: object.DomainToChangeDetailsPb(result),
+	}, nil
+}
+
+func (s *Server) TestEmailProvider(ctx context.Context, req *admin_pb.TestEmailProviderRequest) (*admin_pb.TestEmailProviderResponse, error) {
+	if err := notification.SendTestEmail(ctx, s.query, s.smtpEncryption, req.Id, req.ReceiverAddress); err != nil {
+		return nil, err
+	}
+	return &admin_pb.TestEmailProviderResponse{}, nil
+}
@@ -0,0 +1,85 @@
+package admin
+
+import (
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/notification/channels/httpapi"
+	"github.com/zitadel/zitadel/internal/query"
+	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
+	settings_pb "github.com/zitadel/zitadel/pkg/grpc/settings"
+)
+
+func listEmailProvidersToModel(req *admin_pb.ListEmailProvidersRequest) (*query.EmailProvidersSearchQueries, error) {
+	offset, limit, asc := object.ListQueryToModel(req.Query)
+	return &query.EmailProvidersSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset: offset,
+			Limit:  limit,
+			Asc:    asc,
+		},
+	}, nil
+}
+
+func EmailProvidersToPb(providers []*query.EmailProvider) []*settings_pb.EmailProvider {
+	p := make([]*settings_pb.EmailProvider, len(providers))
+	for i, provider := range providers {
+		p[i] = EmailProviderToPb(provider)
+	}
+	return p
+}
+
+func EmailProviderToPb(provider *query.EmailProvider) *settings_pb.EmailProvider {
+	pb := &settings_pb.EmailProvider{
+		Details:       object.ToViewDetailsPb(provider.Sequence, provider.CreationDate, provider.ChangeDate, provider.ResourceOwner),
+		Id:            provider.ID,
+		State:         emailProviderStateToPb(provider.State),
+		Description:   provider.Description,
+		Priority:      provider.Priority,
+		SenderAddress: provider.SenderAddress,
+		SenderName:    provider.SenderName,
+	}
+	if provider.HTTPConfig != nil {
+		pb.Config = &settings_pb.EmailProvider_Http{
+			Http: &settings_pb.HTTPEmailConfig{
+				Endpoint:     provider.HTTPConfig.Endpoint,
+				Method:       provider.HTTPConfig.Method,
+				BodyTemplate: provider.HTTPConfig.BodyTemplate,
+			},
+		}
+	}
+	return pb
+}
+
+func emailProviderStateToPb(state domain.EmailProviderState) settings_pb.EmailProviderState {
+	switch state {
+	case domain.EmailProviderStateActive:
+		return settings_pb.EmailProviderState_EMAIL_PROVIDER_ACTIVE
+	default:
+		return settings_pb.EmailProviderState_EMAIL_PROVIDER_INACTIVE
+	}
+}
+
+func AddEmailProviderHTTPToConfig(req *admin_pb.AddEmailProviderHTTPRequest) *httpapi.Config {
+	return &httpapi.Config{
+		Description:   req.Description,
+		Priority:      req.Priority,
+		SenderAddress: req.SenderAddress,
+		SenderName:    req.SenderName,
+		Endpoint:      req.Endpoint,
+		Method:        req.Method,
+		Headers:       req.Headers,
+		BodyTemplate:  req.BodyTemplate,
+	}
+}
+
+func UpdateEmailProviderHTTPToConfig(req *admin_pb.UpdateEmailProviderHTTPRequest) *httpapi.Config {
+	return &httpapi.Config{
+		Description:   req.Description,
+		Priority:      req.Priority,
+		SenderAddress: req.SenderAddress,
+		SenderName:    req.SenderName,
+		Endpoint:      req.Endpoint,
+		Method:        req.Method,
+		BodyTemplate:  req.BodyTemplate,
+	}
+}
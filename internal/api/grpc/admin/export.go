@@ -784,23 +784,29 @@ func (s *Server) getProjectsAndApps(ctx context.Context, org string) ([]*v1_pb.D
 				oidcApps = append(oidcApps, &v1_pb.DataOIDCApplication{
 					AppId: app.ID,
 					App: &management_pb.AddOIDCAppRequest{
-						ProjectId:                app.ProjectID,
-						Name:                     app.Name,
-						RedirectUris:             app.OIDCConfig.RedirectURIs,
-						ResponseTypes:            responseTypes,
-						GrantTypes:               grantTypes,
-						AppType:                  app_pb.OIDCAppType(app.OIDCConfig.AppType),
-						AuthMethodType:           app_pb.OIDCAuthMethodType(app.OIDCConfig.AuthMethodType),
-						PostLogoutRedirectUris:   app.OIDCConfig.PostLogoutRedirectURIs,
-						Version:                  app_pb.OIDCVersion(app.OIDCConfig.Version),
-						DevMode:                  app.OIDCConfig.IsDevMode,
-						AccessTokenType:          app_pb.OIDCTokenType(app.OIDCConfig.AccessTokenType),
-						AccessTokenRoleAssertion: app.OIDCConfig.AssertAccessTokenRole,
-						IdTokenRoleAssertion:     app.OIDCConfig.AssertIDTokenRole,
-						IdTokenUserinfoAssertion: app.OIDCConfig.AssertIDTokenUserinfo,
-						ClockSkew:                durationpb.New(app.OIDCConfig.ClockSkew),
-						AdditionalOrigins:        app.OIDCConfig.AdditionalOrigins,
-						SkipNativeAppSuccessPage: app.OIDCConfig.SkipNativeAppSuccessPage,
+						ProjectId:                  app.ProjectID,
+						Name:                       app.Name,
+						RedirectUris:               app.OIDCConfig.RedirectURIs,
+						ResponseTypes:              responseTypes,
+						GrantTypes:                 grantTypes,
+						AppType:                    app_pb.OIDCAppType(app.OIDCConfig.AppType),
+						AuthMethodType:             app_pb.OIDCAuthMethodType(app.OIDCConfig.AuthMethodType),
+						PostLogoutRedirectUris:     app.OIDCConfig.PostLogoutRedirectURIs,
+						Version:                    app_pb.OIDCVersion(app.OIDCConfig.Version),
+						DevMode:                    app.OIDCConfig.IsDevMode,
+						AccessTokenType:            app_pb.OIDCTokenType(app.OIDCConfig.AccessTokenType),
+						AccessTokenRoleAssertion:   app.OIDCConfig.AssertAccessTokenRole,
+						IdTokenRoleAssertion:       app.OIDCConfig.AssertIDTokenRole,
+						IdTokenUserinfoAssertion:   app.OIDCConfig.AssertIDTokenUserinfo,
+						ClockSkew:                  durationpb.New(app.OIDCConfig.ClockSkew),
+						AdditionalOrigins:          app.OIDCConfig.AdditionalOrigins,
+						SkipNativeAppSuccessPage:   app.OIDCConfig.SkipNativeAppSuccessPage,
+						RefreshTokenReuseDetection: app.OIDCConfig.RefreshTokenReuseDetection,
+						BackChannelLogoutUri:       app.OIDCConfig.BackChannelLogoutURI,
+						FrontChannelLogoutUri:      app.OIDCConfig.FrontChannelLogoutURI,
+						SenderConstrainedTokens:    app.OIDCConfig.SenderConstrainedTokens,
+						TlsClientAuthSubjectDn:     app.OIDCConfig.TLSClientAuthSubjectDN,
+						RequireConsent:             app.OIDCConfig.RequireConsent,
 					},
 				})
 			}
@@ -126,3 +126,23 @@ func (s *Server) SetSecurityPolicy(ctx context.Context, req *admin_pb.SetSecurit
 		Details: object.DomainToChangeDetailsPb(details),
 	}, nil
 }
+
+func (s *Server) GetClientRegistrationPolicy(ctx context.Context, req *admin_pb.GetClientRegistrationPolicyRequest) (*admin_pb.GetClientRegistrationPolicyResponse, error) {
+	policy, err := s.query.ClientRegistrationPolicy(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetClientRegistrationPolicyResponse{
+		Policy: ClientRegistrationPolicyToPb(policy),
+	}, nil
+}
+
+func (s *Server) SetClientRegistrationPolicy(ctx context.Context, req *admin_pb.SetClientRegistrationPolicyRequest) (*admin_pb.SetClientRegistrationPolicyResponse, error) {
+	details, err := s.command.SetClientRegistrationPolicy(ctx, SetClientRegistrationPolicyToDomain(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.SetClientRegistrationPolicyResponse{
+		Details: object.DomainToChangeDetailsPb(details),
+	}, nil
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/zitadel/zitadel/internal/api/grpc/object"
 	obj_grpc "github.com/zitadel/zitadel/internal/api/grpc/object"
+	project_grpc "github.com/zitadel/zitadel/internal/api/grpc/project"
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/errors"
@@ -123,6 +124,7 @@ func AddSMTPToConfig(req *admin_pb.AddSMTPConfigRequest) *smtp.Config {
 			User:     req.User,
 			Password: req.Password,
 		},
+		Priority: req.Priority,
 	}
 }
 
@@ -135,6 +137,7 @@ func UpdateSMTPToConfig(req *admin_pb.UpdateSMTPConfigRequest) *smtp.Config {
 			Host: req.Host,
 			User: req.User,
 		},
+		Priority: req.Priority,
 	}
 }
 
@@ -145,6 +148,7 @@ func SMTPConfigToPb(smtp *query.SMTPConfig) *settings_pb.SMTPConfig {
 		SenderName:    smtp.SenderName,
 		Host:          smtp.Host,
 		User:          smtp.User,
+		Priority:      smtp.Priority,
 		Details:       obj_grpc.ToViewDetailsPb(smtp.Sequence, smtp.CreationDate, smtp.ChangeDate, smtp.AggregateID),
 	}
 	return mapped
@@ -157,3 +161,23 @@ func SecurityPolicyToPb(policy *query.SecurityPolicy) *settings_pb.SecurityPolic
 		AllowedOrigins:        policy.AllowedOrigins,
 	}
 }
+
+func ClientRegistrationPolicyToPb(policy *query.ClientRegistrationPolicy) *settings_pb.ClientRegistrationPolicy {
+	return &settings_pb.ClientRegistrationPolicy{
+		Details:             obj_grpc.ToViewDetailsPb(policy.Sequence, policy.CreationDate, policy.ChangeDate, policy.AggregateID),
+		AllowedGrantTypes:   project_grpc.OIDCGrantTypesFromModel(policy.AllowedGrantTypes),
+		RedirectUriPatterns: policy.RedirectURIPatterns,
+	}
+}
+
+func SetClientRegistrationPolicyToDomain(req *admin_pb.SetClientRegistrationPolicyRequest) *domain.ClientRegistrationPolicy {
+	policy := &domain.ClientRegistrationPolicy{
+		RedirectURIPatterns: req.RedirectUriPatterns,
+	}
+	// an empty list does not restrict the grant types,
+	// so it must not be defaulted to authorization code like on the apps
+	if len(req.AllowedGrantTypes) > 0 {
+		policy.AllowedGrantTypes = project_grpc.OIDCGrantTypesToDomain(req.AllowedGrantTypes)
+	}
+	return policy
+}
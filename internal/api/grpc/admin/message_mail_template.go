@@ -0,0 +1,63 @@
+package admin
+
+import (
+	"context"
+
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	text_grpc "github.com/zitadel/zitadel/internal/api/grpc/text"
+	"github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/notification"
+	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
+)
+
+func (s *Server) GetDefaultMessageMailTemplate(ctx context.Context, req *admin_pb.GetDefaultMessageMailTemplateRequest) (*admin_pb.GetDefaultMessageMailTemplateResponse, error) {
+	template, err := s.query.DefaultMessageMailTemplate(ctx, text_grpc.MessageTypeToDomain(req.Type))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetDefaultMessageMailTemplateResponse{
+		Template: text_grpc.MessageMailTemplateToPb(template),
+	}, nil
+}
+
+func (s *Server) SetDefaultMessageMailTemplate(ctx context.Context, req *admin_pb.SetDefaultMessageMailTemplateRequest) (*admin_pb.SetDefaultMessageMailTemplateResponse, error) {
+	details, err := s.command.SetDefaultMessageMailTemplate(ctx, text_grpc.MessageMailTemplateToDomain(req.Type, req.Format, req.Source))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.SetDefaultMessageMailTemplateResponse{
+		Details: object.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) ResetDefaultMessageMailTemplate(ctx context.Context, req *admin_pb.ResetDefaultMessageMailTemplateRequest) (*admin_pb.ResetDefaultMessageMailTemplateResponse, error) {
+	details, err := s.command.RemoveDefaultMessageMailTemplate(ctx, text_grpc.MessageTypeToDomain(req.Type))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ResetDefaultMessageMailTemplateResponse{
+		Details: object.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) PreviewDefaultMessage(ctx context.Context, req *admin_pb.PreviewDefaultMessageRequest) (*admin_pb.PreviewDefaultMessageResponse, error) {
+	preview, err := notification.PreviewMessage(
+		ctx,
+		s.query,
+		http.BuildOrigin(authz.GetInstance(ctx).RequestedHost(), s.externalSecure),
+		s.assetsAPIDomain(ctx),
+		authz.GetInstance(ctx).InstanceID(),
+		text_grpc.MessageTypeToDomain(req.Type),
+		language.Make(req.Language),
+		text_grpc.PreviewMessageMailTemplateToDomain(req.Type, req.Format, req.Source),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.PreviewDefaultMessageResponse{
+		Preview: text_grpc.MessagePreviewToPb(preview),
+	}, nil
+}
@@ -0,0 +1,53 @@
+package admin
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	user_grpc "github.com/zitadel/zitadel/internal/api/grpc/user"
+	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
+)
+
+func (s *Server) ListNotificationMessages(ctx context.Context, req *admin_pb.ListNotificationMessagesRequest) (*admin_pb.ListNotificationMessagesResponse, error) {
+	queries, err := user_grpc.NotificationMessageSearchQueries(req.Query, req.State)
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.query.SearchNotificationMessages(ctx, queries)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ListNotificationMessagesResponse{
+		Details: object.ToListDetails(result.Count, result.Sequence, result.Timestamp),
+		Result:  user_grpc.NotificationMessagesToPb(result.Messages),
+	}, nil
+}
+
+func (s *Server) ResendNotificationMessage(ctx context.Context, req *admin_pb.ResendNotificationMessageRequest) (*admin_pb.ResendNotificationMessageResponse, error) {
+	// the message aggregate is owned by the organisation of the user
+	message, err := s.query.NotificationMessageByID(ctx, true, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	details, err := s.command.ResendNotificationMessage(ctx, "", message.ResourceOwner, message.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ResendNotificationMessageResponse{
+		Details: object.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) DiscardNotificationMessage(ctx context.Context, req *admin_pb.DiscardNotificationMessageRequest) (*admin_pb.DiscardNotificationMessageResponse, error) {
+	message, err := s.query.NotificationMessageByID(ctx, true, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	details, err := s.command.DiscardNotificationMessage(ctx, "", message.ResourceOwner, message.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.DiscardNotificationMessageResponse{
+		Details: object.DomainToChangeDetailsPb(details),
+	}, nil
+}
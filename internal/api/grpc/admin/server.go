@@ -11,9 +11,11 @@ import (
 	"github.com/zitadel/zitadel/internal/api/assets"
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/api/grpc/server"
+	auth_request_cache "github.com/zitadel/zitadel/internal/auth_request/repository/cache"
 	"github.com/zitadel/zitadel/internal/command"
 	"github.com/zitadel/zitadel/internal/config/systemdefaults"
 	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/idpsync"
 	"github.com/zitadel/zitadel/internal/query"
 	"github.com/zitadel/zitadel/pkg/grpc/admin"
 )
@@ -31,9 +33,13 @@ type Server struct {
 	query             *query.Queries
 	administrator     repository.AdministratorRepository
 	assetsAPIDomain   func(context.Context) string
+	externalSecure    bool
 	userCodeAlg       crypto.EncryptionAlgorithm
+	smtpEncryption    crypto.EncryptionAlgorithm
 	passwordHashAlg   crypto.HashAlgorithm
 	auditLogRetention time.Duration
+	ldapSyncer        *idpsync.Syncer
+	authRequests      *auth_request_cache.AuthRequestCache
 }
 
 type Config struct {
@@ -48,7 +54,10 @@ func CreateServer(
 	repo repository.Repository,
 	externalSecure bool,
 	userCodeAlg crypto.EncryptionAlgorithm,
+	smtpEncryption crypto.EncryptionAlgorithm,
 	auditLogRetention time.Duration,
+	ldapSyncer *idpsync.Syncer,
+	authRequests *auth_request_cache.AuthRequestCache,
 ) *Server {
 	return &Server{
 		database:          database,
@@ -56,9 +65,13 @@ func CreateServer(
 		query:             query,
 		administrator:     repo,
 		assetsAPIDomain:   assets.AssetAPI(externalSecure),
+		externalSecure:    externalSecure,
 		userCodeAlg:       userCodeAlg,
+		smtpEncryption:    smtpEncryption,
 		passwordHashAlg:   crypto.NewBCrypt(sd.SecretGenerators.PasswordSaltCost),
 		auditLogRetention: auditLogRetention,
+		ldapSyncer:        ldapSyncer,
+		authRequests:      authRequests,
 	}
 }
 
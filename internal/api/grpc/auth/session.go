@@ -0,0 +1,70 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	user_grpc "github.com/zitadel/zitadel/internal/api/grpc/user"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/pkg/grpc/auth"
+)
+
+func (s *Server) ListMyActiveSessions(ctx context.Context, _ *auth.ListMyActiveSessionsRequest) (*auth.ListMyActiveSessionsResponse, error) {
+	sessions, err := s.repo.GetMyActiveUserSessions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &auth.ListMyActiveSessionsResponse{
+		Result: user_grpc.ActiveSessionsToPb(sessions, authz.GetCtxData(ctx).AgentID),
+	}, nil
+}
+
+func (s *Server) TerminateMySession(ctx context.Context, req *auth.TerminateMySessionRequest) (*auth.TerminateMySessionResponse, error) {
+	sessions, err := s.repo.GetMyActiveUserSessions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	found := false
+	for _, session := range sessions {
+		if session.UserAgentID == req.AgentId {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, errors.ThrowNotFound(nil, "AUTH-Vn4sa", "Errors.UserSession.NotFound")
+	}
+	ctxData := authz.GetCtxData(ctx)
+	details, err := s.command.TerminateHumanSessions(ctx, ctxData.UserID, ctxData.ResourceOwner, []string{req.AgentId})
+	if err != nil {
+		return nil, err
+	}
+	return &auth.TerminateMySessionResponse{
+		Details: object.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) TerminateMyOtherSessions(ctx context.Context, _ *auth.TerminateMyOtherSessionsRequest) (*auth.TerminateMyOtherSessionsResponse, error) {
+	sessions, err := s.repo.GetMyActiveUserSessions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctxData := authz.GetCtxData(ctx)
+	agentIDs := make([]string, 0, len(sessions))
+	for _, session := range sessions {
+		if session.UserAgentID != ctxData.AgentID {
+			agentIDs = append(agentIDs, session.UserAgentID)
+		}
+	}
+	if len(agentIDs) == 0 {
+		return &auth.TerminateMyOtherSessionsResponse{}, nil
+	}
+	details, err := s.command.TerminateHumanSessions(ctx, ctxData.UserID, ctxData.ResourceOwner, agentIDs)
+	if err != nil {
+		return nil, err
+	}
+	return &auth.TerminateMyOtherSessionsResponse{
+		Details: object.DomainToChangeDetailsPb(details),
+	}, nil
+}
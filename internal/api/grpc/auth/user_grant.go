@@ -21,6 +21,7 @@ func ListMyUserGrantsRequestToQuery(ctx context.Context, req *auth_pb.ListMyUser
 			Offset: offset,
 			Limit:  limit,
 			Asc:    asc,
+			Cursor: req.GetQuery().GetCursor(),
 		},
 		Queries: []query.SearchQuery{
 			userGrantUserID,
@@ -0,0 +1,153 @@
+package idp
+
+import (
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	obj_grpc "github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+	idp_pb "github.com/zitadel/zitadel/pkg/grpc/idp"
+	object_pb "github.com/zitadel/zitadel/pkg/grpc/object"
+)
+
+func LDAPSyncToPb(sync *query.IDPLDAPSync) *idp_pb.LDAPSync {
+	pb := &idp_pb.LDAPSync{
+		Details: obj_grpc.ToViewDetailsPb(
+			sync.Sequence,
+			sync.ChangeDate,
+			sync.ChangeDate,
+			sync.ResourceOwner,
+		),
+		Enabled:            sync.Enabled,
+		Interval:           durationpb.New(sync.Interval),
+		OrgId:              sync.OrgID,
+		DeactivateMissing:  sync.DeactivateMissing,
+		DisabledFilter:     sync.DisabledFilter,
+		MetadataAttributes: sync.MetadataAttributes,
+		GroupsAttribute:    sync.GroupsAttribute,
+		GroupRules:         LDAPSyncGroupRulesToPb(sync.GroupRules),
+		NextRun:            timestamppb.New(sync.NextRun),
+	}
+	if !sync.LastRun.IsZero() {
+		pb.LastRun = timestamppb.New(sync.LastRun)
+	}
+	return pb
+}
+
+func LDAPSyncGroupRulesToPb(rules []*domain.LDAPSyncGroupRule) []*idp_pb.LDAPSyncGroupRule {
+	pb := make([]*idp_pb.LDAPSyncGroupRule, len(rules))
+	for i, rule := range rules {
+		pb[i] = &idp_pb.LDAPSyncGroupRule{
+			Group:     rule.Group,
+			ProjectId: rule.ProjectID,
+			RoleKeys:  rule.RoleKeys,
+		}
+	}
+	return pb
+}
+
+func LDAPSyncGroupRulesToDomain(rules []*idp_pb.LDAPSyncGroupRule) []*domain.LDAPSyncGroupRule {
+	if len(rules) == 0 {
+		return nil
+	}
+	domainRules := make([]*domain.LDAPSyncGroupRule, len(rules))
+	for i, rule := range rules {
+		domainRules[i] = &domain.LDAPSyncGroupRule{
+			Group:     rule.Group,
+			ProjectID: rule.ProjectId,
+			RoleKeys:  rule.RoleKeys,
+		}
+	}
+	return domainRules
+}
+
+func LDAPSyncRunsToPb(runs []*query.IDPLDAPSyncRun) []*idp_pb.LDAPSyncRun {
+	pb := make([]*idp_pb.LDAPSyncRun, len(runs))
+	for i, run := range runs {
+		pb[i] = &idp_pb.LDAPSyncRun{
+			Sequence:      run.Sequence,
+			CreationDate:  timestamppb.New(run.CreationDate),
+			StartedAt:     timestamppb.New(run.StartedAt),
+			State:         ldapSyncRunStateToPb(run.State),
+			DryRun:        run.DryRun,
+			Created:       run.Created,
+			Updated:       run.Updated,
+			Deactivated:   run.Deactivated,
+			GrantsChanged: run.GrantsChanged,
+			Failed:        run.Failed,
+			Error:         run.Error,
+		}
+	}
+	return pb
+}
+
+func ldapSyncRunStateToPb(state domain.LDAPSyncRunState) idp_pb.LDAPSyncRunState {
+	switch state {
+	case domain.LDAPSyncRunStateSucceeded:
+		return idp_pb.LDAPSyncRunState_LDAP_SYNC_RUN_STATE_SUCCEEDED
+	case domain.LDAPSyncRunStateFailed:
+		return idp_pb.LDAPSyncRunState_LDAP_SYNC_RUN_STATE_FAILED
+	default:
+		return idp_pb.LDAPSyncRunState_LDAP_SYNC_RUN_STATE_UNSPECIFIED
+	}
+}
+
+func LDAPSyncRunSearchQueries(listQuery *object_pb.ListQuery, onlyFailed bool) (*query.IDPLDAPSyncRunSearchQueries, error) {
+	offset, limit, asc := obj_grpc.ListQueryToModel(listQuery)
+	var queries []query.SearchQuery
+	if onlyFailed {
+		stateQuery, err := query.NewIDPLDAPSyncRunStateSearchQuery(domain.LDAPSyncRunStateFailed)
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, stateQuery)
+	}
+	return &query.IDPLDAPSyncRunSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset:        offset,
+			Limit:         limit,
+			Asc:           asc,
+			SortingColumn: query.IDPLDAPSyncRunSequenceCol,
+		},
+		Queries: queries,
+	}, nil
+}
+
+func LDAPSyncReportToPb(report *domain.LDAPSyncReport) *idp_pb.LDAPSyncReport {
+	changes := make([]*idp_pb.LDAPSyncChange, len(report.Changes))
+	for i, change := range report.Changes {
+		changes[i] = &idp_pb.LDAPSyncChange{
+			Action:         ldapSyncActionToPb(change.Action),
+			ExternalUserId: change.ExternalUserID,
+			UserId:         change.UserID,
+			Username:       change.Username,
+			Error:          change.Error,
+		}
+	}
+	return &idp_pb.LDAPSyncReport{
+		DryRun:        report.DryRun,
+		StartedAt:     timestamppb.New(report.StartedAt),
+		Created:       report.Created,
+		Updated:       report.Updated,
+		Deactivated:   report.Deactivated,
+		GrantsChanged: report.GrantsChanged,
+		Failed:        report.Failed,
+		Changes:       changes,
+	}
+}
+
+func ldapSyncActionToPb(action domain.LDAPSyncAction) idp_pb.LDAPSyncAction {
+	switch action {
+	case domain.LDAPSyncActionCreate:
+		return idp_pb.LDAPSyncAction_LDAP_SYNC_ACTION_CREATE
+	case domain.LDAPSyncActionUpdate:
+		return idp_pb.LDAPSyncAction_LDAP_SYNC_ACTION_UPDATE
+	case domain.LDAPSyncActionDeactivate:
+		return idp_pb.LDAPSyncAction_LDAP_SYNC_ACTION_DEACTIVATE
+	case domain.LDAPSyncActionChangeGrants:
+		return idp_pb.LDAPSyncAction_LDAP_SYNC_ACTION_CHANGE_GRANTS
+	default:
+		return idp_pb.LDAPSyncAction_LDAP_SYNC_ACTION_UNSPECIFIED
+	}
+}
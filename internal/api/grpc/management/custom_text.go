@@ -300,6 +300,54 @@ func (s *Server) ResetCustomPasswordChangeMessageTextToDefault(ctx context.Conte
 	}, nil
 }
 
+func (s *Server) GetCustomSecurityNotificationMessageText(ctx context.Context, req *mgmt_pb.GetCustomSecurityNotificationMessageTextRequest) (*mgmt_pb.GetCustomSecurityNotificationMessageTextResponse, error) {
+	msg, err := s.query.CustomMessageTextByTypeAndLanguage(ctx, authz.GetCtxData(ctx).OrgID, text_grpc.SecurityNotificationTypeToDomain(req.Type), req.Language, false)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetCustomSecurityNotificationMessageTextResponse{
+		CustomText: text_grpc.ModelCustomMessageTextToPb(msg),
+	}, nil
+}
+
+func (s *Server) GetDefaultSecurityNotificationMessageText(ctx context.Context, req *mgmt_pb.GetDefaultSecurityNotificationMessageTextRequest) (*mgmt_pb.GetDefaultSecurityNotificationMessageTextResponse, error) {
+	msg, err := s.query.IAMMessageTextByTypeAndLanguage(ctx, text_grpc.SecurityNotificationTypeToDomain(req.Type), req.Language)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetDefaultSecurityNotificationMessageTextResponse{
+		CustomText: text_grpc.ModelCustomMessageTextToPb(msg),
+	}, nil
+}
+
+func (s *Server) SetCustomSecurityNotificationMessageCustomText(ctx context.Context, req *mgmt_pb.SetCustomSecurityNotificationMessageTextRequest) (*mgmt_pb.SetCustomSecurityNotificationMessageTextResponse, error) {
+	result, err := s.command.SetOrgMessageText(ctx, authz.GetCtxData(ctx).OrgID, SetSecurityNotificationCustomTextToDomain(req))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.SetCustomSecurityNotificationMessageTextResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) ResetCustomSecurityNotificationMessageTextToDefault(ctx context.Context, req *mgmt_pb.ResetCustomSecurityNotificationMessageTextToDefaultRequest) (*mgmt_pb.ResetCustomSecurityNotificationMessageTextToDefaultResponse, error) {
+	result, err := s.command.RemoveOrgMessageTexts(ctx, authz.GetCtxData(ctx).OrgID, text_grpc.SecurityNotificationTypeToDomain(req.Type), language.Make(req.Language))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ResetCustomSecurityNotificationMessageTextToDefaultResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
 func (s *Server) GetCustomPasswordlessRegistrationMessageText(ctx context.Context, req *mgmt_pb.GetCustomPasswordlessRegistrationMessageTextRequest) (*mgmt_pb.GetCustomPasswordlessRegistrationMessageTextResponse, error) {
 	msg, err := s.query.CustomMessageTextByTypeAndLanguage(ctx, authz.GetCtxData(ctx).OrgID, domain.PasswordlessRegistrationMessageType, req.Language, false)
 	if err != nil {
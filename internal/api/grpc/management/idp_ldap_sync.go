@@ -0,0 +1,90 @@
+package management
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	idp_grpc "github.com/zitadel/zitadel/internal/api/grpc/idp"
+	object_pb "github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/query"
+	mgmt_pb "github.com/zitadel/zitadel/pkg/grpc/management"
+)
+
+func (s *Server) GetLDAPProviderSync(ctx context.Context, req *mgmt_pb.GetLDAPProviderSyncRequest) (*mgmt_pb.GetLDAPProviderSyncResponse, error) {
+	ownerQuery, err := query.NewIDPLDAPSyncResourceOwnerSearchQuery(authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	sync, err := s.query.IDPLDAPSyncByID(ctx, true, req.Id, ownerQuery)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetLDAPProviderSyncResponse{
+		Sync: idp_grpc.LDAPSyncToPb(sync),
+	}, nil
+}
+
+func (s *Server) SetLDAPProviderSync(ctx context.Context, req *mgmt_pb.SetLDAPProviderSyncRequest) (*mgmt_pb.SetLDAPProviderSyncResponse, error) {
+	details, err := s.command.SetOrgLDAPSync(ctx, authz.GetCtxData(ctx).OrgID, req.Id, setLDAPProviderSyncToCommand(req))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.SetLDAPProviderSyncResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) RemoveLDAPProviderSync(ctx context.Context, req *mgmt_pb.RemoveLDAPProviderSyncRequest) (*mgmt_pb.RemoveLDAPProviderSyncResponse, error) {
+	details, err := s.command.RemoveOrgLDAPSync(ctx, authz.GetCtxData(ctx).OrgID, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.RemoveLDAPProviderSyncResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) RunLDAPProviderSync(ctx context.Context, req *mgmt_pb.RunLDAPProviderSyncRequest) (*mgmt_pb.RunLDAPProviderSyncResponse, error) {
+	report, err := s.ldapSyncer.Run(ctx, authz.GetCtxData(ctx).OrgID, req.Id, req.DryRun)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.RunLDAPProviderSyncResponse{
+		Report: idp_grpc.LDAPSyncReportToPb(report),
+	}, nil
+}
+
+func (s *Server) ListLDAPProviderSyncRuns(ctx context.Context, req *mgmt_pb.ListLDAPProviderSyncRunsRequest) (*mgmt_pb.ListLDAPProviderSyncRunsResponse, error) {
+	ownerQuery, err := query.NewIDPLDAPSyncResourceOwnerSearchQuery(authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = s.query.IDPLDAPSyncByID(ctx, false, req.Id, ownerQuery); err != nil {
+		return nil, err
+	}
+	queries, err := idp_grpc.LDAPSyncRunSearchQueries(req.Query, req.OnlyFailed)
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.query.SearchIDPLDAPSyncRuns(ctx, req.Id, queries)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ListLDAPProviderSyncRunsResponse{
+		Details: object_pb.ToListDetails(result.Count, result.Sequence, result.Timestamp),
+		Result:  idp_grpc.LDAPSyncRunsToPb(result.Runs),
+	}, nil
+}
+
+func setLDAPProviderSyncToCommand(req *mgmt_pb.SetLDAPProviderSyncRequest) *command.LDAPSync {
+	return &command.LDAPSync{
+		Enabled:            req.Enabled,
+		Interval:           req.Interval.AsDuration(),
+		DeactivateMissing:  req.DeactivateMissing,
+		DisabledFilter:     req.DisabledFilter,
+		MetadataAttributes: req.MetadataAttributes,
+		GroupsAttribute:    req.GroupsAttribute,
+		GroupRules:         idp_grpc.LDAPSyncGroupRulesToDomain(req.GroupRules),
+	}
+}
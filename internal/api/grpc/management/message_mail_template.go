@@ -0,0 +1,63 @@
+package management
+
+import (
+	"context"
+
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	text_grpc "github.com/zitadel/zitadel/internal/api/grpc/text"
+	"github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/notification"
+	mgmt_pb "github.com/zitadel/zitadel/pkg/grpc/management"
+)
+
+func (s *Server) GetMessageMailTemplate(ctx context.Context, req *mgmt_pb.GetMessageMailTemplateRequest) (*mgmt_pb.GetMessageMailTemplateResponse, error) {
+	template, err := s.query.MessageMailTemplateByOrg(ctx, authz.GetCtxData(ctx).OrgID, text_grpc.MessageTypeToDomain(req.Type), false)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetMessageMailTemplateResponse{
+		Template: text_grpc.MessageMailTemplateToPb(template),
+	}, nil
+}
+
+func (s *Server) SetCustomMessageMailTemplate(ctx context.Context, req *mgmt_pb.SetCustomMessageMailTemplateRequest) (*mgmt_pb.SetCustomMessageMailTemplateResponse, error) {
+	details, err := s.command.SetOrgMessageMailTemplate(ctx, authz.GetCtxData(ctx).OrgID, text_grpc.MessageMailTemplateToDomain(req.Type, req.Format, req.Source))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.SetCustomMessageMailTemplateResponse{
+		Details: object.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) ResetCustomMessageMailTemplateToDefault(ctx context.Context, req *mgmt_pb.ResetCustomMessageMailTemplateToDefaultRequest) (*mgmt_pb.ResetCustomMessageMailTemplateToDefaultResponse, error) {
+	details, err := s.command.RemoveOrgMessageMailTemplate(ctx, authz.GetCtxData(ctx).OrgID, text_grpc.MessageTypeToDomain(req.Type))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ResetCustomMessageMailTemplateToDefaultResponse{
+		Details: object.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) PreviewMessage(ctx context.Context, req *mgmt_pb.PreviewMessageRequest) (*mgmt_pb.PreviewMessageResponse, error) {
+	preview, err := notification.PreviewMessage(
+		ctx,
+		s.query,
+		http.BuildOrigin(authz.GetInstance(ctx).RequestedHost(), s.externalSecure),
+		s.assetAPIPrefix(ctx),
+		authz.GetCtxData(ctx).OrgID,
+		text_grpc.MessageTypeToDomain(req.Type),
+		language.Make(req.Language),
+		text_grpc.PreviewMessageMailTemplateToDomain(req.Type, req.Format, req.Source),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.PreviewMessageResponse{
+		Preview: text_grpc.MessagePreviewToPb(preview),
+	}, nil
+}
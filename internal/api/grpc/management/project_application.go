@@ -307,3 +307,26 @@ func (s *Server) RemoveAppKey(ctx context.Context, req *mgmt_pb.RemoveAppKeyRequ
 		Details: object_grpc.DomainToChangeDetailsPb(details),
 	}, nil
 }
+
+func (s *Server) AddProjectInitialAccessToken(ctx context.Context, req *mgmt_pb.AddProjectInitialAccessTokenRequest) (*mgmt_pb.AddProjectInitialAccessTokenResponse, error) {
+	token := AddProjectInitialAccessTokenRequestToCommand(req, authz.GetCtxData(ctx).OrgID)
+	details, err := s.command.AddProjectInitialAccessToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.AddProjectInitialAccessTokenResponse{
+		TokenId: token.TokenID,
+		Token:   token.Token,
+		Details: object_grpc.DomainToAddDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) RemoveProjectInitialAccessToken(ctx context.Context, req *mgmt_pb.RemoveProjectInitialAccessTokenRequest) (*mgmt_pb.RemoveProjectInitialAccessTokenResponse, error) {
+	details, err := s.command.RemoveProjectInitialAccessToken(ctx, RemoveProjectInitialAccessTokenRequestToCommand(req, authz.GetCtxData(ctx).OrgID))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.RemoveProjectInitialAccessTokenResponse{
+		Details: object_grpc.DomainToChangeDetailsPb(details),
+	}, nil
+}
@@ -34,6 +34,7 @@ func ListUsersRequestToModel(req *mgmt_pb.ListUsersRequest) (*query.UserSearchQu
 			Limit:         limit,
 			Asc:           asc,
 			SortingColumn: UserFieldNameToSortingColumn(req.SortingColumn),
+			Cursor:        req.GetQuery().GetCursor(),
 		},
 		Queries: queries,
 	}, nil
@@ -305,6 +306,29 @@ func ListPersonalAccessTokensRequestToQuery(ctx context.Context, req *mgmt_pb.Li
 
 }
 
+func ListUserConsentsRequestToQuery(ctx context.Context, req *mgmt_pb.ListUserConsentsRequest) (*query.UserConsentSearchQueries, error) {
+	resourceOwner, err := query.NewUserConsentResourceOwnerSearchQuery(authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := query.NewUserConsentUserIDSearchQuery(req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	offset, limit, asc := object.ListQueryToModel(req.Query)
+	return &query.UserConsentSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset: offset,
+			Limit:  limit,
+			Asc:    asc,
+		},
+		Queries: []query.SearchQuery{
+			resourceOwner,
+			userID,
+		},
+	}, nil
+}
+
 func RemoveHumanLinkedIDPRequestToDomain(ctx context.Context, req *mgmt_pb.RemoveHumanLinkedIDPRequest) *domain.UserIDPLink {
 	return &domain.UserIDPLink{
 		ObjectRoot: models.ObjectRoot{
@@ -33,6 +33,7 @@ func ListUserGrantsRequestToQuery(ctx context.Context, req *mgmt_pb.ListUserGran
 			Offset: offset,
 			Limit:  limit,
 			Asc:    asc,
+			Cursor: req.GetQuery().GetCursor(),
 		},
 		Queries: queries,
 	}
@@ -0,0 +1,54 @@
+package management
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	obj_grpc "github.com/zitadel/zitadel/internal/api/grpc/object"
+	user_grpc "github.com/zitadel/zitadel/internal/api/grpc/user"
+	"github.com/zitadel/zitadel/internal/query"
+	mgmt_pb "github.com/zitadel/zitadel/pkg/grpc/management"
+)
+
+func (s *Server) ListUserNotificationMessages(ctx context.Context, req *mgmt_pb.ListUserNotificationMessagesRequest) (*mgmt_pb.ListUserNotificationMessagesResponse, error) {
+	userIDQuery, err := query.NewNotificationMessageUserIDSearchQuery(req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	ownerQuery, err := query.NewNotificationMessageResourceOwnerSearchQuery(authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	queries, err := user_grpc.NotificationMessageSearchQueries(req.Query, req.State, userIDQuery, ownerQuery)
+	if err != nil {
+		return nil, err
+	}
+	res, err := s.query.SearchNotificationMessages(ctx, queries)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ListUserNotificationMessagesResponse{
+		Result:  user_grpc.NotificationMessagesToPb(res.Messages),
+		Details: obj_grpc.ToListDetails(res.Count, res.Sequence, res.Timestamp),
+	}, nil
+}
+
+func (s *Server) ResendUserNotificationMessage(ctx context.Context, req *mgmt_pb.ResendUserNotificationMessageRequest) (*mgmt_pb.ResendUserNotificationMessageResponse, error) {
+	details, err := s.command.ResendNotificationMessage(ctx, req.UserId, authz.GetCtxData(ctx).OrgID, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ResendUserNotificationMessageResponse{
+		Details: obj_grpc.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) DiscardUserNotificationMessage(ctx context.Context, req *mgmt_pb.DiscardUserNotificationMessageRequest) (*mgmt_pb.DiscardUserNotificationMessageResponse, error) {
+	details, err := s.command.DiscardNotificationMessage(ctx, req.UserId, authz.GetCtxData(ctx).OrgID, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.DiscardUserNotificationMessageResponse{
+		Details: obj_grpc.DomainToChangeDetailsPb(details),
+	}, nil
+}
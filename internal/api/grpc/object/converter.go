@@ -97,6 +97,13 @@ func ToListDetails(
 	return details
 }
 
+// ToCursorListDetails returns the list details of a search supporting cursors
+func ToCursorListDetails(response query.SearchResponse) *object_pb.ListDetails {
+	details := ToListDetails(response.Count, response.Sequence, response.Timestamp)
+	details.NextCursor = response.NextCursor
+	return details
+}
+
 func TextMethodToModel(method object_pb.TextQueryMethod) domain.SearchMethod {
 	switch method {
 	case object_pb.TextQueryMethod_TEXT_QUERY_METHOD_EQUALS:
@@ -26,6 +26,7 @@ func ToListDetails(response query.SearchResponse) *object.ListDetails {
 	details := &object.ListDetails{
 		TotalResult:       response.Count,
 		ProcessedSequence: response.Sequence,
+		NextCursor:        response.NextCursor,
 	}
 	if !response.Timestamp.IsZero() {
 		details.Timestamp = timestamppb.New(response.Timestamp)
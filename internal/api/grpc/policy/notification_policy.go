@@ -8,8 +8,9 @@ import (
 
 func ModelNotificationPolicyToPb(policy *query.NotificationPolicy) *policy_pb.NotificationPolicy {
 	return &policy_pb.NotificationPolicy{
-		IsDefault:      policy.IsDefault,
-		PasswordChange: policy.PasswordChange,
+		IsDefault:             policy.IsDefault,
+		PasswordChange:        policy.PasswordChange,
+		SecurityNotifications: policy.SecurityNotifications,
 		Details: object.ToViewDetailsPb(
 			policy.Sequence,
 			policy.CreationDate,
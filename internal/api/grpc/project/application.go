@@ -42,25 +42,31 @@ func AppConfigToPb(app *query.App) app_pb.AppConfig {
 func AppOIDCConfigToPb(app *query.OIDCApp) *app_pb.App_OidcConfig {
 	return &app_pb.App_OidcConfig{
 		OidcConfig: &app_pb.OIDCConfig{
-			RedirectUris:             app.RedirectURIs,
-			ResponseTypes:            OIDCResponseTypesFromModel(app.ResponseTypes),
-			GrantTypes:               OIDCGrantTypesFromModel(app.GrantTypes),
-			AppType:                  OIDCApplicationTypeToPb(app.AppType),
-			ClientId:                 app.ClientID,
-			AuthMethodType:           OIDCAuthMethodTypeToPb(app.AuthMethodType),
-			PostLogoutRedirectUris:   app.PostLogoutRedirectURIs,
-			Version:                  OIDCVersionToPb(domain.OIDCVersion(app.Version)),
-			NoneCompliant:            len(app.ComplianceProblems) != 0,
-			ComplianceProblems:       ComplianceProblemsToLocalizedMessages(app.ComplianceProblems),
-			DevMode:                  app.IsDevMode,
-			AccessTokenType:          oidcTokenTypeToPb(app.AccessTokenType),
-			AccessTokenRoleAssertion: app.AssertAccessTokenRole,
-			IdTokenRoleAssertion:     app.AssertIDTokenRole,
-			IdTokenUserinfoAssertion: app.AssertIDTokenUserinfo,
-			ClockSkew:                durationpb.New(app.ClockSkew),
-			AdditionalOrigins:        app.AdditionalOrigins,
-			AllowedOrigins:           app.AllowedOrigins,
-			SkipNativeAppSuccessPage: app.SkipNativeAppSuccessPage,
+			RedirectUris:               app.RedirectURIs,
+			ResponseTypes:              OIDCResponseTypesFromModel(app.ResponseTypes),
+			GrantTypes:                 OIDCGrantTypesFromModel(app.GrantTypes),
+			AppType:                    OIDCApplicationTypeToPb(app.AppType),
+			ClientId:                   app.ClientID,
+			AuthMethodType:             OIDCAuthMethodTypeToPb(app.AuthMethodType),
+			PostLogoutRedirectUris:     app.PostLogoutRedirectURIs,
+			Version:                    OIDCVersionToPb(domain.OIDCVersion(app.Version)),
+			NoneCompliant:              len(app.ComplianceProblems) != 0,
+			ComplianceProblems:         ComplianceProblemsToLocalizedMessages(app.ComplianceProblems),
+			DevMode:                    app.IsDevMode,
+			AccessTokenType:            oidcTokenTypeToPb(app.AccessTokenType),
+			AccessTokenRoleAssertion:   app.AssertAccessTokenRole,
+			IdTokenRoleAssertion:       app.AssertIDTokenRole,
+			IdTokenUserinfoAssertion:   app.AssertIDTokenUserinfo,
+			ClockSkew:                  durationpb.New(app.ClockSkew),
+			AdditionalOrigins:          app.AdditionalOrigins,
+			AllowedOrigins:             app.AllowedOrigins,
+			SkipNativeAppSuccessPage:   app.SkipNativeAppSuccessPage,
+			RefreshTokenReuseDetection: app.RefreshTokenReuseDetection,
+			BackChannelLogoutUri:       app.BackChannelLogoutURI,
+			FrontChannelLogoutUri:      app.FrontChannelLogoutURI,
+			SenderConstrainedTokens:    app.SenderConstrainedTokens,
+			TlsClientAuthSubjectDn:     app.TLSClientAuthSubjectDN,
+			RequireConsent:             app.RequireConsent,
 		},
 	}
 }
@@ -68,7 +74,8 @@ func AppOIDCConfigToPb(app *query.OIDCApp) *app_pb.App_OidcConfig {
 func AppSAMLConfigToPb(app *query.SAMLApp) app_pb.AppConfig {
 	return &app_pb.App_SamlConfig{
 		SamlConfig: &app_pb.SAMLConfig{
-			Metadata: &app_pb.SAMLConfig_MetadataXml{MetadataXml: app.Metadata},
+			Metadata:          &app_pb.SAMLConfig_MetadataXml{MetadataXml: app.Metadata},
+			EncryptAssertions: app.EncryptAssertions,
 		},
 	}
 }
@@ -198,6 +205,10 @@ func OIDCAuthMethodTypeToPb(authType domain.OIDCAuthMethodType) app_pb.OIDCAuthM
 		return app_pb.OIDCAuthMethodType_OIDC_AUTH_METHOD_TYPE_NONE
 	case domain.OIDCAuthMethodTypePrivateKeyJWT:
 		return app_pb.OIDCAuthMethodType_OIDC_AUTH_METHOD_TYPE_PRIVATE_KEY_JWT
+	case domain.OIDCAuthMethodTypeTLSClientAuth:
+		return app_pb.OIDCAuthMethodType_OIDC_AUTH_METHOD_TYPE_TLS_CLIENT_AUTH
+	case domain.OIDCAuthMethodTypeSelfSignedTLSClientAuth:
+		return app_pb.OIDCAuthMethodType_OIDC_AUTH_METHOD_TYPE_SELF_SIGNED_TLS_CLIENT_AUTH
 	default:
 		return app_pb.OIDCAuthMethodType_OIDC_AUTH_METHOD_TYPE_BASIC
 	}
@@ -213,6 +224,10 @@ func OIDCAuthMethodTypeToDomain(authType app_pb.OIDCAuthMethodType) domain.OIDCA
 		return domain.OIDCAuthMethodTypeNone
 	case app_pb.OIDCAuthMethodType_OIDC_AUTH_METHOD_TYPE_PRIVATE_KEY_JWT:
 		return domain.OIDCAuthMethodTypePrivateKeyJWT
+	case app_pb.OIDCAuthMethodType_OIDC_AUTH_METHOD_TYPE_TLS_CLIENT_AUTH:
+		return domain.OIDCAuthMethodTypeTLSClientAuth
+	case app_pb.OIDCAuthMethodType_OIDC_AUTH_METHOD_TYPE_SELF_SIGNED_TLS_CLIENT_AUTH:
+		return domain.OIDCAuthMethodTypeSelfSignedTLSClientAuth
 	default:
 		return domain.OIDCAuthMethodTypeBasic
 	}
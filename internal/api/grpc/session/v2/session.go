@@ -146,6 +146,7 @@ func listSessionsRequestToQuery(ctx context.Context, req *session.ListSessionsRe
 			Offset: offset,
 			Limit:  limit,
 			Asc:    asc,
+			Cursor: req.GetQuery().GetCursor(),
 		},
 		Queries: queries,
 	}, nil
@@ -93,6 +93,57 @@ func (s *Server) RemoveInstance(ctx context.Context, req *system_pb.RemoveInstan
 	}, nil
 }
 
+func (s *Server) ExportInstance(ctx context.Context, req *system_pb.ExportInstanceRequest) (*system_pb.ExportInstanceResponse, error) {
+	archive, err := s.command.StoreInstanceExport(ctx, req.InstanceId, req.SecretsPassphrase)
+	if err != nil {
+		return nil, err
+	}
+	return &system_pb.ExportInstanceResponse{
+		ArchiveId: archive.ID,
+		Version:   uint32(archive.Version),
+		Chunks:    uint32(archive.Chunks),
+		Size:      uint64(archive.Size),
+	}, nil
+}
+
+func (s *Server) GetInstanceArchiveChunk(ctx context.Context, req *system_pb.GetInstanceArchiveChunkRequest) (*system_pb.GetInstanceArchiveChunkResponse, error) {
+	data, err := s.command.InstanceArchiveChunk(ctx, req.ArchiveId, int(req.Index))
+	if err != nil {
+		return nil, err
+	}
+	return &system_pb.GetInstanceArchiveChunkResponse{
+		Data: data,
+	}, nil
+}
+
+func (s *Server) AddInstanceArchiveChunk(ctx context.Context, req *system_pb.AddInstanceArchiveChunkRequest) (*system_pb.AddInstanceArchiveChunkResponse, error) {
+	archiveID, err := s.command.AddInstanceArchiveChunk(ctx, req.ArchiveId, int(req.Index), req.Data)
+	if err != nil {
+		return nil, err
+	}
+	return &system_pb.AddInstanceArchiveChunkResponse{
+		ArchiveId: archiveID,
+	}, nil
+}
+
+func (s *Server) RemoveInstanceArchive(ctx context.Context, req *system_pb.RemoveInstanceArchiveRequest) (*system_pb.RemoveInstanceArchiveResponse, error) {
+	if err := s.command.RemoveInstanceArchive(ctx, req.ArchiveId, int(req.Chunks)); err != nil {
+		return nil, err
+	}
+	return &system_pb.RemoveInstanceArchiveResponse{}, nil
+}
+
+func (s *Server) ImportInstance(ctx context.Context, req *system_pb.ImportInstanceRequest) (*system_pb.ImportInstanceResponse, error) {
+	id, details, err := s.command.ImportStoredInstance(ctx, req.ArchiveId, int(req.Chunks), ImportInstancePbToInstanceImport(req))
+	if err != nil {
+		return nil, err
+	}
+	return &system_pb.ImportInstanceResponse{
+		InstanceId: id,
+		Details:    object.AddToDetailsPb(details.Sequence, details.EventDate, details.ResourceOwner),
+	}, nil
+}
+
 func (s *Server) ListIAMMembers(ctx context.Context, req *system_pb.ListIAMMembersRequest) (*system_pb.ListIAMMembersResponse, error) {
 	queries, err := ListIAMMembersRequestToQuery(req)
 	if err != nil {
@@ -280,3 +280,18 @@ func ListIAMMembersRequestToQuery(req *system_pb.ListIAMMembersRequest) (*query.
 		},
 	}, nil
 }
+
+func ImportInstancePbToInstanceImport(req *system_pb.ImportInstanceRequest) *command.InstanceImport {
+	mappings := make([]*command.InstanceDomainMapping, len(req.DomainMappings))
+	for i, mapping := range req.DomainMappings {
+		mappings[i] = &command.InstanceDomainMapping{
+			From: mapping.From,
+			To:   mapping.To,
+		}
+	}
+	return &command.InstanceImport{
+		InstanceName:      req.InstanceName,
+		DomainMappings:    mappings,
+		SecretsPassphrase: req.SecretsPassphrase,
+	}
+}
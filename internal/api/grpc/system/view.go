@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/query/projection"
 	system_pb "github.com/zitadel/zitadel/pkg/grpc/system"
 )
 
@@ -35,3 +36,20 @@ func (s *Server) ClearView(ctx context.Context, req *system_pb.ClearViewRequest)
 	}
 	return &system_pb.ClearViewResponse{}, nil
 }
+
+func (s *Server) RebuildProjection(ctx context.Context, req *system_pb.RebuildProjectionRequest) (*system_pb.RebuildProjectionResponse, error) {
+	if err := projection.StartRebuild(req.ProjectionName); err != nil {
+		return nil, err
+	}
+	return &system_pb.RebuildProjectionResponse{}, nil
+}
+
+func (s *Server) GetProjectionRebuild(ctx context.Context, req *system_pb.GetProjectionRebuildRequest) (*system_pb.GetProjectionRebuildResponse, error) {
+	state, err := projection.GetRebuildState(req.ProjectionName)
+	if err != nil {
+		return nil, err
+	}
+	return &system_pb.GetProjectionRebuildResponse{
+		Rebuild: ProjectionRebuildToPb(state),
+	}, nil
+}
@@ -3,7 +3,9 @@ package system
 import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
 	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/query/projection"
 	"github.com/zitadel/zitadel/internal/view/model"
 	system_pb "github.com/zitadel/zitadel/pkg/grpc/system"
 )
@@ -42,3 +44,33 @@ func CurrentSequenceToPb(database string, currentSequence *query.CurrentSequence
 		LastSuccessfulSpoolerRun: timestamppb.New(currentSequence.Timestamp),
 	}
 }
+
+func ProjectionRebuildToPb(state *projection.RebuildState) *system_pb.ProjectionRebuild {
+	rebuild := &system_pb.ProjectionRebuild{
+		ProjectionName:  state.Projection,
+		Phase:           ProjectionRebuildPhaseToPb(state.Phase),
+		Running:         state.Running,
+		Instances:       uint32(state.Instances),
+		InstancesDone:   uint32(state.InstancesDone),
+		ProcessedEvents: state.Events,
+	}
+	if state.Err != nil {
+		rebuild.Error = state.Err.Error()
+	}
+	return rebuild
+}
+
+func ProjectionRebuildPhaseToPb(phase crdb.RebuildPhase) system_pb.ProjectionRebuildPhase {
+	switch phase {
+	case crdb.RebuildPhaseCatchingUp:
+		return system_pb.ProjectionRebuildPhase_PROJECTION_REBUILD_PHASE_CATCHING_UP
+	case crdb.RebuildPhaseSwapping:
+		return system_pb.ProjectionRebuildPhase_PROJECTION_REBUILD_PHASE_SWAPPING
+	case crdb.RebuildPhaseVerifying:
+		return system_pb.ProjectionRebuildPhase_PROJECTION_REBUILD_PHASE_VERIFYING
+	case crdb.RebuildPhaseDone:
+		return system_pb.ProjectionRebuildPhase_PROJECTION_REBUILD_PHASE_DONE
+	default:
+		return system_pb.ProjectionRebuildPhase_PROJECTION_REBUILD_PHASE_BUILDING
+	}
+}
@@ -953,3 +953,22 @@ func FooterTextPbToDomain(text *text_pb.FooterText) domain.FooterText {
 		SupportEmail:  text.SupportEmail,
 	}
 }
+
+func SecurityNotificationTypeToDomain(notificationType text_pb.SecurityNotificationType) string {
+	switch notificationType {
+	case text_pb.SecurityNotificationType_SECURITY_NOTIFICATION_TYPE_NEW_DEVICE_LOGIN:
+		return domain.NewDeviceLoginMessageType
+	case text_pb.SecurityNotificationType_SECURITY_NOTIFICATION_TYPE_MFA_ADDED:
+		return domain.MFAAddedMessageType
+	case text_pb.SecurityNotificationType_SECURITY_NOTIFICATION_TYPE_MFA_REMOVED:
+		return domain.MFARemovedMessageType
+	case text_pb.SecurityNotificationType_SECURITY_NOTIFICATION_TYPE_EMAIL_CHANGED:
+		return domain.EmailChangedMessageType
+	case text_pb.SecurityNotificationType_SECURITY_NOTIFICATION_TYPE_MACHINE_CREDENTIAL_ADDED:
+		return domain.MachineCredentialAddedMessageType
+	case text_pb.SecurityNotificationType_SECURITY_NOTIFICATION_TYPE_USER_LOCKED:
+		return domain.UserLockedMessageType
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,141 @@
+package text
+
+import (
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/notification/types"
+	"github.com/zitadel/zitadel/internal/query"
+	text_pb "github.com/zitadel/zitadel/pkg/grpc/text"
+)
+
+func MessageMailTemplateToPb(template *query.MessageMailTemplate) *text_pb.MessageMailTemplate {
+	return &text_pb.MessageMailTemplate{
+		Details: object.ToViewDetailsPb(
+			template.Sequence,
+			template.CreationDate,
+			template.ChangeDate,
+			template.AggregateID,
+		),
+		Type:      MessageTypeToPb(template.MessageType),
+		Format:    MessageTemplateFormatToPb(template.Format),
+		Source:    template.Source,
+		Template:  template.Template,
+		IsDefault: template.IsDefault,
+	}
+}
+
+func MessageMailTemplateToDomain(messageType text_pb.MessageType, format text_pb.MessageTemplateFormat, source []byte) *domain.MessageMailTemplate {
+	return &domain.MessageMailTemplate{
+		MessageType: MessageTypeToDomain(messageType),
+		Format:      MessageTemplateFormatToDomain(format),
+		Source:      source,
+	}
+}
+
+// PreviewMessageMailTemplateToDomain returns the template to preview,
+// nil is returned if no source is provided and therefore the configured template is rendered
+func PreviewMessageMailTemplateToDomain(messageType text_pb.MessageType, format text_pb.MessageTemplateFormat, source []byte) *domain.MessageMailTemplate {
+	if len(source) == 0 {
+		return nil
+	}
+	return MessageMailTemplateToDomain(messageType, format, source)
+}
+
+func MessagePreviewToPb(preview *types.EmailPreview) *text_pb.MessagePreview {
+	return &text_pb.MessagePreview{
+		Subject: preview.Subject,
+		Html:    preview.Content,
+	}
+}
+
+func MessageTypeToDomain(messageType text_pb.MessageType) string {
+	switch messageType {
+	case text_pb.MessageType_MESSAGE_TYPE_INIT:
+		return domain.InitCodeMessageType
+	case text_pb.MessageType_MESSAGE_TYPE_VERIFY_EMAIL:
+		return domain.VerifyEmailMessageType
+	case text_pb.MessageType_MESSAGE_TYPE_PASSWORD_RESET:
+		return domain.PasswordResetMessageType
+	case text_pb.MessageType_MESSAGE_TYPE_DOMAIN_CLAIMED:
+		return domain.DomainClaimedMessageType
+	case text_pb.MessageType_MESSAGE_TYPE_PASSWORDLESS_REGISTRATION:
+		return domain.PasswordlessRegistrationMessageType
+	case text_pb.MessageType_MESSAGE_TYPE_PASSWORD_CHANGE:
+		return domain.PasswordChangeMessageType
+	case text_pb.MessageType_MESSAGE_TYPE_VERIFY_EMAIL_OTP:
+		return domain.VerifyEmailOTPMessageType
+	case text_pb.MessageType_MESSAGE_TYPE_RECOVERY_CODE_USED:
+		return domain.RecoveryCodeUsedMessageType
+	case text_pb.MessageType_MESSAGE_TYPE_NEW_DEVICE_LOGIN:
+		return domain.NewDeviceLoginMessageType
+	case text_pb.MessageType_MESSAGE_TYPE_MFA_ADDED:
+		return domain.MFAAddedMessageType
+	case text_pb.MessageType_MESSAGE_TYPE_MFA_REMOVED:
+		return domain.MFARemovedMessageType
+	case text_pb.MessageType_MESSAGE_TYPE_EMAIL_CHANGED:
+		return domain.EmailChangedMessageType
+	case text_pb.MessageType_MESSAGE_TYPE_MACHINE_CREDENTIAL_ADDED:
+		return domain.MachineCredentialAddedMessageType
+	case text_pb.MessageType_MESSAGE_TYPE_USER_LOCKED:
+		return domain.UserLockedMessageType
+	default:
+		return ""
+	}
+}
+
+func MessageTypeToPb(messageType string) text_pb.MessageType {
+	switch messageType {
+	case domain.InitCodeMessageType:
+		return text_pb.MessageType_MESSAGE_TYPE_INIT
+	case domain.VerifyEmailMessageType:
+		return text_pb.MessageType_MESSAGE_TYPE_VERIFY_EMAIL
+	case domain.PasswordResetMessageType:
+		return text_pb.MessageType_MESSAGE_TYPE_PASSWORD_RESET
+	case domain.DomainClaimedMessageType:
+		return text_pb.MessageType_MESSAGE_TYPE_DOMAIN_CLAIMED
+	case domain.PasswordlessRegistrationMessageType:
+		return text_pb.MessageType_MESSAGE_TYPE_PASSWORDLESS_REGISTRATION
+	case domain.PasswordChangeMessageType:
+		return text_pb.MessageType_MESSAGE_TYPE_PASSWORD_CHANGE
+	case domain.VerifyEmailOTPMessageType:
+		return text_pb.MessageType_MESSAGE_TYPE_VERIFY_EMAIL_OTP
+	case domain.RecoveryCodeUsedMessageType:
+		return text_pb.MessageType_MESSAGE_TYPE_RECOVERY_CODE_USED
+	case domain.NewDeviceLoginMessageType:
+		return text_pb.MessageType_MESSAGE_TYPE_NEW_DEVICE_LOGIN
+	case domain.MFAAddedMessageType:
+		return text_pb.MessageType_MESSAGE_TYPE_MFA_ADDED
+	case domain.MFARemovedMessageType:
+		return text_pb.MessageType_MESSAGE_TYPE_MFA_REMOVED
+	case domain.EmailChangedMessageType:
+		return text_pb.MessageType_MESSAGE_TYPE_EMAIL_CHANGED
+	case domain.MachineCredentialAddedMessageType:
+		return text_pb.MessageType_MESSAGE_TYPE_MACHINE_CREDENTIAL_ADDED
+	case domain.UserLockedMessageType:
+		return text_pb.MessageType_MESSAGE_TYPE_USER_LOCKED
+	default:
+		return text_pb.MessageType_MESSAGE_TYPE_UNSPECIFIED
+	}
+}
+
+func MessageTemplateFormatToDomain(format text_pb.MessageTemplateFormat) domain.MessageTemplateFormat {
+	switch format {
+	case text_pb.MessageTemplateFormat_MESSAGE_TEMPLATE_FORMAT_HTML:
+		return domain.MessageTemplateFormatHTML
+	case text_pb.MessageTemplateFormat_MESSAGE_TEMPLATE_FORMAT_MJML:
+		return domain.MessageTemplateFormatMJML
+	default:
+		return domain.MessageTemplateFormatUnspecified
+	}
+}
+
+func MessageTemplateFormatToPb(format domain.MessageTemplateFormat) text_pb.MessageTemplateFormat {
+	switch format {
+	case domain.MessageTemplateFormatHTML:
+		return text_pb.MessageTemplateFormat_MESSAGE_TEMPLATE_FORMAT_HTML
+	case domain.MessageTemplateFormatMJML:
+		return text_pb.MessageTemplateFormat_MESSAGE_TEMPLATE_FORMAT_MJML
+	default:
+		return text_pb.MessageTemplateFormat_MESSAGE_TEMPLATE_FORMAT_UNSPECIFIED
+	}
+}
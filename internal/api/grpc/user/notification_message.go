@@ -0,0 +1,109 @@
+package user
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+	object_pb "github.com/zitadel/zitadel/pkg/grpc/object"
+	"github.com/zitadel/zitadel/pkg/grpc/user"
+)
+
+func NotificationMessagesToPb(messages []*query.NotificationMessage) []*user.NotificationMessage {
+	m := make([]*user.NotificationMessage, len(messages))
+	for i, message := range messages {
+		m[i] = NotificationMessageToPb(message)
+	}
+	return m
+}
+
+func NotificationMessageToPb(message *query.NotificationMessage) *user.NotificationMessage {
+	m := &user.NotificationMessage{
+		Id:          message.ID,
+		UserId:      message.UserID,
+		MessageType: message.MessageType,
+		Channel:     NotificationChannelToPb(message.Channel),
+		Recipient:   message.Recipient,
+		State:       NotificationMessageStateToPb(message.State),
+		Attempts:    message.Attempts,
+		LastError:   message.LastError,
+		Details: object.ToViewDetailsPb(
+			message.Sequence,
+			message.CreationDate,
+			message.ChangeDate,
+			message.ResourceOwner,
+		),
+	}
+	if message.State.IsPending() {
+		m.NextAttempt = timestamppb.New(message.NextAttempt)
+	}
+	return m
+}
+
+func NotificationChannelToPb(channel domain.NotificationType) user.NotificationChannel {
+	switch channel {
+	case domain.NotificationTypeEmail:
+		return user.NotificationChannel_NOTIFICATION_CHANNEL_EMAIL
+	case domain.NotificationTypeSms:
+		return user.NotificationChannel_NOTIFICATION_CHANNEL_SMS
+	default:
+		return user.NotificationChannel_NOTIFICATION_CHANNEL_UNSPECIFIED
+	}
+}
+
+func NotificationMessageStateToPb(state domain.NotificationMessageState) user.NotificationMessageState {
+	switch state {
+	case domain.NotificationMessageStateQueued:
+		return user.NotificationMessageState_NOTIFICATION_MESSAGE_STATE_QUEUED
+	case domain.NotificationMessageStateRetrying:
+		return user.NotificationMessageState_NOTIFICATION_MESSAGE_STATE_RETRYING
+	case domain.NotificationMessageStateSent:
+		return user.NotificationMessageState_NOTIFICATION_MESSAGE_STATE_SENT
+	case domain.NotificationMessageStateFailed:
+		return user.NotificationMessageState_NOTIFICATION_MESSAGE_STATE_FAILED
+	case domain.NotificationMessageStateDiscarded:
+		return user.NotificationMessageState_NOTIFICATION_MESSAGE_STATE_DISCARDED
+	default:
+		return user.NotificationMessageState_NOTIFICATION_MESSAGE_STATE_UNSPECIFIED
+	}
+}
+
+func NotificationMessageStateToDomain(state user.NotificationMessageState) domain.NotificationMessageState {
+	switch state {
+	case user.NotificationMessageState_NOTIFICATION_MESSAGE_STATE_QUEUED:
+		return domain.NotificationMessageStateQueued
+	case user.NotificationMessageState_NOTIFICATION_MESSAGE_STATE_RETRYING:
+		return domain.NotificationMessageStateRetrying
+	case user.NotificationMessageState_NOTIFICATION_MESSAGE_STATE_SENT:
+		return domain.NotificationMessageStateSent
+	case user.NotificationMessageState_NOTIFICATION_MESSAGE_STATE_FAILED:
+		return domain.NotificationMessageStateFailed
+	case user.NotificationMessageState_NOTIFICATION_MESSAGE_STATE_DISCARDED:
+		return domain.NotificationMessageStateDiscarded
+	default:
+		return domain.NotificationMessageStateUnspecified
+	}
+}
+
+// NotificationMessageSearchQueries returns the queries of the list request,
+// the state is only filtered if it's specified
+func NotificationMessageSearchQueries(listQuery *object_pb.ListQuery, state user.NotificationMessageState, queries ...query.SearchQuery) (*query.NotificationMessageSearchQueries, error) {
+	offset, limit, asc := object.ListQueryToModel(listQuery)
+	if state != user.NotificationMessageState_NOTIFICATION_MESSAGE_STATE_UNSPECIFIED {
+		stateQuery, err := query.NewNotificationMessageStateSearchQuery(NotificationMessageStateToDomain(state))
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, stateQuery)
+	}
+	return &query.NotificationMessageSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset:        offset,
+			Limit:         limit,
+			Asc:           asc,
+			SortingColumn: query.NotificationMessageColumnCreationDate,
+		},
+		Queries: queries,
+	}, nil
+}
@@ -38,6 +38,8 @@ func UserQueryToQuery(query *user_pb.SearchQuery) (query.SearchQuery, error) {
 		return TypeQueryToQuery(q.TypeQuery)
 	case *user_pb.SearchQuery_LoginNameQuery:
 		return LoginNameQueryToQuery(q.LoginNameQuery)
+	case *user_pb.SearchQuery_FullTextQuery:
+		return FullTextQueryToQuery(q.FullTextQuery)
 	case *user_pb.SearchQuery_ResourceOwner:
 		return ResourceOwnerQueryToQuery(q.ResourceOwner)
 	default:
@@ -81,6 +83,10 @@ func LoginNameQueryToQuery(q *user_pb.LoginNameQuery) (query.SearchQuery, error)
 	return query.NewUserLoginNameExistsQuery(q.LoginName, object.TextMethodToQuery(q.Method))
 }
 
+func FullTextQueryToQuery(q *user_pb.FullTextQuery) (query.SearchQuery, error) {
+	return query.NewUserFullTextQuery(q.Text, q.MetadataKeys)
+}
+
 func ResourceOwnerQueryToQuery(q *user_pb.ResourceOwnerQuery) (query.SearchQuery, error) {
 	return query.NewUserResourceOwnerSearchQuery(q.OrgID, query.TextEquals)
 }
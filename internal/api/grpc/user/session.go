@@ -1,6 +1,8 @@
 package user
 
 import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
 	"github.com/zitadel/zitadel/internal/api/grpc/object"
 	"github.com/zitadel/zitadel/internal/domain"
 	user_model "github.com/zitadel/zitadel/internal/user/model"
@@ -44,3 +46,59 @@ func SessionStateToPb(state domain.UserSessionState) user.SessionState {
 		return user.SessionState_SESSION_STATE_UNSPECIFIED
 	}
 }
+
+func ActiveSessionsToPb(sessions []*user_model.UserSessionView, currentAgentID string) []*user.ActiveSession {
+	s := make([]*user.ActiveSession, len(sessions))
+	for i, session := range sessions {
+		s[i] = ActiveSessionToPb(session, currentAgentID)
+	}
+	return s
+}
+
+func ActiveSessionToPb(session *user_model.UserSessionView, currentAgentID string) *user.ActiveSession {
+	return &user.ActiveSession{
+		AgentId:      session.UserAgentID,
+		UserAgent:    session.UserAgent,
+		Ip:           session.RemoteIP,
+		LastActivity: timestamppb.New(session.ChangeDate),
+		Factors:      SessionFactorsToPb(session.Factors()),
+		Current:      session.UserAgentID == currentAgentID,
+		Details: object.ToViewDetailsPb(
+			session.Sequence,
+			session.CreationDate,
+			session.ChangeDate,
+			session.ResourceOwner,
+		),
+	}
+}
+
+func SessionFactorsToPb(factors []user_model.UserSessionFactor) []user.SessionFactor {
+	f := make([]user.SessionFactor, len(factors))
+	for i, factor := range factors {
+		f[i] = SessionFactorToPb(factor)
+	}
+	return f
+}
+
+func SessionFactorToPb(factor user_model.UserSessionFactor) user.SessionFactor {
+	switch factor {
+	case user_model.UserSessionFactorPassword:
+		return user.SessionFactor_SESSION_FACTOR_PASSWORD
+	case user_model.UserSessionFactorPasswordless:
+		return user.SessionFactor_SESSION_FACTOR_PASSWORDLESS
+	case user_model.UserSessionFactorIDP:
+		return user.SessionFactor_SESSION_FACTOR_IDP
+	case user_model.UserSessionFactorOTP:
+		return user.SessionFactor_SESSION_FACTOR_OTP
+	case user_model.UserSessionFactorU2F:
+		return user.SessionFactor_SESSION_FACTOR_U2F
+	case user_model.UserSessionFactorOTPSMS:
+		return user.SessionFactor_SESSION_FACTOR_OTP_SMS
+	case user_model.UserSessionFactorOTPEmail:
+		return user.SessionFactor_SESSION_FACTOR_OTP_EMAIL
+	case user_model.UserSessionFactorRecoveryCode:
+		return user.SessionFactor_SESSION_FACTOR_RECOVERY_CODE
+	default:
+		return user.SessionFactor_SESSION_FACTOR_UNSPECIFIED
+	}
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/api/http/middleware"
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/query"
 	"github.com/zitadel/zitadel/internal/telemetry/tracing"
@@ -94,7 +95,20 @@ func (o *OPStorage) CreateAccessToken(ctx context.Context, req op.TokenRequest)
 		return "", time.Time{}, err
 	}
 
-	resp, err := o.command.AddUserToken(setContextUserSystem(ctx), userOrgID, userAgentID, applicationID, req.GetSubject(), req.GetAudience(), req.GetScopes(), accessTokenLifetime) //PLANNED: lifetime from client
+	var app *query.OIDCApp
+	if applicationID != "" {
+		client, err := o.query.AppByOIDCClientID(ctx, applicationID, false)
+		if err != nil {
+			return "", time.Time{}, err
+		}
+		app = client.OIDCConfig
+	}
+	confirmation, err := tokenConfirmation(ctx, app)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	resp, err := o.command.AddUserToken(setContextUserSystem(ctx), userOrgID, userAgentID, applicationID, req.GetSubject(), req.GetAudience(), req.GetScopes(), accessTokenLifetime, confirmation) //PLANNED: lifetime from client
 	if err != nil {
 		return "", time.Time{}, err
 	}
@@ -118,9 +132,23 @@ func (o *OPStorage) CreateAccessAndRefreshTokens(ctx context.Context, req op.Tok
 		return "", "", time.Time{}, err
 	}
 
+	app, err := o.query.AppByOIDCClientID(ctx, applicationID, false)
+	if err != nil {
+		return "", "", time.Time{}, err
+	}
+	confirmation, err := tokenConfirmation(ctx, app.OIDCConfig)
+	if err != nil {
+		return "", "", time.Time{}, err
+	}
+	// reuse detection is only set on new refresh tokens, renewed tokens keep their setting
+	reuseDetection := refreshToken == "" && app.OIDCConfig.RefreshTokenReuseDetection
+	// refresh tokens of confidential clients are bound by the client authentication,
+	// those of public clients to the proof of possession (RFC 9449 section 5, RFC 8705 section 4)
+	bindRefreshToken := app.OIDCConfig.AuthMethodType == domain.OIDCAuthMethodTypeNone
+
 	resp, token, err := o.command.AddAccessAndRefreshToken(setContextUserSystem(ctx), userOrgID, userAgentID, applicationID, req.GetSubject(),
 		refreshToken, req.GetAudience(), scopes, authMethodsReferences, accessTokenLifetime,
-		refreshTokenIdleExpiration, refreshTokenExpiration, authTime) //PLANNED: lifetime from client
+		refreshTokenIdleExpiration, refreshTokenExpiration, authTime, reuseDetection, confirmation, bindRefreshToken) //PLANNED: lifetime from client
 	if err != nil {
 		if errors.IsErrorInvalidArgument(err) {
 			err = oidc.ErrInvalidGrant().WithParent(err)
@@ -145,6 +173,11 @@ func getInfoFromRequest(req op.TokenRequest) (string, string, string, time.Time,
 func (o *OPStorage) TokenRequestByRefreshToken(ctx context.Context, refreshToken string) (op.RefreshTokenRequest, error) {
 	tokenView, err := o.repo.RefreshTokenByToken(ctx, refreshToken)
 	if err != nil {
+		// only an unknown token can be a reused one, other errors (e.g. of the database) must not revoke the token family
+		if errors.IsNotFound(err) {
+			_, revokeErr := o.command.RevokeReusedRefreshToken(setContextUserSystem(ctx), refreshToken)
+			logging.OnError(revokeErr).Debug("unable to check refresh token reuse")
+		}
 		return nil, err
 	}
 	return RefreshTokenRequestFromBusiness(tokenView), nil
@@ -166,6 +199,7 @@ func (o *OPStorage) TerminateSession(ctx context.Context, userID, clientID strin
 	if len(userIDs) == 0 {
 		return nil
 	}
+	o.addFrontChannelLogoutURIs(ctx, userAgentID, userIDs)
 	data := authz.CtxData{
 		UserID: userID,
 	}
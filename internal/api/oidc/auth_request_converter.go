@@ -24,6 +24,10 @@ const (
 	amrMFA          = "mfa"
 	amrOTP          = "otp"
 	amrUserPresence = "user"
+
+	acrPassword          = "urn:zitadel:acr:password"
+	acrMFA               = "urn:zitadel:acr:mfa"
+	acrPhishingResistant = "urn:zitadel:acr:phishing_resistant"
 )
 
 type AuthRequest struct {
@@ -35,8 +39,7 @@ func (a *AuthRequest) GetID() string {
 }
 
 func (a *AuthRequest) GetACR() string {
-	// return a.
-	return "" //PLANNED: impl
+	return ACRFromLevelOfAssurance(a.LevelOfAssurance())
 }
 
 func (a *AuthRequest) GetAMR() []string {
@@ -183,7 +186,34 @@ func PromptToBusiness(oidcPrompt []string) []domain.Prompt {
 }
 
 func ACRValuesToBusiness(values []string) []domain.LevelOfAssurance {
-	return nil
+	if values == nil {
+		return nil
+	}
+	loas := make([]domain.LevelOfAssurance, 0, len(values))
+	for _, value := range values {
+		switch value {
+		case acrPassword:
+			loas = append(loas, domain.LevelOfAssurancePassword)
+		case acrMFA:
+			loas = append(loas, domain.LevelOfAssuranceMFA)
+		case acrPhishingResistant:
+			loas = append(loas, domain.LevelOfAssurancePhishingResistant)
+		}
+	}
+	return loas
+}
+
+func ACRFromLevelOfAssurance(loa domain.LevelOfAssurance) string {
+	switch loa {
+	case domain.LevelOfAssurancePassword:
+		return acrPassword
+	case domain.LevelOfAssuranceMFA:
+		return acrMFA
+	case domain.LevelOfAssurancePhishingResistant:
+		return acrPhishingResistant
+	default:
+		return ""
+	}
 }
 
 func UILocalesToBusiness(tags []language.Tag) []string {
@@ -270,7 +300,10 @@ func CodeChallengeToOIDC(challenge *domain.OIDCCodeChallenge) *oidc.CodeChalleng
 
 func AMRFromMFAType(mfaType domain.MFAType) string {
 	switch mfaType {
-	case domain.MFATypeOTP:
+	case domain.MFATypeOTP,
+		domain.MFATypeOTPSMS,
+		domain.MFATypeOTPEmail,
+		domain.MFATypeRecoveryCode:
 		return amrOTP
 	case domain.MFATypeU2F,
 		domain.MFATypeU2FUserVerification:
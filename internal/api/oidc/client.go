@@ -111,6 +111,12 @@ func (o *OPStorage) AuthorizeClientIDSecret(ctx context.Context, id string, secr
 		return err
 	}
 	if app.OIDCConfig != nil {
+		switch app.OIDCConfig.AuthMethodType {
+		case domain.OIDCAuthMethodTypeTLSClientAuth:
+			return o.verifyTLSClientAuth(ctx, app.OIDCConfig.TLSClientAuthSubjectDN)
+		case domain.OIDCAuthMethodTypeSelfSignedTLSClientAuth:
+			return o.verifySelfSignedTLSClientAuth(ctx, app.ID)
+		}
 		return o.command.VerifyOIDCClientSecret(ctx, app.ProjectID, app.ID, secret)
 	}
 	return o.command.VerifyAPIClientSecret(ctx, app.ProjectID, app.ID, secret)
@@ -123,6 +129,9 @@ func (o *OPStorage) SetUserinfoFromToken(ctx context.Context, userInfo *oidc.Use
 	if err != nil {
 		return errors.ThrowPermissionDenied(nil, "OIDC-Dsfb2", "token is not valid or has expired")
 	}
+	if !token.Confirmation.Matches(senderConfirmation(ctx)) {
+		return errors.ThrowPermissionDenied(nil, "OIDC-Wq3hv", "token is sender-constrained, proof of possession is missing or invalid")
+	}
 	if token.ApplicationID != "" {
 		app, err := o.query.AppByOIDCClientID(ctx, token.ApplicationID, false)
 		if err != nil {
@@ -179,6 +188,9 @@ func (o *OPStorage) SetIntrospectionFromToken(ctx context.Context, introspection
 			introspection.Scope = token.Scopes
 			introspection.ClientID = token.ApplicationID
 			introspection.TokenType = oidc.BearerToken
+			if !token.Confirmation.IsEmpty() {
+				setIntrospectionConfirmation(introspection, token.Confirmation)
+			}
 			introspection.Expiration = oidc.FromTime(token.Expiration)
 			introspection.IssuedAt = oidc.FromTime(token.CreationDate)
 			introspection.NotBefore = oidc.FromTime(token.CreationDate)
@@ -573,6 +585,10 @@ func (o *OPStorage) GetPrivateClaimsFromScopes(ctx context.Context, userID, clie
 		}
 	}
 
+	if confirmation := senderConfirmation(ctx); confirmation != nil {
+		claims = appendClaim(claims, confirmationClaim, confirmation)
+	}
+
 	return o.privateClaimsFlows(ctx, userID, userGrants, claims)
 }
 
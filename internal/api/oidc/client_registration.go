@@ -0,0 +1,422 @@
+package oidc
+
+import (
+	"encoding/json"
+	errs "errors"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/zitadel/logging"
+	"github.com/zitadel/oidc/v2/pkg/oidc"
+
+	http_utils "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+const (
+	// ClientRegistrationPath is the endpoint of the OIDC dynamic client registration (RFC 7591)
+	// and the client configuration endpoint (RFC 7592) on ClientRegistrationPath/{client_id}
+	ClientRegistrationPath = "/oauth/v2/register"
+
+	clientIDParam = "client_id"
+
+	registrationErrorInvalidRedirectURI    = "invalid_redirect_uri"
+	registrationErrorInvalidClientMetadata = "invalid_client_metadata"
+	registrationErrorInvalidToken          = "invalid_token"
+	registrationErrorServerError           = "server_error"
+
+	applicationTypeWeb    = "web"
+	applicationTypeNative = "native"
+	grantTypeImplicit     = "implicit"
+	grantTypeDeviceCode   = "urn:ietf:params:oauth:grant-type:device_code"
+)
+
+// clientMetadata is the registered metadata of a client (RFC 7591 section 2)
+// including the extensions for logout, mTLS and DPoP supported by ZITADEL
+type clientMetadata struct {
+	RedirectURIs            []string `json:"redirect_uris,omitempty"`
+	PostLogoutRedirectURIs  []string `json:"post_logout_redirect_uris,omitempty"`
+	TokenEndpointAuthMethod string   `json:"token_endpoint_auth_method,omitempty"`
+	GrantTypes              []string `json:"grant_types,omitempty"`
+	ResponseTypes           []string `json:"response_types,omitempty"`
+	ApplicationType         string   `json:"application_type,omitempty"`
+	ClientName              string   `json:"client_name,omitempty"`
+	BackChannelLogoutURI    string   `json:"backchannel_logout_uri,omitempty"`
+	FrontChannelLogoutURI   string   `json:"frontchannel_logout_uri,omitempty"`
+	TLSClientAuthSubjectDN  string   `json:"tls_client_auth_subject_dn,omitempty"`
+	DPoPBoundAccessTokens   bool     `json:"dpop_bound_access_tokens,omitempty"`
+}
+
+type clientInformation struct {
+	clientMetadata
+	ClientID                string `json:"client_id"`
+	ClientSecret            string `json:"client_secret,omitempty"`
+	ClientIDIssuedAt        int64  `json:"client_id_issued_at,omitempty"`
+	ClientSecretExpiresAt   *int64 `json:"client_secret_expires_at,omitempty"`
+	RegistrationAccessToken string `json:"registration_access_token,omitempty"`
+	RegistrationClientURI   string `json:"registration_client_uri"`
+}
+
+type registrationError struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description,omitempty"`
+}
+
+type clientRegistrationHandler struct {
+	command        *command.Commands
+	query          *query.Queries
+	externalSecure bool
+}
+
+// NewClientRegistrationHandler serves the registration of clients authorized by an initial access token of a project
+// and the management of a registration authorized by the registration access token returned to the client
+func NewClientRegistrationHandler(command *command.Commands, query *query.Queries, externalSecure bool, instanceInterceptor, accessInterceptor func(http.Handler) http.Handler) http.Handler {
+	h := &clientRegistrationHandler{
+		command:        command,
+		query:          query,
+		externalSecure: externalSecure,
+	}
+	// the handler is registered with the full path (without stripping the prefix),
+	// because the router would redirect the empty path of the registration endpoint
+	router := mux.NewRouter()
+	router.Use(instanceInterceptor, accessInterceptor)
+	router.HandleFunc(ClientRegistrationPath, h.register).Methods(http.MethodPost)
+	router.HandleFunc(ClientRegistrationPath+"/", h.register).Methods(http.MethodPost)
+	router.HandleFunc(ClientRegistrationPath+"/{"+clientIDParam+"}", h.read).Methods(http.MethodGet)
+	router.HandleFunc(ClientRegistrationPath+"/{"+clientIDParam+"}", h.update).Methods(http.MethodPut)
+	router.HandleFunc(ClientRegistrationPath+"/{"+clientIDParam+"}", h.delete).Methods(http.MethodDelete)
+	return http_utils.CopyHeadersToContext(router)
+}
+
+func (h *clientRegistrationHandler) register(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	projectID, resourceOwner, err := h.command.VerifyInitialAccessToken(ctx, bearerToken(r))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	metadata := new(clientMetadata)
+	if err = json.NewDecoder(r.Body).Decode(metadata); err != nil {
+		h.writeError(w, errors.ThrowInvalidArgument(err, "OIDC-Xw9vb", "Errors.Project.App.Invalid"))
+		return
+	}
+	app, err := metadata.toOIDCApp(projectID, resourceOwner)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	app, registrationToken, err := h.command.RegisterOIDCApplication(ctx, app, resourceOwner)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	information := &clientInformation{
+		clientMetadata:          *metadata,
+		ClientID:                app.ClientID,
+		ClientSecret:            app.ClientSecretString,
+		ClientIDIssuedAt:        app.ChangeDate.Unix(),
+		RegistrationAccessToken: registrationToken,
+		RegistrationClientURI:   h.registrationClientURI(r, app.ClientID),
+	}
+	if app.ClientSecretString != "" {
+		information.ClientSecretExpiresAt = new(int64)
+	}
+	h.writeJSON(w, http.StatusCreated, information)
+}
+
+func (h *clientRegistrationHandler) read(w http.ResponseWriter, r *http.Request) {
+	app, err := h.authorizedApp(r)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	h.writeJSON(w, http.StatusOK, &clientInformation{
+		clientMetadata:        metadataFromQuery(app),
+		ClientID:              app.OIDCConfig.ClientID,
+		ClientIDIssuedAt:      app.CreationDate.Unix(),
+		RegistrationClientURI: h.registrationClientURI(r, app.OIDCConfig.ClientID),
+	})
+}
+
+func (h *clientRegistrationHandler) update(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	existing, err := h.authorizedApp(r)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	metadata := new(clientMetadata)
+	if err = json.NewDecoder(r.Body).Decode(metadata); err != nil {
+		h.writeError(w, errors.ThrowInvalidArgument(err, "OIDC-Vn2ox", "Errors.Project.App.Invalid"))
+		return
+	}
+	app, err := metadata.toOIDCApp(existing.ProjectID, existing.ResourceOwner)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	app.AppID = existing.ID
+	keepManagedSettings(app, existing.OIDCConfig)
+	changed, err := h.command.ChangeRegisteredOIDCApplication(ctx, app, existing.ResourceOwner)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	information := &clientInformation{
+		clientMetadata:        *metadata,
+		ClientID:              existing.OIDCConfig.ClientID,
+		ClientSecret:          changed.ClientSecretString,
+		ClientIDIssuedAt:      existing.CreationDate.Unix(),
+		RegistrationClientURI: h.registrationClientURI(r, existing.OIDCConfig.ClientID),
+	}
+	if changed.ClientSecretString != "" {
+		information.ClientSecretExpiresAt = new(int64)
+	}
+	h.writeJSON(w, http.StatusOK, information)
+}
+
+func (h *clientRegistrationHandler) delete(w http.ResponseWriter, r *http.Request) {
+	app, err := h.authorizedApp(r)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	if _, err = h.command.RemoveApplication(r.Context(), app.ProjectID, app.ID, app.ResourceOwner); err != nil {
+		h.writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// authorizedApp returns the app of the client_id in the path
+// if the request is authorized by its registration access token
+func (h *clientRegistrationHandler) authorizedApp(r *http.Request) (*query.App, error) {
+	ctx := r.Context()
+	app, err := h.query.AppByOIDCClientID(ctx, mux.Vars(r)[clientIDParam], false)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, errors.ThrowUnauthenticated(err, "OIDC-Ea5ry", "Errors.Project.App.RegistrationTokenInvalid")
+		}
+		return nil, err
+	}
+	if err = h.command.VerifyOIDCRegistrationToken(ctx, app.ProjectID, app.ID, bearerToken(r)); err != nil {
+		return nil, err
+	}
+	return app, nil
+}
+
+func (h *clientRegistrationHandler) registrationClientURI(r *http.Request, clientID string) string {
+	return http_utils.BuildOrigin(r.Host, h.externalSecure) + ClientRegistrationPath + "/" + clientID
+}
+
+func (h *clientRegistrationHandler) writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Pragma", "no-cache")
+	w.WriteHeader(status)
+	err := json.NewEncoder(w).Encode(body)
+	logging.OnError(err).Error("unable to write client registration response")
+}
+
+func (h *clientRegistrationHandler) writeError(w http.ResponseWriter, err error) {
+	status, registrationErr := registrationErrorFromError(err)
+	if status == http.StatusUnauthorized {
+		w.Header().Set("WWW-Authenticate", `Bearer error="`+registrationErrorInvalidToken+`"`)
+	}
+	if status == http.StatusInternalServerError {
+		logging.WithError(err).Error("client registration failed")
+	}
+	h.writeJSON(w, status, registrationErr)
+}
+
+func registrationErrorFromError(err error) (int, *registrationError) {
+	caosErr := new(errors.CaosError)
+	description := ""
+	if errs.As(err, &caosErr) {
+		description = caosErr.GetMessage()
+	}
+	switch {
+	case errors.IsUnauthenticated(err):
+		return http.StatusUnauthorized, &registrationError{Error: registrationErrorInvalidToken}
+	case description == "Errors.Project.App.RedirectURINotAllowed":
+		return http.StatusBadRequest, &registrationError{Error: registrationErrorInvalidRedirectURI, ErrorDescription: description}
+	case errors.IsErrorInvalidArgument(err), errors.IsPreconditionFailed(err), errors.IsErrorAlreadyExists(err):
+		return http.StatusBadRequest, &registrationError{Error: registrationErrorInvalidClientMetadata, ErrorDescription: description}
+	default:
+		return http.StatusInternalServerError, &registrationError{Error: registrationErrorServerError}
+	}
+}
+
+func bearerToken(r *http.Request) string {
+	authorization := r.Header.Get(authorizationHeader)
+	if len(authorization) <= len(oidc.PrefixBearer) || !strings.EqualFold(authorization[:len(oidc.PrefixBearer)], oidc.PrefixBearer) {
+		return ""
+	}
+	return authorization[len(oidc.PrefixBearer):]
+}
+
+func (m *clientMetadata) toOIDCApp(projectID, resourceOwner string) (*domain.OIDCApp, error) {
+	if m.ClientName == "" {
+		return nil, errors.ThrowInvalidArgument(nil, "OIDC-Bq4lo", "Errors.Project.App.Invalid")
+	}
+	authMethod, err := authMethodFromRegistration(m.TokenEndpointAuthMethod)
+	if err != nil {
+		return nil, err
+	}
+	grantTypes, err := grantTypesFromRegistration(m.GrantTypes)
+	if err != nil {
+		return nil, err
+	}
+	responseTypes, err := responseTypesFromRegistration(m.ResponseTypes)
+	if err != nil {
+		return nil, err
+	}
+	appType, err := applicationTypeFromRegistration(m.ApplicationType, authMethod)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.OIDCApp{
+		ObjectRoot: models.ObjectRoot{
+			AggregateID:   projectID,
+			ResourceOwner: resourceOwner,
+		},
+		AppName:                 m.ClientName,
+		OIDCVersion:             domain.OIDCVersionV1,
+		RedirectUris:            m.RedirectURIs,
+		PostLogoutRedirectUris:  m.PostLogoutRedirectURIs,
+		ResponseTypes:           responseTypes,
+		GrantTypes:              grantTypes,
+		ApplicationType:         appType,
+		AuthMethodType:          authMethod,
+		AccessTokenType:         domain.OIDCTokenTypeBearer,
+		BackChannelLogoutURI:    m.BackChannelLogoutURI,
+		FrontChannelLogoutURI:   m.FrontChannelLogoutURI,
+		TLSClientAuthSubjectDN:  m.TLSClientAuthSubjectDN,
+		SenderConstrainedTokens: m.DPoPBoundAccessTokens,
+		// dynamically registered clients are third-party applications
+		RequireConsent: true,
+	}, nil
+}
+
+// keepManagedSettings takes over the settings which are not part of the registration metadata,
+// so an update of the client does not reset what was configured by the project owner
+func keepManagedSettings(app *domain.OIDCApp, existing *query.OIDCApp) {
+	app.DevMode = existing.IsDevMode
+	app.AccessTokenType = existing.AccessTokenType
+	app.AccessTokenRoleAssertion = existing.AssertAccessTokenRole
+	app.IDTokenRoleAssertion = existing.AssertIDTokenRole
+	app.IDTokenUserinfoAssertion = existing.AssertIDTokenUserinfo
+	app.ClockSkew = existing.ClockSkew
+	app.AdditionalOrigins = existing.AdditionalOrigins
+	app.SkipNativeAppSuccessPage = existing.SkipNativeAppSuccessPage
+	app.RefreshTokenReuseDetection = existing.RefreshTokenReuseDetection
+	app.RequireConsent = existing.RequireConsent
+}
+
+func metadataFromQuery(app *query.App) clientMetadata {
+	grantTypes := grantTypesToOIDC(app.OIDCConfig.GrantTypes)
+	metadata := clientMetadata{
+		RedirectURIs:            app.OIDCConfig.RedirectURIs,
+		PostLogoutRedirectURIs:  app.OIDCConfig.PostLogoutRedirectURIs,
+		TokenEndpointAuthMethod: string(authMethodToOIDC(app.OIDCConfig.AuthMethodType)),
+		GrantTypes:              make([]string, len(grantTypes)),
+		ResponseTypes:           make([]string, len(app.OIDCConfig.ResponseTypes)),
+		ApplicationType:         applicationTypeWeb,
+		ClientName:              app.Name,
+		BackChannelLogoutURI:    app.OIDCConfig.BackChannelLogoutURI,
+		FrontChannelLogoutURI:   app.OIDCConfig.FrontChannelLogoutURI,
+		TLSClientAuthSubjectDN:  app.OIDCConfig.TLSClientAuthSubjectDN,
+		DPoPBoundAccessTokens:   app.OIDCConfig.SenderConstrainedTokens,
+	}
+	for i, grantType := range grantTypes {
+		metadata.GrantTypes[i] = string(grantType)
+	}
+	for i, responseType := range app.OIDCConfig.ResponseTypes {
+		metadata.ResponseTypes[i] = string(responseTypeToOIDC(responseType))
+	}
+	if app.OIDCConfig.AppType == domain.OIDCApplicationTypeNative {
+		metadata.ApplicationType = applicationTypeNative
+	}
+	return metadata
+}
+
+func authMethodFromRegistration(method string) (domain.OIDCAuthMethodType, error) {
+	switch method {
+	case "", string(oidc.AuthMethodBasic):
+		return domain.OIDCAuthMethodTypeBasic, nil
+	case string(oidc.AuthMethodPost):
+		return domain.OIDCAuthMethodTypePost, nil
+	case string(oidc.AuthMethodNone):
+		return domain.OIDCAuthMethodTypeNone, nil
+	case string(oidc.AuthMethodPrivateKeyJWT):
+		return domain.OIDCAuthMethodTypePrivateKeyJWT, nil
+	case authMethodTLSClientAuth:
+		return domain.OIDCAuthMethodTypeTLSClientAuth, nil
+	case authMethodSelfSignedTLSAuth:
+		return domain.OIDCAuthMethodTypeSelfSignedTLSClientAuth, nil
+	default:
+		return 0, errors.ThrowInvalidArgument(nil, "OIDC-Uo1sx", "Errors.Project.App.Invalid")
+	}
+}
+
+func grantTypesFromRegistration(grantTypes []string) ([]domain.OIDCGrantType, error) {
+	if len(grantTypes) == 0 {
+		return []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode}, nil
+	}
+	types := make([]domain.OIDCGrantType, len(grantTypes))
+	for i, grantType := range grantTypes {
+		switch grantType {
+		case string(oidc.GrantTypeCode):
+			types[i] = domain.OIDCGrantTypeAuthorizationCode
+		case grantTypeImplicit:
+			types[i] = domain.OIDCGrantTypeImplicit
+		case string(oidc.GrantTypeRefreshToken):
+			types[i] = domain.OIDCGrantTypeRefreshToken
+		case grantTypeDeviceCode:
+			types[i] = domain.OIDCGrantTypeDeviceCode
+		default:
+			return nil, errors.ThrowInvalidArgument(nil, "OIDC-Pz7ma", "Errors.Project.App.Invalid")
+		}
+	}
+	return types, nil
+}
+
+func responseTypesFromRegistration(responseTypes []string) ([]domain.OIDCResponseType, error) {
+	if len(responseTypes) == 0 {
+		return []domain.OIDCResponseType{domain.OIDCResponseTypeCode}, nil
+	}
+	types := make([]domain.OIDCResponseType, len(responseTypes))
+	for i, responseType := range responseTypes {
+		switch oidc.ResponseType(responseType) {
+		case oidc.ResponseTypeCode:
+			types[i] = domain.OIDCResponseTypeCode
+		case oidc.ResponseTypeIDToken:
+			types[i] = domain.OIDCResponseTypeIDTokenToken
+		case oidc.ResponseTypeIDTokenOnly:
+			types[i] = domain.OIDCResponseTypeIDToken
+		default:
+			return nil, errors.ThrowInvalidArgument(nil, "OIDC-Ju8dt", "Errors.Project.App.Invalid")
+		}
+	}
+	return types, nil
+}
+
+func applicationTypeFromRegistration(appType string, authMethod domain.OIDCAuthMethodType) (domain.OIDCApplicationType, error) {
+	switch appType {
+	case "", applicationTypeWeb:
+		// web clients without authentication run in the browser
+		if authMethod == domain.OIDCAuthMethodTypeNone {
+			return domain.OIDCApplicationTypeUserAgent, nil
+		}
+		return domain.OIDCApplicationTypeWeb, nil
+	case applicationTypeNative:
+		return domain.OIDCApplicationTypeNative, nil
+	default:
+		return 0, errors.ThrowInvalidArgument(nil, "OIDC-Ol5gi", "Errors.Project.App.Invalid")
+	}
+}
@@ -0,0 +1,168 @@
+package oidc
+
+import (
+	"context"
+	"html/template"
+	"net/http"
+	"net/url"
+
+	"github.com/zitadel/logging"
+	"github.com/zitadel/oidc/v2/pkg/oidc"
+	"github.com/zitadel/oidc/v2/pkg/op"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	sessionIDClaim = "sid"
+	issuerParam    = "iss"
+	sessionIDParam = "sid"
+)
+
+var frontChannelLogoutTemplate = template.Must(template.New("frontChannelLogout").Parse(`<!DOCTYPE html>
+<html>
+<head>
+	<meta charset="utf-8">
+	<meta http-equiv="refresh" content="2;url={{.RedirectURI}}">
+	<title>Logout</title>
+</head>
+<body>
+	{{range .LogoutURIs}}<iframe src="{{.}}" style="display:none" width="0" height="0"></iframe>
+	{{end}}<a href="{{.RedirectURI}}">Continue</a>
+</body>
+</html>
+`))
+
+// SetUserinfoFromRequest implements the op.CanSetUserinfoFromRequest interface
+// it adds the session id (user agent) as `sid` claim to the id_token
+func (o *OPStorage) SetUserinfoFromRequest(ctx context.Context, userinfo *oidc.UserInfo, request op.IDTokenRequest, _ []string) error {
+	if sessionID := sessionIDFromRequest(request); sessionID != "" {
+		userinfo.AppendClaims(sessionIDClaim, sessionID)
+	}
+	return nil
+}
+
+func sessionIDFromRequest(request op.IDTokenRequest) string {
+	switch req := request.(type) {
+	case *AuthRequest:
+		return req.AgentID
+	case *RefreshTokenRequest:
+		return req.UserAgentID
+	}
+	return ""
+}
+
+type frontChannelLogoutKey struct{}
+
+// frontChannelLogout collects the front-channel logout uris of the clients
+// the user agent was signed out of during an end_session request
+type frontChannelLogout struct {
+	logoutURIs []string
+}
+
+func (l *frontChannelLogout) add(logoutURI string) {
+	for _, existing := range l.logoutURIs {
+		if existing == logoutURI {
+			return
+		}
+	}
+	l.logoutURIs = append(l.logoutURIs, logoutURI)
+}
+
+func frontChannelLogoutFromContext(ctx context.Context) (*frontChannelLogout, bool) {
+	logout, ok := ctx.Value(frontChannelLogoutKey{}).(*frontChannelLogout)
+	return logout, ok
+}
+
+// FrontChannelLogoutInterceptor renders the front-channel logout uris as iframes
+// before redirecting the user agent to the post_logout_redirect_uri of an end_session request
+func FrontChannelLogoutInterceptor(endSessionPath string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != endSessionPath {
+				next.ServeHTTP(w, r)
+				return
+			}
+			logout := new(frontChannelLogout)
+			ctx := context.WithValue(r.Context(), frontChannelLogoutKey{}, logout)
+			next.ServeHTTP(&frontChannelLogoutWriter{ResponseWriter: w, logout: logout}, r.WithContext(ctx))
+		})
+	}
+}
+
+type frontChannelLogoutWriter struct {
+	http.ResponseWriter
+	logout   *frontChannelLogout
+	rendered bool
+}
+
+func (w *frontChannelLogoutWriter) WriteHeader(statusCode int) {
+	if statusCode != http.StatusFound || len(w.logout.logoutURIs) == 0 {
+		w.ResponseWriter.WriteHeader(statusCode)
+		return
+	}
+	w.rendered = true
+	redirectURI := w.Header().Get("Location")
+	w.Header().Del("Location")
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.ResponseWriter.WriteHeader(http.StatusOK)
+	err := frontChannelLogoutTemplate.Execute(w.ResponseWriter, &struct {
+		LogoutURIs  []string
+		RedirectURI string
+	}{
+		LogoutURIs:  w.logout.logoutURIs,
+		RedirectURI: redirectURI,
+	})
+	logging.OnError(err).Error("unable to render front-channel logout")
+}
+
+// Write discards the body of the redirect, if the front-channel logout was rendered instead
+func (w *frontChannelLogoutWriter) Write(b []byte) (int, error) {
+	if w.rendered {
+		return len(b), nil
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (o *OPStorage) addFrontChannelLogoutURIs(ctx context.Context, userAgentID string, userIDs []string) {
+	logout, ok := frontChannelLogoutFromContext(ctx)
+	if !ok {
+		return
+	}
+	issuer := op.IssuerFromContext(ctx)
+	for _, userID := range userIDs {
+		clientIDs, err := o.query.SessionClientIDs(ctx, userID, userAgentID, 0)
+		if err != nil {
+			logging.WithFields("userID", userID).WithError(err).Warn("unable to get session clients for front-channel logout")
+			continue
+		}
+		for _, clientID := range clientIDs {
+			app, err := o.query.AppByOIDCClientID(ctx, clientID, false)
+			if err != nil {
+				logging.WithFields("clientID", clientID).WithError(err).Warn("unable to get client for front-channel logout")
+				continue
+			}
+			if app.OIDCConfig.FrontChannelLogoutURI == "" {
+				continue
+			}
+			logoutURI, err := frontChannelLogoutURI(app.OIDCConfig.FrontChannelLogoutURI, issuer, userAgentID)
+			if err != nil {
+				logging.WithFields("clientID", clientID).WithError(err).Warn("invalid front-channel logout uri")
+				continue
+			}
+			logout.add(logoutURI)
+		}
+	}
+}
+
+func frontChannelLogoutURI(logoutURI, issuer, sessionID string) (string, error) {
+	u, err := url.Parse(logoutURI)
+	if err != nil {
+		return "", errors.ThrowInvalidArgument(err, "OIDC-Fq2ga", "invalid front-channel logout uri")
+	}
+	query := u.Query()
+	query.Set(issuerParam, issuer)
+	query.Set(sessionIDParam, sessionID)
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
@@ -2,6 +2,7 @@ package oidc
 
 import (
 	"context"
+	"crypto/x509"
 	"fmt"
 	"net/http"
 	"time"
@@ -41,6 +42,7 @@ type Config struct {
 	Cache                             *middleware.CacheConfig
 	CustomEndpoints                   *EndpointConfig
 	DeviceAuth                        *DeviceAuthorizationConfig
+	SenderConstraint                  *SenderConstraintConfig
 }
 
 type EndpointConfig struct {
@@ -73,6 +75,7 @@ type OPStorage struct {
 	encAlg                            crypto.EncryptionAlgorithm
 	locker                            crdb.Locker
 	assetAPIPrefix                    func(ctx context.Context) string
+	clientCAs                         *x509.CertPool
 }
 
 func NewProvider(config Config, defaultLogoutRedirectURI string, externalSecure bool, command *command.Commands, query *query.Queries, repo repository.Repository, encryptionAlg crypto.EncryptionAlgorithm, cryptoKey []byte, es *eventstore.Eventstore, projections *database.DB, userAgentCookie, instanceHandler, accessHandler func(http.Handler) http.Handler) (op.OpenIDProvider, error) {
@@ -80,7 +83,11 @@ func NewProvider(config Config, defaultLogoutRedirectURI string, externalSecure
 	if err != nil {
 		return nil, caos_errs.ThrowInternal(err, "OIDC-EGrqd", "cannot create op config: %w")
 	}
-	storage := newStorage(config, command, query, repo, encryptionAlg, es, projections, externalSecure)
+	clientCAs, err := config.SenderConstraint.clientCAs()
+	if err != nil {
+		return nil, err
+	}
+	storage := newStorage(config, command, query, repo, encryptionAlg, es, projections, externalSecure, clientCAs)
 	options, err := createOptions(config, externalSecure, userAgentCookie, instanceHandler, accessHandler)
 	if err != nil {
 		return nil, caos_errs.ThrowInternal(err, "OIDC-D3gq1", "cannot create options: %w")
@@ -130,6 +137,8 @@ func createOptions(config Config, externalSecure bool, userAgentCookie, instance
 			userAgentCookie,
 			http_utils.CopyHeadersToContext,
 			accessHandler,
+			FrontChannelLogoutInterceptor(endSessionPath(config.CustomEndpoints)),
+			SenderConstraintInterceptor(config.SenderConstraint, tokenPath(config.CustomEndpoints), userinfoPath(config.CustomEndpoints), externalSecure),
 		),
 	}
 	if !externalSecure {
@@ -174,7 +183,28 @@ func customEndpoints(endpointConfig *EndpointConfig) []op.Option {
 	return options
 }
 
-func newStorage(config Config, command *command.Commands, query *query.Queries, repo repository.Repository, encAlg crypto.EncryptionAlgorithm, es *eventstore.Eventstore, db *database.DB, externalSecure bool) *OPStorage {
+func endSessionPath(endpointConfig *EndpointConfig) string {
+	if endpointConfig == nil || endpointConfig.EndSession == nil {
+		return op.DefaultEndpoints.EndSession.Relative()
+	}
+	return op.NewEndpoint(endpointConfig.EndSession.Path).Relative()
+}
+
+func tokenPath(endpointConfig *EndpointConfig) string {
+	if endpointConfig == nil || endpointConfig.Token == nil {
+		return op.DefaultEndpoints.Token.Relative()
+	}
+	return op.NewEndpoint(endpointConfig.Token.Path).Relative()
+}
+
+func userinfoPath(endpointConfig *EndpointConfig) string {
+	if endpointConfig == nil || endpointConfig.Userinfo == nil {
+		return op.DefaultEndpoints.Userinfo.Relative()
+	}
+	return op.NewEndpoint(endpointConfig.Userinfo.Path).Relative()
+}
+
+func newStorage(config Config, command *command.Commands, query *query.Queries, repo repository.Repository, encAlg crypto.EncryptionAlgorithm, es *eventstore.Eventstore, db *database.DB, externalSecure bool, clientCAs *x509.CertPool) *OPStorage {
 	return &OPStorage{
 		repo:                              repo,
 		command:                           command,
@@ -189,6 +219,7 @@ func newStorage(config Config, command *command.Commands, query *query.Queries,
 		encAlg:                            encAlg,
 		locker:                            crdb.NewLocker(db.DB, locksTable, signingKey),
 		assetAPIPrefix:                    assets.AssetAPI(externalSecure),
+		clientCAs:                         clientCAs,
 	}
 }
 
@@ -0,0 +1,438 @@
+package oidc
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/zitadel/logging"
+	"github.com/zitadel/oidc/v2/pkg/oidc"
+	"github.com/zitadel/oidc/v2/pkg/op"
+	"gopkg.in/square/go-jose.v2"
+
+	http_utils "github.com/zitadel/zitadel/internal/api/http"
+	zitadel_crypto "github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+const (
+	dpopHeader                   = "DPoP"
+	dpopTokenType                = "DPoP"
+	dpopProofType                = "dpop+jwt"
+	dpopProofDefaultLifetime     = time.Minute
+	dpopProofClockSkew           = 5 * time.Second
+	invalidDPoPProof             = "invalid_dpop_proof"
+	authMethodTLSClientAuth      = "tls_client_auth"
+	authMethodSelfSignedTLSAuth  = "self_signed_tls_client_auth"
+	confirmationClaim            = "cnf"
+	authorizationHeader          = "Authorization"
+	authorizationSchemeBearer    = "Bearer "
+	authorizationSchemeDPoP      = "DPoP "
+	tokenResponseTokenTypeField  = "token_type"
+	wwwAuthenticateHeader        = "WWW-Authenticate"
+	wwwAuthenticateInvalidDPoP   = `DPoP error="invalid_dpop_proof"`
+	clientCertificateHeaderLimit = 16 * 1024
+)
+
+type SenderConstraintConfig struct {
+	// DPoPProofLifetime is the maximum age of a DPoP proof, counted from its iat claim
+	DPoPProofLifetime time.Duration
+	// ClientCertificateHeader is the header a TLS terminating reverse proxy
+	// passes the url encoded PEM client certificate in (e.g. X-SSL-Client-Cert).
+	// Certificates passed in the header are trusted to be verified by the proxy.
+	ClientCertificateHeader string
+	// ClientCertificateCAPath is the path to a PEM bundle of the CAs trusted to issue
+	// client certificates for tls_client_auth, if TLS is terminated by ZITADEL
+	ClientCertificateCAPath string
+}
+
+func (c *SenderConstraintConfig) dpopProofLifetime() time.Duration {
+	if c == nil || c.DPoPProofLifetime == 0 {
+		return dpopProofDefaultLifetime
+	}
+	return c.DPoPProofLifetime
+}
+
+func (c *SenderConstraintConfig) clientCertificateHeader() string {
+	if c == nil {
+		return ""
+	}
+	return c.ClientCertificateHeader
+}
+
+// clientCAs loads the CA bundle for tls_client_auth.
+// Safe to call when c is nil.
+func (c *SenderConstraintConfig) clientCAs() (*x509.CertPool, error) {
+	if c == nil || c.ClientCertificateCAPath == "" {
+		return nil, nil
+	}
+	bundle, err := os.ReadFile(c.ClientCertificateCAPath)
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(bundle) {
+		return nil, errors.ThrowInvalidArgument(nil, "OIDC-Wk2rd", "no certificates found in client certificate CA bundle")
+	}
+	return pool, nil
+}
+
+type senderConstraintKey struct{}
+
+// senderConstraint holds the proof of possession presented with a request:
+// the key of a valid DPoP proof and / or the TLS client certificate
+type senderConstraint struct {
+	certificate *x509.Certificate
+	// certificateForwarded is set if the certificate was passed by a reverse proxy
+	certificateForwarded bool
+	jwkThumbprint        string
+	// binding is set for requests to the token and userinfo endpoint,
+	// the certificate of other requests is only used for client authentication
+	binding bool
+}
+
+func (s *senderConstraint) confirmation() *domain.TokenConfirmation {
+	if !s.binding {
+		return nil
+	}
+	confirmation := &domain.TokenConfirmation{
+		JWKThumbprint: s.jwkThumbprint,
+	}
+	if s.certificate != nil {
+		confirmation.X509Thumbprint = certificateThumbprint(s.certificate)
+	}
+	if confirmation.IsEmpty() {
+		return nil
+	}
+	return confirmation
+}
+
+func senderConstraintFromContext(ctx context.Context) *senderConstraint {
+	constraint, ok := ctx.Value(senderConstraintKey{}).(*senderConstraint)
+	if !ok {
+		return new(senderConstraint)
+	}
+	return constraint
+}
+
+// senderConfirmation returns the confirmation the tokens of the current request have to be bound to
+func senderConfirmation(ctx context.Context) *domain.TokenConfirmation {
+	return senderConstraintFromContext(ctx).confirmation()
+}
+
+// SenderConstraintInterceptor extracts the TLS client certificate of every request
+// and validates DPoP proofs (RFC 9449) sent to the token and userinfo endpoint.
+// Token responses for requests with a DPoP proof are returned with token_type DPoP,
+// DPoP bound access tokens are accepted on the userinfo endpoint with the DPoP authorization scheme.
+// The jti of a proof is not tracked, replays are limited by the lifetime of the proof.
+func SenderConstraintInterceptor(config *SenderConstraintConfig, tokenPath, userinfoPath string, externalSecure bool) func(http.Handler) http.Handler {
+	proofLifetime := config.dpopProofLifetime()
+	certificateHeader := config.clientCertificateHeader()
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			constraint := new(senderConstraint)
+			certificate, forwarded, err := clientCertificate(r, certificateHeader)
+			if err != nil {
+				logging.WithError(err).Info("invalid client certificate")
+			}
+			constraint.certificate, constraint.certificateForwarded = certificate, forwarded
+
+			switch r.URL.Path {
+			case tokenPath:
+				constraint.binding = true
+				proof := r.Header.Get(dpopHeader)
+				if proof == "" {
+					break
+				}
+				constraint.jwkThumbprint, err = verifyDPoPProof(proof, r.Method, requestURI(r, externalSecure), "", proofLifetime)
+				if err != nil {
+					op.RequestError(w, r, &oidc.Error{ErrorType: invalidDPoPProof, Description: err.Error()})
+					return
+				}
+				writer := &dpopTokenResponseWriter{ResponseWriter: w}
+				defer writer.flush()
+				w = writer
+			case userinfoPath:
+				constraint.binding = true
+				authorization := r.Header.Get(authorizationHeader)
+				if !strings.HasPrefix(authorization, authorizationSchemeDPoP) {
+					break
+				}
+				accessToken := strings.TrimPrefix(authorization, authorizationSchemeDPoP)
+				constraint.jwkThumbprint, err = verifyDPoPProof(r.Header.Get(dpopHeader), r.Method, requestURI(r, externalSecure), accessToken, proofLifetime)
+				if err != nil {
+					w.Header().Set(wwwAuthenticateHeader, wwwAuthenticateInvalidDPoP)
+					http.Error(w, err.Error(), http.StatusUnauthorized)
+					return
+				}
+				// the userinfo endpoint of the OP only accepts the Bearer scheme,
+				// the binding of the token is checked in SetUserinfoFromToken
+				r.Header.Set(authorizationHeader, authorizationSchemeBearer+accessToken)
+			}
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), senderConstraintKey{}, constraint)))
+		})
+	}
+}
+
+func requestURI(r *http.Request, externalSecure bool) string {
+	return http_utils.BuildOrigin(r.Host, externalSecure) + r.URL.Path
+}
+
+type dpopProofClaims struct {
+	ID              string    `json:"jti"`
+	Method          string    `json:"htm"`
+	URI             string    `json:"htu"`
+	IssuedAt        oidc.Time `json:"iat"`
+	AccessTokenHash string    `json:"ath,omitempty"`
+}
+
+// verifyDPoPProof validates the proof for the request and returns the thumbprint (RFC 7638) of its key.
+// If an accessToken is passed, the proof must contain its hash (ath).
+func verifyDPoPProof(proof, method, uri, accessToken string, lifetime time.Duration) (string, error) {
+	if proof == "" {
+		return "", errors.ThrowUnauthenticated(nil, "OIDC-Rw3pn", "DPoP proof missing")
+	}
+	jws, err := jose.ParseSigned(proof)
+	if err != nil || len(jws.Signatures) != 1 {
+		return "", errors.ThrowInvalidArgument(err, "OIDC-Bq0vd", "DPoP proof is not a valid JWT")
+	}
+	header := jws.Signatures[0].Protected
+	if typ, _ := header.ExtraHeaders[jose.HeaderType].(string); typ != dpopProofType {
+		return "", errors.ThrowInvalidArgument(nil, "OIDC-Hm5cx", "DPoP proof has invalid typ")
+	}
+	if header.Algorithm == "" || header.Algorithm == "none" || strings.HasPrefix(header.Algorithm, "HS") {
+		return "", errors.ThrowInvalidArgument(nil, "OIDC-Tz4qa", "DPoP proof must be signed with an asymmetric algorithm")
+	}
+	key := header.JSONWebKey
+	if key == nil || !key.Valid() || !key.IsPublic() {
+		return "", errors.ThrowInvalidArgument(nil, "OIDC-Lj2ow", "DPoP proof must contain a public jwk")
+	}
+	payload, err := jws.Verify(key)
+	if err != nil {
+		return "", errors.ThrowInvalidArgument(err, "OIDC-Pf8xe", "DPoP proof signature invalid")
+	}
+	claims := new(dpopProofClaims)
+	if err = json.Unmarshal(payload, claims); err != nil {
+		return "", errors.ThrowInvalidArgument(err, "OIDC-Ns1ke", "DPoP proof claims invalid")
+	}
+	if claims.ID == "" {
+		return "", errors.ThrowInvalidArgument(nil, "OIDC-Gx7ub", "DPoP proof jti missing")
+	}
+	if claims.Method != method || !dpopURIMatches(claims.URI, uri) {
+		return "", errors.ThrowInvalidArgument(nil, "OIDC-Vd3sr", "DPoP proof does not match the request")
+	}
+	issuedAt := claims.IssuedAt.AsTime()
+	if now := time.Now(); issuedAt.Before(now.Add(-lifetime)) || issuedAt.After(now.Add(dpopProofClockSkew)) {
+		return "", errors.ThrowInvalidArgument(nil, "OIDC-Yc6mt", "DPoP proof expired")
+	}
+	if accessToken != "" && claims.AccessTokenHash != accessTokenHash(accessToken) {
+		return "", errors.ThrowInvalidArgument(nil, "OIDC-Ek9wf", "DPoP proof is not bound to the access token")
+	}
+	thumbprint, err := key.Thumbprint(crypto.SHA256)
+	if err != nil {
+		return "", errors.ThrowInvalidArgument(err, "OIDC-Jr5zh", "DPoP proof jwk invalid")
+	}
+	return base64.RawURLEncoding.EncodeToString(thumbprint), nil
+}
+
+// dpopURIMatches compares the htu claim to the request uri without query and fragment
+func dpopURIMatches(htu, uri string) bool {
+	claimed, err := url.Parse(htu)
+	if err != nil {
+		return false
+	}
+	requested, err := url.Parse(uri)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(claimed.Scheme, requested.Scheme) &&
+		strings.EqualFold(claimed.Host, requested.Host) &&
+		claimed.Path == requested.Path
+}
+
+func accessTokenHash(accessToken string) string {
+	hash := sha256.Sum256([]byte(accessToken))
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
+
+// dpopTokenResponseWriter buffers the token response
+// to set the token_type of successful responses to DPoP
+type dpopTokenResponseWriter struct {
+	http.ResponseWriter
+	statusCode int
+	body       bytes.Buffer
+}
+
+func (w *dpopTokenResponseWriter) WriteHeader(statusCode int) {
+	w.statusCode = statusCode
+}
+
+func (w *dpopTokenResponseWriter) Write(b []byte) (int, error) {
+	return w.body.Write(b)
+}
+
+func (w *dpopTokenResponseWriter) flush() {
+	if w.statusCode == 0 {
+		w.statusCode = http.StatusOK
+	}
+	body := w.body.Bytes()
+	if w.statusCode == http.StatusOK {
+		body = dpopTokenResponse(body)
+	}
+	w.ResponseWriter.WriteHeader(w.statusCode)
+	_, err := w.ResponseWriter.Write(body)
+	logging.OnError(err).Error("unable to write token response")
+}
+
+func dpopTokenResponse(body []byte) []byte {
+	response := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(body, &response); err != nil {
+		return body
+	}
+	if _, ok := response[tokenResponseTokenTypeField]; !ok {
+		return body
+	}
+	response[tokenResponseTokenTypeField] = json.RawMessage(`"` + dpopTokenType + `"`)
+	dpopBody, err := json.Marshal(response)
+	if err != nil {
+		return body
+	}
+	return dpopBody
+}
+
+// clientCertificate returns the client certificate of the TLS connection
+// or the one forwarded by a reverse proxy in the configured header
+func clientCertificate(r *http.Request, header string) (certificate *x509.Certificate, forwarded bool, err error) {
+	if r.TLS != nil && len(r.TLS.PeerCertificates) > 0 {
+		return r.TLS.PeerCertificates[0], false, nil
+	}
+	if header == "" {
+		return nil, false, nil
+	}
+	value := r.Header.Get(header)
+	if value == "" || len(value) > clientCertificateHeaderLimit {
+		return nil, false, nil
+	}
+	value, err = url.QueryUnescape(value)
+	if err != nil {
+		return nil, false, err
+	}
+	block, _ := pem.Decode([]byte(value))
+	if block == nil {
+		return nil, false, errors.ThrowInvalidArgument(nil, "OIDC-Xk8vn", "forwarded client certificate is not PEM encoded")
+	}
+	certificate, err = x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, false, err
+	}
+	return certificate, true, nil
+}
+
+// certificateThumbprint returns the x5t#S256 of the certificate (RFC 8705)
+func certificateThumbprint(certificate *x509.Certificate) string {
+	hash := sha256.Sum256(certificate.Raw)
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
+
+// tokenConfirmation returns the confirmation new tokens of the request are bound to.
+// It fails if the application requires sender-constrained tokens, but no proof of possession was presented.
+func tokenConfirmation(ctx context.Context, app *query.OIDCApp) (*domain.TokenConfirmation, error) {
+	confirmation := senderConfirmation(ctx)
+	if confirmation.IsEmpty() && app != nil && app.SenderConstrainedTokens {
+		return nil, oidc.ErrInvalidRequest().WithDescription("client requires sender-constrained tokens, a DPoP proof or client certificate must be presented")
+	}
+	return confirmation, nil
+}
+
+func setIntrospectionConfirmation(introspection *oidc.IntrospectionResponse, confirmation *domain.TokenConfirmation) {
+	if confirmation.JWKThumbprint != "" {
+		introspection.TokenType = dpopTokenType
+	}
+	if introspection.Claims == nil {
+		introspection.Claims = make(map[string]any)
+	}
+	introspection.Claims[confirmationClaim] = confirmation
+}
+
+// verifyTLSClientAuth authenticates a client by the subject of its CA issued certificate (tls_client_auth)
+func (o *OPStorage) verifyTLSClientAuth(ctx context.Context, subjectDN string) error {
+	constraint := senderConstraintFromContext(ctx)
+	if constraint.certificate == nil {
+		return errors.ThrowUnauthenticated(nil, "OIDC-Sd9vq", "client certificate missing")
+	}
+	if !constraint.certificateForwarded {
+		if o.clientCAs == nil {
+			return errors.ThrowPreconditionFailed(nil, "OIDC-Qm4yu", "no CAs configured for tls_client_auth")
+		}
+		_, err := constraint.certificate.Verify(x509.VerifyOptions{
+			Roots:     o.clientCAs,
+			KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		})
+		if err != nil {
+			return errors.ThrowUnauthenticated(err, "OIDC-Fp6ws", "client certificate is not trusted")
+		}
+	}
+	if !subjectDNMatches(constraint.certificate.Subject.String(), subjectDN) {
+		return errors.ThrowUnauthenticated(nil, "OIDC-Zt1ib", "client certificate subject does not match")
+	}
+	return nil
+}
+
+// verifySelfSignedTLSClientAuth authenticates a client by a certificate
+// issued for one of the keys registered on the application (self_signed_tls_client_auth)
+func (o *OPStorage) verifySelfSignedTLSClientAuth(ctx context.Context, appID string) error {
+	certificate := senderConstraintFromContext(ctx).certificate
+	if certificate == nil {
+		return errors.ThrowUnauthenticated(nil, "OIDC-Mv7oj", "client certificate missing")
+	}
+	now := time.Now()
+	if now.Before(certificate.NotBefore) || now.After(certificate.NotAfter) {
+		return errors.ThrowUnauthenticated(nil, "OIDC-Ub2ko", "client certificate expired")
+	}
+	objectQuery, err := query.NewAuthNKeyObjectIDQuery(appID)
+	if err != nil {
+		return err
+	}
+	keys, err := o.query.SearchAuthNKeysData(ctx, &query.AuthNKeySearchQueries{Queries: []query.SearchQuery{objectQuery}}, false)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys.AuthNKeysData {
+		if key.Expiration.Before(now) {
+			continue
+		}
+		publicKey, err := zitadel_crypto.BytesToPublicKey(key.PublicKey)
+		if err != nil {
+			logging.WithFields("keyID", key.ID).WithError(err).Warn("unable to parse application key")
+			continue
+		}
+		if publicKey.Equal(certificate.PublicKey) {
+			return nil
+		}
+	}
+	return errors.ThrowUnauthenticated(nil, "OIDC-Ia4ts", "client certificate does not match a key of the application")
+}
+
+// subjectDNMatches compares the distinguished names ignoring case and whitespace around the separators
+func subjectDNMatches(subject, expected string) bool {
+	normalize := func(dn string) string {
+		parts := strings.Split(dn, ",")
+		for i, part := range parts {
+			parts[i] = strings.TrimSpace(part)
+		}
+		return strings.Join(parts, ",")
+	}
+	return expected != "" && strings.EqualFold(normalize(subject), normalize(expected))
+}
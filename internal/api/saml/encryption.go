@@ -0,0 +1,344 @@
+package saml
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/xml"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+
+	"github.com/zitadel/logging"
+	"github.com/zitadel/saml/pkg/provider/signature"
+	saml_xml "github.com/zitadel/saml/pkg/provider/xml"
+	"github.com/zitadel/saml/pkg/provider/xml/md"
+	"github.com/zitadel/saml/pkg/provider/xml/samlp"
+	"github.com/zitadel/saml/pkg/provider/xml/xenc"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	assertionNamespace        = "urn:oasis:names:tc:SAML:2.0:assertion"
+	encryptionTypeElement     = "http://www.w3.org/2001/04/xmlenc#Element"
+	encryptionMethodAES128CBC = "http://www.w3.org/2001/04/xmlenc#aes128-cbc"
+	encryptionMethodAES256CBC = "http://www.w3.org/2001/04/xmlenc#aes256-cbc"
+	encryptionMethodAES128GCM = "http://www.w3.org/2009/xmlenc11#aes128-gcm"
+	encryptionMethodAES256GCM = "http://www.w3.org/2009/xmlenc11#aes256-gcm"
+	keyTransportRSAOAEP       = "http://www.w3.org/2001/04/xmlenc#rsa-oaep-mgf1p"
+	digestMethodSHA1          = "http://www.w3.org/2000/09/xmldsig#sha1"
+)
+
+// dataEncryptionKeySizes maps the supported data encryption methods to their key size
+var dataEncryptionKeySizes = map[string]int{
+	encryptionMethodAES128CBC: 16,
+	encryptionMethodAES256CBC: 32,
+	encryptionMethodAES128GCM: 16,
+	encryptionMethodAES256GCM: 32,
+}
+
+var samlResponseFormValue = regexp.MustCompile(`(name="SAMLResponse"\s+value=")([^"]*)(")`)
+
+// assertionEncryptionInterceptor encrypts the assertion of the responses sent by the callback endpoint,
+// if the service provider (SAML app) requires encrypted assertions
+func (p *Storage) assertionEncryptionInterceptor(callbackPath string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != callbackPath {
+				next.ServeHTTP(w, r)
+				return
+			}
+			recorder := &responseRecorder{ResponseWriter: w, statusCode: http.StatusOK}
+			next.ServeHTTP(recorder, r)
+			body, err := p.encryptResponseBody(r, recorder.statusCode, w.Header().Get("Location"), recorder.body.Bytes())
+			if err != nil {
+				logging.WithError(err).Warn("unable to encrypt saml assertion")
+				w.Header().Del("Location")
+				http.Error(w, "failed to encrypt assertion", http.StatusInternalServerError)
+				return
+			}
+			w.WriteHeader(recorder.statusCode)
+			_, err = w.Write(body)
+			logging.OnError(err).Error("unable to write saml response")
+		})
+	}
+}
+
+// responseRecorder buffers the response, so it can be modified before it's sent to the user agent
+type responseRecorder struct {
+	http.ResponseWriter
+	statusCode int
+	body       bytes.Buffer
+}
+
+func (w *responseRecorder) WriteHeader(statusCode int) {
+	w.statusCode = statusCode
+}
+
+func (w *responseRecorder) Write(b []byte) (int, error) {
+	return w.body.Write(b)
+}
+
+// encryptResponseBody replaces the assertion of the SAML response in the HTTP-POST form with an encrypted assertion.
+// Responses over the HTTP-Redirect binding are denied for apps requiring encrypted assertions.
+func (p *Storage) encryptResponseBody(r *http.Request, statusCode int, location string, body []byte) ([]byte, error) {
+	if statusCode == http.StatusFound {
+		response, err := redirectResponse(location)
+		if err != nil || response == nil {
+			return body, err
+		}
+		app, err := p.query.AppBySAMLEntityID(r.Context(), responseAudience(response), false)
+		if err != nil || !app.SAMLConfig.EncryptAssertions {
+			return body, err
+		}
+		return nil, errors.ThrowPreconditionFailed(nil, "SAML-Rk2bq", "assertion encryption requires the HTTP-POST binding")
+	}
+	match := samlResponseFormValue.FindSubmatchIndex(body)
+	if statusCode != http.StatusOK || match == nil {
+		return body, nil
+	}
+	rawResponse, err := base64.StdEncoding.DecodeString(html.UnescapeString(string(body[match[4]:match[5]])))
+	if err != nil {
+		return nil, err
+	}
+	response := new(samlp.ResponseType)
+	if err = xml.Unmarshal(rawResponse, response); err != nil {
+		return nil, err
+	}
+	audience := responseAudience(response)
+	if audience == "" {
+		return body, nil
+	}
+	app, err := p.query.AppBySAMLEntityID(r.Context(), audience, false)
+	if err != nil {
+		return nil, err
+	}
+	if !app.SAMLConfig.EncryptAssertions {
+		return body, nil
+	}
+	certificate, method, err := encryptionCertificate(app.SAMLConfig.Metadata)
+	if err != nil {
+		return nil, err
+	}
+	encryptedResponse, err := replaceAssertion(rawResponse, func(assertion []byte) ([]byte, error) {
+		return encryptAssertion(assertion, certificate, method)
+	})
+	if err != nil {
+		return nil, err
+	}
+	encodedResponse := base64.StdEncoding.EncodeToString(encryptedResponse)
+	encryptedBody := make([]byte, 0, len(body)+len(encodedResponse))
+	encryptedBody = append(encryptedBody, body[:match[4]]...)
+	encryptedBody = append(encryptedBody, encodedResponse...)
+	return append(encryptedBody, body[match[5]:]...), nil
+}
+
+func redirectResponse(location string) (*samlp.ResponseType, error) {
+	u, err := url.Parse(location)
+	if err != nil {
+		return nil, err
+	}
+	message := u.Query().Get("SAMLResponse")
+	if message == "" {
+		return nil, nil
+	}
+	decoded, err := base64.StdEncoding.DecodeString(message)
+	if err != nil {
+		return nil, err
+	}
+	return saml_xml.DecodeResponse(saml_xml.EncodingDeflate, string(decoded))
+}
+
+func responseAudience(response *samlp.ResponseType) string {
+	if response.Assertion.Conditions == nil {
+		return ""
+	}
+	for _, restriction := range response.Assertion.Conditions.AudienceRestriction {
+		for _, audience := range restriction.Audience {
+			if audience != "" {
+				return audience
+			}
+		}
+	}
+	return ""
+}
+
+// encryptionCertificate returns the first certificate of the SP metadata usable for encryption
+// and the preferred data encryption method of the SP supported by ZITADEL
+func encryptionCertificate(metadata []byte) (*x509.Certificate, string, error) {
+	entity, err := saml_xml.ParseMetadataXmlIntoStruct(metadata)
+	if err != nil {
+		return nil, "", err
+	}
+	if entity.SPSSODescriptor == nil {
+		return nil, "", errors.ThrowPreconditionFailed(nil, "SAML-Hw8ne", "Errors.Project.App.SAMLEncryptionCertificateMissing")
+	}
+	for _, keyDescriptor := range entity.SPSSODescriptor.KeyDescriptor {
+		if keyDescriptor.Use != "" && keyDescriptor.Use != md.KeyTypesEncryption {
+			continue
+		}
+		for _, x509Data := range keyDescriptor.KeyInfo.X509Data {
+			if x509Data.X509Certificate == "" {
+				continue
+			}
+			certificates, err := signature.ParseCertificates([]string{x509Data.X509Certificate})
+			if err != nil {
+				return nil, "", err
+			}
+			if _, ok := certificates[0].PublicKey.(*rsa.PublicKey); !ok {
+				continue
+			}
+			return certificates[0], dataEncryptionMethod(keyDescriptor.EncryptionMethod), nil
+		}
+	}
+	return nil, "", errors.ThrowPreconditionFailed(nil, "SAML-Hw8ne", "Errors.Project.App.SAMLEncryptionCertificateMissing")
+}
+
+// dataEncryptionMethod returns the first supported method listed by the SP
+// or AES-256-GCM if the SP doesn't list any
+func dataEncryptionMethod(methods []xenc.EncryptionMethodType) string {
+	for _, method := range methods {
+		if _, ok := dataEncryptionKeySizes[method.Algorithm]; ok {
+			return method.Algorithm
+		}
+	}
+	return encryptionMethodAES256GCM
+}
+
+// replaceAssertion replaces the assertion element of the (marshalled) response
+// with the element returned by replace
+func replaceAssertion(response []byte, replace func(assertion []byte) ([]byte, error)) ([]byte, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(response))
+	depth := 0
+	for {
+		offset := decoder.InputOffset()
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return nil, errors.ThrowInternal(nil, "SAML-Zp3vk", "assertion not found in response")
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch element := token.(type) {
+		case xml.StartElement:
+			if depth != 1 || element.Name.Space != assertionNamespace || element.Name.Local != "Assertion" {
+				depth++
+				continue
+			}
+			if err = decoder.Skip(); err != nil {
+				return nil, err
+			}
+			replacement, err := replace(response[offset:decoder.InputOffset()])
+			if err != nil {
+				return nil, err
+			}
+			replaced := make([]byte, 0, len(response)+len(replacement))
+			replaced = append(replaced, response[:offset]...)
+			replaced = append(replaced, replacement...)
+			return append(replaced, response[decoder.InputOffset():]...), nil
+		case xml.EndElement:
+			depth--
+		}
+	}
+}
+
+type encryptedAssertion struct {
+	XMLName       xml.Name      `xml:"urn:oasis:names:tc:SAML:2.0:assertion EncryptedAssertion"`
+	EncryptedData encryptedData `xml:"http://www.w3.org/2001/04/xmlenc# EncryptedData"`
+}
+
+type encryptedData struct {
+	Type             string           `xml:"Type,attr"`
+	EncryptionMethod encryptionMethod `xml:"http://www.w3.org/2001/04/xmlenc# EncryptionMethod"`
+	KeyInfo          struct {
+		EncryptedKey encryptedKey `xml:"http://www.w3.org/2001/04/xmlenc# EncryptedKey"`
+	} `xml:"http://www.w3.org/2000/09/xmldsig# KeyInfo"`
+	CipherValue string `xml:"http://www.w3.org/2001/04/xmlenc# CipherData>CipherValue"`
+}
+
+type encryptedKey struct {
+	EncryptionMethod encryptionMethod `xml:"http://www.w3.org/2001/04/xmlenc# EncryptionMethod"`
+	KeyInfo          struct {
+		X509Certificate string `xml:"http://www.w3.org/2000/09/xmldsig# X509Data>X509Certificate"`
+	} `xml:"http://www.w3.org/2000/09/xmldsig# KeyInfo"`
+	CipherValue string `xml:"http://www.w3.org/2001/04/xmlenc# CipherData>CipherValue"`
+}
+
+type encryptionMethod struct {
+	Algorithm    string        `xml:"Algorithm,attr"`
+	DigestMethod *digestMethod `xml:"http://www.w3.org/2000/09/xmldsig# DigestMethod,omitempty"`
+}
+
+type digestMethod struct {
+	Algorithm string `xml:"Algorithm,attr"`
+}
+
+// encryptAssertion encrypts the assertion element with a random key using the data encryption method
+// and adds the key encrypted (RSA-OAEP) for the certificate of the SP
+func encryptAssertion(assertion []byte, certificate *x509.Certificate, method string) ([]byte, error) {
+	key := make([]byte, dataEncryptionKeySizes[method])
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	cipherValue, err := encryptData(assertion, key, method)
+	if err != nil {
+		return nil, err
+	}
+	encryptedKeyValue, err := rsa.EncryptOAEP(sha1.New(), rand.Reader, certificate.PublicKey.(*rsa.PublicKey), key, nil)
+	if err != nil {
+		return nil, err
+	}
+	encrypted := encryptedAssertion{
+		EncryptedData: encryptedData{
+			Type:             encryptionTypeElement,
+			EncryptionMethod: encryptionMethod{Algorithm: method},
+			CipherValue:      base64.StdEncoding.EncodeToString(cipherValue),
+		},
+	}
+	encrypted.EncryptedData.KeyInfo.EncryptedKey = encryptedKey{
+		EncryptionMethod: encryptionMethod{
+			Algorithm:    keyTransportRSAOAEP,
+			DigestMethod: &digestMethod{Algorithm: digestMethodSHA1},
+		},
+		CipherValue: base64.StdEncoding.EncodeToString(encryptedKeyValue),
+	}
+	encrypted.EncryptedData.KeyInfo.EncryptedKey.KeyInfo.X509Certificate = base64.StdEncoding.EncodeToString(certificate.Raw)
+	return xml.Marshal(encrypted)
+}
+
+// encryptData returns the cipher value (iv prepended to the ciphertext) as specified by XML Encryption
+func encryptData(data, key []byte, method string) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	switch method {
+	case encryptionMethodAES128GCM, encryptionMethodAES256GCM:
+		gcm, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, err
+		}
+		nonce := make([]byte, gcm.NonceSize())
+		if _, err = rand.Read(nonce); err != nil {
+			return nil, err
+		}
+		return gcm.Seal(nonce, nonce, data, nil), nil
+	default:
+		padding := aes.BlockSize - len(data)%aes.BlockSize
+		padded := append(data[:len(data):len(data)], bytes.Repeat([]byte{byte(padding)}, padding)...)
+		cipherValue := make([]byte, aes.BlockSize+len(padded))
+		if _, err = rand.Read(cipherValue[:aes.BlockSize]); err != nil {
+			return nil, err
+		}
+		cipher.NewCBCEncrypter(block, cipherValue[:aes.BlockSize]).CryptBlocks(cipherValue[aes.BlockSize:], padded)
+		return cipherValue, nil
+	}
+}
@@ -0,0 +1,513 @@
+package saml
+
+import (
+	"bytes"
+	"compress/flate"
+	"context"
+	"encoding/base64"
+	"encoding/xml"
+	"html/template"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/zitadel/logging"
+	"github.com/zitadel/saml/pkg/provider"
+	"github.com/zitadel/saml/pkg/provider/serviceprovider"
+	"github.com/zitadel/saml/pkg/provider/signature"
+	saml_xml "github.com/zitadel/saml/pkg/provider/xml"
+	"github.com/zitadel/saml/pkg/provider/xml/md"
+	"github.com/zitadel/saml/pkg/provider/xml/saml"
+	"github.com/zitadel/saml/pkg/provider/xml/samlp"
+
+	"github.com/zitadel/zitadel/internal/api/http/middleware"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	samlRequestParam  = "SAMLRequest"
+	samlResponseParam = "SAMLResponse"
+	relayStateParam   = "RelayState"
+	sigAlgParam       = "SigAlg"
+	signatureParam    = "Signature"
+
+	nameIDFormatEntity      = "urn:oasis:names:tc:SAML:2.0:nameid-format:entity"
+	nameIDFormatUnspecified = "urn:oasis:names:tc:SAML:1.1:nameid-format:unspecified"
+	logoutRequestLifetime   = 5 * time.Minute
+	samlTimeFormat          = "2006-01-02T15:04:05.999Z"
+)
+
+var logoutPostTemplate = template.Must(template.New("logoutPost").Parse(`<!DOCTYPE html>
+<html>
+<head>
+	<meta charset="utf-8">
+	<title>Logout</title>
+</head>
+<body onload="document.forms[0].submit()">
+	{{range .LogoutURLs}}<iframe src="{{.}}" style="display:none" width="0" height="0"></iframe>
+	{{end}}<form method="post" action="{{.URL}}">
+		<input type="hidden" name="SAMLResponse" value="{{.SAMLResponse}}"/>
+		{{if .RelayState}}<input type="hidden" name="RelayState" value="{{.RelayState}}"/>{{end}}
+		<noscript><input type="submit" value="Continue"/></noscript>
+	</form>
+</body>
+</html>
+`))
+
+var logoutRedirectTemplate = template.Must(template.New("logoutRedirect").Parse(`<!DOCTYPE html>
+<html>
+<head>
+	<meta charset="utf-8">
+	<meta http-equiv="refresh" content="2;url={{.URL}}">
+	<title>Logout</title>
+</head>
+<body>
+	{{range .LogoutURLs}}<iframe src="{{.}}" style="display:none" width="0" height="0"></iframe>
+	{{end}}<a href="{{.URL}}">Continue</a>
+</body>
+</html>
+`))
+
+// singleLogout handles the LogoutRequests of the SPs (SAML apps) sent over the HTTP-Redirect and HTTP-POST binding,
+// it replaces the single logout endpoint of the provider
+type singleLogout struct {
+	storage                *Storage
+	path                   string
+	metadataEndpoint       provider.Endpoint
+	signatureAlgorithm     string
+	wantAuthRequestsSigned bool
+}
+
+type logoutRequestForm struct {
+	binding    string
+	message    []byte
+	request    *samlp.LogoutRequestType
+	relayState string
+}
+
+type logoutResponse struct {
+	destination string
+	binding     string
+	requestID   string
+	relayState  string
+	logoutURLs  []string
+}
+
+func (p *Storage) singleLogoutInterceptor(path string, metadataEndpoint provider.Endpoint, signatureAlgorithm string, wantAuthRequestsSigned bool) func(http.Handler) http.Handler {
+	logout := &singleLogout{
+		storage:                p,
+		path:                   path,
+		metadataEndpoint:       metadataEndpoint,
+		signatureAlgorithm:     signatureAlgorithm,
+		wantAuthRequestsSigned: wantAuthRequestsSigned,
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != logout.path {
+				next.ServeHTTP(w, r)
+				return
+			}
+			logout.handle(w, r)
+		})
+	}
+}
+
+type sentAuthRequestKey struct{}
+
+// responseSentInterceptor remembers the SP session of the user agent for the single logout,
+// after the callback endpoint successfully sent the response of a finished auth request to the SP
+func (p *Storage) responseSentInterceptor(callbackPath string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != callbackPath {
+				next.ServeHTTP(w, r)
+				return
+			}
+			sent := new(domain.AuthRequest)
+			recorder := &statusRecorder{ResponseWriter: w, statusCode: http.StatusOK}
+			next.ServeHTTP(recorder, r.WithContext(context.WithValue(r.Context(), sentAuthRequestKey{}, sent)))
+			if recorder.statusCode >= http.StatusBadRequest || !sent.Done() {
+				return
+			}
+			userAgentID, _ := middleware.UserAgentIDFromCtx(r.Context())
+			err := p.command.HumanSAMLResponseSent(r.Context(), sent.UserOrgID, sent.UserID, userAgentID, sent.ApplicationID)
+			logging.WithFields("authRequestID", sent.ID).OnError(err).Warn("unable to remember saml session for single logout")
+		})
+	}
+}
+
+// setSentAuthRequest passes the auth request read by the callback endpoint to the responseSentInterceptor
+func setSentAuthRequest(ctx context.Context, authRequest *domain.AuthRequest) {
+	if sent, ok := ctx.Value(sentAuthRequestKey{}).(*domain.AuthRequest); ok {
+		*sent = *authRequest
+	}
+}
+
+// statusRecorder only records the status code of the response
+type statusRecorder struct {
+	http.ResponseWriter
+	statusCode int
+}
+
+func (w *statusRecorder) WriteHeader(statusCode int) {
+	w.statusCode = statusCode
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (l *singleLogout) handle(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "failed to parse form", http.StatusBadRequest)
+		return
+	}
+	// LogoutResponses of the SPs the logout was propagated to only need to be acknowledged
+	if r.Form.Get(samlResponseParam) != "" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	form, err := parseLogoutRequest(r)
+	if err != nil {
+		logging.WithError(err).Info("invalid saml logout request")
+		http.Error(w, "failed to decode logout request", http.StatusBadRequest)
+		return
+	}
+	if form.request.Issuer == nil {
+		http.Error(w, "issuer of logout request missing", http.StatusBadRequest)
+		return
+	}
+	sp, err := l.storage.GetEntityByID(r.Context(), form.request.Issuer.Text)
+	if err != nil {
+		http.Error(w, "unknown service provider", http.StatusBadRequest)
+		return
+	}
+	response := &logoutResponse{
+		requestID:  form.request.Id,
+		relayState: form.relayState,
+	}
+	service := singleLogoutService(sp.Metadata, form.binding)
+	if service == nil {
+		http.Error(w, "no single logout service of service provider found", http.StatusBadRequest)
+		return
+	}
+	response.binding = service.Binding
+	response.destination = service.ResponseLocation
+	if response.destination == "" {
+		response.destination = service.Location
+	}
+	if err = l.validateLogoutRequest(r, sp, form); err != nil {
+		logging.WithFields("entityID", sp.GetEntityID()).WithError(err).Info("saml logout request denied")
+		l.sendLogoutResponse(w, r, response, provider.StatusCodeRequestDenied, err.Error())
+		return
+	}
+	response.logoutURLs, err = l.logout(r.Context(), sp.ID)
+	if err != nil {
+		logging.WithFields("entityID", sp.GetEntityID()).WithError(err).Warn("saml logout failed")
+		l.sendLogoutResponse(w, r, response, provider.StatusCodeResponder, "failed to terminate sessions")
+		return
+	}
+	l.sendLogoutResponse(w, r, response, provider.StatusCodeSuccess, "")
+}
+
+func parseLogoutRequest(r *http.Request) (*logoutRequestForm, error) {
+	form := &logoutRequestForm{
+		binding:    provider.PostBinding,
+		relayState: r.Form.Get(relayStateParam),
+	}
+	if r.Method == http.MethodGet {
+		form.binding = provider.RedirectBinding
+	}
+	message, err := base64.StdEncoding.DecodeString(r.Form.Get(samlRequestParam))
+	if err != nil {
+		return nil, err
+	}
+	if len(message) == 0 {
+		return nil, errors.ThrowInvalidArgument(nil, "SAML-Nf3ks", "logout request missing")
+	}
+	form.message = message
+	if form.binding == provider.RedirectBinding {
+		var inflated bytes.Buffer
+		if _, err = inflated.ReadFrom(flate.NewReader(bytes.NewReader(message))); err != nil {
+			return nil, err
+		}
+		form.message = inflated.Bytes()
+	}
+	form.request = new(samlp.LogoutRequestType)
+	if err = xml.Unmarshal(form.message, form.request); err != nil {
+		return nil, err
+	}
+	return form, nil
+}
+
+// validateLogoutRequest checks the validity period and the signature of the request.
+// The request must be signed if the SP provides a signing certificate in its metadata or the IdP requires signed requests,
+// otherwise any site could log out the user agent (logout CSRF).
+func (l *singleLogout) validateLogoutRequest(r *http.Request, sp *serviceprovider.ServiceProvider, form *logoutRequestForm) error {
+	if form.request.NotOnOrAfter != "" {
+		notOnOrAfter, err := time.Parse(time.RFC3339, form.request.NotOnOrAfter)
+		if err != nil {
+			return errors.ThrowInvalidArgument(err, "SAML-Lq9ds", "invalid NotOnOrAfter")
+		}
+		if !time.Now().Before(notOnOrAfter) {
+			return errors.ThrowInvalidArgument(nil, "SAML-Hs7bq", "logout request expired")
+		}
+	}
+	signatureRequired := l.wantAuthRequestsSigned || hasSigningCertificate(sp.Metadata)
+	if form.binding == provider.RedirectBinding {
+		if r.Form.Get(signatureParam) == "" {
+			if signatureRequired {
+				return errors.ThrowInvalidArgument(nil, "SAML-Tb2ma", "signature missing")
+			}
+			return nil
+		}
+		return sp.ValidateRedirectSignature(r.Form.Get(samlRequestParam), form.relayState, r.Form.Get(sigAlgParam), r.Form.Get(signatureParam))
+	}
+	if form.request.Signature == nil {
+		if signatureRequired {
+			return errors.ThrowInvalidArgument(nil, "SAML-Pw4na", "signature missing")
+		}
+		return nil
+	}
+	return sp.ValidatePostSignature(string(form.message))
+}
+
+func hasSigningCertificate(metadata *md.EntityDescriptorType) bool {
+	return metadata != nil && metadata.SPSSODescriptor != nil &&
+		len(saml_xml.GetCertsFromKeyDescriptors(metadata.SPSSODescriptor.KeyDescriptor)) > 0
+}
+
+// logout terminates the sessions of the user agent and returns the logout urls
+// of the other SPs the user agent has sessions with
+func (l *singleLogout) logout(ctx context.Context, requestingAppID string) ([]string, error) {
+	userAgentID, ok := middleware.UserAgentIDFromCtx(ctx)
+	if !ok {
+		return nil, nil
+	}
+	userIDs, err := l.storage.repo.UserSessionUserIDsByAgentID(ctx, userAgentID)
+	if err != nil || len(userIDs) == 0 {
+		return nil, err
+	}
+	logoutURLs := l.propagationLogoutURLs(ctx, userAgentID, userIDs, requestingAppID)
+	if err = l.storage.command.HumansSignOut(ctx, userAgentID, userIDs); err != nil {
+		return nil, err
+	}
+	return logoutURLs, nil
+}
+
+// propagationLogoutURLs creates a signed LogoutRequest (HTTP-Redirect binding) for every other SP
+// the users of the user agent received a response for since their last sign out
+func (l *singleLogout) propagationLogoutURLs(ctx context.Context, userAgentID string, userIDs []string, requestingAppID string) []string {
+	logoutURLs := make([]string, 0)
+	for _, userID := range userIDs {
+		appIDs, err := l.storage.query.SessionSAMLAppIDs(ctx, userID, userAgentID)
+		if err != nil {
+			logging.WithFields("userID", userID).WithError(err).Warn("unable to get saml sessions for logout")
+			continue
+		}
+		for _, appID := range appIDs {
+			if appID == requestingAppID {
+				continue
+			}
+			logoutURL, err := l.propagationLogoutURL(ctx, userID, appID)
+			if err != nil {
+				logging.WithFields("appID", appID).WithError(err).Warn("unable to create saml logout request")
+				continue
+			}
+			if logoutURL != "" {
+				logoutURLs = append(logoutURLs, logoutURL)
+			}
+		}
+	}
+	return logoutURLs
+}
+
+func (l *singleLogout) propagationLogoutURL(ctx context.Context, userID, appID string) (string, error) {
+	app, err := l.storage.query.AppByID(ctx, appID, false)
+	if err != nil {
+		return "", err
+	}
+	if app.SAMLConfig == nil {
+		return "", nil
+	}
+	metadata, err := saml_xml.ParseMetadataXmlIntoStruct(app.SAMLConfig.Metadata)
+	if err != nil {
+		return "", err
+	}
+	service := singleLogoutService(metadata, provider.RedirectBinding)
+	if service == nil || service.Binding != provider.RedirectBinding {
+		return "", nil
+	}
+	user, err := l.storage.query.GetUserByID(ctx, false, userID, false)
+	if err != nil {
+		return "", err
+	}
+	now := time.Now().UTC()
+	request := &samlp.LogoutRequestType{
+		Id:           provider.NewID(),
+		Version:      "2.0",
+		IssueInstant: now.Format(samlTimeFormat),
+		NotOnOrAfter: now.Add(logoutRequestLifetime).Format(samlTimeFormat),
+		Destination:  service.Location,
+		Issuer:       l.issuer(ctx),
+		NameID: &saml.NameIDType{
+			Format: nameIDFormatUnspecified,
+			Text:   user.PreferredLoginName,
+		},
+	}
+	message, err := xml.Marshal(request)
+	if err != nil {
+		return "", err
+	}
+	query, err := l.redirectQuery(ctx, samlRequestParam, message, "")
+	if err != nil {
+		return "", err
+	}
+	return appendQuery(service.Location, query), nil
+}
+
+func (l *singleLogout) sendLogoutResponse(w http.ResponseWriter, r *http.Request, response *logoutResponse, status, message string) {
+	logoutResponse := &samlp.LogoutResponseType{
+		Id:           provider.NewID(),
+		InResponseTo: response.requestID,
+		Version:      "2.0",
+		IssueInstant: time.Now().UTC().Format(samlTimeFormat),
+		Destination:  response.destination,
+		Issuer:       l.issuer(r.Context()),
+		Status: samlp.StatusType{
+			StatusCode: samlp.StatusCodeType{
+				Value: status,
+			},
+			StatusMessage: message,
+		},
+	}
+	var err error
+	if response.binding == provider.RedirectBinding {
+		err = l.sendRedirectLogoutResponse(w, r, response, logoutResponse)
+	} else {
+		err = l.sendPostLogoutResponse(w, r, response, logoutResponse)
+	}
+	if err != nil {
+		logging.WithError(err).Error("unable to send saml logout response")
+		http.Error(w, "failed to send logout response", http.StatusInternalServerError)
+	}
+}
+
+func (l *singleLogout) sendPostLogoutResponse(w http.ResponseWriter, r *http.Request, response *logoutResponse, logoutResponse *samlp.LogoutResponseType) error {
+	certAndKey, err := l.storage.GetResponseSigningKey(r.Context())
+	if err != nil {
+		return err
+	}
+	signer, err := signature.GetSigner(certAndKey.Certificate, certAndKey.Key, l.signatureAlgorithm)
+	if err != nil {
+		return err
+	}
+	logoutResponse.Signature, err = signature.Create(signer, logoutResponse)
+	if err != nil {
+		return err
+	}
+	message, err := xml.Marshal(logoutResponse)
+	if err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	return logoutPostTemplate.Execute(w, &struct {
+		URL          string
+		SAMLResponse string
+		RelayState   string
+		LogoutURLs   []string
+	}{
+		URL:          response.destination,
+		SAMLResponse: base64.StdEncoding.EncodeToString(append([]byte(xml.Header), message...)),
+		RelayState:   response.relayState,
+		LogoutURLs:   response.logoutURLs,
+	})
+}
+
+func (l *singleLogout) sendRedirectLogoutResponse(w http.ResponseWriter, r *http.Request, response *logoutResponse, logoutResponse *samlp.LogoutResponseType) error {
+	message, err := xml.Marshal(logoutResponse)
+	if err != nil {
+		return err
+	}
+	query, err := l.redirectQuery(r.Context(), samlResponseParam, message, response.relayState)
+	if err != nil {
+		return err
+	}
+	redirectURL := appendQuery(response.destination, query)
+	if len(response.logoutURLs) == 0 {
+		http.Redirect(w, r, redirectURL, http.StatusFound)
+		return nil
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	return logoutRedirectTemplate.Execute(w, &struct {
+		URL        string
+		LogoutURLs []string
+	}{
+		URL:        redirectURL,
+		LogoutURLs: response.logoutURLs,
+	})
+}
+
+// redirectQuery deflates and signs the message as specified by the HTTP-Redirect binding
+func (l *singleLogout) redirectQuery(ctx context.Context, param string, message []byte, relayState string) (string, error) {
+	encoded, err := saml_xml.DeflateAndBase64(message)
+	if err != nil {
+		return "", err
+	}
+	query := param + "=" + url.QueryEscape(string(encoded))
+	if relayState != "" {
+		query += "&" + relayStateParam + "=" + url.QueryEscape(relayState)
+	}
+	query += "&" + sigAlgParam + "=" + url.QueryEscape(l.signatureAlgorithm)
+
+	certAndKey, err := l.storage.GetResponseSigningKey(ctx)
+	if err != nil {
+		return "", err
+	}
+	tlsCert, err := signature.ParseTlsKeyPair(certAndKey.Certificate, certAndKey.Key)
+	if err != nil {
+		return "", err
+	}
+	signingContext, err := signature.GetSigningContext(tlsCert, l.signatureAlgorithm)
+	if err != nil {
+		return "", err
+	}
+	sig, err := signature.CreateRedirect(signingContext, query)
+	if err != nil {
+		return "", err
+	}
+	return query + "&" + signatureParam + "=" + url.QueryEscape(base64.StdEncoding.EncodeToString(sig)), nil
+}
+
+func (l *singleLogout) issuer(ctx context.Context) *saml.NameIDType {
+	return &saml.NameIDType{
+		Format: nameIDFormatEntity,
+		Text:   l.metadataEndpoint.Absolute(provider.IssuerFromContext(ctx)),
+	}
+}
+
+// singleLogoutService returns the single logout service of the SP, preferring the requested binding
+func singleLogoutService(metadata *md.EntityDescriptorType, binding string) *md.EndpointType {
+	if metadata == nil || metadata.SPSSODescriptor == nil {
+		return nil
+	}
+	var fallback *md.EndpointType
+	for i, service := range metadata.SPSSODescriptor.SingleLogoutService {
+		if service.Binding != provider.RedirectBinding && service.Binding != provider.PostBinding {
+			continue
+		}
+		if service.Binding == binding {
+			return &metadata.SPSSODescriptor.SingleLogoutService[i]
+		}
+		if fallback == nil {
+			fallback = &metadata.SPSSODescriptor.SingleLogoutService[i]
+		}
+	}
+	return fallback
+}
+
+func appendQuery(location, query string) string {
+	if strings.Contains(location, "?") {
+		return location + "&" + query
+	}
+	return location + "?" + query
+}
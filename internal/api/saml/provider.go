@@ -65,6 +65,14 @@ func NewProvider(
 			userAgentCookie,
 			accessHandler,
 			http_utils.CopyHeadersToContext,
+			provStorage.responseSentInterceptor(callbackEndpoint(conf.ProviderConfig).Relative()),
+			provStorage.assertionEncryptionInterceptor(callbackEndpoint(conf.ProviderConfig).Relative()),
+			provStorage.singleLogoutInterceptor(
+				singleLogoutEndpoint(conf.ProviderConfig).Relative(),
+				metadataEndpoint(conf.ProviderConfig),
+				conf.ProviderConfig.IDPConfig.SignatureAlgorithm,
+				conf.ProviderConfig.IDPConfig.WantAuthRequestsSigned == "true",
+			),
 		),
 		provider.WithCustomTimeFormat("2006-01-02T15:04:05.999Z"),
 	}
@@ -80,6 +88,27 @@ func NewProvider(
 	)
 }
 
+func metadataEndpoint(conf *provider.Config) provider.Endpoint {
+	if conf.Metadata != nil {
+		return *conf.Metadata
+	}
+	return provider.NewEndpoint(provider.DefaultMetadataEndpoint)
+}
+
+func callbackEndpoint(conf *provider.Config) provider.Endpoint {
+	if conf.IDPConfig.Endpoints != nil && conf.IDPConfig.Endpoints.Callback != nil {
+		return *conf.IDPConfig.Endpoints.Callback
+	}
+	return provider.NewEndpoint(provider.DefaultCallbackEndpoint)
+}
+
+func singleLogoutEndpoint(conf *provider.Config) provider.Endpoint {
+	if conf.IDPConfig.Endpoints != nil && conf.IDPConfig.Endpoints.SingleLogOut != nil {
+		return *conf.IDPConfig.Endpoints.SingleLogOut
+	}
+	return provider.NewEndpoint(provider.DefaultSingleLogOutEndpoint)
+}
+
 func newStorage(
 	command *command.Commands,
 	query *query.Queries,
@@ -115,6 +115,7 @@ func (p *Storage) AuthRequestByID(ctx context.Context, id string) (_ models.Auth
 	if err != nil {
 		return nil, err
 	}
+	setSentAuthRequest(ctx, resp)
 	return AuthRequestFromBusiness(resp)
 }
 
@@ -0,0 +1,98 @@
+package login
+
+import (
+	"net/http"
+	"net/url"
+
+	http_mw "github.com/zitadel/zitadel/internal/api/http/middleware"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	tmplConsent = "consent"
+)
+
+type consentData struct {
+	userData
+	Scopes []string
+	Roles  []string
+}
+
+type consentFormData struct {
+	Deny bool `schema:"deny"`
+}
+
+func (l *Login) renderConsent(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, step *domain.ConsentStep, err error) {
+	var errID, errMessage string
+	if err != nil {
+		errID, errMessage = l.getErrorMessage(r, err)
+	}
+	data := consentData{
+		userData: l.getUserData(r, authReq, "Consent.Title", "Consent.Description", errID, errMessage),
+		Scopes:   step.Scopes,
+		Roles:    step.Roles,
+	}
+	l.renderer.RenderTemplate(w, r, l.getTranslator(r.Context(), authReq), l.renderer.Templates[tmplConsent], data, nil)
+}
+
+func (l *Login) handleConsent(w http.ResponseWriter, r *http.Request) {
+	data := new(consentFormData)
+	authReq, err := l.getAuthRequestAndParseData(r, data)
+	if err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	if data.Deny {
+		l.denyConsent(w, r, authReq)
+		return
+	}
+	userAgentID, _ := http_mw.UserAgentIDFromCtx(r.Context())
+	err = l.authRepo.GrantUserConsent(setContext(r.Context(), authReq.UserOrgID), authReq.ID, authReq.UserID, userAgentID)
+	if err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	l.renderNextStep(w, r, authReq)
+}
+
+// denyConsent terminates the auth request and returns an access_denied error to the client
+func (l *Login) denyConsent(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest) {
+	callback, err := consentDeniedCallback(authReq)
+	if err != nil {
+		l.renderInternalError(w, r, authReq, err)
+		return
+	}
+	if err = l.authRepo.DeleteAuthRequest(r.Context(), authReq.ID); err != nil {
+		l.renderInternalError(w, r, authReq, err)
+		return
+	}
+	http.Redirect(w, r, callback, http.StatusFound)
+}
+
+func consentDeniedCallback(authReq *domain.AuthRequest) (string, error) {
+	oidcRequest, ok := authReq.Request.(*domain.AuthRequestOIDC)
+	if !ok {
+		return "", caos_errs.ThrowInternal(nil, "LOGIN-Pe4wq", "Errors.AuthRequest.RequestTypeNotSupported")
+	}
+	callback, err := url.Parse(authReq.CallbackURI)
+	if err != nil {
+		return "", caos_errs.ThrowInternal(err, "LOGIN-Ul7cz", "Errors.Internal")
+	}
+	params := url.Values{}
+	params.Set("error", "access_denied")
+	params.Set("error_description", "the user denied the consent")
+	if authReq.TransferState != "" {
+		params.Set("state", authReq.TransferState)
+	}
+	if oidcRequest.ResponseType != domain.OIDCResponseTypeCode {
+		callback.Fragment = params.Encode()
+		return callback.String(), nil
+	}
+	query := callback.Query()
+	for key, values := range params {
+		query[key] = values
+	}
+	callback.RawQuery = query.Encode()
+	return callback.String(), nil
+}
@@ -150,6 +150,9 @@ const (
 	authMethodPassword     authMethod = "password"
 	authMethodOTP          authMethod = "OTP"
 	authMethodU2F          authMethod = "U2F"
+	authMethodOTPSMS       authMethod = "OTP SMS"
+	authMethodOTPEmail     authMethod = "OTP Email"
+	authMethodRecoveryCode authMethod = "recovery code"
 	authMethodPasswordless authMethod = "passwordless"
 )
 
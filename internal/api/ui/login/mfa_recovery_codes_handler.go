@@ -0,0 +1,62 @@
+package login
+
+import (
+	"net/http"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	tmplMFARecoveryCodes = "mfarecoverycodes"
+)
+
+type mfaRecoveryCodesData struct {
+	baseData
+	profileData
+	Codes []string
+}
+
+func (l *Login) handleMFARecoveryCodes(w http.ResponseWriter, r *http.Request) {
+	authReq, err := l.getAuthRequestAndParseData(r, new(struct{}))
+	if err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	// the codes replace the second factor, so the user must already be fully authenticated
+	if !recoveryCodesGenerationAllowed(authReq) {
+		l.renderError(w, r, authReq, errors.ThrowPreconditionFailed(nil, "LOGIN-Bq7wz", "Errors.User.MFA.RecoveryCode.NotAuthenticated"))
+		return
+	}
+	codes, _, err := l.command.GenerateHumanRecoveryCodes(setContext(r.Context(), authReq.UserOrgID), authReq.UserID, authReq.UserOrgID)
+	if err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	l.renderMFARecoveryCodes(w, r, authReq, codes)
+}
+
+func (l *Login) renderMFARecoveryCodes(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, codes []string) {
+	translator := l.getTranslator(r.Context(), authReq)
+	data := &mfaRecoveryCodesData{
+		baseData:    l.getBaseData(r, authReq, "MFARecoveryCodes.Title", "MFARecoveryCodes.Description", "", ""),
+		profileData: l.getProfileData(authReq),
+		Codes:       codes,
+	}
+	l.renderer.RenderTemplate(w, r, translator, l.renderer.Templates[tmplMFARecoveryCodes], data, nil)
+}
+
+func recoveryCodesGenerationAllowed(authReq *domain.AuthRequest) bool {
+	if authReq == nil || authReq.UserID == "" || len(authReq.MFAsVerified) == 0 {
+		return false
+	}
+	if authReq.PasswordVerified {
+		return true
+	}
+	for _, mfaType := range authReq.MFAsVerified {
+		if mfaType == domain.MFATypeU2FUserVerification {
+			return true
+		}
+	}
+	return false
+}
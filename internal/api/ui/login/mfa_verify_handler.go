@@ -8,7 +8,9 @@ import (
 )
 
 const (
-	tmplMFAVerify = "mfaverify"
+	tmplMFAVerify             = "mfaverify"
+	tmplMFAVerifyOTPCode      = "mfaverifyotpcode"
+	tmplMFAVerifyRecoveryCode = "mfaverifyrecoverycode"
 )
 
 type mfaVerifyFormData struct {
@@ -33,21 +35,36 @@ func (l *Login) handleMFAVerify(w http.ResponseWriter, r *http.Request) {
 		l.renderMFAVerifySelected(w, r, authReq, step, data.SelectedProvider, nil)
 		return
 	}
-	if data.MFAType == domain.MFATypeOTP {
-		userAgentID, _ := http_mw.UserAgentIDFromCtx(r.Context())
+	var method authMethod
+	userAgentID, _ := http_mw.UserAgentIDFromCtx(r.Context())
+	switch data.MFAType {
+	case domain.MFATypeOTP:
+		method = authMethodOTP
 		err = l.authRepo.VerifyMFAOTP(setContext(r.Context(), authReq.UserOrgID), authReq.ID, authReq.UserID, authReq.UserOrgID, data.Code, userAgentID, domain.BrowserInfoFromRequest(r))
+	case domain.MFATypeOTPSMS:
+		method = authMethodOTPSMS
+		err = l.authRepo.VerifyMFAOTPSMS(setContext(r.Context(), authReq.UserOrgID), authReq.ID, authReq.UserID, authReq.UserOrgID, data.Code, userAgentID, domain.BrowserInfoFromRequest(r))
+	case domain.MFATypeOTPEmail:
+		method = authMethodOTPEmail
+		err = l.authRepo.VerifyMFAOTPEmail(setContext(r.Context(), authReq.UserOrgID), authReq.ID, authReq.UserID, authReq.UserOrgID, data.Code, userAgentID, domain.BrowserInfoFromRequest(r))
+	case domain.MFATypeRecoveryCode:
+		method = authMethodRecoveryCode
+		err = l.authRepo.VerifyMFARecoveryCode(setContext(r.Context(), authReq.UserOrgID), authReq.ID, authReq.UserID, authReq.UserOrgID, data.Code, userAgentID, domain.BrowserInfoFromRequest(r))
+	default:
+		l.renderNextStep(w, r, authReq)
+		return
+	}
 
-		metadata, actionErr := l.runPostInternalAuthenticationActions(authReq, r, authMethodOTP, err)
-		if err == nil && actionErr == nil && len(metadata) > 0 {
-			_, err = l.command.BulkSetUserMetadata(r.Context(), authReq.UserID, authReq.UserOrgID, metadata...)
-		} else if actionErr != nil && err == nil {
-			err = actionErr
-		}
+	metadata, actionErr := l.runPostInternalAuthenticationActions(authReq, r, method, err)
+	if err == nil && actionErr == nil && len(metadata) > 0 {
+		_, err = l.command.BulkSetUserMetadata(r.Context(), authReq.UserID, authReq.UserOrgID, metadata...)
+	} else if actionErr != nil && err == nil {
+		err = actionErr
+	}
 
-		if err != nil {
-			l.renderMFAVerifySelected(w, r, authReq, step, domain.MFATypeOTP, err)
-			return
-		}
+	if err != nil {
+		l.renderMFAVerifySelected(w, r, authReq, step, data.MFAType, err)
+		return
 	}
 	l.renderNextStep(w, r, authReq)
 }
@@ -62,6 +79,10 @@ func (l *Login) renderMFAVerify(w http.ResponseWriter, r *http.Request, authReq
 }
 
 func (l *Login) renderMFAVerifySelected(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, verificationStep *domain.MFAVerificationStep, selectedProvider domain.MFAType, err error) {
+	if err == nil && authReq != nil {
+		// a new code is only sent if the page is not rendered because of a failed verification
+		err = l.sendMFAOTPCode(r, authReq, selectedProvider)
+	}
 	var errID, errMessage string
 	if err != nil {
 		errID, errMessage = l.getErrorMessage(r, err)
@@ -84,6 +105,27 @@ func (l *Login) renderMFAVerifySelected(w http.ResponseWriter, r *http.Request,
 		data.SelectedMFAProvider = domain.MFATypeOTP
 		data.Title = translator.LocalizeWithoutArgs("VerifyMFAOTP.Title")
 		data.Description = translator.LocalizeWithoutArgs("VerifyMFAOTP.Description")
+	case domain.MFATypeOTPSMS:
+		data.MFAProviders = removeSelectedProviderFromList(verificationStep.MFAProviders, domain.MFATypeOTPSMS)
+		data.SelectedMFAProvider = domain.MFATypeOTPSMS
+		data.Title = translator.LocalizeWithoutArgs("VerifyMFAOTPSMS.Title")
+		data.Description = translator.LocalizeWithoutArgs("VerifyMFAOTPSMS.Description")
+		l.renderer.RenderTemplate(w, r, translator, l.renderer.Templates[tmplMFAVerifyOTPCode], data, nil)
+		return
+	case domain.MFATypeOTPEmail:
+		data.MFAProviders = removeSelectedProviderFromList(verificationStep.MFAProviders, domain.MFATypeOTPEmail)
+		data.SelectedMFAProvider = domain.MFATypeOTPEmail
+		data.Title = translator.LocalizeWithoutArgs("VerifyMFAOTPEmail.Title")
+		data.Description = translator.LocalizeWithoutArgs("VerifyMFAOTPEmail.Description")
+		l.renderer.RenderTemplate(w, r, translator, l.renderer.Templates[tmplMFAVerifyOTPCode], data, nil)
+		return
+	case domain.MFATypeRecoveryCode:
+		data.MFAProviders = removeSelectedProviderFromList(verificationStep.MFAProviders, domain.MFATypeRecoveryCode)
+		data.SelectedMFAProvider = domain.MFATypeRecoveryCode
+		data.Title = translator.LocalizeWithoutArgs("VerifyMFARecoveryCode.Title")
+		data.Description = translator.LocalizeWithoutArgs("VerifyMFARecoveryCode.Description")
+		l.renderer.RenderTemplate(w, r, translator, l.renderer.Templates[tmplMFAVerifyRecoveryCode], data, nil)
+		return
 	default:
 		l.renderError(w, r, authReq, err)
 		return
@@ -91,6 +133,18 @@ func (l *Login) renderMFAVerifySelected(w http.ResponseWriter, r *http.Request,
 	l.renderer.RenderTemplate(w, r, translator, l.renderer.Templates[tmplMFAVerify], data, nil)
 }
 
+func (l *Login) sendMFAOTPCode(r *http.Request, authReq *domain.AuthRequest, provider domain.MFAType) error {
+	userAgentID, _ := http_mw.UserAgentIDFromCtx(r.Context())
+	switch provider {
+	case domain.MFATypeOTPSMS:
+		return l.authRepo.SendMFAOTPSMS(setContext(r.Context(), authReq.UserOrgID), authReq.ID, authReq.UserID, authReq.UserOrgID, userAgentID, domain.BrowserInfoFromRequest(r))
+	case domain.MFATypeOTPEmail:
+		return l.authRepo.SendMFAOTPEmail(setContext(r.Context(), authReq.UserOrgID), authReq.ID, authReq.UserID, authReq.UserOrgID, userAgentID, domain.BrowserInfoFromRequest(r))
+	default:
+		return nil
+	}
+}
+
 func removeSelectedProviderFromList(providers []domain.MFAType, selected domain.MFAType) []domain.MFAType {
 	for i := len(providers) - 1; i >= 0; i-- {
 		if providers[i] == selected {
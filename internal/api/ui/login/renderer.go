@@ -55,6 +55,9 @@ func CreateRenderer(pathPrefix string, staticDir http.FileSystem, staticStorage
 		tmplPasswordlessRegistrationDone: "passwordless_registration_done.html",
 		tmplPasswordlessPrompt:           "passwordless_prompt.html",
 		tmplMFAVerify:                    "mfa_verify_otp.html",
+		tmplMFAVerifyOTPCode:             "mfa_verify_otp_code.html",
+		tmplMFAVerifyRecoveryCode:        "mfa_verify_recovery_code.html",
+		tmplMFARecoveryCodes:             "mfa_recovery_codes.html",
 		tmplMFAPrompt:                    "mfa_prompt.html",
 		tmplMFAInitVerify:                "mfa_init_otp.html",
 		tmplMFAU2FInit:                   "mfa_init_u2f.html",
@@ -75,6 +78,8 @@ func CreateRenderer(pathPrefix string, staticDir http.FileSystem, staticStorage
 		tmplRegisterOrg:                  "register_org.html",
 		tmplChangeUsername:               "change_username.html",
 		tmplChangeUsernameDone:           "change_username_done.html",
+		tmplConsent:                      "consent.html",
+		tmplSessions:                     "sessions.html",
 		tmplLinkUsersDone:                "link_users_done.html",
 		tmplExternalNotFoundOption:       "external_not_found_option.html",
 		tmplLoginSuccess:                 "login_success.html",
@@ -176,6 +181,15 @@ func CreateRenderer(pathPrefix string, staticDir http.FileSystem, staticStorage
 		"mfaInitU2FLoginUrl": func() string {
 			return path.Join(r.pathPrefix, EndpointU2FVerification)
 		},
+		"mfaRecoveryCodesUrl": func() string {
+			return path.Join(r.pathPrefix, EndpointMFARecoveryCodes)
+		},
+		"consentUrl": func() string {
+			return path.Join(r.pathPrefix, EndpointConsent)
+		},
+		"sessionsUrl": func() string {
+			return path.Join(r.pathPrefix, EndpointSessions)
+		},
 		"mailVerificationUrl": func() string {
 			return path.Join(r.pathPrefix, EndpointMailVerification)
 		},
@@ -319,6 +333,8 @@ func (l *Login) chooseNextStep(w http.ResponseWriter, r *http.Request, authReq *
 		l.renderInternalError(w, r, authReq, caos_errs.ThrowPreconditionFailed(nil, "APP-asb43", "Errors.User.GrantRequired"))
 	case *domain.ProjectRequiredStep:
 		l.renderInternalError(w, r, authReq, caos_errs.ThrowPreconditionFailed(nil, "APP-m92d", "Errors.User.ProjectRequired"))
+	case *domain.ConsentStep:
+		l.renderConsent(w, r, authReq, step, err)
 	default:
 		l.renderInternalError(w, r, authReq, caos_errs.ThrowInternal(nil, "APP-ds3QF", "step no possible"))
 	}
@@ -32,6 +32,7 @@ const (
 	EndpointMFAPrompt                = "/mfa/prompt"
 	EndpointMFAInitVerify            = "/mfa/init/verify"
 	EndpointMFAInitU2FVerify         = "/mfa/init/u2f/verify"
+	EndpointMFARecoveryCodes         = "/mfa/recoverycodes"
 	EndpointU2FVerification          = "/mfa/u2f/verify"
 	EndpointMailVerification         = "/mail/verification"
 	EndpointMailVerified             = "/mail/verified"
@@ -42,6 +43,8 @@ const (
 	EndpointRegisterOrg              = "/register/org"
 	EndpointLogoutDone               = "/logout/done"
 	EndpointLoginSuccess             = "/login/success"
+	EndpointConsent                  = "/consent"
+	EndpointSessions                 = "/sessions"
 	EndpointExternalNotFoundOption   = "/externaluser/option"
 
 	EndpointResources        = "/resources"
@@ -90,6 +93,7 @@ func CreateRouter(login *Login, staticDir http.FileSystem, interceptors ...mux.M
 	router.HandleFunc(EndpointMFAPrompt, login.handleMFAPrompt).Methods(http.MethodPost)
 	router.HandleFunc(EndpointMFAInitVerify, login.handleMFAInitVerify).Methods(http.MethodPost)
 	router.HandleFunc(EndpointMFAInitU2FVerify, login.handleRegisterU2F).Methods(http.MethodPost)
+	router.HandleFunc(EndpointMFARecoveryCodes, login.handleMFARecoveryCodes).Methods(http.MethodPost)
 	router.HandleFunc(EndpointU2FVerification, login.handleU2FVerification).Methods(http.MethodPost)
 	router.HandleFunc(EndpointMailVerification, login.handleMailVerification).Methods(http.MethodGet)
 	router.HandleFunc(EndpointMailVerification, login.handleMailVerificationCheck).Methods(http.MethodPost)
@@ -107,6 +111,9 @@ func CreateRouter(login *Login, staticDir http.FileSystem, interceptors ...mux.M
 	router.HandleFunc(EndpointRegisterOrg, login.handleRegisterOrg).Methods(http.MethodGet)
 	router.HandleFunc(EndpointRegisterOrg, login.handleRegisterOrgCheck).Methods(http.MethodPost)
 	router.HandleFunc(EndpointLoginSuccess, login.handleLoginSuccess).Methods(http.MethodGet)
+	router.HandleFunc(EndpointConsent, login.handleConsent).Methods(http.MethodPost)
+	router.HandleFunc(EndpointSessions, login.handleSessions).Methods(http.MethodGet)
+	router.HandleFunc(EndpointSessions, login.handleSessionsTerminate).Methods(http.MethodPost)
 	router.HandleFunc(EndpointLDAPLogin, login.handleLDAP).Methods(http.MethodGet)
 	router.HandleFunc(EndpointLDAPCallback, login.handleLDAPCallback).Methods(http.MethodPost)
 	router.SkipClean(true).Handle("", http.RedirectHandler(HandlerPrefix+"/", http.StatusMovedPermanently))
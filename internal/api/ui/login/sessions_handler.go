@@ -0,0 +1,166 @@
+package login
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+
+	http_mw "github.com/zitadel/zitadel/internal/api/http/middleware"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	user_model "github.com/zitadel/zitadel/internal/user/model"
+)
+
+const (
+	tmplSessions = "sessions"
+)
+
+type sessionsData struct {
+	baseData
+	UserID      string
+	DisplayName string
+	LoginName   string
+	Sessions    []*sessionData
+}
+
+type sessionData struct {
+	AgentID      string
+	UserAgent    string
+	RemoteIP     string
+	LastActivity time.Time
+	Factors      []string
+	Current      bool
+}
+
+type sessionsFormData struct {
+	UserID  string `schema:"userID"`
+	AgentID string `schema:"agentID"`
+	Others  bool   `schema:"others"`
+}
+
+func (l *Login) handleSessions(w http.ResponseWriter, r *http.Request) {
+	userID, err := l.sessionsUserID(r, r.URL.Query().Get("userID"))
+	if err != nil {
+		l.renderInternalError(w, r, nil, err)
+		return
+	}
+	l.renderSessions(w, r, userID, nil)
+}
+
+func (l *Login) handleSessionsTerminate(w http.ResponseWriter, r *http.Request) {
+	data := new(sessionsFormData)
+	if err := l.parser.Parse(r, data); err != nil {
+		l.renderInternalError(w, r, nil, err)
+		return
+	}
+	userID, err := l.sessionsUserID(r, data.UserID)
+	if err != nil {
+		l.renderInternalError(w, r, nil, err)
+		return
+	}
+	sessions, err := l.authRepo.ActiveUserSessionsByUserID(r.Context(), userID)
+	if err != nil {
+		l.renderSessions(w, r, userID, err)
+		return
+	}
+	userAgentID, _ := http_mw.UserAgentIDFromCtx(r.Context())
+	agentIDs := make([]string, 0, len(sessions))
+	for _, session := range sessions {
+		if data.Others && session.UserAgentID != userAgentID ||
+			!data.Others && session.UserAgentID == data.AgentID {
+			agentIDs = append(agentIDs, session.UserAgentID)
+		}
+	}
+	if len(agentIDs) > 0 {
+		orgID := sessions[0].ResourceOwner
+		if _, err = l.command.TerminateHumanSessions(setContext(r.Context(), orgID), userID, orgID, agentIDs); err != nil {
+			l.renderSessions(w, r, userID, err)
+			return
+		}
+	}
+	if !data.Others && data.AgentID == userAgentID {
+		http.Redirect(w, r, l.renderer.pathPrefix+EndpointLogoutDone, http.StatusFound)
+		return
+	}
+	http.Redirect(w, r, l.renderer.pathPrefix+EndpointSessions+"?userID="+url.QueryEscape(userID), http.StatusFound)
+}
+
+// sessionsUserID returns the user, whose sessions are managed.
+// The user must be authenticated on the current user agent.
+// If no user is provided, the only user authenticated on the user agent is used.
+func (l *Login) sessionsUserID(r *http.Request, userID string) (string, error) {
+	userAgentID, ok := http_mw.UserAgentIDFromCtx(r.Context())
+	if !ok {
+		return "", caos_errs.ThrowNotFound(nil, "LOGIN-Ds9wk", "Errors.UserSession.NotFound")
+	}
+	userIDs, err := l.authRepo.UserSessionUserIDsByAgentID(r.Context(), userAgentID)
+	if err != nil {
+		return "", err
+	}
+	if userID == "" && len(userIDs) == 1 {
+		return userIDs[0], nil
+	}
+	for _, id := range userIDs {
+		if id == userID {
+			return userID, nil
+		}
+	}
+	return "", caos_errs.ThrowNotFound(nil, "LOGIN-Ke2vq", "Errors.UserSession.NotFound")
+}
+
+func (l *Login) renderSessions(w http.ResponseWriter, r *http.Request, userID string, err error) {
+	var errID, errMessage string
+	if err != nil {
+		errID, errMessage = l.getErrorMessage(r, err)
+	}
+	sessions, err := l.authRepo.ActiveUserSessionsByUserID(r.Context(), userID)
+	if err != nil {
+		l.renderInternalError(w, r, nil, err)
+		return
+	}
+	userAgentID, _ := http_mw.UserAgentIDFromCtx(r.Context())
+	data := sessionsData{
+		baseData: l.getBaseData(r, nil, "Sessions.Title", "Sessions.Description", errID, errMessage),
+		UserID:   userID,
+		Sessions: make([]*sessionData, len(sessions)),
+	}
+	for i, session := range sessions {
+		if session.UserAgentID == userAgentID {
+			data.DisplayName = session.DisplayName
+			data.LoginName = session.LoginName
+		}
+		data.Sessions[i] = &sessionData{
+			AgentID:      session.UserAgentID,
+			UserAgent:    session.UserAgent,
+			RemoteIP:     session.RemoteIP,
+			LastActivity: session.ChangeDate,
+			Factors:      sessionFactorsToI18nKeys(session.Factors()),
+			Current:      session.UserAgentID == userAgentID,
+		}
+	}
+	l.renderer.RenderTemplate(w, r, l.getTranslator(r.Context(), nil), l.renderer.Templates[tmplSessions], data, nil)
+}
+
+func sessionFactorsToI18nKeys(factors []user_model.UserSessionFactor) []string {
+	keys := make([]string, 0, len(factors))
+	for _, factor := range factors {
+		switch factor {
+		case user_model.UserSessionFactorPassword:
+			keys = append(keys, "Sessions.Factors.Password")
+		case user_model.UserSessionFactorPasswordless:
+			keys = append(keys, "Sessions.Factors.Passwordless")
+		case user_model.UserSessionFactorIDP:
+			keys = append(keys, "Sessions.Factors.IDP")
+		case user_model.UserSessionFactorOTP:
+			keys = append(keys, "Sessions.Factors.OTP")
+		case user_model.UserSessionFactorU2F:
+			keys = append(keys, "Sessions.Factors.U2F")
+		case user_model.UserSessionFactorOTPSMS:
+			keys = append(keys, "Sessions.Factors.OTPSMS")
+		case user_model.UserSessionFactorOTPEmail:
+			keys = append(keys, "Sessions.Factors.OTPEmail")
+		case user_model.UserSessionFactorRecoveryCode:
+			keys = append(keys, "Sessions.Factors.RecoveryCode")
+		}
+	}
+	return keys
+}
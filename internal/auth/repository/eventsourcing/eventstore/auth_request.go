@@ -50,6 +50,7 @@ type AuthRequestRepo struct {
 	UserGrantProvider         userGrantProvider
 	ProjectProvider           projectProvider
 	ApplicationProvider       applicationProvider
+	UserConsentProvider       userConsentProvider
 
 	IdGenerator id.Generator
 }
@@ -115,6 +116,10 @@ type applicationProvider interface {
 	AppByOIDCClientID(context.Context, string, bool) (*query.App, error)
 }
 
+type userConsentProvider interface {
+	UserConsentByUserAndClientID(context.Context, bool, string, string) (*query.UserConsent, error)
+}
+
 func (repo *AuthRequestRepo) Health(ctx context.Context) error {
 	return repo.AuthRequests.Health(ctx)
 }
@@ -359,6 +364,64 @@ func (repo *AuthRequestRepo) VerifyMFAOTP(ctx context.Context, authRequestID, us
 	return repo.Command.HumanCheckMFAOTP(ctx, userID, code, resourceOwner, request.WithCurrentInfo(info))
 }
 
+func (repo *AuthRequestRepo) SendMFAOTPSMS(ctx context.Context, authRequestID, userID, resourceOwner, userAgentID string, info *domain.BrowserInfo) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	request, err := repo.getAuthRequestEnsureUser(ctx, authRequestID, userAgentID, userID)
+	if err != nil {
+		return err
+	}
+	return repo.Command.HumanSendOTPSMS(ctx, userID, resourceOwner, request.WithCurrentInfo(info))
+}
+
+func (repo *AuthRequestRepo) VerifyMFAOTPSMS(ctx context.Context, authRequestID, userID, resourceOwner, code, userAgentID string, info *domain.BrowserInfo) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	request, err := repo.getAuthRequestEnsureUser(ctx, authRequestID, userAgentID, userID)
+	if err != nil {
+		return err
+	}
+	policy, err := repo.getLockoutPolicy(ctx, resourceOwner)
+	if err != nil {
+		return err
+	}
+	return repo.Command.HumanCheckOTPSMS(ctx, userID, code, resourceOwner, request.WithCurrentInfo(info), lockoutPolicyToDomain(policy))
+}
+
+func (repo *AuthRequestRepo) SendMFAOTPEmail(ctx context.Context, authRequestID, userID, resourceOwner, userAgentID string, info *domain.BrowserInfo) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	request, err := repo.getAuthRequestEnsureUser(ctx, authRequestID, userAgentID, userID)
+	if err != nil {
+		return err
+	}
+	return repo.Command.HumanSendOTPEmail(ctx, userID, resourceOwner, request.WithCurrentInfo(info))
+}
+
+func (repo *AuthRequestRepo) VerifyMFAOTPEmail(ctx context.Context, authRequestID, userID, resourceOwner, code, userAgentID string, info *domain.BrowserInfo) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	request, err := repo.getAuthRequestEnsureUser(ctx, authRequestID, userAgentID, userID)
+	if err != nil {
+		return err
+	}
+	policy, err := repo.getLockoutPolicy(ctx, resourceOwner)
+	if err != nil {
+		return err
+	}
+	return repo.Command.HumanCheckOTPEmail(ctx, userID, code, resourceOwner, request.WithCurrentInfo(info), lockoutPolicyToDomain(policy))
+}
+
+func (repo *AuthRequestRepo) VerifyMFARecoveryCode(ctx context.Context, authRequestID, userID, resourceOwner, code, userAgentID string, info *domain.BrowserInfo) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	request, err := repo.getAuthRequestEnsureUser(ctx, authRequestID, userAgentID, userID)
+	if err != nil {
+		return err
+	}
+	return repo.Command.HumanCheckRecoveryCode(ctx, userID, code, resourceOwner, request.WithCurrentInfo(info))
+}
+
 func (repo *AuthRequestRepo) BeginMFAU2FLogin(ctx context.Context, userID, resourceOwner, authRequestID, userAgentID string) (login *domain.WebAuthNLogin, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
@@ -453,6 +516,24 @@ func (repo *AuthRequestRepo) LinkExternalUsers(ctx context.Context, authReqID, u
 	return repo.AuthRequests.UpdateAuthRequest(ctx, request)
 }
 
+func (repo *AuthRequestRepo) GrantUserConsent(ctx context.Context, authReqID, userID, userAgentID string) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	request, err := repo.getAuthRequestEnsureUser(ctx, authReqID, userAgentID, userID)
+	if err != nil {
+		return err
+	}
+	app, step, err := requestedConsent(ctx, request, repo.ApplicationProvider, repo.UserGrantProvider)
+	if err != nil {
+		return err
+	}
+	if step == nil {
+		return errors.ThrowPreconditionFailed(nil, "EVENT-Tn4sk", "Errors.AuthRequest.RequestTypeNotSupported")
+	}
+	_, err = repo.Command.GrantUserConsent(ctx, request.UserID, request.UserOrgID, request.ApplicationID, app.ProjectID, step.Scopes, step.Roles)
+	return err
+}
+
 func (repo *AuthRequestRepo) ResetLinkingUsers(ctx context.Context, authReqID, userAgentID string) error {
 	request, err := repo.getAuthRequest(ctx, authReqID, userAgentID)
 	if err != nil {
@@ -987,7 +1068,6 @@ func (repo *AuthRequestRepo) nextSteps(ctx context.Context, request *domain.Auth
 	if request.LinkingUsers != nil && len(request.LinkingUsers) != 0 {
 		return append(steps, &domain.LinkUsersStep{}), nil
 	}
-	//PLANNED: consent step
 
 	missing, err := projectRequired(ctx, request, repo.ProjectProvider)
 	if err != nil {
@@ -1005,6 +1085,14 @@ func (repo *AuthRequestRepo) nextSteps(ctx context.Context, request *domain.Auth
 		return append(steps, &domain.GrantRequiredStep{}), nil
 	}
 
+	consentStep, err := consentRequired(ctx, request, repo.ApplicationProvider, repo.UserGrantProvider, repo.UserConsentProvider)
+	if err != nil {
+		return nil, err
+	}
+	if consentStep != nil {
+		return append(steps, consentStep), nil
+	}
+
 	ok, err = repo.hasSucceededPage(ctx, request, repo.ApplicationProvider)
 	if err != nil {
 		return nil, err
@@ -1083,10 +1171,23 @@ func (repo *AuthRequestRepo) firstFactorChecked(request *domain.AuthRequest, use
 
 func (repo *AuthRequestRepo) mfaChecked(userSession *user_model.UserSessionView, request *domain.AuthRequest, user *user_model.UserView) (domain.NextStep, bool, error) {
 	mfaLevel := request.MFALevel()
+	// a passwordless authentication is phishing resistant and satisfies every requested level
+	if mfaLevel >= domain.MFALevelSecondFactor && checkVerificationTimeMaxAge(userSession.MultiFactorVerification, request.LoginPolicy.MultiFactorCheckLifetime, request) {
+		request.MFAsVerified = append(request.MFAsVerified, userSession.MultiFactorVerificationType)
+		request.AuthTime = userSession.MultiFactorVerification
+		return nil, true, nil
+	}
+	phishingResistant := request.RequiredLevelOfAssurance() == domain.LevelOfAssurancePhishingResistant
 	allowedProviders, required := user.MFATypesAllowed(mfaLevel, request.LoginPolicy)
+	if phishingResistant {
+		allowedProviders = phishingResistantMFATypes(allowedProviders)
+	}
 	promptRequired := (user.MFAMaxSetUp < mfaLevel) || (len(allowedProviders) == 0 && required)
 	if promptRequired || !repo.mfaSkippedOrSetUp(user, request) {
 		types := user.MFATypesSetupPossible(mfaLevel, request.LoginPolicy)
+		if phishingResistant {
+			types = phishingResistantMFATypes(types)
+		}
 		if promptRequired && len(types) == 0 {
 			return nil, false, errors.ThrowPreconditionFailed(nil, "LOGIN-5Hm8s", "Errors.Login.LoginPolicy.MFA.ForceAndNotConfigured")
 		}
@@ -1107,7 +1208,8 @@ func (repo *AuthRequestRepo) mfaChecked(userSession *user_model.UserSessionView,
 		}
 		fallthrough
 	case domain.MFALevelSecondFactor:
-		if checkVerificationTimeMaxAge(userSession.SecondFactorVerification, request.LoginPolicy.SecondFactorCheckLifetime, request) {
+		if (!phishingResistant || userSession.SecondFactorVerificationType.IsPhishingResistant()) &&
+			checkVerificationTimeMaxAge(userSession.SecondFactorVerification, request.LoginPolicy.SecondFactorCheckLifetime, request) {
 			request.MFAsVerified = append(request.MFAsVerified, userSession.SecondFactorVerificationType)
 			request.AuthTime = userSession.SecondFactorVerification
 			return nil, true, nil
@@ -1125,6 +1227,16 @@ func (repo *AuthRequestRepo) mfaChecked(userSession *user_model.UserSessionView,
 	}, false, nil
 }
 
+func phishingResistantMFATypes(types []domain.MFAType) []domain.MFAType {
+	filtered := make([]domain.MFAType, 0, len(types))
+	for _, mfaType := range types {
+		if mfaType.IsPhishingResistant() {
+			filtered = append(filtered, mfaType)
+		}
+	}
+	return filtered
+}
+
 func (repo *AuthRequestRepo) mfaSkippedOrSetUp(user *user_model.UserView, request *domain.AuthRequest) bool {
 	if user.MFAMaxSetUp > domain.MFALevelNotSetUp {
 		return true
@@ -1340,7 +1452,13 @@ func userSessionByIDs(ctx context.Context, provider userSessionViewProvider, eve
 			user_repo.HumanPasswordlessTokenCheckSucceededType,
 			user_repo.HumanPasswordlessTokenCheckFailedType,
 			user_repo.HumanU2FTokenCheckSucceededType,
-			user_repo.HumanU2FTokenCheckFailedType:
+			user_repo.HumanU2FTokenCheckFailedType,
+			user_repo.HumanMFAOTPSMSCheckSucceededType,
+			user_repo.HumanMFAOTPSMSCheckFailedType,
+			user_repo.HumanMFAOTPEmailCheckSucceededType,
+			user_repo.HumanMFAOTPEmailCheckFailedType,
+			user_repo.HumanMFARecoveryCodeCheckSucceededType,
+			user_repo.HumanMFARecoveryCodeCheckFailedType:
 			eventData, err := user_view_model.UserSessionFromEvent(event)
 			if err != nil {
 				logging.WithFields("traceID", tracing.TraceIDFromCtx(ctx)).WithError(err).Debug("error getting event data")
@@ -1475,6 +1593,54 @@ func userGrantRequired(ctx context.Context, request *domain.AuthRequest, user *u
 	return len(grants) == 0, nil
 }
 
+// requestedConsent returns the scopes and roles the user has to consent to,
+// if the requested application requires consent
+func requestedConsent(ctx context.Context, request *domain.AuthRequest, appProvider applicationProvider, userGrantProvider userGrantProvider) (*query.App, *domain.ConsentStep, error) {
+	oidcRequest, ok := request.Request.(*domain.AuthRequestOIDC)
+	if !ok {
+		return nil, nil, nil
+	}
+	app, err := appProvider.AppByOIDCClientID(ctx, request.ApplicationID, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	if app.OIDCConfig == nil || !app.OIDCConfig.RequireConsent {
+		return app, nil, nil
+	}
+	grants, err := userGrantProvider.UserGrantsByProjectAndUserID(ctx, app.ProjectID, request.UserID)
+	if err != nil {
+		return nil, nil, err
+	}
+	roles := make([]string, 0)
+	for _, grant := range grants {
+		roles = append(roles, grant.Roles...)
+	}
+	return app, &domain.ConsentStep{Scopes: oidcRequest.Scopes, Roles: roles}, nil
+}
+
+func consentRequired(ctx context.Context, request *domain.AuthRequest, appProvider applicationProvider, userGrantProvider userGrantProvider, consentProvider userConsentProvider) (*domain.ConsentStep, error) {
+	_, step, err := requestedConsent(ctx, request, appProvider, userGrantProvider)
+	if err != nil || step == nil {
+		return nil, err
+	}
+	// the projection is triggered, so a consent granted right before is taken into account
+	consent, err := consentProvider.UserConsentByUserAndClientID(ctx, true, request.UserID, request.ApplicationID)
+	if errors.IsNotFound(err) {
+		return step, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	// prompt=consent requires a consent given during this auth request
+	if domain.IsPrompt(request.Prompt, domain.PromptConsent) && consent.ChangeDate.Before(request.CreationDate) {
+		return step, nil
+	}
+	if !domain.ConsentCovers(consent.Scopes, step.Scopes) || !domain.ConsentCovers(consent.Roles, step.Roles) {
+		return step, nil
+	}
+	return nil, nil
+}
+
 func projectRequired(ctx context.Context, request *domain.AuthRequest, projectProvider projectProvider) (missingGrant bool, err error) {
 	var project *query.Project
 	switch request.Request.Type() {
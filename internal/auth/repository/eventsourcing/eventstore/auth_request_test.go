@@ -6,11 +6,13 @@ import (
 	"testing"
 	"time"
 
+	"github.com/muhlemmer/gu"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/zitadel/zitadel/internal/auth/repository/eventsourcing/view"
 	"github.com/zitadel/zitadel/internal/auth_request/repository/cache"
 	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/errors"
 	es_models "github.com/zitadel/zitadel/internal/eventstore/v1/models"
@@ -248,6 +250,17 @@ func (m *mockApp) AppByOIDCClientID(ctx context.Context, id string, _ bool) (*qu
 	return nil, errors.ThrowNotFound(nil, "ERROR", "error")
 }
 
+type mockUserConsent struct {
+	consent *query.UserConsent
+}
+
+func (m *mockUserConsent) UserConsentByUserAndClientID(ctx context.Context, _ bool, userID, clientID string) (*query.UserConsent, error) {
+	if m.consent != nil {
+		return m.consent, nil
+	}
+	return nil, errors.ThrowNotFound(nil, "ERROR", "error")
+}
+
 type mockIDPUserLinks struct {
 	idps []*query.IDPUserLink
 }
@@ -267,6 +280,7 @@ func TestAuthRequestRepo_nextSteps(t *testing.T) {
 		userGrantProvider       userGrantProvider
 		projectProvider         projectProvider
 		applicationProvider     applicationProvider
+		userConsentProvider     userConsentProvider
 		loginPolicyProvider     loginPolicyViewProvider
 		lockoutPolicyProvider   lockoutPolicyViewProvider
 		idpUserLinksProvider    idpUserLinksProvider
@@ -1103,6 +1117,162 @@ func TestAuthRequestRepo_nextSteps(t *testing.T) {
 			[]domain.NextStep{&domain.RedirectToCallbackStep{}},
 			nil,
 		},
+		{
+			"consent required and not given, consent step",
+			fields{
+				userSessionViewProvider: &mockViewUserSession{
+					PasswordVerification:     testNow.Add(-5 * time.Minute),
+					SecondFactorVerification: testNow.Add(-5 * time.Minute),
+				},
+				userViewProvider: &mockViewUser{
+					PasswordSet:     true,
+					IsEmailVerified: true,
+					MFAMaxSetUp:     int32(domain.MFALevelSecondFactor),
+				},
+				userEventProvider:   &mockEventUser{},
+				orgViewProvider:     &mockViewOrg{State: domain.OrgStateActive},
+				userGrantProvider:   &mockUserGrants{},
+				projectProvider:     &mockProject{},
+				applicationProvider: &mockApp{app: &query.App{OIDCConfig: &query.OIDCApp{AppType: domain.OIDCApplicationTypeWeb, RequireConsent: true}}},
+				userConsentProvider: &mockUserConsent{},
+				lockoutPolicyProvider: &mockLockoutPolicy{
+					policy: &query.LockoutPolicy{
+						ShowFailures: true,
+					},
+				},
+				idpUserLinksProvider: &mockIDPUserLinks{},
+			},
+			args{&domain.AuthRequest{
+				UserID:       "UserID",
+				CreationDate: testNow,
+				Prompt:       nil,
+				Request:      &domain.AuthRequestOIDC{Scopes: []string{"openid", "profile"}},
+				LoginPolicy: &domain.LoginPolicy{
+					SecondFactors:             []domain.SecondFactorType{domain.SecondFactorTypeOTP},
+					PasswordCheckLifetime:     10 * 24 * time.Hour,
+					SecondFactorCheckLifetime: 18 * time.Hour,
+				},
+			}, false},
+			[]domain.NextStep{&domain.ConsentStep{Scopes: []string{"openid", "profile"}, Roles: []string{}}},
+			nil,
+		},
+		{
+			"consent given for fewer scopes, consent step",
+			fields{
+				userSessionViewProvider: &mockViewUserSession{
+					PasswordVerification:     testNow.Add(-5 * time.Minute),
+					SecondFactorVerification: testNow.Add(-5 * time.Minute),
+				},
+				userViewProvider: &mockViewUser{
+					PasswordSet:     true,
+					IsEmailVerified: true,
+					MFAMaxSetUp:     int32(domain.MFALevelSecondFactor),
+				},
+				userEventProvider:   &mockEventUser{},
+				orgViewProvider:     &mockViewOrg{State: domain.OrgStateActive},
+				userGrantProvider:   &mockUserGrants{},
+				projectProvider:     &mockProject{},
+				applicationProvider: &mockApp{app: &query.App{OIDCConfig: &query.OIDCApp{AppType: domain.OIDCApplicationTypeWeb, RequireConsent: true}}},
+				userConsentProvider: &mockUserConsent{consent: &query.UserConsent{Scopes: database.StringArray{"openid"}, ChangeDate: testNow.Add(-time.Hour)}},
+				lockoutPolicyProvider: &mockLockoutPolicy{
+					policy: &query.LockoutPolicy{
+						ShowFailures: true,
+					},
+				},
+				idpUserLinksProvider: &mockIDPUserLinks{},
+			},
+			args{&domain.AuthRequest{
+				UserID:       "UserID",
+				CreationDate: testNow,
+				Prompt:       nil,
+				Request:      &domain.AuthRequestOIDC{Scopes: []string{"openid", "profile"}},
+				LoginPolicy: &domain.LoginPolicy{
+					SecondFactors:             []domain.SecondFactorType{domain.SecondFactorTypeOTP},
+					PasswordCheckLifetime:     10 * 24 * time.Hour,
+					SecondFactorCheckLifetime: 18 * time.Hour,
+				},
+			}, false},
+			[]domain.NextStep{&domain.ConsentStep{Scopes: []string{"openid", "profile"}, Roles: []string{}}},
+			nil,
+		},
+		{
+			"consent given, redirect to callback step",
+			fields{
+				userSessionViewProvider: &mockViewUserSession{
+					PasswordVerification:     testNow.Add(-5 * time.Minute),
+					SecondFactorVerification: testNow.Add(-5 * time.Minute),
+				},
+				userViewProvider: &mockViewUser{
+					PasswordSet:     true,
+					IsEmailVerified: true,
+					MFAMaxSetUp:     int32(domain.MFALevelSecondFactor),
+				},
+				userEventProvider:   &mockEventUser{},
+				orgViewProvider:     &mockViewOrg{State: domain.OrgStateActive},
+				userGrantProvider:   &mockUserGrants{},
+				projectProvider:     &mockProject{},
+				applicationProvider: &mockApp{app: &query.App{OIDCConfig: &query.OIDCApp{AppType: domain.OIDCApplicationTypeWeb, RequireConsent: true}}},
+				userConsentProvider: &mockUserConsent{consent: &query.UserConsent{Scopes: database.StringArray{"openid", "profile"}, ChangeDate: testNow.Add(-time.Hour)}},
+				lockoutPolicyProvider: &mockLockoutPolicy{
+					policy: &query.LockoutPolicy{
+						ShowFailures: true,
+					},
+				},
+				idpUserLinksProvider: &mockIDPUserLinks{},
+			},
+			args{&domain.AuthRequest{
+				UserID:       "UserID",
+				CreationDate: testNow,
+				Prompt:       nil,
+				Request:      &domain.AuthRequestOIDC{Scopes: []string{"openid", "profile"}},
+				LoginPolicy: &domain.LoginPolicy{
+					SecondFactors:             []domain.SecondFactorType{domain.SecondFactorTypeOTP},
+					PasswordCheckLifetime:     10 * 24 * time.Hour,
+					SecondFactorCheckLifetime: 18 * time.Hour,
+				},
+			}, false},
+			[]domain.NextStep{&domain.RedirectToCallbackStep{}},
+			nil,
+		},
+		{
+			"prompt consent and consent given before request, consent step",
+			fields{
+				userSessionViewProvider: &mockViewUserSession{
+					PasswordVerification:     testNow.Add(-5 * time.Minute),
+					SecondFactorVerification: testNow.Add(-5 * time.Minute),
+				},
+				userViewProvider: &mockViewUser{
+					PasswordSet:     true,
+					IsEmailVerified: true,
+					MFAMaxSetUp:     int32(domain.MFALevelSecondFactor),
+				},
+				userEventProvider:   &mockEventUser{},
+				orgViewProvider:     &mockViewOrg{State: domain.OrgStateActive},
+				userGrantProvider:   &mockUserGrants{},
+				projectProvider:     &mockProject{},
+				applicationProvider: &mockApp{app: &query.App{OIDCConfig: &query.OIDCApp{AppType: domain.OIDCApplicationTypeWeb, RequireConsent: true}}},
+				userConsentProvider: &mockUserConsent{consent: &query.UserConsent{Scopes: database.StringArray{"openid", "profile"}, ChangeDate: testNow.Add(-time.Hour)}},
+				lockoutPolicyProvider: &mockLockoutPolicy{
+					policy: &query.LockoutPolicy{
+						ShowFailures: true,
+					},
+				},
+				idpUserLinksProvider: &mockIDPUserLinks{},
+			},
+			args{&domain.AuthRequest{
+				UserID:       "UserID",
+				CreationDate: testNow,
+				Prompt:       []domain.Prompt{domain.PromptConsent},
+				Request:      &domain.AuthRequestOIDC{Scopes: []string{"openid", "profile"}},
+				LoginPolicy: &domain.LoginPolicy{
+					SecondFactors:             []domain.SecondFactorType{domain.SecondFactorTypeOTP},
+					PasswordCheckLifetime:     10 * 24 * time.Hour,
+					SecondFactorCheckLifetime: 18 * time.Hour,
+				},
+			}, false},
+			[]domain.NextStep{&domain.ConsentStep{Scopes: []string{"openid", "profile"}, Roles: []string{}}},
+			nil,
+		},
 		{
 			"prompt none, checkLoggedIn true and authenticated, redirect to callback step",
 			fields{
@@ -1420,6 +1590,7 @@ func TestAuthRequestRepo_nextSteps(t *testing.T) {
 				UserGrantProvider:         tt.fields.userGrantProvider,
 				ProjectProvider:           tt.fields.projectProvider,
 				ApplicationProvider:       tt.fields.applicationProvider,
+				UserConsentProvider:       tt.fields.userConsentProvider,
 				LoginPolicyViewProvider:   tt.fields.loginPolicyProvider,
 				LockoutPolicyViewProvider: tt.fields.lockoutPolicyProvider,
 				IDPUserLinksProvider:      tt.fields.idpUserLinksProvider,
@@ -1447,17 +1618,165 @@ func TestAuthRequestRepo_mfaChecked(t *testing.T) {
 		wantChecked bool
 		errFunc     func(err error) bool
 	}{
-		//{
-		//	"required, prompt and false", //TODO: enable when LevelsOfAssurance is checked
-		//	fields{},
-		//	args{
-		//		request: &domain.AuthRequest{PossibleLOAs: []model.LevelOfAssurance{}},
-		//		user: &user_model.UserView{
-		//			OTPState: user_model.MFAStateReady,
-		//		},
-		//	},
-		//	false,
-		//},
+		{
+			"mfa requested, not set up, required prompt and false",
+			args{
+				request: &domain.AuthRequest{
+					PossibleLOAs: []domain.LevelOfAssurance{domain.LevelOfAssuranceMFA},
+					LoginPolicy: &domain.LoginPolicy{
+						SecondFactors:       []domain.SecondFactorType{domain.SecondFactorTypeOTP},
+						MFAInitSkipLifetime: 30 * 24 * time.Hour,
+					},
+				},
+				user: &user_model.UserView{
+					HumanView: &user_model.HumanView{
+						MFAMaxSetUp: domain.MFALevelNotSetUp,
+					},
+				},
+				userSession: &user_model.UserSessionView{},
+			},
+			&domain.MFAPromptStep{
+				Required: true,
+				MFAProviders: []domain.MFAType{
+					domain.MFATypeOTP,
+				},
+			},
+			false,
+			nil,
+		},
+		{
+			"phishing resistant requested, otp checked, u2f check and false",
+			args{
+				request: &domain.AuthRequest{
+					PossibleLOAs: []domain.LevelOfAssurance{domain.LevelOfAssurancePhishingResistant},
+					LoginPolicy: &domain.LoginPolicy{
+						SecondFactors:             []domain.SecondFactorType{domain.SecondFactorTypeOTP, domain.SecondFactorTypeU2F},
+						SecondFactorCheckLifetime: 18 * time.Hour,
+					},
+				},
+				user: &user_model.UserView{
+					HumanView: &user_model.HumanView{
+						MFAMaxSetUp: domain.MFALevelSecondFactor,
+						OTPState:    user_model.MFAStateReady,
+						U2FTokens:   []*user_model.WebAuthNView{{State: user_model.MFAStateReady}},
+					},
+				},
+				userSession: &user_model.UserSessionView{
+					SecondFactorVerification:     testNow.Add(-5 * time.Hour),
+					SecondFactorVerificationType: domain.MFATypeOTP,
+				},
+			},
+			&domain.MFAVerificationStep{
+				MFAProviders: []domain.MFAType{domain.MFATypeU2F},
+			},
+			false,
+			nil,
+		},
+		{
+			"phishing resistant requested, only otp set up, required u2f prompt and false",
+			args{
+				request: &domain.AuthRequest{
+					PossibleLOAs: []domain.LevelOfAssurance{domain.LevelOfAssurancePhishingResistant},
+					LoginPolicy: &domain.LoginPolicy{
+						SecondFactors:             []domain.SecondFactorType{domain.SecondFactorTypeOTP, domain.SecondFactorTypeU2F},
+						SecondFactorCheckLifetime: 18 * time.Hour,
+					},
+				},
+				user: &user_model.UserView{
+					HumanView: &user_model.HumanView{
+						MFAMaxSetUp: domain.MFALevelSecondFactor,
+						OTPState:    user_model.MFAStateReady,
+					},
+				},
+				userSession: &user_model.UserSessionView{
+					SecondFactorVerification:     testNow.Add(-5 * time.Hour),
+					SecondFactorVerificationType: domain.MFATypeOTP,
+				},
+			},
+			&domain.MFAPromptStep{
+				Required:     true,
+				MFAProviders: []domain.MFAType{domain.MFATypeU2F},
+			},
+			false,
+			nil,
+		},
+		{
+			"phishing resistant requested, u2f checked, true",
+			args{
+				request: &domain.AuthRequest{
+					PossibleLOAs: []domain.LevelOfAssurance{domain.LevelOfAssurancePhishingResistant},
+					LoginPolicy: &domain.LoginPolicy{
+						SecondFactors:             []domain.SecondFactorType{domain.SecondFactorTypeU2F},
+						SecondFactorCheckLifetime: 18 * time.Hour,
+					},
+				},
+				user: &user_model.UserView{
+					HumanView: &user_model.HumanView{
+						MFAMaxSetUp: domain.MFALevelSecondFactor,
+						U2FTokens:   []*user_model.WebAuthNView{{State: user_model.MFAStateReady}},
+					},
+				},
+				userSession: &user_model.UserSessionView{
+					SecondFactorVerification:     testNow.Add(-5 * time.Hour),
+					SecondFactorVerificationType: domain.MFATypeU2F,
+				},
+			},
+			nil,
+			true,
+			nil,
+		},
+		{
+			"phishing resistant requested, passwordless checked, true",
+			args{
+				request: &domain.AuthRequest{
+					PossibleLOAs: []domain.LevelOfAssurance{domain.LevelOfAssurancePhishingResistant},
+					LoginPolicy: &domain.LoginPolicy{
+						MultiFactorCheckLifetime: 12 * time.Hour,
+					},
+				},
+				user: &user_model.UserView{
+					HumanView: &user_model.HumanView{
+						MFAMaxSetUp: domain.MFALevelMultiFactor,
+					},
+				},
+				userSession: &user_model.UserSessionView{
+					MultiFactorVerification:     testNow.Add(-5 * time.Hour),
+					MultiFactorVerificationType: domain.MFATypeU2FUserVerification,
+				},
+			},
+			nil,
+			true,
+			nil,
+		},
+		{
+			"mfa requested, second factor checked before max age, check and false",
+			args{
+				request: &domain.AuthRequest{
+					CreationDate: testNow,
+					MaxAuthAge:   gu.Ptr(time.Hour),
+					PossibleLOAs: []domain.LevelOfAssurance{domain.LevelOfAssuranceMFA},
+					LoginPolicy: &domain.LoginPolicy{
+						SecondFactors:             []domain.SecondFactorType{domain.SecondFactorTypeOTP},
+						SecondFactorCheckLifetime: 18 * time.Hour,
+					},
+				},
+				user: &user_model.UserView{
+					HumanView: &user_model.HumanView{
+						MFAMaxSetUp: domain.MFALevelSecondFactor,
+						OTPState:    user_model.MFAStateReady,
+					},
+				},
+				userSession: &user_model.UserSessionView{
+					SecondFactorVerification:     testNow.Add(-5 * time.Hour),
+					SecondFactorVerificationType: domain.MFATypeOTP,
+				},
+			},
+			&domain.MFAVerificationStep{
+				MFAProviders: []domain.MFAType{domain.MFATypeOTP},
+			},
+			false,
+			nil,
+		},
 		{
 			"not set up, forced by policy, no mfas configured, error",
 			args{
@@ -101,7 +101,7 @@ func (r *RefreshTokenRepo) SearchMyRefreshTokens(ctx context.Context, userID str
 		Limit:       request.Limit,
 		TotalResult: count,
 		Sequence:    sequence.CurrentSequence,
-		Timestamp:   sequence.LastSuccessfulSpoolerRun,
+		Timestamp:   sequence.EventTimestamp,
 		Result:      model.RefreshTokenViewsToModel(tokens),
 	}, nil
 }
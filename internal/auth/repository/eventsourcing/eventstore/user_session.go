@@ -5,6 +5,7 @@ import (
 
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/auth/repository/eventsourcing/view"
+	"github.com/zitadel/zitadel/internal/domain"
 	usr_model "github.com/zitadel/zitadel/internal/user/model"
 	"github.com/zitadel/zitadel/internal/user/repository/view/model"
 )
@@ -21,6 +22,26 @@ func (repo *UserSessionRepo) GetMyUserSessions(ctx context.Context) ([]*usr_mode
 	return model.UserSessionsToModel(userSessions), nil
 }
 
+// GetMyActiveUserSessions returns the active sessions of the authenticated user on all user agents
+func (repo *UserSessionRepo) GetMyActiveUserSessions(ctx context.Context) ([]*usr_model.UserSessionView, error) {
+	return repo.ActiveUserSessionsByUserID(ctx, authz.GetCtxData(ctx).UserID)
+}
+
+// ActiveUserSessionsByUserID returns the active sessions of the user on all user agents
+func (repo *UserSessionRepo) ActiveUserSessionsByUserID(ctx context.Context, userID string) ([]*usr_model.UserSessionView, error) {
+	userSessions, err := repo.View.UserSessionsByUserID(userID, authz.GetInstance(ctx).InstanceID())
+	if err != nil {
+		return nil, err
+	}
+	activeSessions := make([]*model.UserSessionView, 0, len(userSessions))
+	for _, session := range userSessions {
+		if session.State == int32(domain.UserSessionStateActive) {
+			activeSessions = append(activeSessions, session)
+		}
+	}
+	return model.UserSessionsToModel(activeSessions), nil
+}
+
 func (repo *UserSessionRepo) ActiveUserSessionCount() int64 {
 	userSessions, _ := repo.View.ActiveUserSessionsCount()
 	return int64(userSessions)
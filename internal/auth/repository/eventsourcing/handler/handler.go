@@ -36,11 +36,6 @@ func Register(ctx context.Context, configs Configs, bulkLimit, errorCount uint64
 	return []query.Handler{
 		newUser(ctx,
 			handler{view, bulkLimit, configs.cycleDuration("User"), errorCount, es}, queries),
-		newUserSession(ctx,
-			handler{view, bulkLimit, configs.cycleDuration("UserSession"), errorCount, es}, queries),
-		newToken(ctx,
-			handler{view, bulkLimit, configs.cycleDuration("Token"), errorCount, es}),
-		newRefreshToken(ctx, handler{view, bulkLimit, configs.cycleDuration("RefreshToken"), errorCount, es}),
 	}
 }
 
@@ -21,6 +21,7 @@ import (
 type Config struct {
 	SearchLimit uint64
 	Spooler     spooler.SpoolerConfig
+	AuthRequest cache.Config
 }
 
 type EsRepository struct {
@@ -35,7 +36,7 @@ type EsRepository struct {
 }
 
 func Start(ctx context.Context, conf Config, systemDefaults sd.SystemDefaults, command *command.Commands, queries *query.Queries, dbClient *database.DB, esV2 *eventstore2.Eventstore, oidcEncryption crypto.EncryptionAlgorithm, userEncryption crypto.EncryptionAlgorithm, allowOrderByCreationDate bool) (*EsRepository, error) {
-	es, err := v1.Start(dbClient, allowOrderByCreationDate)
+	es, err := v1.Start(dbClient, allowOrderByCreationDate, esV2)
 	if err != nil {
 		return nil, err
 	}
@@ -46,7 +47,7 @@ func Start(ctx context.Context, conf Config, systemDefaults sd.SystemDefaults, c
 		return nil, err
 	}
 
-	authReq := cache.Start(dbClient)
+	authReq := cache.Start(dbClient, conf.AuthRequest)
 
 	spool := spooler.StartSpooler(ctx, conf.Spooler, es, esV2, view, dbClient, queries)
 
@@ -87,6 +88,7 @@ func Start(ctx context.Context, conf Config, systemDefaults sd.SystemDefaults, c
 			UserGrantProvider:         queryView,
 			ProjectProvider:           queryView,
 			ApplicationProvider:       queries,
+			UserConsentProvider:       queries,
 			IdGenerator:               idGenerator,
 		},
 		eventstore.TokenRepo{
@@ -138,6 +138,10 @@ func (v *View) UsersByOrgID(orgID, instanceID string) ([]*model.UserView, error)
 	return view.UsersByOrgID(v.Db, userTable, orgID, instanceID)
 }
 
+func (v *View) UsersByIDs(userIDs []string, instanceID string) ([]*model.UserView, error) {
+	return view.UsersByIDs(v.Db, userTable, userIDs, instanceID)
+}
+
 func (v *View) UserIDsByDomain(domain, instanceID string) ([]string, error) {
 	return view.UserIDsByDomain(v.Db, userTable, domain, instanceID)
 }
@@ -4,96 +4,99 @@ import (
 	"context"
 
 	"github.com/zitadel/zitadel/internal/errors"
-	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/query/projection"
 	"github.com/zitadel/zitadel/internal/user/repository/view"
 	"github.com/zitadel/zitadel/internal/user/repository/view/model"
 	"github.com/zitadel/zitadel/internal/view/repository"
 )
 
-const (
-	userSessionTable = "auth.user_sessions"
-)
-
 func (v *View) UserSessionByIDs(agentID, userID, instanceID string) (*model.UserSessionView, error) {
-	return view.UserSessionByIDs(v.Db, userSessionTable, agentID, userID, instanceID)
+	session, err := view.UserSessionByIDs(v.Db, projection.UserSessionProjectionTable, agentID, userID, instanceID)
+	if err != nil {
+		return nil, err
+	}
+	if err = v.fillUserSessionUserInfo(session); err != nil {
+		return nil, err
+	}
+	return session, nil
 }
 
 func (v *View) UserSessionsByUserID(userID, instanceID string) ([]*model.UserSessionView, error) {
-	return view.UserSessionsByUserID(v.Db, userSessionTable, userID, instanceID)
+	sessions, err := view.UserSessionsByUserID(v.Db, projection.UserSessionProjectionTable, userID, instanceID)
+	if err != nil {
+		return nil, err
+	}
+	return sessions, v.fillUserSessionsUserInfo(sessions, instanceID)
 }
 
 func (v *View) UserSessionsByAgentID(agentID, instanceID string) ([]*model.UserSessionView, error) {
-	return view.UserSessionsByAgentID(v.Db, userSessionTable, agentID, instanceID)
-}
-
-func (v *View) UserSessionsByOrgID(orgID, instanceID string) ([]*model.UserSessionView, error) {
-	return view.UserSessionsByOrgID(v.Db, userSessionTable, orgID, instanceID)
+	sessions, err := view.UserSessionsByAgentID(v.Db, projection.UserSessionProjectionTable, agentID, instanceID)
+	if err != nil {
+		return nil, err
+	}
+	return sessions, v.fillUserSessionsUserInfo(sessions, instanceID)
 }
 
 func (v *View) ActiveUserSessionsCount() (uint64, error) {
-	return view.ActiveUserSessions(v.Db, userSessionTable)
+	return view.ActiveUserSessions(v.Db, projection.UserSessionProjectionTable)
 }
 
-func (v *View) PutUserSession(userSession *model.UserSessionView, event *models.Event) error {
-	err := view.PutUserSession(v.Db, userSessionTable, userSession)
+func (v *View) GetLatestUserSessionSequence(ctx context.Context, instanceID string) (*repository.CurrentSequence, error) {
+	sequence, err := v.query.LatestProjectionSequence(ctx, instanceID, projection.UserSessionProjectionTable)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return v.ProcessedUserSessionSequence(event)
-}
-
-func (v *View) PutUserSessions(userSession []*model.UserSessionView, event *models.Event) error {
-	err := view.PutUserSessions(v.Db, userSessionTable, userSession...)
+	return &repository.CurrentSequence{
+		ViewName:        projection.UserSessionProjectionTable,
+		CurrentSequence: sequence.Sequence,
+		EventTimestamp:  sequence.Timestamp,
+		InstanceID:      instanceID,
+	}, nil
+}
+
+// fillUserSessionsUserInfo sets the names and avatar of the users of the sessions,
+// which are queried at once for all sessions of the instance
+func (v *View) fillUserSessionsUserInfo(sessions []*model.UserSessionView, instanceID string) error {
+	if len(sessions) == 0 {
+		return nil
+	}
+	userIDs := make([]string, 0, len(sessions))
+	for _, session := range sessions {
+		userIDs = append(userIDs, session.UserID)
+	}
+	users, err := v.UsersByIDs(userIDs, instanceID)
 	if err != nil {
 		return err
 	}
-	return v.ProcessedUserSessionSequence(event)
-}
-
-func (v *View) DeleteUserSessions(userID, instanceID string, event *models.Event) error {
-	err := view.DeleteUserSessions(v.Db, userSessionTable, userID, instanceID)
-	if err != nil && !errors.IsNotFound(err) {
-		return err
+	usersByID := make(map[string]*model.UserView, len(users))
+	for _, user := range users {
+		usersByID[user.ID] = user
 	}
-	return v.ProcessedUserSessionSequence(event)
-}
-
-func (v *View) DeleteInstanceUserSessions(event *models.Event) error {
-	err := view.DeleteInstanceUserSessions(v.Db, userSessionTable, event.InstanceID)
-	if err != nil && !errors.IsNotFound(err) {
-		return err
+	for _, session := range sessions {
+		if user, ok := usersByID[session.UserID]; ok {
+			setUserSessionUserInfo(session, user)
+		}
 	}
-	return v.ProcessedUserSessionSequence(event)
+	return nil
 }
 
-func (v *View) DeleteOrgUserSessions(event *models.Event) error {
-	err := view.DeleteOrgUserSessions(v.Db, userSessionTable, event.InstanceID, event.ResourceOwner)
-	if err != nil && !errors.IsNotFound(err) {
+// fillUserSessionUserInfo sets the names and avatar of the user,
+// which are not part of the user sessions projection
+func (v *View) fillUserSessionUserInfo(session *model.UserSessionView) error {
+	user, err := v.UserByID(session.UserID, session.InstanceID)
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
 		return err
 	}
-	return v.ProcessedUserSessionSequence(event)
-}
-
-func (v *View) GetLatestUserSessionSequence(ctx context.Context, instanceID string) (*repository.CurrentSequence, error) {
-	return v.latestSequence(ctx, userSessionTable, instanceID)
-}
-
-func (v *View) GetLatestUserSessionSequences(ctx context.Context, instanceIDs []string) ([]*repository.CurrentSequence, error) {
-	return v.latestSequences(ctx, userSessionTable, instanceIDs)
-}
-
-func (v *View) ProcessedUserSessionSequence(event *models.Event) error {
-	return v.saveCurrentSequence(userSessionTable, event)
-}
-
-func (v *View) UpdateUserSessionSpoolerRunTimestamp(instanceIDs []string) error {
-	return v.updateSpoolerRunSequence(userSessionTable, instanceIDs)
-}
-
-func (v *View) GetLatestUserSessionFailedEvent(sequence uint64, instanceID string) (*repository.FailedEvent, error) {
-	return v.latestFailedEvent(userSessionTable, instanceID, sequence)
+	setUserSessionUserInfo(session, user)
+	return nil
 }
 
-func (v *View) ProcessedUserSessionFailedEvent(failedEvent *repository.FailedEvent) error {
-	return v.saveFailedEvent(failedEvent)
+func setUserSessionUserInfo(session *model.UserSessionView, user *model.UserView) {
+	session.UserName = user.UserName
+	session.LoginName = user.PreferredLoginName
+	session.DisplayName = user.DisplayName
+	session.AvatarKey = user.AvatarKey
 }
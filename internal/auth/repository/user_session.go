@@ -8,5 +8,7 @@ import (
 
 type UserSessionRepository interface {
 	GetMyUserSessions(ctx context.Context) ([]*model.UserSessionView, error)
+	GetMyActiveUserSessions(ctx context.Context) ([]*model.UserSessionView, error)
+	ActiveUserSessionsByUserID(ctx context.Context, userID string) ([]*model.UserSessionView, error)
 	ActiveUserSessionCount() int64
 }
@@ -14,13 +14,27 @@ import (
 	caos_errs "github.com/zitadel/zitadel/internal/errors"
 )
 
+type Config struct {
+	// TTL is the maximum age of an auth request,
+	// older requests are rejected and removed by the cleanup. 0 keeps them forever
+	TTL time.Duration
+	// Cleanup starts the worker, which periodically removes the expired auth requests
+	Cleanup bool
+	// SearchLimit is the default and maximum limit of a search of the auth requests
+	SearchLimit uint64
+}
+
 type AuthRequestCache struct {
-	client *database.DB
+	client      *database.DB
+	ttl         time.Duration
+	searchLimit uint64
 }
 
-func Start(dbClient *database.DB) *AuthRequestCache {
+func Start(dbClient *database.DB, config Config) *AuthRequestCache {
 	return &AuthRequestCache{
-		client: dbClient,
+		client:      dbClient,
+		ttl:         config.TTL,
+		searchLimit: config.SearchLimit,
 	}
 }
 
@@ -58,14 +72,32 @@ func (c *AuthRequestCache) DeleteAuthRequest(ctx context.Context, id string) err
 func (c *AuthRequestCache) getAuthRequest(key, value, instanceID string) (*domain.AuthRequest, error) {
 	var b []byte
 	var requestType domain.AuthRequestType
-	query := fmt.Sprintf("SELECT request, request_type FROM auth.auth_requests WHERE instance_id = $1 and %s = $2", key)
-	err := c.client.QueryRow(query, instanceID, value).Scan(&b, &requestType)
+	var creationDate sql.NullTime
+	query := fmt.Sprintf("SELECT request, request_type, creation_date FROM auth.auth_requests WHERE instance_id = $1 and %s = $2", key)
+	err := c.client.QueryRow(query, instanceID, value).Scan(&b, &requestType, &creationDate)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, caos_errs.ThrowNotFound(err, "CACHE-d24aD", "Errors.AuthRequest.NotFound")
 		}
 		return nil, caos_errs.ThrowInternal(err, "CACHE-as3kj", "Errors.Internal")
 	}
+	if c.expired(creationDate) {
+		return nil, caos_errs.ThrowNotFound(nil, "CACHE-Qe3lk", "Errors.AuthRequest.Expired")
+	}
+	return unmarshalAuthRequest(b, requestType)
+}
+
+// expired checks the age of the request against the ttl,
+// the expired requests are removed by the cleanup
+func (c *AuthRequestCache) expired(creationDate sql.NullTime) bool {
+	return c.ttl > 0 && creationDate.Valid && creationDate.Time.Before(c.expiredBefore())
+}
+
+func (c *AuthRequestCache) expiredBefore() time.Time {
+	return time.Now().Add(-c.ttl)
+}
+
+func unmarshalAuthRequest(b []byte, requestType domain.AuthRequestType) (*domain.AuthRequest, error) {
 	request, err := domain.NewAuthRequestFromType(requestType)
 	if err == nil {
 		err = json.Unmarshal(b, request)
@@ -0,0 +1,137 @@
+package cache
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+func mockCache(t *testing.T, ttl time.Duration) (*AuthRequestCache, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return Start(&database.DB{DB: db}, Config{TTL: ttl}), mock
+}
+
+func TestAuthRequestCache_GetAuthRequestByID(t *testing.T) {
+	query := regexp.QuoteMeta("SELECT request, request_type, creation_date FROM auth.auth_requests WHERE instance_id = $1 and id = $2")
+	tests := []struct {
+		name         string
+		ttl          time.Duration
+		creationDate time.Time
+		wantErr      func(error) bool
+	}{
+		{
+			name:         "no ttl, old request found",
+			creationDate: time.Now().Add(-48 * time.Hour),
+		},
+		{
+			name:         "within ttl, found",
+			ttl:          time.Hour,
+			creationDate: time.Now().Add(-time.Minute),
+		},
+		{
+			name:         "older than ttl, not found",
+			ttl:          time.Hour,
+			creationDate: time.Now().Add(-2 * time.Hour),
+			wantErr:      caos_errs.IsNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, mock := mockCache(t, tt.ttl)
+			mock.ExpectQuery(query).
+				WithArgs("instanceID", "id").
+				WillReturnRows(sqlmock.NewRows([]string{"request", "request_type", "creation_date"}).
+					AddRow([]byte(`{"ID":"id","UserID":"userID"}`), domain.AuthRequestTypeOIDC, tt.creationDate))
+
+			got, err := c.GetAuthRequestByID(authz.WithInstanceID(context.Background(), "instanceID"), "id")
+			if tt.wantErr != nil {
+				assert.True(t, tt.wantErr(err), err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "userID", got.UserID)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestAuthRequestCache_searchAuthRequestsStmt(t *testing.T) {
+	tests := []struct {
+		name     string
+		ttl      time.Duration
+		query    *AuthRequestSearchQuery
+		wantStmt string
+		wantArgs int
+	}{
+		{
+			name:     "no filter",
+			query:    &AuthRequestSearchQuery{},
+			wantStmt: "SELECT request, request_type, COUNT(*) OVER () FROM auth.auth_requests WHERE instance_id = $1 ORDER BY creation_date DESC, id",
+			wantArgs: 1,
+		},
+		{
+			name:     "ttl, filters and paging",
+			ttl:      time.Hour,
+			query:    &AuthRequestSearchQuery{Offset: 20, Limit: 10, UserID: "userID", ApplicationID: "appID"},
+			wantStmt: "SELECT request, request_type, COUNT(*) OVER () FROM auth.auth_requests WHERE instance_id = $1 AND creation_date >= $2 AND request->>'UserID' = $3 AND request->>'ApplicationID' = $4 ORDER BY creation_date DESC, id LIMIT 10 OFFSET 20",
+			wantArgs: 4,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &AuthRequestCache{ttl: tt.ttl}
+			stmt, args := c.searchAuthRequestsStmt("instanceID", tt.query)
+			assert.Equal(t, tt.wantStmt, stmt)
+			assert.Len(t, args, tt.wantArgs)
+			assert.Equal(t, "instanceID", args[0])
+		})
+	}
+}
+
+func TestAuthRequestSearchQuery_EnsureLimit(t *testing.T) {
+	tests := []struct {
+		name      string
+		limit     uint64
+		wantLimit uint64
+		wantErr   bool
+	}{
+		{
+			name:      "no limit, default applied",
+			wantLimit: 1000,
+		},
+		{
+			name:      "within limit",
+			limit:     10,
+			wantLimit: 10,
+		},
+		{
+			name:    "exceeds limit, error",
+			limit:   1001,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := &AuthRequestSearchQuery{Limit: tt.limit}
+			err := query.EnsureLimit(1000)
+			if tt.wantErr {
+				assert.True(t, caos_errs.IsErrorInvalidArgument(err), err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantLimit, query.Limit)
+		})
+	}
+}
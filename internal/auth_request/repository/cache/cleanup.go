@@ -0,0 +1,126 @@
+package cache
+
+import (
+	"context"
+	"sync"
+
+	"github.com/zitadel/logging"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/instrument"
+
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/metrics"
+)
+
+const (
+	CleanupLockName = "auth.auth_requests_cleanup"
+
+	PendingAuthRequests              = "zitadel.auth_requests_pending"
+	PendingAuthRequestsDescription   = "In-flight auth requests per instance at the last cleanup"
+	AbandonedAuthRequests            = "zitadel.auth_requests_abandoned"
+	AbandonedAuthRequestsDescription = "Expired auth requests removed by the cleanup per instance"
+	instanceLabel                    = "instance"
+
+	countAuthRequestsStmt  = "SELECT instance_id, COUNT(*), COUNT(CASE WHEN creation_date < $1 THEN 1 END) FROM auth.auth_requests GROUP BY instance_id"
+	deleteAuthRequestsStmt = "DELETE FROM auth.auth_requests WHERE creation_date < $1"
+)
+
+// StartCleanup removes the expired auth requests in the background if the cleanup is enabled
+func StartCleanup(ctx context.Context, config Config, customConfig projection.CustomConfig, cache *AuthRequestCache) {
+	if !config.Cleanup || config.TTL <= 0 {
+		return
+	}
+	w := newCleanupWorker(projection.ApplyCustomConfig(customConfig), cache)
+	err := metrics.RegisterValueObserver(PendingAuthRequests, PendingAuthRequestsDescription, w.observePending)
+	logging.WithFields("worker", CleanupLockName).OnError(err).Warn("unable to register pending auth requests metric")
+	err = metrics.RegisterCounter(AbandonedAuthRequests, AbandonedAuthRequestsDescription)
+	logging.WithFields("worker", CleanupLockName).OnError(err).Warn("unable to register abandoned auth requests metric")
+	w.Start(ctx)
+}
+
+// instanceAuthRequests is the result of a cleanup in an instance
+type instanceAuthRequests struct {
+	InstanceID string
+	Pending    int64
+	Abandoned  int64
+}
+
+// cleanupWorker periodically removes the expired auth requests
+type cleanupWorker struct {
+	*crdb.PeriodicWorker
+	cache *AuthRequestCache
+
+	mu      sync.Mutex
+	pending map[string]int64
+}
+
+func newCleanupWorker(
+	config crdb.StatementHandlerConfig,
+	cache *AuthRequestCache,
+) *cleanupWorker {
+	w := new(cleanupWorker)
+	w.PeriodicWorker = crdb.NewPeriodicWorker(config.Client.DB, config.LockTable, CleanupLockName, config.RequeueEvery, w.cleanup)
+	w.cache = cache
+	return w
+}
+
+// cleanup removes the expired auth requests and counts the pending and abandoned ones,
+// ctx is canceled as soon as the lock of the worker is lost
+func (w *cleanupWorker) cleanup(ctx context.Context) {
+	instances, err := w.cache.cleanup(ctx)
+	if err != nil {
+		logging.WithFields("worker", CleanupLockName).WithError(err).Error("unable to remove expired auth requests")
+		return
+	}
+	pending := make(map[string]int64, len(instances))
+	for _, instance := range instances {
+		pending[instance.InstanceID] = instance.Pending
+		if instance.Abandoned == 0 {
+			continue
+		}
+		logging.WithFields("worker", CleanupLockName, "instance", instance.InstanceID, "abandoned", instance.Abandoned).Debug("expired auth requests removed")
+		err = metrics.AddCount(ctx, AbandonedAuthRequests, instance.Abandoned, map[string]attribute.Value{instanceLabel: attribute.StringValue(instance.InstanceID)})
+		logging.WithFields("worker", CleanupLockName).OnError(err).Warn("unable to count abandoned auth requests")
+	}
+	w.mu.Lock()
+	w.pending = pending
+	w.mu.Unlock()
+}
+
+func (w *cleanupWorker) observePending(_ context.Context, observer instrument.Int64Observer) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for instanceID, pending := range w.pending {
+		observer.Observe(pending, attribute.String(instanceLabel, instanceID))
+	}
+	return nil
+}
+
+// cleanup counts the pending and expired auth requests per instance
+// and removes the expired ones
+func (c *AuthRequestCache) cleanup(ctx context.Context) ([]*instanceAuthRequests, error) {
+	expiredBefore := c.expiredBefore()
+	rows, err := c.client.QueryContext(ctx, countAuthRequestsStmt, expiredBefore)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	instances := make([]*instanceAuthRequests, 0)
+	for rows.Next() {
+		var total int64
+		instance := new(instanceAuthRequests)
+		if err = rows.Scan(&instance.InstanceID, &total, &instance.Abandoned); err != nil {
+			return nil, err
+		}
+		instance.Pending = total - instance.Abandoned
+		instances = append(instances, instance)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	if _, err = c.client.ExecContext(ctx, deleteAuthRequestsStmt, expiredBefore); err != nil {
+		return nil, err
+	}
+	return instances, nil
+}
@@ -0,0 +1,98 @@
+package cache
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+// AuthRequestSearchQuery filters the in-flight auth requests of the instance
+type AuthRequestSearchQuery struct {
+	Offset        uint64
+	Limit         uint64
+	UserID        string
+	ApplicationID string
+}
+
+// EnsureLimit applies the limit if none is requested
+// and refuses requests exceeding it
+func (q *AuthRequestSearchQuery) EnsureLimit(limit uint64) error {
+	if q.Limit > limit {
+		return caos_errs.ThrowInvalidArgument(nil, "CACHE-Lm3kq", "Errors.Limit.ExceedsDefault")
+	}
+	if q.Limit == 0 {
+		q.Limit = limit
+	}
+	return nil
+}
+
+type AuthRequests struct {
+	Count    uint64
+	Requests []*domain.AuthRequest
+}
+
+// SearchAuthRequests returns the in-flight auth requests of the instance, latest first.
+// Expired requests are not returned
+func (c *AuthRequestCache) SearchAuthRequests(ctx context.Context, query *AuthRequestSearchQuery) (*AuthRequests, error) {
+	if c.searchLimit > 0 {
+		if err := query.EnsureLimit(c.searchLimit); err != nil {
+			return nil, err
+		}
+	}
+	stmt, args := c.searchAuthRequestsStmt(authz.GetInstance(ctx).InstanceID(), query)
+	rows, err := c.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, caos_errs.ThrowInternal(err, "CACHE-Jx8sd", "Errors.Internal")
+	}
+	defer rows.Close()
+
+	result := &AuthRequests{Requests: make([]*domain.AuthRequest, 0)}
+	for rows.Next() {
+		var b []byte
+		var requestType domain.AuthRequestType
+		if err = rows.Scan(&b, &requestType, &result.Count); err != nil {
+			return nil, caos_errs.ThrowInternal(err, "CACHE-Uf4mb", "Errors.Internal")
+		}
+		request, err := unmarshalAuthRequest(b, requestType)
+		if err != nil {
+			return nil, err
+		}
+		result.Requests = append(result.Requests, request)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, caos_errs.ThrowInternal(err, "CACHE-Wv2ne", "Errors.Internal")
+	}
+	return result, nil
+}
+
+func (c *AuthRequestCache) searchAuthRequestsStmt(instanceID string, query *AuthRequestSearchQuery) (string, []interface{}) {
+	conditions := []string{"instance_id = $1"}
+	args := []interface{}{instanceID}
+	addCondition := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, condition+" $"+strconv.Itoa(len(args)))
+	}
+	if c.ttl > 0 {
+		addCondition("creation_date >=", c.expiredBefore())
+	}
+	if query.UserID != "" {
+		addCondition("request->>'UserID' =", query.UserID)
+	}
+	if query.ApplicationID != "" {
+		addCondition("request->>'ApplicationID' =", query.ApplicationID)
+	}
+	stmt := "SELECT request, request_type, COUNT(*) OVER () FROM auth.auth_requests WHERE " +
+		strings.Join(conditions, " AND ") +
+		" ORDER BY creation_date DESC, id"
+	if query.Limit > 0 {
+		stmt += " LIMIT " + strconv.FormatUint(query.Limit, 10)
+	}
+	if query.Offset > 0 {
+		stmt += " OFFSET " + strconv.FormatUint(query.Offset, 10)
+	}
+	return stmt, args
+}
@@ -5,9 +5,10 @@ import (
 	"github.com/zitadel/zitadel/internal/authz/repository/eventsourcing"
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/query"
 )
 
-func Start(queries *query.Queries, dbClient *database.DB, keyEncryptionAlgorithm crypto.EncryptionAlgorithm, externalSecure, allowOrderByCreationDate bool) (repository.Repository, error) {
-	return eventsourcing.Start(queries, dbClient, keyEncryptionAlgorithm, externalSecure, allowOrderByCreationDate)
+func Start(queries *query.Queries, dbClient *database.DB, esV2 *eventstore.Eventstore, keyEncryptionAlgorithm crypto.EncryptionAlgorithm, externalSecure, allowOrderByCreationDate bool) (repository.Repository, error) {
+	return eventsourcing.Start(queries, dbClient, esV2, keyEncryptionAlgorithm, externalSecure, allowOrderByCreationDate)
 }
@@ -15,10 +15,13 @@ import (
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/id"
 	"github.com/zitadel/zitadel/internal/repository/action"
+	"github.com/zitadel/zitadel/internal/repository/idpsync"
 	instance_repo "github.com/zitadel/zitadel/internal/repository/instance"
 	"github.com/zitadel/zitadel/internal/repository/keypair"
+	"github.com/zitadel/zitadel/internal/repository/notification"
 	"github.com/zitadel/zitadel/internal/repository/org"
 	proj_repo "github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/repository/purge"
 	"github.com/zitadel/zitadel/internal/repository/quota"
 	"github.com/zitadel/zitadel/internal/repository/session"
 	usr_repo "github.com/zitadel/zitadel/internal/repository/user"
@@ -63,6 +66,7 @@ type Commands struct {
 	privateKeyLifetime   time.Duration
 	publicKeyLifetime    time.Duration
 	certificateLifetime  time.Duration
+	notificationOutbox   sd.NotificationOutbox
 }
 
 func StartCommands(
@@ -98,6 +102,7 @@ func StartCommands(
 		privateKeyLifetime:    defaults.KeyConfig.PrivateKeyLifetime,
 		publicKeyLifetime:     defaults.KeyConfig.PublicKeyLifetime,
 		certificateLifetime:   defaults.KeyConfig.CertificateLifetime,
+		notificationOutbox:    defaults.Notifications.Outbox,
 		idpConfigEncryption:   idpConfigEncryption,
 		smtpEncryption:        smtpEncryption,
 		smsEncryption:         smsEncryption,
@@ -122,6 +127,9 @@ func StartCommands(
 	action.RegisterEventMappers(repo.eventstore)
 	quota.RegisterEventMappers(repo.eventstore)
 	session.RegisterEventMappers(repo.eventstore)
+	notification.RegisterEventMappers(repo.eventstore)
+	idpsync.RegisterEventMappers(repo.eventstore)
+	purge.RegisterEventMappers(repo.eventstore)
 
 	repo.userPasswordAlg = crypto.NewBCrypt(defaults.SecretGenerators.PasswordSaltCost)
 	repo.machineKeySize = int(defaults.SecretGenerators.MachineKeySize)
@@ -132,6 +140,20 @@ func StartCommands(
 			CryptoMFA: otpEncryption,
 			Issuer:    defaults.Multifactors.OTP.Issuer,
 		},
+		OTPSMS: domain.OTPCodeConfig{
+			CodeGenerator:  defaults.Multifactors.OTPSMS.CodeGenerator,
+			ResendInterval: defaults.Multifactors.OTPSMS.ResendInterval,
+			MaxAttempts:    defaults.Multifactors.OTPSMS.MaxAttempts,
+		},
+		OTPEmail: domain.OTPCodeConfig{
+			CodeGenerator:  defaults.Multifactors.OTPEmail.CodeGenerator,
+			ResendInterval: defaults.Multifactors.OTPEmail.ResendInterval,
+			MaxAttempts:    defaults.Multifactors.OTPEmail.MaxAttempts,
+		},
+		RecoveryCodes: domain.RecoveryCodesConfig{
+			Count:         defaults.Multifactors.RecoveryCodes.Count,
+			CodeGenerator: defaults.Multifactors.RecoveryCodes.CodeGenerator,
+		},
 	}
 
 	repo.domainVerificationGenerator = crypto.NewEncryptionGenerator(defaults.DomainVerification.VerificationGenerator, repo.domainVerificationAlg)
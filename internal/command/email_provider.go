@@ -0,0 +1,239 @@
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/notification/channels/httpapi"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+)
+
+func (c *Commands) AddEmailProviderHTTP(ctx context.Context, instanceID string, config *httpapi.Config) (string, *domain.ObjectDetails, error) {
+	config.Method = strings.ToUpper(config.Method)
+	if err := config.Validate(); err != nil {
+		return "", nil, err
+	}
+	id, err := c.idGenerator.Next()
+	if err != nil {
+		return "", nil, err
+	}
+	writeModel, err := c.getEmailProvider(ctx, instanceID, id)
+	if err != nil {
+		return "", nil, err
+	}
+	headers, err := c.encryptEmailProviderHeaders(config.Headers)
+	if err != nil {
+		return "", nil, err
+	}
+
+	iamAgg := InstanceAggregateFromWriteModel(&writeModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, instance.NewEmailProviderHTTPAddedEvent(
+		ctx,
+		iamAgg,
+		id,
+		config.Description,
+		config.Priority,
+		config.SenderAddress,
+		config.SenderName,
+		config.Endpoint,
+		config.Method,
+		headers,
+		config.BodyTemplate,
+	))
+	if err != nil {
+		return "", nil, err
+	}
+	err = AppendAndReduce(writeModel, pushedEvents...)
+	if err != nil {
+		return "", nil, err
+	}
+	return id, writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+func (c *Commands) ChangeEmailProviderHTTP(ctx context.Context, instanceID, id string, config *httpapi.Config) (*domain.ObjectDetails, error) {
+	if id == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Ep2bd", "Errors.IDMissing")
+	}
+	config.Method = strings.ToUpper(config.Method)
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	writeModel, err := c.getEmailProvider(ctx, instanceID, id)
+	if err != nil {
+		return nil, err
+	}
+	if !writeModel.State.Exists() || writeModel.HTTP == nil {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Ep7ks", "Errors.EmailProvider.NotFound")
+	}
+	iamAgg := InstanceAggregateFromWriteModel(&writeModel.WriteModel)
+
+	changedEvent, hasChanged, err := writeModel.NewHTTPChangedEvent(
+		ctx,
+		iamAgg,
+		id,
+		config.Description,
+		config.Priority,
+		config.SenderAddress,
+		config.SenderName,
+		config.Endpoint,
+		config.Method,
+		config.BodyTemplate,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if !hasChanged {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Ep4mw", "Errors.NoChangesFound")
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, changedEvent)
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(writeModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+// ChangeEmailProviderHTTPHeaders replaces the headers of the provider,
+// they're stored encrypted as they usually contain the credentials of the API
+func (c *Commands) ChangeEmailProviderHTTPHeaders(ctx context.Context, instanceID, id string, headers map[string]string) (*domain.ObjectDetails, error) {
+	if id == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Eh3vq", "Errors.IDMissing")
+	}
+	writeModel, err := c.getEmailProvider(ctx, instanceID, id)
+	if err != nil {
+		return nil, err
+	}
+	if !writeModel.State.Exists() || writeModel.HTTP == nil {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Eh8xn", "Errors.EmailProvider.NotFound")
+	}
+	encryptedHeaders, err := c.encryptEmailProviderHeaders(headers)
+	if err != nil {
+		return nil, err
+	}
+	iamAgg := InstanceAggregateFromWriteModel(&writeModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, instance.NewEmailProviderHTTPHeadersChangedEvent(
+		ctx,
+		iamAgg,
+		id,
+		encryptedHeaders,
+	))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(writeModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+func (c *Commands) ActivateEmailProvider(ctx context.Context, instanceID, id string) (*domain.ObjectDetails, error) {
+	if id == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Ea5jc", "Errors.IDMissing")
+	}
+	writeModel, err := c.getEmailProvider(ctx, instanceID, id)
+	if err != nil {
+		return nil, err
+	}
+	if !writeModel.State.Exists() {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Ea2ws", "Errors.EmailProvider.NotFound")
+	}
+	if writeModel.State == domain.EmailProviderStateActive {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Ea9qp", "Errors.EmailProvider.AlreadyActive")
+	}
+	iamAgg := InstanceAggregateFromWriteModel(&writeModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, instance.NewEmailProviderActivatedEvent(
+		ctx,
+		iamAgg,
+		id))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(writeModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+func (c *Commands) DeactivateEmailProvider(ctx context.Context, instanceID, id string) (*domain.ObjectDetails, error) {
+	if id == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Ed4lf", "Errors.IDMissing")
+	}
+	writeModel, err := c.getEmailProvider(ctx, instanceID, id)
+	if err != nil {
+		return nil, err
+	}
+	if !writeModel.State.Exists() {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Ed8zk", "Errors.EmailProvider.NotFound")
+	}
+	if writeModel.State == domain.EmailProviderStateInactive {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Ed1vr", "Errors.EmailProvider.AlreadyDeactivated")
+	}
+	iamAgg := InstanceAggregateFromWriteModel(&writeModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, instance.NewEmailProviderDeactivatedEvent(
+		ctx,
+		iamAgg,
+		id))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(writeModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+func (c *Commands) RemoveEmailProvider(ctx context.Context, instanceID, id string) (*domain.ObjectDetails, error) {
+	if id == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Er6gm", "Errors.IDMissing")
+	}
+	writeModel, err := c.getEmailProvider(ctx, instanceID, id)
+	if err != nil {
+		return nil, err
+	}
+	if !writeModel.State.Exists() {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Er3yt", "Errors.EmailProvider.NotFound")
+	}
+	iamAgg := InstanceAggregateFromWriteModel(&writeModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, instance.NewEmailProviderRemovedEvent(
+		ctx,
+		iamAgg,
+		id))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(writeModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+func (c *Commands) encryptEmailProviderHeaders(headers map[string]string) (*crypto.CryptoValue, error) {
+	if len(headers) == 0 {
+		return nil, nil
+	}
+	value, err := json.Marshal(headers)
+	if err != nil {
+		return nil, caos_errs.ThrowInternal(err, "COMMAND-Eh5ob", "Errors.Internal")
+	}
+	return crypto.Encrypt(value, c.smtpEncryption)
+}
+
+func (c *Commands) getEmailProvider(ctx context.Context, instanceID, id string) (_ *EmailProviderWriteModel, err error) {
+	writeModel := NewEmailProviderWriteModel(instanceID, id)
+	err = c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
@@ -0,0 +1,170 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+)
+
+type EmailProviderWriteModel struct {
+	eventstore.WriteModel
+
+	ID            string
+	Description   string
+	Priority      int32
+	SenderAddress string
+	SenderName    string
+	HTTP          *EmailProviderHTTP
+	State         domain.EmailProviderState
+}
+
+type EmailProviderHTTP struct {
+	Endpoint     string
+	Method       string
+	Headers      *crypto.CryptoValue
+	BodyTemplate string
+}
+
+func NewEmailProviderWriteModel(instanceID, id string) *EmailProviderWriteModel {
+	return &EmailProviderWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   instanceID,
+			ResourceOwner: instanceID,
+		},
+		ID: id,
+	}
+}
+
+func (wm *EmailProviderWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *instance.EmailProviderHTTPAddedEvent:
+			if wm.ID != e.ID {
+				continue
+			}
+			wm.Description = e.Description
+			wm.Priority = e.Priority
+			wm.SenderAddress = e.SenderAddress
+			wm.SenderName = e.SenderName
+			wm.HTTP = &EmailProviderHTTP{
+				Endpoint:     e.Endpoint,
+				Method:       e.Method,
+				Headers:      e.Headers,
+				BodyTemplate: e.BodyTemplate,
+			}
+			wm.State = domain.EmailProviderStateInactive
+		case *instance.EmailProviderHTTPChangedEvent:
+			if wm.ID != e.ID {
+				continue
+			}
+			if e.Description != nil {
+				wm.Description = *e.Description
+			}
+			if e.Priority != nil {
+				wm.Priority = *e.Priority
+			}
+			if e.SenderAddress != nil {
+				wm.SenderAddress = *e.SenderAddress
+			}
+			if e.SenderName != nil {
+				wm.SenderName = *e.SenderName
+			}
+			if e.Endpoint != nil {
+				wm.HTTP.Endpoint = *e.Endpoint
+			}
+			if e.Method != nil {
+				wm.HTTP.Method = *e.Method
+			}
+			if e.BodyTemplate != nil {
+				wm.HTTP.BodyTemplate = *e.BodyTemplate
+			}
+		case *instance.EmailProviderHTTPHeadersChangedEvent:
+			if wm.ID != e.ID {
+				continue
+			}
+			wm.HTTP.Headers = e.Headers
+		case *instance.EmailProviderActivatedEvent:
+			if wm.ID != e.ID {
+				continue
+			}
+			wm.State = domain.EmailProviderStateActive
+		case *instance.EmailProviderDeactivatedEvent:
+			if wm.ID != e.ID {
+				continue
+			}
+			wm.State = domain.EmailProviderStateInactive
+		case *instance.EmailProviderRemovedEvent:
+			if wm.ID != e.ID {
+				continue
+			}
+			wm.HTTP = nil
+			wm.State = domain.EmailProviderStateRemoved
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *EmailProviderWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(instance.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			instance.EmailProviderHTTPAddedEventType,
+			instance.EmailProviderHTTPChangedEventType,
+			instance.EmailProviderHTTPHeadersChangedEventType,
+			instance.EmailProviderActivatedEventType,
+			instance.EmailProviderDeactivatedEventType,
+			instance.EmailProviderRemovedEventType).
+		Builder()
+}
+
+func (wm *EmailProviderWriteModel) NewHTTPChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id,
+	description string,
+	priority int32,
+	senderAddress,
+	senderName,
+	endpoint,
+	method,
+	bodyTemplate string,
+) (*instance.EmailProviderHTTPChangedEvent, bool, error) {
+	changes := make([]instance.EmailProviderHTTPChanges, 0)
+
+	if wm.Description != description {
+		changes = append(changes, instance.ChangeEmailProviderHTTPDescription(description))
+	}
+	if wm.Priority != priority {
+		changes = append(changes, instance.ChangeEmailProviderHTTPPriority(priority))
+	}
+	if wm.SenderAddress != senderAddress {
+		changes = append(changes, instance.ChangeEmailProviderHTTPSenderAddress(senderAddress))
+	}
+	if wm.SenderName != senderName {
+		changes = append(changes, instance.ChangeEmailProviderHTTPSenderName(senderName))
+	}
+	if wm.HTTP.Endpoint != endpoint {
+		changes = append(changes, instance.ChangeEmailProviderHTTPEndpoint(endpoint))
+	}
+	if wm.HTTP.Method != method {
+		changes = append(changes, instance.ChangeEmailProviderHTTPMethod(method))
+	}
+	if wm.HTTP.BodyTemplate != bodyTemplate {
+		changes = append(changes, instance.ChangeEmailProviderHTTPBodyTemplate(bodyTemplate))
+	}
+
+	if len(changes) == 0 {
+		return nil, false, nil
+	}
+	changeEvent, err := instance.NewEmailProviderHTTPChangedEvent(ctx, aggregate, id, changes)
+	if err != nil {
+		return nil, false, err
+	}
+	return changeEvent, true, nil
+}
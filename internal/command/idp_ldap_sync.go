@@ -0,0 +1,155 @@
+package command
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/idpsync"
+)
+
+// minLDAPSyncInterval prevents the directory being paged through all the time
+const minLDAPSyncInterval = 5 * time.Minute
+
+// LDAPSync configures the periodic synchronization of the users of an LDAP identity provider
+type LDAPSync struct {
+	Enabled  bool
+	Interval time.Duration
+	// OrgID is the organization new users are created in
+	OrgID string
+	// DeactivateMissing deactivates the linked users, which no longer exist in the directory
+	DeactivateMissing bool
+	// DisabledFilter is an LDAP filter matching the users disabled in the directory,
+	// e.g. (userAccountControl:1.2.840.113556.1.4.803:=2) for Active Directory
+	DisabledFilter string
+	// MetadataAttributes are stored as metadata of the user, the attribute name is used as key
+	MetadataAttributes []string
+	// GroupsAttribute contains the groups of the user, e.g. memberOf
+	GroupsAttribute string
+	GroupRules      []*domain.LDAPSyncGroupRule
+}
+
+func (s *LDAPSync) validate() error {
+	if s.Enabled && s.Interval < minLDAPSyncInterval {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Ls2vj", "Errors.IDPConfig.LDAPSync.IntervalTooShort")
+	}
+	if s.OrgID = strings.TrimSpace(s.OrgID); s.OrgID == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Ls7qn", "Errors.IDPConfig.LDAPSync.OrgMissing")
+	}
+	s.DisabledFilter = strings.TrimSpace(s.DisabledFilter)
+	if s.DisabledFilter != "" && (!strings.HasPrefix(s.DisabledFilter, "(") || !strings.HasSuffix(s.DisabledFilter, ")")) {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Ls8fz", "Errors.IDPConfig.LDAPSync.DisabledFilterInvalid")
+	}
+	for _, attribute := range s.MetadataAttributes {
+		if strings.TrimSpace(attribute) == "" {
+			return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Ls4kd", "Errors.IDPConfig.LDAPSync.MetadataAttributeInvalid")
+		}
+	}
+	if len(s.GroupRules) > 0 && strings.TrimSpace(s.GroupsAttribute) == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Ls9wb", "Errors.IDPConfig.LDAPSync.GroupsAttributeMissing")
+	}
+	for _, rule := range s.GroupRules {
+		if strings.TrimSpace(rule.Group) == "" || strings.TrimSpace(rule.ProjectID) == "" {
+			return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Ls1gx", "Errors.IDPConfig.LDAPSync.GroupRuleInvalid")
+		}
+	}
+	return nil
+}
+
+// AddLDAPSyncRun records the result of a sync run of the LDAP identity provider of the instance or an organization,
+// runErr is set if the run could not be executed at all
+func (c *Commands) AddLDAPSyncRun(ctx context.Context, resourceOwner, idpID string, report *domain.LDAPSyncReport, runErr error) (*domain.ObjectDetails, error) {
+	writeModel, err := c.existingLDAPSync(ctx, resourceOwner, idpID)
+	if err != nil {
+		return nil, err
+	}
+	agg := LDAPSyncAggregateFromWriteModel(&writeModel.WriteModel)
+	var event eventstore.Command = idpsync.NewLDAPSyncRunSucceededEvent(ctx, agg, report)
+	if runErr != nil {
+		event = idpsync.NewLDAPSyncRunFailedEvent(ctx, agg, report.DryRun, report.StartedAt, runErr.Error())
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(writeModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+func (c *Commands) setLDAPSync(ctx context.Context, resourceOwner, idpID string, sync *LDAPSync) (*domain.ObjectDetails, error) {
+	if err := c.checkOrgExists(ctx, sync.OrgID); err != nil {
+		return nil, err
+	}
+	writeModel, err := c.getLDAPSyncWriteModel(ctx, resourceOwner, idpID)
+	if err != nil {
+		return nil, err
+	}
+	if !writeModel.hasChanged(sync) {
+		return writeModelToObjectDetails(&writeModel.WriteModel), nil
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, idpsync.NewLDAPSyncSetEvent(
+		ctx,
+		LDAPSyncAggregateFromWriteModel(&writeModel.WriteModel),
+		sync.Enabled,
+		sync.Interval,
+		sync.OrgID,
+		sync.DeactivateMissing,
+		sync.DisabledFilter,
+		sync.MetadataAttributes,
+		sync.GroupsAttribute,
+		sync.GroupRules,
+	))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(writeModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+func (c *Commands) removeLDAPSync(ctx context.Context, resourceOwner, idpID string) (*domain.ObjectDetails, error) {
+	writeModel, err := c.existingLDAPSync(ctx, resourceOwner, idpID)
+	if err != nil {
+		return nil, err
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, idpsync.NewLDAPSyncRemovedEvent(ctx, LDAPSyncAggregateFromWriteModel(&writeModel.WriteModel)))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(writeModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+func (c *Commands) existingLDAPSync(ctx context.Context, resourceOwner, idpID string) (*LDAPSyncWriteModel, error) {
+	if idpID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Ls8rt", "Errors.IDMissing")
+	}
+	writeModel, err := c.getLDAPSyncWriteModel(ctx, resourceOwner, idpID)
+	if err != nil {
+		return nil, err
+	}
+	if !writeModel.State.Exists() {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Ls6hc", "Errors.IDPConfig.LDAPSync.NotFound")
+	}
+	return writeModel, nil
+}
+
+func (c *Commands) getLDAPSyncWriteModel(ctx context.Context, resourceOwner, idpID string) (*LDAPSyncWriteModel, error) {
+	writeModel := NewLDAPSyncWriteModel(resourceOwner, idpID)
+	err := c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
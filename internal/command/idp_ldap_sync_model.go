@@ -0,0 +1,82 @@
+package command
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/idpsync"
+)
+
+type LDAPSyncWriteModel struct {
+	eventstore.WriteModel
+
+	Enabled            bool
+	Interval           time.Duration
+	OrgID              string
+	DeactivateMissing  bool
+	DisabledFilter     string
+	MetadataAttributes []string
+	GroupsAttribute    string
+	GroupRules         []*domain.LDAPSyncGroupRule
+	State              domain.LDAPSyncState
+}
+
+func NewLDAPSyncWriteModel(resourceOwner, idpID string) *LDAPSyncWriteModel {
+	return &LDAPSyncWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   idpID,
+			ResourceOwner: resourceOwner,
+		},
+	}
+}
+
+func (wm *LDAPSyncWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *idpsync.LDAPSyncSetEvent:
+			wm.Enabled = e.Enabled
+			wm.Interval = e.Interval
+			wm.OrgID = e.OrgID
+			wm.DeactivateMissing = e.DeactivateMissing
+			wm.DisabledFilter = e.DisabledFilter
+			wm.MetadataAttributes = e.MetadataAttributes
+			wm.GroupsAttribute = e.GroupsAttribute
+			wm.GroupRules = e.GroupRules
+			wm.State = domain.LDAPSyncStateActive
+		case *idpsync.LDAPSyncRemovedEvent:
+			wm.State = domain.LDAPSyncStateRemoved
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *LDAPSyncWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(idpsync.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			idpsync.LDAPSyncSetType,
+			idpsync.LDAPSyncRemovedType,
+		).
+		Builder()
+}
+
+func (wm *LDAPSyncWriteModel) hasChanged(sync *LDAPSync) bool {
+	return !wm.State.Exists() ||
+		wm.Enabled != sync.Enabled ||
+		wm.Interval != sync.Interval ||
+		wm.OrgID != sync.OrgID ||
+		wm.DeactivateMissing != sync.DeactivateMissing ||
+		wm.DisabledFilter != sync.DisabledFilter ||
+		!reflect.DeepEqual(wm.MetadataAttributes, sync.MetadataAttributes) ||
+		wm.GroupsAttribute != sync.GroupsAttribute ||
+		!reflect.DeepEqual(wm.GroupRules, sync.GroupRules)
+}
+
+func LDAPSyncAggregateFromWriteModel(wm *eventstore.WriteModel) *eventstore.Aggregate {
+	return &idpsync.NewAggregate(wm.AggregateID, wm.ResourceOwner).Aggregate
+}
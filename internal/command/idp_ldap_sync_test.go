@@ -0,0 +1,123 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/idpsync"
+)
+
+func TestCommands_AddLDAPSyncRun(t *testing.T) {
+	startedAt := time.Date(2023, 6, 1, 10, 0, 0, 0, time.UTC)
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		resourceOwner string
+		idpID         string
+		report        *domain.LDAPSyncReport
+		runErr        error
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "succeeded, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						testLDAPSyncSetEvent(true),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusherWithInstanceID(
+								"instance1",
+								idpsync.NewLDAPSyncRunSucceededEvent(context.Background(), &idpsync.NewAggregate("idp1", "instance1").Aggregate,
+									&domain.LDAPSyncReport{StartedAt: startedAt, Created: 2, Deactivated: 1},
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instance1"),
+				resourceOwner: "instance1",
+				idpID:         "idp1",
+				report:        &domain.LDAPSyncReport{StartedAt: startedAt, Created: 2, Deactivated: 1},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "instance1",
+				},
+			},
+		},
+		{
+			name: "failed, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						testLDAPSyncSetEvent(true),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusherWithInstanceID(
+								"instance1",
+								idpsync.NewLDAPSyncRunFailedEvent(context.Background(), &idpsync.NewAggregate("idp1", "instance1").Aggregate,
+									true,
+									startedAt,
+									"unreachable",
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instance1"),
+				resourceOwner: "instance1",
+				idpID:         "idp1",
+				report:        &domain.LDAPSyncReport{DryRun: true, StartedAt: startedAt},
+				runErr:        errors.New("unreachable"),
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "instance1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := c.AddLDAPSyncRun(tt.args.ctx, tt.args.resourceOwner, tt.args.idpID, tt.args.report, tt.args.runErr)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
@@ -84,7 +84,8 @@ type InstanceSetup struct {
 		MultiFactorCheckLifetime   time.Duration
 	}
 	NotificationPolicy struct {
-		PasswordChange bool
+		PasswordChange        bool
+		SecurityNotifications bool
 	}
 	PrivacyPolicy struct {
 		TOSLink      string
@@ -244,7 +245,7 @@ func (c *Commands) SetUpInstance(ctx context.Context, setup *InstanceSetup) (str
 		prepareAddMultiFactorToDefaultLoginPolicy(instanceAgg, domain.MultiFactorTypeU2FWithPIN),
 
 		prepareAddDefaultPrivacyPolicy(instanceAgg, setup.PrivacyPolicy.TOSLink, setup.PrivacyPolicy.PrivacyLink, setup.PrivacyPolicy.HelpLink, setup.PrivacyPolicy.SupportEmail),
-		prepareAddDefaultNotificationPolicy(instanceAgg, setup.NotificationPolicy.PasswordChange),
+		prepareAddDefaultNotificationPolicy(instanceAgg, setup.NotificationPolicy.PasswordChange, setup.NotificationPolicy.SecurityNotifications),
 		prepareAddDefaultLockoutPolicy(instanceAgg, setup.LockoutPolicy.MaxAttempts, setup.LockoutPolicy.ShouldShowLockoutFailure),
 
 		prepareAddDefaultLabelPolicy(
@@ -407,6 +408,7 @@ func (c *Commands) SetUpInstance(ctx context.Context, setup *InstanceSetup) (str
 				setup.SMTPConfiguration.SMTP.User,
 				[]byte(setup.SMTPConfiguration.SMTP.Password),
 				setup.SMTPConfiguration.Tls,
+				setup.SMTPConfiguration.Priority,
 			),
 		)
 	}
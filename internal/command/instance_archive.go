@@ -0,0 +1,208 @@
+package command
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/static"
+)
+
+// InstanceArchiveVersion is the version of the archive format written by ExportInstance.
+// Archives with a newer version are rejected by ReadInstanceArchive
+const InstanceArchiveVersion = 1
+
+const (
+	instanceArchiveManifest          = "manifest.json"
+	instanceArchiveEvents            = "events.jsonl"
+	instanceArchiveUniqueConstraints = "unique_constraints.jsonl"
+	instanceArchiveAssets            = "assets.jsonl"
+	instanceArchiveAssetPrefix       = "assets/"
+)
+
+// InstanceArchive contains everything needed to recreate an instance:
+// its events, the unique constraints and the assets of the static storage
+type InstanceArchive struct {
+	Version    int       `json:"version"`
+	InstanceID string    `json:"instanceId"`
+	ExportedAt time.Time `json:"exportedAt"`
+	// Secrets is set if the encrypted secrets of the events were re-encrypted with a passphrase
+	Secrets *InstanceArchiveSecrets `json:"secrets,omitempty"`
+
+	Events            []*InstanceArchiveEvent            `json:"-"`
+	UniqueConstraints []*InstanceArchiveUniqueConstraint `json:"-"`
+	Assets            []*InstanceArchiveAsset            `json:"-"`
+}
+
+type InstanceArchiveSecrets struct {
+	Salt     []byte `json:"salt"`
+	Verifier []byte `json:"verifier"`
+}
+
+type InstanceArchiveEvent struct {
+	AggregateType    eventstore.AggregateType `json:"aggregateType"`
+	AggregateID      string                   `json:"aggregateId"`
+	AggregateVersion eventstore.Version       `json:"aggregateVersion"`
+	ResourceOwner    string                   `json:"resourceOwner"`
+	Type             eventstore.EventType     `json:"type"`
+	CreationDate     time.Time                `json:"creationDate"`
+	EditorUser       string                   `json:"editorUser"`
+	EditorService    string                   `json:"editorService"`
+	Data             json.RawMessage          `json:"data,omitempty"`
+}
+
+type InstanceArchiveUniqueConstraint struct {
+	Type  string `json:"type"`
+	Field string `json:"field"`
+}
+
+type InstanceArchiveAsset struct {
+	ResourceOwner string            `json:"resourceOwner"`
+	Name          string            `json:"name"`
+	ContentType   string            `json:"contentType"`
+	ObjectType    static.ObjectType `json:"objectType"`
+	// File is the path of the asset content inside the archive
+	File string `json:"file"`
+	Data []byte `json:"-"`
+}
+
+// Write writes the archive as gzip compressed tar
+func (a *InstanceArchive) Write(w io.Writer) (err error) {
+	gz := gzip.NewWriter(w)
+	archive := tar.NewWriter(gz)
+
+	manifest, err := json.Marshal(a)
+	if err != nil {
+		return errors.ThrowInternal(err, "COMMA-Wm3Ls", "Errors.Internal")
+	}
+	if err = writeInstanceArchiveFile(archive, instanceArchiveManifest, manifest); err != nil {
+		return err
+	}
+	if err = writeInstanceArchiveLines(archive, instanceArchiveEvents, a.Events); err != nil {
+		return err
+	}
+	if err = writeInstanceArchiveLines(archive, instanceArchiveUniqueConstraints, a.UniqueConstraints); err != nil {
+		return err
+	}
+	for i, asset := range a.Assets {
+		asset.File = instanceArchiveAssetPrefix + strconv.Itoa(i)
+	}
+	if err = writeInstanceArchiveLines(archive, instanceArchiveAssets, a.Assets); err != nil {
+		return err
+	}
+	for _, asset := range a.Assets {
+		if err = writeInstanceArchiveFile(archive, asset.File, asset.Data); err != nil {
+			return err
+		}
+	}
+
+	if err = archive.Close(); err != nil {
+		return errors.ThrowInternal(err, "COMMA-Fq9vA", "Errors.Internal")
+	}
+	if err = gz.Close(); err != nil {
+		return errors.ThrowInternal(err, "COMMA-Ze2cM", "Errors.Internal")
+	}
+	return nil
+}
+
+func writeInstanceArchiveLines[T any](archive *tar.Writer, name string, lines []T) error {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, line := range lines {
+		if err := encoder.Encode(line); err != nil {
+			return errors.ThrowInternal(err, "COMMA-Pq8zN", "Errors.Internal")
+		}
+	}
+	return writeInstanceArchiveFile(archive, name, buf.Bytes())
+}
+
+func writeInstanceArchiveFile(archive *tar.Writer, name string, content []byte) error {
+	err := archive.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0600,
+		Size:    int64(len(content)),
+		ModTime: time.Now(),
+	})
+	if err != nil {
+		return errors.ThrowInternal(err, "COMMA-Nc5ud", "Errors.Internal")
+	}
+	if _, err = archive.Write(content); err != nil {
+		return errors.ThrowInternal(err, "COMMA-Hs7rW", "Errors.Internal")
+	}
+	return nil
+}
+
+// ReadInstanceArchive reads an archive written by InstanceArchive.Write
+func ReadInstanceArchive(r io.Reader) (*InstanceArchive, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "COMMA-Ub4kS", "Errors.Instance.Archive.Invalid")
+	}
+	defer gz.Close()
+
+	files := make(map[string][]byte)
+	archive := tar.NewReader(gz)
+	for {
+		header, err := archive.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.ThrowInvalidArgument(err, "COMMA-Cv2jX", "Errors.Instance.Archive.Invalid")
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		files[header.Name], err = io.ReadAll(archive)
+		if err != nil {
+			return nil, errors.ThrowInvalidArgument(err, "COMMA-Tk6wE", "Errors.Instance.Archive.Invalid")
+		}
+	}
+
+	manifest, ok := files[instanceArchiveManifest]
+	if !ok {
+		return nil, errors.ThrowInvalidArgument(nil, "COMMA-Ld9fH", "Errors.Instance.Archive.Invalid")
+	}
+	a := new(InstanceArchive)
+	if err = json.Unmarshal(manifest, a); err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "COMMA-Yb3gR", "Errors.Instance.Archive.Invalid")
+	}
+	if a.Version < 1 || a.Version > InstanceArchiveVersion {
+		return nil, errors.ThrowInvalidArgument(nil, "COMMA-Gx7pD", "Errors.Instance.Archive.VersionUnsupported")
+	}
+
+	if a.Events, err = readInstanceArchiveLines[InstanceArchiveEvent](files[instanceArchiveEvents]); err != nil {
+		return nil, err
+	}
+	if a.UniqueConstraints, err = readInstanceArchiveLines[InstanceArchiveUniqueConstraint](files[instanceArchiveUniqueConstraints]); err != nil {
+		return nil, err
+	}
+	if a.Assets, err = readInstanceArchiveLines[InstanceArchiveAsset](files[instanceArchiveAssets]); err != nil {
+		return nil, err
+	}
+	for _, asset := range a.Assets {
+		if asset.Data, ok = files[asset.File]; !ok {
+			return nil, errors.ThrowInvalidArgument(nil, "COMMA-Rn2vB", "Errors.Instance.Archive.Invalid")
+		}
+	}
+	return a, nil
+}
+
+func readInstanceArchiveLines[T any](content []byte) ([]*T, error) {
+	lines := make([]*T, 0)
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	for decoder.More() {
+		line := new(T)
+		if err := decoder.Decode(line); err != nil {
+			return nil, errors.ThrowInvalidArgument(err, "COMMA-Jw8eQ", "Errors.Instance.Archive.Invalid")
+		}
+		lines = append(lines, line)
+	}
+	return lines, nil
+}
@@ -0,0 +1,242 @@
+package command
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	instanceArchiveKeyIDPrefix   = "instance_archive."
+	instanceArchiveKeyIterations = 100000
+	instanceArchiveKeyLength     = 32
+	instanceArchiveSaltLength    = 16
+)
+
+var instanceArchiveVerifierMessage = []byte("zitadel instance archive")
+
+// instanceArchiveEncryption names an encryption algorithm of the cluster,
+// so a secret can be encrypted by the algorithm with the same purpose on import
+type instanceArchiveEncryption struct {
+	name string
+	alg  crypto.EncryptionAlgorithm
+}
+
+func (c *Commands) instanceArchiveEncryptions() []*instanceArchiveEncryption {
+	encryptions := []*instanceArchiveEncryption{
+		{name: "idp_config", alg: c.idpConfigEncryption},
+		{name: "otp", alg: c.multifactors.OTP.CryptoMFA},
+		{name: "smtp", alg: c.smtpEncryption},
+		{name: "sms", alg: c.smsEncryption},
+		{name: "user", alg: c.userEncryption},
+		{name: "domain_verification", alg: c.domainVerificationAlg},
+		{name: "oidc", alg: c.keyAlgorithm},
+		{name: "saml", alg: c.certificateAlgorithm},
+	}
+	configured := make([]*instanceArchiveEncryption, 0, len(encryptions))
+	for _, encryption := range encryptions {
+		if encryption.alg != nil {
+			configured = append(configured, encryption)
+		}
+	}
+	return configured
+}
+
+// instanceArchiveSecretsCrypto re-encrypts the secrets of an instance
+// with a key derived from the passphrase of the archive
+type instanceArchiveSecretsCrypto struct {
+	key         string
+	encryptions []*instanceArchiveEncryption
+}
+
+func newInstanceArchiveSecrets(passphrase string, encryptions []*instanceArchiveEncryption) (*InstanceArchiveSecrets, *instanceArchiveSecretsCrypto, error) {
+	salt := make([]byte, instanceArchiveSaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, nil, errors.ThrowInternal(err, "COMMA-Vd4nK", "Errors.Internal")
+	}
+	key := instanceArchiveKey(passphrase, salt)
+	return &InstanceArchiveSecrets{
+			Salt:     salt,
+			Verifier: instanceArchiveVerifier(key),
+		},
+		&instanceArchiveSecretsCrypto{
+			key:         string(key),
+			encryptions: encryptions,
+		},
+		nil
+}
+
+func (s *InstanceArchiveSecrets) crypto(passphrase string, encryptions []*instanceArchiveEncryption) (*instanceArchiveSecretsCrypto, error) {
+	if passphrase == "" {
+		return nil, errors.ThrowInvalidArgument(nil, "COMMA-Mx7bT", "Errors.Instance.Archive.SecretsPassphraseMissing")
+	}
+	key := instanceArchiveKey(passphrase, s.Salt)
+	if !hmac.Equal(instanceArchiveVerifier(key), s.Verifier) {
+		return nil, errors.ThrowInvalidArgument(nil, "COMMA-Kp2sW", "Errors.Instance.Archive.SecretsPassphraseInvalid")
+	}
+	return &instanceArchiveSecretsCrypto{
+		key:         string(key),
+		encryptions: encryptions,
+	}, nil
+}
+
+func instanceArchiveKey(passphrase string, salt []byte) []byte {
+	return pbkdf2.Key([]byte(passphrase), salt, instanceArchiveKeyIterations, instanceArchiveKeyLength, sha256.New)
+}
+
+func instanceArchiveVerifier(key []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(instanceArchiveVerifierMessage)
+	return mac.Sum(nil)
+}
+
+// protect decrypts the secret with the encryption of this cluster
+// and encrypts it with the key of the archive
+func (s *instanceArchiveSecretsCrypto) protect(value *crypto.CryptoValue) error {
+	for _, encryption := range s.encryptions {
+		if !hasKeyID(encryption.alg, value.KeyID) {
+			continue
+		}
+		decrypted, err := encryption.alg.Decrypt(value.Crypted, value.KeyID)
+		if err != nil {
+			return errors.ThrowPreconditionFailed(err, "COMMA-Ej5zU", "Errors.Instance.Archive.SecretNotDecryptable")
+		}
+		value.Crypted, err = crypto.EncryptAES(decrypted, s.key)
+		if err != nil {
+			return errors.ThrowInternal(err, "COMMA-Qb8hL", "Errors.Internal")
+		}
+		value.KeyID = instanceArchiveKeyIDPrefix + encryption.name
+		return nil
+	}
+	return errors.ThrowPreconditionFailed(nil, "COMMA-Ag3wY", "Errors.Instance.Archive.SecretNotDecryptable")
+}
+
+// unprotect decrypts a secret protected by the archive
+// and encrypts it with the encryption of this cluster with the same purpose
+func (s *instanceArchiveSecretsCrypto) unprotect(value *crypto.CryptoValue) error {
+	if !strings.HasPrefix(value.KeyID, instanceArchiveKeyIDPrefix) {
+		return nil
+	}
+	name := strings.TrimPrefix(value.KeyID, instanceArchiveKeyIDPrefix)
+	for _, encryption := range s.encryptions {
+		if encryption.name != name {
+			continue
+		}
+		decrypted, err := crypto.DecryptAES(value.Crypted, s.key)
+		if err != nil {
+			return errors.ThrowInvalidArgument(err, "COMMA-Ws9cF", "Errors.Instance.Archive.SecretNotDecryptable")
+		}
+		encrypted, err := crypto.Encrypt(decrypted, encryption.alg)
+		if err != nil {
+			return err
+		}
+		*value = *encrypted
+		return nil
+	}
+	return errors.ThrowPreconditionFailed(nil, "COMMA-Hn6qR", "Errors.Instance.Archive.SecretNotDecryptable")
+}
+
+func hasKeyID(alg crypto.EncryptionAlgorithm, keyID string) bool {
+	if alg.EncryptionKeyID() == keyID {
+		return true
+	}
+	for _, id := range alg.DecryptionKeyIDs() {
+		if id == keyID {
+			return true
+		}
+	}
+	return false
+}
+
+var cryptoValueMarker = []byte(`"Crypted"`)
+
+// mapCryptoValues calls fn for every encrypted crypto value in the payload of an event
+// and returns if any of them was changed
+func mapCryptoValues(payload interface{}, fn func(*crypto.CryptoValue) error) (changed bool, err error) {
+	switch value := payload.(type) {
+	case map[string]interface{}:
+		if cryptoValue, ok := cryptoValueFromPayload(value); ok {
+			if err = fn(cryptoValue); err != nil {
+				return false, err
+			}
+			value["Algorithm"] = cryptoValue.Algorithm
+			value["KeyID"] = cryptoValue.KeyID
+			value["Crypted"] = base64.StdEncoding.EncodeToString(cryptoValue.Crypted)
+			return true, nil
+		}
+		for _, field := range value {
+			fieldChanged, err := mapCryptoValues(field, fn)
+			if err != nil {
+				return false, err
+			}
+			changed = changed || fieldChanged
+		}
+	case []interface{}:
+		for _, item := range value {
+			itemChanged, err := mapCryptoValues(item, fn)
+			if err != nil {
+				return false, err
+			}
+			changed = changed || itemChanged
+		}
+	}
+	return changed, nil
+}
+
+func cryptoValueFromPayload(payload map[string]interface{}) (*crypto.CryptoValue, bool) {
+	if len(payload) != 4 {
+		return nil, false
+	}
+	cryptoType, ok := payload["CryptoType"].(json.Number)
+	if !ok || cryptoType.String() != "0" {
+		return nil, false
+	}
+	algorithm, ok := payload["Algorithm"].(string)
+	if !ok {
+		return nil, false
+	}
+	keyID, ok := payload["KeyID"].(string)
+	if !ok {
+		return nil, false
+	}
+	crypted, ok := payload["Crypted"].(string)
+	if !ok {
+		return nil, false
+	}
+	decoded, err := base64.StdEncoding.DecodeString(crypted)
+	if err != nil {
+		return nil, false
+	}
+	return &crypto.CryptoValue{
+		CryptoType: crypto.TypeEncryption,
+		Algorithm:  algorithm,
+		KeyID:      keyID,
+		Crypted:    decoded,
+	}, true
+}
+
+func decodeEventPayload(data []byte) (payload interface{}, err error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err = decoder.Decode(&payload); err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "COMMA-Rz4tB", "Errors.Instance.Archive.Invalid")
+	}
+	return payload, nil
+}
+
+func encodeEventPayload(payload interface{}) ([]byte, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "COMMA-Oc3eV", "Errors.Internal")
+	}
+	return data, nil
+}
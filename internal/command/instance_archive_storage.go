@@ -0,0 +1,150 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"strconv"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/static"
+)
+
+const (
+	// InstanceArchiveChunkSize keeps the chunks of an archive well below the default message size limit of gRPC (4MB)
+	InstanceArchiveChunkSize = 1 << 20
+
+	// instanceArchiveStorageInstance stores the archives outside of the exported and imported instances
+	instanceArchiveStorageInstance = "system"
+	instanceArchiveStorageLocation = "instance_archives"
+	instanceArchiveContentType     = "application/gzip"
+)
+
+// StoredInstanceArchive references an archive in the static storage,
+// which is transferred in chunks of at most InstanceArchiveChunkSize bytes
+type StoredInstanceArchive struct {
+	ID      string
+	Version int
+	Chunks  int
+	Size    int64
+}
+
+// StoreInstanceExport exports the instance and stores the archive in chunks,
+// which can be downloaded using InstanceArchiveChunk
+func (c *Commands) StoreInstanceExport(ctx context.Context, instanceID, secretsPassphrase string) (*StoredInstanceArchive, error) {
+	archive, err := c.ExportInstance(ctx, instanceID, secretsPassphrase)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err = archive.Write(&buf); err != nil {
+		return nil, err
+	}
+	archiveID, err := c.idGenerator.Next()
+	if err != nil {
+		return nil, err
+	}
+	stored := &StoredInstanceArchive{
+		ID:      archiveID,
+		Version: archive.Version,
+		Size:    int64(buf.Len()),
+	}
+	for chunk := buf.Next(InstanceArchiveChunkSize); len(chunk) > 0; chunk = buf.Next(InstanceArchiveChunkSize) {
+		if err = c.putInstanceArchiveChunk(ctx, archiveID, stored.Chunks, chunk); err != nil {
+			logging.WithFields("archive", archiveID).OnError(c.RemoveInstanceArchive(ctx, archiveID, stored.Chunks)).Warn("unable to remove incomplete instance archive")
+			return nil, err
+		}
+		stored.Chunks++
+	}
+	return stored, nil
+}
+
+// InstanceArchiveChunk returns the chunk of a stored archive
+func (c *Commands) InstanceArchiveChunk(ctx context.Context, archiveID string, index int) ([]byte, error) {
+	if archiveID == "" || index < 0 {
+		return nil, errors.ThrowInvalidArgument(nil, "COMMA-Hc4xW", "Errors.Instance.Archive.ChunkInvalid")
+	}
+	data, _, err := c.static.GetObject(ctx, instanceArchiveStorageInstance, archiveID, instanceArchiveChunkName(index))
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// AddInstanceArchiveChunk stores a chunk of an archive to be imported by ImportStoredInstance,
+// a new archive is started if archiveID is empty
+func (c *Commands) AddInstanceArchiveChunk(ctx context.Context, archiveID string, index int, data []byte) (string, error) {
+	if index < 0 || len(data) == 0 || len(data) > InstanceArchiveChunkSize {
+		return "", errors.ThrowInvalidArgument(nil, "COMMA-Qy7nB", "Errors.Instance.Archive.ChunkInvalid")
+	}
+	if archiveID == "" {
+		var err error
+		if archiveID, err = c.idGenerator.Next(); err != nil {
+			return "", err
+		}
+	}
+	if err := c.putInstanceArchiveChunk(ctx, archiveID, index, data); err != nil {
+		return "", err
+	}
+	return archiveID, nil
+}
+
+// ImportStoredInstance imports the archive, which was stored in chunks by AddInstanceArchiveChunk.
+// The chunks are removed after a successful import
+func (c *Commands) ImportStoredInstance(ctx context.Context, archiveID string, chunks int, imp *InstanceImport) (string, *domain.ObjectDetails, error) {
+	if archiveID == "" || chunks < 1 {
+		return "", nil, errors.ThrowInvalidArgument(nil, "COMMA-Ve5kR", "Errors.Instance.Archive.ChunkInvalid")
+	}
+	var buf bytes.Buffer
+	for i := 0; i < chunks; i++ {
+		data, err := c.InstanceArchiveChunk(ctx, archiveID, i)
+		if err != nil {
+			return "", nil, err
+		}
+		buf.Write(data)
+	}
+	archive, err := ReadInstanceArchive(&buf)
+	if err != nil {
+		return "", nil, err
+	}
+	imp.Archive = archive
+	instanceID, details, err := c.ImportInstance(ctx, imp)
+	if err != nil {
+		return "", nil, err
+	}
+	logging.WithFields("archive", archiveID).OnError(c.RemoveInstanceArchive(ctx, archiveID, chunks)).Warn("unable to remove imported instance archive")
+	return instanceID, details, nil
+}
+
+// RemoveInstanceArchive removes the chunks of a stored archive
+func (c *Commands) RemoveInstanceArchive(ctx context.Context, archiveID string, chunks int) error {
+	if archiveID == "" {
+		return errors.ThrowInvalidArgument(nil, "COMMA-Dm8sK", "Errors.Instance.Archive.ChunkInvalid")
+	}
+	for i := 0; i < chunks; i++ {
+		if err := c.static.RemoveObject(ctx, instanceArchiveStorageInstance, archiveID, instanceArchiveChunkName(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Commands) putInstanceArchiveChunk(ctx context.Context, archiveID string, index int, data []byte) error {
+	_, err := c.static.PutObject(ctx,
+		instanceArchiveStorageInstance,
+		instanceArchiveStorageLocation,
+		archiveID,
+		instanceArchiveChunkName(index),
+		instanceArchiveContentType,
+		static.ObjectTypeInstanceArchive,
+		bytes.NewReader(data),
+		int64(len(data)),
+	)
+	return err
+}
+
+func instanceArchiveChunkName(index int) string {
+	return strconv.Itoa(index)
+}
@@ -0,0 +1,168 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/id"
+	id_mock "github.com/zitadel/zitadel/internal/id/mock"
+	"github.com/zitadel/zitadel/internal/static"
+	static_mock "github.com/zitadel/zitadel/internal/static/mock"
+)
+
+func TestCommands_AddInstanceArchiveChunk(t *testing.T) {
+	type fields struct {
+		idGenerator id.Generator
+		static      static.Storage
+	}
+	type args struct {
+		archiveID string
+		index     int
+		data      []byte
+	}
+	type res struct {
+		archiveID string
+		err       func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "empty chunk, invalid argument error",
+			args: args{
+				archiveID: "archive1",
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "chunk too large, invalid argument error",
+			args: args{
+				archiveID: "archive1",
+				data:      make([]byte, InstanceArchiveChunkSize+1),
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "first chunk, new archive",
+			fields: fields{
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "archive1"),
+				static:      static_mock.NewStorage(t).ExpectPutObject(),
+			},
+			args: args{
+				data: []byte("chunk"),
+			},
+			res: res{
+				archiveID: "archive1",
+			},
+		},
+		{
+			name: "next chunk, ok",
+			fields: fields{
+				static: static_mock.NewStorage(t).ExpectPutObject(),
+			},
+			args: args{
+				archiveID: "archive1",
+				index:     1,
+				data:      []byte("chunk"),
+			},
+			res: res{
+				archiveID: "archive1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				idGenerator: tt.fields.idGenerator,
+				static:      tt.fields.static,
+			}
+			got, err := c.AddInstanceArchiveChunk(context.Background(), tt.args.archiveID, tt.args.index, tt.args.data)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.archiveID, got)
+			}
+		})
+	}
+}
+
+func TestCommands_ImportStoredInstance(t *testing.T) {
+	var archive bytes.Buffer
+	err := testInstanceArchive().Write(&archive)
+	assert.NoError(t, err)
+
+	type fields struct {
+		static static.Storage
+	}
+	type args struct {
+		archiveID string
+		chunks    int
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		err    func(error) bool
+	}{
+		{
+			name: "no chunks, invalid argument error",
+			args: args{
+				archiveID: "archive1",
+			},
+			err: caos_errs.IsErrorInvalidArgument,
+		},
+		{
+			name: "incomplete archive, invalid argument error",
+			fields: fields{
+				static: static_mock.NewStorage(t).ExpectGetObject(archive.Bytes()[:archive.Len()/2], instanceArchiveContentType),
+			},
+			args: args{
+				archiveID: "archive1",
+				chunks:    1,
+			},
+			err: caos_errs.IsErrorInvalidArgument,
+		},
+		{
+			name: "chunks combined, import validated",
+			fields: fields{
+				static: static_mock.NewStorage(t).
+					ExpectGetObject(archive.Bytes()[:archive.Len()/2], instanceArchiveContentType).
+					ExpectGetObject(archive.Bytes()[archive.Len()/2:], instanceArchiveContentType),
+			},
+			args: args{
+				archiveID: "archive1",
+				chunks:    2,
+			},
+			// the archive is read completely, so the import fails on the domain mapping
+			err: caos_errs.IsErrorInvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				static: tt.fields.static,
+			}
+			_, _, err := c.ImportStoredInstance(context.Background(), tt.args.archiveID, tt.args.chunks, &InstanceImport{
+				DomainMappings: []*InstanceDomainMapping{{From: "old.example.com"}},
+			})
+			if !tt.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/static"
+)
+
+const instanceExportBulkLimit = 1000
+
+// ExportInstance exports the events, unique constraints and assets of an instance.
+// If secretsPassphrase is set, the encrypted secrets are re-encrypted with a key derived from it,
+// so the archive can be imported by a cluster with different encryption keys
+func (c *Commands) ExportInstance(ctx context.Context, instanceID, secretsPassphrase string) (*InstanceArchive, error) {
+	ctx = authz.WithInstanceID(ctx, instanceID)
+	writeModel, err := c.getInstanceWriteModelByID(ctx, instanceID)
+	if err != nil {
+		return nil, err
+	}
+	if !writeModel.State.Exists() {
+		return nil, errors.ThrowNotFound(nil, "COMMA-Tb8dN", "Errors.Instance.NotFound")
+	}
+
+	archive := &InstanceArchive{
+		Version:    InstanceArchiveVersion,
+		InstanceID: instanceID,
+		ExportedAt: time.Now(),
+	}
+	var secrets *instanceArchiveSecretsCrypto
+	if secretsPassphrase != "" {
+		archive.Secrets, secrets, err = newInstanceArchiveSecrets(secretsPassphrase, c.instanceArchiveEncryptions())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	assets := newInstanceArchiveAssetRefs()
+	var sequence uint64
+	for {
+		events, err := c.eventstore.Filter(ctx, eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+			OrderAsc().
+			Limit(instanceExportBulkLimit).
+			AddQuery().
+			SequenceGreater(sequence).
+			Builder())
+		if err != nil {
+			return nil, err
+		}
+		for _, event := range events {
+			exported, err := exportInstanceEvent(event, secrets)
+			if err != nil {
+				return nil, err
+			}
+			archive.Events = append(archive.Events, exported)
+			assets.reduce(exported)
+			sequence = event.Sequence()
+		}
+		if len(events) < instanceExportBulkLimit {
+			break
+		}
+	}
+
+	constraints, err := c.eventstore.UniqueConstraints(ctx, instanceID)
+	if err != nil {
+		return nil, err
+	}
+	archive.UniqueConstraints = make([]*InstanceArchiveUniqueConstraint, len(constraints))
+	for i, constraint := range constraints {
+		archive.UniqueConstraints[i] = &InstanceArchiveUniqueConstraint{
+			Type:  constraint.UniqueType,
+			Field: constraint.UniqueField,
+		}
+	}
+
+	archive.Assets, err = c.exportInstanceAssets(ctx, instanceID, assets.refs)
+	if err != nil {
+		return nil, err
+	}
+	return archive, nil
+}
+
+func exportInstanceEvent(event eventstore.Event, secrets *instanceArchiveSecretsCrypto) (*InstanceArchiveEvent, error) {
+	data := event.DataAsBytes()
+	if secrets != nil && bytes.Contains(data, cryptoValueMarker) {
+		payload, err := decodeEventPayload(data)
+		if err != nil {
+			return nil, err
+		}
+		changed, err := mapCryptoValues(payload, secrets.protect)
+		if err != nil {
+			return nil, err
+		}
+		if changed {
+			if data, err = encodeEventPayload(payload); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return &InstanceArchiveEvent{
+		AggregateType:    event.Aggregate().Type,
+		AggregateID:      event.Aggregate().ID,
+		AggregateVersion: event.Aggregate().Version,
+		ResourceOwner:    event.Aggregate().ResourceOwner,
+		Type:             event.Type(),
+		CreationDate:     event.CreationDate(),
+		EditorUser:       event.EditorUser(),
+		EditorService:    event.EditorService(),
+		Data:             data,
+	}, nil
+}
+
+func (c *Commands) exportInstanceAssets(ctx context.Context, instanceID string, refs []*InstanceArchiveAsset) ([]*InstanceArchiveAsset, error) {
+	assets := make([]*InstanceArchiveAsset, 0, len(refs))
+	for _, ref := range refs {
+		data, getInfo, err := c.static.GetObject(ctx, instanceID, ref.ResourceOwner, ref.Name)
+		if errors.IsNotFound(err) {
+			// the asset was removed together with all assets of its resource owner
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		info, err := getInfo()
+		if err != nil {
+			return nil, err
+		}
+		ref.ContentType = info.ContentType
+		ref.Data = data
+		assets = append(assets, ref)
+	}
+	return assets, nil
+}
+
+// instanceArchiveAssetRefs collects the assets referenced by the events of an instance
+type instanceArchiveAssetRefs struct {
+	refs []*InstanceArchiveAsset
+}
+
+func newInstanceArchiveAssetRefs() *instanceArchiveAssetRefs {
+	return &instanceArchiveAssetRefs{refs: make([]*InstanceArchiveAsset, 0)}
+}
+
+type storeKeyPayload struct {
+	StoreKey string `json:"storeKey"`
+}
+
+var storeKeyMarker = []byte(`"storeKey"`)
+
+func (r *instanceArchiveAssetRefs) reduce(event *InstanceArchiveEvent) {
+	if !bytes.Contains(event.Data, storeKeyMarker) {
+		return
+	}
+	payload := new(storeKeyPayload)
+	if err := json.Unmarshal(event.Data, payload); err != nil || payload.StoreKey == "" {
+		return
+	}
+	r.remove(event.ResourceOwner, payload.StoreKey)
+	if strings.HasSuffix(string(event.Type), ".removed") {
+		return
+	}
+	objectType := static.ObjectTypeStyling
+	if event.AggregateType == user.AggregateType {
+		objectType = static.ObjectTypeUserAvatar
+	}
+	r.refs = append(r.refs, &InstanceArchiveAsset{
+		ResourceOwner: event.ResourceOwner,
+		Name:          payload.StoreKey,
+		ObjectType:    objectType,
+	})
+}
+
+func (r *instanceArchiveAssetRefs) remove(resourceOwner, name string) {
+	for i, ref := range r.refs {
+		if ref.ResourceOwner == resourceOwner && ref.Name == name {
+			r.refs = append(r.refs[:i], r.refs[i+1:]...)
+			return
+		}
+	}
+}
@@ -0,0 +1,261 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/static"
+	static_mock "github.com/zitadel/zitadel/internal/static/mock"
+)
+
+func TestInstanceArchive_Write_Read(t *testing.T) {
+	archive := &InstanceArchive{
+		Version:    InstanceArchiveVersion,
+		InstanceID: "instance1",
+		ExportedAt: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		Secrets: &InstanceArchiveSecrets{
+			Salt:     []byte("salt"),
+			Verifier: []byte("verifier"),
+		},
+		Events: []*InstanceArchiveEvent{
+			{
+				AggregateType:    instance.AggregateType,
+				AggregateID:      "instance1",
+				AggregateVersion: instance.AggregateVersion,
+				ResourceOwner:    "instance1",
+				Type:             instance.InstanceAddedEventType,
+				CreationDate:     time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+				Data:             json.RawMessage(`{"name":"name"}`),
+			},
+		},
+		UniqueConstraints: []*InstanceArchiveUniqueConstraint{
+			{Type: "usernames", Field: "username"},
+		},
+		Assets: []*InstanceArchiveAsset{
+			{
+				ResourceOwner: "org1",
+				Name:          "avatar",
+				ContentType:   "image/png",
+				ObjectType:    static.ObjectTypeUserAvatar,
+				Data:          []byte("image"),
+			},
+		},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, archive.Write(&buf))
+
+	got, err := ReadInstanceArchive(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, archive, got)
+}
+
+func TestReadInstanceArchive(t *testing.T) {
+	tests := []struct {
+		name    string
+		archive *InstanceArchive
+		errFunc func(error) bool
+	}{
+		{
+			name:    "unsupported version, invalid argument error",
+			archive: &InstanceArchive{Version: InstanceArchiveVersion + 1},
+			errFunc: caos_errs.IsErrorInvalidArgument,
+		},
+		{
+			name:    "missing version, invalid argument error",
+			archive: &InstanceArchive{},
+			errFunc: caos_errs.IsErrorInvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, tt.archive.Write(&buf))
+			_, err := ReadInstanceArchive(&buf)
+			assert.True(t, tt.errFunc(err))
+		})
+	}
+}
+
+func TestCommands_ExportInstance(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+		static     static.Storage
+	}
+	type args struct {
+		instanceID string
+	}
+	type res struct {
+		events            []*InstanceArchiveEvent
+		uniqueConstraints []*InstanceArchiveUniqueConstraint
+		assets            []*InstanceArchiveAsset
+		err               func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "instance not found, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				instanceID: "instance1",
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "export events, constraints and assets, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewInstanceAddedEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate, "name"),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewInstanceAddedEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate, "name"),
+						),
+						eventFromEventPusher(
+							user.NewHumanAvatarAddedEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate, "avatar"),
+						),
+					),
+					expectUniqueConstraints(
+						&repository.UniqueConstraint{UniqueType: "usernames", UniqueField: "username", InstanceID: "instance1"},
+					),
+				),
+				static: static_mock.NewStorage(t).ExpectGetObject([]byte("image"), "image/png"),
+			},
+			args: args{
+				instanceID: "instance1",
+			},
+			res: res{
+				events: []*InstanceArchiveEvent{
+					{
+						AggregateType:    instance.AggregateType,
+						AggregateID:      "instance1",
+						AggregateVersion: instance.AggregateVersion,
+						ResourceOwner:    "instance1",
+						Type:             instance.InstanceAddedEventType,
+						Data:             json.RawMessage(`{"name":"name"}`),
+					},
+					{
+						AggregateType:    user.AggregateType,
+						AggregateID:      "user1",
+						AggregateVersion: user.AggregateVersion,
+						ResourceOwner:    "org1",
+						Type:             user.HumanAvatarAddedType,
+						Data:             json.RawMessage(`{"storeKey":"avatar"}`),
+					},
+				},
+				uniqueConstraints: []*InstanceArchiveUniqueConstraint{
+					{Type: "usernames", Field: "username"},
+				},
+				assets: []*InstanceArchiveAsset{
+					{
+						ResourceOwner: "org1",
+						Name:          "avatar",
+						ContentType:   "image/png",
+						ObjectType:    static.ObjectTypeUserAvatar,
+						Data:          []byte("image"),
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+				static:     tt.fields.static,
+			}
+			got, err := c.ExportInstance(context.Background(), tt.args.instanceID, "")
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, InstanceArchiveVersion, got.Version)
+				assert.Equal(t, tt.args.instanceID, got.InstanceID)
+				assert.Nil(t, got.Secrets)
+				assert.Equal(t, tt.res.events, got.Events)
+				assert.Equal(t, tt.res.uniqueConstraints, got.UniqueConstraints)
+				assert.Equal(t, tt.res.assets, got.Assets)
+			}
+		})
+	}
+}
+
+func TestInstanceArchiveSecrets(t *testing.T) {
+	source := []*instanceArchiveEncryption{{name: "idp_config", alg: crypto.CreateMockEncryptionAlg(gomock.NewController(t))}}
+	target := []*instanceArchiveEncryption{{name: "idp_config", alg: crypto.CreateMockEncryptionAlg(gomock.NewController(t))}}
+
+	secrets, protector, err := newInstanceArchiveSecrets("passphrase", source)
+	require.NoError(t, err)
+
+	value := &crypto.CryptoValue{
+		CryptoType: crypto.TypeEncryption,
+		Algorithm:  "enc",
+		KeyID:      "id",
+		Crypted:    []byte("secret"),
+	}
+	require.NoError(t, protector.protect(value))
+	assert.Equal(t, instanceArchiveKeyIDPrefix+"idp_config", value.KeyID)
+	assert.NotEqual(t, []byte("secret"), value.Crypted)
+
+	_, err = secrets.crypto("wrong", target)
+	assert.True(t, caos_errs.IsErrorInvalidArgument(err))
+	_, err = secrets.crypto("", target)
+	assert.True(t, caos_errs.IsErrorInvalidArgument(err))
+
+	unprotector, err := secrets.crypto("passphrase", target)
+	require.NoError(t, err)
+	require.NoError(t, unprotector.unprotect(value))
+	assert.Equal(t, &crypto.CryptoValue{
+		CryptoType: crypto.TypeEncryption,
+		Algorithm:  "enc",
+		KeyID:      "id",
+		Crypted:    []byte("secret"),
+	}, value)
+}
+
+func Test_mapCryptoValues(t *testing.T) {
+	payload, err := decodeEventPayload([]byte(`{"clientId":"client","clientSecret":{"CryptoType":0,"Algorithm":"enc","KeyID":"id","Crypted":"c2VjcmV0"},"password":{"CryptoType":1,"Algorithm":"bcrypt","KeyID":"","Crypted":"aGFzaA=="}}`))
+	require.NoError(t, err)
+
+	var found []string
+	changed, err := mapCryptoValues(payload, func(value *crypto.CryptoValue) error {
+		found = append(found, string(value.Crypted))
+		value.KeyID = "other"
+		return nil
+	})
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, []string{"secret"}, found)
+
+	data, err := encodeEventPayload(payload)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"clientId":"client","clientSecret":{"CryptoType":0,"Algorithm":"enc","KeyID":"other","Crypted":"c2VjcmV0"},"password":{"CryptoType":1,"Algorithm":"bcrypt","KeyID":"","Crypted":"aGFzaA=="}}`, string(data))
+}
@@ -0,0 +1,34 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+// SetInstanceLDAPSync configures the synchronization of the LDAP identity provider of the instance
+func (c *Commands) SetInstanceLDAPSync(ctx context.Context, idpID string, sync *LDAPSync) (*domain.ObjectDetails, error) {
+	if idpID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Ls5ue", "Errors.IDMissing")
+	}
+	if err := sync.validate(); err != nil {
+		return nil, err
+	}
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	provider := NewLDAPInstanceIDPWriteModel(instanceID, idpID)
+	if err := c.eventstore.FilterToQueryReducer(ctx, provider); err != nil {
+		return nil, err
+	}
+	if !provider.State.Exists() {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Ls3pa", "Errors.IDPConfig.NotExisting")
+	}
+	return c.setLDAPSync(ctx, instanceID, idpID, sync)
+}
+
+// RemoveInstanceLDAPSync stops the synchronization of the LDAP identity provider of the instance,
+// the already synchronized users are kept
+func (c *Commands) RemoveInstanceLDAPSync(ctx context.Context, idpID string) (*domain.ObjectDetails, error) {
+	return c.removeLDAPSync(ctx, authz.GetInstance(ctx).InstanceID(), idpID)
+}
@@ -0,0 +1,372 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/idp"
+	"github.com/zitadel/zitadel/internal/repository/idpsync"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+func testLDAPIDPAddedEvent() *repository.Event {
+	return eventFromEventPusher(
+		instance.NewLDAPIDPAddedEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+			"idp1",
+			"name",
+			[]string{"server"},
+			false,
+			"baseDN",
+			"dn",
+			&crypto.CryptoValue{
+				CryptoType: crypto.TypeEncryption,
+				Algorithm:  "enc",
+				KeyID:      "id",
+				Crypted:    []byte("password"),
+			},
+			"user",
+			[]string{"object"},
+			[]string{"filter"},
+			time.Second*30,
+			idp.LDAPAttributes{},
+			idp.Options{},
+		),
+	)
+}
+
+func testLDAPSyncSetEvent(enabled bool) *repository.Event {
+	return eventFromEventPusher(
+		idpsync.NewLDAPSyncSetEvent(context.Background(), &idpsync.NewAggregate("idp1", "instance1").Aggregate,
+			enabled,
+			time.Hour,
+			"org1",
+			true,
+			"(disabled=TRUE)",
+			[]string{"department"},
+			"memberOf",
+			[]*domain.LDAPSyncGroupRule{{Group: "cn=admins", ProjectID: "project1", RoleKeys: []string{"admin"}}},
+		),
+	)
+}
+
+func testLDAPSync(enabled bool) *LDAPSync {
+	return &LDAPSync{
+		Enabled:            enabled,
+		Interval:           time.Hour,
+		OrgID:              "org1",
+		DeactivateMissing:  true,
+		DisabledFilter:     "(disabled=TRUE)",
+		MetadataAttributes: []string{"department"},
+		GroupsAttribute:    "memberOf",
+		GroupRules:         []*domain.LDAPSyncGroupRule{{Group: "cn=admins", ProjectID: "project1", RoleKeys: []string{"admin"}}},
+	}
+}
+
+func TestCommands_SetInstanceLDAPSync(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx   context.Context
+		idpID string
+		sync  *LDAPSync
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "missing id, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:  authz.WithInstanceID(context.Background(), "instance1"),
+				sync: testLDAPSync(true),
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "interval too short, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:   authz.WithInstanceID(context.Background(), "instance1"),
+				idpID: "idp1",
+				sync: &LDAPSync{
+					Enabled:  true,
+					Interval: time.Minute,
+					OrgID:    "org1",
+				},
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Ls2vj", ""))
+				},
+			},
+		},
+		{
+			name: "disabled filter without parentheses, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:   authz.WithInstanceID(context.Background(), "instance1"),
+				idpID: "idp1",
+				sync: &LDAPSync{
+					Interval:       time.Hour,
+					OrgID:          "org1",
+					DisabledFilter: "disabled=TRUE",
+				},
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Ls8fz", ""))
+				},
+			},
+		},
+		{
+			name: "group rules without groups attribute, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:   authz.WithInstanceID(context.Background(), "instance1"),
+				idpID: "idp1",
+				sync: &LDAPSync{
+					Interval:   time.Hour,
+					OrgID:      "org1",
+					GroupRules: []*domain.LDAPSyncGroupRule{{Group: "cn=admins", ProjectID: "project1"}},
+				},
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Ls9wb", ""))
+				},
+			},
+		},
+		{
+			name: "idp not found, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:   authz.WithInstanceID(context.Background(), "instance1"),
+				idpID: "idp1",
+				sync:  testLDAPSync(true),
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "org not found, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						testLDAPIDPAddedEvent(),
+					),
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:   authz.WithInstanceID(context.Background(), "instance1"),
+				idpID: "idp1",
+				sync:  testLDAPSync(true),
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "set, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						testLDAPIDPAddedEvent(),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							org.NewOrgAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "org"),
+						),
+					),
+					expectFilter(),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusherWithInstanceID(
+								"instance1",
+								idpsync.NewLDAPSyncSetEvent(context.Background(), &idpsync.NewAggregate("idp1", "instance1").Aggregate,
+									true,
+									time.Hour,
+									"org1",
+									true,
+									"(disabled=TRUE)",
+									[]string{"department"},
+									"memberOf",
+									[]*domain.LDAPSyncGroupRule{{Group: "cn=admins", ProjectID: "project1", RoleKeys: []string{"admin"}}},
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:   authz.WithInstanceID(context.Background(), "instance1"),
+				idpID: "idp1",
+				sync:  testLDAPSync(true),
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "instance1",
+				},
+			},
+		},
+		{
+			name: "unchanged, no push",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						testLDAPIDPAddedEvent(),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							org.NewOrgAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "org"),
+						),
+					),
+					expectFilter(
+						testLDAPSyncSetEvent(true),
+					),
+				),
+			},
+			args: args{
+				ctx:   authz.WithInstanceID(context.Background(), "instance1"),
+				idpID: "idp1",
+				sync:  testLDAPSync(true),
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "instance1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := c.SetInstanceLDAPSync(tt.args.ctx, tt.args.idpID, tt.args.sync)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommands_RemoveInstanceLDAPSync(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx   context.Context
+		idpID string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "not configured, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:   authz.WithInstanceID(context.Background(), "instance1"),
+				idpID: "idp1",
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "remove, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						testLDAPSyncSetEvent(true),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusherWithInstanceID(
+								"instance1",
+								idpsync.NewLDAPSyncRemovedEvent(context.Background(), &idpsync.NewAggregate("idp1", "instance1").Aggregate),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:   authz.WithInstanceID(context.Background(), "instance1"),
+				idpID: "idp1",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "instance1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := c.RemoveInstanceLDAPSync(tt.args.ctx, tt.args.idpID)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
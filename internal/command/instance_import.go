@@ -0,0 +1,385 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/project"
+)
+
+// instanceImportPushBatchSize is the maximum amount of events pushed in a single transaction on import
+const instanceImportPushBatchSize = 1000
+
+type InstanceImport struct {
+	Archive *InstanceArchive
+	// InstanceName renames the imported instance if set
+	InstanceName string
+	// DomainMappings replace the domains of the exported instance and their subdomains
+	DomainMappings []*InstanceDomainMapping
+	// SecretsPassphrase is required if the secrets of the archive are protected by a passphrase
+	SecretsPassphrase string
+}
+
+type InstanceDomainMapping struct {
+	From string
+	To   string
+}
+
+// ImportInstance creates a new instance from an archive written by ExportInstance.
+// The events of the archive are pushed for the new instance id, the domains are remapped
+// and protected secrets are encrypted with the encryption keys of this cluster
+func (c *Commands) ImportInstance(ctx context.Context, imp *InstanceImport) (string, *domain.ObjectDetails, error) {
+	if imp.Archive == nil || len(imp.Archive.Events) == 0 {
+		return "", nil, errors.ThrowInvalidArgument(nil, "COMMA-Zc6fP", "Errors.Instance.Archive.Invalid")
+	}
+	mappings, err := newInstanceDomainMappings(imp.DomainMappings)
+	if err != nil {
+		return "", nil, err
+	}
+	var secrets *instanceArchiveSecretsCrypto
+	if imp.Archive.Secrets != nil {
+		secrets, err = imp.Archive.Secrets.crypto(imp.SecretsPassphrase, c.instanceArchiveEncryptions())
+		if err != nil {
+			return "", nil, err
+		}
+	}
+
+	instanceID, err := c.idGenerator.Next()
+	if err != nil {
+		return "", nil, err
+	}
+	importer := &instanceImporter{
+		oldInstanceID: imp.Archive.InstanceID,
+		newInstanceID: instanceID,
+		domains:       mappings,
+		secrets:       secrets,
+	}
+	cmds, err := importer.commands(imp.Archive)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if err = c.eventstore.NewInstance(ctx, instanceID); err != nil {
+		return "", nil, err
+	}
+	ctx = authz.SetCtxData(authz.WithInstanceID(ctx, instanceID), authz.CtxData{OrgID: instanceID, ResourceOwner: instanceID})
+	if imp.InstanceName != "" {
+		cmds = append(cmds, instance.NewInstanceChangedEvent(ctx, &instance.NewAggregate(instanceID).Aggregate, imp.InstanceName))
+	}
+
+	events, err := c.pushImportedEvents(ctx, instanceID, cmds, instanceImportPushBatchSize)
+	if err != nil {
+		return "", nil, err
+	}
+
+	for _, asset := range imp.Archive.Assets {
+		_, err = c.static.PutObject(ctx,
+			instanceID,
+			"",
+			importer.resourceOwner(asset.ResourceOwner),
+			asset.Name,
+			asset.ContentType,
+			asset.ObjectType,
+			bytes.NewReader(asset.Data),
+			int64(len(asset.Data)),
+		)
+		if err != nil {
+			return "", nil, err
+		}
+	}
+
+	return instanceID, &domain.ObjectDetails{
+		Sequence:      events[len(events)-1].Sequence(),
+		EventDate:     events[len(events)-1].CreationDate(),
+		ResourceOwner: instanceID,
+	}, nil
+}
+
+// pushImportedEvents pushes the events in batches, so a large instance doesn't end up in a single transaction.
+// If a batch fails after the instance was partially imported, the instance is removed again
+func (c *Commands) pushImportedEvents(ctx context.Context, instanceID string, cmds []eventstore.Command, batchSize int) ([]eventstore.Event, error) {
+	events := make([]eventstore.Event, 0, len(cmds))
+	for start := 0; start < len(cmds); start += batchSize {
+		end := start + batchSize
+		if end > len(cmds) {
+			end = len(cmds)
+		}
+		pushed, err := c.eventstore.Push(ctx, cmds[start:end]...)
+		if err != nil {
+			if start > 0 {
+				_, removeErr := c.RemoveInstance(ctx, instanceID)
+				logging.WithFields("instance", instanceID).OnError(removeErr).Error("unable to remove partially imported instance")
+			}
+			return nil, err
+		}
+		events = append(events, pushed...)
+	}
+	return events, nil
+}
+
+type instanceImporter struct {
+	oldInstanceID string
+	newInstanceID string
+	domains       instanceDomainMappings
+	secrets       *instanceArchiveSecretsCrypto
+}
+
+func (i *instanceImporter) commands(archive *InstanceArchive) ([]eventstore.Command, error) {
+	cmds := make([]eventstore.Command, len(archive.Events))
+	instanceDomains := make([]string, 0)
+	for j, event := range archive.Events {
+		cmd, err := i.event(event)
+		if err != nil {
+			return nil, err
+		}
+		cmds[j] = cmd
+		instanceDomains = reduceImportedInstanceDomains(instanceDomains, cmd)
+	}
+
+	constraints := make([]*eventstore.EventUniqueConstraint, 0, len(archive.UniqueConstraints)+len(instanceDomains))
+	for _, constraint := range archive.UniqueConstraints {
+		constraints = append(constraints, i.uniqueConstraint(constraint))
+	}
+	// the domains of instances are unique over all instances and therefore not part of the archive
+	for _, instanceDomain := range instanceDomains {
+		constraints = append(constraints, instance.NewAddInstanceDomainUniqueConstraint(instanceDomain))
+	}
+	cmds[0].(*importedEvent).constraints = constraints
+	return cmds, nil
+}
+
+func (i *instanceImporter) event(event *InstanceArchiveEvent) (*importedEvent, error) {
+	aggregate := eventstore.Aggregate{
+		ID:            event.AggregateID,
+		Type:          event.AggregateType,
+		ResourceOwner: i.resourceOwner(event.ResourceOwner),
+		InstanceID:    i.newInstanceID,
+		Version:       event.AggregateVersion,
+	}
+	if aggregate.ID == i.oldInstanceID {
+		aggregate.ID = i.newInstanceID
+	}
+	data, err := i.data(event)
+	if err != nil {
+		return nil, err
+	}
+	return &importedEvent{
+		aggregate:     aggregate,
+		typ:           event.Type,
+		editorUser:    event.EditorUser,
+		editorService: event.EditorService,
+		data:          data,
+	}, nil
+}
+
+func (i *instanceImporter) data(event *InstanceArchiveEvent) ([]byte, error) {
+	remapDomains := len(i.domains) > 0 && containsDomains(event.Type)
+	decryptSecrets := i.secrets != nil && bytes.Contains(event.Data, cryptoValueMarker)
+	if !remapDomains && !decryptSecrets {
+		return event.Data, nil
+	}
+	payload, err := decodeEventPayload(event.Data)
+	if err != nil {
+		return nil, err
+	}
+	changed := false
+	if remapDomains {
+		changed = i.domains.remapPayload(event.Type, payload)
+	}
+	if decryptSecrets {
+		secretsChanged, err := mapCryptoValues(payload, i.secrets.unprotect)
+		if err != nil {
+			return nil, err
+		}
+		changed = changed || secretsChanged
+	}
+	if !changed {
+		return event.Data, nil
+	}
+	return encodeEventPayload(payload)
+}
+
+func (i *instanceImporter) resourceOwner(resourceOwner string) string {
+	if resourceOwner == i.oldInstanceID {
+		return i.newInstanceID
+	}
+	return resourceOwner
+}
+
+func (i *instanceImporter) uniqueConstraint(constraint *InstanceArchiveUniqueConstraint) *eventstore.EventUniqueConstraint {
+	field := strings.ReplaceAll(constraint.Field, i.oldInstanceID, i.newInstanceID)
+	if constraint.Type == org.UniqueOrgDomain {
+		field = i.domains.remap(field)
+	}
+	return eventstore.NewAddEventUniqueConstraint(constraint.Type, field, "Errors.Instance.Archive.UniqueConstraintViolated")
+}
+
+func reduceImportedInstanceDomains(domains []string, event *importedEvent) []string {
+	switch event.typ {
+	case instance.InstanceDomainAddedEventType:
+		return append(domains, domainOfPayload(event.data))
+	case instance.InstanceDomainRemovedEventType:
+		removed := domainOfPayload(event.data)
+		for i, instanceDomain := range domains {
+			if instanceDomain == removed {
+				return append(domains[:i], domains[i+1:]...)
+			}
+		}
+	}
+	return domains
+}
+
+func domainOfPayload(data []byte) string {
+	payload, err := decodeEventPayload(data)
+	if err != nil {
+		return ""
+	}
+	fields, _ := payload.(map[string]interface{})
+	domainName, _ := fields["domain"].(string)
+	return domainName
+}
+
+func containsDomains(typ eventstore.EventType) bool {
+	switch typ {
+	case instance.InstanceDomainAddedEventType,
+		instance.InstanceDomainPrimarySetEventType,
+		instance.InstanceDomainRemovedEventType,
+		org.OrgDomainAddedEventType,
+		org.OrgDomainVerificationAddedEventType,
+		org.OrgDomainVerificationFailedEventType,
+		org.OrgDomainVerifiedEventType,
+		org.OrgDomainPrimarySetEventType,
+		org.OrgDomainRemovedEventType,
+		project.OIDCConfigAddedType,
+		project.OIDCConfigChangedType:
+		return true
+	}
+	return false
+}
+
+type instanceDomainMappings []*InstanceDomainMapping
+
+func newInstanceDomainMappings(mappings []*InstanceDomainMapping) (instanceDomainMappings, error) {
+	normalized := make(instanceDomainMappings, len(mappings))
+	for i, mapping := range mappings {
+		from := strings.ToLower(strings.TrimSpace(mapping.From))
+		to := strings.ToLower(strings.TrimSpace(mapping.To))
+		if from == "" || to == "" {
+			return nil, errors.ThrowInvalidArgument(nil, "COMMA-Bf5mQ", "Errors.Instance.Archive.DomainMappingInvalid")
+		}
+		normalized[i] = &InstanceDomainMapping{From: from, To: to}
+	}
+	return normalized, nil
+}
+
+// remap replaces the domain if it or one of its parent domains is mapped
+func (m instanceDomainMappings) remap(domainName string) string {
+	lower := strings.ToLower(domainName)
+	for _, mapping := range m {
+		if lower == mapping.From {
+			return mapping.To
+		}
+		if strings.HasSuffix(lower, "."+mapping.From) {
+			return strings.TrimSuffix(lower, mapping.From) + mapping.To
+		}
+	}
+	return domainName
+}
+
+func (m instanceDomainMappings) remapURI(uri string) string {
+	parsed, err := url.Parse(uri)
+	if err != nil || parsed.Host == "" {
+		return uri
+	}
+	host := parsed.Hostname()
+	remapped := m.remap(host)
+	if remapped == host {
+		return uri
+	}
+	if port := parsed.Port(); port != "" {
+		remapped = net.JoinHostPort(remapped, port)
+	}
+	parsed.Host = remapped
+	return parsed.String()
+}
+
+func (m instanceDomainMappings) remapPayload(typ eventstore.EventType, payload interface{}) (changed bool) {
+	fields, ok := payload.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	if typ == project.OIDCConfigAddedType || typ == project.OIDCConfigChangedType {
+		for _, key := range []string{"redirectUris", "postLogoutRedirectUris"} {
+			uris, ok := fields[key].([]interface{})
+			if !ok {
+				continue
+			}
+			for i, uri := range uris {
+				if uriString, ok := uri.(string); ok {
+					if remapped := m.remapURI(uriString); remapped != uriString {
+						uris[i] = remapped
+						changed = true
+					}
+				}
+			}
+		}
+		return changed
+	}
+	domainName, ok := fields["domain"].(string)
+	if !ok {
+		return false
+	}
+	if remapped := m.remap(domainName); remapped != domainName {
+		fields["domain"] = remapped
+		return true
+	}
+	return false
+}
+
+// importedEvent pushes an event of an archive as it was exported
+type importedEvent struct {
+	aggregate     eventstore.Aggregate
+	typ           eventstore.EventType
+	editorUser    string
+	editorService string
+	data          []byte
+	constraints   []*eventstore.EventUniqueConstraint
+}
+
+func (e *importedEvent) Aggregate() eventstore.Aggregate {
+	return e.aggregate
+}
+
+func (e *importedEvent) EditorService() string {
+	return e.editorService
+}
+
+func (e *importedEvent) EditorUser() string {
+	return e.editorUser
+}
+
+func (e *importedEvent) Type() eventstore.EventType {
+	return e.typ
+}
+
+func (e *importedEvent) Data() interface{} {
+	if len(e.data) == 0 {
+		return nil
+	}
+	return e.data
+}
+
+func (e *importedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return e.constraints
+}
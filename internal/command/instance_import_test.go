@@ -0,0 +1,318 @@
+package command
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/id"
+	id_mock "github.com/zitadel/zitadel/internal/id/mock"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/static"
+	static_mock "github.com/zitadel/zitadel/internal/static/mock"
+)
+
+func testInstanceArchive() *InstanceArchive {
+	return &InstanceArchive{
+		Version:    InstanceArchiveVersion,
+		InstanceID: "instance1",
+		Events: []*InstanceArchiveEvent{
+			{
+				AggregateType:    instance.AggregateType,
+				AggregateID:      "instance1",
+				AggregateVersion: instance.AggregateVersion,
+				ResourceOwner:    "instance1",
+				Type:             instance.InstanceAddedEventType,
+				EditorUser:       "user1",
+				EditorService:    "system",
+				Data:             json.RawMessage(`{"name":"name"}`),
+			},
+			{
+				AggregateType:    instance.AggregateType,
+				AggregateID:      "instance1",
+				AggregateVersion: instance.AggregateVersion,
+				ResourceOwner:    "instance1",
+				Type:             instance.InstanceDomainAddedEventType,
+				EditorUser:       "user1",
+				EditorService:    "system",
+				Data:             json.RawMessage(`{"domain":"old.example.com"}`),
+			},
+			{
+				AggregateType:    org.AggregateType,
+				AggregateID:      "org1",
+				AggregateVersion: org.AggregateVersion,
+				ResourceOwner:    "org1",
+				Type:             org.OrgDomainAddedEventType,
+				EditorUser:       "user1",
+				EditorService:    "system",
+				Data:             json.RawMessage(`{"domain":"org.old.example.com"}`),
+			},
+		},
+		UniqueConstraints: []*InstanceArchiveUniqueConstraint{
+			{Type: org.UniqueOrgDomain, Field: "org.old.example.com"},
+			{Type: "member", Field: "instance1:user1"},
+		},
+		Assets: []*InstanceArchiveAsset{
+			{
+				ResourceOwner: "instance1",
+				Name:          "logo",
+				ContentType:   "image/png",
+				ObjectType:    static.ObjectTypeStyling,
+				Data:          []byte("image"),
+			},
+		},
+	}
+}
+
+func importedRepositoryEvent(aggregateType, aggregateID, resourceOwner, version, typ, data string) *repository.Event {
+	return &repository.Event{
+		AggregateID:   aggregateID,
+		AggregateType: repository.AggregateType(aggregateType),
+		ResourceOwner: sql.NullString{String: resourceOwner, Valid: true},
+		InstanceID:    "instance2",
+		EditorService: "system",
+		EditorUser:    "user1",
+		Type:          repository.EventType(typ),
+		Version:       repository.Version(version),
+		Data:          []byte(data),
+	}
+}
+
+func TestCommands_ImportInstance(t *testing.T) {
+	type fields struct {
+		eventstore  *eventstore.Eventstore
+		idGenerator id.Generator
+		static      static.Storage
+	}
+	type args struct {
+		imp *InstanceImport
+	}
+	type res struct {
+		instanceID string
+		want       *domain.ObjectDetails
+		err        func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "empty archive, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				imp: &InstanceImport{
+					Archive: &InstanceArchive{Version: InstanceArchiveVersion},
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "invalid domain mapping, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				imp: &InstanceImport{
+					Archive:        testInstanceArchive(),
+					DomainMappings: []*InstanceDomainMapping{{From: "old.example.com"}},
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "protected secrets without passphrase, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				imp: &InstanceImport{
+					Archive: func() *InstanceArchive {
+						archive := testInstanceArchive()
+						archive.Secrets = &InstanceArchiveSecrets{Salt: []byte("salt"), Verifier: []byte("verifier")}
+						return archive
+					}(),
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "import with domain mapping, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectCreateInstance("instance2"),
+					expectPush(
+						[]*repository.Event{
+							importedRepositoryEvent(string(instance.AggregateType), "instance2", "instance2", string(instance.AggregateVersion), string(instance.InstanceAddedEventType), `{"name":"name"}`),
+							importedRepositoryEvent(string(instance.AggregateType), "instance2", "instance2", string(instance.AggregateVersion), string(instance.InstanceDomainAddedEventType), `{"domain":"new.example.com"}`),
+							importedRepositoryEvent(string(org.AggregateType), "org1", "org1", string(org.AggregateVersion), string(org.OrgDomainAddedEventType), `{"domain":"org.new.example.com"}`),
+						},
+						&repository.UniqueConstraint{
+							UniqueType:   org.UniqueOrgDomain,
+							UniqueField:  "org.new.example.com",
+							InstanceID:   "instance2",
+							Action:       repository.UniqueConstraintAdd,
+							ErrorMessage: "Errors.Instance.Archive.UniqueConstraintViolated",
+						},
+						&repository.UniqueConstraint{
+							UniqueType:   "member",
+							UniqueField:  "instance2:user1",
+							InstanceID:   "instance2",
+							Action:       repository.UniqueConstraintAdd,
+							ErrorMessage: "Errors.Instance.Archive.UniqueConstraintViolated",
+						},
+						&repository.UniqueConstraint{
+							UniqueType:   instance.UniqueInstanceDomain,
+							UniqueField:  "new.example.com",
+							Action:       repository.UniqueConstraintAdd,
+							ErrorMessage: "Errors.Instance.Domain.AlreadyExists",
+						},
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "instance2"),
+				static:      static_mock.NewStorage(t).ExpectPutObject(),
+			},
+			args: args{
+				imp: &InstanceImport{
+					Archive:        testInstanceArchive(),
+					DomainMappings: []*InstanceDomainMapping{{From: "old.example.com", To: "new.example.com"}},
+				},
+			},
+			res: res{
+				instanceID: "instance2",
+				want: &domain.ObjectDetails{
+					ResourceOwner: "instance2",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:  tt.fields.eventstore,
+				idGenerator: tt.fields.idGenerator,
+				static:      tt.fields.static,
+			}
+			instanceID, got, err := c.ImportInstance(context.Background(), tt.args.imp)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.instanceID, instanceID)
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestInstanceDomainMappings_remap(t *testing.T) {
+	mappings := instanceDomainMappings{
+		{From: "old.example.com", To: "new.example.com"},
+		{From: "example.org", To: "example.net"},
+	}
+	tests := []struct {
+		domain string
+		want   string
+	}{
+		{domain: "old.example.com", want: "new.example.com"},
+		{domain: "Old.Example.com", want: "new.example.com"},
+		{domain: "org.old.example.com", want: "org.new.example.com"},
+		{domain: "gold.example.com", want: "gold.example.com"},
+		{domain: "login.example.org", want: "login.example.net"},
+		{domain: "example.io", want: "example.io"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.domain, func(t *testing.T) {
+			assert.Equal(t, tt.want, mappings.remap(tt.domain))
+		})
+	}
+}
+
+func TestInstanceDomainMappings_remapURI(t *testing.T) {
+	mappings := instanceDomainMappings{
+		{From: "old.example.com", To: "new.example.com"},
+	}
+	tests := []struct {
+		uri  string
+		want string
+	}{
+		{uri: "https://old.example.com/ui/console/auth/callback", want: "https://new.example.com/ui/console/auth/callback"},
+		{uri: "http://app.old.example.com:8080/callback?a=b", want: "http://app.new.example.com:8080/callback?a=b"},
+		{uri: "https://other.example.com/callback", want: "https://other.example.com/callback"},
+		{uri: "com.example.app:/callback", want: "com.example.app:/callback"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.uri, func(t *testing.T) {
+			assert.Equal(t, tt.want, mappings.remapURI(tt.uri))
+		})
+	}
+}
+
+func TestCommands_pushImportedEvents(t *testing.T) {
+	importer := &instanceImporter{
+		oldInstanceID: "instance1",
+		newInstanceID: "instance2",
+	}
+	cmds, err := importer.commands(testInstanceArchive())
+	assert.NoError(t, err)
+
+	c := &Commands{
+		eventstore: eventstoreExpect(t,
+			expectPush(
+				[]*repository.Event{
+					importedRepositoryEvent(string(instance.AggregateType), "instance2", "instance2", string(instance.AggregateVersion), string(instance.InstanceAddedEventType), `{"name":"name"}`),
+					importedRepositoryEvent(string(instance.AggregateType), "instance2", "instance2", string(instance.AggregateVersion), string(instance.InstanceDomainAddedEventType), `{"domain":"old.example.com"}`),
+				},
+				&repository.UniqueConstraint{
+					UniqueType:   org.UniqueOrgDomain,
+					UniqueField:  "org.old.example.com",
+					InstanceID:   "instance2",
+					Action:       repository.UniqueConstraintAdd,
+					ErrorMessage: "Errors.Instance.Archive.UniqueConstraintViolated",
+				},
+				&repository.UniqueConstraint{
+					UniqueType:   "member",
+					UniqueField:  "instance2:user1",
+					InstanceID:   "instance2",
+					Action:       repository.UniqueConstraintAdd,
+					ErrorMessage: "Errors.Instance.Archive.UniqueConstraintViolated",
+				},
+				&repository.UniqueConstraint{
+					UniqueType:   instance.UniqueInstanceDomain,
+					UniqueField:  "old.example.com",
+					Action:       repository.UniqueConstraintAdd,
+					ErrorMessage: "Errors.Instance.Domain.AlreadyExists",
+				},
+			),
+			expectPush(
+				[]*repository.Event{
+					importedRepositoryEvent(string(org.AggregateType), "org1", "org1", string(org.AggregateVersion), string(org.OrgDomainAddedEventType), `{"domain":"org.old.example.com"}`),
+				},
+			),
+		),
+	}
+	events, err := c.pushImportedEvents(authz.WithInstanceID(context.Background(), "instance2"), "instance2", cmds, 2)
+	assert.NoError(t, err)
+	assert.Len(t, events, 3)
+}
@@ -60,7 +60,7 @@ func (wm *InstanceWriteModel) Reduce() error {
 			wm.DefaultLanguage = e.Language
 		}
 	}
-	return nil
+	return wm.WriteModel.Reduce()
 }
 
 func (wm *InstanceWriteModel) Query() *eventstore.SearchQueryBuilder {
@@ -81,6 +81,16 @@ func (wm *InstanceWriteModel) Query() *eventstore.SearchQueryBuilder {
 		Builder()
 }
 
+// SnapshotKey implements eventstore.SnapshotReducer
+func (wm *InstanceWriteModel) SnapshotKey() (eventstore.AggregateType, string, string) {
+	return instance.AggregateType, wm.AggregateID, "instance"
+}
+
+// SnapshotVersion implements eventstore.SnapshotReducer
+func (wm *InstanceWriteModel) SnapshotVersion() uint16 {
+	return 1
+}
+
 func InstanceAggregateFromWriteModel(wm *eventstore.WriteModel) *eventstore.Aggregate {
 	return eventstore.AggregateFromWriteModel(wm, instance.AggregateType, instance.AggregateVersion)
 }
@@ -0,0 +1,143 @@
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/eventstore/repository/mock"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+)
+
+func TestInstanceWriteModel_snapshots(t *testing.T) {
+	ctx := authz.WithInstanceID(context.Background(), "INSTANCE")
+	agg := &instance.NewAggregate("INSTANCE").Aggregate
+	instanceEvent := func(sequence uint64, event eventstore.Command) *repository.Event {
+		e := eventFromEventPusherWithInstanceID("INSTANCE", event)
+		e.Sequence = sequence
+		e.CreationDate = time.Now().Add(-time.Hour)
+		return e
+	}
+	type snapshot struct {
+		sequence uint64
+		data     string
+		outdated bool
+	}
+	tests := []struct {
+		name             string
+		snapshot         *snapshot
+		events           []*repository.Event
+		wantName         string
+		wantDomains      []string
+		wantSequence     uint64
+		wantStoredSeq    uint64
+		wantStoredDomain string
+	}{
+		{
+			name: "no snapshot, snapshot stored",
+			events: []*repository.Event{
+				instanceEvent(1, instance.NewInstanceAddedEvent(ctx, agg, "instance")),
+				instanceEvent(2, instance.NewDomainAddedEvent(ctx, agg, "instance.domain", true)),
+			},
+			wantName:         "instance",
+			wantDomains:      []string{"instance.domain"},
+			wantSequence:     2,
+			wantStoredSeq:    2,
+			wantStoredDomain: "instance.domain",
+		},
+		{
+			name:     "snapshot restored, newer events reduced",
+			snapshot: &snapshot{sequence: 10, data: `{"Name":"instance","State":1,"GeneratedDomain":"instance.domain","Domains":["instance.domain"]}`},
+			events: []*repository.Event{
+				instanceEvent(11, instance.NewInstanceChangedEvent(ctx, agg, "renamed")),
+			},
+			wantName:     "renamed",
+			wantDomains:  []string{"instance.domain"},
+			wantSequence: 11,
+		},
+		{
+			name:     "snapshot of other version ignored",
+			snapshot: &snapshot{sequence: 10, data: `{"Name":"outdated"}`, outdated: true},
+			events: []*repository.Event{
+				instanceEvent(1, instance.NewInstanceAddedEvent(ctx, agg, "instance")),
+				instanceEvent(2, instance.NewDomainAddedEvent(ctx, agg, "instance.domain", true)),
+			},
+			wantName:         "instance",
+			wantDomains:      []string{"instance.domain"},
+			wantSequence:     2,
+			wantStoredSeq:    2,
+			wantStoredDomain: "instance.domain",
+		},
+		{
+			name: "sequence unknown, no snapshot stored",
+			events: []*repository.Event{
+				instanceEvent(0, instance.NewInstanceAddedEvent(ctx, agg, "instance")),
+				instanceEvent(0, instance.NewDomainAddedEvent(ctx, agg, "instance.domain", true)),
+			},
+			wantName:    "instance",
+			wantDomains: []string{"instance.domain"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mock.NewRepo(t)
+			var version string
+			repo.EXPECT().Snapshot(gomock.Any(), "INSTANCE", repository.AggregateType(instance.AggregateType), "INSTANCE", "instance", gomock.Any()).DoAndReturn(
+				func(_ context.Context, _ string, _ repository.AggregateType, _, _, v string) (*repository.Snapshot, error) {
+					version = v
+					if tt.snapshot == nil || tt.snapshot.outdated {
+						return nil, caos_errs.ThrowNotFound(nil, "SQL-Gs8ka", "Errors.NotFound")
+					}
+					return &repository.Snapshot{
+						Version:  v,
+						Sequence: tt.snapshot.sequence,
+						Data:     []byte(tt.snapshot.data),
+					}, nil
+				},
+			)
+			repo.ExpectFilterEvents(tt.events...)
+			var stored *repository.Snapshot
+			if tt.wantStoredSeq > 0 {
+				repo.EXPECT().StoreSnapshot(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, snapshot *repository.Snapshot) error {
+						stored = snapshot
+						return nil
+					},
+				)
+			}
+			config := eventstore.TestConfig(repo)
+			config.Snapshots = eventstore.SnapshotConfig{Enabled: true, Interval: 2}
+			es := eventstore.NewEventstore(config)
+			instance.RegisterEventMappers(es)
+
+			wm := NewInstanceWriteModel("INSTANCE")
+			require.NoError(t, es.FilterToQueryReducer(ctx, wm))
+			assert.Equal(t, tt.wantName, wm.Name)
+			assert.Equal(t, domain.InstanceStateActive, wm.State)
+			assert.Equal(t, tt.wantDomains, wm.Domains)
+			assert.Equal(t, tt.wantSequence, wm.ProcessedSequence)
+			assert.Regexp(t, `^1-[0-9a-f]{16}$`, version)
+
+			if tt.wantStoredSeq == 0 {
+				assert.Nil(t, stored)
+				return
+			}
+			require.NotNil(t, stored)
+			assert.Equal(t, tt.wantStoredSeq, stored.Sequence)
+			assert.Equal(t, version, stored.Version)
+			restored := NewInstanceWriteModel("INSTANCE")
+			require.NoError(t, json.Unmarshal(stored.Data, restored))
+			assert.Equal(t, tt.wantStoredDomain, restored.GeneratedDomain)
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package command
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+)
+
+type InstanceClientRegistrationPolicyWriteModel struct {
+	eventstore.WriteModel
+
+	AllowedGrantTypes   []domain.OIDCGrantType
+	RedirectURIPatterns []string
+}
+
+func NewInstanceClientRegistrationPolicyWriteModel(ctx context.Context) *InstanceClientRegistrationPolicyWriteModel {
+	return &InstanceClientRegistrationPolicyWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   authz.GetInstance(ctx).InstanceID(),
+			ResourceOwner: authz.GetInstance(ctx).InstanceID(),
+		},
+	}
+}
+
+func (wm *InstanceClientRegistrationPolicyWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		if e, ok := event.(*instance.ClientRegistrationPolicySetEvent); ok {
+			if e.AllowedGrantTypes != nil {
+				wm.AllowedGrantTypes = *e.AllowedGrantTypes
+			}
+			if e.RedirectURIPatterns != nil {
+				wm.RedirectURIPatterns = *e.RedirectURIPatterns
+			}
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *InstanceClientRegistrationPolicyWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(instance.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			instance.ClientRegistrationPolicySetEventType).
+		Builder()
+}
+
+func (wm *InstanceClientRegistrationPolicyWriteModel) Policy() *domain.ClientRegistrationPolicy {
+	return &domain.ClientRegistrationPolicy{
+		AllowedGrantTypes:   wm.AllowedGrantTypes,
+		RedirectURIPatterns: wm.RedirectURIPatterns,
+	}
+}
+
+func (wm *InstanceClientRegistrationPolicyWriteModel) NewSetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	allowedGrantTypes []domain.OIDCGrantType,
+	redirectURIPatterns []string,
+) (*instance.ClientRegistrationPolicySetEvent, error) {
+	changes := make([]instance.ClientRegistrationPolicyChanges, 0, 2)
+	if (len(wm.AllowedGrantTypes) != 0 || len(allowedGrantTypes) != 0) && !reflect.DeepEqual(wm.AllowedGrantTypes, allowedGrantTypes) {
+		changes = append(changes, instance.ChangeClientRegistrationPolicyAllowedGrantTypes(allowedGrantTypes))
+	}
+	if (len(wm.RedirectURIPatterns) != 0 || len(redirectURIPatterns) != 0) && !reflect.DeepEqual(wm.RedirectURIPatterns, redirectURIPatterns) {
+		changes = append(changes, instance.ChangeClientRegistrationPolicyRedirectURIPatterns(redirectURIPatterns))
+	}
+	return instance.NewClientRegistrationPolicySetEvent(ctx, aggregate, changes)
+}
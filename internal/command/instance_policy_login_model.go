@@ -58,6 +58,16 @@ func (wm *InstanceLoginPolicyWriteModel) Query() *eventstore.SearchQueryBuilder
 		Builder()
 }
 
+// SnapshotKey implements eventstore.SnapshotReducer
+func (wm *InstanceLoginPolicyWriteModel) SnapshotKey() (eventstore.AggregateType, string, string) {
+	return instance.AggregateType, wm.AggregateID, "login_policy"
+}
+
+// SnapshotVersion implements eventstore.SnapshotReducer
+func (wm *InstanceLoginPolicyWriteModel) SnapshotVersion() uint16 {
+	return 1
+}
+
 func (wm *InstanceLoginPolicyWriteModel) NewChangedEvent(
 	ctx context.Context,
 	aggregate *eventstore.Aggregate,
@@ -0,0 +1,71 @@
+package command
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/notification/templates"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+)
+
+// SetDefaultMessageMailTemplate sets the mail template used for emails of the message type of the instance.
+// MJML templates are compiled to HTML, the template is validated before it's stored.
+func (c *Commands) SetDefaultMessageMailTemplate(ctx context.Context, template *domain.MessageMailTemplate) (*domain.ObjectDetails, error) {
+	if !template.IsValid() {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "INSTANCE-Mt5qa", "Errors.IAM.MailTemplate.MessageTemplate.Invalid")
+	}
+	html, err := templates.CompileMessageTemplate(template.Format, template.Source)
+	if err != nil {
+		return nil, err
+	}
+	existingTemplate := NewInstanceMessageMailTemplateWriteModel(ctx, template.MessageType)
+	err = c.eventstore.FilterToQueryReducer(ctx, existingTemplate)
+	if err != nil {
+		return nil, err
+	}
+	if existingTemplate.State == domain.PolicyStateActive &&
+		existingTemplate.Format == template.Format &&
+		bytes.Equal(existingTemplate.Source, template.Source) {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "INSTANCE-Mt3hb", "Errors.IAM.MailTemplate.NotChanged")
+	}
+
+	instanceAgg := InstanceAggregateFromWriteModel(&existingTemplate.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, instance.NewMessageMailTemplateSetEvent(ctx, instanceAgg, template.MessageType, template.Format, template.Source, html))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingTemplate, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingTemplate.WriteModel), nil
+}
+
+// RemoveDefaultMessageMailTemplate removes the mail template of the message type of the instance,
+// the mail template is used afterwards
+func (c *Commands) RemoveDefaultMessageMailTemplate(ctx context.Context, messageType string) (*domain.ObjectDetails, error) {
+	if !domain.IsMailMessageType(messageType) {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "INSTANCE-Mr7ce", "Errors.IAM.MailTemplate.MessageTemplate.Invalid")
+	}
+	existingTemplate := NewInstanceMessageMailTemplateWriteModel(ctx, messageType)
+	err := c.eventstore.FilterToQueryReducer(ctx, existingTemplate)
+	if err != nil {
+		return nil, err
+	}
+	if existingTemplate.State != domain.PolicyStateActive {
+		return nil, caos_errs.ThrowNotFound(nil, "INSTANCE-Mr2sj", "Errors.IAM.MailTemplate.MessageTemplate.NotFound")
+	}
+
+	instanceAgg := InstanceAggregateFromWriteModel(&existingTemplate.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, instance.NewMessageMailTemplateRemovedEvent(ctx, instanceAgg, messageType))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingTemplate, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingTemplate.WriteModel), nil
+}
@@ -0,0 +1,52 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+)
+
+type InstanceMessageMailTemplateWriteModel struct {
+	MessageMailTemplateWriteModel
+}
+
+func NewInstanceMessageMailTemplateWriteModel(ctx context.Context, messageType string) *InstanceMessageMailTemplateWriteModel {
+	return &InstanceMessageMailTemplateWriteModel{
+		MessageMailTemplateWriteModel{
+			WriteModel: eventstore.WriteModel{
+				AggregateID:   authz.GetInstance(ctx).InstanceID(),
+				ResourceOwner: authz.GetInstance(ctx).InstanceID(),
+			},
+			MessageType: messageType,
+		},
+	}
+}
+
+func (wm *InstanceMessageMailTemplateWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *instance.MessageMailTemplateSetEvent:
+			wm.MessageMailTemplateWriteModel.AppendEvents(&e.MessageMailTemplateSetEvent)
+		case *instance.MessageMailTemplateRemovedEvent:
+			wm.MessageMailTemplateWriteModel.AppendEvents(&e.MessageMailTemplateRemovedEvent)
+		}
+	}
+}
+
+func (wm *InstanceMessageMailTemplateWriteModel) Reduce() error {
+	return wm.MessageMailTemplateWriteModel.Reduce()
+}
+
+func (wm *InstanceMessageMailTemplateWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(instance.AggregateType).
+		AggregateIDs(wm.MessageMailTemplateWriteModel.AggregateID).
+		EventTypes(
+			instance.MessageMailTemplateSetEventType,
+			instance.MessageMailTemplateRemovedEventType).
+		Builder()
+}
@@ -0,0 +1,276 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+)
+
+func TestCommandSide_SetDefaultMessageMailTemplate(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx      context.Context
+		template *domain.MessageMailTemplate
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "sms message type, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				template: &domain.MessageMailTemplate{
+					MessageType: domain.VerifyPhoneMessageType,
+					Format:      domain.MessageTemplateFormatHTML,
+					Source:      []byte("<p>{{.Text}}</p>"),
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "invalid mjml, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				template: &domain.MessageMailTemplate{
+					MessageType: domain.InitCodeMessageType,
+					Format:      domain.MessageTemplateFormatMJML,
+					Source:      []byte("<mjml><mj-body><mj-text>{{.Text}}</mj-text></mj-body></mjml>"),
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "template not changed, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewMessageMailTemplateSetEvent(context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								domain.InitCodeMessageType,
+								domain.MessageTemplateFormatHTML,
+								[]byte("<p>{{.Text}}</p>"),
+								[]byte("<p>{{.Text}}</p>"),
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				template: &domain.MessageMailTemplate{
+					MessageType: domain.InitCodeMessageType,
+					Format:      domain.MessageTemplateFormatHTML,
+					Source:      []byte("<p>{{.Text}}</p>"),
+				},
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "set template, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewMessageMailTemplateSetEvent(context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								domain.InitCodeMessageType,
+								domain.MessageTemplateFormatHTML,
+								[]byte("<p>{{.Text}}</p>"),
+								[]byte("<p>{{.Text}}</p>"),
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusherWithInstanceID(
+								"INSTANCE",
+								instance.NewMessageMailTemplateSetEvent(context.Background(),
+									&instance.NewAggregate("INSTANCE").Aggregate,
+									domain.InitCodeMessageType,
+									domain.MessageTemplateFormatHTML,
+									[]byte("<p>{{.Greeting}}</p>"),
+									[]byte("<p>{{.Greeting}}</p>"),
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				template: &domain.MessageMailTemplate{
+					MessageType: domain.InitCodeMessageType,
+					Format:      domain.MessageTemplateFormatHTML,
+					Source:      []byte("<p>{{.Greeting}}</p>"),
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "INSTANCE",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.SetDefaultMessageMailTemplate(tt.args.ctx, tt.args.template)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_RemoveDefaultMessageMailTemplate(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx         context.Context
+		messageType string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "template not existing, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewMessageMailTemplateSetEvent(context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								domain.InitCodeMessageType,
+								domain.MessageTemplateFormatHTML,
+								[]byte("<p>{{.Text}}</p>"),
+								[]byte("<p>{{.Text}}</p>"),
+							),
+						),
+						eventFromEventPusher(
+							instance.NewMessageMailTemplateRemovedEvent(context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								domain.InitCodeMessageType,
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:         authz.WithInstanceID(context.Background(), "INSTANCE"),
+				messageType: domain.InitCodeMessageType,
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "remove template, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewMessageMailTemplateSetEvent(context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								domain.InitCodeMessageType,
+								domain.MessageTemplateFormatHTML,
+								[]byte("<p>{{.Text}}</p>"),
+								[]byte("<p>{{.Text}}</p>"),
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusherWithInstanceID(
+								"INSTANCE",
+								instance.NewMessageMailTemplateRemovedEvent(context.Background(),
+									&instance.NewAggregate("INSTANCE").Aggregate,
+									domain.InitCodeMessageType,
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:         authz.WithInstanceID(context.Background(), "INSTANCE"),
+				messageType: domain.InitCodeMessageType,
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "INSTANCE",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.RemoveDefaultMessageMailTemplate(tt.args.ctx, tt.args.messageType)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
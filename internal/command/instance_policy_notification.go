@@ -10,9 +10,9 @@ import (
 	"github.com/zitadel/zitadel/internal/repository/instance"
 )
 
-func (c *Commands) AddDefaultNotificationPolicy(ctx context.Context, resourceOwner string, passwordChange bool) (*domain.ObjectDetails, error) {
+func (c *Commands) AddDefaultNotificationPolicy(ctx context.Context, resourceOwner string, passwordChange, securityNotifications bool) (*domain.ObjectDetails, error) {
 	instanceAgg := instance.NewAggregate(resourceOwner)
-	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, prepareAddDefaultNotificationPolicy(instanceAgg, passwordChange))
+	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, prepareAddDefaultNotificationPolicy(instanceAgg, passwordChange, securityNotifications))
 	if err != nil {
 		return nil, err
 	}
@@ -23,9 +23,9 @@ func (c *Commands) AddDefaultNotificationPolicy(ctx context.Context, resourceOwn
 	return pushedEventsToObjectDetails(pushedEvents), nil
 }
 
-func (c *Commands) ChangeDefaultNotificationPolicy(ctx context.Context, resourceOwner string, passwordChange bool) (*domain.ObjectDetails, error) {
+func (c *Commands) ChangeDefaultNotificationPolicy(ctx context.Context, resourceOwner string, passwordChange, securityNotifications bool) (*domain.ObjectDetails, error) {
 	instanceAgg := instance.NewAggregate(resourceOwner)
-	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, prepareChangeDefaultNotificationPolicy(instanceAgg, passwordChange))
+	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, prepareChangeDefaultNotificationPolicy(instanceAgg, passwordChange, securityNotifications))
 	if err != nil {
 		return nil, err
 	}
@@ -38,7 +38,8 @@ func (c *Commands) ChangeDefaultNotificationPolicy(ctx context.Context, resource
 
 func prepareAddDefaultNotificationPolicy(
 	a *instance.Aggregate,
-	passwordChange bool,
+	passwordChange,
+	securityNotifications bool,
 ) preparation.Validation {
 	return func() (preparation.CreateCommands, error) {
 		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
@@ -55,7 +56,7 @@ func prepareAddDefaultNotificationPolicy(
 				return nil, caos_errs.ThrowAlreadyExists(nil, "INSTANCE-xpo1bj", "Errors.Instance.NotificationPolicy.AlreadyExists")
 			}
 			return []eventstore.Command{
-				instance.NewNotificationPolicyAddedEvent(ctx, &a.Aggregate, passwordChange),
+				instance.NewNotificationPolicyAddedEvent(ctx, &a.Aggregate, passwordChange, securityNotifications),
 			}, nil
 		}, nil
 	}
@@ -63,7 +64,8 @@ func prepareAddDefaultNotificationPolicy(
 
 func prepareChangeDefaultNotificationPolicy(
 	a *instance.Aggregate,
-	passwordChange bool,
+	passwordChange,
+	securityNotifications bool,
 ) preparation.Validation {
 	return func() (preparation.CreateCommands, error) {
 		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
@@ -80,7 +82,7 @@ func prepareChangeDefaultNotificationPolicy(
 			if writeModel.State == domain.PolicyStateUnspecified || writeModel.State == domain.PolicyStateRemoved {
 				return nil, caos_errs.ThrowNotFound(nil, "INSTANCE-x891na", "Errors.IAM.NotificationPolicy.NotFound")
 			}
-			change, hasChanged := writeModel.NewChangedEvent(ctx, &a.Aggregate, passwordChange)
+			change, hasChanged := writeModel.NewChangedEvent(ctx, &a.Aggregate, passwordChange, securityNotifications)
 			if !hasChanged {
 				return nil, caos_errs.ThrowPreconditionFailed(nil, "INSTANCE-29x02n", "Errors.IAM.NotificationPolicy.NotChanged")
 			}
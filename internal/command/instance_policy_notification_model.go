@@ -54,13 +54,17 @@ func (wm *InstanceNotificationPolicyWriteModel) Query() *eventstore.SearchQueryB
 func (wm *InstanceNotificationPolicyWriteModel) NewChangedEvent(
 	ctx context.Context,
 	aggregate *eventstore.Aggregate,
-	passwordChange bool,
+	passwordChange,
+	securityNotifications bool,
 ) (*instance.NotificationPolicyChangedEvent, bool) {
 
 	changes := make([]policy.NotificationPolicyChanges, 0)
 	if wm.PasswordChange != passwordChange {
 		changes = append(changes, policy.ChangePasswordChange(passwordChange))
 	}
+	if wm.SecurityNotifications != securityNotifications {
+		changes = append(changes, policy.ChangeSecurityNotifications(securityNotifications))
+	}
 	if len(changes) == 0 {
 		return nil, false
 	}
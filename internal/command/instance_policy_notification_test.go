@@ -19,9 +19,10 @@ func TestCommandSide_AddDefaultNotificationPolicy(t *testing.T) {
 		eventstore *eventstore.Eventstore
 	}
 	type args struct {
-		ctx            context.Context
-		resourceOwner  string
-		passwordChange bool
+		ctx                   context.Context
+		resourceOwner         string
+		passwordChange        bool
+		securityNotifications bool
 	}
 	type res struct {
 		want *domain.ObjectDetails
@@ -43,6 +44,7 @@ func TestCommandSide_AddDefaultNotificationPolicy(t *testing.T) {
 							instance.NewNotificationPolicyAddedEvent(context.Background(),
 								&instance.NewAggregate("INSTANCE").Aggregate,
 								true,
+								false,
 							),
 						),
 					),
@@ -69,6 +71,7 @@ func TestCommandSide_AddDefaultNotificationPolicy(t *testing.T) {
 								instance.NewNotificationPolicyAddedEvent(context.Background(),
 									&instance.NewAggregate("INSTANCE").Aggregate,
 									true,
+									false,
 								),
 							),
 						},
@@ -98,6 +101,7 @@ func TestCommandSide_AddDefaultNotificationPolicy(t *testing.T) {
 								instance.NewNotificationPolicyAddedEvent(context.Background(),
 									&instance.NewAggregate("INSTANCE").Aggregate,
 									true,
+									false,
 								),
 							),
 						},
@@ -121,7 +125,7 @@ func TestCommandSide_AddDefaultNotificationPolicy(t *testing.T) {
 			r := &Commands{
 				eventstore: tt.fields.eventstore,
 			}
-			got, err := r.AddDefaultNotificationPolicy(tt.args.ctx, tt.args.resourceOwner, tt.args.passwordChange)
+			got, err := r.AddDefaultNotificationPolicy(tt.args.ctx, tt.args.resourceOwner, tt.args.passwordChange, tt.args.securityNotifications)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -140,9 +144,10 @@ func TestCommandSide_ChangeDefaultNotificationPolicy(t *testing.T) {
 		eventstore *eventstore.Eventstore
 	}
 	type args struct {
-		ctx            context.Context
-		resourceOwner  string
-		passwordChange bool
+		ctx                   context.Context
+		resourceOwner         string
+		passwordChange        bool
+		securityNotifications bool
 	}
 	type res struct {
 		want *domain.ObjectDetails
@@ -181,6 +186,7 @@ func TestCommandSide_ChangeDefaultNotificationPolicy(t *testing.T) {
 							instance.NewNotificationPolicyAddedEvent(context.Background(),
 								&instance.NewAggregate("INSTANCE").Aggregate,
 								true,
+								false,
 							),
 						),
 					),
@@ -205,6 +211,7 @@ func TestCommandSide_ChangeDefaultNotificationPolicy(t *testing.T) {
 							instance.NewNotificationPolicyAddedEvent(context.Background(),
 								&instance.NewAggregate("INSTANCE").Aggregate,
 								false,
+								false,
 							),
 						),
 					),
@@ -213,15 +220,17 @@ func TestCommandSide_ChangeDefaultNotificationPolicy(t *testing.T) {
 							eventFromEventPusher(
 								newDefaultNotificationPolicyChangedEvent(context.Background(),
 									true,
+									true,
 								)),
 						},
 					),
 				),
 			},
 			args: args{
-				ctx:            context.Background(),
-				resourceOwner:  "INSTANCE",
-				passwordChange: true,
+				ctx:                   context.Background(),
+				resourceOwner:         "INSTANCE",
+				passwordChange:        true,
+				securityNotifications: true,
 			},
 			res: res{
 				want: &domain.ObjectDetails{
@@ -235,7 +244,7 @@ func TestCommandSide_ChangeDefaultNotificationPolicy(t *testing.T) {
 			r := &Commands{
 				eventstore: tt.fields.eventstore,
 			}
-			got, err := r.ChangeDefaultNotificationPolicy(tt.args.ctx, tt.args.resourceOwner, tt.args.passwordChange)
+			got, err := r.ChangeDefaultNotificationPolicy(tt.args.ctx, tt.args.resourceOwner, tt.args.passwordChange, tt.args.securityNotifications)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -249,11 +258,12 @@ func TestCommandSide_ChangeDefaultNotificationPolicy(t *testing.T) {
 	}
 }
 
-func newDefaultNotificationPolicyChangedEvent(ctx context.Context, passwordChange bool) *instance.NotificationPolicyChangedEvent {
+func newDefaultNotificationPolicyChangedEvent(ctx context.Context, passwordChange, securityNotifications bool) *instance.NotificationPolicyChangedEvent {
 	event, _ := instance.NewNotificationPolicyChangedEvent(ctx,
 		&instance.NewAggregate("INSTANCE").Aggregate,
 		[]policy.NotificationPolicyChanges{
 			policy.ChangePasswordChange(passwordChange),
+			policy.ChangeSecurityNotifications(securityNotifications),
 		},
 	)
 	return event
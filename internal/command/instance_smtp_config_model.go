@@ -18,6 +18,7 @@ type InstanceSMTPConfigWriteModel struct {
 	Host          string
 	User          string
 	Password      *crypto.CryptoValue
+	Priority      int32
 	State         domain.SMTPConfigState
 
 	domain                                 string
@@ -65,6 +66,7 @@ func (wm *InstanceSMTPConfigWriteModel) Reduce() error {
 			wm.Host = e.Host
 			wm.User = e.User
 			wm.Password = e.Password
+			wm.Priority = e.Priority
 			wm.State = domain.SMTPConfigStateActive
 		case *instance.SMTPConfigChangedEvent:
 			if e.TLS != nil {
@@ -82,6 +84,9 @@ func (wm *InstanceSMTPConfigWriteModel) Reduce() error {
 			if e.User != nil {
 				wm.User = *e.User
 			}
+			if e.Priority != nil {
+				wm.Priority = *e.Priority
+			}
 		case *instance.SMTPConfigRemovedEvent:
 			wm.State = domain.SMTPConfigStateRemoved
 			wm.TLS = false
@@ -90,6 +95,7 @@ func (wm *InstanceSMTPConfigWriteModel) Reduce() error {
 			wm.Host = ""
 			wm.User = ""
 			wm.Password = nil
+			wm.Priority = 0
 		case *instance.DomainAddedEvent:
 			wm.domainState = domain.InstanceDomainStateActive
 		case *instance.DomainRemovedEvent:
@@ -122,7 +128,7 @@ func (wm *InstanceSMTPConfigWriteModel) Query() *eventstore.SearchQueryBuilder {
 		Builder()
 }
 
-func (wm *InstanceSMTPConfigWriteModel) NewChangedEvent(ctx context.Context, aggregate *eventstore.Aggregate, tls bool, fromAddress, fromName, smtpHost, smtpUser string) (*instance.SMTPConfigChangedEvent, bool, error) {
+func (wm *InstanceSMTPConfigWriteModel) NewChangedEvent(ctx context.Context, aggregate *eventstore.Aggregate, tls bool, fromAddress, fromName, smtpHost, smtpUser string, priority int32) (*instance.SMTPConfigChangedEvent, bool, error) {
 	changes := make([]instance.SMTPConfigChanges, 0)
 	var err error
 
@@ -141,6 +147,9 @@ func (wm *InstanceSMTPConfigWriteModel) NewChangedEvent(ctx context.Context, agg
 	if wm.User != smtpUser {
 		changes = append(changes, instance.ChangeSMTPConfigSMTPUser(smtpUser))
 	}
+	if wm.Priority != priority {
+		changes = append(changes, instance.ChangeSMTPConfigPriority(priority))
+	}
 
 	if len(changes) == 0 {
 		return nil, false, nil
@@ -16,8 +16,10 @@ import (
 	"github.com/zitadel/zitadel/internal/eventstore/repository"
 	"github.com/zitadel/zitadel/internal/eventstore/repository/mock"
 	action_repo "github.com/zitadel/zitadel/internal/repository/action"
+	"github.com/zitadel/zitadel/internal/repository/idpsync"
 	iam_repo "github.com/zitadel/zitadel/internal/repository/instance"
 	key_repo "github.com/zitadel/zitadel/internal/repository/keypair"
+	"github.com/zitadel/zitadel/internal/repository/notification"
 	"github.com/zitadel/zitadel/internal/repository/org"
 	proj_repo "github.com/zitadel/zitadel/internal/repository/project"
 	"github.com/zitadel/zitadel/internal/repository/session"
@@ -41,6 +43,8 @@ func eventstoreExpect(t *testing.T, expects ...expect) *eventstore.Eventstore {
 	key_repo.RegisterEventMappers(es)
 	action_repo.RegisterEventMappers(es)
 	session.RegisterEventMappers(es)
+	notification.RegisterEventMappers(es)
+	idpsync.RegisterEventMappers(es)
 	return es
 }
 
@@ -123,6 +127,18 @@ func expectPushFailed(err error, events []*repository.Event, uniqueConstraints .
 	}
 }
 
+func expectCreateInstance(instanceID string) expect {
+	return func(m *mock.MockRepository) {
+		m.ExpectCreateInstance(instanceID)
+	}
+}
+
+func expectUniqueConstraints(constraints ...*repository.UniqueConstraint) expect {
+	return func(m *mock.MockRepository) {
+		m.ExpectUniqueConstraints(constraints...)
+	}
+}
+
 func expectFilter(events ...*repository.Event) expect {
 	return func(m *mock.MockRepository) {
 		m.ExpectFilterEvents(events...)
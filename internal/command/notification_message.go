@@ -0,0 +1,203 @@
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/notification"
+)
+
+// NotificationMessage is a rendered email or SMS of the notification outbox
+type NotificationMessage struct {
+	ID              string
+	UserID          string
+	ResourceOwner   string
+	MessageType     string
+	Channel         domain.NotificationType
+	Recipient       string
+	Subject         string
+	Content         string
+	TriggeringEvent eventstore.Event
+}
+
+type notificationMessageContent struct {
+	Subject string `json:"subject,omitempty"`
+	Content string `json:"content"`
+}
+
+// QueueNotificationMessage persists the message in the notification outbox, it's delivered asynchronously.
+// If a message of the same type was already queued for the triggering event, it's not queued again.
+func (c *Commands) QueueNotificationMessage(ctx context.Context, message *NotificationMessage) error {
+	if message.UserID == "" || message.Recipient == "" || message.TriggeringEvent == nil {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Nq4vd", "Errors.Notification.Outbox.Invalid")
+	}
+	id, err := c.idGenerator.Next()
+	if err != nil {
+		return err
+	}
+	content, err := json.Marshal(&notificationMessageContent{Subject: message.Subject, Content: message.Content})
+	if err != nil {
+		return caos_errs.ThrowInternal(err, "COMMAND-Nq8sx", "Errors.Internal")
+	}
+	encryptedContent, err := crypto.Encrypt(content, c.userEncryption)
+	if err != nil {
+		return err
+	}
+	agg := notification.NewAggregate(id, message.ResourceOwner)
+	_, err = c.eventstore.Push(ctx, notification.NewMessageQueuedEvent(
+		ctx,
+		&agg.Aggregate,
+		message.UserID,
+		message.MessageType,
+		message.Channel,
+		message.Recipient,
+		encryptedContent,
+		message.TriggeringEvent,
+	))
+	if caos_errs.IsErrorAlreadyExists(err) {
+		return nil
+	}
+	return err
+}
+
+// DeliverNotificationMessage sends the message through send, if it's still pending and due.
+// A failed attempt is retried with exponential backoff until the maximum of attempts is reached,
+// the message is marked as failed afterwards.
+func (c *Commands) DeliverNotificationMessage(ctx context.Context, resourceOwner, id string, send func(context.Context, *NotificationMessage) error) error {
+	writeModel, err := c.getNotificationMessageWriteModel(ctx, resourceOwner, id)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	if !writeModel.isDue(now) {
+		return nil
+	}
+	message, err := c.notificationMessageFromWriteModel(writeModel)
+	if err != nil {
+		return err
+	}
+	agg := NotificationMessageAggregateFromWriteModel(&writeModel.WriteModel)
+	attempt := writeModel.Attempts + 1
+	var event eventstore.Command = notification.NewMessageSentEvent(ctx, agg, attempt)
+	if sendErr := send(ctx, message); sendErr != nil {
+		event = notification.NewMessageAttemptFailedEvent(ctx, agg, attempt, sendErr.Error(), now.Add(c.notificationMessageBackoff(attempt)))
+		if attempt >= c.notificationOutbox.MaxAttempts {
+			event = notification.NewMessageFailedEvent(ctx, agg, attempt, sendErr.Error())
+		}
+	}
+	_, err = c.eventstore.Push(ctx, event)
+	return err
+}
+
+// ResendNotificationMessage queues a sent or failed message again,
+// userID is optional and ensures the message was sent to the user
+func (c *Commands) ResendNotificationMessage(ctx context.Context, userID, resourceOwner, id string) (*domain.ObjectDetails, error) {
+	writeModel, err := c.existingNotificationMessage(ctx, userID, resourceOwner, id)
+	if err != nil {
+		return nil, err
+	}
+	if writeModel.State.IsPending() {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Nr3kq", "Errors.Notification.Outbox.AlreadyQueued")
+	}
+	if writeModel.State == domain.NotificationMessageStateDiscarded {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Nr6ml", "Errors.Notification.Outbox.Discarded")
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, notification.NewMessageResendRequestedEvent(ctx, NotificationMessageAggregateFromWriteModel(&writeModel.WriteModel)))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(writeModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+// DiscardNotificationMessage stops the delivery of a pending or failed message,
+// userID is optional and ensures the message was sent to the user
+func (c *Commands) DiscardNotificationMessage(ctx context.Context, userID, resourceOwner, id string) (*domain.ObjectDetails, error) {
+	writeModel, err := c.existingNotificationMessage(ctx, userID, resourceOwner, id)
+	if err != nil {
+		return nil, err
+	}
+	if writeModel.State == domain.NotificationMessageStateSent {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Nd2wa", "Errors.Notification.Outbox.AlreadySent")
+	}
+	if writeModel.State == domain.NotificationMessageStateDiscarded {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Nd7jc", "Errors.Notification.Outbox.Discarded")
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, notification.NewMessageDiscardedEvent(ctx, NotificationMessageAggregateFromWriteModel(&writeModel.WriteModel)))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(writeModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+func (c *Commands) existingNotificationMessage(ctx context.Context, userID, resourceOwner, id string) (*NotificationMessageWriteModel, error) {
+	if id == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Ne5tb", "Errors.IDMissing")
+	}
+	writeModel, err := c.getNotificationMessageWriteModel(ctx, resourceOwner, id)
+	if err != nil {
+		return nil, err
+	}
+	if !writeModel.State.Exists() || userID != "" && writeModel.UserID != userID {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Ne9fz", "Errors.Notification.Outbox.NotFound")
+	}
+	return writeModel, nil
+}
+
+func (c *Commands) notificationMessageFromWriteModel(writeModel *NotificationMessageWriteModel) (*NotificationMessage, error) {
+	decrypted, err := crypto.Decrypt(writeModel.Content, c.userEncryption)
+	if err != nil {
+		return nil, err
+	}
+	content := new(notificationMessageContent)
+	if err = json.Unmarshal(decrypted, content); err != nil {
+		return nil, caos_errs.ThrowInternal(err, "COMMAND-Nc1ue", "Errors.Internal")
+	}
+	return &NotificationMessage{
+		ID:            writeModel.AggregateID,
+		UserID:        writeModel.UserID,
+		ResourceOwner: writeModel.ResourceOwner,
+		MessageType:   writeModel.MessageType,
+		Channel:       writeModel.Channel,
+		Recipient:     writeModel.Recipient,
+		Subject:       content.Subject,
+		Content:       content.Content,
+		// the triggering event is only used for logging and metrics of the channels
+		TriggeringEvent: &eventstore.BaseEvent{
+			EventType: writeModel.TriggerEventType,
+		},
+	}, nil
+}
+
+// notificationMessageBackoff doubles the initial backoff for every failed attempt up to the maximum backoff
+func (c *Commands) notificationMessageBackoff(attempt uint64) time.Duration {
+	backoff := c.notificationOutbox.InitialBackoff
+	for i := uint64(1); i < attempt && backoff < c.notificationOutbox.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	if c.notificationOutbox.MaxBackoff > 0 && backoff > c.notificationOutbox.MaxBackoff {
+		return c.notificationOutbox.MaxBackoff
+	}
+	return backoff
+}
+
+func (c *Commands) getNotificationMessageWriteModel(ctx context.Context, resourceOwner, id string) (*NotificationMessageWriteModel, error) {
+	writeModel := NewNotificationMessageWriteModel(id, resourceOwner)
+	err := c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
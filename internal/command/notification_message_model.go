@@ -0,0 +1,91 @@
+package command
+
+import (
+	"time"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/notification"
+)
+
+type NotificationMessageWriteModel struct {
+	eventstore.WriteModel
+
+	UserID           string
+	MessageType      string
+	Channel          domain.NotificationType
+	Recipient        string
+	Content          *crypto.CryptoValue
+	TriggerEventType eventstore.EventType
+	State            domain.NotificationMessageState
+	Attempts         uint64
+	NextAttempt      time.Time
+}
+
+func NewNotificationMessageWriteModel(id, resourceOwner string) *NotificationMessageWriteModel {
+	return &NotificationMessageWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   id,
+			ResourceOwner: resourceOwner,
+		},
+	}
+}
+
+func (wm *NotificationMessageWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *notification.MessageQueuedEvent:
+			wm.UserID = e.UserID
+			wm.MessageType = e.MessageType
+			wm.Channel = e.Channel
+			wm.Recipient = e.Recipient
+			wm.Content = e.Content
+			wm.TriggerEventType = e.TriggerEventType
+			wm.State = domain.NotificationMessageStateQueued
+		case *notification.MessageSentEvent:
+			wm.Attempts = e.Attempt
+			wm.State = domain.NotificationMessageStateSent
+		case *notification.MessageAttemptFailedEvent:
+			wm.Attempts = e.Attempt
+			wm.NextAttempt = e.NextAttempt
+			wm.State = domain.NotificationMessageStateRetrying
+		case *notification.MessageFailedEvent:
+			wm.Attempts = e.Attempt
+			wm.State = domain.NotificationMessageStateFailed
+		case *notification.MessageResendRequestedEvent:
+			wm.Attempts = 0
+			wm.NextAttempt = time.Time{}
+			wm.State = domain.NotificationMessageStateQueued
+		case *notification.MessageDiscardedEvent:
+			wm.State = domain.NotificationMessageStateDiscarded
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *NotificationMessageWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(notification.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			notification.MessageQueuedType,
+			notification.MessageSentType,
+			notification.MessageAttemptFailedType,
+			notification.MessageFailedType,
+			notification.MessageResendRequestedType,
+			notification.MessageDiscardedType,
+		).
+		Builder()
+}
+
+// isDue returns true if the message is pending and the backoff of the last failed attempt is over
+func (wm *NotificationMessageWriteModel) isDue(now time.Time) bool {
+	return wm.State.IsPending() && !wm.NextAttempt.After(now)
+}
+
+func NotificationMessageAggregateFromWriteModel(wm *eventstore.WriteModel) *eventstore.Aggregate {
+	return eventstore.AggregateFromWriteModel(wm, notification.AggregateType, notification.AggregateVersion)
+}
@@ -0,0 +1,480 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/config/systemdefaults"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/id"
+	id_mock "github.com/zitadel/zitadel/internal/id/mock"
+	"github.com/zitadel/zitadel/internal/repository/notification"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+func testNotificationTriggeringEvent() eventstore.Event {
+	return eventstore.BaseEventFromRepo(&repository.Event{
+		AggregateID:   "user1",
+		AggregateType: user.AggregateType,
+		Sequence:      5,
+		Type:          repository.EventType(user.HumanInitialCodeAddedType),
+	})
+}
+
+func testNotificationMessageContent() *crypto.CryptoValue {
+	return &crypto.CryptoValue{
+		CryptoType: crypto.TypeEncryption,
+		Algorithm:  "enc",
+		KeyID:      "id",
+		Crypted:    []byte(`{"subject":"Subject","content":"Content"}`),
+	}
+}
+
+func testNotificationMessageQueuedEvent() *notification.MessageQueuedEvent {
+	return notification.NewMessageQueuedEvent(
+		context.Background(),
+		&notification.NewAggregate("message1", "org1").Aggregate,
+		"user1",
+		domain.InitCodeMessageType,
+		domain.NotificationTypeEmail,
+		"user@example.com",
+		testNotificationMessageContent(),
+		testNotificationTriggeringEvent(),
+	)
+}
+
+func TestCommandSide_QueueNotificationMessage(t *testing.T) {
+	type fields struct {
+		eventstore  *eventstore.Eventstore
+		idGenerator id.Generator
+	}
+	type args struct {
+		ctx     context.Context
+		message *NotificationMessage
+	}
+	type res struct {
+		err func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "recipient missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx: context.Background(),
+				message: &NotificationMessage{
+					UserID:          "user1",
+					ResourceOwner:   "org1",
+					TriggeringEvent: testNotificationTriggeringEvent(),
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "queue message, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(testNotificationMessageQueuedEvent()),
+						},
+						uniqueConstraintsFromEventConstraint(notification.NewAddMessageTriggerUniqueConstraint("user1", 5, domain.InitCodeMessageType)),
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "message1"),
+			},
+			args: args{
+				ctx: context.Background(),
+				message: &NotificationMessage{
+					UserID:          "user1",
+					ResourceOwner:   "org1",
+					MessageType:     domain.InitCodeMessageType,
+					Channel:         domain.NotificationTypeEmail,
+					Recipient:       "user@example.com",
+					Subject:         "Subject",
+					Content:         "Content",
+					TriggeringEvent: testNotificationTriggeringEvent(),
+				},
+			},
+		},
+		{
+			name: "already queued, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectPushFailed(
+						caos_errs.ThrowAlreadyExists(nil, "id", "Errors.Notification.Outbox.AlreadyQueued"),
+						[]*repository.Event{
+							eventFromEventPusher(testNotificationMessageQueuedEvent()),
+						},
+						uniqueConstraintsFromEventConstraint(notification.NewAddMessageTriggerUniqueConstraint("user1", 5, domain.InitCodeMessageType)),
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "message1"),
+			},
+			args: args{
+				ctx: context.Background(),
+				message: &NotificationMessage{
+					UserID:          "user1",
+					ResourceOwner:   "org1",
+					MessageType:     domain.InitCodeMessageType,
+					Channel:         domain.NotificationTypeEmail,
+					Recipient:       "user@example.com",
+					Subject:         "Subject",
+					Content:         "Content",
+					TriggeringEvent: testNotificationTriggeringEvent(),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:     tt.fields.eventstore,
+				idGenerator:    tt.fields.idGenerator,
+				userEncryption: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			}
+			err := r.QueueNotificationMessage(tt.args.ctx, tt.args.message)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+		})
+	}
+}
+
+func TestCommandSide_DeliverNotificationMessage(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx     context.Context
+		sendErr error
+	}
+	type res struct {
+		sent bool
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "already sent, not sent again",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(testNotificationMessageQueuedEvent()),
+						eventFromEventPusher(notification.NewMessageSentEvent(context.Background(),
+							&notification.NewAggregate("message1", "org1").Aggregate,
+							1,
+						)),
+					),
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+			},
+			res: res{
+				sent: false,
+			},
+		},
+		{
+			name: "send, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(testNotificationMessageQueuedEvent()),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(notification.NewMessageSentEvent(context.Background(),
+								&notification.NewAggregate("message1", "org1").Aggregate,
+								1,
+							)),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+			},
+			res: res{
+				sent: true,
+			},
+		},
+		{
+			name: "send failed on last attempt, message failed",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(testNotificationMessageQueuedEvent()),
+						eventFromEventPusher(notification.NewMessageAttemptFailedEvent(context.Background(),
+							&notification.NewAggregate("message1", "org1").Aggregate,
+							1,
+							"unavailable",
+							time.Now(),
+						)),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(notification.NewMessageFailedEvent(context.Background(),
+								&notification.NewAggregate("message1", "org1").Aggregate,
+								2,
+								"unavailable",
+							)),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:     context.Background(),
+				sendErr: errors.New("unavailable"),
+			},
+			res: res{
+				sent: true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:         tt.fields.eventstore,
+				userEncryption:     crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+				notificationOutbox: systemdefaults.NotificationOutbox{MaxAttempts: 2},
+			}
+			var sent bool
+			err := r.DeliverNotificationMessage(tt.args.ctx, "org1", "message1", func(_ context.Context, message *NotificationMessage) error {
+				sent = true
+				assert.Equal(t, "user@example.com", message.Recipient)
+				assert.Equal(t, "Subject", message.Subject)
+				assert.Equal(t, "Content", message.Content)
+				return tt.args.sendErr
+			})
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			assert.Equal(t, tt.res.sent, sent)
+		})
+	}
+}
+
+func TestCommandSide_ResendNotificationMessage(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx    context.Context
+		userID string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "message of other user, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(testNotificationMessageQueuedEvent()),
+					),
+				),
+			},
+			args: args{
+				ctx:    context.Background(),
+				userID: "user2",
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "message still queued, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(testNotificationMessageQueuedEvent()),
+					),
+				),
+			},
+			args: args{
+				ctx:    context.Background(),
+				userID: "user1",
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "resend failed message, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(testNotificationMessageQueuedEvent()),
+						eventFromEventPusher(notification.NewMessageFailedEvent(context.Background(),
+							&notification.NewAggregate("message1", "org1").Aggregate,
+							8,
+							"unavailable",
+						)),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(notification.NewMessageResendRequestedEvent(context.Background(),
+								&notification.NewAggregate("message1", "org1").Aggregate,
+							)),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:    context.Background(),
+				userID: "user1",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.ResendNotificationMessage(tt.args.ctx, tt.args.userID, "org1", "message1")
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_DiscardNotificationMessage(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		res    res
+	}{
+		{
+			name: "message not found, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(),
+				),
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "message already sent, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(testNotificationMessageQueuedEvent()),
+						eventFromEventPusher(notification.NewMessageSentEvent(context.Background(),
+							&notification.NewAggregate("message1", "org1").Aggregate,
+							1,
+						)),
+					),
+				),
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "discard queued message, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(testNotificationMessageQueuedEvent()),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(notification.NewMessageDiscardedEvent(context.Background(),
+								&notification.NewAggregate("message1", "org1").Aggregate,
+							)),
+						},
+					),
+				),
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.DiscardNotificationMessage(context.Background(), "", "org1", "message1")
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
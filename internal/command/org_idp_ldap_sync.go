@@ -0,0 +1,45 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+// SetOrgLDAPSync configures the synchronization of an LDAP identity provider of the organization,
+// the users are always created in the organization owning the identity provider
+func (c *Commands) SetOrgLDAPSync(ctx context.Context, resourceOwner, idpID string, sync *LDAPSync) (*domain.ObjectDetails, error) {
+	if resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Lt2mc", "Errors.ResourceOwnerMissing")
+	}
+	if idpID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Lt6sk", "Errors.IDMissing")
+	}
+	if sync.OrgID == "" {
+		sync.OrgID = resourceOwner
+	}
+	if sync.OrgID != resourceOwner {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Lt9dw", "Errors.IDPConfig.LDAPSync.OrgInvalid")
+	}
+	if err := sync.validate(); err != nil {
+		return nil, err
+	}
+	provider := NewLDAPOrgIDPWriteModel(resourceOwner, idpID)
+	if err := c.eventstore.FilterToQueryReducer(ctx, provider); err != nil {
+		return nil, err
+	}
+	if !provider.State.Exists() {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Lt4xa", "Errors.IDPConfig.NotExisting")
+	}
+	return c.setLDAPSync(ctx, resourceOwner, idpID, sync)
+}
+
+// RemoveOrgLDAPSync stops the synchronization of an LDAP identity provider of the organization,
+// the already synchronized users are kept
+func (c *Commands) RemoveOrgLDAPSync(ctx context.Context, resourceOwner, idpID string) (*domain.ObjectDetails, error) {
+	if resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Lt1vb", "Errors.ResourceOwnerMissing")
+	}
+	return c.removeLDAPSync(ctx, resourceOwner, idpID)
+}
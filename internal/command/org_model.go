@@ -60,6 +60,16 @@ func (wm *OrgWriteModel) Query() *eventstore.SearchQueryBuilder {
 		Builder()
 }
 
+// SnapshotKey implements eventstore.SnapshotReducer
+func (wm *OrgWriteModel) SnapshotKey() (eventstore.AggregateType, string, string) {
+	return org.AggregateType, wm.AggregateID, "org"
+}
+
+// SnapshotVersion implements eventstore.SnapshotReducer
+func (wm *OrgWriteModel) SnapshotVersion() uint16 {
+	return 1
+}
+
 func OrgAggregateFromWriteModel(wm *eventstore.WriteModel) *eventstore.Aggregate {
 	return eventstore.AggregateFromWriteModel(wm, org.AggregateType, org.AggregateVersion)
 }
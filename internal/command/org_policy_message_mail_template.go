@@ -0,0 +1,77 @@
+package command
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/notification/templates"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+// SetOrgMessageMailTemplate sets the mail template used for emails of the message type of the organisation.
+// MJML templates are compiled to HTML, the template is validated before it's stored.
+func (c *Commands) SetOrgMessageMailTemplate(ctx context.Context, resourceOwner string, template *domain.MessageMailTemplate) (*domain.ObjectDetails, error) {
+	if resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "Org-Mt7ds", "Errors.ResourceOwnerMissing")
+	}
+	if !template.IsValid() {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "Org-Mt2vp", "Errors.Org.MailTemplate.MessageTemplate.Invalid")
+	}
+	html, err := templates.CompileMessageTemplate(template.Format, template.Source)
+	if err != nil {
+		return nil, err
+	}
+	existingTemplate := NewOrgMessageMailTemplateWriteModel(resourceOwner, template.MessageType)
+	err = c.eventstore.FilterToQueryReducer(ctx, existingTemplate)
+	if err != nil {
+		return nil, err
+	}
+	if existingTemplate.State == domain.PolicyStateActive &&
+		existingTemplate.Format == template.Format &&
+		bytes.Equal(existingTemplate.Source, template.Source) {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "Org-Mt9xk", "Errors.Org.MailTemplate.NotChanged")
+	}
+
+	orgAgg := OrgAggregateFromWriteModel(&existingTemplate.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, org.NewMessageMailTemplateSetEvent(ctx, orgAgg, template.MessageType, template.Format, template.Source, html))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingTemplate, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingTemplate.WriteModel), nil
+}
+
+// RemoveOrgMessageMailTemplate removes the mail template of the message type of the organisation,
+// the default template of the message type or the mail template is used afterwards
+func (c *Commands) RemoveOrgMessageMailTemplate(ctx context.Context, resourceOwner, messageType string) (*domain.ObjectDetails, error) {
+	if resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "Org-Mr4pd", "Errors.ResourceOwnerMissing")
+	}
+	if !domain.IsMailMessageType(messageType) {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "Org-Mr6wn", "Errors.Org.MailTemplate.MessageTemplate.Invalid")
+	}
+	existingTemplate := NewOrgMessageMailTemplateWriteModel(resourceOwner, messageType)
+	err := c.eventstore.FilterToQueryReducer(ctx, existingTemplate)
+	if err != nil {
+		return nil, err
+	}
+	if existingTemplate.State != domain.PolicyStateActive {
+		return nil, caos_errs.ThrowNotFound(nil, "Org-Mr1zt", "Errors.Org.MailTemplate.MessageTemplate.NotFound")
+	}
+
+	orgAgg := OrgAggregateFromWriteModel(&existingTemplate.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, org.NewMessageMailTemplateRemovedEvent(ctx, orgAgg, messageType))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingTemplate, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingTemplate.WriteModel), nil
+}
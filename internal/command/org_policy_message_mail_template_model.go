@@ -0,0 +1,49 @@
+package command
+
+import (
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+type OrgMessageMailTemplateWriteModel struct {
+	MessageMailTemplateWriteModel
+}
+
+func NewOrgMessageMailTemplateWriteModel(orgID, messageType string) *OrgMessageMailTemplateWriteModel {
+	return &OrgMessageMailTemplateWriteModel{
+		MessageMailTemplateWriteModel{
+			WriteModel: eventstore.WriteModel{
+				AggregateID:   orgID,
+				ResourceOwner: orgID,
+			},
+			MessageType: messageType,
+		},
+	}
+}
+
+func (wm *OrgMessageMailTemplateWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *org.MessageMailTemplateSetEvent:
+			wm.MessageMailTemplateWriteModel.AppendEvents(&e.MessageMailTemplateSetEvent)
+		case *org.MessageMailTemplateRemovedEvent:
+			wm.MessageMailTemplateWriteModel.AppendEvents(&e.MessageMailTemplateRemovedEvent)
+		}
+	}
+}
+
+func (wm *OrgMessageMailTemplateWriteModel) Reduce() error {
+	return wm.MessageMailTemplateWriteModel.Reduce()
+}
+
+func (wm *OrgMessageMailTemplateWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(org.AggregateType).
+		AggregateIDs(wm.MessageMailTemplateWriteModel.AggregateID).
+		EventTypes(
+			org.MessageMailTemplateSetEventType,
+			org.MessageMailTemplateRemovedEventType).
+		Builder()
+}
@@ -10,12 +10,12 @@ import (
 	"github.com/zitadel/zitadel/internal/repository/org"
 )
 
-func (c *Commands) AddNotificationPolicy(ctx context.Context, resourceOwner string, passwordChange bool) (*domain.ObjectDetails, error) {
+func (c *Commands) AddNotificationPolicy(ctx context.Context, resourceOwner string, passwordChange, securityNotifications bool) (*domain.ObjectDetails, error) {
 	if resourceOwner == "" {
 		return nil, caos_errs.ThrowInvalidArgument(nil, "Org-x801sk2i", "Errors.ResourceOwnerMissing")
 	}
 	orgAgg := org.NewAggregate(resourceOwner)
-	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, prepareAddNotificationPolicy(orgAgg, passwordChange))
+	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, prepareAddNotificationPolicy(orgAgg, passwordChange, securityNotifications))
 	if err != nil {
 		return nil, err
 	}
@@ -28,7 +28,8 @@ func (c *Commands) AddNotificationPolicy(ctx context.Context, resourceOwner stri
 
 func prepareAddNotificationPolicy(
 	a *org.Aggregate,
-	passwordChange bool,
+	passwordChange,
+	securityNotifications bool,
 ) preparation.Validation {
 	return func() (preparation.CreateCommands, error) {
 		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
@@ -45,18 +46,18 @@ func prepareAddNotificationPolicy(
 				return nil, caos_errs.ThrowAlreadyExists(nil, "Org-xa08n2", "Errors.Org.NotificationPolicy.AlreadyExists")
 			}
 			return []eventstore.Command{
-				org.NewNotificationPolicyAddedEvent(ctx, &a.Aggregate, passwordChange),
+				org.NewNotificationPolicyAddedEvent(ctx, &a.Aggregate, passwordChange, securityNotifications),
 			}, nil
 		}, nil
 	}
 }
 
-func (c *Commands) ChangeNotificationPolicy(ctx context.Context, resourceOwner string, passwordChange bool) (*domain.ObjectDetails, error) {
+func (c *Commands) ChangeNotificationPolicy(ctx context.Context, resourceOwner string, passwordChange, securityNotifications bool) (*domain.ObjectDetails, error) {
 	if resourceOwner == "" {
 		return nil, caos_errs.ThrowInvalidArgument(nil, "Org-x091n1g", "Errors.ResourceOwnerMissing")
 	}
 	orgAgg := org.NewAggregate(resourceOwner)
-	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, prepareChangeNotificationPolicy(orgAgg, passwordChange))
+	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, prepareChangeNotificationPolicy(orgAgg, passwordChange, securityNotifications))
 	if err != nil {
 		return nil, err
 	}
@@ -69,7 +70,8 @@ func (c *Commands) ChangeNotificationPolicy(ctx context.Context, resourceOwner s
 
 func prepareChangeNotificationPolicy(
 	a *org.Aggregate,
-	passwordChange bool,
+	passwordChange,
+	securityNotifications bool,
 ) preparation.Validation {
 	return func() (preparation.CreateCommands, error) {
 		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
@@ -86,7 +88,7 @@ func prepareChangeNotificationPolicy(
 			if writeModel.State == domain.PolicyStateUnspecified || writeModel.State == domain.PolicyStateRemoved {
 				return nil, caos_errs.ThrowNotFound(nil, "ORG-x029n3", "Errors.Org.NotificationPolicy.NotFound")
 			}
-			change, hasChanged := writeModel.NewChangedEvent(ctx, &a.Aggregate, passwordChange)
+			change, hasChanged := writeModel.NewChangedEvent(ctx, &a.Aggregate, passwordChange, securityNotifications)
 			if !hasChanged {
 				return nil, caos_errs.ThrowPreconditionFailed(nil, "Org-ioqnxz", "Errors.Org.NotificationPolicy.NotChanged")
 			}
@@ -55,13 +55,17 @@ func (wm *OrgNotificationPolicyWriteModel) Query() *eventstore.SearchQueryBuilde
 func (wm *OrgNotificationPolicyWriteModel) NewChangedEvent(
 	ctx context.Context,
 	aggregate *eventstore.Aggregate,
-	passwordChange bool,
+	passwordChange,
+	securityNotifications bool,
 ) (*org.NotificationPolicyChangedEvent, bool) {
 
 	changes := make([]policy.NotificationPolicyChanges, 0)
 	if wm.PasswordChange != passwordChange {
 		changes = append(changes, policy.ChangePasswordChange(passwordChange))
 	}
+	if wm.SecurityNotifications != securityNotifications {
+		changes = append(changes, policy.ChangeSecurityNotifications(securityNotifications))
+	}
 	if len(changes) == 0 {
 		return nil, false
 	}
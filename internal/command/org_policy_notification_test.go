@@ -19,9 +19,10 @@ func TestCommandSide_AddNotificationPolicy(t *testing.T) {
 		eventstore *eventstore.Eventstore
 	}
 	type args struct {
-		ctx            context.Context
-		orgID          string
-		passwordChange bool
+		ctx                   context.Context
+		orgID                 string
+		passwordChange        bool
+		securityNotifications bool
 	}
 	type res struct {
 		want *domain.ObjectDetails
@@ -59,6 +60,7 @@ func TestCommandSide_AddNotificationPolicy(t *testing.T) {
 							org.NewNotificationPolicyAddedEvent(context.Background(),
 								&org.NewAggregate("org1").Aggregate,
 								true,
+								false,
 							),
 						),
 					),
@@ -85,6 +87,7 @@ func TestCommandSide_AddNotificationPolicy(t *testing.T) {
 								org.NewNotificationPolicyAddedEvent(context.Background(),
 									&org.NewAggregate("org1").Aggregate,
 									true,
+									false,
 								),
 							),
 						},
@@ -114,6 +117,7 @@ func TestCommandSide_AddNotificationPolicy(t *testing.T) {
 								org.NewNotificationPolicyAddedEvent(context.Background(),
 									&org.NewAggregate("org1").Aggregate,
 									false,
+									false,
 								),
 							),
 						},
@@ -137,7 +141,7 @@ func TestCommandSide_AddNotificationPolicy(t *testing.T) {
 			r := &Commands{
 				eventstore: tt.fields.eventstore,
 			}
-			got, err := r.AddNotificationPolicy(tt.args.ctx, tt.args.orgID, tt.args.passwordChange)
+			got, err := r.AddNotificationPolicy(tt.args.ctx, tt.args.orgID, tt.args.passwordChange, tt.args.securityNotifications)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -156,9 +160,10 @@ func TestCommandSide_ChangeNotificationPolicy(t *testing.T) {
 		eventstore *eventstore.Eventstore
 	}
 	type args struct {
-		ctx            context.Context
-		orgID          string
-		passwordChange bool
+		ctx                   context.Context
+		orgID                 string
+		passwordChange        bool
+		securityNotifications bool
 	}
 	type res struct {
 		want *domain.ObjectDetails
@@ -212,6 +217,7 @@ func TestCommandSide_ChangeNotificationPolicy(t *testing.T) {
 							org.NewNotificationPolicyAddedEvent(context.Background(),
 								&org.NewAggregate("org1").Aggregate,
 								true,
+								false,
 							),
 						),
 					),
@@ -236,22 +242,24 @@ func TestCommandSide_ChangeNotificationPolicy(t *testing.T) {
 							org.NewNotificationPolicyAddedEvent(context.Background(),
 								&org.NewAggregate("org1").Aggregate,
 								true,
+								false,
 							),
 						),
 					),
 					expectPush(
 						[]*repository.Event{
 							eventFromEventPusher(
-								newNotificationPolicyChangedEvent(context.Background(), "org1", false),
+								newNotificationPolicyChangedEvent(context.Background(), "org1", false, true),
 							),
 						},
 					),
 				),
 			},
 			args: args{
-				ctx:            context.Background(),
-				orgID:          "org1",
-				passwordChange: false,
+				ctx:                   context.Background(),
+				orgID:                 "org1",
+				passwordChange:        false,
+				securityNotifications: true,
 			},
 			res: res{
 				want: &domain.ObjectDetails{
@@ -265,7 +273,7 @@ func TestCommandSide_ChangeNotificationPolicy(t *testing.T) {
 			r := &Commands{
 				eventstore: tt.fields.eventstore,
 			}
-			got, err := r.ChangeNotificationPolicy(tt.args.ctx, tt.args.orgID, tt.args.passwordChange)
+			got, err := r.ChangeNotificationPolicy(tt.args.ctx, tt.args.orgID, tt.args.passwordChange, tt.args.securityNotifications)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -337,6 +345,7 @@ func TestCommandSide_RemoveNotificationPolicy(t *testing.T) {
 							org.NewNotificationPolicyAddedEvent(context.Background(),
 								&org.NewAggregate("org1").Aggregate,
 								true,
+								false,
 							),
 						),
 					),
@@ -380,11 +389,12 @@ func TestCommandSide_RemoveNotificationPolicy(t *testing.T) {
 	}
 }
 
-func newNotificationPolicyChangedEvent(ctx context.Context, orgID string, passwordChange bool) *org.NotificationPolicyChangedEvent {
+func newNotificationPolicyChangedEvent(ctx context.Context, orgID string, passwordChange, securityNotifications bool) *org.NotificationPolicyChangedEvent {
 	event, _ := org.NewNotificationPolicyChangedEvent(ctx,
 		&org.NewAggregate(orgID).Aggregate,
 		[]policy.NotificationPolicyChanges{
 			policy.ChangePasswordChange(passwordChange),
+			policy.ChangeSecurityNotifications(securityNotifications),
 		},
 	)
 	return event
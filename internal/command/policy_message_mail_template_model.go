@@ -0,0 +1,39 @@
+package command
+
+import (
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/policy"
+)
+
+type MessageMailTemplateWriteModel struct {
+	eventstore.WriteModel
+
+	MessageType string
+	Format      domain.MessageTemplateFormat
+	Source      []byte
+
+	State domain.PolicyState
+}
+
+func (wm *MessageMailTemplateWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *policy.MessageMailTemplateSetEvent:
+			if e.MessageType != wm.MessageType {
+				continue
+			}
+			wm.Format = e.Format
+			wm.Source = e.Source
+			wm.State = domain.PolicyStateActive
+		case *policy.MessageMailTemplateRemovedEvent:
+			if e.MessageType != wm.MessageType {
+				continue
+			}
+			wm.Format = domain.MessageTemplateFormatUnspecified
+			wm.Source = nil
+			wm.State = domain.PolicyStateRemoved
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
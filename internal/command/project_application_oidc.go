@@ -34,6 +34,12 @@ type addOIDCApp struct {
 	ClockSkew                   time.Duration
 	AdditionalOrigins           []string
 	SkipSuccessPageForNativeApp bool
+	RefreshTokenReuseDetection  bool
+	BackChannelLogoutURI        string
+	FrontChannelLogoutURI       string
+	SenderConstrainedTokens     bool
+	TLSClientAuthSubjectDN      string
+	RequireConsent              bool
 
 	ClientID          string
 	ClientSecret      *crypto.CryptoValue
@@ -65,6 +71,14 @@ func (c *Commands) AddOIDCAppCommand(app *addOIDCApp, clientSecretAlg crypto.Has
 			return nil, errors.ThrowInvalidArgument(nil, "V2-sLpW1", "Errors.Invalid.Argument")
 		}
 
+		if !domain.LogoutURIValid(app.BackChannelLogoutURI) || !domain.LogoutURIValid(app.FrontChannelLogoutURI) {
+			return nil, errors.ThrowInvalidArgument(nil, "V2-Bc8Nq", "Errors.Invalid.Argument")
+		}
+
+		if !domain.TLSClientAuthValid(app.AuthMethodType, app.TLSClientAuthSubjectDN) {
+			return nil, errors.ThrowInvalidArgument(nil, "V2-Tq4mx", "Errors.Invalid.Argument")
+		}
+
 		return func(ctx context.Context, filter preparation.FilterToQueryReducer) (_ []eventstore.Command, err error) {
 			project, err := projectWriteModel(ctx, filter, app.Aggregate.ID, app.Aggregate.ResourceOwner)
 			if err != nil || !project.State.Valid() {
@@ -111,6 +125,12 @@ func (c *Commands) AddOIDCAppCommand(app *addOIDCApp, clientSecretAlg crypto.Has
 					app.ClockSkew,
 					app.AdditionalOrigins,
 					app.SkipSuccessPageForNativeApp,
+					app.RefreshTokenReuseDetection,
+					app.BackChannelLogoutURI,
+					app.FrontChannelLogoutURI,
+					app.SenderConstrainedTokens,
+					app.TLSClientAuthSubjectDN,
+					app.RequireConsent,
 				),
 			}, nil
 		}, nil
@@ -155,7 +175,7 @@ func (c *Commands) AddOIDCApplication(ctx context.Context, oidcApp *domain.OIDCA
 	return c.addOIDCApplicationWithID(ctx, oidcApp, resourceOwner, project, appID, appSecretGenerator)
 }
 
-func (c *Commands) addOIDCApplicationWithID(ctx context.Context, oidcApp *domain.OIDCApp, resourceOwner string, project *domain.Project, appID string, appSecretGenerator crypto.Generator) (_ *domain.OIDCApp, err error) {
+func (c *Commands) addOIDCApplicationWithID(ctx context.Context, oidcApp *domain.OIDCApp, resourceOwner string, project *domain.Project, appID string, appSecretGenerator crypto.Generator, additionalEvents ...eventstore.Command) (_ *domain.OIDCApp, err error) {
 
 	addedApplication := NewOIDCApplicationWriteModel(oidcApp.AggregateID, resourceOwner)
 	projectAgg := ProjectAggregateFromWriteModel(&addedApplication.WriteModel)
@@ -195,7 +215,14 @@ func (c *Commands) addOIDCApplicationWithID(ctx context.Context, oidcApp *domain
 		oidcApp.ClockSkew,
 		oidcApp.AdditionalOrigins,
 		oidcApp.SkipNativeAppSuccessPage,
+		oidcApp.RefreshTokenReuseDetection,
+		oidcApp.BackChannelLogoutURI,
+		oidcApp.FrontChannelLogoutURI,
+		oidcApp.SenderConstrainedTokens,
+		oidcApp.TLSClientAuthSubjectDN,
+		oidcApp.RequireConsent,
 	))
+	events = append(events, additionalEvents...)
 
 	addedApplication.AppID = oidcApp.AppID
 	pushedEvents, err := c.eventstore.Push(ctx, events...)
@@ -247,6 +274,12 @@ func (c *Commands) ChangeOIDCApplication(ctx context.Context, oidc *domain.OIDCA
 		oidc.ClockSkew,
 		oidc.AdditionalOrigins,
 		oidc.SkipNativeAppSuccessPage,
+		oidc.RefreshTokenReuseDetection,
+		oidc.BackChannelLogoutURI,
+		oidc.FrontChannelLogoutURI,
+		oidc.SenderConstrainedTokens,
+		oidc.TLSClientAuthSubjectDN,
+		oidc.RequireConsent,
 	)
 	if err != nil {
 		return nil, err
@@ -14,29 +14,36 @@ import (
 type OIDCApplicationWriteModel struct {
 	eventstore.WriteModel
 
-	AppID                    string
-	AppName                  string
-	ClientID                 string
-	ClientSecret             *crypto.CryptoValue
-	ClientSecretString       string
-	RedirectUris             []string
-	ResponseTypes            []domain.OIDCResponseType
-	GrantTypes               []domain.OIDCGrantType
-	ApplicationType          domain.OIDCApplicationType
-	AuthMethodType           domain.OIDCAuthMethodType
-	PostLogoutRedirectUris   []string
-	OIDCVersion              domain.OIDCVersion
-	Compliance               *domain.Compliance
-	DevMode                  bool
-	AccessTokenType          domain.OIDCTokenType
-	AccessTokenRoleAssertion bool
-	IDTokenRoleAssertion     bool
-	IDTokenUserinfoAssertion bool
-	ClockSkew                time.Duration
-	State                    domain.AppState
-	AdditionalOrigins        []string
-	SkipNativeAppSuccessPage bool
-	oidc                     bool
+	AppID                      string
+	AppName                    string
+	ClientID                   string
+	ClientSecret               *crypto.CryptoValue
+	ClientSecretString         string
+	RedirectUris               []string
+	ResponseTypes              []domain.OIDCResponseType
+	GrantTypes                 []domain.OIDCGrantType
+	ApplicationType            domain.OIDCApplicationType
+	AuthMethodType             domain.OIDCAuthMethodType
+	PostLogoutRedirectUris     []string
+	OIDCVersion                domain.OIDCVersion
+	Compliance                 *domain.Compliance
+	DevMode                    bool
+	AccessTokenType            domain.OIDCTokenType
+	AccessTokenRoleAssertion   bool
+	IDTokenRoleAssertion       bool
+	IDTokenUserinfoAssertion   bool
+	ClockSkew                  time.Duration
+	State                      domain.AppState
+	AdditionalOrigins          []string
+	SkipNativeAppSuccessPage   bool
+	RefreshTokenReuseDetection bool
+	BackChannelLogoutURI       string
+	FrontChannelLogoutURI      string
+	SenderConstrainedTokens    bool
+	TLSClientAuthSubjectDN     string
+	RequireConsent             bool
+	RegistrationToken          *crypto.CryptoValue
+	oidc                       bool
 }
 
 func NewOIDCApplicationWriteModelWithAppID(projectID, appID, resourceOwner string) *OIDCApplicationWriteModel {
@@ -100,6 +107,11 @@ func (wm *OIDCApplicationWriteModel) AppendEvents(events ...eventstore.Event) {
 				continue
 			}
 			wm.WriteModel.AppendEvents(e)
+		case *project.OIDCConfigRegistrationTokenSetEvent:
+			if e.AppID != wm.AppID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
 		case *project.ProjectRemovedEvent:
 			wm.WriteModel.AppendEvents(e)
 		}
@@ -132,6 +144,8 @@ func (wm *OIDCApplicationWriteModel) Reduce() error {
 			wm.appendChangeOIDCEvent(e)
 		case *project.OIDCConfigSecretChangedEvent:
 			wm.ClientSecret = e.ClientSecret
+		case *project.OIDCConfigRegistrationTokenSetEvent:
+			wm.RegistrationToken = e.RegistrationToken
 		case *project.ProjectRemovedEvent:
 			wm.State = domain.AppStateRemoved
 		}
@@ -158,6 +172,12 @@ func (wm *OIDCApplicationWriteModel) appendAddOIDCEvent(e *project.OIDCConfigAdd
 	wm.ClockSkew = e.ClockSkew
 	wm.AdditionalOrigins = e.AdditionalOrigins
 	wm.SkipNativeAppSuccessPage = e.SkipNativeAppSuccessPage
+	wm.RefreshTokenReuseDetection = e.RefreshTokenReuseDetection
+	wm.BackChannelLogoutURI = e.BackChannelLogoutURI
+	wm.FrontChannelLogoutURI = e.FrontChannelLogoutURI
+	wm.SenderConstrainedTokens = e.SenderConstrainedTokens
+	wm.TLSClientAuthSubjectDN = e.TLSClientAuthSubjectDN
+	wm.RequireConsent = e.RequireConsent
 }
 
 func (wm *OIDCApplicationWriteModel) appendChangeOIDCEvent(e *project.OIDCConfigChangedEvent) {
@@ -206,6 +226,24 @@ func (wm *OIDCApplicationWriteModel) appendChangeOIDCEvent(e *project.OIDCConfig
 	if e.SkipNativeAppSuccessPage != nil {
 		wm.SkipNativeAppSuccessPage = *e.SkipNativeAppSuccessPage
 	}
+	if e.RefreshTokenReuseDetection != nil {
+		wm.RefreshTokenReuseDetection = *e.RefreshTokenReuseDetection
+	}
+	if e.BackChannelLogoutURI != nil {
+		wm.BackChannelLogoutURI = *e.BackChannelLogoutURI
+	}
+	if e.FrontChannelLogoutURI != nil {
+		wm.FrontChannelLogoutURI = *e.FrontChannelLogoutURI
+	}
+	if e.SenderConstrainedTokens != nil {
+		wm.SenderConstrainedTokens = *e.SenderConstrainedTokens
+	}
+	if e.TLSClientAuthSubjectDN != nil {
+		wm.TLSClientAuthSubjectDN = *e.TLSClientAuthSubjectDN
+	}
+	if e.RequireConsent != nil {
+		wm.RequireConsent = *e.RequireConsent
+	}
 }
 
 func (wm *OIDCApplicationWriteModel) Query() *eventstore.SearchQueryBuilder {
@@ -223,6 +261,7 @@ func (wm *OIDCApplicationWriteModel) Query() *eventstore.SearchQueryBuilder {
 			project.OIDCConfigAddedType,
 			project.OIDCConfigChangedType,
 			project.OIDCConfigSecretChangedType,
+			project.OIDCConfigRegistrationTokenSetType,
 			project.ProjectRemovedType).
 		Builder()
 }
@@ -245,7 +284,13 @@ func (wm *OIDCApplicationWriteModel) NewChangedEvent(
 	idTokenUserinfoAssertion bool,
 	clockSkew time.Duration,
 	additionalOrigins []string,
-	skipNativeAppSuccessPage bool,
+	skipNativeAppSuccessPage,
+	refreshTokenReuseDetection bool,
+	backChannelLogoutURI,
+	frontChannelLogoutURI string,
+	senderConstrainedTokens bool,
+	tlsClientAuthSubjectDN string,
+	requireConsent bool,
 ) (*project.OIDCConfigChangedEvent, bool, error) {
 	changes := make([]project.OIDCConfigChanges, 0)
 	var err error
@@ -295,6 +340,24 @@ func (wm *OIDCApplicationWriteModel) NewChangedEvent(
 	if wm.SkipNativeAppSuccessPage != skipNativeAppSuccessPage {
 		changes = append(changes, project.ChangeSkipNativeAppSuccessPage(skipNativeAppSuccessPage))
 	}
+	if wm.RefreshTokenReuseDetection != refreshTokenReuseDetection {
+		changes = append(changes, project.ChangeRefreshTokenReuseDetection(refreshTokenReuseDetection))
+	}
+	if wm.BackChannelLogoutURI != backChannelLogoutURI {
+		changes = append(changes, project.ChangeBackChannelLogoutURI(backChannelLogoutURI))
+	}
+	if wm.FrontChannelLogoutURI != frontChannelLogoutURI {
+		changes = append(changes, project.ChangeFrontChannelLogoutURI(frontChannelLogoutURI))
+	}
+	if wm.SenderConstrainedTokens != senderConstrainedTokens {
+		changes = append(changes, project.ChangeSenderConstrainedTokens(senderConstrainedTokens))
+	}
+	if wm.TLSClientAuthSubjectDN != tlsClientAuthSubjectDN {
+		changes = append(changes, project.ChangeTLSClientAuthSubjectDN(tlsClientAuthSubjectDN))
+	}
+	if wm.RequireConsent != requireConsent {
+		changes = append(changes, project.ChangeRequireConsent(requireConsent))
+	}
 
 	if len(changes) == 0 {
 		return nil, false, nil
@@ -0,0 +1,178 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	project_repo "github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+// RegisterOIDCApplication adds an OIDC application requested on the dynamic client registration endpoint.
+// The metadata must comply with the client registration policy of the instance.
+// The returned registration access token authorizes the client to manage its registration.
+func (c *Commands) RegisterOIDCApplication(ctx context.Context, oidcApp *domain.OIDCApp, resourceOwner string) (_ *domain.OIDCApp, registrationToken string, err error) {
+	if oidcApp == nil || oidcApp.AggregateID == "" {
+		return nil, "", errors.ThrowInvalidArgument(nil, "COMMAND-Yk2cs", "Errors.Project.App.Invalid")
+	}
+	if oidcApp.AppName == "" || !oidcApp.IsValid() {
+		return nil, "", errors.ThrowInvalidArgument(nil, "COMMAND-Bm7sd", "Errors.Project.App.Invalid")
+	}
+	if err = c.checkClientRegistrationPolicy(ctx, oidcApp); err != nil {
+		return nil, "", err
+	}
+	project, err := c.getProjectByID(ctx, oidcApp.AggregateID, resourceOwner)
+	if err != nil {
+		return nil, "", errors.ThrowPreconditionFailed(err, "COMMAND-Ox4nf", "Errors.Project.NotFound")
+	}
+	appID, err := c.idGenerator.Next()
+	if err != nil {
+		return nil, "", err
+	}
+	secretConfig, err := secretGeneratorConfig(ctx, c.eventstore.Filter, domain.SecretGeneratorTypeAppSecret)
+	if err != nil {
+		return nil, "", err
+	}
+	hashedToken, registrationToken, err := newAppClientSecret(ctx, c.eventstore.Filter, c.userPasswordAlg)
+	if err != nil {
+		return nil, "", err
+	}
+	projectAgg := ProjectAggregateFromWriteModel(&NewOIDCApplicationWriteModel(oidcApp.AggregateID, resourceOwner).WriteModel)
+	app, err := c.addOIDCApplicationWithID(ctx, oidcApp, resourceOwner, project, appID,
+		crypto.NewHashGenerator(*secretConfig, c.userPasswordAlg),
+		project_repo.NewOIDCConfigRegistrationTokenSetEvent(ctx, projectAgg, appID, hashedToken),
+	)
+	if err != nil {
+		return nil, "", err
+	}
+	return app, registrationToken, nil
+}
+
+// ChangeRegisteredOIDCApplication replaces the metadata of a client
+// on behalf of the client itself, the name and the OIDC configuration are changed in one step.
+// A new client secret is generated and returned if the client changes to an auth method requiring a secret.
+func (c *Commands) ChangeRegisteredOIDCApplication(ctx context.Context, oidcApp *domain.OIDCApp, resourceOwner string) (*domain.OIDCApp, error) {
+	if oidcApp.AppName == "" || !oidcApp.IsValid() || oidcApp.AppID == "" || oidcApp.AggregateID == "" {
+		return nil, errors.ThrowInvalidArgument(nil, "COMMAND-Fe6hq", "Errors.Project.App.OIDCConfigInvalid")
+	}
+	if err := c.checkClientRegistrationPolicy(ctx, oidcApp); err != nil {
+		return nil, err
+	}
+	existingOIDC, err := c.getOIDCAppWriteModel(ctx, oidcApp.AggregateID, oidcApp.AppID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if existingOIDC.State == domain.AppStateUnspecified || existingOIDC.State == domain.AppStateRemoved {
+		return nil, errors.ThrowNotFound(nil, "COMMAND-Iq0bx", "Errors.Project.App.NotExisting")
+	}
+	if !existingOIDC.IsOIDC() {
+		return nil, errors.ThrowInvalidArgument(nil, "COMMAND-Nr8pe", "Errors.Project.App.IsNotOIDC")
+	}
+	projectAgg := ProjectAggregateFromWriteModel(&existingOIDC.WriteModel)
+	events := make([]eventstore.Command, 0, 2)
+	if existingOIDC.AppName != oidcApp.AppName {
+		events = append(events, project_repo.NewApplicationChangedEvent(ctx, projectAgg, oidcApp.AppID, existingOIDC.AppName, oidcApp.AppName))
+	}
+	changedEvent, hasChanged, err := existingOIDC.NewChangedEvent(
+		ctx,
+		projectAgg,
+		oidcApp.AppID,
+		oidcApp.RedirectUris,
+		oidcApp.PostLogoutRedirectUris,
+		oidcApp.ResponseTypes,
+		oidcApp.GrantTypes,
+		oidcApp.ApplicationType,
+		oidcApp.AuthMethodType,
+		oidcApp.OIDCVersion,
+		oidcApp.AccessTokenType,
+		oidcApp.DevMode,
+		oidcApp.AccessTokenRoleAssertion,
+		oidcApp.IDTokenRoleAssertion,
+		oidcApp.IDTokenUserinfoAssertion,
+		oidcApp.ClockSkew,
+		oidcApp.AdditionalOrigins,
+		oidcApp.SkipNativeAppSuccessPage,
+		oidcApp.RefreshTokenReuseDetection,
+		oidcApp.BackChannelLogoutURI,
+		oidcApp.FrontChannelLogoutURI,
+		oidcApp.SenderConstrainedTokens,
+		oidcApp.TLSClientAuthSubjectDN,
+		oidcApp.RequireConsent,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if hasChanged {
+		events = append(events, changedEvent)
+	}
+	var secretString string
+	if requiresClientSecret(oidcApp.AuthMethodType) && !requiresClientSecret(existingOIDC.AuthMethodType) {
+		secretConfig, err := secretGeneratorConfig(ctx, c.eventstore.Filter, domain.SecretGeneratorTypeAppSecret)
+		if err != nil {
+			return nil, err
+		}
+		var clientSecret *crypto.CryptoValue
+		clientSecret, secretString, err = domain.NewClientSecret(crypto.NewHashGenerator(*secretConfig, c.userPasswordAlg))
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, project_repo.NewOIDCConfigSecretChangedEvent(ctx, projectAgg, oidcApp.AppID, clientSecret))
+	}
+	if len(events) > 0 {
+		pushedEvents, err := c.eventstore.Push(ctx, events...)
+		if err != nil {
+			return nil, err
+		}
+		if err = AppendAndReduce(existingOIDC, pushedEvents...); err != nil {
+			return nil, err
+		}
+	}
+	result := oidcWriteModelToOIDCConfig(existingOIDC)
+	result.ClientSecretString = secretString
+	result.FillCompliance()
+	return result, nil
+}
+
+func requiresClientSecret(authMethodType domain.OIDCAuthMethodType) bool {
+	return authMethodType == domain.OIDCAuthMethodTypeBasic || authMethodType == domain.OIDCAuthMethodTypePost
+}
+
+// VerifyOIDCRegistrationToken checks the registration access token
+// presented by a client on the management of its registration
+func (c *Commands) VerifyOIDCRegistrationToken(ctx context.Context, projectID, appID, token string) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	app, err := c.getOIDCAppWriteModel(ctx, projectID, appID, "")
+	if err != nil {
+		return err
+	}
+	if !app.State.Exists() || !app.IsOIDC() || app.RegistrationToken == nil {
+		return errors.ThrowUnauthenticated(nil, "COMMAND-Jw5tb", "Errors.Project.App.RegistrationTokenInvalid")
+	}
+	ctx, spanHashComparison := tracing.NewNamedSpan(ctx, "crypto.CompareHash")
+	err = crypto.CompareHash(app.RegistrationToken, []byte(token), c.userPasswordAlg)
+	spanHashComparison.EndWithError(err)
+	if err != nil {
+		return errors.ThrowUnauthenticated(err, "COMMAND-Zc1pk", "Errors.Project.App.RegistrationTokenInvalid")
+	}
+	return nil
+}
+
+func (c *Commands) checkClientRegistrationPolicy(ctx context.Context, oidcApp *domain.OIDCApp) error {
+	writeModel, err := getClientRegistrationPolicyWriteModel(ctx, c.eventstore.Filter)
+	if err != nil {
+		return err
+	}
+	policy := writeModel.Policy()
+	if !policy.GrantTypesAllowed(oidcApp.GrantTypes) {
+		return errors.ThrowInvalidArgument(nil, "COMMAND-Ha3vx", "Errors.Project.App.GrantTypeNotAllowed")
+	}
+	if !policy.RedirectURIsAllowed(oidcApp.RedirectUris) || !policy.RedirectURIsAllowed(oidcApp.PostLogoutRedirectUris) {
+		return errors.ThrowInvalidArgument(nil, "COMMAND-Cs2lm", "Errors.Project.App.RedirectURINotAllowed")
+	}
+	return nil
+}
@@ -82,6 +82,51 @@ func TestAddOIDCApp(t *testing.T) {
 				ValidationErr: errors.ThrowInvalidArgument(nil, "PROJE-Fef31", "Errors.Invalid.Argument"),
 			},
 		},
+		{
+			name:   "invalid back-channel logout uri",
+			fields: fields{},
+			args: args{
+				app: &addOIDCApp{
+					AddApp: AddApp{
+						Aggregate: *agg,
+						ID:        "id",
+						Name:      "name",
+					},
+					GrantTypes:           []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
+					ResponseTypes:        []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+					Version:              domain.OIDCVersionV1,
+					ApplicationType:      domain.OIDCApplicationTypeWeb,
+					AuthMethodType:       domain.OIDCAuthMethodTypeNone,
+					AccessTokenType:      domain.OIDCTokenTypeBearer,
+					BackChannelLogoutURI: "logout",
+				},
+			},
+			want: Want{
+				ValidationErr: errors.ThrowInvalidArgument(nil, "V2-Bc8Nq", "Errors.Invalid.Argument"),
+			},
+		},
+		{
+			name:   "tls client auth without subject dn",
+			fields: fields{},
+			args: args{
+				app: &addOIDCApp{
+					AddApp: AddApp{
+						Aggregate: *agg,
+						ID:        "id",
+						Name:      "name",
+					},
+					GrantTypes:      []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
+					ResponseTypes:   []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+					Version:         domain.OIDCVersionV1,
+					ApplicationType: domain.OIDCApplicationTypeWeb,
+					AuthMethodType:  domain.OIDCAuthMethodTypeTLSClientAuth,
+					AccessTokenType: domain.OIDCTokenTypeBearer,
+				},
+			},
+			want: Want{
+				ValidationErr: errors.ThrowInvalidArgument(nil, "V2-Tq4mx", "Errors.Invalid.Argument"),
+			},
+		},
 		{
 			name:   "project not exists",
 			fields: fields{},
@@ -170,6 +215,12 @@ func TestAddOIDCApp(t *testing.T) {
 						0,
 						nil,
 						false,
+						false,
+						"",
+						"",
+						false,
+						"",
+						false,
 					),
 				},
 			},
@@ -328,6 +379,12 @@ func TestCommandSide_AddOIDCApplication(t *testing.T) {
 									time.Second*1,
 									[]string{"https://sub.test.ch"},
 									true,
+									false,
+									"",
+									"",
+									false,
+									"",
+									false,
 								),
 							),
 						},
@@ -565,6 +622,12 @@ func TestCommandSide_ChangeOIDCApplication(t *testing.T) {
 								time.Second*1,
 								[]string{"https://sub.test.ch"},
 								true,
+								false,
+								"",
+								"",
+								false,
+								"",
+								false,
 							),
 						),
 					),
@@ -639,6 +702,12 @@ func TestCommandSide_ChangeOIDCApplication(t *testing.T) {
 								time.Second*1,
 								[]string{"https://sub.test.ch"},
 								true,
+								false,
+								"",
+								"",
+								false,
+								"",
+								false,
 							),
 						),
 					),
@@ -840,6 +909,12 @@ func TestCommandSide_ChangeOIDCApplicationSecret(t *testing.T) {
 								time.Second*1,
 								[]string{"https://sub.test.ch"},
 								false,
+								false,
+								"",
+								"",
+								false,
+								"",
+								false,
 							),
 						),
 					),
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/zitadel/saml/pkg/provider/xml"
+	"github.com/zitadel/saml/pkg/provider/xml/md"
 
 	"github.com/zitadel/zitadel/internal/domain"
 	caos_errs "github.com/zitadel/zitadel/internal/errors"
@@ -62,6 +63,9 @@ func (c *Commands) addSAMLApplication(ctx context.Context, projectAgg *eventstor
 	if err != nil {
 		return nil, caos_errs.ThrowInvalidArgument(err, "SAML-bquso", "Errors.Project.App.SAMLMetadataFormat")
 	}
+	if samlApp.EncryptAssertions && !samlEncryptionCertificateExists(entity) {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "SAML-Ec7vq", "Errors.Project.App.SAMLEncryptionCertificateMissing")
+	}
 
 	samlApp.AppID, err = c.idGenerator.Next()
 	if err != nil {
@@ -76,6 +80,7 @@ func (c *Commands) addSAMLApplication(ctx context.Context, projectAgg *eventstor
 			string(entity.EntityID),
 			samlApp.Metadata,
 			samlApp.MetadataURL,
+			samlApp.EncryptAssertions,
 		),
 	}, nil
 }
@@ -109,6 +114,9 @@ func (c *Commands) ChangeSAMLApplication(ctx context.Context, samlApp *domain.SA
 	if err != nil {
 		return nil, caos_errs.ThrowInvalidArgument(err, "SAML-3fk2b", "Errors.Project.App.SAMLMetadataFormat")
 	}
+	if samlApp.EncryptAssertions && !samlEncryptionCertificateExists(entity) {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "SAML-Wq3nd", "Errors.Project.App.SAMLEncryptionCertificateMissing")
+	}
 
 	changedEvent, hasChanged, err := existingSAML.NewChangedEvent(
 		ctx,
@@ -116,7 +124,8 @@ func (c *Commands) ChangeSAMLApplication(ctx context.Context, samlApp *domain.SA
 		samlApp.AppID,
 		string(entity.EntityID),
 		samlApp.Metadata,
-		samlApp.MetadataURL)
+		samlApp.MetadataURL,
+		samlApp.EncryptAssertions)
 	if err != nil {
 		return nil, err
 	}
@@ -136,6 +145,25 @@ func (c *Commands) ChangeSAMLApplication(ctx context.Context, samlApp *domain.SA
 	return samlWriteModelToSAMLConfig(existingSAML), nil
 }
 
+// samlEncryptionCertificateExists checks if the service provider provides a certificate,
+// which can be used for the encryption of assertions
+func samlEncryptionCertificateExists(entity *md.EntityDescriptorType) bool {
+	if entity.SPSSODescriptor == nil {
+		return false
+	}
+	for _, keyDescriptor := range entity.SPSSODescriptor.KeyDescriptor {
+		if keyDescriptor.Use != "" && keyDescriptor.Use != md.KeyTypesEncryption {
+			continue
+		}
+		for _, x509Data := range keyDescriptor.KeyInfo.X509Data {
+			if x509Data.X509Certificate != "" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func (c *Commands) getSAMLAppWriteModel(ctx context.Context, projectID, appID, resourceOwner string) (*SAMLApplicationWriteModel, error) {
 	appWriteModel := NewSAMLApplicationWriteModelWithAppID(projectID, appID, resourceOwner)
 	err := c.eventstore.FilterToQueryReducer(ctx, appWriteModel)
@@ -138,6 +138,38 @@ func TestCommandSide_AddSAMLApplication(t *testing.T) {
 				err: errors.IsErrorInvalidArgument,
 			},
 		},
+		{
+			name: "encrypt assertions without encryption certificate, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							project.NewProjectAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"project", true, true, true,
+								domain.PrivateLabelingSettingUnspecified),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				samlApp: &domain.SAMLApp{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "project1",
+					},
+					AppName:           "app",
+					EntityID:          "https://test.com/saml/metadata",
+					Metadata:          testMetadata,
+					EncryptAssertions: true,
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
 		{
 			name: "create saml app, metadata not parsable",
 			fields: fields{
@@ -200,6 +232,7 @@ func TestCommandSide_AddSAMLApplication(t *testing.T) {
 									"https://test.com/saml/metadata",
 									testMetadata,
 									"",
+									false,
 								),
 							),
 						},
@@ -266,6 +299,7 @@ func TestCommandSide_AddSAMLApplication(t *testing.T) {
 									"https://test.com/saml/metadata",
 									testMetadata,
 									"http://localhost:8080/saml/metadata",
+									false,
 								),
 							),
 						},
@@ -490,6 +524,7 @@ func TestCommandSide_ChangeSAMLApplication(t *testing.T) {
 								"https://test.com/saml/metadata",
 								testMetadata,
 								"http://localhost:8080/saml/metadata",
+								false,
 							),
 						),
 					),
@@ -535,6 +570,7 @@ func TestCommandSide_ChangeSAMLApplication(t *testing.T) {
 								"https://test.com/saml/metadata",
 								testMetadata,
 								"",
+								false,
 							),
 						),
 					),
@@ -580,6 +616,7 @@ func TestCommandSide_ChangeSAMLApplication(t *testing.T) {
 								"https://test.com/saml/metadata",
 								testMetadata,
 								"http://localhost:8080/saml/metadata",
+								false,
 							),
 						),
 					),
@@ -652,6 +689,7 @@ func TestCommandSide_ChangeSAMLApplication(t *testing.T) {
 								"https://test.com/saml/metadata",
 								testMetadata,
 								"",
+								false,
 							),
 						),
 					),
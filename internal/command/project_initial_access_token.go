@@ -0,0 +1,206 @@
+package command
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/command/preparation"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+// InitialAccessToken authorizes the registration of clients
+// in a project over the OIDC dynamic client registration endpoint
+type InitialAccessToken struct {
+	models.ObjectRoot
+
+	ExpirationDate time.Time
+
+	TokenID string
+	Token   string
+}
+
+func NewInitialAccessToken(resourceOwner, projectID string, expirationDate time.Time) *InitialAccessToken {
+	return &InitialAccessToken{
+		ObjectRoot: models.ObjectRoot{
+			AggregateID:   projectID,
+			ResourceOwner: resourceOwner,
+		},
+		ExpirationDate: expirationDate,
+	}
+}
+
+func (t *InitialAccessToken) content() error {
+	if t.ResourceOwner == "" {
+		return errors.ThrowInvalidArgument(nil, "COMMAND-Vb3ls", "Errors.ResourceOwnerMissing")
+	}
+	if t.AggregateID == "" {
+		return errors.ThrowInvalidArgument(nil, "COMMAND-Ad9rj", "Errors.Project.ProjectIDMissing")
+	}
+	if t.TokenID == "" {
+		return errors.ThrowInvalidArgument(nil, "COMMAND-Ux5ew", "Errors.IDMissing")
+	}
+	return nil
+}
+
+func (c *Commands) AddProjectInitialAccessToken(ctx context.Context, token *InitialAccessToken) (_ *domain.ObjectDetails, err error) {
+	if token.TokenID == "" {
+		token.TokenID, err = c.idGenerator.Next()
+		if err != nil {
+			return nil, err
+		}
+	}
+	validation := prepareAddInitialAccessToken(token, c.userPasswordAlg)
+	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, validation)
+	if err != nil {
+		return nil, err
+	}
+	events, err := c.eventstore.Push(ctx, cmds...)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.ObjectDetails{
+		Sequence:      events[len(events)-1].Sequence(),
+		EventDate:     events[len(events)-1].CreationDate(),
+		ResourceOwner: events[len(events)-1].Aggregate().ResourceOwner,
+	}, nil
+}
+
+func prepareAddInitialAccessToken(token *InitialAccessToken, alg crypto.HashAlgorithm) preparation.Validation {
+	return func() (_ preparation.CreateCommands, err error) {
+		if err := token.content(); err != nil {
+			return nil, err
+		}
+		token.ExpirationDate, err = domain.ValidateExpirationDate(token.ExpirationDate)
+		if err != nil {
+			return nil, err
+		}
+		return func(ctx context.Context, filter preparation.FilterToQueryReducer) (_ []eventstore.Command, err error) {
+			existingProject, err := projectWriteModel(ctx, filter, token.AggregateID, token.ResourceOwner)
+			if err != nil {
+				return nil, err
+			}
+			if existingProject.State == domain.ProjectStateUnspecified || existingProject.State == domain.ProjectStateRemoved {
+				return nil, errors.ThrowPreconditionFailed(nil, "COMMAND-Kz8ma", "Errors.Project.NotFound")
+			}
+			secret, plain, err := newAppClientSecret(ctx, filter, alg)
+			if err != nil {
+				return nil, err
+			}
+			token.Token = encodeInitialAccessToken(token.AggregateID, token.TokenID, plain)
+			return []eventstore.Command{
+				project.NewInitialAccessTokenAddedEvent(
+					ctx,
+					ProjectAggregateFromWriteModel(&existingProject.WriteModel),
+					token.TokenID,
+					secret,
+					token.ExpirationDate,
+				),
+			}, nil
+		}, nil
+	}
+}
+
+func (c *Commands) RemoveProjectInitialAccessToken(ctx context.Context, token *InitialAccessToken) (*domain.ObjectDetails, error) {
+	validation := prepareRemoveInitialAccessToken(token)
+	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, validation)
+	if err != nil {
+		return nil, err
+	}
+	events, err := c.eventstore.Push(ctx, cmds...)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.ObjectDetails{
+		Sequence:      events[len(events)-1].Sequence(),
+		EventDate:     events[len(events)-1].CreationDate(),
+		ResourceOwner: events[len(events)-1].Aggregate().ResourceOwner,
+	}, nil
+}
+
+func prepareRemoveInitialAccessToken(token *InitialAccessToken) preparation.Validation {
+	return func() (_ preparation.CreateCommands, err error) {
+		if err := token.content(); err != nil {
+			return nil, err
+		}
+		return func(ctx context.Context, filter preparation.FilterToQueryReducer) (_ []eventstore.Command, err error) {
+			writeModel, err := getInitialAccessTokenWriteModelByID(ctx, filter, token.AggregateID, token.TokenID, token.ResourceOwner)
+			if err != nil {
+				return nil, err
+			}
+			if !writeModel.Exists() {
+				return nil, errors.ThrowNotFound(nil, "COMMAND-Tn4oq", "Errors.Project.InitialAccessToken.NotFound")
+			}
+			return []eventstore.Command{
+				project.NewInitialAccessTokenRemovedEvent(
+					ctx,
+					ProjectAggregateFromWriteModel(&writeModel.WriteModel),
+					token.TokenID,
+				),
+			}, nil
+		}, nil
+	}
+}
+
+// VerifyInitialAccessToken checks the token presented on client registration
+// and returns the project and its organisation the client will be registered in
+func (c *Commands) VerifyInitialAccessToken(ctx context.Context, token string) (projectID, resourceOwner string, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	projectID, tokenID, secret, err := decodeInitialAccessToken(token)
+	if err != nil {
+		return "", "", err
+	}
+	writeModel, err := getInitialAccessTokenWriteModelByID(ctx, c.eventstore.Filter, projectID, tokenID, "")
+	if err != nil {
+		return "", "", err
+	}
+	if !writeModel.Exists() || writeModel.ExpirationDate.Before(time.Now()) {
+		return "", "", errors.ThrowUnauthenticated(nil, "COMMAND-Ew2ja", "Errors.Project.InitialAccessToken.Invalid")
+	}
+	ctx, spanHashComparison := tracing.NewNamedSpan(ctx, "crypto.CompareHash")
+	err = crypto.CompareHash(writeModel.Token, []byte(secret), c.userPasswordAlg)
+	spanHashComparison.EndWithError(err)
+	if err != nil {
+		return "", "", errors.ThrowUnauthenticated(err, "COMMAND-Gq6zd", "Errors.Project.InitialAccessToken.Invalid")
+	}
+	return writeModel.AggregateID, writeModel.ResourceOwner, nil
+}
+
+func encodeInitialAccessToken(projectID, tokenID, secret string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(projectID + ":" + tokenID + ":" + secret))
+}
+
+func decodeInitialAccessToken(token string) (projectID, tokenID, secret string, err error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return "", "", "", errors.ThrowUnauthenticated(err, "COMMAND-Lr0dv", "Errors.Project.InitialAccessToken.Invalid")
+	}
+	parts := strings.SplitN(string(decoded), ":", 3)
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" {
+		return "", "", "", errors.ThrowUnauthenticated(nil, "COMMAND-Mh1ct", "Errors.Project.InitialAccessToken.Invalid")
+	}
+	return parts[0], parts[1], parts[2], nil
+}
+
+func getInitialAccessTokenWriteModelByID(ctx context.Context, filter preparation.FilterToQueryReducer, projectID, tokenID, resourceOwner string) (_ *InitialAccessTokenWriteModel, err error) {
+	writeModel := NewInitialAccessTokenWriteModel(projectID, tokenID, resourceOwner)
+	events, err := filter(ctx, writeModel.Query())
+	if err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		return writeModel, nil
+	}
+	writeModel.AppendEvents(events...)
+	err = writeModel.Reduce()
+	return writeModel, err
+}
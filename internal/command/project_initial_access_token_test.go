@@ -0,0 +1,329 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/repository/project"
+)
+
+func TestCommands_RemoveProjectInitialAccessToken(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx   context.Context
+		token *InitialAccessToken
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"token id missing, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx: context.Background(),
+				token: &InitialAccessToken{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID:   "project1",
+						ResourceOwner: "org1",
+					},
+				},
+			},
+			res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"token does not exist, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			args{
+				ctx: context.Background(),
+				token: &InitialAccessToken{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID:   "project1",
+						ResourceOwner: "org1",
+					},
+					TokenID: "token1",
+				},
+			},
+			res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			"remove token, ok",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							project.NewInitialAccessTokenAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"token1",
+								&crypto.CryptoValue{
+									CryptoType: crypto.TypeHash,
+									Algorithm:  "hash",
+									Crypted:    []byte("secret"),
+								},
+								time.Date(9999, 12, 31, 23, 59, 59, 0, time.UTC),
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								project.NewInitialAccessTokenRemovedEvent(context.Background(),
+									&project.NewAggregate("project1", "org1").Aggregate,
+									"token1",
+								),
+							),
+						},
+					),
+				),
+			},
+			args{
+				ctx: context.Background(),
+				token: &InitialAccessToken{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID:   "project1",
+						ResourceOwner: "org1",
+					},
+					TokenID: "token1",
+				},
+			},
+			res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := c.RemoveProjectInitialAccessToken(tt.args.ctx, tt.args.token)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommands_VerifyInitialAccessToken(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx   context.Context
+		token string
+	}
+	type res struct {
+		projectID     string
+		resourceOwner string
+		err           func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"malformed token, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx:   context.Background(),
+				token: "not-a-token",
+			},
+			res{
+				err: caos_errs.IsUnauthenticated,
+			},
+		},
+		{
+			"token does not exist, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			args{
+				ctx:   context.Background(),
+				token: encodeInitialAccessToken("project1", "token1", "secret"),
+			},
+			res{
+				err: caos_errs.IsUnauthenticated,
+			},
+		},
+		{
+			"token expired, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							project.NewInitialAccessTokenAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"token1",
+								&crypto.CryptoValue{
+									CryptoType: crypto.TypeHash,
+									Algorithm:  "hash",
+									Crypted:    []byte("secret"),
+								},
+								time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+							),
+						),
+					),
+				),
+			},
+			args{
+				ctx:   context.Background(),
+				token: encodeInitialAccessToken("project1", "token1", "secret"),
+			},
+			res{
+				err: caos_errs.IsUnauthenticated,
+			},
+		},
+		{
+			"token removed, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							project.NewInitialAccessTokenAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"token1",
+								&crypto.CryptoValue{
+									CryptoType: crypto.TypeHash,
+									Algorithm:  "hash",
+									Crypted:    []byte("secret"),
+								},
+								time.Date(9999, 12, 31, 23, 59, 59, 0, time.UTC),
+							),
+						),
+						eventFromEventPusher(
+							project.NewInitialAccessTokenRemovedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"token1",
+							),
+						),
+					),
+				),
+			},
+			args{
+				ctx:   context.Background(),
+				token: encodeInitialAccessToken("project1", "token1", "secret"),
+			},
+			res{
+				err: caos_errs.IsUnauthenticated,
+			},
+		},
+		{
+			"wrong secret, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							project.NewInitialAccessTokenAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"token1",
+								&crypto.CryptoValue{
+									CryptoType: crypto.TypeHash,
+									Algorithm:  "hash",
+									Crypted:    []byte("secret"),
+								},
+								time.Date(9999, 12, 31, 23, 59, 59, 0, time.UTC),
+							),
+						),
+					),
+				),
+			},
+			args{
+				ctx:   context.Background(),
+				token: encodeInitialAccessToken("project1", "token1", "wrong"),
+			},
+			res{
+				err: caos_errs.IsUnauthenticated,
+			},
+		},
+		{
+			"valid token, ok",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							project.NewInitialAccessTokenAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"token1",
+								&crypto.CryptoValue{
+									CryptoType: crypto.TypeHash,
+									Algorithm:  "hash",
+									Crypted:    []byte("secret"),
+								},
+								time.Date(9999, 12, 31, 23, 59, 59, 0, time.UTC),
+							),
+						),
+					),
+				),
+			},
+			args{
+				ctx:   context.Background(),
+				token: encodeInitialAccessToken("project1", "token1", "secret"),
+			},
+			res{
+				projectID:     "project1",
+				resourceOwner: "org1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:      tt.fields.eventstore,
+				userPasswordAlg: crypto.CreateMockHashAlg(gomock.NewController(t)),
+			}
+			projectID, resourceOwner, err := c.VerifyInitialAccessToken(tt.args.ctx, tt.args.token)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.projectID, projectID)
+				assert.Equal(t, tt.res.resourceOwner, resourceOwner)
+			}
+		})
+	}
+}
@@ -98,6 +98,7 @@ func TestCommandSide_AddSMTPConfig(t *testing.T) {
 								"host:587",
 								"user",
 								&crypto.CryptoValue{},
+								0,
 							),
 						),
 					),
@@ -158,6 +159,7 @@ func TestCommandSide_AddSMTPConfig(t *testing.T) {
 										KeyID:      "id",
 										Crypted:    []byte("password"),
 									},
+									0,
 								),
 							),
 						},
@@ -266,6 +268,7 @@ func TestCommandSide_AddSMTPConfig(t *testing.T) {
 										KeyID:      "id",
 										Crypted:    []byte("password"),
 									},
+									0,
 								),
 							),
 						},
@@ -399,6 +402,7 @@ func TestCommandSide_ChangeSMTPConfig(t *testing.T) {
 								"host:587",
 								"user",
 								&crypto.CryptoValue{},
+								0,
 							),
 						),
 					),
@@ -449,6 +453,7 @@ func TestCommandSide_ChangeSMTPConfig(t *testing.T) {
 								"host:587",
 								"user",
 								&crypto.CryptoValue{},
+								0,
 							),
 						),
 					),
@@ -499,6 +504,7 @@ func TestCommandSide_ChangeSMTPConfig(t *testing.T) {
 								"host:587",
 								"user",
 								&crypto.CryptoValue{},
+								0,
 							),
 						),
 					),
@@ -537,6 +543,74 @@ func TestCommandSide_ChangeSMTPConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "smtp config priority change, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewDomainAddedEvent(context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"domain.ch",
+								false,
+							),
+						),
+						eventFromEventPusher(
+							instance.NewDomainPolicyAddedEvent(context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								true, true, true,
+							),
+						),
+						eventFromEventPusher(
+							instance.NewSMTPConfigAddedEvent(
+								context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								true,
+								"from@domain.ch",
+								"name",
+								"host:587",
+								"user",
+								&crypto.CryptoValue{},
+								0,
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusherWithInstanceID(
+								"INSTANCE",
+								func() eventstore.Command {
+									event, _ := instance.NewSMTPConfigChangeEvent(context.Background(),
+										&instance.NewAggregate("INSTANCE").Aggregate,
+										[]instance.SMTPConfigChanges{instance.ChangeSMTPConfigPriority(2)},
+									)
+									return event
+								}(),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				smtp: &smtp.Config{
+					Tls:      true,
+					From:     "from@domain.ch",
+					FromName: "name",
+					SMTP: smtp.SMTP{
+						Host: "host:587",
+						User: "user",
+					},
+					Priority: 2,
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "INSTANCE",
+				},
+			},
+		},
 		{
 			name: "smtp config, port is missing",
 			fields: fields{
@@ -610,6 +684,7 @@ func TestCommandSide_ChangeSMTPConfig(t *testing.T) {
 								"host:587",
 								"user",
 								&crypto.CryptoValue{},
+								0,
 							),
 						),
 					),
@@ -719,6 +794,7 @@ func TestCommandSide_ChangeSMTPConfigPassword(t *testing.T) {
 								"host:587",
 								"user",
 								&crypto.CryptoValue{},
+								0,
 							),
 						),
 					),
@@ -822,6 +898,7 @@ func TestCommandSide_RemoveSMTPConfig(t *testing.T) {
 								"host:587",
 								"user",
 								&crypto.CryptoValue{},
+								0,
 							),
 						),
 					),
@@ -136,7 +136,7 @@ func (c *Commands) LockUser(ctx context.Context, userID, resourceOwner string) (
 	}
 
 	pushedEvents, err := c.eventstore.Push(ctx,
-		user.NewUserLockedEvent(ctx, UserAggregateFromWriteModel(&existingUser.WriteModel)))
+		user.NewUserLockedEvent(ctx, UserAggregateFromWriteModel(&existingUser.WriteModel), false))
 	if err != nil {
 		return nil, err
 	}
@@ -224,12 +224,12 @@ func (c *Commands) RemoveUser(ctx context.Context, userID, resourceOwner string,
 	return writeModelToObjectDetails(&existingUser.WriteModel), nil
 }
 
-func (c *Commands) AddUserToken(ctx context.Context, orgID, agentID, clientID, userID string, audience, scopes []string, lifetime time.Duration) (*domain.Token, error) {
+func (c *Commands) AddUserToken(ctx context.Context, orgID, agentID, clientID, userID string, audience, scopes []string, lifetime time.Duration, confirmation *domain.TokenConfirmation) (*domain.Token, error) {
 	if userID == "" { //do not check for empty orgID (JWT Profile requests won't provide it, so service user requests fail)
 		return nil, errors.ThrowInvalidArgument(nil, "COMMAND-Dbge4", "Errors.IDMissing")
 	}
 	userWriteModel := NewUserWriteModel(userID, orgID)
-	event, accessToken, err := c.addUserToken(ctx, userWriteModel, agentID, clientID, "", audience, scopes, lifetime)
+	event, accessToken, err := c.addUserToken(ctx, userWriteModel, agentID, clientID, "", audience, scopes, lifetime, confirmation)
 	if err != nil {
 		return nil, err
 	}
@@ -256,7 +256,7 @@ func (c *Commands) RevokeAccessToken(ctx context.Context, userID, orgID, tokenID
 	return writeModelToObjectDetails(&accessTokenWriteModel.WriteModel), nil
 }
 
-func (c *Commands) addUserToken(ctx context.Context, userWriteModel *UserWriteModel, agentID, clientID, refreshTokenID string, audience, scopes []string, lifetime time.Duration) (*user.UserTokenAddedEvent, *domain.Token, error) {
+func (c *Commands) addUserToken(ctx context.Context, userWriteModel *UserWriteModel, agentID, clientID, refreshTokenID string, audience, scopes []string, lifetime time.Duration, confirmation *domain.TokenConfirmation) (*user.UserTokenAddedEvent, *domain.Token, error) {
 	err := c.eventstore.FilterToQueryReducer(ctx, userWriteModel)
 	if err != nil {
 		return nil, nil, err
@@ -279,7 +279,7 @@ func (c *Commands) addUserToken(ctx context.Context, userWriteModel *UserWriteMo
 	}
 
 	userAgg := UserAggregateFromWriteModel(&userWriteModel.WriteModel)
-	return user.NewUserTokenAddedEvent(ctx, userAgg, tokenID, clientID, agentID, preferredLanguage, refreshTokenID, audience, scopes, expiration),
+	return user.NewUserTokenAddedEvent(ctx, userAgg, tokenID, clientID, agentID, preferredLanguage, refreshTokenID, audience, scopes, expiration, confirmation),
 		&domain.Token{
 			ObjectRoot: models.ObjectRoot{
 				AggregateID: userWriteModel.AggregateID,
@@ -292,6 +292,7 @@ func (c *Commands) addUserToken(ctx context.Context, userWriteModel *UserWriteMo
 			Scopes:            scopes,
 			Expiration:        expiration,
 			PreferredLanguage: preferredLanguage,
+			Confirmation:      confirmation,
 		}, nil
 }
 
@@ -385,6 +386,25 @@ func (c *Commands) UserDomainClaimedSent(ctx context.Context, orgID, userID stri
 	return err
 }
 
+// UserSecurityNotificationSent marks the security notification of the messageType,
+// triggered by the event with triggerSequence on the user, as sent
+func (c *Commands) UserSecurityNotificationSent(ctx context.Context, orgID, userID, messageType string, triggerSequence uint64) (err error) {
+	if userID == "" {
+		return errors.ThrowInvalidArgument(nil, "COMMAND-Sn2xe", "Errors.IDMissing")
+	}
+	existingUser, err := c.userWriteModelByID(ctx, userID, orgID)
+	if err != nil {
+		return err
+	}
+	if !isUserStateExists(existingUser.UserState) {
+		return errors.ThrowNotFound(nil, "COMMAND-Sn9qa", "Errors.User.NotFound")
+	}
+
+	_, err = c.eventstore.Push(ctx,
+		user.NewUserSecurityNotificationSentEvent(ctx, UserAggregateFromWriteModel(&existingUser.WriteModel), messageType, triggerSequence))
+	return err
+}
+
 func (c *Commands) checkUserExists(ctx context.Context, userID, resourceOwner string) error {
 	existingUser, err := c.userWriteModelByID(ctx, userID, resourceOwner)
 	if err != nil {
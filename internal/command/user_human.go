@@ -722,6 +722,85 @@ func (c *Commands) HumansSignOut(ctx context.Context, agentID string, userIDs []
 	return err
 }
 
+// TerminateHumanSessions signs the user out of the provided user agents
+// and revokes all refresh tokens which were issued to them
+func (c *Commands) TerminateHumanSessions(ctx context.Context, userID, orgID string, agentIDs []string) (*domain.ObjectDetails, error) {
+	if userID == "" {
+		return nil, errors.ThrowInvalidArgument(nil, "COMMAND-Tq8wz", "Errors.User.UserIDMissing")
+	}
+	if len(agentIDs) == 0 {
+		return nil, errors.ThrowInvalidArgument(nil, "COMMAND-Lw3nd", "Errors.UserSession.AgentIDMissing")
+	}
+	tokensWriteModel := NewHumanRefreshTokensWriteModel(userID, orgID)
+	err := c.eventstore.FilterToQueryReducer(ctx, tokensWriteModel)
+	if err != nil {
+		return nil, err
+	}
+	if tokensWriteModel.UserState != domain.UserStateActive {
+		return nil, errors.ThrowNotFound(nil, "COMMAND-Pc5kv", "Errors.User.NotFound")
+	}
+	userAgg := UserAggregateFromWriteModel(&tokensWriteModel.WriteModel)
+	events := make([]eventstore.Command, 0, len(agentIDs))
+	for _, tokenID := range tokensWriteModel.TokenIDsOfUserAgents(agentIDs...) {
+		events = append(events, user.NewHumanRefreshTokenRemovedEvent(ctx, userAgg, tokenID))
+	}
+	for _, agentID := range agentIDs {
+		events = append(events, user.NewHumanSignedOutEvent(ctx, userAgg, agentID))
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, events...)
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(tokensWriteModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&tokensWriteModel.WriteModel), nil
+}
+
+// HumanBackChannelLogoutSent records that the client was notified about the sign out of the user agent
+func (c *Commands) HumanBackChannelLogoutSent(ctx context.Context, orgID, userID, agentID, clientID string) error {
+	if userID == "" || agentID == "" || clientID == "" {
+		return errors.ThrowInvalidArgument(nil, "COMMAND-Rb3sx", "Errors.IDMissing")
+	}
+	existingUser, err := c.getHumanWriteModelByID(ctx, userID, orgID)
+	if err != nil {
+		return err
+	}
+	if !isUserStateExists(existingUser.UserState) {
+		return errors.ThrowNotFound(nil, "COMMAND-Hk9ew", "Errors.User.NotFound")
+	}
+	_, err = c.eventstore.Push(ctx, user.NewHumanBackChannelLogoutSentEvent(
+		ctx,
+		UserAggregateFromWriteModel(&existingUser.WriteModel),
+		agentID,
+		clientID,
+	))
+	return err
+}
+
+// HumanSAMLResponseSent records that the user agent received a SAML response (assertion) for the application,
+// so the application can be notified on a single logout of the user agent
+func (c *Commands) HumanSAMLResponseSent(ctx context.Context, orgID, userID, agentID, applicationID string) error {
+	if userID == "" || agentID == "" || applicationID == "" {
+		return errors.ThrowInvalidArgument(nil, "COMMAND-Jq8fs", "Errors.IDMissing")
+	}
+	existingUser, err := c.getHumanWriteModelByID(ctx, userID, orgID)
+	if err != nil {
+		return err
+	}
+	if !isUserStateExists(existingUser.UserState) {
+		return errors.ThrowNotFound(nil, "COMMAND-Vd6ne", "Errors.User.NotFound")
+	}
+	_, err = c.eventstore.Push(ctx, user.NewHumanSAMLResponseSentEvent(
+		ctx,
+		UserAggregateFromWriteModel(&existingUser.WriteModel),
+		agentID,
+		applicationID,
+	))
+	return err
+}
+
 func (c *Commands) getHumanWriteModelByID(ctx context.Context, userID, resourceowner string) (*HumanWriteModel, error) {
 	humanWriteModel := NewHumanWriteModel(userID, resourceowner)
 	err := c.eventstore.FilterToQueryReducer(ctx, humanWriteModel)
@@ -0,0 +1,78 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+// GrantUserConsent stores the consent of the user to the scopes and project roles requested by the client.
+// An existing consent of the user for the client is replaced.
+func (c *Commands) GrantUserConsent(ctx context.Context, userID, resourceOwner, clientID, projectID string, scopes, roles []string) (*domain.ObjectDetails, error) {
+	if userID == "" || clientID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Gc4vr", "Errors.IDMissing")
+	}
+	if err := c.checkUserExists(ctx, userID, resourceOwner); err != nil {
+		return nil, err
+	}
+	consentWriteModel, err := c.userConsentWriteModelByID(ctx, userID, resourceOwner, clientID)
+	if err != nil {
+		return nil, err
+	}
+	userAgg := UserAggregateFromWriteModel(&consentWriteModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, user.NewHumanConsentGrantedEvent(ctx, userAgg, clientID, projectID, scopes, roles))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(consentWriteModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&consentWriteModel.WriteModel), nil
+}
+
+// RevokeUserConsent removes the consent of the user for the client
+// and revokes all refresh tokens issued to the client for the user.
+func (c *Commands) RevokeUserConsent(ctx context.Context, userID, resourceOwner, clientID string) (*domain.ObjectDetails, error) {
+	if userID == "" || clientID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Pw2xu", "Errors.IDMissing")
+	}
+	consentWriteModel, err := c.userConsentWriteModelByID(ctx, userID, resourceOwner, clientID)
+	if err != nil {
+		return nil, err
+	}
+	if !consentWriteModel.State.Exists() {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Nh7fe", "Errors.User.Consent.NotFound")
+	}
+	userAgg := UserAggregateFromWriteModel(&consentWriteModel.WriteModel)
+	events := make([]eventstore.Command, 0, len(consentWriteModel.RefreshTokenIDs)+1)
+	events = append(events, user.NewHumanConsentRevokedEvent(ctx, userAgg, clientID))
+	for _, tokenID := range consentWriteModel.RefreshTokenIDs {
+		events = append(events, user.NewHumanRefreshTokenRemovedEvent(ctx, userAgg, tokenID))
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, events...)
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(consentWriteModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&consentWriteModel.WriteModel), nil
+}
+
+func (c *Commands) userConsentWriteModelByID(ctx context.Context, userID, resourceOwner, clientID string) (writeModel *HumanConsentWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	writeModel = NewHumanConsentWriteModel(userID, resourceOwner, clientID)
+	err = c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
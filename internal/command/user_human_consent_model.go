@@ -0,0 +1,104 @@
+package command
+
+import (
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+type HumanConsentWriteModel struct {
+	eventstore.WriteModel
+
+	ClientID  string
+	ProjectID string
+	Scopes    []string
+	Roles     []string
+	State     domain.UserConsentState
+
+	// RefreshTokenIDs are the active refresh tokens issued to the client
+	RefreshTokenIDs []string
+}
+
+func NewHumanConsentWriteModel(userID, resourceOwner, clientID string) *HumanConsentWriteModel {
+	return &HumanConsentWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   userID,
+			ResourceOwner: resourceOwner,
+		},
+		ClientID: clientID,
+	}
+}
+
+func (wm *HumanConsentWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *user.HumanConsentGrantedEvent:
+			if wm.ClientID != e.ClientID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *user.HumanConsentRevokedEvent:
+			if wm.ClientID != e.ClientID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *user.HumanRefreshTokenAddedEvent:
+			if wm.ClientID != e.ClientID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		default:
+			wm.WriteModel.AppendEvents(e)
+		}
+	}
+}
+
+func (wm *HumanConsentWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *user.HumanConsentGrantedEvent:
+			wm.ProjectID = e.ProjectID
+			wm.Scopes = e.Scopes
+			wm.Roles = e.Roles
+			wm.State = domain.UserConsentStateActive
+		case *user.HumanConsentRevokedEvent:
+			wm.State = domain.UserConsentStateRemoved
+		case *user.HumanRefreshTokenAddedEvent:
+			wm.RefreshTokenIDs = append(wm.RefreshTokenIDs, e.TokenID)
+		case *user.HumanRefreshTokenRemovedEvent:
+			wm.removeRefreshToken(e.TokenID)
+		case *user.UserRemovedEvent:
+			wm.State = domain.UserConsentStateRemoved
+			wm.RefreshTokenIDs = nil
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *HumanConsentWriteModel) Query() *eventstore.SearchQueryBuilder {
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			user.HumanConsentGrantedType,
+			user.HumanConsentRevokedType,
+			user.HumanRefreshTokenAddedType,
+			user.HumanRefreshTokenRemovedType,
+			user.UserRemovedType).
+		Builder()
+
+	if wm.ResourceOwner != "" {
+		query.ResourceOwner(wm.ResourceOwner)
+	}
+	return query
+}
+
+func (wm *HumanConsentWriteModel) removeRefreshToken(tokenID string) {
+	for i, id := range wm.RefreshTokenIDs {
+		if id == tokenID {
+			wm.RefreshTokenIDs = append(wm.RefreshTokenIDs[:i], wm.RefreshTokenIDs[i+1:]...)
+			return
+		}
+	}
+}
@@ -0,0 +1,327 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+func TestCommandSide_GrantUserConsent(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx       context.Context
+		orgID     string
+		userID    string
+		clientID  string
+		projectID string
+		scopes    []string
+		roles     []string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "client id missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:    context.Background(),
+				orgID:  "org1",
+				userID: "user1",
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "user not existing, precondition failed error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:      context.Background(),
+				orgID:    "org1",
+				userID:   "user1",
+				clientID: "client1",
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "grant consent, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"username",
+								"firstname",
+								"lastname",
+								"nickname",
+								"displayname",
+								language.German,
+								domain.GenderUnspecified,
+								"email@test.ch",
+								true,
+							),
+						),
+					),
+					expectFilter(),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								user.NewHumanConsentGrantedEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									"client1",
+									"project1",
+									[]string{"openid", "profile"},
+									[]string{"role1"},
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:       context.Background(),
+				orgID:     "org1",
+				userID:    "user1",
+				clientID:  "client1",
+				projectID: "project1",
+				scopes:    []string{"openid", "profile"},
+				roles:     []string{"role1"},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.GrantUserConsent(tt.args.ctx, tt.args.userID, tt.args.orgID, tt.args.clientID, tt.args.projectID, tt.args.scopes, tt.args.roles)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_RevokeUserConsent(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx      context.Context
+		orgID    string
+		userID   string
+		clientID string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	refreshTokenAddedEvent := func(tokenID, clientID string) *repository.Event {
+		return eventFromEventPusher(
+			user.NewHumanRefreshTokenAddedEvent(context.Background(),
+				&user.NewAggregate("user1", "org1").Aggregate,
+				tokenID,
+				clientID,
+				"agent1",
+				"de",
+				[]string{clientID},
+				[]string{"openid", "offline_access"},
+				[]string{"password"},
+				time.Now(),
+				time.Hour,
+				24*time.Hour,
+				false,
+				nil,
+			),
+		)
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "client id missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:    context.Background(),
+				orgID:  "org1",
+				userID: "user1",
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "consent not existing, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:      context.Background(),
+				orgID:    "org1",
+				userID:   "user1",
+				clientID: "client1",
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "consent already revoked, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanConsentGrantedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"client1",
+								"project1",
+								[]string{"openid"},
+								nil,
+							),
+						),
+						eventFromEventPusher(
+							user.NewHumanConsentRevokedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"client1",
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:      context.Background(),
+				orgID:    "org1",
+				userID:   "user1",
+				clientID: "client1",
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "revoke consent, refresh tokens of client removed",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanConsentGrantedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"client1",
+								"project1",
+								[]string{"openid", "offline_access"},
+								nil,
+							),
+						),
+						refreshTokenAddedEvent("token1", "client1"),
+						refreshTokenAddedEvent("token2", "client2"),
+						refreshTokenAddedEvent("token3", "client1"),
+						eventFromEventPusher(
+							user.NewHumanRefreshTokenRemovedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"token3",
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								user.NewHumanConsentRevokedEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									"client1",
+								),
+							),
+							eventFromEventPusher(
+								user.NewHumanRefreshTokenRemovedEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									"token1",
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:      context.Background(),
+				orgID:    "org1",
+				userID:   "user1",
+				clientID: "client1",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.RevokeUserConsent(tt.args.ctx, tt.args.userID, tt.args.orgID, tt.args.clientID)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
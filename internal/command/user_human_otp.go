@@ -2,6 +2,7 @@ package command
 
 import (
 	"context"
+	"time"
 
 	"github.com/zitadel/logging"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
 	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
 	"github.com/zitadel/zitadel/internal/repository/user"
 	"github.com/zitadel/zitadel/internal/telemetry/tracing"
@@ -181,3 +183,286 @@ func (c *Commands) otpWriteModelByID(ctx context.Context, userID, resourceOwner
 	}
 	return writeModel, nil
 }
+
+func (c *Commands) AddHumanOTPSMS(ctx context.Context, userID, resourceOwner string) (*domain.ObjectDetails, error) {
+	if userID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Qs3cn", "Errors.User.UserIDMissing")
+	}
+	otpWriteModel, err := c.otpSMSWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if otpWriteModel.State == domain.MFAStateReady {
+		return nil, caos_errs.ThrowAlreadyExists(nil, "COMMAND-Ad3gs", "Errors.User.MFA.OTPSMS.AlreadyReady")
+	}
+	if !otpWriteModel.IsPhoneVerified {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Zq8rv", "Errors.User.MFA.OTPSMS.PhoneNotVerified")
+	}
+	userAgg := UserAggregateFromWriteModel(&otpWriteModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, user.NewHumanOTPSMSAddedEvent(ctx, userAgg))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(otpWriteModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&otpWriteModel.WriteModel), nil
+}
+
+func (c *Commands) RemoveHumanOTPSMS(ctx context.Context, userID, resourceOwner string) (*domain.ObjectDetails, error) {
+	if userID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Fw9ql", "Errors.User.UserIDMissing")
+	}
+	otpWriteModel, err := c.otpSMSWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if otpWriteModel.State != domain.MFAStateReady {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Pv2mz", "Errors.User.MFA.OTPSMS.NotExisting")
+	}
+	userAgg := UserAggregateFromWriteModel(&otpWriteModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, user.NewHumanOTPSMSRemovedEvent(ctx, userAgg))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(otpWriteModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&otpWriteModel.WriteModel), nil
+}
+
+// HumanSendOTPSMS creates a new one-time code, which will be sent to the verified phone of the user.
+// A new code can only be requested once the configured resend interval has passed.
+func (c *Commands) HumanSendOTPSMS(ctx context.Context, userID, resourceOwner string, authRequest *domain.AuthRequest) error {
+	if userID == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Ui6dw", "Errors.User.UserIDMissing")
+	}
+	otpWriteModel, err := c.otpSMSWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return err
+	}
+	if otpWriteModel.State != domain.MFAStateReady || !otpWriteModel.IsPhoneVerified {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Jk3ds", "Errors.User.MFA.OTPSMS.NotExisting")
+	}
+	if otpCodeRecentlySent(otpWriteModel.CodeCreationDate, c.multifactors.OTPSMS.ResendInterval) {
+		return caos_errs.ThrowResourceExhausted(nil, "COMMAND-Wm2xo", "Errors.User.MFA.OTPSMS.CodeRecentlySent")
+	}
+	code, _, err := crypto.NewCode(crypto.NewEncryptionGenerator(c.multifactors.OTPSMS.CodeGenerator, c.userEncryption))
+	if err != nil {
+		return err
+	}
+	userAgg := UserAggregateFromWriteModel(&otpWriteModel.WriteModel)
+	_, err = c.eventstore.Push(ctx, user.NewHumanOTPSMSCodeAddedEvent(ctx, userAgg, code, c.multifactors.OTPSMS.CodeGenerator.Expiry, authRequestDomainToAuthRequestInfo(authRequest)))
+	return err
+}
+
+func (c *Commands) HumanOTPSMSCodeSent(ctx context.Context, userID, resourceOwner string) error {
+	if userID == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Ye4pb", "Errors.User.UserIDMissing")
+	}
+	otpWriteModel, err := c.otpSMSWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return err
+	}
+	if otpWriteModel.State != domain.MFAStateReady {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Ro7fe", "Errors.User.MFA.OTPSMS.NotExisting")
+	}
+	userAgg := UserAggregateFromWriteModel(&otpWriteModel.WriteModel)
+	_, err = c.eventstore.Push(ctx, user.NewHumanOTPSMSCodeSentEvent(ctx, userAgg))
+	return err
+}
+
+// HumanCheckOTPSMS verifies the code sent to the user.
+// The code is invalidated after the configured amount of failed checks
+// and the user is locked, if the failed checks since the last successful one reach the max attempts of the lockout policy.
+func (c *Commands) HumanCheckOTPSMS(ctx context.Context, userID, code, resourceOwner string, authRequest *domain.AuthRequest, lockoutPolicy *domain.LockoutPolicy) error {
+	if userID == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Xc1wa", "Errors.User.UserIDMissing")
+	}
+	if code == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Nd5ij", "Errors.User.Code.Empty")
+	}
+	otpWriteModel, err := c.otpSMSWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return err
+	}
+	if otpWriteModel.State != domain.MFAStateReady {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Ob6tl", "Errors.User.MFA.OTPSMS.NotExisting")
+	}
+	if otpWriteModel.Code == nil || otpCodeAttemptsExceeded(otpWriteModel.CodeCheckFailedCount, c.multifactors.OTPSMS.MaxAttempts) {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Ew2ak", "Errors.User.Code.NotFound")
+	}
+	userAgg := UserAggregateFromWriteModel(&otpWriteModel.WriteModel)
+	err = crypto.VerifyCode(otpWriteModel.CodeCreationDate, otpWriteModel.CodeExpiry, otpWriteModel.Code, code, crypto.NewEncryptionGenerator(c.multifactors.OTPSMS.CodeGenerator, c.userEncryption))
+	if err == nil {
+		_, err = c.eventstore.Push(ctx, user.NewHumanOTPSMSCheckSucceededEvent(ctx, userAgg, authRequestDomainToAuthRequestInfo(authRequest)))
+		return err
+	}
+	events := []eventstore.Command{user.NewHumanOTPSMSCheckFailedEvent(ctx, userAgg, authRequestDomainToAuthRequestInfo(authRequest))}
+	if lockoutPolicy != nil && lockoutPolicy.MaxPasswordAttempts > 0 && otpWriteModel.CheckFailedCount+1 >= lockoutPolicy.MaxPasswordAttempts && !otpWriteModel.UserLocked {
+		events = append(events, user.NewUserLockedEvent(ctx, userAgg, true))
+	}
+	_, pushErr := c.eventstore.Push(ctx, events...)
+	logging.WithFields("userID", userID).OnError(pushErr).Error("error create otp sms check failed event")
+	return err
+}
+
+// otpCodeAttemptsExceeded checks if the code was checked too often without success, so a new code has to be requested
+func otpCodeAttemptsExceeded(failedCount, maxAttempts uint64) bool {
+	return maxAttempts > 0 && failedCount >= maxAttempts
+}
+
+func (c *Commands) otpSMSWriteModelByID(ctx context.Context, userID, resourceOwner string) (writeModel *HumanOTPSMSWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	writeModel = NewHumanOTPSMSWriteModel(userID, resourceOwner)
+	err = c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
+
+func (c *Commands) AddHumanOTPEmail(ctx context.Context, userID, resourceOwner string) (*domain.ObjectDetails, error) {
+	if userID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Hx7gu", "Errors.User.UserIDMissing")
+	}
+	otpWriteModel, err := c.otpEmailWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if otpWriteModel.State == domain.MFAStateReady {
+		return nil, caos_errs.ThrowAlreadyExists(nil, "COMMAND-Tm5sq", "Errors.User.MFA.OTPEmail.AlreadyReady")
+	}
+	if !otpWriteModel.IsEmailVerified {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Lb9ow", "Errors.User.MFA.OTPEmail.EmailNotVerified")
+	}
+	userAgg := UserAggregateFromWriteModel(&otpWriteModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, user.NewHumanOTPEmailAddedEvent(ctx, userAgg))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(otpWriteModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&otpWriteModel.WriteModel), nil
+}
+
+func (c *Commands) RemoveHumanOTPEmail(ctx context.Context, userID, resourceOwner string) (*domain.ObjectDetails, error) {
+	if userID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Ck2ny", "Errors.User.UserIDMissing")
+	}
+	otpWriteModel, err := c.otpEmailWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if otpWriteModel.State != domain.MFAStateReady {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Sa4vh", "Errors.User.MFA.OTPEmail.NotExisting")
+	}
+	userAgg := UserAggregateFromWriteModel(&otpWriteModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, user.NewHumanOTPEmailRemovedEvent(ctx, userAgg))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(otpWriteModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&otpWriteModel.WriteModel), nil
+}
+
+// HumanSendOTPEmail creates a new one-time code, which will be sent to the verified email of the user.
+// A new code can only be requested once the configured resend interval has passed.
+func (c *Commands) HumanSendOTPEmail(ctx context.Context, userID, resourceOwner string, authRequest *domain.AuthRequest) error {
+	if userID == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Vo1rz", "Errors.User.UserIDMissing")
+	}
+	otpWriteModel, err := c.otpEmailWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return err
+	}
+	if otpWriteModel.State != domain.MFAStateReady || !otpWriteModel.IsEmailVerified {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Gt8ee", "Errors.User.MFA.OTPEmail.NotExisting")
+	}
+	if otpCodeRecentlySent(otpWriteModel.CodeCreationDate, c.multifactors.OTPEmail.ResendInterval) {
+		return caos_errs.ThrowResourceExhausted(nil, "COMMAND-Ry5lp", "Errors.User.MFA.OTPEmail.CodeRecentlySent")
+	}
+	code, _, err := crypto.NewCode(crypto.NewEncryptionGenerator(c.multifactors.OTPEmail.CodeGenerator, c.userEncryption))
+	if err != nil {
+		return err
+	}
+	userAgg := UserAggregateFromWriteModel(&otpWriteModel.WriteModel)
+	_, err = c.eventstore.Push(ctx, user.NewHumanOTPEmailCodeAddedEvent(ctx, userAgg, code, c.multifactors.OTPEmail.CodeGenerator.Expiry, authRequestDomainToAuthRequestInfo(authRequest)))
+	return err
+}
+
+func (c *Commands) HumanOTPEmailCodeSent(ctx context.Context, userID, resourceOwner string) error {
+	if userID == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Dn3kq", "Errors.User.UserIDMissing")
+	}
+	otpWriteModel, err := c.otpEmailWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return err
+	}
+	if otpWriteModel.State != domain.MFAStateReady {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Mi6ha", "Errors.User.MFA.OTPEmail.NotExisting")
+	}
+	userAgg := UserAggregateFromWriteModel(&otpWriteModel.WriteModel)
+	_, err = c.eventstore.Push(ctx, user.NewHumanOTPEmailCodeSentEvent(ctx, userAgg))
+	return err
+}
+
+// HumanCheckOTPEmail verifies the code sent to the user.
+// The code is invalidated after the configured amount of failed checks
+// and the user is locked, if the failed checks since the last successful one reach the max attempts of the lockout policy.
+func (c *Commands) HumanCheckOTPEmail(ctx context.Context, userID, code, resourceOwner string, authRequest *domain.AuthRequest, lockoutPolicy *domain.LockoutPolicy) error {
+	if userID == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Uw9jc", "Errors.User.UserIDMissing")
+	}
+	if code == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Qp2zt", "Errors.User.Code.Empty")
+	}
+	otpWriteModel, err := c.otpEmailWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return err
+	}
+	if otpWriteModel.State != domain.MFAStateReady {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Fe7xn", "Errors.User.MFA.OTPEmail.NotExisting")
+	}
+	if otpWriteModel.Code == nil || otpCodeAttemptsExceeded(otpWriteModel.CodeCheckFailedCount, c.multifactors.OTPEmail.MaxAttempts) {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Jr4bm", "Errors.User.Code.NotFound")
+	}
+	userAgg := UserAggregateFromWriteModel(&otpWriteModel.WriteModel)
+	err = crypto.VerifyCode(otpWriteModel.CodeCreationDate, otpWriteModel.CodeExpiry, otpWriteModel.Code, code, crypto.NewEncryptionGenerator(c.multifactors.OTPEmail.CodeGenerator, c.userEncryption))
+	if err == nil {
+		_, err = c.eventstore.Push(ctx, user.NewHumanOTPEmailCheckSucceededEvent(ctx, userAgg, authRequestDomainToAuthRequestInfo(authRequest)))
+		return err
+	}
+	events := []eventstore.Command{user.NewHumanOTPEmailCheckFailedEvent(ctx, userAgg, authRequestDomainToAuthRequestInfo(authRequest))}
+	if lockoutPolicy != nil && lockoutPolicy.MaxPasswordAttempts > 0 && otpWriteModel.CheckFailedCount+1 >= lockoutPolicy.MaxPasswordAttempts && !otpWriteModel.UserLocked {
+		events = append(events, user.NewUserLockedEvent(ctx, userAgg, true))
+	}
+	_, pushErr := c.eventstore.Push(ctx, events...)
+	logging.WithFields("userID", userID).OnError(pushErr).Error("error create otp email check failed event")
+	return err
+}
+
+func (c *Commands) otpEmailWriteModelByID(ctx context.Context, userID, resourceOwner string) (writeModel *HumanOTPEmailWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	writeModel = NewHumanOTPEmailWriteModel(userID, resourceOwner)
+	err = c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
+
+func otpCodeRecentlySent(lastCodeCreationDate time.Time, resendInterval time.Duration) bool {
+	return !lastCodeCreationDate.IsZero() && lastCodeCreationDate.Add(resendInterval).After(time.Now())
+}
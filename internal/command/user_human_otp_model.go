@@ -1,6 +1,8 @@
 package command
 
 import (
+	"time"
+
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
@@ -59,3 +61,184 @@ func (wm *HumanOTPWriteModel) Query() *eventstore.SearchQueryBuilder {
 	}
 	return query
 }
+
+type HumanOTPSMSWriteModel struct {
+	eventstore.WriteModel
+
+	State           domain.MFAState
+	IsPhoneVerified bool
+
+	Code             *crypto.CryptoValue
+	CodeCreationDate time.Time
+	CodeExpiry       time.Duration
+	// CodeCheckFailedCount counts the failed checks of the current code
+	CodeCheckFailedCount uint64
+	// CheckFailedCount counts the failed checks since the last successful check over all codes
+	CheckFailedCount uint64
+	// UserLocked is set if the user is locked, so the user isn't locked again on further failed checks
+	UserLocked bool
+}
+
+func NewHumanOTPSMSWriteModel(userID, resourceOwner string) *HumanOTPSMSWriteModel {
+	return &HumanOTPSMSWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   userID,
+			ResourceOwner: resourceOwner,
+		},
+	}
+}
+
+func (wm *HumanOTPSMSWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *user.HumanPhoneChangedEvent,
+			*user.HumanPhoneRemovedEvent:
+			wm.IsPhoneVerified = false
+		case *user.HumanPhoneVerifiedEvent:
+			wm.IsPhoneVerified = true
+		case *user.HumanOTPSMSAddedEvent:
+			wm.State = domain.MFAStateReady
+		case *user.HumanOTPSMSCodeAddedEvent:
+			wm.Code = e.Code
+			wm.CodeCreationDate = e.CreationDate()
+			wm.CodeExpiry = e.Expiry
+			wm.CodeCheckFailedCount = 0
+		case *user.HumanOTPSMSCheckSucceededEvent:
+			wm.Code = nil
+			wm.CodeCheckFailedCount = 0
+			wm.CheckFailedCount = 0
+		case *user.HumanOTPSMSCheckFailedEvent:
+			wm.CodeCheckFailedCount++
+			wm.CheckFailedCount++
+		case *user.UserLockedEvent:
+			wm.UserLocked = true
+		case *user.UserUnlockedEvent:
+			wm.CheckFailedCount = 0
+			wm.UserLocked = false
+		case *user.HumanOTPSMSRemovedEvent:
+			wm.State = domain.MFAStateRemoved
+			wm.Code = nil
+		case *user.UserRemovedEvent:
+			wm.State = domain.MFAStateRemoved
+			wm.IsPhoneVerified = false
+			wm.Code = nil
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *HumanOTPSMSWriteModel) Query() *eventstore.SearchQueryBuilder {
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(user.HumanPhoneChangedType,
+			user.HumanPhoneVerifiedType,
+			user.HumanPhoneRemovedType,
+			user.HumanMFAOTPSMSAddedType,
+			user.HumanMFAOTPSMSCodeAddedType,
+			user.HumanMFAOTPSMSCheckSucceededType,
+			user.HumanMFAOTPSMSCheckFailedType,
+			user.UserLockedType,
+			user.UserUnlockedType,
+			user.HumanMFAOTPSMSRemovedType,
+			user.UserRemovedType,
+			user.UserV1PhoneChangedType,
+			user.UserV1PhoneVerifiedType,
+			user.UserV1PhoneRemovedType).
+		Builder()
+
+	if wm.ResourceOwner != "" {
+		query.ResourceOwner(wm.ResourceOwner)
+	}
+	return query
+}
+
+type HumanOTPEmailWriteModel struct {
+	eventstore.WriteModel
+
+	State           domain.MFAState
+	IsEmailVerified bool
+
+	Code             *crypto.CryptoValue
+	CodeCreationDate time.Time
+	CodeExpiry       time.Duration
+	// CodeCheckFailedCount counts the failed checks of the current code
+	CodeCheckFailedCount uint64
+	// CheckFailedCount counts the failed checks since the last successful check over all codes
+	CheckFailedCount uint64
+	// UserLocked is set if the user is locked, so the user isn't locked again on further failed checks
+	UserLocked bool
+}
+
+func NewHumanOTPEmailWriteModel(userID, resourceOwner string) *HumanOTPEmailWriteModel {
+	return &HumanOTPEmailWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   userID,
+			ResourceOwner: resourceOwner,
+		},
+	}
+}
+
+func (wm *HumanOTPEmailWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *user.HumanEmailChangedEvent:
+			wm.IsEmailVerified = false
+		case *user.HumanEmailVerifiedEvent:
+			wm.IsEmailVerified = true
+		case *user.HumanOTPEmailAddedEvent:
+			wm.State = domain.MFAStateReady
+		case *user.HumanOTPEmailCodeAddedEvent:
+			wm.Code = e.Code
+			wm.CodeCreationDate = e.CreationDate()
+			wm.CodeExpiry = e.Expiry
+			wm.CodeCheckFailedCount = 0
+		case *user.HumanOTPEmailCheckSucceededEvent:
+			wm.Code = nil
+			wm.CodeCheckFailedCount = 0
+			wm.CheckFailedCount = 0
+		case *user.HumanOTPEmailCheckFailedEvent:
+			wm.CodeCheckFailedCount++
+			wm.CheckFailedCount++
+		case *user.UserLockedEvent:
+			wm.UserLocked = true
+		case *user.UserUnlockedEvent:
+			wm.CheckFailedCount = 0
+			wm.UserLocked = false
+		case *user.HumanOTPEmailRemovedEvent:
+			wm.State = domain.MFAStateRemoved
+			wm.Code = nil
+		case *user.UserRemovedEvent:
+			wm.State = domain.MFAStateRemoved
+			wm.IsEmailVerified = false
+			wm.Code = nil
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *HumanOTPEmailWriteModel) Query() *eventstore.SearchQueryBuilder {
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(user.HumanEmailChangedType,
+			user.HumanEmailVerifiedType,
+			user.HumanMFAOTPEmailAddedType,
+			user.HumanMFAOTPEmailCodeAddedType,
+			user.HumanMFAOTPEmailCheckSucceededType,
+			user.HumanMFAOTPEmailCheckFailedType,
+			user.UserLockedType,
+			user.UserUnlockedType,
+			user.HumanMFAOTPEmailRemovedType,
+			user.UserRemovedType,
+			user.UserV1EmailChangedType,
+			user.UserV1EmailVerifiedType).
+		Builder()
+
+	if wm.ResourceOwner != "" {
+		query.ResourceOwner(wm.ResourceOwner)
+	}
+	return query
+}
@@ -1445,6 +1445,7 @@ func TestCommandSide_CheckPassword(t *testing.T) {
 							eventFromEventPusher(
 								user.NewUserLockedEvent(context.Background(),
 									&user.NewAggregate("user1", "org1").Aggregate,
+									true,
 								),
 							),
 						},
@@ -0,0 +1,104 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+// GenerateHumanRecoveryCodes creates a new set of single-use recovery codes for the user.
+// Only the hashes are stored, so the returned plain codes can't be retrieved again.
+// Any previously generated set is invalidated.
+func (c *Commands) GenerateHumanRecoveryCodes(ctx context.Context, userID, resourceOwner string) ([]string, *domain.ObjectDetails, error) {
+	if userID == "" {
+		return nil, nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Vb3sq", "Errors.User.UserIDMissing")
+	}
+	if err := c.checkUserExists(ctx, userID, resourceOwner); err != nil {
+		return nil, nil, err
+	}
+	recoveryCodesWriteModel, err := c.recoveryCodesWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return nil, nil, err
+	}
+	generator := crypto.NewHashGenerator(c.multifactors.RecoveryCodes.CodeGenerator, c.userPasswordAlg)
+	hashedCodes := make([]*crypto.CryptoValue, c.multifactors.RecoveryCodes.Count)
+	codes := make([]string, c.multifactors.RecoveryCodes.Count)
+	for i := range codes {
+		hashedCodes[i], codes[i], err = crypto.NewCode(generator)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	userAgg := UserAggregateFromWriteModel(&recoveryCodesWriteModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, user.NewHumanRecoveryCodesGeneratedEvent(ctx, userAgg, hashedCodes))
+	if err != nil {
+		return nil, nil, err
+	}
+	err = AppendAndReduce(recoveryCodesWriteModel, pushedEvents...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return codes, writeModelToObjectDetails(&recoveryCodesWriteModel.WriteModel), nil
+}
+
+// HumanCheckRecoveryCode verifies the code against the unused codes of the current set
+// and invalidates the matching code on success.
+func (c *Commands) HumanCheckRecoveryCode(ctx context.Context, userID, code, resourceOwner string, authRequest *domain.AuthRequest) error {
+	if userID == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Lp8ce", "Errors.User.UserIDMissing")
+	}
+	if code == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Tz4ka", "Errors.User.Code.Empty")
+	}
+	recoveryCodesWriteModel, err := c.recoveryCodesWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return err
+	}
+	if recoveryCodesWriteModel.RemainingCodes() == 0 {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Hs9vn", "Errors.User.MFA.RecoveryCode.NoneLeft")
+	}
+	userAgg := UserAggregateFromWriteModel(&recoveryCodesWriteModel.WriteModel)
+	for i, hashedCode := range recoveryCodesWriteModel.Codes {
+		if hashedCode == nil {
+			continue
+		}
+		if crypto.CompareHash(hashedCode, []byte(code), c.userPasswordAlg) == nil {
+			_, err = c.eventstore.Push(ctx, user.NewHumanRecoveryCodeCheckSucceededEvent(ctx, userAgg, i, authRequestDomainToAuthRequestInfo(authRequest)))
+			return err
+		}
+	}
+	_, pushErr := c.eventstore.Push(ctx, user.NewHumanRecoveryCodeCheckFailedEvent(ctx, userAgg, authRequestDomainToAuthRequestInfo(authRequest)))
+	logging.WithFields("userID", userID).OnError(pushErr).Error("error create recovery code check failed event")
+	return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Qe6uf", "Errors.User.MFA.RecoveryCode.Invalid")
+}
+
+func (c *Commands) HumanRecoveryCodeUsedNotificationSent(ctx context.Context, userID, resourceOwner string, codeIndex int) error {
+	if userID == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Ck2zp", "Errors.User.UserIDMissing")
+	}
+	recoveryCodesWriteModel, err := c.recoveryCodesWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return err
+	}
+	userAgg := UserAggregateFromWriteModel(&recoveryCodesWriteModel.WriteModel)
+	_, err = c.eventstore.Push(ctx, user.NewHumanRecoveryCodeUsedNotificationSentEvent(ctx, userAgg, codeIndex))
+	return err
+}
+
+func (c *Commands) recoveryCodesWriteModelByID(ctx context.Context, userID, resourceOwner string) (writeModel *HumanRecoveryCodesWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	writeModel = NewHumanRecoveryCodesWriteModel(userID, resourceOwner)
+	err = c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
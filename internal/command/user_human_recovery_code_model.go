@@ -0,0 +1,66 @@
+package command
+
+import (
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+type HumanRecoveryCodesWriteModel struct {
+	eventstore.WriteModel
+
+	// Codes contains the hashed codes of the current set, used codes are nil
+	Codes []*crypto.CryptoValue
+}
+
+func NewHumanRecoveryCodesWriteModel(userID, resourceOwner string) *HumanRecoveryCodesWriteModel {
+	return &HumanRecoveryCodesWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   userID,
+			ResourceOwner: resourceOwner,
+		},
+	}
+}
+
+func (wm *HumanRecoveryCodesWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *user.HumanRecoveryCodesGeneratedEvent:
+			wm.Codes = e.Codes
+		case *user.HumanRecoveryCodeCheckSucceededEvent:
+			if e.CodeIndex >= 0 && e.CodeIndex < len(wm.Codes) {
+				wm.Codes[e.CodeIndex] = nil
+			}
+		case *user.UserRemovedEvent:
+			wm.Codes = nil
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *HumanRecoveryCodesWriteModel) Query() *eventstore.SearchQueryBuilder {
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(user.HumanMFARecoveryCodesGeneratedType,
+			user.HumanMFARecoveryCodeCheckSucceededType,
+			user.UserRemovedType).
+		Builder()
+
+	if wm.ResourceOwner != "" {
+		query.ResourceOwner(wm.ResourceOwner)
+	}
+	return query
+}
+
+// RemainingCodes returns the amount of codes of the current set which were not used yet
+func (wm *HumanRecoveryCodesWriteModel) RemainingCodes() int {
+	remaining := 0
+	for _, code := range wm.Codes {
+		if code != nil {
+			remaining++
+		}
+	}
+	return remaining
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"time"
 
+	"github.com/zitadel/logging"
+
 	"github.com/zitadel/zitadel/internal/domain"
 	caos_errs "github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/eventstore"
@@ -24,11 +26,14 @@ func (c *Commands) AddAccessAndRefreshToken(
 	refreshIdleExpiration,
 	refreshExpiration time.Duration,
 	authTime time.Time,
+	reuseDetection bool,
+	confirmation *domain.TokenConfirmation,
+	bindRefreshToken bool,
 ) (accessToken *domain.Token, newRefreshToken string, err error) {
 	if refreshToken == "" {
-		return c.AddNewRefreshTokenAndAccessToken(ctx, userID, orgID, agentID, clientID, audience, scopes, authMethodsReferences, refreshExpiration, accessLifetime, refreshIdleExpiration, authTime)
+		return c.AddNewRefreshTokenAndAccessToken(ctx, userID, orgID, agentID, clientID, audience, scopes, authMethodsReferences, refreshExpiration, accessLifetime, refreshIdleExpiration, authTime, reuseDetection, confirmation, bindRefreshToken)
 	}
-	return c.RenewRefreshTokenAndAccessToken(ctx, userID, orgID, refreshToken, agentID, clientID, audience, scopes, refreshIdleExpiration, accessLifetime)
+	return c.RenewRefreshTokenAndAccessToken(ctx, userID, orgID, refreshToken, agentID, clientID, audience, scopes, refreshIdleExpiration, accessLifetime, confirmation)
 }
 
 func (c *Commands) AddNewRefreshTokenAndAccessToken(
@@ -44,6 +49,9 @@ func (c *Commands) AddNewRefreshTokenAndAccessToken(
 	accessLifetime,
 	refreshIdleExpiration time.Duration,
 	authTime time.Time,
+	reuseDetection bool,
+	confirmation *domain.TokenConfirmation,
+	bindRefreshToken bool,
 ) (accessToken *domain.Token, newRefreshToken string, err error) {
 	if userID == "" || agentID == "" || clientID == "" {
 		return nil, "", caos_errs.ThrowInvalidArgument(nil, "COMMAND-adg4r", "Errors.IDMissing")
@@ -53,11 +61,15 @@ func (c *Commands) AddNewRefreshTokenAndAccessToken(
 	if err != nil {
 		return nil, "", err
 	}
-	accessTokenEvent, accessToken, err := c.addUserToken(ctx, userWriteModel, agentID, clientID, refreshTokenID, audience, scopes, accessLifetime)
+	accessTokenEvent, accessToken, err := c.addUserToken(ctx, userWriteModel, agentID, clientID, refreshTokenID, audience, scopes, accessLifetime, confirmation)
 	if err != nil {
 		return nil, "", err
 	}
-	refreshTokenEvent, newRefreshToken, err := c.addRefreshToken(ctx, accessToken, authMethodsReferences, authTime, refreshIdleExpiration, refreshExpiration)
+	var refreshTokenConfirmation *domain.TokenConfirmation
+	if bindRefreshToken {
+		refreshTokenConfirmation = confirmation
+	}
+	refreshTokenEvent, newRefreshToken, err := c.addRefreshToken(ctx, accessToken, authMethodsReferences, authTime, refreshIdleExpiration, refreshExpiration, reuseDetection, refreshTokenConfirmation)
 	if err != nil {
 		return nil, "", err
 	}
@@ -79,13 +91,14 @@ func (c *Commands) RenewRefreshTokenAndAccessToken(
 	scopes []string,
 	idleExpiration,
 	accessLifetime time.Duration,
+	confirmation *domain.TokenConfirmation,
 ) (accessToken *domain.Token, newRefreshToken string, err error) {
-	refreshTokenEvent, refreshTokenID, newRefreshToken, err := c.renewRefreshToken(ctx, userID, orgID, refreshToken, idleExpiration)
+	refreshTokenEvent, refreshTokenID, newRefreshToken, err := c.renewRefreshToken(ctx, userID, orgID, refreshToken, idleExpiration, confirmation)
 	if err != nil {
 		return nil, "", err
 	}
 	userWriteModel := NewUserWriteModel(userID, orgID)
-	accessTokenEvent, accessToken, err := c.addUserToken(ctx, userWriteModel, agentID, clientID, refreshTokenID, audience, scopes, accessLifetime)
+	accessTokenEvent, accessToken, err := c.addUserToken(ctx, userWriteModel, agentID, clientID, refreshTokenID, audience, scopes, accessLifetime, confirmation)
 	if err != nil {
 		return nil, "", err
 	}
@@ -128,7 +141,42 @@ func (c *Commands) RevokeRefreshTokens(ctx context.Context, userID, orgID string
 	return err
 }
 
-func (c *Commands) addRefreshToken(ctx context.Context, accessToken *domain.Token, authMethodsReferences []string, authTime time.Time, idleExpiration, expiration time.Duration) (*user.HumanRefreshTokenAddedEvent, string, error) {
+// RevokeReusedRefreshToken checks if the provided refresh token has already been renewed.
+// If so and reuse detection was enabled for the token, the whole token family is revoked.
+// It returns whether the token family was revoked.
+func (c *Commands) RevokeReusedRefreshToken(ctx context.Context, refreshToken string) (revoked bool, err error) {
+	userID, tokenID, token, err := domain.FromRefreshToken(refreshToken, c.keyAlgorithm)
+	if err != nil {
+		return false, caos_errs.ThrowInvalidArgument(err, "COMMAND-Gh3sq", "Errors.User.RefreshToken.Invalid")
+	}
+	refreshTokenWriteModel := NewHumanRefreshTokenWriteModel(userID, "", tokenID)
+	err = c.eventstore.FilterToQueryReducer(ctx, refreshTokenWriteModel)
+	if err != nil {
+		return false, err
+	}
+	if refreshTokenWriteModel.RefreshToken == token {
+		return false, nil
+	}
+	return c.revokeReusedRefreshToken(ctx, refreshTokenWriteModel), nil
+}
+
+// revokeReusedRefreshToken pushes the reused and removed events for a token (family),
+// which was presented with an outdated token value.
+// Failures are only logged, as the caller will reject the token anyway.
+func (c *Commands) revokeReusedRefreshToken(ctx context.Context, refreshTokenWriteModel *HumanRefreshTokenWriteModel) bool {
+	if refreshTokenWriteModel.UserState != domain.UserStateActive || !refreshTokenWriteModel.ReuseDetection {
+		return false
+	}
+	userAgg := UserAggregateFromWriteModel(&refreshTokenWriteModel.WriteModel)
+	_, err := c.eventstore.Push(ctx,
+		user.NewHumanRefreshTokenReusedEvent(ctx, userAgg, refreshTokenWriteModel.TokenID, refreshTokenWriteModel.ClientID, refreshTokenWriteModel.UserAgentID),
+		user.NewHumanRefreshTokenRemovedEvent(ctx, userAgg, refreshTokenWriteModel.TokenID),
+	)
+	logging.WithFields("userID", refreshTokenWriteModel.AggregateID, "tokenID", refreshTokenWriteModel.TokenID).OnError(err).Error("could not revoke reused refresh token")
+	return err == nil
+}
+
+func (c *Commands) addRefreshToken(ctx context.Context, accessToken *domain.Token, authMethodsReferences []string, authTime time.Time, idleExpiration, expiration time.Duration, reuseDetection bool, confirmation *domain.TokenConfirmation) (*user.HumanRefreshTokenAddedEvent, string, error) {
 	refreshToken, err := domain.NewRefreshToken(accessToken.AggregateID, accessToken.RefreshTokenID, c.keyAlgorithm)
 	if err != nil {
 		return nil, "", err
@@ -136,11 +184,11 @@ func (c *Commands) addRefreshToken(ctx context.Context, accessToken *domain.Toke
 	refreshTokenWriteModel := NewHumanRefreshTokenWriteModel(accessToken.AggregateID, accessToken.ResourceOwner, accessToken.RefreshTokenID)
 	userAgg := UserAggregateFromWriteModel(&refreshTokenWriteModel.WriteModel)
 	return user.NewHumanRefreshTokenAddedEvent(ctx, userAgg, accessToken.RefreshTokenID, accessToken.ApplicationID, accessToken.UserAgentID,
-			accessToken.PreferredLanguage, accessToken.Audience, accessToken.Scopes, authMethodsReferences, authTime, idleExpiration, expiration),
+			accessToken.PreferredLanguage, accessToken.Audience, accessToken.Scopes, authMethodsReferences, authTime, idleExpiration, expiration, reuseDetection, confirmation),
 		refreshToken, nil
 }
 
-func (c *Commands) renewRefreshToken(ctx context.Context, userID, orgID, refreshToken string, idleExpiration time.Duration) (event *user.HumanRefreshTokenRenewedEvent, refreshTokenID, newRefreshToken string, err error) {
+func (c *Commands) renewRefreshToken(ctx context.Context, userID, orgID, refreshToken string, idleExpiration time.Duration, confirmation *domain.TokenConfirmation) (event *user.HumanRefreshTokenRenewedEvent, refreshTokenID, newRefreshToken string, err error) {
 	if refreshToken == "" {
 		return nil, "", "", caos_errs.ThrowInvalidArgument(nil, "COMMAND-DHrr3", "Errors.IDMissing")
 	}
@@ -160,11 +208,17 @@ func (c *Commands) renewRefreshToken(ctx context.Context, userID, orgID, refresh
 	if refreshTokenWriteModel.UserState != domain.UserStateActive {
 		return nil, "", "", caos_errs.ThrowInvalidArgument(nil, "COMMAND-BHnhs", "Errors.User.RefreshToken.Invalid")
 	}
-	if refreshTokenWriteModel.RefreshToken != token ||
-		refreshTokenWriteModel.IdleExpiration.Before(time.Now()) ||
+	if refreshTokenWriteModel.RefreshToken != token {
+		c.revokeReusedRefreshToken(ctx, refreshTokenWriteModel)
+		return nil, "", "", caos_errs.ThrowInvalidArgument(nil, "COMMAND-Vr43e", "Errors.User.RefreshToken.Invalid")
+	}
+	if refreshTokenWriteModel.IdleExpiration.Before(time.Now()) ||
 		refreshTokenWriteModel.Expiration.Before(time.Now()) {
 		return nil, "", "", caos_errs.ThrowInvalidArgument(nil, "COMMAND-Vr43e", "Errors.User.RefreshToken.Invalid")
 	}
+	if !refreshTokenWriteModel.Confirmation.Matches(confirmation) {
+		return nil, "", "", caos_errs.ThrowInvalidArgument(nil, "COMMAND-Ck3ps", "Errors.User.RefreshToken.Invalid")
+	}
 
 	newToken, err := c.idGenerator.Next()
 	if err != nil {
@@ -1,6 +1,7 @@
 package command
 
 import (
+	"sort"
 	"time"
 
 	"github.com/zitadel/zitadel/internal/eventstore"
@@ -19,6 +20,9 @@ type HumanRefreshTokenWriteModel struct {
 	IdleExpiration time.Time
 	Expiration     time.Time
 	UserAgentID    string
+	ClientID       string
+	ReuseDetection bool
+	Confirmation   *domain.TokenConfirmation
 }
 
 func NewHumanRefreshTokenWriteModel(userID, resourceOwner, tokenID string) *HumanRefreshTokenWriteModel {
@@ -65,6 +69,9 @@ func (wm *HumanRefreshTokenWriteModel) Reduce() error {
 			wm.Expiration = e.CreationDate().Add(e.Expiration)
 			wm.UserState = domain.UserStateActive
 			wm.UserAgentID = e.UserAgentID
+			wm.ClientID = e.ClientID
+			wm.ReuseDetection = e.ReuseDetection
+			wm.Confirmation = e.Confirmation
 		case *user.HumanRefreshTokenRenewedEvent:
 			if wm.UserState == domain.UserStateActive {
 				wm.RefreshToken = e.RefreshToken
@@ -105,3 +112,87 @@ func (wm *HumanRefreshTokenWriteModel) Query() *eventstore.SearchQueryBuilder {
 	}
 	return query
 }
+
+// HumanRefreshTokensWriteModel collects the active refresh tokens of a user by the user agent they were issued to
+type HumanRefreshTokensWriteModel struct {
+	eventstore.WriteModel
+
+	UserState domain.UserState
+	// TokenUserAgents maps the id of every active refresh token to its user agent
+	TokenUserAgents map[string]string
+}
+
+func NewHumanRefreshTokensWriteModel(userID, resourceOwner string) *HumanRefreshTokensWriteModel {
+	return &HumanRefreshTokensWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   userID,
+			ResourceOwner: resourceOwner,
+		},
+		TokenUserAgents: make(map[string]string),
+	}
+}
+
+func (wm *HumanRefreshTokensWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *user.HumanAddedEvent, *user.HumanRegisteredEvent:
+			wm.UserState = domain.UserStateActive
+		case *user.HumanRefreshTokenAddedEvent:
+			wm.TokenUserAgents[e.TokenID] = e.UserAgentID
+		case *user.HumanRefreshTokenRemovedEvent:
+			delete(wm.TokenUserAgents, e.TokenID)
+		case *user.HumanSignedOutEvent:
+			for tokenID, agentID := range wm.TokenUserAgents {
+				if agentID == e.UserAgentID {
+					delete(wm.TokenUserAgents, tokenID)
+				}
+			}
+		case *user.UserLockedEvent,
+			*user.UserDeactivatedEvent:
+			wm.TokenUserAgents = make(map[string]string)
+		case *user.UserRemovedEvent:
+			wm.UserState = domain.UserStateDeleted
+			wm.TokenUserAgents = make(map[string]string)
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *HumanRefreshTokensWriteModel) Query() *eventstore.SearchQueryBuilder {
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			user.HumanAddedType,
+			user.HumanRegisteredType,
+			user.UserV1AddedType,
+			user.UserV1RegisteredType,
+			user.HumanRefreshTokenAddedType,
+			user.HumanRefreshTokenRemovedType,
+			user.HumanSignedOutType,
+			user.UserLockedType,
+			user.UserDeactivatedType,
+			user.UserRemovedType).
+		Builder()
+
+	if wm.ResourceOwner != "" {
+		query.ResourceOwner(wm.ResourceOwner)
+	}
+	return query
+}
+
+// TokenIDsOfUserAgents returns the ids of the active refresh tokens issued to one of the user agents
+func (wm *HumanRefreshTokensWriteModel) TokenIDsOfUserAgents(agentIDs ...string) []string {
+	tokenIDs := make([]string, 0)
+	for tokenID, agentID := range wm.TokenUserAgents {
+		for _, id := range agentIDs {
+			if agentID == id {
+				tokenIDs = append(tokenIDs, tokenID)
+				break
+			}
+		}
+	}
+	sort.Strings(tokenIDs)
+	return tokenIDs
+}
@@ -41,6 +41,7 @@ func TestCommands_AddAccessAndRefreshToken(t *testing.T) {
 		authTime              time.Time
 		refreshIdleExpiration time.Duration
 		refreshExpiration     time.Duration
+		reuseDetection        bool
 	}
 	type res struct {
 		token        *domain.Token
@@ -136,6 +137,8 @@ func TestCommands_AddAccessAndRefreshToken(t *testing.T) {
 							time.Now(),
 							1*time.Hour,
 							24*time.Hour,
+							false,
+							nil,
 						)),
 						eventFromEventPusher(user.NewHumanRefreshTokenRemovedEvent(
 							context.Background(),
@@ -174,6 +177,8 @@ func TestCommands_AddAccessAndRefreshToken(t *testing.T) {
 							time.Now(),
 							-1*time.Hour,
 							24*time.Hour,
+							false,
+							nil,
 						)),
 					),
 				),
@@ -238,7 +243,7 @@ func TestCommands_AddAccessAndRefreshToken(t *testing.T) {
 		//					time.Now(),
 		//					1*time.Hour,
 		//					24*time.Hour,
-		//				)),
+		//				, false, nil)),
 		//			),
 		//			expectPushFailed(
 		//				caos_errs.ThrowInternal(nil, "ERROR", "internal"),
@@ -253,7 +258,7 @@ func TestCommands_AddAccessAndRefreshToken(t *testing.T) {
 		//						[]string{"clientID1"},
 		//						[]string{oidc.ScopeOpenID, oidc.ScopeProfile, oidc.ScopeEmail, oidc.ScopeOfflineAccess},
 		//						time.Now().Add(5*time.Minute),
-		//					)),
+		//					, nil)),
 		//					eventFromEventPusher(user.NewHumanRefreshTokenRenewedEvent(
 		//						context.Background(),
 		//						&user.NewAggregate("userID", "orgID").Aggregate,
@@ -293,7 +298,7 @@ func TestCommands_AddAccessAndRefreshToken(t *testing.T) {
 				keyAlgorithm: tt.fields.keyAlgorithm,
 			}
 			got, gotRefresh, err := c.AddAccessAndRefreshToken(tt.args.ctx, tt.args.orgID, tt.args.agentID, tt.args.clientID, tt.args.userID, tt.args.refreshToken,
-				tt.args.audience, tt.args.scopes, tt.args.authMethodsReferences, tt.args.lifetime, tt.args.refreshIdleExpiration, tt.args.refreshExpiration, tt.args.authTime)
+				tt.args.audience, tt.args.scopes, tt.args.authMethodsReferences, tt.args.lifetime, tt.args.refreshIdleExpiration, tt.args.refreshExpiration, tt.args.authTime, tt.args.reuseDetection, nil, false)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -373,6 +378,8 @@ func TestCommands_RevokeRefreshToken(t *testing.T) {
 							time.Now(),
 							1*time.Hour,
 							10*time.Hour,
+							false,
+							nil,
 						)),
 					),
 					expectPushFailed(caos_errs.ThrowInternal(nil, "ERROR", "internal"),
@@ -414,6 +421,8 @@ func TestCommands_RevokeRefreshToken(t *testing.T) {
 							time.Now(),
 							1*time.Hour,
 							10*time.Hour,
+							false,
+							nil,
 						)),
 					),
 					expectPush(
@@ -511,6 +520,8 @@ func TestCommands_RevokeRefreshTokens(t *testing.T) {
 							time.Now(),
 							1*time.Hour,
 							10*time.Hour,
+							false,
+							nil,
 						)),
 					),
 					expectFilter(),
@@ -544,6 +555,8 @@ func TestCommands_RevokeRefreshTokens(t *testing.T) {
 							time.Now(),
 							1*time.Hour,
 							10*time.Hour,
+							false,
+							nil,
 						)),
 					),
 					expectFilter(
@@ -560,6 +573,8 @@ func TestCommands_RevokeRefreshTokens(t *testing.T) {
 							time.Now(),
 							1*time.Hour,
 							10*time.Hour,
+							false,
+							nil,
 						)),
 					),
 					expectPushFailed(caos_errs.ThrowInternal(nil, "ERROR", "internal"),
@@ -606,6 +621,8 @@ func TestCommands_RevokeRefreshTokens(t *testing.T) {
 							time.Now(),
 							1*time.Hour,
 							10*time.Hour,
+							false,
+							nil,
 						)),
 					),
 					expectFilter(
@@ -622,6 +639,8 @@ func TestCommands_RevokeRefreshTokens(t *testing.T) {
 							time.Now(),
 							1*time.Hour,
 							10*time.Hour,
+							false,
+							nil,
 						)),
 					),
 					expectPush(
@@ -700,6 +719,7 @@ func TestCommands_addRefreshToken(t *testing.T) {
 		authTime              time.Time
 		idleExpiration        time.Duration
 		expiration            time.Duration
+		reuseDetection        bool
 	}
 	type res struct {
 		event        *user.HumanRefreshTokenAddedEvent
@@ -754,6 +774,8 @@ func TestCommands_addRefreshToken(t *testing.T) {
 					authTime,
 					1*time.Hour,
 					10*time.Hour,
+					false,
+					nil,
 				),
 				refreshToken: base64.RawURLEncoding.EncodeToString([]byte("userID:refreshTokenID:refreshTokenID")),
 			},
@@ -765,7 +787,7 @@ func TestCommands_addRefreshToken(t *testing.T) {
 				eventstore:   tt.fields.eventstore,
 				keyAlgorithm: tt.fields.keyAlgorithm,
 			}
-			gotEvent, gotRefreshToken, err := c.addRefreshToken(tt.args.ctx, tt.args.accessToken, tt.args.authMethodsReferences, tt.args.authTime, tt.args.idleExpiration, tt.args.expiration)
+			gotEvent, gotRefreshToken, err := c.addRefreshToken(tt.args.ctx, tt.args.accessToken, tt.args.authMethodsReferences, tt.args.authTime, tt.args.idleExpiration, tt.args.expiration, tt.args.reuseDetection, nil)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -792,6 +814,7 @@ func TestCommands_renewRefreshToken(t *testing.T) {
 		orgID          string
 		refreshToken   string
 		idleExpiration time.Duration
+		confirmation   *domain.TokenConfirmation
 	}
 	type res struct {
 		event           *user.HumanRefreshTokenRenewedEvent
@@ -865,6 +888,8 @@ func TestCommands_renewRefreshToken(t *testing.T) {
 							time.Now(),
 							1*time.Hour,
 							24*time.Hour,
+							false,
+							nil,
 						)),
 						eventFromEventPusher(user.NewHumanRefreshTokenRemovedEvent(
 							context.Background(),
@@ -903,6 +928,8 @@ func TestCommands_renewRefreshToken(t *testing.T) {
 							time.Now(),
 							1*time.Hour,
 							24*time.Hour,
+							false,
+							nil,
 						)),
 					),
 				),
@@ -936,6 +963,8 @@ func TestCommands_renewRefreshToken(t *testing.T) {
 							time.Now(),
 							1*time.Hour,
 							24*time.Hour,
+							false,
+							nil,
 						)),
 						eventFromEventPusher(
 							user.NewUserDeactivatedEvent(
@@ -976,6 +1005,8 @@ func TestCommands_renewRefreshToken(t *testing.T) {
 							time.Now(),
 							1*time.Hour,
 							24*time.Hour,
+							false,
+							nil,
 						)),
 						eventFromEventPusher(
 							user.NewHumanSignedOutEvent(
@@ -999,6 +1030,145 @@ func TestCommands_renewRefreshToken(t *testing.T) {
 				err: caos_errs.IsErrorInvalidArgument,
 			},
 		},
+		{
+			name: "token reused without reuse detection, error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusherWithCreationDateNow(user.NewHumanRefreshTokenAddedEvent(
+							context.Background(),
+							&user.NewAggregate("userID", "orgID").Aggregate,
+							"tokenID",
+							"applicationID",
+							"userAgentID",
+							"de",
+							[]string{"clientID1"},
+							[]string{oidc.ScopeOpenID, oidc.ScopeProfile, oidc.ScopeEmail, oidc.ScopeOfflineAccess},
+							[]string{"password"},
+							time.Now(),
+							1*time.Hour,
+							24*time.Hour,
+							false,
+							nil,
+						)),
+						eventFromEventPusherWithCreationDateNow(user.NewHumanRefreshTokenRenewedEvent(
+							context.Background(),
+							&user.NewAggregate("userID", "orgID").Aggregate,
+							"tokenID",
+							"refreshToken1",
+							1*time.Hour,
+						)),
+					),
+				),
+				keyAlgorithm: refreshTokenEncryptionAlgorithm(gomock.NewController(t)),
+			},
+			args: args{
+				ctx:            context.Background(),
+				userID:         "userID",
+				orgID:          "orgID",
+				refreshToken:   base64.RawURLEncoding.EncodeToString([]byte("userID:tokenID:tokenID")),
+				idleExpiration: 1 * time.Hour,
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "token reused with reuse detection, revoked and error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusherWithCreationDateNow(user.NewHumanRefreshTokenAddedEvent(
+							context.Background(),
+							&user.NewAggregate("userID", "orgID").Aggregate,
+							"tokenID",
+							"applicationID",
+							"userAgentID",
+							"de",
+							[]string{"clientID1"},
+							[]string{oidc.ScopeOpenID, oidc.ScopeProfile, oidc.ScopeEmail, oidc.ScopeOfflineAccess},
+							[]string{"password"},
+							time.Now(),
+							1*time.Hour,
+							24*time.Hour,
+							true,
+							nil,
+						)),
+						eventFromEventPusherWithCreationDateNow(user.NewHumanRefreshTokenRenewedEvent(
+							context.Background(),
+							&user.NewAggregate("userID", "orgID").Aggregate,
+							"tokenID",
+							"refreshToken1",
+							1*time.Hour,
+						)),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(user.NewHumanRefreshTokenReusedEvent(
+								context.Background(),
+								&user.NewAggregate("userID", "orgID").Aggregate,
+								"tokenID",
+								"applicationID",
+								"userAgentID",
+							)),
+							eventFromEventPusher(user.NewHumanRefreshTokenRemovedEvent(
+								context.Background(),
+								&user.NewAggregate("userID", "orgID").Aggregate,
+								"tokenID",
+							)),
+						},
+					),
+				),
+				keyAlgorithm: refreshTokenEncryptionAlgorithm(gomock.NewController(t)),
+			},
+			args: args{
+				ctx:            context.Background(),
+				userID:         "userID",
+				orgID:          "orgID",
+				refreshToken:   base64.RawURLEncoding.EncodeToString([]byte("userID:tokenID:tokenID")),
+				idleExpiration: 1 * time.Hour,
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "token bound to other key, error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusherWithCreationDateNow(user.NewHumanRefreshTokenAddedEvent(
+							context.Background(),
+							&user.NewAggregate("userID", "orgID").Aggregate,
+							"tokenID",
+							"applicationID",
+							"userAgentID",
+							"de",
+							[]string{"clientID1"},
+							[]string{oidc.ScopeOpenID, oidc.ScopeProfile, oidc.ScopeEmail, oidc.ScopeOfflineAccess},
+							[]string{"password"},
+							time.Now(),
+							1*time.Hour,
+							24*time.Hour,
+							false,
+							&domain.TokenConfirmation{JWKThumbprint: "jkt"},
+						)),
+					),
+				),
+				keyAlgorithm: refreshTokenEncryptionAlgorithm(gomock.NewController(t)),
+			},
+			args: args{
+				ctx:            context.Background(),
+				userID:         "userID",
+				orgID:          "orgID",
+				refreshToken:   base64.RawURLEncoding.EncodeToString([]byte("userID:tokenID:tokenID")),
+				idleExpiration: 1 * time.Hour,
+				confirmation:   &domain.TokenConfirmation{JWKThumbprint: "other"},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
 		{
 			name: "token renewed, ok",
 			fields: fields{
@@ -1017,6 +1187,8 @@ func TestCommands_renewRefreshToken(t *testing.T) {
 							time.Now(),
 							1*time.Hour,
 							24*time.Hour,
+							false,
+							nil,
 						)),
 					),
 				),
@@ -1050,7 +1222,7 @@ func TestCommands_renewRefreshToken(t *testing.T) {
 				idGenerator:  tt.fields.idGenerator,
 				keyAlgorithm: tt.fields.keyAlgorithm,
 			}
-			gotEvent, gotRefreshTokenID, gotNewRefreshToken, err := c.renewRefreshToken(tt.args.ctx, tt.args.userID, tt.args.orgID, tt.args.refreshToken, tt.args.idleExpiration)
+			gotEvent, gotRefreshTokenID, gotNewRefreshToken, err := c.renewRefreshToken(tt.args.ctx, tt.args.userID, tt.args.orgID, tt.args.refreshToken, tt.args.idleExpiration, tt.args.confirmation)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -1065,3 +1237,191 @@ func TestCommands_renewRefreshToken(t *testing.T) {
 		})
 	}
 }
+
+func TestCommands_RevokeReusedRefreshToken(t *testing.T) {
+	type fields struct {
+		eventstore   *eventstore.Eventstore
+		keyAlgorithm crypto.EncryptionAlgorithm
+	}
+	type args struct {
+		ctx          context.Context
+		refreshToken string
+	}
+	type res struct {
+		revoked bool
+		err     func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "invalid token, error",
+			fields: fields{
+				eventstore:   eventstoreExpect(t),
+				keyAlgorithm: refreshTokenEncryptionAlgorithm(gomock.NewController(t)),
+			},
+			args: args{
+				ctx:          context.Background(),
+				refreshToken: "invalid",
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "current token, not revoked",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusherWithCreationDateNow(user.NewHumanRefreshTokenAddedEvent(
+							context.Background(),
+							&user.NewAggregate("userID", "orgID").Aggregate,
+							"tokenID",
+							"applicationID",
+							"userAgentID",
+							"de",
+							[]string{"clientID1"},
+							[]string{oidc.ScopeOpenID, oidc.ScopeOfflineAccess},
+							[]string{"password"},
+							time.Now(),
+							1*time.Hour,
+							24*time.Hour,
+							true,
+							nil,
+						)),
+					),
+				),
+				keyAlgorithm: refreshTokenEncryptionAlgorithm(gomock.NewController(t)),
+			},
+			args: args{
+				ctx:          context.Background(),
+				refreshToken: base64.RawURLEncoding.EncodeToString([]byte("userID:tokenID:tokenID")),
+			},
+			res: res{
+				revoked: false,
+			},
+		},
+		{
+			name: "already revoked token, not revoked",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusherWithCreationDateNow(user.NewHumanRefreshTokenAddedEvent(
+							context.Background(),
+							&user.NewAggregate("userID", "orgID").Aggregate,
+							"tokenID",
+							"applicationID",
+							"userAgentID",
+							"de",
+							[]string{"clientID1"},
+							[]string{oidc.ScopeOpenID, oidc.ScopeOfflineAccess},
+							[]string{"password"},
+							time.Now(),
+							1*time.Hour,
+							24*time.Hour,
+							true,
+							nil,
+						)),
+						eventFromEventPusherWithCreationDateNow(user.NewHumanRefreshTokenRenewedEvent(
+							context.Background(),
+							&user.NewAggregate("userID", "orgID").Aggregate,
+							"tokenID",
+							"refreshToken1",
+							1*time.Hour,
+						)),
+						eventFromEventPusher(user.NewHumanRefreshTokenRemovedEvent(
+							context.Background(),
+							&user.NewAggregate("userID", "orgID").Aggregate,
+							"tokenID",
+						)),
+					),
+				),
+				keyAlgorithm: refreshTokenEncryptionAlgorithm(gomock.NewController(t)),
+			},
+			args: args{
+				ctx:          context.Background(),
+				refreshToken: base64.RawURLEncoding.EncodeToString([]byte("userID:tokenID:tokenID")),
+			},
+			res: res{
+				revoked: false,
+			},
+		},
+		{
+			name: "reused token, revoked",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusherWithCreationDateNow(user.NewHumanRefreshTokenAddedEvent(
+							context.Background(),
+							&user.NewAggregate("userID", "orgID").Aggregate,
+							"tokenID",
+							"applicationID",
+							"userAgentID",
+							"de",
+							[]string{"clientID1"},
+							[]string{oidc.ScopeOpenID, oidc.ScopeOfflineAccess},
+							[]string{"password"},
+							time.Now(),
+							1*time.Hour,
+							24*time.Hour,
+							true,
+							nil,
+						)),
+						eventFromEventPusherWithCreationDateNow(user.NewHumanRefreshTokenRenewedEvent(
+							context.Background(),
+							&user.NewAggregate("userID", "orgID").Aggregate,
+							"tokenID",
+							"refreshToken1",
+							1*time.Hour,
+						)),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(user.NewHumanRefreshTokenReusedEvent(
+								context.Background(),
+								&user.NewAggregate("userID", "orgID").Aggregate,
+								"tokenID",
+								"applicationID",
+								"userAgentID",
+							)),
+							eventFromEventPusher(user.NewHumanRefreshTokenRemovedEvent(
+								context.Background(),
+								&user.NewAggregate("userID", "orgID").Aggregate,
+								"tokenID",
+							)),
+						},
+					),
+				),
+				keyAlgorithm: refreshTokenEncryptionAlgorithm(gomock.NewController(t)),
+			},
+			args: args{
+				ctx:          context.Background(),
+				refreshToken: base64.RawURLEncoding.EncodeToString([]byte("userID:tokenID:tokenID")),
+			},
+			res: res{
+				revoked: true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:   tt.fields.eventstore,
+				keyAlgorithm: tt.fields.keyAlgorithm,
+			}
+			got, err := c.RevokeReusedRefreshToken(tt.args.ctx, tt.args.refreshToken)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.revoked, got)
+			}
+		})
+	}
+}
@@ -3734,6 +3734,410 @@ func TestCommandSide_HumanSignOut(t *testing.T) {
 	}
 }
 
+func TestCommandSide_TerminateHumanSessions(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx      context.Context
+		userID   string
+		orgID    string
+		agentIDs []string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	humanAddedEvent := func() *user.HumanAddedEvent {
+		return user.NewHumanAddedEvent(context.Background(),
+			&user.NewAggregate("user1", "org1").Aggregate,
+			"username",
+			"firstname",
+			"lastname",
+			"nickname",
+			"displayname",
+			language.German,
+			domain.GenderUnspecified,
+			"email@test.ch",
+			true,
+		)
+	}
+	refreshTokenAddedEvent := func(tokenID, agentID string) *user.HumanRefreshTokenAddedEvent {
+		return user.NewHumanRefreshTokenAddedEvent(context.Background(),
+			&user.NewAggregate("user1", "org1").Aggregate,
+			tokenID,
+			"clientID",
+			agentID,
+			"de",
+			[]string{"clientID"},
+			[]string{"openid", "offline_access"},
+			[]string{"password"},
+			time.Now(),
+			1*time.Hour,
+			24*time.Hour,
+			false,
+			nil,
+		)
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "userid missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:      context.Background(),
+				orgID:    "org1",
+				agentIDs: []string{"agent1"},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "agent ids missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:    context.Background(),
+				userID: "user1",
+				orgID:  "org1",
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "user not existing, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:      context.Background(),
+				userID:   "user1",
+				orgID:    "org1",
+				agentIDs: []string{"agent1"},
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "terminate sessions and revoke their refresh tokens, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(humanAddedEvent()),
+						eventFromEventPusher(refreshTokenAddedEvent("token1", "agent1")),
+						eventFromEventPusher(refreshTokenAddedEvent("token2", "agent2")),
+						eventFromEventPusher(refreshTokenAddedEvent("token3", "agent1")),
+						eventFromEventPusher(
+							user.NewHumanRefreshTokenRemovedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"token3",
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								user.NewHumanRefreshTokenRemovedEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									"token1",
+								),
+							),
+							eventFromEventPusher(
+								user.NewHumanSignedOutEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									"agent1",
+								),
+							),
+							eventFromEventPusher(
+								user.NewHumanSignedOutEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									"agent3",
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:      context.Background(),
+				userID:   "user1",
+				orgID:    "org1",
+				agentIDs: []string{"agent1", "agent3"},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.TerminateHumanSessions(tt.args.ctx, tt.args.userID, tt.args.orgID, tt.args.agentIDs)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_HumanBackChannelLogoutSent(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type (
+		args struct {
+			ctx      context.Context
+			orgID    string
+			userID   string
+			agentID  string
+			clientID string
+		}
+	)
+	type res struct {
+		err func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "clientid missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:     context.Background(),
+				orgID:   "org1",
+				userID:  "user1",
+				agentID: "agent1",
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "user not existing, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:      context.Background(),
+				orgID:    "org1",
+				userID:   "user1",
+				agentID:  "agent1",
+				clientID: "client1",
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "back-channel logout sent, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"username",
+								"firstname",
+								"lastname",
+								"nickname",
+								"displayname",
+								language.German,
+								domain.GenderUnspecified,
+								"email@test.ch",
+								true,
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								user.NewHumanBackChannelLogoutSentEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									"agent1",
+									"client1",
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:      context.Background(),
+				orgID:    "org1",
+				userID:   "user1",
+				agentID:  "agent1",
+				clientID: "client1",
+			},
+			res: res{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			err := r.HumanBackChannelLogoutSent(tt.args.ctx, tt.args.orgID, tt.args.userID, tt.args.agentID, tt.args.clientID)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+		})
+	}
+}
+
+func TestCommandSide_HumanSAMLResponseSent(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type (
+		args struct {
+			ctx           context.Context
+			orgID         string
+			userID        string
+			agentID       string
+			applicationID string
+		}
+	)
+	type res struct {
+		err func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "applicationid missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:     context.Background(),
+				orgID:   "org1",
+				userID:  "user1",
+				agentID: "agent1",
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "user not existing, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				orgID:         "org1",
+				userID:        "user1",
+				agentID:       "agent1",
+				applicationID: "app1",
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "saml response sent, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"username",
+								"firstname",
+								"lastname",
+								"nickname",
+								"displayname",
+								language.German,
+								domain.GenderUnspecified,
+								"email@test.ch",
+								true,
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								user.NewHumanSAMLResponseSentEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									"agent1",
+									"app1",
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				orgID:         "org1",
+				userID:        "user1",
+				agentID:       "agent1",
+				applicationID: "app1",
+			},
+			res: res{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			err := r.HumanSAMLResponseSent(tt.args.ctx, tt.args.orgID, tt.args.userID, tt.args.agentID, tt.args.applicationID)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+		})
+	}
+}
+
 func newAddHumanEvent(password string, changeRequired bool, phone string) *user.HumanAddedEvent {
 	event := user.NewHumanAddedEvent(context.Background(),
 		&user.NewAggregate("user1", "org1").Aggregate,
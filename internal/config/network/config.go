@@ -25,6 +25,9 @@ type TLS struct {
 	Key []byte
 	//Certificate for the TLS connection (CertPath will this overwrite, if specified)
 	Cert []byte
+	//If enabled, clients are asked for a certificate during the TLS handshake
+	//it will not be verified on the connection, but by the endpoints using it
+	RequestClientCertificate bool
 }
 
 func (t *TLS) Config() (_ *tls.Config, err error) {
@@ -50,7 +53,11 @@ func (t *TLS) Config() (_ *tls.Config, err error) {
 	if err != nil {
 		return nil, err
 	}
-	return &tls.Config{
+	config := &tls.Config{
 		Certificates: []tls.Certificate{tlsCert},
-	}, nil
+	}
+	if t.RequestClientCertificate {
+		config.ClientAuth = tls.RequestClientCert
+	}
+	return config, nil
 }
@@ -21,19 +21,42 @@ type SecretGenerators struct {
 }
 
 type MultifactorConfig struct {
-	OTP OTPConfig
+	OTP           OTPConfig
+	OTPSMS        OTPCodeConfig
+	OTPEmail      OTPCodeConfig
+	RecoveryCodes RecoveryCodesConfig
 }
 
 type OTPConfig struct {
 	Issuer string
 }
 
+type OTPCodeConfig struct {
+	CodeGenerator  crypto.GeneratorConfig
+	ResendInterval time.Duration
+	MaxAttempts    uint64
+}
+
+type RecoveryCodesConfig struct {
+	Count         uint
+	CodeGenerator crypto.GeneratorConfig
+}
+
 type DomainVerification struct {
 	VerificationGenerator crypto.GeneratorConfig
 }
 
 type Notifications struct {
 	FileSystemPath string
+	Outbox         NotificationOutbox
+}
+
+type NotificationOutbox struct {
+	// MaxAttempts until the message is marked as failed
+	MaxAttempts uint64
+	// InitialBackoff is the delay after the first failed attempt, it's doubled for every further attempt
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
 }
 
 type KeyConfig struct {
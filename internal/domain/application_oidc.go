@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"net/url"
 	"strings"
 	"time"
 
@@ -25,27 +26,33 @@ const (
 type OIDCApp struct {
 	models.ObjectRoot
 
-	AppID                    string
-	AppName                  string
-	ClientID                 string
-	ClientSecret             *crypto.CryptoValue
-	ClientSecretString       string
-	RedirectUris             []string
-	ResponseTypes            []OIDCResponseType
-	GrantTypes               []OIDCGrantType
-	ApplicationType          OIDCApplicationType
-	AuthMethodType           OIDCAuthMethodType
-	PostLogoutRedirectUris   []string
-	OIDCVersion              OIDCVersion
-	Compliance               *Compliance
-	DevMode                  bool
-	AccessTokenType          OIDCTokenType
-	AccessTokenRoleAssertion bool
-	IDTokenRoleAssertion     bool
-	IDTokenUserinfoAssertion bool
-	ClockSkew                time.Duration
-	AdditionalOrigins        []string
-	SkipNativeAppSuccessPage bool
+	AppID                      string
+	AppName                    string
+	ClientID                   string
+	ClientSecret               *crypto.CryptoValue
+	ClientSecretString         string
+	RedirectUris               []string
+	ResponseTypes              []OIDCResponseType
+	GrantTypes                 []OIDCGrantType
+	ApplicationType            OIDCApplicationType
+	AuthMethodType             OIDCAuthMethodType
+	PostLogoutRedirectUris     []string
+	OIDCVersion                OIDCVersion
+	Compliance                 *Compliance
+	DevMode                    bool
+	AccessTokenType            OIDCTokenType
+	AccessTokenRoleAssertion   bool
+	IDTokenRoleAssertion       bool
+	IDTokenUserinfoAssertion   bool
+	ClockSkew                  time.Duration
+	AdditionalOrigins          []string
+	SkipNativeAppSuccessPage   bool
+	RefreshTokenReuseDetection bool
+	BackChannelLogoutURI       string
+	FrontChannelLogoutURI      string
+	SenderConstrainedTokens    bool
+	TLSClientAuthSubjectDN     string
+	RequireConsent             bool
 
 	State AppState
 }
@@ -108,8 +115,16 @@ const (
 	OIDCAuthMethodTypePost
 	OIDCAuthMethodTypeNone
 	OIDCAuthMethodTypePrivateKeyJWT
+	OIDCAuthMethodTypeTLSClientAuth
+	OIDCAuthMethodTypeSelfSignedTLSClientAuth
 )
 
+// KeysAllowed returns true if the client authenticates with keys registered on the application,
+// either to sign a JWT or to issue a self-signed client certificate
+func (t OIDCAuthMethodType) KeysAllowed() bool {
+	return t == OIDCAuthMethodTypePrivateKeyJWT || t == OIDCAuthMethodTypeSelfSignedTLSClientAuth
+}
+
 type Compliance struct {
 	NoneCompliant bool
 	Problems      []string
@@ -123,7 +138,7 @@ const (
 )
 
 func (a *OIDCApp) IsValid() bool {
-	if a.ClockSkew > time.Second*5 || a.ClockSkew < time.Second*0 || !a.OriginsValid() {
+	if a.ClockSkew > time.Second*5 || a.ClockSkew < time.Second*0 || !a.OriginsValid() || !a.LogoutURIsValid() || !TLSClientAuthValid(a.AuthMethodType, a.TLSClientAuthSubjectDN) {
 		return false
 	}
 	grantTypes := a.getRequiredGrantTypes()
@@ -148,6 +163,28 @@ func (a *OIDCApp) OriginsValid() bool {
 	return true
 }
 
+// LogoutURIsValid checks that the back- and front-channel logout uris are absolute http(s) uris without fragment
+func (a *OIDCApp) LogoutURIsValid() bool {
+	return LogoutURIValid(a.BackChannelLogoutURI) && LogoutURIValid(a.FrontChannelLogoutURI)
+}
+
+func LogoutURIValid(logoutURI string) bool {
+	if logoutURI == "" {
+		return true
+	}
+	u, err := url.Parse(logoutURI)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != "" && u.Fragment == ""
+}
+
+// TLSClientAuthValid checks that the expected subject distinguished name of the client certificate
+// is set for the tls_client_auth method
+func TLSClientAuthValid(authMethod OIDCAuthMethodType, subjectDN string) bool {
+	return authMethod != OIDCAuthMethodTypeTLSClientAuth || strings.TrimSpace(subjectDN) != ""
+}
+
 func ContainsRequiredGrantTypes(responseTypes []OIDCResponseType, grantTypes []OIDCGrantType) bool {
 	required := RequiredOIDCGrantTypes(responseTypes)
 	return ContainsOIDCGrantTypes(required, grantTypes)
@@ -7,11 +7,12 @@ import (
 type SAMLApp struct {
 	models.ObjectRoot
 
-	AppID       string
-	AppName     string
-	EntityID    string
-	Metadata    []byte
-	MetadataURL string
+	AppID             string
+	AppName           string
+	EntityID          string
+	Metadata          []byte
+	MetadataURL       string
+	EncryptAssertions bool
 
 	State AppState
 }
@@ -97,6 +97,9 @@ type LevelOfAssurance int
 
 const (
 	LevelOfAssuranceNone LevelOfAssurance = iota
+	LevelOfAssurancePassword
+	LevelOfAssuranceMFA
+	LevelOfAssurancePhishingResistant
 )
 
 type MFAType int
@@ -105,8 +108,16 @@ const (
 	MFATypeOTP MFAType = iota
 	MFATypeU2F
 	MFATypeU2FUserVerification
+	MFATypeOTPSMS
+	MFATypeOTPEmail
+	MFATypeRecoveryCode
 )
 
+// IsPhishingResistant returns true for factors bound to the origin of the login (WebAuthN)
+func (m MFAType) IsPhishingResistant() bool {
+	return m == MFATypeU2F || m == MFATypeU2FUserVerification
+}
+
 type MFALevel int
 
 const (
@@ -150,8 +161,43 @@ func (a *AuthRequest) SetOrgInformation(id, name, primaryDomain string, requeste
 }
 
 func (a *AuthRequest) MFALevel() MFALevel {
-	return -1
-	//PLANNED: check a.PossibleLOAs (and Prompt Login?)
+	switch a.RequiredLevelOfAssurance() {
+	case LevelOfAssuranceMFA, LevelOfAssurancePhishingResistant:
+		return MFALevelSecondFactor
+	default:
+		return -1
+	}
+}
+
+// RequiredLevelOfAssurance returns the lowest of the requested levels,
+// because each of them satisfies the request
+func (a *AuthRequest) RequiredLevelOfAssurance() LevelOfAssurance {
+	required := LevelOfAssuranceNone
+	for _, loa := range a.PossibleLOAs {
+		if loa == LevelOfAssuranceNone {
+			continue
+		}
+		if required == LevelOfAssuranceNone || loa < required {
+			required = loa
+		}
+	}
+	return required
+}
+
+// LevelOfAssurance returns the level reached by the factors verified during the auth request
+func (a *AuthRequest) LevelOfAssurance() LevelOfAssurance {
+	for _, mfa := range a.MFAsVerified {
+		if mfa.IsPhishingResistant() {
+			return LevelOfAssurancePhishingResistant
+		}
+	}
+	if len(a.MFAsVerified) > 0 {
+		return LevelOfAssuranceMFA
+	}
+	if a.PasswordVerified {
+		return LevelOfAssurancePassword
+	}
+	return LevelOfAssuranceNone
 }
 
 func (a *AuthRequest) AppendAudIfNotExisting(aud string) {
@@ -195,3 +241,32 @@ func (a *AuthRequest) Done() bool {
 	}
 	return false
 }
+
+// AuthRequestStep is the progress of an in-flight auth request,
+// derived from the stored request as the possible next steps are only computed by the login
+type AuthRequestStep int32
+
+const (
+	AuthRequestStepUnspecified AuthRequestStep = iota
+	AuthRequestStepUserSelection
+	AuthRequestStepFirstFactor
+	AuthRequestStepSecondFactor
+	AuthRequestStepAuthenticated
+	AuthRequestStepCodeIssued
+)
+
+// CurrentStep returns the step the auth request is waiting for
+func (a *AuthRequest) CurrentStep() AuthRequestStep {
+	switch {
+	case a.Code != "":
+		return AuthRequestStepCodeIssued
+	case a.UserID == "":
+		return AuthRequestStepUserSelection
+	case a.LevelOfAssurance() == LevelOfAssuranceNone:
+		return AuthRequestStepFirstFactor
+	case a.LevelOfAssurance() < a.RequiredLevelOfAssurance():
+		return AuthRequestStepSecondFactor
+	default:
+		return AuthRequestStepAuthenticated
+	}
+}
@@ -0,0 +1,131 @@
+package domain
+
+import (
+	"testing"
+)
+
+func TestAuthRequest_RequiredLevelOfAssurance(t *testing.T) {
+	tests := []struct {
+		name string
+		loas []LevelOfAssurance
+		want LevelOfAssurance
+	}{
+		{
+			name: "nothing requested, none",
+			want: LevelOfAssuranceNone,
+		},
+		{
+			name: "single level",
+			loas: []LevelOfAssurance{LevelOfAssuranceMFA},
+			want: LevelOfAssuranceMFA,
+		},
+		{
+			name: "multiple levels, lowest",
+			loas: []LevelOfAssurance{LevelOfAssurancePhishingResistant, LevelOfAssuranceMFA},
+			want: LevelOfAssuranceMFA,
+		},
+		{
+			name: "none is ignored",
+			loas: []LevelOfAssurance{LevelOfAssuranceNone, LevelOfAssurancePhishingResistant},
+			want: LevelOfAssurancePhishingResistant,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &AuthRequest{PossibleLOAs: tt.loas}
+			if got := a.RequiredLevelOfAssurance(); got != tt.want {
+				t.Errorf("AuthRequest.RequiredLevelOfAssurance() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuthRequest_LevelOfAssurance(t *testing.T) {
+	tests := []struct {
+		name             string
+		passwordVerified bool
+		mfasVerified     []MFAType
+		want             LevelOfAssurance
+	}{
+		{
+			name: "nothing verified, none",
+			want: LevelOfAssuranceNone,
+		},
+		{
+			name:             "password verified",
+			passwordVerified: true,
+			want:             LevelOfAssurancePassword,
+		},
+		{
+			name:             "otp verified, mfa",
+			passwordVerified: true,
+			mfasVerified:     []MFAType{MFATypeOTP},
+			want:             LevelOfAssuranceMFA,
+		},
+		{
+			name:             "u2f verified, phishing resistant",
+			passwordVerified: true,
+			mfasVerified:     []MFAType{MFATypeOTP, MFATypeU2F},
+			want:             LevelOfAssurancePhishingResistant,
+		},
+		{
+			name:         "passwordless verified, phishing resistant",
+			mfasVerified: []MFAType{MFATypeU2FUserVerification},
+			want:         LevelOfAssurancePhishingResistant,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &AuthRequest{PasswordVerified: tt.passwordVerified, MFAsVerified: tt.mfasVerified}
+			if got := a.LevelOfAssurance(); got != tt.want {
+				t.Errorf("AuthRequest.LevelOfAssurance() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuthRequest_CurrentStep(t *testing.T) {
+	tests := []struct {
+		name    string
+		request *AuthRequest
+		want    AuthRequestStep
+	}{
+		{
+			name:    "no user, user selection",
+			request: &AuthRequest{},
+			want:    AuthRequestStepUserSelection,
+		},
+		{
+			name:    "user selected, first factor",
+			request: &AuthRequest{UserID: "userID"},
+			want:    AuthRequestStepFirstFactor,
+		},
+		{
+			name:    "password verified, mfa requested, second factor",
+			request: &AuthRequest{UserID: "userID", PasswordVerified: true, PossibleLOAs: []LevelOfAssurance{LevelOfAssuranceMFA}},
+			want:    AuthRequestStepSecondFactor,
+		},
+		{
+			name:    "password verified, authenticated",
+			request: &AuthRequest{UserID: "userID", PasswordVerified: true},
+			want:    AuthRequestStepAuthenticated,
+		},
+		{
+			name:    "passwordless verified, mfa requested, authenticated",
+			request: &AuthRequest{UserID: "userID", MFAsVerified: []MFAType{MFATypeU2FUserVerification}, PossibleLOAs: []LevelOfAssurance{LevelOfAssuranceMFA}},
+			want:    AuthRequestStepAuthenticated,
+		},
+		{
+			name:    "code issued",
+			request: &AuthRequest{UserID: "userID", PasswordVerified: true, Code: "code"},
+			want:    AuthRequestStepCodeIssued,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.request.CurrentStep(); got != tt.want {
+				t.Errorf("AuthRequest.CurrentStep() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"path"
+)
+
+type InitialAccessTokenState int32
+
+const (
+	InitialAccessTokenStateUnspecified InitialAccessTokenState = iota
+	InitialAccessTokenStateActive
+	InitialAccessTokenStateRemoved
+)
+
+// ClientRegistrationPolicy restricts the metadata of clients registered
+// over the OIDC dynamic client registration endpoint.
+// Empty lists do not restrict the metadata.
+type ClientRegistrationPolicy struct {
+	AllowedGrantTypes   []OIDCGrantType
+	RedirectURIPatterns []string
+}
+
+// ValidRedirectURIPatterns checks if all patterns can be used with path.Match
+func (p *ClientRegistrationPolicy) ValidRedirectURIPatterns() bool {
+	for _, pattern := range p.RedirectURIPatterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *ClientRegistrationPolicy) GrantTypesAllowed(grantTypes []OIDCGrantType) bool {
+	if len(p.AllowedGrantTypes) == 0 {
+		return true
+	}
+	for _, grantType := range grantTypes {
+		if !containsOIDCGrantType(p.AllowedGrantTypes, grantType) {
+			return false
+		}
+	}
+	return true
+}
+
+// RedirectURIsAllowed checks if every uri matches at least one pattern of the policy,
+// a `*` matches any sequence of characters except `/`
+func (p *ClientRegistrationPolicy) RedirectURIsAllowed(uris []string) bool {
+	if len(p.RedirectURIPatterns) == 0 {
+		return true
+	}
+	for _, uri := range uris {
+		if !p.redirectURIAllowed(uri) {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *ClientRegistrationPolicy) redirectURIAllowed(uri string) bool {
+	for _, pattern := range p.RedirectURIPatterns {
+		if matched, _ := path.Match(pattern, uri); matched {
+			return true
+		}
+	}
+	return false
+}
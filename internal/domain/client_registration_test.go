@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"testing"
+)
+
+func TestClientRegistrationPolicy_GrantTypesAllowed(t *testing.T) {
+	tests := []struct {
+		name       string
+		policy     *ClientRegistrationPolicy
+		grantTypes []OIDCGrantType
+		want       bool
+	}{
+		{
+			name:       "empty policy, allowed",
+			policy:     &ClientRegistrationPolicy{},
+			grantTypes: []OIDCGrantType{OIDCGrantTypeImplicit},
+			want:       true,
+		},
+		{
+			name: "all grant types allowed",
+			policy: &ClientRegistrationPolicy{
+				AllowedGrantTypes: []OIDCGrantType{OIDCGrantTypeAuthorizationCode, OIDCGrantTypeRefreshToken},
+			},
+			grantTypes: []OIDCGrantType{OIDCGrantTypeAuthorizationCode, OIDCGrantTypeRefreshToken},
+			want:       true,
+		},
+		{
+			name: "one grant type not allowed",
+			policy: &ClientRegistrationPolicy{
+				AllowedGrantTypes: []OIDCGrantType{OIDCGrantTypeAuthorizationCode},
+			},
+			grantTypes: []OIDCGrantType{OIDCGrantTypeAuthorizationCode, OIDCGrantTypeImplicit},
+			want:       false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.GrantTypesAllowed(tt.grantTypes); got != tt.want {
+				t.Errorf("ClientRegistrationPolicy.GrantTypesAllowed() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClientRegistrationPolicy_RedirectURIsAllowed(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy *ClientRegistrationPolicy
+		uris   []string
+		want   bool
+	}{
+		{
+			name:   "empty policy, allowed",
+			policy: &ClientRegistrationPolicy{},
+			uris:   []string{"https://example.com/callback"},
+			want:   true,
+		},
+		{
+			name: "exact match, allowed",
+			policy: &ClientRegistrationPolicy{
+				RedirectURIPatterns: []string{"https://example.com/callback"},
+			},
+			uris: []string{"https://example.com/callback"},
+			want: true,
+		},
+		{
+			name: "wildcard match, allowed",
+			policy: &ClientRegistrationPolicy{
+				RedirectURIPatterns: []string{"https://*.example.com/*"},
+			},
+			uris: []string{"https://app.example.com/callback"},
+			want: true,
+		},
+		{
+			name: "wildcard does not match path separator, not allowed",
+			policy: &ClientRegistrationPolicy{
+				RedirectURIPatterns: []string{"https://example.com/*"},
+			},
+			uris: []string{"https://example.com/auth/callback"},
+			want: false,
+		},
+		{
+			name: "one uri not matching, not allowed",
+			policy: &ClientRegistrationPolicy{
+				RedirectURIPatterns: []string{"https://example.com/*"},
+			},
+			uris: []string{"https://example.com/callback", "https://evil.com/callback"},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.RedirectURIsAllowed(tt.uris); got != tt.want {
+				t.Errorf("ClientRegistrationPolicy.RedirectURIsAllowed() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
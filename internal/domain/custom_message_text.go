@@ -14,6 +14,15 @@ const (
 	DomainClaimedMessageType            = "DomainClaimed"
 	PasswordlessRegistrationMessageType = "PasswordlessRegistration"
 	PasswordChangeMessageType           = "PasswordChange"
+	VerifySMSOTPMessageType             = "VerifySMSOTP"
+	VerifyEmailOTPMessageType           = "VerifyEmailOTP"
+	RecoveryCodeUsedMessageType         = "RecoveryCodeUsed"
+	NewDeviceLoginMessageType           = "NewDeviceLogin"
+	MFAAddedMessageType                 = "MFAAdded"
+	MFARemovedMessageType               = "MFARemoved"
+	EmailChangedMessageType             = "EmailChanged"
+	MachineCredentialAddedMessageType   = "MachineCredentialAdded"
+	UserLockedMessageType               = "UserLocked"
 	MessageTitle                        = "Title"
 	MessagePreHeader                    = "PreHeader"
 	MessageSubject                      = "Subject"
@@ -31,6 +40,15 @@ type MessageTexts struct {
 	DomainClaimed            CustomMessageText
 	PasswordlessRegistration CustomMessageText
 	PasswordChange           CustomMessageText
+	VerifySMSOTP             CustomMessageText
+	VerifyEmailOTP           CustomMessageText
+	RecoveryCodeUsed         CustomMessageText
+	NewDeviceLogin           CustomMessageText
+	MFAAdded                 CustomMessageText
+	MFARemoved               CustomMessageText
+	EmailChanged             CustomMessageText
+	MachineCredentialAdded   CustomMessageText
+	UserLocked               CustomMessageText
 }
 
 type CustomMessageText struct {
@@ -69,6 +87,24 @@ func (m *MessageTexts) GetMessageTextByType(msgType string) *CustomMessageText {
 		return &m.PasswordlessRegistration
 	case PasswordChangeMessageType:
 		return &m.PasswordChange
+	case VerifySMSOTPMessageType:
+		return &m.VerifySMSOTP
+	case VerifyEmailOTPMessageType:
+		return &m.VerifyEmailOTP
+	case RecoveryCodeUsedMessageType:
+		return &m.RecoveryCodeUsed
+	case NewDeviceLoginMessageType:
+		return &m.NewDeviceLogin
+	case MFAAddedMessageType:
+		return &m.MFAAdded
+	case MFARemovedMessageType:
+		return &m.MFARemoved
+	case EmailChangedMessageType:
+		return &m.EmailChanged
+	case MachineCredentialAddedMessageType:
+		return &m.MachineCredentialAdded
+	case UserLockedMessageType:
+		return &m.UserLocked
 	}
 	return nil
 }
@@ -80,5 +116,14 @@ func IsMessageTextType(textType string) bool {
 		textType == VerifyPhoneMessageType ||
 		textType == DomainClaimedMessageType ||
 		textType == PasswordlessRegistrationMessageType ||
-		textType == PasswordChangeMessageType
+		textType == PasswordChangeMessageType ||
+		textType == VerifySMSOTPMessageType ||
+		textType == VerifyEmailOTPMessageType ||
+		textType == RecoveryCodeUsedMessageType ||
+		textType == NewDeviceLoginMessageType ||
+		textType == MFAAddedMessageType ||
+		textType == MFARemovedMessageType ||
+		textType == EmailChangedMessageType ||
+		textType == MachineCredentialAddedMessageType ||
+		textType == UserLockedMessageType
 }
@@ -0,0 +1,21 @@
+package domain
+
+type EmailProviderState int32
+
+const (
+	EmailProviderStateUnspecified EmailProviderState = iota
+	EmailProviderStateActive
+	EmailProviderStateInactive
+	EmailProviderStateRemoved
+)
+
+func (s EmailProviderState) Exists() bool {
+	return s != EmailProviderStateUnspecified && s != EmailProviderStateRemoved
+}
+
+type EmailProviderType int32
+
+const (
+	EmailProviderTypeUnspecified EmailProviderType = iota
+	EmailProviderTypeHTTP
+)
@@ -0,0 +1,83 @@
+package domain
+
+import "time"
+
+type LDAPSyncState int32
+
+const (
+	LDAPSyncStateUnspecified LDAPSyncState = iota
+	LDAPSyncStateActive
+	LDAPSyncStateRemoved
+)
+
+func (s LDAPSyncState) Exists() bool {
+	return s != LDAPSyncStateUnspecified && s != LDAPSyncStateRemoved
+}
+
+// LDAPSyncGroupRule grants the roles of the project to all users,
+// which are member of the LDAP group
+type LDAPSyncGroupRule struct {
+	Group     string   `json:"group"`
+	ProjectID string   `json:"projectId"`
+	RoleKeys  []string `json:"roleKeys,omitempty"`
+}
+
+type LDAPSyncRunState int32
+
+const (
+	LDAPSyncRunStateUnspecified LDAPSyncRunState = iota
+	LDAPSyncRunStateSucceeded
+	LDAPSyncRunStateFailed
+)
+
+type LDAPSyncAction int32
+
+const (
+	LDAPSyncActionUnspecified LDAPSyncAction = iota
+	LDAPSyncActionCreate
+	LDAPSyncActionUpdate
+	LDAPSyncActionDeactivate
+	LDAPSyncActionChangeGrants
+)
+
+// LDAPSyncReport sums up the changes of a sync run,
+// on a dry run the changes are only determined but not executed
+type LDAPSyncReport struct {
+	DryRun        bool
+	StartedAt     time.Time
+	Created       uint64
+	Updated       uint64
+	Deactivated   uint64
+	GrantsChanged uint64
+	Failed        uint64
+	Changes       []*LDAPSyncChange
+}
+
+// LDAPSyncChange is a single change of a user during a sync run
+type LDAPSyncChange struct {
+	Action         LDAPSyncAction
+	ExternalUserID string
+	UserID         string
+	Username       string
+	Error          string
+}
+
+// Add appends the change and increases the counter of the action,
+// failed changes are only counted as failed
+func (r *LDAPSyncReport) Add(change *LDAPSyncChange) {
+	r.Changes = append(r.Changes, change)
+	if change.Error != "" {
+		r.Failed++
+		return
+	}
+	switch change.Action {
+	case LDAPSyncActionCreate:
+		r.Created++
+	case LDAPSyncActionUpdate:
+		r.Updated++
+	case LDAPSyncActionDeactivate:
+		r.Deactivated++
+	case LDAPSyncActionChangeGrants:
+		r.GrantsChanged++
+	}
+}
@@ -1,6 +1,10 @@
 package domain
 
-import "github.com/zitadel/zitadel/internal/crypto"
+import (
+	"time"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+)
 
 type MFAState int32
 
@@ -18,10 +22,29 @@ func (f MFAState) Valid() bool {
 }
 
 type MultifactorConfigs struct {
-	OTP OTPConfig
+	OTP           OTPConfig
+	OTPSMS        OTPCodeConfig
+	OTPEmail      OTPCodeConfig
+	RecoveryCodes RecoveryCodesConfig
 }
 
 type OTPConfig struct {
 	Issuer    string
 	CryptoMFA crypto.EncryptionAlgorithm
 }
+
+// OTPCodeConfig configures the one-time codes sent to the user over sms or email
+type OTPCodeConfig struct {
+	CodeGenerator crypto.GeneratorConfig
+	// ResendInterval is the minimal duration between two codes sent to the same user
+	ResendInterval time.Duration
+	// MaxAttempts is the amount of failed checks after which a code is invalidated, 0 disables the limit
+	MaxAttempts uint64
+}
+
+// RecoveryCodesConfig configures the single-use codes a user can use instead of a second factor
+type RecoveryCodesConfig struct {
+	// Count is the amount of codes generated at once
+	Count         uint
+	CodeGenerator crypto.GeneratorConfig
+}
@@ -29,6 +29,7 @@ const (
 	NextStepProjectRequired
 	NextStepRedirectToExternalIDP
 	NextStepLoginSucceeded
+	NextStepConsent
 )
 
 type LoginStep struct{}
@@ -187,3 +188,12 @@ type LoginSucceededStep struct{}
 func (s *LoginSucceededStep) Type() NextStepType {
 	return NextStepLoginSucceeded
 }
+
+type ConsentStep struct {
+	Scopes []string
+	Roles  []string
+}
+
+func (s *ConsentStep) Type() NextStepType {
+	return NextStepConsent
+}
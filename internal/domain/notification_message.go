@@ -0,0 +1,21 @@
+package domain
+
+type NotificationMessageState int32
+
+const (
+	NotificationMessageStateUnspecified NotificationMessageState = iota
+	NotificationMessageStateQueued
+	NotificationMessageStateRetrying
+	NotificationMessageStateSent
+	NotificationMessageStateFailed
+	NotificationMessageStateDiscarded
+)
+
+func (s NotificationMessageState) Exists() bool {
+	return s != NotificationMessageStateUnspecified
+}
+
+// IsPending returns true if the message still has to be delivered
+func (s NotificationMessageState) IsPending() bool {
+	return s == NotificationMessageStateQueued || s == NotificationMessageStateRetrying
+}
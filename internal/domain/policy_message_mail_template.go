@@ -0,0 +1,40 @@
+package domain
+
+import "github.com/zitadel/zitadel/internal/eventstore/v1/models"
+
+type MessageTemplateFormat int32
+
+const (
+	MessageTemplateFormatUnspecified MessageTemplateFormat = iota
+	MessageTemplateFormatHTML
+	MessageTemplateFormatMJML
+
+	messageTemplateFormatCount
+)
+
+func (f MessageTemplateFormat) Valid() bool {
+	return f > MessageTemplateFormatUnspecified && f < messageTemplateFormatCount
+}
+
+// MessageMailTemplate is the mail template of a single message type,
+// it replaces the MailTemplate for emails of the message type
+type MessageMailTemplate struct {
+	models.ObjectRoot
+
+	State       PolicyState
+	Default     bool
+	MessageType string
+	Format      MessageTemplateFormat
+	Source      []byte
+}
+
+func (m *MessageMailTemplate) IsValid() bool {
+	return IsMailMessageType(m.MessageType) && m.Format.Valid() && len(m.Source) > 0
+}
+
+// IsMailMessageType checks if the message type is sent by email
+func IsMailMessageType(messageType string) bool {
+	return IsMessageTextType(messageType) &&
+		messageType != VerifyPhoneMessageType &&
+		messageType != VerifySMSOTPMessageType
+}
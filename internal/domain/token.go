@@ -20,6 +20,32 @@ type Token struct {
 	Expiration        time.Time
 	Scopes            []string
 	PreferredLanguage string
+	Confirmation      *TokenConfirmation
+}
+
+// TokenConfirmation binds a token to a key held by the client (cnf claim, RFC 7800).
+// JWKThumbprint is the thumbprint of the DPoP proof key (RFC 9449),
+// X509Thumbprint the thumbprint of the mTLS client certificate (RFC 8705).
+type TokenConfirmation struct {
+	JWKThumbprint  string `json:"jkt,omitempty"`
+	X509Thumbprint string `json:"x5t#S256,omitempty"`
+}
+
+func (c *TokenConfirmation) IsEmpty() bool {
+	return c == nil || (c.JWKThumbprint == "" && c.X509Thumbprint == "")
+}
+
+// Matches checks if the presented confirmation satisfies the binding.
+// A token without binding is satisfied by any confirmation.
+func (c *TokenConfirmation) Matches(presented *TokenConfirmation) bool {
+	if c.IsEmpty() {
+		return true
+	}
+	if presented == nil {
+		return false
+	}
+	return (c.JWKThumbprint == "" || c.JWKThumbprint == presented.JWKThumbprint) &&
+		(c.X509Thumbprint == "" || c.X509Thumbprint == presented.X509Thumbprint)
 }
 
 func AddAudScopeToAudience(ctx context.Context, audience, scopes []string) []string {
@@ -0,0 +1,32 @@
+package domain
+
+type UserConsentState int32
+
+const (
+	UserConsentStateUnspecified UserConsentState = iota
+	UserConsentStateActive
+	UserConsentStateRemoved
+)
+
+func (s UserConsentState) Exists() bool {
+	return s == UserConsentStateActive
+}
+
+// ConsentCovers checks if all requested scopes or roles are part of the ones the user already consented to
+func ConsentCovers(consented, requested []string) bool {
+	for _, r := range requested {
+		if !consentContains(consented, r) {
+			return false
+		}
+	}
+	return true
+}
+
+func consentContains(consented []string, value string) bool {
+	for _, c := range consented {
+		if c == value {
+			return true
+		}
+	}
+	return false
+}
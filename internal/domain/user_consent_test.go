@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"testing"
+)
+
+func TestConsentCovers(t *testing.T) {
+	tests := []struct {
+		name      string
+		consented []string
+		requested []string
+		want      bool
+	}{
+		{
+			name:      "nothing requested, covered",
+			consented: nil,
+			requested: nil,
+			want:      true,
+		},
+		{
+			name:      "all requested consented, covered",
+			consented: []string{"openid", "profile", "email"},
+			requested: []string{"openid", "email"},
+			want:      true,
+		},
+		{
+			name:      "additional value requested, not covered",
+			consented: []string{"openid", "profile"},
+			requested: []string{"openid", "email"},
+			want:      false,
+		},
+		{
+			name:      "no consent, not covered",
+			consented: nil,
+			requested: []string{"openid"},
+			want:      false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ConsentCovers(tt.consented, tt.requested); got != tt.want {
+				t.Errorf("ConsentCovers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
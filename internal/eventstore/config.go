@@ -12,6 +12,7 @@ type Config struct {
 	PushTimeout              time.Duration
 	Client                   *database.DB
 	AllowOrderByCreationDate bool
+	Snapshots                SnapshotConfig
 
 	repo repository.Repository
 }
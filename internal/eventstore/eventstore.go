@@ -23,10 +23,13 @@ type Eventstore struct {
 	eventTypes        []string
 	aggregateTypes    []string
 	PushTimeout       time.Duration
+	snapshots         SnapshotConfig
 }
 
 type eventTypeInterceptors struct {
 	eventMapper func(*repository.Event) (Event, error)
+	// upcasters are mapped by the schema version they upcast from
+	upcasters map[SchemaVersion]*Upcaster
 }
 
 func NewEventstore(config *Config) *Eventstore {
@@ -35,6 +38,7 @@ func NewEventstore(config *Config) *Eventstore {
 		eventInterceptors: map[EventType]eventTypeInterceptors{},
 		interceptorMutex:  sync.Mutex{},
 		PushTimeout:       config.PushTimeout,
+		snapshots:         config.Snapshots,
 	}
 }
 
@@ -51,6 +55,7 @@ func (es *Eventstore) Push(ctx context.Context, cmds ...Command) ([]Event, error
 	if err != nil {
 		return nil, err
 	}
+	es.setSchemaVersions(events)
 
 	if es.PushTimeout > 0 {
 		var cancel func()
@@ -163,6 +168,9 @@ func (es *Eventstore) mapEvents(events []*repository.Event) (mappedEvents []Even
 
 	for i, event := range events {
 		interceptors, ok := es.eventInterceptors[EventType(event.Type)]
+		if event, err = interceptors.upcast(event); err != nil {
+			return nil, err
+		}
 		if !ok || interceptors.eventMapper == nil {
 			mappedEvents[i] = BaseEventFromRepo(event)
 			//TODO: return error if unable to map event
@@ -216,6 +224,36 @@ func (es *Eventstore) InstanceIDs(ctx context.Context, queryFactory *SearchQuery
 	return es.repo.InstanceIDs(ctx, query)
 }
 
+// UniqueConstraints returns the unique constraints stored for the given instance
+// global unique constraints are not part of the result
+func (es *Eventstore) UniqueConstraints(ctx context.Context, instanceID string) ([]*EventUniqueConstraint, error) {
+	constraints, err := es.repo.UniqueConstraints(ctx, instanceID)
+	if err != nil {
+		return nil, err
+	}
+	uniqueConstraints := make([]*EventUniqueConstraint, len(constraints))
+	for i, constraint := range constraints {
+		uniqueConstraints[i] = &EventUniqueConstraint{
+			UniqueType:  constraint.UniqueType,
+			UniqueField: constraint.UniqueField,
+			Action:      UniqueConstraintAdd,
+		}
+	}
+	return uniqueConstraints, nil
+}
+
+// DeleteInstance physically deletes all events and unique constraints of the instance
+// and returns the count of the deleted events
+func (es *Eventstore) DeleteInstance(ctx context.Context, instanceID string) (int64, error) {
+	return es.repo.DeleteInstance(ctx, instanceID)
+}
+
+// UpdateEventData replaces the stored data of the event,
+// it must only be used to erase personal data from events which are no longer needed
+func (es *Eventstore) UpdateEventData(ctx context.Context, event Event, data []byte) error {
+	return es.repo.UpdateEventData(ctx, event.Aggregate().InstanceID, event.Sequence(), data)
+}
+
 type QueryReducer interface {
 	reducer
 	//Query returns the SearchQueryFactory for the events needed in reducer
@@ -224,7 +262,11 @@ type QueryReducer interface {
 
 // FilterToQueryReducer filters the events based on the search query of the query function,
 // appends all events to the reducer and calls it's reduce function
+// if snapshots are enabled, reducers implementing SnapshotReducer start from their last snapshot
 func (es *Eventstore) FilterToQueryReducer(ctx context.Context, r QueryReducer) error {
+	if snapshotReducer, ok := r.(SnapshotReducer); ok && es.snapshots.Enabled {
+		return es.filterToSnapshotReducer(ctx, snapshotReducer)
+	}
 	events, err := es.Filter(ctx, r.Query())
 	if err != nil {
 		return err
@@ -691,6 +691,10 @@ type testRepo struct {
 	events    []*repository.Event
 	sequence  uint64
 	instances []string
+	snapshot  *repository.Snapshot
+	stored    *repository.Snapshot
+	updated   []*repository.Event
+	query     *repository.SearchQuery
 	err       error
 	t         *testing.T
 }
@@ -703,6 +707,31 @@ func (repo *testRepo) CreateInstance(ctx context.Context, instance string) error
 	return nil
 }
 
+func (repo *testRepo) UniqueConstraints(ctx context.Context, instanceID string) ([]*repository.UniqueConstraint, error) {
+	return nil, nil
+}
+
+func (repo *testRepo) DeleteInstance(ctx context.Context, instanceID string) (int64, error) {
+	return 0, nil
+}
+
+func (repo *testRepo) UpdateEventData(ctx context.Context, instanceID string, sequence uint64, data []byte) error {
+	return nil
+}
+
+func (repo *testRepo) UpdateEventSchemaVersion(ctx context.Context, instanceID string, sequence uint64, schemaVersion uint16, data []byte) error {
+	repo.updated = append(repo.updated, &repository.Event{InstanceID: instanceID, Sequence: sequence, SchemaVersion: schemaVersion, Data: data})
+	return nil
+}
+
+func (repo *testRepo) Snapshot(ctx context.Context, instanceID string, aggregateType repository.AggregateType, aggregateID, model, version string) (*repository.Snapshot, error) {
+	return nil, errors.ThrowNotFound(nil, "V2-Hs9ap", "snapshot not found")
+}
+
+func (repo *testRepo) StoreSnapshot(ctx context.Context, snapshot *repository.Snapshot) error {
+	return nil
+}
+
 func (repo *testRepo) Step20(context.Context, uint64) error { return nil }
 
 func (repo *testRepo) Push(ctx context.Context, events []*repository.Event, uniqueConstraints ...*repository.UniqueConstraint) error {
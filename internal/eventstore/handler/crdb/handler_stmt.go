@@ -37,6 +37,8 @@ type StatementHandler struct {
 
 	client                  *database.DB
 	sequenceTable           string
+	lockTable               string
+	failedEventsTable       string
 	currentSequenceStmt     string
 	updateSequencesBaseStmt string
 	maxFailureCount         uint
@@ -67,6 +69,8 @@ func NewStatementHandler(
 	h := StatementHandler{
 		client:                  config.Client,
 		sequenceTable:           config.SequenceTable,
+		lockTable:               config.LockTable,
+		failedEventsTable:       config.FailedEventsTable,
 		maxFailureCount:         config.MaxFailureCount,
 		currentSequenceStmt:     fmt.Sprintf(currentSequenceStmtFormat, config.SequenceTable),
 		updateSequencesBaseStmt: fmt.Sprintf(updateCurrentSequencesStmtFormat, config.SequenceTable),
@@ -142,6 +142,7 @@ type Index struct {
 	Name        string
 	Columns     []string
 	bucketCount uint16
+	trigram     bool
 }
 
 type indexOpts func(*Index)
@@ -152,6 +153,15 @@ func Hash(bucketsCount uint16) indexOpts {
 	}
 }
 
+// Trigram creates an inverted index over the trigrams of the columns
+// which is used by similarity and (I)LIKE comparisons.
+// The pg_trgm extension must be installed on postgres
+func Trigram() indexOpts {
+	return func(i *Index) {
+		i.trigram = true
+	}
+}
+
 func NewConstraint(name string, columns []string) *Constraint {
 	i := &Constraint{
 		Name:    name,
@@ -325,6 +335,9 @@ func createIndexCheck(index *Index) func(config execConfig) string {
 }
 
 func createIndexStatement(index *Index, tableName string) string {
+	if index.trigram {
+		return createTrigramIndexStatement(index, tableName)
+	}
 	stmt := fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s ON %s (%s)",
 		indexName(index.Name, tableName),
 		tableName,
@@ -337,6 +350,18 @@ func createIndexStatement(index *Index, tableName string) string {
 		stmt, index.bucketCount)
 }
 
+func createTrigramIndexStatement(index *Index, tableName string) string {
+	columns := make([]string, len(index.Columns))
+	for i, column := range index.Columns {
+		columns[i] = column + " gin_trgm_ops"
+	}
+	return fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s ON %s USING GIN (%s);",
+		indexName(index.Name, tableName),
+		tableName,
+		strings.Join(columns, ","),
+	)
+}
+
 func foreignKeyName(name, tableName, suffix string) string {
 	if name == "" {
 		key := "fk" + suffix + "_ref_" + tableNameWithoutSchema(tableName)
@@ -47,3 +47,47 @@ type testStringer int
 func (t testStringer) String() string {
 	return "0529958243"
 }
+
+func Test_createIndexStatement(t *testing.T) {
+	type args struct {
+		index     *Index
+		tableName string
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "index",
+			args: args{
+				index:     NewIndex("username", []string{"username"}),
+				tableName: "projections.users",
+			},
+			want: "CREATE INDEX IF NOT EXISTS users_username_idx ON projections.users (username);",
+		},
+		{
+			name: "hash",
+			args: args{
+				index:     NewIndex("username", []string{"username"}, Hash(8)),
+				tableName: "projections.users",
+			},
+			want: "SET experimental_enable_hash_sharded_indexes=on; CREATE INDEX IF NOT EXISTS users_username_idx ON projections.users (username) USING HASH WITH BUCKET_COUNT = 8;",
+		},
+		{
+			name: "trigram",
+			args: args{
+				index:     NewIndex("username_trgm", []string{"username"}, Trigram()),
+				tableName: "projections.users",
+			},
+			want: "CREATE INDEX IF NOT EXISTS users_username_trgm_idx ON projections.users USING GIN (username gin_trgm_ops);",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := createIndexStatement(tt.args.index, tt.args.tableName); got != tt.want {
+				t.Errorf("createIndexStatement() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package crdb
+
+import (
+	"context"
+	"database/sql"
+	"runtime/debug"
+	"time"
+
+	"github.com/zitadel/logging"
+)
+
+// periodicWorkerLockInstance locks the worker over all instances,
+// so the task is only run by a single worker at a time
+const periodicWorkerLockInstance = "system"
+
+// PeriodicWorker runs a task every requeueEvery while it holds the lock of the worker
+type PeriodicWorker struct {
+	Locker
+	name         string
+	requeueEvery time.Duration
+	run          func(ctx context.Context)
+}
+
+// NewPeriodicWorker creates a worker, which runs the task under the lock named by the worker name.
+// The context passed to the task is canceled as soon as the lock is lost.
+func NewPeriodicWorker(client *sql.DB, lockTable, name string, requeueEvery time.Duration, run func(ctx context.Context)) *PeriodicWorker {
+	return &PeriodicWorker{
+		Locker:       NewLocker(client, lockTable, name),
+		name:         name,
+		requeueEvery: requeueEvery,
+		run:          run,
+	}
+}
+
+func (w *PeriodicWorker) Start(ctx context.Context) {
+	go w.schedule(ctx)
+}
+
+func (w *PeriodicWorker) schedule(ctx context.Context) {
+	defer func() {
+		if err := recover(); err != nil {
+			logging.WithFields("worker", w.name, "cause", err, "stack", string(debug.Stack())).Error("schedule panicked")
+		}
+	}()
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+			w.runLocked(ctx)
+			timer.Reset(w.requeueEvery)
+		}
+	}
+}
+
+func (w *PeriodicWorker) runLocked(ctx context.Context) {
+	lockCtx, cancelLock := context.WithCancel(ctx)
+	errs := w.Lock(lockCtx, w.requeueEvery, periodicWorkerLockInstance)
+	if err, ok := <-errs; err != nil || !ok {
+		cancelLock()
+		logging.WithFields("worker", w.name).OnError(err).Debug("lock failed")
+		return
+	}
+	go w.cancelOnErr(lockCtx, errs, cancelLock)
+	defer func() {
+		// stop renewing the lock before releasing it
+		cancelLock()
+		err := w.Unlock(periodicWorkerLockInstance)
+		logging.WithFields("worker", w.name).OnError(err).Warn("unable to unlock")
+	}()
+	w.run(lockCtx)
+}
+
+func (w *PeriodicWorker) cancelOnErr(ctx context.Context, errs <-chan error, cancel func()) {
+	for {
+		select {
+		case err := <-errs:
+			if err != nil {
+				logging.WithFields("worker", w.name).WithError(err).Warn("run canceled")
+				cancel()
+				return
+			}
+		case <-ctx.Done():
+			cancel()
+			return
+		}
+	}
+}
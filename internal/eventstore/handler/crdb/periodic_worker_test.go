@@ -0,0 +1,91 @@
+package crdb
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type lockerMock struct {
+	lockErrs []error
+	unlocked []string
+}
+
+func (l *lockerMock) Lock(ctx context.Context, _ time.Duration, _ ...string) <-chan error {
+	errs := make(chan error)
+	go func() {
+		defer close(errs)
+		for _, err := range l.lockErrs {
+			select {
+			case errs <- err:
+			case <-ctx.Done():
+				return
+			}
+		}
+		<-ctx.Done()
+	}()
+	return errs
+}
+
+func (l *lockerMock) Unlock(instanceIDs ...string) error {
+	l.unlocked = append(l.unlocked, instanceIDs...)
+	return nil
+}
+
+func TestPeriodicWorker_runLocked(t *testing.T) {
+	tests := []struct {
+		name         string
+		lockErrs     []error
+		wantRun      bool
+		wantCanceled bool
+	}{
+		{
+			name:     "lock failed, not run",
+			lockErrs: []error{errors.New("locked")},
+		},
+		{
+			name:     "locked, run and unlocked",
+			lockErrs: []error{nil},
+			wantRun:  true,
+		},
+		{
+			name:         "lock lost, run canceled",
+			lockErrs:     []error{nil, errors.New("lost")},
+			wantRun:      true,
+			wantCanceled: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			locker := &lockerMock{lockErrs: tt.lockErrs}
+			var run, canceled bool
+			w := &PeriodicWorker{
+				Locker:       locker,
+				name:         "test",
+				requeueEvery: time.Minute,
+				run: func(ctx context.Context) {
+					run = true
+					if !tt.wantCanceled {
+						return
+					}
+					select {
+					case <-ctx.Done():
+						canceled = true
+					case <-time.After(time.Second):
+					}
+				},
+			}
+			w.runLocked(context.Background())
+			assert.Equal(t, tt.wantRun, run)
+			assert.Equal(t, tt.wantCanceled, canceled)
+			if tt.wantRun {
+				assert.Equal(t, []string{periodicWorkerLockInstance}, locker.unlocked)
+			} else {
+				assert.Empty(t, locker.unlocked)
+			}
+		})
+	}
+}
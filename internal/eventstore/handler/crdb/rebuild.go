@@ -0,0 +1,509 @@
+package crdb
+
+import (
+	"context"
+	"database/sql"
+	errs "errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+)
+
+const (
+	shadowSchemaSuffix   = "_shadow"
+	previousSchemaSuffix = "_previous"
+
+	// rebuildLockInstance locks the rebuild of a projection over all instances,
+	// so only a single rebuild of a projection runs at a time
+	rebuildLockInstance  = "system"
+	rebuildLockDuration  = 10 * time.Second
+	rebuildLockRetry     = time.Second
+	rebuildInstanceBatch = 10
+
+	createSchemaStmtFormat   = "CREATE SCHEMA IF NOT EXISTS %s"
+	projectionTablesStmt     = "SELECT table_name FROM information_schema.tables WHERE table_schema = $1 AND table_type = 'BASE TABLE' AND (table_name = $2 OR table_name LIKE $3)"
+	projectionViewsStmt      = "SELECT COUNT(*) FROM information_schema.views WHERE table_schema = $1 AND table_name = $2"
+	dropTableStmtFormat      = "DROP TABLE IF EXISTS %s CASCADE"
+	moveTableStmtFormat      = "ALTER TABLE IF EXISTS %s SET SCHEMA %s"
+	countRowsStmtFormat      = "SELECT COUNT(*) FROM %s"
+	lockProjectionStmtFormat = "SELECT projection_name FROM %s WHERE projection_name = ANY ($1) FOR UPDATE"
+	deleteProjectionFormat   = "DELETE FROM %s WHERE projection_name = $1"
+	renameProjectionFormat   = "UPDATE %s SET projection_name = $1 WHERE projection_name = $2"
+	countProjectionFormat    = "SELECT COUNT(*) FROM %s WHERE projection_name = $1"
+)
+
+// RebuildPhase is the step a rebuild of a projection is in
+type RebuildPhase int32
+
+const (
+	// RebuildPhaseBuilding reduces all events from sequence zero into the shadow tables
+	RebuildPhaseBuilding RebuildPhase = iota
+	// RebuildPhaseCatchingUp reduces the events which were pushed during the build
+	// while the projection is locked
+	RebuildPhaseCatchingUp
+	// RebuildPhaseSwapping moves the shadow tables into place
+	RebuildPhaseSwapping
+	// RebuildPhaseVerifying checks the swapped tables before the previous tables are dropped
+	RebuildPhaseVerifying
+	// RebuildPhaseDone is reached after the previous tables are dropped
+	RebuildPhaseDone
+)
+
+func (p RebuildPhase) String() string {
+	switch p {
+	case RebuildPhaseBuilding:
+		return "building"
+	case RebuildPhaseCatchingUp:
+		return "catching up"
+	case RebuildPhaseSwapping:
+		return "swapping"
+	case RebuildPhaseVerifying:
+		return "verifying"
+	case RebuildPhaseDone:
+		return "done"
+	default:
+		return "unknown"
+	}
+}
+
+// RebuildProgress reports the state of a running rebuild
+type RebuildProgress struct {
+	Projection    string
+	Phase         RebuildPhase
+	Instances     int
+	InstancesDone int
+	Events        uint64
+}
+
+// RebuildProgressFunc is called whenever the rebuild made progress
+type RebuildProgressFunc func(RebuildProgress)
+
+// Rebuild builds the projection from sequence zero into shadow tables
+// while the projection itself keeps serving its current state.
+// As soon as the shadow tables caught up with the eventstore,
+// they are swapped into place together with their current sequences in a single transaction.
+// The replaced tables are moved to the previous schema and only dropped after the swap is verified.
+func (h *StatementHandler) Rebuild(ctx context.Context, progress RebuildProgressFunc) (err error) {
+	schema, table := splitTableName(h.ProjectionName)
+	if schema == "" {
+		return errors.ThrowPreconditionFailed(nil, "CRDB-Vbw3u", "projection without schema cannot be rebuilt")
+	}
+	var views int
+	if err = h.client.QueryRowContext(ctx, projectionViewsStmt, schema, table).Scan(&views); err != nil {
+		return errors.ThrowInternal(err, "CRDB-Ks8ne", "unable to check projection")
+	}
+	if views > 0 {
+		return errors.ThrowPreconditionFailed(nil, "CRDB-uP0dq", "projections based on views cannot be rebuilt")
+	}
+
+	shadow := h.shadowHandler()
+	lockCtx, cancelLock := context.WithCancel(ctx)
+	defer cancelLock()
+	lockErrs := shadow.Lock(lockCtx, rebuildLockDuration, rebuildLockInstance)
+	if err, ok := <-lockErrs; err != nil || !ok {
+		return errors.ThrowAlreadyExists(err, "CRDB-Wd9lk", "projection rebuild already running")
+	}
+	go cancelOnLockErr(lockErrs, cancelLock)
+	defer func() {
+		cancelLock()
+		unlockErr := shadow.Unlock(rebuildLockInstance)
+		logging.WithFields("projection", h.ProjectionName).OnError(unlockErr).Warn("unable to unlock rebuild")
+	}()
+
+	state := RebuildProgress{Projection: h.ProjectionName, Phase: RebuildPhaseBuilding}
+	report := func() {
+		if progress != nil {
+			progress(state)
+		}
+	}
+	processed := func(events int) {
+		state.Events += uint64(events)
+		report()
+	}
+
+	if err = h.prepareShadow(lockCtx); err != nil {
+		return err
+	}
+	if err = shadow.Init(lockCtx); err != nil {
+		return err
+	}
+	instanceIDs, err := h.instanceIDs(lockCtx)
+	if err != nil {
+		return err
+	}
+	state.Instances = len(instanceIDs)
+	report()
+	for i := 0; i < len(instanceIDs); i += rebuildInstanceBatch {
+		max := i + rebuildInstanceBatch
+		if max > len(instanceIDs) {
+			max = len(instanceIDs)
+		}
+		if err = shadow.catchUp(lockCtx, instanceIDs[i:max], processed); err != nil {
+			return err
+		}
+		state.InstancesDone = max
+		report()
+	}
+
+	// keep the schedulers from updating the projection until the shadow replaced it
+	state.Phase = RebuildPhaseCatchingUp
+	report()
+	instanceIDs, unlockProjection, err := h.lockProjectionInstances(lockCtx, instanceIDs)
+	if err != nil {
+		return err
+	}
+	defer unlockProjection()
+	state.Instances = len(instanceIDs)
+	if err = shadow.catchUp(lockCtx, instanceIDs, processed); err != nil {
+		return err
+	}
+	state.InstancesDone = len(instanceIDs)
+
+	state.Phase = RebuildPhaseSwapping
+	report()
+	tables, sequences, err := h.swapShadow(lockCtx)
+	if err != nil {
+		return err
+	}
+	unlockProjection()
+
+	state.Phase = RebuildPhaseVerifying
+	report()
+	if err = h.verifySwap(lockCtx, tables, sequences); err != nil {
+		return err
+	}
+	if err = h.dropPrevious(lockCtx, tables); err != nil {
+		return err
+	}
+
+	state.Phase = RebuildPhaseDone
+	report()
+	return nil
+}
+
+// shadowHandler returns a copy of the handler which projects into the shadow tables
+// and stores its current sequences and failed events under the shadow name
+func (h *StatementHandler) shadowHandler() *StatementHandler {
+	shadow := *h
+	projectionHandler := *h.ProjectionHandler
+	projectionHandler.ProjectionName = shadowTableName(h.ProjectionName)
+	shadow.ProjectionHandler = &projectionHandler
+	shadow.Locker = NewLocker(h.client.DB, h.lockTable, projectionHandler.ProjectionName)
+	return &shadow
+}
+
+// prepareShadow creates the shadow and previous schemas
+// and removes the leftovers of a canceled rebuild
+func (h *StatementHandler) prepareShadow(ctx context.Context) error {
+	schema, table := splitTableName(h.ProjectionName)
+	shadowName := shadowTableName(h.ProjectionName)
+	tx, err := h.client.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.ThrowInternal(err, "CRDB-Qm3ft", "begin failed")
+	}
+	for _, stmt := range []string{
+		fmt.Sprintf(createSchemaStmtFormat, schema+shadowSchemaSuffix),
+		fmt.Sprintf(createSchemaStmtFormat, schema+previousSchemaSuffix),
+	} {
+		if _, err = tx.ExecContext(ctx, stmt); err != nil {
+			tx.Rollback()
+			return errors.ThrowInternal(err, "CRDB-Lp2sc", "unable to create schema")
+		}
+	}
+	tables, err := projectionTables(ctx, tx, schema+shadowSchemaSuffix, table)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	for _, table := range tables {
+		if _, err = tx.ExecContext(ctx, fmt.Sprintf(dropTableStmtFormat, schema+shadowSchemaSuffix+"."+table)); err != nil {
+			tx.Rollback()
+			return errors.ThrowInternal(err, "CRDB-Fh7xz", "unable to drop shadow table")
+		}
+	}
+	for _, projectionTable := range []string{h.sequenceTable, h.failedEventsTable} {
+		if _, err = tx.ExecContext(ctx, fmt.Sprintf(deleteProjectionFormat, projectionTable), shadowName); err != nil {
+			tx.Rollback()
+			return errors.ThrowInternal(err, "CRDB-Ow1vd", "unable to reset shadow")
+		}
+	}
+	return tx.Commit()
+}
+
+// catchUp reduces the events of the given instances until no newer events are found
+func (h *StatementHandler) catchUp(ctx context.Context, instanceIDs []string, processed func(int)) error {
+	if len(instanceIDs) == 0 {
+		return nil
+	}
+	for {
+		query, limit, err := h.SearchQuery(ctx, instanceIDs)
+		if err != nil {
+			return err
+		}
+		events, err := h.Eventstore.Filter(ctx, query)
+		if err != nil {
+			return err
+		}
+		if len(events) == 0 {
+			return nil
+		}
+		stmts := make([]*handler.Statement, len(events))
+		for i, event := range events {
+			if stmts[i], err = h.reduce(event); err != nil {
+				return err
+			}
+		}
+		index, err := h.Update(ctx, stmts, h.reduce)
+		if err != nil && !errs.Is(err, handler.ErrSomeStmtsFailed) {
+			return err
+		}
+		processed(index + 1)
+		// failed statements are retried until the max failure count is reached
+		if err == nil && uint64(len(events)) < limit {
+			return nil
+		}
+	}
+}
+
+// lockProjectionInstances locks the given instances of the projection
+// and the instances created in the meantime, until no further instance was found.
+// It returns all locked instances, so they are caught up before the swap
+func (h *StatementHandler) lockProjectionInstances(ctx context.Context, instanceIDs []string) (_ []string, unlock func(), err error) {
+	unlocks := make([]func(), 0, 1)
+	unlock = func() {
+		for _, unlockInstances := range unlocks {
+			unlockInstances()
+		}
+	}
+	locked := make([]string, 0, len(instanceIDs))
+	for {
+		if len(instanceIDs) > 0 {
+			unlockInstances, err := h.lockProjection(ctx, instanceIDs)
+			if err != nil {
+				unlock()
+				return nil, nil, err
+			}
+			unlocks = append(unlocks, unlockInstances)
+			locked = append(locked, instanceIDs...)
+		}
+		current, err := h.instanceIDs(ctx)
+		if err != nil {
+			unlock()
+			return nil, nil, err
+		}
+		if instanceIDs = missingInstanceIDs(locked, current); len(instanceIDs) == 0 {
+			return locked, unlock, nil
+		}
+	}
+}
+
+// missingInstanceIDs returns the instances of current which are not part of known
+func missingInstanceIDs(known, current []string) []string {
+	knownIDs := make(map[string]struct{}, len(known))
+	for _, instanceID := range known {
+		knownIDs[instanceID] = struct{}{}
+	}
+	missing := make([]string, 0)
+	for _, instanceID := range current {
+		if _, ok := knownIDs[instanceID]; !ok {
+			missing = append(missing, instanceID)
+		}
+	}
+	return missing
+}
+
+// lockProjection waits until the schedulers of the projection released the given instances
+func (h *StatementHandler) lockProjection(ctx context.Context, instanceIDs []string) (unlock func(), err error) {
+	if len(instanceIDs) == 0 {
+		return func() {}, nil
+	}
+	for {
+		lockCtx, cancelLock := context.WithCancel(ctx)
+		lockErrs := h.Lock(lockCtx, rebuildLockDuration, instanceIDs...)
+		if err, ok := <-lockErrs; err == nil && ok {
+			go cancelOnLockErr(lockErrs, cancelLock)
+			var unlocked bool
+			return func() {
+				if unlocked {
+					return
+				}
+				unlocked = true
+				cancelLock()
+				unlockErr := h.Unlock(instanceIDs...)
+				logging.WithFields("projection", h.ProjectionName).OnError(unlockErr).Warn("unable to unlock projection")
+			}, nil
+		}
+		cancelLock()
+		select {
+		case <-ctx.Done():
+			return nil, errors.ThrowInternal(ctx.Err(), "CRDB-Zu5xn", "unable to lock projection")
+		case <-time.After(rebuildLockRetry):
+		}
+	}
+}
+
+// swapShadow moves the tables of the projection to the previous schema
+// and the shadow tables into their place.
+// The current sequences and failed events are moved along with the tables
+func (h *StatementHandler) swapShadow(ctx context.Context) (tables []string, sequences int64, err error) {
+	schema, table := splitTableName(h.ProjectionName)
+	shadowName := shadowTableName(h.ProjectionName)
+	previousName := previousTableName(h.ProjectionName)
+
+	tx, err := h.client.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, 0, errors.ThrowInternal(err, "CRDB-Ap5sj", "begin failed")
+	}
+	// blocks the handlers of the projection until the swap is done
+	if _, err = tx.ExecContext(ctx, fmt.Sprintf(lockProjectionStmtFormat, h.sequenceTable), database.StringArray{h.ProjectionName, shadowName}); err != nil {
+		tx.Rollback()
+		return nil, 0, errors.ThrowInternal(err, "CRDB-Gm2lq", "unable to lock current sequences")
+	}
+	tables, err = projectionTables(ctx, tx, schema+shadowSchemaSuffix, table)
+	if err != nil {
+		tx.Rollback()
+		return nil, 0, err
+	}
+	if len(tables) == 0 {
+		tx.Rollback()
+		return nil, 0, errors.ThrowPreconditionFailed(nil, "CRDB-Td6ko", "no shadow tables found")
+	}
+	for _, table := range tables {
+		for _, stmt := range []string{
+			fmt.Sprintf(dropTableStmtFormat, schema+previousSchemaSuffix+"."+table),
+			fmt.Sprintf(moveTableStmtFormat, schema+"."+table, schema+previousSchemaSuffix),
+			fmt.Sprintf(moveTableStmtFormat, schema+shadowSchemaSuffix+"."+table, schema),
+		} {
+			if _, err = tx.ExecContext(ctx, stmt); err != nil {
+				tx.Rollback()
+				return nil, 0, errors.ThrowInternal(err, "CRDB-Rk3ny", "unable to swap tables")
+			}
+		}
+	}
+	for _, projectionTable := range []string{h.sequenceTable, h.failedEventsTable} {
+		if _, err = tx.ExecContext(ctx, fmt.Sprintf(deleteProjectionFormat, projectionTable), previousName); err != nil {
+			tx.Rollback()
+			return nil, 0, errors.ThrowInternal(err, "CRDB-Bx9wr", "unable to swap sequences")
+		}
+		if _, err = tx.ExecContext(ctx, fmt.Sprintf(renameProjectionFormat, projectionTable), previousName, h.ProjectionName); err != nil {
+			tx.Rollback()
+			return nil, 0, errors.ThrowInternal(err, "CRDB-Nf4ph", "unable to swap sequences")
+		}
+		res, err := tx.ExecContext(ctx, fmt.Sprintf(renameProjectionFormat, projectionTable), h.ProjectionName, shadowName)
+		if err != nil {
+			tx.Rollback()
+			return nil, 0, errors.ThrowInternal(err, "CRDB-Ue8cs", "unable to swap sequences")
+		}
+		if projectionTable == h.sequenceTable {
+			sequences, _ = res.RowsAffected()
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		return nil, 0, errors.ThrowInternal(err, "CRDB-Yj1gb", "unable to commit swap")
+	}
+	return tables, sequences, nil
+}
+
+// verifySwap checks if the swapped tables are queryable
+// and the current sequences of the shadow are in place
+func (h *StatementHandler) verifySwap(ctx context.Context, tables []string, sequences int64) error {
+	schema, _ := splitTableName(h.ProjectionName)
+	for _, table := range tables {
+		var count int64
+		if err := h.client.QueryRowContext(ctx, fmt.Sprintf(countRowsStmtFormat, schema+"."+table)).Scan(&count); err != nil {
+			return errors.ThrowInternal(err, "CRDB-Hs2vb", "swapped table not verified, previous tables are kept in "+schema+previousSchemaSuffix)
+		}
+	}
+	var count int64
+	if err := h.client.QueryRowContext(ctx, fmt.Sprintf(countProjectionFormat, h.sequenceTable), h.ProjectionName).Scan(&count); err != nil || count != sequences {
+		return errors.ThrowInternal(err, "CRDB-Pq7dn", "swapped sequences not verified, previous tables are kept in "+schema+previousSchemaSuffix)
+	}
+	return nil
+}
+
+// dropPrevious removes the replaced tables together with their current sequences and failed events
+func (h *StatementHandler) dropPrevious(ctx context.Context, tables []string) error {
+	schema, _ := splitTableName(h.ProjectionName)
+	previousName := previousTableName(h.ProjectionName)
+	tx, err := h.client.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.ThrowInternal(err, "CRDB-Ei4xm", "begin failed")
+	}
+	for _, table := range tables {
+		if _, err = tx.ExecContext(ctx, fmt.Sprintf(dropTableStmtFormat, schema+previousSchemaSuffix+"."+table)); err != nil {
+			tx.Rollback()
+			return errors.ThrowInternal(err, "CRDB-Cz6ut", "unable to drop previous table")
+		}
+	}
+	for _, projectionTable := range []string{h.sequenceTable, h.failedEventsTable} {
+		if _, err = tx.ExecContext(ctx, fmt.Sprintf(deleteProjectionFormat, projectionTable), previousName); err != nil {
+			tx.Rollback()
+			return errors.ThrowInternal(err, "CRDB-Jw0ob", "unable to remove previous sequences")
+		}
+	}
+	return tx.Commit()
+}
+
+// instanceIDs returns all instances which have events of the aggregates of the projection
+func (h *StatementHandler) instanceIDs(ctx context.Context) ([]string, error) {
+	return h.Eventstore.InstanceIDs(ctx, eventstore.NewSearchQueryBuilder(eventstore.ColumnsInstanceIDs).
+		AddQuery().
+		AggregateTypes(h.aggregates...).
+		ExcludedInstanceID("").
+		Builder(),
+	)
+}
+
+// projectionTables returns the name of the table and its suffixed tables in the schema
+func projectionTables(ctx context.Context, tx *sql.Tx, schema, table string) ([]string, error) {
+	rows, err := tx.QueryContext(ctx, projectionTablesStmt, schema, table, strings.ReplaceAll(table, "_", `\_`)+`\_%`)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "CRDB-Mv8ge", "unable to query projection tables")
+	}
+	defer rows.Close()
+	tables := make([]string, 0)
+	for rows.Next() {
+		var name string
+		if err = rows.Scan(&name); err != nil {
+			return nil, errors.ThrowInternal(err, "CRDB-Xo4wd", "scan failed")
+		}
+		tables = append(tables, name)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, errors.ThrowInternal(err, "CRDB-Sa7fh", "errors in scanning rows")
+	}
+	return tables, nil
+}
+
+func cancelOnLockErr(errs <-chan error, cancel func()) {
+	for err := range errs {
+		if err != nil {
+			cancel()
+		}
+	}
+}
+
+func splitTableName(name string) (schema, table string) {
+	parts := strings.SplitN(name, ".", 2)
+	if len(parts) < 2 {
+		return "", name
+	}
+	return parts[0], parts[1]
+}
+
+func shadowTableName(name string) string {
+	schema, table := splitTableName(name)
+	return schema + shadowSchemaSuffix + "." + table
+}
+
+func previousTableName(name string) string {
+	schema, table := splitTableName(name)
+	return schema + previousSchemaSuffix + "." + table
+}
@@ -0,0 +1,238 @@
+package crdb
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+)
+
+func TestStatementHandler_swapShadow(t *testing.T) {
+	type want struct {
+		expectations []mockExpectation
+		tables       []string
+		sequences    int64
+		isErr        func(error) bool
+	}
+	tests := []struct {
+		name string
+		want want
+	}{
+		{
+			name: "no shadow tables",
+			want: want{
+				expectations: []mockExpectation{
+					expectBegin(),
+					expectLockProjection(),
+					expectProjectionTables(),
+					expectRollback(),
+				},
+				isErr: func(err error) bool {
+					return err != nil
+				},
+			},
+		},
+		{
+			name: "move table fails",
+			want: want{
+				expectations: []mockExpectation{
+					expectBegin(),
+					expectLockProjection(),
+					expectProjectionTables("users"),
+					expectExecRebuild("DROP TABLE IF EXISTS projections_previous.users CASCADE"),
+					expectExecRebuildErr("ALTER TABLE IF EXISTS projections.users SET SCHEMA projections_previous", sql.ErrConnDone),
+					expectRollback(),
+				},
+				isErr: func(err error) bool {
+					return errors.Is(err, sql.ErrConnDone)
+				},
+			},
+		},
+		{
+			name: "correct",
+			want: want{
+				expectations: []mockExpectation{
+					expectBegin(),
+					expectLockProjection(),
+					expectProjectionTables("users", "users_humans"),
+					expectExecRebuild("DROP TABLE IF EXISTS projections_previous.users CASCADE"),
+					expectExecRebuild("ALTER TABLE IF EXISTS projections.users SET SCHEMA projections_previous"),
+					expectExecRebuild("ALTER TABLE IF EXISTS projections_shadow.users SET SCHEMA projections"),
+					expectExecRebuild("DROP TABLE IF EXISTS projections_previous.users_humans CASCADE"),
+					expectExecRebuild("ALTER TABLE IF EXISTS projections.users_humans SET SCHEMA projections_previous"),
+					expectExecRebuild("ALTER TABLE IF EXISTS projections_shadow.users_humans SET SCHEMA projections"),
+					expectExecRebuild("DELETE FROM sequence_table WHERE projection_name = $1", "projections_previous.users"),
+					expectExecRebuild("UPDATE sequence_table SET projection_name = $1 WHERE projection_name = $2", "projections_previous.users", "projections.users"),
+					expectExecRebuildRows("UPDATE sequence_table SET projection_name = $1 WHERE projection_name = $2", 3, "projections.users", "projections_shadow.users"),
+					expectExecRebuild("DELETE FROM failed_table WHERE projection_name = $1", "projections_previous.users"),
+					expectExecRebuild("UPDATE failed_table SET projection_name = $1 WHERE projection_name = $2", "projections_previous.users", "projections.users"),
+					expectExecRebuild("UPDATE failed_table SET projection_name = $1 WHERE projection_name = $2", "projections.users", "projections_shadow.users"),
+					expectCommit(),
+				},
+				tables:    []string{"users", "users_humans"},
+				sequences: 3,
+				isErr: func(err error) bool {
+					return err == nil
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer client.Close()
+
+			h := &StatementHandler{
+				ProjectionHandler: &handler.ProjectionHandler{
+					ProjectionName: "projections.users",
+				},
+				client: &database.DB{
+					DB: client,
+				},
+				sequenceTable:     "sequence_table",
+				failedEventsTable: "failed_table",
+			}
+
+			for _, expectation := range tt.want.expectations {
+				expectation(mock)
+			}
+
+			tables, sequences, err := h.swapShadow(context.Background())
+			if !tt.want.isErr(err) {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if len(tables) != len(tt.want.tables) || sequences != tt.want.sequences {
+				t.Errorf("unexpected result: tables %v, sequences %d", tables, sequences)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("expectations not met: %v", err)
+			}
+		})
+	}
+}
+
+func Test_rebuildTableNames(t *testing.T) {
+	tests := []struct {
+		name         string
+		projection   string
+		wantSchema   string
+		wantShadow   string
+		wantPrevious string
+	}{
+		{
+			name:         "with schema",
+			projection:   "projections.users",
+			wantSchema:   "projections",
+			wantShadow:   "projections_shadow.users",
+			wantPrevious: "projections_previous.users",
+		},
+		{
+			name:         "without schema",
+			projection:   "users",
+			wantSchema:   "",
+			wantShadow:   "_shadow.users",
+			wantPrevious: "_previous.users",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if schema, _ := splitTableName(tt.projection); schema != tt.wantSchema {
+				t.Errorf("splitTableName() = %q, want %q", schema, tt.wantSchema)
+			}
+			if got := shadowTableName(tt.projection); got != tt.wantShadow {
+				t.Errorf("shadowTableName() = %q, want %q", got, tt.wantShadow)
+			}
+			if got := previousTableName(tt.projection); got != tt.wantPrevious {
+				t.Errorf("previousTableName() = %q, want %q", got, tt.wantPrevious)
+			}
+		})
+	}
+}
+
+func Test_missingInstanceIDs(t *testing.T) {
+	tests := []struct {
+		name    string
+		known   []string
+		current []string
+		want    []string
+	}{
+		{
+			name:    "none missing",
+			known:   []string{"instance1", "instance2"},
+			current: []string{"instance2", "instance1"},
+			want:    []string{},
+		},
+		{
+			name:    "instance created during rebuild",
+			known:   []string{"instance1"},
+			current: []string{"instance1", "instance2"},
+			want:    []string{"instance2"},
+		},
+		{
+			name:    "no instances known",
+			current: []string{"instance1"},
+			want:    []string{"instance1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := missingInstanceIDs(tt.known, tt.current); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("missingInstanceIDs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func expectLockProjection() func(sqlmock.Sqlmock) {
+	return func(m sqlmock.Sqlmock) {
+		m.ExpectExec(regexp.QuoteMeta("SELECT projection_name FROM sequence_table WHERE projection_name = ANY ($1) FOR UPDATE")).
+			WithArgs(database.StringArray{"projections.users", "projections_shadow.users"}).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+	}
+}
+
+func expectProjectionTables(tables ...string) func(sqlmock.Sqlmock) {
+	return func(m sqlmock.Sqlmock) {
+		rows := sqlmock.NewRows([]string{"table_name"})
+		for _, table := range tables {
+			rows.AddRow(table)
+		}
+		m.ExpectQuery(regexp.QuoteMeta(projectionTablesStmt)).
+			WithArgs("projections_shadow", "users", `users\_%`).
+			WillReturnRows(rows)
+	}
+}
+
+func expectExecRebuild(stmt string, args ...interface{}) func(sqlmock.Sqlmock) {
+	return expectExecRebuildRows(stmt, 0, args...)
+}
+
+func expectExecRebuildRows(stmt string, rows int64, args ...interface{}) func(sqlmock.Sqlmock) {
+	values := make([]driver.Value, len(args))
+	for i, arg := range args {
+		values[i] = arg
+	}
+	return func(m sqlmock.Sqlmock) {
+		m.ExpectExec(regexp.QuoteMeta(stmt)).
+			WithArgs(values...).
+			WillReturnResult(sqlmock.NewResult(0, rows))
+	}
+}
+
+func expectExecRebuildErr(stmt string, err error) func(sqlmock.Sqlmock) {
+	return func(m sqlmock.Sqlmock) {
+		m.ExpectExec(regexp.QuoteMeta(stmt)).
+			WillReturnError(err)
+	}
+}
@@ -1,6 +1,9 @@
 package crdb
 
 import (
+	"database/sql"
+
+	"github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/eventstore/handler"
 )
@@ -14,3 +17,31 @@ func (h *StatementHandler) reduce(event eventstore.Event) (*handler.Statement, e
 
 	return reduce(event)
 }
+
+// EventTypes returns the event types reduced by the handler
+func (h *StatementHandler) EventTypes() []eventstore.EventType {
+	eventTypes := make([]eventstore.EventType, 0, len(h.reduces))
+	for eventType := range h.reduces {
+		eventTypes = append(eventTypes, eventType)
+	}
+	return eventTypes
+}
+
+// Replay reduces the events and executes the statements on the projection in the given transaction
+// without checking or updating the current sequences.
+// It's used to repair the rows of single aggregates
+func (h *StatementHandler) Replay(tx *sql.Tx, events ...eventstore.Event) error {
+	for _, event := range events {
+		stmt, err := h.reduce(event)
+		if err != nil {
+			return err
+		}
+		if stmt.IsNoop() {
+			continue
+		}
+		if err = stmt.Execute(tx, h.ProjectionName); err != nil {
+			return errors.ThrowInternal(err, "CRDB-Rp4ek", "unable to replay event")
+		}
+	}
+	return nil
+}
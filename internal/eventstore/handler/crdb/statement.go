@@ -94,6 +94,38 @@ func NewUpsertStatement(event eventstore.Event, conflictCols []handler.Column, v
 	}
 }
 
+// NewCreateIfNotExistsStatement inserts the values
+// if no row with the same values of the conflict columns exists
+func NewCreateIfNotExistsStatement(event eventstore.Event, conflictCols []handler.Column, values []handler.Column, opts ...execOption) *handler.Statement {
+	cols, params, args := columnsToQuery(values)
+
+	conflictTarget := make([]string, len(conflictCols))
+	for i, col := range conflictCols {
+		conflictTarget[i] = col.Name
+	}
+
+	config := execConfig{
+		args: args,
+	}
+
+	if len(values) == 0 {
+		config.err = handler.ErrNoValues
+	}
+
+	q := func(config execConfig) string {
+		return "INSERT INTO " + config.tableName + " (" + strings.Join(cols, ", ") + ") VALUES (" + strings.Join(params, ", ") + ")" +
+			" ON CONFLICT (" + strings.Join(conflictTarget, ", ") + ") DO NOTHING"
+	}
+
+	return &handler.Statement{
+		AggregateType:    event.Aggregate().Type,
+		Sequence:         event.Sequence(),
+		PreviousSequence: event.PreviousAggregateTypeSequence(),
+		InstanceID:       event.Aggregate().InstanceID,
+		Execute:          exec(config, q, opts),
+	}
+}
+
 func getUpdateCols(cols, conflictTarget []string) (updateCols, updateVals []string) {
 	updateCols = make([]string, len(cols))
 	updateVals = make([]string, len(cols))
@@ -223,6 +255,12 @@ func AddUpsertStatement(indexCols []handler.Column, values []handler.Column, opt
 	}
 }
 
+func AddCreateIfNotExistsStatement(conflictCols []handler.Column, values []handler.Column, opts ...execOption) func(eventstore.Event) Exec {
+	return func(event eventstore.Event) Exec {
+		return NewCreateIfNotExistsStatement(event, conflictCols, values, opts...).Execute
+	}
+}
+
 func AddUpdateStatement(values []handler.Column, conditions []handler.Condition, opts ...execOption) func(eventstore.Event) Exec {
 	return func(event eventstore.Event) Exec {
 		return NewUpdateStatement(event, values, conditions, opts...).Execute
@@ -46,6 +46,9 @@ type Event struct {
 	//Version describes the definition of the aggregate at a certain point in time
 	// it's used in read models to reduce the events in the correct definition
 	Version Version
+	//SchemaVersion is the version of the payload of the event type
+	// it's increased by the upcasters of the event type, events without upcasters have version 0
+	SchemaVersion uint16
 	//AggregateID id is the unique identifier of the aggregate
 	// the client must generate it by it's own
 	AggregateID string
@@ -49,6 +49,21 @@ func (mr *MockRepositoryMockRecorder) CreateInstance(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInstance", reflect.TypeOf((*MockRepository)(nil).CreateInstance), arg0, arg1)
 }
 
+// DeleteInstance mocks base method.
+func (m *MockRepository) DeleteInstance(arg0 context.Context, arg1 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteInstance", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteInstance indicates an expected call of DeleteInstance.
+func (mr *MockRepositoryMockRecorder) DeleteInstance(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteInstance", reflect.TypeOf((*MockRepository)(nil).DeleteInstance), arg0, arg1)
+}
+
 // Filter mocks base method.
 func (m *MockRepository) Filter(arg0 context.Context, arg1 *repository.SearchQuery) ([]*repository.Event, error) {
 	m.ctrl.T.Helper()
@@ -126,3 +141,75 @@ func (mr *MockRepositoryMockRecorder) Push(arg0, arg1 interface{}, arg2 ...inter
 	varargs := append([]interface{}{arg0, arg1}, arg2...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Push", reflect.TypeOf((*MockRepository)(nil).Push), varargs...)
 }
+
+// Snapshot mocks base method.
+func (m *MockRepository) Snapshot(arg0 context.Context, arg1 string, arg2 repository.AggregateType, arg3, arg4, arg5 string) (*repository.Snapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Snapshot", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(*repository.Snapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Snapshot indicates an expected call of Snapshot.
+func (mr *MockRepositoryMockRecorder) Snapshot(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Snapshot", reflect.TypeOf((*MockRepository)(nil).Snapshot), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
+// StoreSnapshot mocks base method.
+func (m *MockRepository) StoreSnapshot(arg0 context.Context, arg1 *repository.Snapshot) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreSnapshot", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreSnapshot indicates an expected call of StoreSnapshot.
+func (mr *MockRepositoryMockRecorder) StoreSnapshot(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreSnapshot", reflect.TypeOf((*MockRepository)(nil).StoreSnapshot), arg0, arg1)
+}
+
+// UniqueConstraints mocks base method.
+func (m *MockRepository) UniqueConstraints(arg0 context.Context, arg1 string) ([]*repository.UniqueConstraint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UniqueConstraints", arg0, arg1)
+	ret0, _ := ret[0].([]*repository.UniqueConstraint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UniqueConstraints indicates an expected call of UniqueConstraints.
+func (mr *MockRepositoryMockRecorder) UniqueConstraints(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UniqueConstraints", reflect.TypeOf((*MockRepository)(nil).UniqueConstraints), arg0, arg1)
+}
+
+// UpdateEventData mocks base method.
+func (m *MockRepository) UpdateEventData(arg0 context.Context, arg1 string, arg2 uint64, arg3 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEventData", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateEventData indicates an expected call of UpdateEventData.
+func (mr *MockRepositoryMockRecorder) UpdateEventData(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEventData", reflect.TypeOf((*MockRepository)(nil).UpdateEventData), arg0, arg1, arg2, arg3)
+}
+
+// UpdateEventSchemaVersion mocks base method.
+func (m *MockRepository) UpdateEventSchemaVersion(arg0 context.Context, arg1 string, arg2 uint64, arg3 uint16, arg4 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEventSchemaVersion", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateEventSchemaVersion indicates an expected call of UpdateEventSchemaVersion.
+func (mr *MockRepositoryMockRecorder) UpdateEventSchemaVersion(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEventSchemaVersion", reflect.TypeOf((*MockRepository)(nil).UpdateEventSchemaVersion), arg0, arg1, arg2, arg3, arg4)
+}
@@ -43,6 +43,16 @@ func (m *MockRepository) ExpectInstanceIDsError(err error) *MockRepository {
 	return m
 }
 
+func (m *MockRepository) ExpectCreateInstance(instanceID string) *MockRepository {
+	m.EXPECT().CreateInstance(gomock.Any(), instanceID).Return(nil)
+	return m
+}
+
+func (m *MockRepository) ExpectUniqueConstraints(constraints ...*repository.UniqueConstraint) *MockRepository {
+	m.EXPECT().UniqueConstraints(gomock.Any(), gomock.Any()).Return(constraints, nil)
+	return m
+}
+
 func (m *MockRepository) ExpectPush(expectedEvents []*repository.Event, expectedUniqueConstraints ...*repository.UniqueConstraint) *MockRepository {
 	m.EXPECT().Push(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
 		func(ctx context.Context, events []*repository.Event, uniqueConstraints ...*repository.UniqueConstraint) error {
@@ -70,3 +80,8 @@ func (m *MockRepository) ExpectPushFailed(err error, expectedEvents []*repositor
 	)
 	return m
 }
+
+func (m *MockRepository) ExpectUpdateEventSchemaVersion(instanceID string, sequence uint64, schemaVersion uint16, data []byte) *MockRepository {
+	m.EXPECT().UpdateEventSchemaVersion(gomock.Any(), instanceID, sequence, schemaVersion, data).Return(nil)
+	return m
+}
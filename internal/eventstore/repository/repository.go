@@ -20,4 +20,16 @@ type Repository interface {
 	InstanceIDs(ctx context.Context, queryFactory *SearchQuery) ([]string, error)
 	//CreateInstance creates a new sequence for the given instance
 	CreateInstance(ctx context.Context, instanceID string) error
+	//UniqueConstraints returns the unique constraints stored for the given instance
+	UniqueConstraints(ctx context.Context, instanceID string) ([]*UniqueConstraint, error)
+	//DeleteInstance deletes all events, unique constraints and the sequence of the given instance
+	DeleteInstance(ctx context.Context, instanceID string) (deletedEvents int64, err error)
+	//UpdateEventData replaces the data of the event with the given sequence
+	UpdateEventData(ctx context.Context, instanceID string, sequence uint64, data []byte) error
+	//UpdateEventSchemaVersion replaces the schema version and the data of the event with the given sequence
+	UpdateEventSchemaVersion(ctx context.Context, instanceID string, sequence uint64, schemaVersion uint16, data []byte) error
+	//Snapshot returns the stored snapshot of the model with the given version
+	Snapshot(ctx context.Context, instanceID string, aggregateType AggregateType, aggregateID, model, version string) (*Snapshot, error)
+	//StoreSnapshot creates or replaces the snapshot of the model
+	StoreSnapshot(ctx context.Context, snapshot *Snapshot) error
 }
@@ -0,0 +1,19 @@
+package repository
+
+import "time"
+
+// Snapshot is the serialized state of a write model
+// after it reduced all events up to the sequence
+type Snapshot struct {
+	InstanceID    string
+	AggregateType AggregateType
+	AggregateID   string
+	// Model distinguishes the write models of the same aggregate
+	Model string
+	// Version invalidates the snapshot as soon as the reducer of the model changes
+	Version       string
+	Sequence      uint64
+	ResourceOwner string
+	ChangeDate    time.Time
+	Data          []byte
+}
@@ -51,6 +51,7 @@ const (
 		" aggregate_type," +
 		" aggregate_id," +
 		" aggregate_version," +
+		" event_schema_version," +
 		" creation_date," +
 		" event_data," +
 		" editor_user," +
@@ -67,6 +68,7 @@ const (
 		" $2::VARCHAR AS aggregate_type," +
 		" $3::VARCHAR AS aggregate_id," +
 		" $4::VARCHAR AS aggregate_version," +
+		" $10::INT2 AS event_schema_version," +
 		" statement_timestamp() AS creation_date," +
 		" $5::JSONB AS event_data," +
 		" $6::VARCHAR AS editor_user," +
@@ -95,6 +97,32 @@ const (
 					WHERE unique_type = $1 and unique_field = $2 and instance_id = $3`
 	uniqueDeleteInstance = `DELETE FROM eventstore.unique_constraints
 					WHERE instance_id = $1`
+	uniqueSelectInstance = `SELECT unique_type, unique_field FROM eventstore.unique_constraints
+					WHERE instance_id = $1`
+
+	// eventsDeleteInstance deletes the events of an instance in batches,
+	// so the size of a single transaction stays limited
+	eventsDeleteInstance = `DELETE FROM eventstore.events
+					WHERE instance_id = $1 AND event_sequence IN (
+						SELECT event_sequence FROM eventstore.events WHERE instance_id = $1 LIMIT $2
+					)`
+	eventUpdateData = `UPDATE eventstore.events SET event_data = $1
+					WHERE instance_id = $2 AND event_sequence = $3`
+	eventUpdateSchemaVersion = `UPDATE eventstore.events SET event_schema_version = $1, event_data = $2
+					WHERE instance_id = $3 AND event_sequence = $4`
+
+	snapshotSelect = `SELECT sequence, resource_owner, change_date, data FROM eventstore.snapshots
+					WHERE instance_id = $1 AND aggregate_type = $2 AND aggregate_id = $3 AND model = $4 AND version = $5`
+	snapshotUpsert = `INSERT INTO eventstore.snapshots
+					(instance_id, aggregate_type, aggregate_id, model, version, sequence, resource_owner, change_date, data)
+					VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+					ON CONFLICT (instance_id, aggregate_type, aggregate_id, model) DO UPDATE SET
+					version = EXCLUDED.version, sequence = EXCLUDED.sequence, resource_owner = EXCLUDED.resource_owner,
+					change_date = EXCLUDED.change_date, data = EXCLUDED.data`
+	snapshotsDeleteInstance = `DELETE FROM eventstore.snapshots
+					WHERE instance_id = $1`
+
+	eventsDeleteBatchSize = 10000
 )
 
 type CRDB struct {
@@ -128,6 +156,7 @@ func (db *CRDB) Push(ctx context.Context, events []*repository.Event, uniqueCons
 				event.EditorService,
 				event.ResourceOwner,
 				event.InstanceID,
+				event.SchemaVersion,
 			).Scan(&event.ID, &event.Sequence, &previousAggregateSequence, &previousAggregateTypeSequence, &event.CreationDate, &event.ResourceOwner, &event.InstanceID)
 
 			event.PreviousAggregateSequence = uint64(previousAggregateSequence)
@@ -161,13 +190,9 @@ func (db *CRDB) Push(ctx context.Context, events []*repository.Event, uniqueCons
 var instanceRegexp = regexp.MustCompile(`eventstore\.i_[0-9a-zA-Z]{1,}_seq`)
 
 func (db *CRDB) CreateInstance(ctx context.Context, instanceID string) error {
-	row := db.QueryRowContext(ctx, "SELECT CONCAT('eventstore.i_', $1::TEXT, '_seq')", instanceID)
-	if row.Err() != nil {
-		return caos_errs.ThrowInvalidArgument(row.Err(), "SQL-7gtFA", "Errors.InvalidArgument")
-	}
-	var sequenceName string
-	if err := row.Scan(&sequenceName); err != nil || !instanceRegexp.MatchString(sequenceName) {
-		return caos_errs.ThrowInvalidArgument(err, "SQL-7gtFA", "Errors.InvalidArgument")
+	sequenceName, err := db.instanceSequenceName(ctx, instanceID)
+	if err != nil {
+		return err
 	}
 
 	if _, err := db.ExecContext(ctx, "CREATE SEQUENCE "+sequenceName); err != nil {
@@ -177,6 +202,121 @@ func (db *CRDB) CreateInstance(ctx context.Context, instanceID string) error {
 	return nil
 }
 
+// DeleteInstance deletes all events and unique constraints of the instance and drops its sequence.
+// If the deletion fails it can be repeated until it succeeds
+func (db *CRDB) DeleteInstance(ctx context.Context, instanceID string) (deletedEvents int64, err error) {
+	sequenceName, err := db.instanceSequenceName(ctx, instanceID)
+	if err != nil {
+		return 0, err
+	}
+	for {
+		result, err := db.ExecContext(ctx, eventsDeleteInstance, instanceID, eventsDeleteBatchSize)
+		if err != nil {
+			return deletedEvents, caos_errs.ThrowInternal(err, "SQL-Rv6kD", "unable to delete events")
+		}
+		deleted, err := result.RowsAffected()
+		if err != nil {
+			return deletedEvents, caos_errs.ThrowInternal(err, "SQL-Ub2wN", "unable to delete events")
+		}
+		deletedEvents += deleted
+		if deleted < eventsDeleteBatchSize {
+			break
+		}
+	}
+	if _, err = db.ExecContext(ctx, uniqueDeleteInstance, instanceID); err != nil {
+		return deletedEvents, caos_errs.ThrowInternal(err, "SQL-Hc4qZ", "unable to delete unique constraints")
+	}
+	if _, err = db.ExecContext(ctx, snapshotsDeleteInstance, instanceID); err != nil {
+		return deletedEvents, caos_errs.ThrowInternal(err, "SQL-Sd2hv", "unable to delete snapshots")
+	}
+	if _, err = db.ExecContext(ctx, "DROP SEQUENCE IF EXISTS "+sequenceName); err != nil {
+		return deletedEvents, caos_errs.ThrowInternal(err, "SQL-Pe8tM", "unable to drop sequence")
+	}
+	return deletedEvents, nil
+}
+
+// UpdateEventData replaces the data of the event with the given sequence
+func (db *CRDB) UpdateEventData(ctx context.Context, instanceID string, sequence uint64, data []byte) error {
+	result, err := db.ExecContext(ctx, eventUpdateData, Data(data), instanceID, sequence)
+	if err != nil {
+		return caos_errs.ThrowInternal(err, "SQL-Yw5bG", "unable to update event data")
+	}
+	if rows, err := result.RowsAffected(); err != nil || rows != 1 {
+		return caos_errs.ThrowNotFound(err, "SQL-Ko3sX", "event not found")
+	}
+	return nil
+}
+
+// UpdateEventSchemaVersion replaces the schema version and the data of the event with the given sequence
+func (db *CRDB) UpdateEventSchemaVersion(ctx context.Context, instanceID string, sequence uint64, schemaVersion uint16, data []byte) error {
+	result, err := db.ExecContext(ctx, eventUpdateSchemaVersion, schemaVersion, Data(data), instanceID, sequence)
+	if err != nil {
+		return caos_errs.ThrowInternal(err, "SQL-Vu4ex", "unable to update event schema version")
+	}
+	if rows, err := result.RowsAffected(); err != nil || rows != 1 {
+		return caos_errs.ThrowNotFound(err, "SQL-Nf6rk", "event not found")
+	}
+	return nil
+}
+
+// Snapshot returns the stored snapshot of the model,
+// snapshots of other versions are not found
+func (db *CRDB) Snapshot(ctx context.Context, instanceID string, aggregateType repository.AggregateType, aggregateID, model, version string) (*repository.Snapshot, error) {
+	snapshot := &repository.Snapshot{
+		InstanceID:    instanceID,
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		Model:         model,
+		Version:       version,
+	}
+	var (
+		sequence Sequence
+		data     Data
+	)
+	err := db.QueryRowContext(ctx, snapshotSelect, instanceID, aggregateType, aggregateID, model, version).
+		Scan(&sequence, &snapshot.ResourceOwner, &snapshot.ChangeDate, &data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, caos_errs.ThrowNotFound(err, "SQL-Gw4nz", "snapshot not found")
+	}
+	if err != nil {
+		return nil, caos_errs.ThrowInternal(err, "SQL-Ab7sd", "unable to query snapshot")
+	}
+	snapshot.Sequence = uint64(sequence)
+	snapshot.Data = data
+	return snapshot, nil
+}
+
+// StoreSnapshot creates or replaces the snapshot of the model
+func (db *CRDB) StoreSnapshot(ctx context.Context, snapshot *repository.Snapshot) error {
+	_, err := db.ExecContext(ctx, snapshotUpsert,
+		snapshot.InstanceID,
+		snapshot.AggregateType,
+		snapshot.AggregateID,
+		snapshot.Model,
+		snapshot.Version,
+		snapshot.Sequence,
+		snapshot.ResourceOwner,
+		snapshot.ChangeDate,
+		Data(snapshot.Data),
+	)
+	if err != nil {
+		return caos_errs.ThrowInternal(err, "SQL-Ws3kf", "unable to store snapshot")
+	}
+	return nil
+}
+
+func (db *CRDB) instanceSequenceName(ctx context.Context, instanceID string) (string, error) {
+	row := db.QueryRowContext(ctx, "SELECT CONCAT('eventstore.i_', $1::TEXT, '_seq')", instanceID)
+	if row.Err() != nil {
+		return "", caos_errs.ThrowInvalidArgument(row.Err(), "SQL-7gtFA", "Errors.InvalidArgument")
+	}
+	var sequenceName string
+	if err := row.Scan(&sequenceName); err != nil || !instanceRegexp.MatchString(sequenceName) {
+		return "", caos_errs.ThrowInvalidArgument(err, "SQL-7gtFA", "Errors.InvalidArgument")
+	}
+	return sequenceName, nil
+}
+
 // handleUniqueConstraints adds or removes unique constraints
 func (db *CRDB) handleUniqueConstraints(ctx context.Context, tx *sql.Tx, uniqueConstraints ...*repository.UniqueConstraint) (err error) {
 	if len(uniqueConstraints) == 0 || (len(uniqueConstraints) == 1 && uniqueConstraints[0] == nil) {
@@ -250,6 +390,31 @@ func (db *CRDB) InstanceIDs(ctx context.Context, searchQuery *repository.SearchQ
 	return ids, nil
 }
 
+// UniqueConstraints returns the unique constraints stored for the given instance
+func (db *CRDB) UniqueConstraints(ctx context.Context, instanceID string) (_ []*repository.UniqueConstraint, err error) {
+	rows, err := db.QueryContext(ctx, uniqueSelectInstance, instanceID)
+	if err != nil {
+		return nil, caos_errs.ThrowInternal(err, "SQL-Wq3vT", "unable to query unique constraints")
+	}
+	defer rows.Close()
+
+	constraints := make([]*repository.UniqueConstraint, 0)
+	for rows.Next() {
+		constraint := &repository.UniqueConstraint{
+			InstanceID: instanceID,
+			Action:     repository.UniqueConstraintAdd,
+		}
+		if err := rows.Scan(&constraint.UniqueType, &constraint.UniqueField); err != nil {
+			return nil, caos_errs.ThrowInternal(err, "SQL-Jd8fU", "unable to scan unique constraint")
+		}
+		constraints = append(constraints, constraint)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, caos_errs.ThrowInternal(err, "SQL-Ox4pE", "unable to query unique constraints")
+	}
+	return constraints, nil
+}
+
 func (db *CRDB) db() *sql.DB {
 	return db.DB.DB
 }
@@ -285,6 +450,7 @@ func (db *CRDB) eventQuery() string {
 		", aggregate_type" +
 		", aggregate_id" +
 		", aggregate_version" +
+		", event_schema_version" +
 		" FROM eventstore.events"
 }
 
@@ -155,6 +155,7 @@ func eventsScanner(scanner scan, dest interface{}) (err error) {
 		&event.AggregateType,
 		&event.AggregateID,
 		&event.Version,
+		&event.SchemaVersion,
 	)
 
 	if err != nil {
@@ -237,6 +237,28 @@ func (query *SearchQuery) matches(event Event) bool {
 	return true
 }
 
+// snapshotable checks if the events of the query can be reduced starting at a snapshot
+func (builder *SearchQueryBuilder) snapshotable() bool {
+	if builder.limit > 0 || builder.desc || builder.allowTimeTravel || builder.columns != repository.ColumnsEvent {
+		return false
+	}
+	for _, query := range builder.queries {
+		if query.eventSequenceLess > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// sequenceGreater restricts all queries to events after the sequence
+func (builder *SearchQueryBuilder) sequenceGreater(sequence uint64) {
+	for _, query := range builder.queries {
+		if query.eventSequenceGreater < sequence {
+			query.eventSequenceGreater = sequence
+		}
+	}
+}
+
 func (builder *SearchQueryBuilder) build(instanceID string) (*repository.SearchQuery, error) {
 	if builder == nil ||
 		len(builder.queries) < 1 ||
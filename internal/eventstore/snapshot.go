@@ -0,0 +1,181 @@
+package eventstore
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+// SnapshotConfig configures the snapshots of write models
+type SnapshotConfig struct {
+	Enabled bool
+	// Interval is the minimum count of events a write model must reduce
+	// after its last snapshot before a new snapshot is stored
+	Interval uint32
+}
+
+// SnapshotReducer is a QueryReducer whose state can be stored in snapshots,
+// so only the events after the snapshot must be filtered.
+// The state is serialized as json, so it must only consist of exported fields.
+// The WriteModel must be embedded to implement the interface
+type SnapshotReducer interface {
+	QueryReducer
+	// SnapshotKey identifies the snapshot inside the instance,
+	// write models of the same type and aggregate with different queries must return different models
+	SnapshotKey() (aggregateType AggregateType, aggregateID, model string)
+	// SnapshotVersion must be increased as soon as the reducer computes the state differently,
+	// changes of the fields of the write model invalidate the snapshots automatically
+	SnapshotVersion() uint16
+	writeModel() *WriteModel
+}
+
+// filterToSnapshotReducer loads the snapshot of the reducer, filters the events after the snapshot
+// and stores a new snapshot if enough events were reduced
+func (es *Eventstore) filterToSnapshotReducer(ctx context.Context, r SnapshotReducer) error {
+	query := r.Query()
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	if instanceID == "" || !query.snapshotable() {
+		return es.FilterToReducer(ctx, query, r)
+	}
+	aggregateType, aggregateID, model := r.SnapshotKey()
+	version := snapshotVersion(r)
+	logger := logging.WithFields("instance", instanceID, "aggregate", aggregateID, "model", model)
+
+	snapshot, err := es.repo.Snapshot(ctx, instanceID, repository.AggregateType(aggregateType), aggregateID, model, version)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	if snapshot != nil {
+		if err = restoreSnapshot(r, snapshot); err != nil {
+			logger.WithError(err).Warn("unable to restore snapshot")
+		} else {
+			query.sequenceGreater(snapshot.Sequence)
+		}
+	}
+
+	events, err := es.Filter(ctx, query)
+	if err != nil {
+		return err
+	}
+	r.AppendEvents(events...)
+	if err = r.Reduce(); err != nil {
+		return err
+	}
+
+	if len(events) == 0 || uint32(len(events)) < es.snapshots.Interval {
+		return nil
+	}
+	wm := r.writeModel()
+	// the reducer did not record the sequence of its events, so the snapshot could not be continued
+	if wm.ProcessedSequence == 0 {
+		return nil
+	}
+	// events of concurrent pushes with lower sequences are visible at the latest after the push timeout
+	if time.Since(wm.ChangeDate) < es.PushTimeout {
+		return nil
+	}
+	data, err := json.Marshal(r)
+	if err != nil {
+		logger.WithError(err).Warn("unable to marshal snapshot")
+		return nil
+	}
+	err = es.repo.StoreSnapshot(ctx, &repository.Snapshot{
+		InstanceID:    instanceID,
+		AggregateType: repository.AggregateType(aggregateType),
+		AggregateID:   aggregateID,
+		Model:         model,
+		Version:       version,
+		Sequence:      wm.ProcessedSequence,
+		ResourceOwner: wm.ResourceOwner,
+		ChangeDate:    wm.ChangeDate,
+		Data:          data,
+	})
+	logger.OnError(err).Warn("unable to store snapshot")
+	return nil
+}
+
+// restoreSnapshot sets the state of the snapshot on the reducer
+// the reducer stays untouched if the snapshot cannot be unmarshalled
+func restoreSnapshot(r SnapshotReducer, snapshot *repository.Snapshot) error {
+	if err := json.Unmarshal(snapshot.Data, reflect.New(reflect.TypeOf(r).Elem()).Interface()); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(snapshot.Data, r); err != nil {
+		return err
+	}
+	wm := r.writeModel()
+	if wm.AggregateID == "" {
+		wm.AggregateID = snapshot.AggregateID
+	}
+	if wm.InstanceID == "" {
+		wm.InstanceID = snapshot.InstanceID
+	}
+	if wm.ResourceOwner == "" {
+		wm.ResourceOwner = snapshot.ResourceOwner
+	}
+	wm.ProcessedSequence = snapshot.Sequence
+	wm.ChangeDate = snapshot.ChangeDate
+	return nil
+}
+
+var fingerprints sync.Map
+
+// snapshotVersion combines the version of the reducer with a fingerprint of its fields
+func snapshotVersion(r SnapshotReducer) string {
+	typ := reflect.TypeOf(r)
+	fingerprint, ok := fingerprints.Load(typ)
+	if !ok {
+		builder := new(strings.Builder)
+		writeTypeFingerprint(builder, typ, make(map[reflect.Type]bool))
+		hash := sha256.Sum256([]byte(builder.String()))
+		fingerprint = hex.EncodeToString(hash[:8])
+		fingerprints.Store(typ, fingerprint)
+	}
+	return strconv.Itoa(int(r.SnapshotVersion())) + "-" + fingerprint.(string)
+}
+
+func writeTypeFingerprint(builder *strings.Builder, typ reflect.Type, visited map[reflect.Type]bool) {
+	switch typ.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Array:
+		builder.WriteString(typ.Kind().String() + "[")
+		writeTypeFingerprint(builder, typ.Elem(), visited)
+		builder.WriteString("]")
+	case reflect.Map:
+		builder.WriteString("map[")
+		writeTypeFingerprint(builder, typ.Key(), visited)
+		builder.WriteString("]")
+		writeTypeFingerprint(builder, typ.Elem(), visited)
+	case reflect.Struct:
+		builder.WriteString(typ.String())
+		if visited[typ] {
+			return
+		}
+		visited[typ] = true
+		builder.WriteString("{")
+		for i := 0; i < typ.NumField(); i++ {
+			field := typ.Field(i)
+			if !field.IsExported() || field.Tag.Get("json") == "-" {
+				continue
+			}
+			fmt.Fprintf(builder, "%s %q ", field.Name, field.Tag.Get("json"))
+			writeTypeFingerprint(builder, field.Type, visited)
+			builder.WriteString(";")
+		}
+		builder.WriteString("}")
+	default:
+		builder.WriteString(typ.String())
+	}
+}
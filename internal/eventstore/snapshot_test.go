@@ -0,0 +1,41 @@
+package eventstore
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_writeTypeFingerprint(t *testing.T) {
+	type state struct {
+		Name    string
+		Domains []string `json:"domains"`
+		ignored int
+	}
+	type renamedField struct {
+		Name    string
+		Domains []string `json:"urls"`
+		ignored int
+	}
+	fingerprint := func(value interface{}) string {
+		builder := new(strings.Builder)
+		writeTypeFingerprint(builder, reflect.TypeOf(value), make(map[reflect.Type]bool))
+		return builder.String()
+	}
+	assert.Equal(t, fingerprint(new(state)), fingerprint(new(state)))
+	assert.NotContains(t, fingerprint(new(state)), "ignored")
+	assert.NotEqual(t, strings.TrimPrefix(fingerprint(new(state)), "ptr[eventstore.state"), strings.TrimPrefix(fingerprint(new(renamedField)), "ptr[eventstore.renamedField"))
+}
+
+func TestSearchQueryBuilder_snapshotable(t *testing.T) {
+	query := func() *SearchQueryBuilder {
+		return NewSearchQueryBuilder(ColumnsEvent).AddQuery().AggregateTypes("test").Builder()
+	}
+	assert.True(t, query().snapshotable())
+	assert.False(t, query().Limit(1).snapshotable())
+	assert.False(t, query().OrderDesc().snapshotable())
+	assert.False(t, NewSearchQueryBuilder(ColumnsMaxSequence).AddQuery().AggregateTypes("test").Builder().snapshotable())
+	assert.False(t, NewSearchQueryBuilder(ColumnsEvent).AddQuery().SequenceLess(10).Builder().snapshotable())
+}
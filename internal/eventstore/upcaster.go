@@ -0,0 +1,250 @@
+package eventstore
+
+import (
+	"context"
+	"sort"
+	"strconv"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+)
+
+const upcastBatchSize = 1000
+
+// SchemaVersion is the version of the payload of an event type.
+// It's stored per event and independent of the version of the aggregate,
+// events of event types without upcasters have version 0
+type SchemaVersion uint16
+
+// Upcaster transforms the payload of an event type from one schema version to the next.
+// Upcasters are applied on read by the eventstore and the v1 eventstore,
+// so the event mappers and v1 view models only have to handle the latest schema version.
+// New events are stored with the latest schema version of their event type.
+// They are registered next to the event mappers in RegisterEventMappers of the repository packages
+type Upcaster struct {
+	EventType EventType
+	// From is the schema version of the events the upcaster is applied to
+	From SchemaVersion
+	// To is the schema version of the events after the upcast, it must be greater than From
+	To SchemaVersion
+	// Upcast transforms the data of the event
+	Upcast func(data []byte) ([]byte, error)
+}
+
+// RegisterUpcaster registers the upcasters for transforming stored events to newer versions
+// the upcasters of an event type are chained by their versions
+func (es *Eventstore) RegisterUpcaster(upcasters ...*Upcaster) *Eventstore {
+	es.interceptorMutex.Lock()
+	defer es.interceptorMutex.Unlock()
+
+	for _, upcaster := range upcasters {
+		if upcaster == nil {
+			continue
+		}
+		if err := upcaster.validate(); err != nil {
+			logging.WithFields("eventType", upcaster.EventType).WithError(err).Warn("upcaster ignored")
+			continue
+		}
+		interceptor := es.eventInterceptors[upcaster.EventType]
+		if interceptor.upcasters == nil {
+			interceptor.upcasters = make(map[SchemaVersion]*Upcaster)
+		}
+		interceptor.upcasters[upcaster.From] = upcaster
+		es.eventInterceptors[upcaster.EventType] = interceptor
+	}
+	return es
+}
+
+// Upcasters returns the registered upcasters as `eventType:from:to` sorted by event type and schema version
+func (es *Eventstore) Upcasters() []string {
+	es.interceptorMutex.Lock()
+	defer es.interceptorMutex.Unlock()
+
+	upcasters := make([]string, 0)
+	for eventType, interceptor := range es.eventInterceptors {
+		for _, upcaster := range interceptor.upcasters {
+			upcasters = append(upcasters, string(eventType)+":"+upcaster.From.String()+":"+upcaster.To.String())
+		}
+	}
+	sort.Strings(upcasters)
+	return upcasters
+}
+
+// UpcastStoredEvents rewrites the stored events of all instances
+// which have registered upcasters to their latest schema version
+// and returns the count of the rewritten events
+func (es *Eventstore) UpcastStoredEvents(ctx context.Context) (upcasted int64, err error) {
+	eventTypes := es.upcastedEventTypes()
+	if len(eventTypes) == 0 {
+		return 0, nil
+	}
+	instanceIDs, err := es.InstanceIDs(ctx, NewSearchQueryBuilder(ColumnsInstanceIDs).
+		AddQuery().
+		ExcludedInstanceID("").
+		EventTypes(eventTypes...).
+		Builder())
+	if err != nil {
+		return 0, err
+	}
+	for _, instanceID := range instanceIDs {
+		count, err := es.upcastStoredEvents(ctx, instanceID, eventTypes)
+		upcasted += count
+		if err != nil {
+			return upcasted, err
+		}
+	}
+	return upcasted, nil
+}
+
+func (es *Eventstore) upcastStoredEvents(ctx context.Context, instanceID string, eventTypes []EventType) (upcasted int64, err error) {
+	var sequence uint64
+	for {
+		query, err := NewSearchQueryBuilder(ColumnsEvent).
+			Limit(upcastBatchSize).
+			AddQuery().
+			EventTypes(eventTypes...).
+			SequenceGreater(sequence).
+			Builder().
+			build(instanceID)
+		if err != nil {
+			return upcasted, err
+		}
+		events, err := es.repo.Filter(ctx, query)
+		if err != nil {
+			return upcasted, err
+		}
+		for _, event := range events {
+			sequence = event.Sequence
+			upcastedEvent, err := es.upcast(event)
+			if err != nil {
+				return upcasted, err
+			}
+			if upcastedEvent == event {
+				continue
+			}
+			if err = es.repo.UpdateEventSchemaVersion(ctx, event.InstanceID, event.Sequence, upcastedEvent.SchemaVersion, upcastedEvent.Data); err != nil {
+				return upcasted, err
+			}
+			upcasted++
+		}
+		if len(events) < upcastBatchSize {
+			return upcasted, nil
+		}
+	}
+}
+
+func (es *Eventstore) upcastedEventTypes() []EventType {
+	es.interceptorMutex.Lock()
+	defer es.interceptorMutex.Unlock()
+
+	eventTypes := make([]EventType, 0)
+	for eventType, interceptor := range es.eventInterceptors {
+		if len(interceptor.upcasters) > 0 {
+			eventTypes = append(eventTypes, eventType)
+		}
+	}
+	sort.Slice(eventTypes, func(i, j int) bool {
+		return eventTypes[i] < eventTypes[j]
+	})
+	return eventTypes
+}
+
+// Upcast applies the registered upcasters to the data of a stored event
+// and returns the latest schema version and its data
+func (es *Eventstore) Upcast(eventType EventType, schemaVersion SchemaVersion, data []byte) (SchemaVersion, []byte, error) {
+	upcasted, err := es.upcast(&repository.Event{
+		Type:          repository.EventType(eventType),
+		SchemaVersion: uint16(schemaVersion),
+		Data:          data,
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+	return SchemaVersion(upcasted.SchemaVersion), upcasted.Data, nil
+}
+
+// UpcastV1Events applies the registered upcasters to the events read by the v1 eventstore,
+// the events are changed in place
+func (es *Eventstore) UpcastV1Events(events []*models.Event) error {
+	es.interceptorMutex.Lock()
+	defer es.interceptorMutex.Unlock()
+	for _, event := range events {
+		upcasted, err := es.eventInterceptors[EventType(event.Type)].upcast(&repository.Event{
+			Type:          repository.EventType(event.Type),
+			SchemaVersion: event.SchemaVersion,
+			Data:          event.Data,
+		})
+		if err != nil {
+			return err
+		}
+		event.SchemaVersion = upcasted.SchemaVersion
+		event.Data = upcasted.Data
+	}
+	return nil
+}
+
+func (es *Eventstore) upcast(event *repository.Event) (*repository.Event, error) {
+	es.interceptorMutex.Lock()
+	defer es.interceptorMutex.Unlock()
+	return es.eventInterceptors[EventType(event.Type)].upcast(event)
+}
+
+// setSchemaVersions sets the latest schema version of their event types on the events to push
+func (es *Eventstore) setSchemaVersions(events []*repository.Event) {
+	es.interceptorMutex.Lock()
+	defer es.interceptorMutex.Unlock()
+	for _, event := range events {
+		event.SchemaVersion = uint16(es.eventInterceptors[EventType(event.Type)].latestSchemaVersion())
+	}
+}
+
+// upcast applies the upcasters of the event type until no upcaster for the schema version is registered,
+// the event is returned unchanged if no upcaster applies
+func (interceptors eventTypeInterceptors) upcast(event *repository.Event) (*repository.Event, error) {
+	version := SchemaVersion(event.SchemaVersion)
+	upcaster, ok := interceptors.upcasters[version]
+	if !ok {
+		return event, nil
+	}
+	data := event.Data
+	// the upcasters increase the schema version, so the chain ends
+	for ; ok; upcaster, ok = interceptors.upcasters[version] {
+		var err error
+		data, err = upcaster.Upcast(data)
+		if err != nil {
+			return nil, errors.ThrowInternalf(err, "V2-Up7cs", "unable to upcast %s from %d to %d", event.Type, upcaster.From, upcaster.To)
+		}
+		version = upcaster.To
+	}
+	upcasted := *event
+	upcasted.SchemaVersion = uint16(version)
+	upcasted.Data = data
+	return &upcasted, nil
+}
+
+// latestSchemaVersion returns the schema version the upcasters of the event type end with
+func (interceptors eventTypeInterceptors) latestSchemaVersion() (latest SchemaVersion) {
+	for _, upcaster := range interceptors.upcasters {
+		if upcaster.To > latest {
+			latest = upcaster.To
+		}
+	}
+	return latest
+}
+
+func (upcaster *Upcaster) validate() error {
+	if upcaster.EventType == "" || upcaster.Upcast == nil {
+		return errors.ThrowPreconditionFailed(nil, "V2-Ks8ud", "upcaster incomplete")
+	}
+	if upcaster.To <= upcaster.From {
+		return errors.ThrowPreconditionFailed(nil, "V2-Px3ma", "upcaster must increase the schema version")
+	}
+	return nil
+}
+
+func (v SchemaVersion) String() string {
+	return strconv.Itoa(int(v))
+}
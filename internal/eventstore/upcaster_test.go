@@ -0,0 +1,188 @@
+package eventstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+)
+
+func renameField(from, to string) func([]byte) ([]byte, error) {
+	return func(data []byte) ([]byte, error) {
+		payload := make(map[string]interface{})
+		if err := json.Unmarshal(data, &payload); err != nil {
+			return nil, err
+		}
+		payload[to] = payload[from]
+		delete(payload, from)
+		return json.Marshal(payload)
+	}
+}
+
+func testUpcasters() []*Upcaster {
+	return []*Upcaster{
+		{EventType: "test.event", From: 0, To: 1, Upcast: renameField("name", "userName")},
+		{EventType: "test.event", From: 1, To: 2, Upcast: renameField("userName", "username")},
+		{EventType: "test.failing", From: 0, To: 1, Upcast: func([]byte) ([]byte, error) { return nil, fmt.Errorf("failed") }},
+	}
+}
+
+func TestEventstore_Upcast(t *testing.T) {
+	es := NewEventstore(TestConfig(nil)).RegisterUpcaster(testUpcasters()...)
+	tests := []struct {
+		name        string
+		eventType   EventType
+		version     SchemaVersion
+		data        string
+		wantVersion SchemaVersion
+		wantData    string
+		wantErr     bool
+	}{
+		{
+			name:        "chained",
+			eventType:   "test.event",
+			version:     0,
+			data:        `{"name":"gigi"}`,
+			wantVersion: 2,
+			wantData:    `{"username":"gigi"}`,
+		},
+		{
+			name:        "partially upcasted",
+			eventType:   "test.event",
+			version:     1,
+			data:        `{"userName":"gigi"}`,
+			wantVersion: 2,
+			wantData:    `{"username":"gigi"}`,
+		},
+		{
+			name:        "latest version",
+			eventType:   "test.event",
+			version:     2,
+			data:        `{"username":"gigi"}`,
+			wantVersion: 2,
+			wantData:    `{"username":"gigi"}`,
+		},
+		{
+			name:        "no upcasters",
+			eventType:   "test.other",
+			version:     0,
+			data:        `{"name":"gigi"}`,
+			wantVersion: 0,
+			wantData:    `{"name":"gigi"}`,
+		},
+		{
+			name:      "upcast fails",
+			eventType: "test.failing",
+			version:   0,
+			data:      `{}`,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version, data, err := es.Upcast(tt.eventType, tt.version, []byte(tt.data))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantVersion, version)
+			assert.JSONEq(t, tt.wantData, string(data))
+		})
+	}
+}
+
+func TestEventstore_RegisterUpcaster_invalid(t *testing.T) {
+	es := NewEventstore(TestConfig(nil)).RegisterUpcaster(
+		nil,
+		&Upcaster{EventType: "test.event", From: 0, To: 1},
+		&Upcaster{EventType: "test.event", From: 1, To: 1, Upcast: renameField("a", "b")},
+		&Upcaster{EventType: "test.event", From: 2, To: 1, Upcast: renameField("b", "a")},
+	)
+	assert.Empty(t, es.Upcasters())
+}
+
+func TestEventstore_mapEvents_upcasted(t *testing.T) {
+	var mapped *repository.Event
+	es := NewEventstore(TestConfig(nil)).
+		RegisterFilterEventMapper("test", "test.event", func(event *repository.Event) (Event, error) {
+			mapped = event
+			return BaseEventFromRepo(event), nil
+		}).
+		RegisterUpcaster(testUpcasters()...)
+
+	stored := &repository.Event{Type: "test.event", Version: "v1", SchemaVersion: 0, Data: []byte(`{"name":"gigi"}`)}
+	_, err := es.mapEvents([]*repository.Event{stored})
+	require.NoError(t, err)
+	assert.Equal(t, uint16(2), mapped.SchemaVersion)
+	assert.Equal(t, repository.Version("v1"), mapped.Version, "aggregate version must not be changed")
+	assert.JSONEq(t, `{"username":"gigi"}`, string(mapped.Data))
+	assert.Equal(t, uint16(0), stored.SchemaVersion, "stored event must not be changed")
+
+	_, err = es.mapEvents([]*repository.Event{{Type: "test.failing", Version: "v1", Data: []byte(`{}`)}})
+	assert.Error(t, err)
+}
+
+func TestEventstore_UpcastV1Events(t *testing.T) {
+	es := NewEventstore(TestConfig(nil)).RegisterUpcaster(testUpcasters()...)
+
+	events := []*models.Event{
+		{Type: "test.event", AggregateVersion: "v1", SchemaVersion: 0, Data: []byte(`{"name":"gigi"}`)},
+		{Type: "test.event", AggregateVersion: "v1", SchemaVersion: 2, Data: []byte(`{"username":"gigi"}`)},
+		{Type: "test.other", AggregateVersion: "v1", Data: []byte(`{"name":"gigi"}`)},
+	}
+	require.NoError(t, es.UpcastV1Events(events))
+	for _, event := range events[:2] {
+		assert.Equal(t, uint16(2), event.SchemaVersion)
+		assert.Equal(t, models.Version("v1"), event.AggregateVersion, "aggregate version must not be changed")
+		assert.JSONEq(t, `{"username":"gigi"}`, string(event.Data))
+	}
+	assert.Equal(t, uint16(0), events[2].SchemaVersion)
+	assert.JSONEq(t, `{"name":"gigi"}`, string(events[2].Data))
+
+	assert.Error(t, es.UpcastV1Events([]*models.Event{{Type: "test.failing", Data: []byte(`{}`)}}))
+}
+
+func TestEventstore_UpcastStoredEvents(t *testing.T) {
+	repo := &testRepo{
+		t:         t,
+		instances: []string{"instanceID"},
+		events: []*repository.Event{
+			{Type: "test.event", Version: "v1", SchemaVersion: 0, Sequence: 1, InstanceID: "instanceID", Data: []byte(`{"name":"gigi"}`)},
+			{Type: "test.event", Version: "v1", SchemaVersion: 2, Sequence: 2, InstanceID: "instanceID", Data: []byte(`{"username":"gigi"}`)},
+			{Type: "test.event", Version: "v1", SchemaVersion: 1, Sequence: 3, InstanceID: "instanceID", Data: []byte(`{"userName":"gigi"}`)},
+		},
+	}
+	es := NewEventstore(TestConfig(repo)).RegisterUpcaster(testUpcasters()[:2]...)
+
+	upcasted, err := es.UpcastStoredEvents(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), upcasted)
+	require.Len(t, repo.updated, 2)
+	for i, sequence := range []uint64{1, 3} {
+		assert.Equal(t, sequence, repo.updated[i].Sequence)
+		assert.Equal(t, uint16(2), repo.updated[i].SchemaVersion)
+		assert.JSONEq(t, `{"username":"gigi"}`, string(repo.updated[i].Data))
+	}
+	assert.Equal(t, []string{"test.event:0:1", "test.event:1:2"}, es.Upcasters())
+}
+
+func TestEventstore_setSchemaVersions(t *testing.T) {
+	es := NewEventstore(TestConfig(nil)).RegisterUpcaster(testUpcasters()...)
+	events := []*repository.Event{
+		{Type: "test.event", Version: "v1"},
+		{Type: "test.failing", Version: "v1"},
+		{Type: "test.other", Version: "v1"},
+	}
+	es.setSchemaVersions(events)
+	for i, want := range []uint16{2, 1, 0} {
+		assert.Equal(t, want, events[i].SchemaVersion)
+		assert.Equal(t, repository.Version("v1"), events[i].Version)
+	}
+}
@@ -0,0 +1,47 @@
+// Package upcastertest provides helpers for testing upcasters of events
+package upcastertest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+// Test describes the expected result of reading a stored event with the registered upcasters
+type Test struct {
+	Name      string
+	EventType eventstore.EventType
+	// SchemaVersion and Data describe the stored event
+	SchemaVersion eventstore.SchemaVersion
+	Data          string
+	// WantSchemaVersion and WantData describe the event passed to the event mapper,
+	// the data is compared as json
+	WantSchemaVersion eventstore.SchemaVersion
+	WantData          string
+	WantErr           bool
+}
+
+// NewEventstore creates an eventstore without repository which only knows the given upcasters
+func NewEventstore(upcasters ...*eventstore.Upcaster) *eventstore.Eventstore {
+	return eventstore.NewEventstore(eventstore.TestConfig(nil)).RegisterUpcaster(upcasters...)
+}
+
+// Run runs the tests against the upcasters registered on the eventstore
+func Run(t *testing.T, es *eventstore.Eventstore, tests ...Test) {
+	t.Helper()
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			version, data, err := es.Upcast(tt.EventType, tt.SchemaVersion, []byte(tt.Data))
+			if tt.WantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.WantSchemaVersion, version)
+			assert.JSONEq(t, tt.WantData, string(data))
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package upcastertest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+func TestRun(t *testing.T) {
+	es := NewEventstore(&eventstore.Upcaster{
+		EventType: "test.event",
+		From:      0,
+		To:        1,
+		Upcast: func(data []byte) ([]byte, error) {
+			return []byte(strings.ReplaceAll(string(data), `"name"`, `"username"`)), nil
+		},
+	})
+	Run(t, es,
+		Test{
+			Name:              "upcasted",
+			EventType:         "test.event",
+			SchemaVersion:     0,
+			Data:              `{"name":"gigi"}`,
+			WantSchemaVersion: 1,
+			WantData:          `{"username":"gigi"}`,
+		},
+		Test{
+			Name:              "latest",
+			EventType:         "test.event",
+			SchemaVersion:     1,
+			Data:              `{"username":"gigi"}`,
+			WantSchemaVersion: 1,
+			WantData:          `{"username":"gigi"}`,
+		},
+	)
+}
@@ -16,15 +16,23 @@ type Eventstore interface {
 	InstanceIDs(ctx context.Context, searchQuery *models.SearchQuery) ([]string, error)
 }
 
+// Upcaster applies the upcasters registered on the eventstore v2 to the events read by the v1 eventstore,
+// so the handlers and view models only have to handle the latest schema version of the event types
+type Upcaster interface {
+	UpcastV1Events(events []*models.Event) error
+}
+
 var _ Eventstore = (*eventstore)(nil)
 
 type eventstore struct {
-	repo repository.Repository
+	repo     repository.Repository
+	upcaster Upcaster
 }
 
-func Start(db *database.DB, allowOrderByCreationDate bool) (Eventstore, error) {
+func Start(db *database.DB, allowOrderByCreationDate bool, upcaster Upcaster) (Eventstore, error) {
 	return &eventstore{
-		repo: z_sql.Start(db, allowOrderByCreationDate),
+		repo:     z_sql.Start(db, allowOrderByCreationDate),
+		upcaster: upcaster,
 	}, nil
 }
 
@@ -32,7 +40,14 @@ func (es *eventstore) FilterEvents(ctx context.Context, searchQuery *models.Sear
 	if err := searchQuery.Validate(); err != nil {
 		return nil, err
 	}
-	return es.repo.Filter(ctx, models.FactoryFromSearchQuery(searchQuery))
+	events, err := es.repo.Filter(ctx, models.FactoryFromSearchQuery(searchQuery))
+	if err != nil || es.upcaster == nil {
+		return events, err
+	}
+	if err = es.upcaster.UpcastV1Events(events); err != nil {
+		return nil, err
+	}
+	return events, nil
 }
 
 func (es *eventstore) Health(ctx context.Context) error {
@@ -30,6 +30,7 @@ const (
 		", aggregate_type" +
 		", aggregate_id" +
 		", aggregate_version" +
+		", event_schema_version" +
 		" FROM eventstore.events"
 )
 
@@ -150,6 +151,7 @@ func prepareColumns(columns es_models.Columns) (string, func(s scan, dest interf
 				&event.AggregateType,
 				&event.AggregateID,
 				&event.AggregateVersion,
+				&event.SchemaVersion,
 			)
 
 			if err != nil {
@@ -21,6 +21,8 @@ type Event struct {
 	Type             EventType
 	PreviousSequence uint64
 	Data             []byte
+	// SchemaVersion is the version of the payload of the event type
+	SchemaVersion uint16
 
 	AggregateID      string
 	AggregateType    AggregateType
@@ -45,3 +45,7 @@ func (wm *WriteModel) Reduce() error {
 	wm.Events = []Event{}
 	return nil
 }
+
+func (wm *WriteModel) writeModel() *WriteModel {
+	return wm
+}
@@ -0,0 +1,150 @@
+package ldap
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+// DirectoryUser is a user found by searching the whole directory (instead of authenticating a single user)
+// it additionally provides the DN and the raw values of the requested attributes
+type DirectoryUser struct {
+	*User
+	DN    string
+	entry *ldap.Entry
+}
+
+// GetAttributeValues returns all values of the attribute, if it was requested on the search
+func (u *DirectoryUser) GetAttributeValues(attribute string) []string {
+	return u.entry.GetAttributeValues(attribute)
+}
+
+// SearchUsers pages through all users of the configured base DN matching the object classes and user filters
+// the additional attributes (e.g. for metadata or group memberships) are requested besides the mapped ones
+func (p *Provider) SearchUsers(ctx context.Context, pageSize uint32, attributes ...string) ([]*DirectoryUser, error) {
+	return p.searchUsers(ctx, pageSize, "", attributes)
+}
+
+// SearchUsersMatching pages through the users found by SearchUsers, which additionally match the filter
+// e.g. the users disabled in the directory
+func (p *Provider) SearchUsersMatching(ctx context.Context, pageSize uint32, filter string) ([]*DirectoryUser, error) {
+	return p.searchUsers(ctx, pageSize, filter, nil)
+}
+
+func (p *Provider) searchUsers(ctx context.Context, pageSize uint32, filter string, attributes []string) (users []*DirectoryUser, err error) {
+	var entries []*ldap.Entry
+	for _, server := range p.servers {
+		if err = ctx.Err(); err != nil {
+			return nil, err
+		}
+		entries, err = trySearchUsers(server,
+			p.startTLS,
+			p.bindDN,
+			p.bindPassword,
+			p.baseDN,
+			append(p.getNecessaryAttributes(), attributes...),
+			usersSearchQuery(p.userObjectClasses, p.userFilters, filter),
+			pageSize,
+			p.timeout,
+		)
+		// if the search was successful on a server the directory is complete, otherwise try the next one
+		if err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	users = make([]*DirectoryUser, len(entries))
+	for i, entry := range entries {
+		user, err := mapLDAPEntryToUser(
+			entry,
+			p.idAttribute,
+			p.firstNameAttribute,
+			p.lastNameAttribute,
+			p.displayNameAttribute,
+			p.nickNameAttribute,
+			p.preferredUsernameAttribute,
+			p.emailAttribute,
+			p.emailVerifiedAttribute,
+			p.phoneAttribute,
+			p.phoneVerifiedAttribute,
+			p.preferredLanguageAttribute,
+			p.avatarURLAttribute,
+			p.profileAttribute,
+		)
+		if err != nil {
+			return nil, err
+		}
+		users[i] = &DirectoryUser{User: user, DN: entry.DN, entry: entry}
+	}
+	return users, nil
+}
+
+func trySearchUsers(
+	server string,
+	startTLS bool,
+	bindDN string,
+	bindPassword string,
+	baseDN string,
+	attributes []string,
+	searchQuery string,
+	pageSize uint32,
+	timeout time.Duration,
+) ([]*ldap.Entry, error) {
+	conn, err := getConnection(server, startTLS, timeout)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if err := conn.Bind(bindDN, bindPassword); err != nil {
+		return nil, err
+	}
+
+	searchRequest := ldap.NewSearchRequest(
+		baseDN,
+		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, int(timeout.Seconds()), false,
+		searchQuery,
+		attributes,
+		nil,
+	)
+	sr, err := conn.SearchWithPaging(searchRequest, pageSize)
+	if err != nil {
+		return nil, err
+	}
+	return sr.Entries, nil
+}
+
+// usersSearchQuery matches all entries of the object classes, which have at least one of the user filter attributes set
+// and match the additional filter, if any
+func usersSearchQuery(objectClasses []string, userFilters []string, additionalFilter string) string {
+	queries := make([]string, 0, 3)
+	if classes := objectClassesToSearchQuery(objectClasses); classes != "" {
+		queries = append(queries, classes)
+	}
+	if filters := queriesOrToSearchQuery(userFiltersToPresentQueries(userFilters)...); filters != "" {
+		queries = append(queries, filters)
+	}
+	if additionalFilter != "" {
+		queries = append(queries, additionalFilter)
+	}
+	if len(queries) == 0 {
+		return "(objectClass=*)"
+	}
+	if len(queries) == 1 && len(objectClasses) <= 1 {
+		return queries[0]
+	}
+	return "(&" + strings.Join(queries, "") + ")"
+}
+
+func userFiltersToPresentQueries(filters []string) []string {
+	queries := make([]string, len(filters))
+	for i, filter := range filters {
+		queries[i] = "(" + filter + "=*)"
+	}
+	return queries
+}
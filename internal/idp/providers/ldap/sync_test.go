@@ -0,0 +1,86 @@
+package ldap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProvider_usersSearchQuery(t *testing.T) {
+	type args struct {
+		objectClasses    []string
+		userFilters      []string
+		additionalFilter string
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "zero",
+			args: args{},
+			want: "(objectClass=*)",
+		},
+		{
+			name: "one object class",
+			args: args{
+				objectClasses: []string{"user"},
+			},
+			want: "(objectClass=user)",
+		},
+		{
+			name: "multiple object classes",
+			args: args{
+				objectClasses: []string{"user", "person"},
+			},
+			want: "(&(objectClass=user)(objectClass=person))",
+		},
+		{
+			name: "multiple user filters",
+			args: args{
+				userFilters: []string{"uid", "mail"},
+			},
+			want: "(|(uid=*)(mail=*))",
+		},
+		{
+			name: "object class and user filter",
+			args: args{
+				objectClasses: []string{"user"},
+				userFilters:   []string{"uid"},
+			},
+			want: "(&(objectClass=user)(uid=*))",
+		},
+		{
+			name: "object classes and user filters",
+			args: args{
+				objectClasses: []string{"user", "person"},
+				userFilters:   []string{"uid", "mail"},
+			},
+			want: "(&(objectClass=user)(objectClass=person)(|(uid=*)(mail=*)))",
+		},
+		{
+			name: "additional filter",
+			args: args{
+				additionalFilter: "(disabled=TRUE)",
+			},
+			want: "(disabled=TRUE)",
+		},
+		{
+			name: "object class, user filter and additional filter",
+			args: args{
+				objectClasses:    []string{"user"},
+				userFilters:      []string{"uid"},
+				additionalFilter: "(disabled=TRUE)",
+			},
+			want: "(&(objectClass=user)(uid=*)(disabled=TRUE))",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+
+			a.Equal(tt.want, usersSearchQuery(tt.args.objectClasses, tt.args.userFilters, tt.args.additionalFilter))
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package idpsync
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/query/projection"
+)
+
+type Config struct {
+	// PageSize is the number of entries requested per page when searching the directory
+	PageSize uint32
+}
+
+// Start runs the due synchronizations of all instances in the background
+func Start(ctx context.Context, customConfig projection.CustomConfig, syncer *Syncer) {
+	newWorker(projection.ApplyCustomConfig(customConfig), syncer, syncer.queries).Start(ctx)
+}
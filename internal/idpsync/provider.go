@@ -0,0 +1,73 @@
+package idpsync
+
+import (
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/idp/providers/ldap"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+// ldapProvider creates the provider of the LDAP IDP,
+// as the directory is only searched no login url is required
+func (s *Syncer) ldapProvider(identityProvider *query.IDPTemplate) (*ldap.Provider, error) {
+	password, err := crypto.DecryptString(identityProvider.LDAPIDPTemplate.BindPassword, s.idpConfigEncryption)
+	if err != nil {
+		return nil, err
+	}
+	var opts []ldap.ProviderOpts
+	if !identityProvider.LDAPIDPTemplate.StartTLS {
+		opts = append(opts, ldap.WithoutStartTLS())
+	}
+	attributes := identityProvider.LDAPIDPTemplate.LDAPAttributes
+	if attributes.IDAttribute != "" {
+		opts = append(opts, ldap.WithCustomIDAttribute(attributes.IDAttribute))
+	}
+	if attributes.FirstNameAttribute != "" {
+		opts = append(opts, ldap.WithFirstNameAttribute(attributes.FirstNameAttribute))
+	}
+	if attributes.LastNameAttribute != "" {
+		opts = append(opts, ldap.WithLastNameAttribute(attributes.LastNameAttribute))
+	}
+	if attributes.DisplayNameAttribute != "" {
+		opts = append(opts, ldap.WithDisplayNameAttribute(attributes.DisplayNameAttribute))
+	}
+	if attributes.NickNameAttribute != "" {
+		opts = append(opts, ldap.WithNickNameAttribute(attributes.NickNameAttribute))
+	}
+	if attributes.PreferredUsernameAttribute != "" {
+		opts = append(opts, ldap.WithPreferredUsernameAttribute(attributes.PreferredUsernameAttribute))
+	}
+	if attributes.EmailAttribute != "" {
+		opts = append(opts, ldap.WithEmailAttribute(attributes.EmailAttribute))
+	}
+	if attributes.EmailVerifiedAttribute != "" {
+		opts = append(opts, ldap.WithEmailVerifiedAttribute(attributes.EmailVerifiedAttribute))
+	}
+	if attributes.PhoneAttribute != "" {
+		opts = append(opts, ldap.WithPhoneAttribute(attributes.PhoneAttribute))
+	}
+	if attributes.PhoneVerifiedAttribute != "" {
+		opts = append(opts, ldap.WithPhoneVerifiedAttribute(attributes.PhoneVerifiedAttribute))
+	}
+	if attributes.PreferredLanguageAttribute != "" {
+		opts = append(opts, ldap.WithPreferredLanguageAttribute(attributes.PreferredLanguageAttribute))
+	}
+	if attributes.AvatarURLAttribute != "" {
+		opts = append(opts, ldap.WithAvatarURLAttribute(attributes.AvatarURLAttribute))
+	}
+	if attributes.ProfileAttribute != "" {
+		opts = append(opts, ldap.WithProfileAttribute(attributes.ProfileAttribute))
+	}
+	return ldap.New(
+		identityProvider.Name,
+		identityProvider.Servers,
+		identityProvider.BaseDN,
+		identityProvider.BindDN,
+		password,
+		identityProvider.UserBase,
+		identityProvider.UserObjectClasses,
+		identityProvider.UserFilters,
+		identityProvider.Timeout,
+		"",
+		opts...,
+	), nil
+}
@@ -0,0 +1,110 @@
+package idpsync
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+// desiredRoles maps the groups of a user to the roles per project according to the rules,
+// a project is only contained if at least one rule matched (even without roles)
+func desiredRoles(rules []*domain.LDAPSyncGroupRule, groups []string) map[string][]string {
+	roles := make(map[string][]string)
+	for _, rule := range rules {
+		if !isMember(rule.Group, groups) {
+			continue
+		}
+		roles[rule.ProjectID] = appendRoles(roles[rule.ProjectID], rule.RoleKeys...)
+	}
+	for projectID := range roles {
+		sort.Strings(roles[projectID])
+	}
+	return roles
+}
+
+// isMember compares the groups case-insensitive as distinguished names are
+func isMember(group string, groups []string) bool {
+	for _, g := range groups {
+		if strings.EqualFold(g, group) {
+			return true
+		}
+	}
+	return false
+}
+
+func appendRoles(roles []string, keys ...string) []string {
+	if roles == nil {
+		roles = []string{}
+	}
+	for _, key := range keys {
+		if !contains(roles, key) {
+			roles = append(roles, key)
+		}
+	}
+	return roles
+}
+
+func contains(roles []string, key string) bool {
+	for _, role := range roles {
+		if role == key {
+			return true
+		}
+	}
+	return false
+}
+
+// ruleProjects returns the distinct projects of the rules in order of their first occurrence
+func ruleProjects(rules []*domain.LDAPSyncGroupRule) []string {
+	projects := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		if !contains(projects, rule.ProjectID) {
+			projects = append(projects, rule.ProjectID)
+		}
+	}
+	return projects
+}
+
+func sameRoles(desired, granted []string) bool {
+	if len(desired) != len(granted) {
+		return false
+	}
+	for _, role := range granted {
+		if !contains(desired, role) {
+			return false
+		}
+	}
+	return true
+}
+
+// metadataChanges returns the metadata to set and the keys of the synced attributes to remove,
+// metadata of keys not synced is left untouched
+func metadataChanges(existing []*query.UserMetadata, synced []*domain.Metadata, attributes []string) (set []*domain.Metadata, remove []string) {
+	current := make(map[string][]byte, len(existing))
+	for _, metadata := range existing {
+		current[metadata.Key] = metadata.Value
+	}
+	for _, metadata := range synced {
+		if value, ok := current[metadata.Key]; !ok || !bytes.Equal(value, metadata.Value) {
+			set = append(set, metadata)
+		}
+	}
+	for _, attribute := range attributes {
+		if _, ok := current[attribute]; !ok || hasMetadata(synced, attribute) {
+			continue
+		}
+		remove = append(remove, attribute)
+	}
+	return set, remove
+}
+
+func hasMetadata(metadata []*domain.Metadata, key string) bool {
+	for _, m := range metadata {
+		if m.Key == key {
+			return true
+		}
+	}
+	return false
+}
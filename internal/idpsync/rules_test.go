@@ -0,0 +1,148 @@
+package idpsync
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+func Test_desiredRoles(t *testing.T) {
+	rules := []*domain.LDAPSyncGroupRule{
+		{Group: "cn=admins,dc=example,dc=com", ProjectID: "project1", RoleKeys: []string{"admin", "user"}},
+		{Group: "cn=users,dc=example,dc=com", ProjectID: "project1", RoleKeys: []string{"user"}},
+		{Group: "cn=users,dc=example,dc=com", ProjectID: "project2"},
+	}
+	tests := []struct {
+		name   string
+		groups []string
+		want   map[string][]string
+	}{
+		{
+			name:   "no groups",
+			groups: nil,
+			want:   map[string][]string{},
+		},
+		{
+			name:   "unknown group",
+			groups: []string{"cn=guests,dc=example,dc=com"},
+			want:   map[string][]string{},
+		},
+		{
+			name:   "member of one group",
+			groups: []string{"cn=users,dc=example,dc=com"},
+			want: map[string][]string{
+				"project1": {"user"},
+				"project2": {},
+			},
+		},
+		{
+			name:   "member of multiple groups, roles merged",
+			groups: []string{"CN=Users,DC=example,DC=com", "cn=admins,dc=example,dc=com"},
+			want: map[string][]string{
+				"project1": {"admin", "user"},
+				"project2": {},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, desiredRoles(rules, tt.groups))
+		})
+	}
+}
+
+func Test_sameRoles(t *testing.T) {
+	tests := []struct {
+		name    string
+		desired []string
+		granted []string
+		want    bool
+	}{
+		{
+			name: "both empty",
+			want: true,
+		},
+		{
+			name:    "same order",
+			desired: []string{"admin", "user"},
+			granted: []string{"admin", "user"},
+			want:    true,
+		},
+		{
+			name:    "different order",
+			desired: []string{"admin", "user"},
+			granted: []string{"user", "admin"},
+			want:    true,
+		},
+		{
+			name:    "role missing",
+			desired: []string{"admin", "user"},
+			granted: []string{"user"},
+			want:    false,
+		},
+		{
+			name:    "different role",
+			desired: []string{"admin"},
+			granted: []string{"user"},
+			want:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, sameRoles(tt.desired, tt.granted))
+		})
+	}
+}
+
+func Test_metadataChanges(t *testing.T) {
+	type res struct {
+		set    []*domain.Metadata
+		remove []string
+	}
+	tests := []struct {
+		name       string
+		existing   []*query.UserMetadata
+		synced     []*domain.Metadata
+		attributes []string
+		res        res
+	}{
+		{
+			name:       "unchanged",
+			existing:   []*query.UserMetadata{{Key: "department", Value: []byte("IT")}},
+			synced:     []*domain.Metadata{{Key: "department", Value: []byte("IT")}},
+			attributes: []string{"department"},
+			res:        res{},
+		},
+		{
+			name:       "added and changed",
+			existing:   []*query.UserMetadata{{Key: "department", Value: []byte("IT")}},
+			synced:     []*domain.Metadata{{Key: "department", Value: []byte("HR")}, {Key: "location", Value: []byte("Zurich")}},
+			attributes: []string{"department", "location"},
+			res: res{
+				set: []*domain.Metadata{{Key: "department", Value: []byte("HR")}, {Key: "location", Value: []byte("Zurich")}},
+			},
+		},
+		{
+			name: "attribute without value removed, other metadata untouched",
+			existing: []*query.UserMetadata{
+				{Key: "department", Value: []byte("IT")},
+				{Key: "custom", Value: []byte("value")},
+			},
+			synced:     nil,
+			attributes: []string{"department", "location"},
+			res: res{
+				remove: []string{"department"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set, remove := metadataChanges(tt.existing, tt.synced, tt.attributes)
+			assert.Equal(t, tt.res.set, set)
+			assert.Equal(t, tt.res.remove, remove)
+		})
+	}
+}
@@ -0,0 +1,565 @@
+package idpsync
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/idp/providers/ldap"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+// SyncUserID is set as editor of all changes made by the synchronization
+const SyncUserID = "IDP_SYNC"
+
+// metadataValueSeparator joins multiple values of a metadata attribute
+const metadataValueSeparator = ","
+
+// Syncer synchronizes the users linked to an LDAP IDP with the directory
+type Syncer struct {
+	commands            *command.Commands
+	queries             *query.Queries
+	idpConfigEncryption crypto.EncryptionAlgorithm
+	userCodeAlg         crypto.EncryptionAlgorithm
+	pageSize            uint32
+}
+
+func NewSyncer(
+	commands *command.Commands,
+	queries *query.Queries,
+	idpConfigEncryption,
+	userCodeAlg crypto.EncryptionAlgorithm,
+	pageSize uint32,
+) *Syncer {
+	return &Syncer{
+		commands:            commands,
+		queries:             queries,
+		idpConfigEncryption: idpConfigEncryption,
+		userCodeAlg:         userCodeAlg,
+		pageSize:            pageSize,
+	}
+}
+
+// Run synchronizes the users of the LDAP IDP owned by the resourceOwner (instance or organization),
+// on a dry run the changes are only reported and not executed.
+// Either way the run is added to the history of the sync.
+func (s *Syncer) Run(ctx context.Context, resourceOwner, idpID string, dryRun bool) (*domain.LDAPSyncReport, error) {
+	report := &domain.LDAPSyncReport{
+		DryRun:    dryRun,
+		StartedAt: time.Now(),
+	}
+	runErr := s.run(syncContext(ctx, resourceOwner), resourceOwner, idpID, report)
+	if _, err := s.commands.AddLDAPSyncRun(ctx, resourceOwner, idpID, report, runErr); err != nil {
+		return nil, err
+	}
+	if runErr != nil {
+		return nil, runErr
+	}
+	return report, nil
+}
+
+func syncContext(ctx context.Context, resourceOwner string) context.Context {
+	return authz.SetCtxData(ctx, authz.CtxData{UserID: SyncUserID, OrgID: resourceOwner})
+}
+
+type userSync struct {
+	*Syncer
+	config *query.IDPLDAPSync
+	report *domain.LDAPSyncReport
+
+	initCodeGenerator         crypto.Generator
+	emailCodeGenerator        crypto.Generator
+	phoneCodeGenerator        crypto.Generator
+	passwordlessCodeGenerator crypto.Generator
+}
+
+func (s *Syncer) run(ctx context.Context, resourceOwner, idpID string, report *domain.LDAPSyncReport) error {
+	syncOwnerQuery, err := query.NewIDPLDAPSyncResourceOwnerSearchQuery(resourceOwner)
+	if err != nil {
+		return err
+	}
+	config, err := s.queries.IDPLDAPSyncByID(ctx, true, idpID, syncOwnerQuery)
+	if err != nil {
+		return err
+	}
+	templateOwnerQuery, err := query.NewIDPTemplateResourceOwnerSearchQuery(resourceOwner)
+	if err != nil {
+		return err
+	}
+	template, err := s.queries.IDPTemplateByID(ctx, true, idpID, false, templateOwnerQuery)
+	if err != nil {
+		return err
+	}
+	if template.LDAPIDPTemplate == nil {
+		return errors.ThrowPreconditionFailed(nil, "IDPSYN-Ls3md", "Errors.IDPConfig.NotExisting")
+	}
+	provider, err := s.ldapProvider(template)
+	if err != nil {
+		return err
+	}
+	users, err := provider.SearchUsers(ctx, s.pageSize, syncAttributes(config)...)
+	if err != nil {
+		return err
+	}
+	disabled, err := disabledUsers(ctx, provider, s.pageSize, config.DisabledFilter)
+	if err != nil {
+		return err
+	}
+	links, err := s.linkedUsers(ctx, idpID)
+	if err != nil {
+		return err
+	}
+	// an empty result is most likely caused by a misconfiguration and would deactivate all linked users
+	if len(users) == 0 && len(links) > 0 && config.DeactivateMissing {
+		return errors.ThrowPreconditionFailed(nil, "IDPSYN-Ls6wq", "Errors.IDPConfig.LDAPSync.DirectoryEmpty")
+	}
+
+	sync := &userSync{
+		Syncer: s,
+		config: config,
+		report: report,
+	}
+	if !report.DryRun {
+		if err = sync.initGenerators(ctx); err != nil {
+			return err
+		}
+	}
+	for _, user := range users {
+		if err = ctx.Err(); err != nil {
+			return err
+		}
+		if user.GetID() == "" {
+			continue
+		}
+		link, ok := links[user.GetID()]
+		delete(links, user.GetID())
+		// users disabled in the directory are deactivated and never created
+		if _, isDisabled := disabled[user.GetID()]; isDisabled {
+			if ok {
+				sync.deactivateUser(ctx, link)
+			}
+			continue
+		}
+		if !ok {
+			sync.createUser(ctx, user)
+			continue
+		}
+		sync.updateUser(ctx, user, link)
+	}
+	if !config.DeactivateMissing {
+		return nil
+	}
+	for _, link := range links {
+		if err = ctx.Err(); err != nil {
+			return err
+		}
+		sync.deactivateUser(ctx, link)
+	}
+	return nil
+}
+
+// disabledUsers returns the ids of the users matching the disabled filter in the directory
+func disabledUsers(ctx context.Context, provider *ldap.Provider, pageSize uint32, filter string) (map[string]struct{}, error) {
+	if filter == "" {
+		return nil, nil
+	}
+	users, err := provider.SearchUsersMatching(ctx, pageSize, filter)
+	if err != nil {
+		return nil, err
+	}
+	disabled := make(map[string]struct{}, len(users))
+	for _, user := range users {
+		disabled[user.GetID()] = struct{}{}
+	}
+	return disabled, nil
+}
+
+func syncAttributes(config *query.IDPLDAPSync) []string {
+	attributes := make([]string, 0, len(config.MetadataAttributes)+1)
+	attributes = append(attributes, config.MetadataAttributes...)
+	if config.GroupsAttribute != "" {
+		attributes = append(attributes, config.GroupsAttribute)
+	}
+	return attributes
+}
+
+// linkedUsers returns the links of the IDP mapped by the id of the user in the directory
+func (s *Syncer) linkedUsers(ctx context.Context, idpID string) (map[string]*query.IDPUserLink, error) {
+	idpQuery, err := query.NewIDPUserLinkIDPIDSearchQuery(idpID)
+	if err != nil {
+		return nil, err
+	}
+	links, err := s.queries.IDPUserLinks(ctx, &query.IDPUserLinksSearchQuery{Queries: []query.SearchQuery{idpQuery}}, false)
+	if err != nil {
+		return nil, err
+	}
+	linked := make(map[string]*query.IDPUserLink, len(links.Links))
+	for _, link := range links.Links {
+		linked[link.ProvidedUserID] = link
+	}
+	return linked, nil
+}
+
+func (s *userSync) initGenerators(ctx context.Context) (err error) {
+	s.initCodeGenerator, err = s.queries.InitEncryptionGenerator(ctx, domain.SecretGeneratorTypeInitCode, s.userCodeAlg)
+	if err != nil {
+		return err
+	}
+	s.emailCodeGenerator, err = s.queries.InitEncryptionGenerator(ctx, domain.SecretGeneratorTypeVerifyEmailCode, s.userCodeAlg)
+	if err != nil {
+		return err
+	}
+	s.phoneCodeGenerator, err = s.queries.InitEncryptionGenerator(ctx, domain.SecretGeneratorTypeVerifyPhoneCode, s.userCodeAlg)
+	if err != nil {
+		return err
+	}
+	s.passwordlessCodeGenerator, err = s.queries.InitEncryptionGenerator(ctx, domain.SecretGeneratorTypePasswordlessInitCode, s.userCodeAlg)
+	return err
+}
+
+func (s *userSync) createUser(ctx context.Context, user *ldap.DirectoryUser) {
+	change := &domain.LDAPSyncChange{
+		Action:         domain.LDAPSyncActionCreate,
+		ExternalUserID: user.GetID(),
+		Username:       username(user),
+	}
+	defer s.report.Add(change)
+	if s.report.DryRun {
+		s.syncGrants(ctx, user, "")
+		return
+	}
+	human, _, err := s.commands.ImportHuman(ctx, s.config.OrgID, mapUserToHuman(user), false,
+		[]*domain.UserIDPLink{
+			{
+				IDPConfigID:    s.config.IDPID,
+				ExternalUserID: user.GetID(),
+				DisplayName:    username(user),
+			},
+		},
+		s.initCodeGenerator,
+		s.emailCodeGenerator,
+		s.phoneCodeGenerator,
+		s.passwordlessCodeGenerator,
+	)
+	if err != nil {
+		change.Error = err.Error()
+		return
+	}
+	change.UserID = human.AggregateID
+	if metadata := mapUserToMetadata(user, s.config.MetadataAttributes); len(metadata) > 0 {
+		_, err = s.commands.BulkSetUserMetadata(ctx, human.AggregateID, s.config.OrgID, metadata...)
+		if err != nil {
+			change.Error = err.Error()
+			return
+		}
+	}
+	s.syncGrants(ctx, user, human.AggregateID)
+}
+
+func (s *userSync) updateUser(ctx context.Context, user *ldap.DirectoryUser, link *query.IDPUserLink) {
+	change := &domain.LDAPSyncChange{
+		Action:         domain.LDAPSyncActionUpdate,
+		ExternalUserID: user.GetID(),
+		UserID:         link.UserID,
+		Username:       username(user),
+	}
+	changed, err := s.syncUser(ctx, user, link)
+	if err != nil {
+		change.Error = err.Error()
+	}
+	if changed || err != nil {
+		s.report.Add(change)
+	}
+	if err == nil {
+		s.syncGrants(ctx, user, link.UserID)
+	}
+}
+
+// syncUser updates the profile, email, phone and metadata of the user and reactivates the user, if it was deactivated by the sync,
+// it returns if anything changed (or would change on a dry run)
+func (s *userSync) syncUser(ctx context.Context, user *ldap.DirectoryUser, link *query.IDPUserLink) (changed bool, err error) {
+	existing, err := s.queries.GetUserByID(ctx, false, link.UserID, false)
+	if err != nil {
+		return false, err
+	}
+	if existing.Human == nil {
+		return false, errors.ThrowPreconditionFailed(nil, "IDPSYN-Ls1vb", "Errors.User.NotHuman")
+	}
+	if existing.State == domain.UserStateInactive {
+		reactivate, err := s.deactivatedBySync(ctx, link.UserID)
+		if err != nil {
+			return false, err
+		}
+		if reactivate {
+			changed = true
+			if !s.report.DryRun {
+				if _, err = s.commands.ReactivateUser(ctx, link.UserID, link.ResourceOwner); err != nil {
+					return changed, err
+				}
+			}
+		}
+	}
+	if user.GetEmail() != "" && (user.GetEmail() != existing.Human.Email || user.IsEmailVerified() != existing.Human.IsEmailVerified) {
+		changed = true
+		if !s.report.DryRun {
+			_, err = s.commands.ChangeHumanEmail(ctx,
+				&domain.Email{
+					ObjectRoot:      models.ObjectRoot{AggregateID: link.UserID, ResourceOwner: link.ResourceOwner},
+					EmailAddress:    user.GetEmail(),
+					IsEmailVerified: user.IsEmailVerified(),
+				},
+				s.emailCodeGenerator,
+			)
+			if err != nil {
+				return changed, err
+			}
+		}
+	}
+	if user.GetPhone() != "" && (user.GetPhone() != existing.Human.Phone || user.IsPhoneVerified() != existing.Human.IsPhoneVerified) {
+		changed = true
+		if !s.report.DryRun {
+			_, err = s.commands.ChangeHumanPhone(ctx,
+				&domain.Phone{
+					ObjectRoot:      models.ObjectRoot{AggregateID: link.UserID},
+					PhoneNumber:     user.GetPhone(),
+					IsPhoneVerified: user.IsPhoneVerified(),
+				},
+				link.ResourceOwner,
+				s.phoneCodeGenerator,
+			)
+			if err != nil {
+				return changed, err
+			}
+		}
+	}
+	if user.GetFirstName() != existing.Human.FirstName ||
+		user.GetLastName() != existing.Human.LastName ||
+		user.GetNickname() != existing.Human.NickName ||
+		user.GetDisplayName() != existing.Human.DisplayName ||
+		user.GetPreferredLanguage() != existing.Human.PreferredLanguage {
+		changed = true
+		if !s.report.DryRun {
+			_, err = s.commands.ChangeHumanProfile(ctx, &domain.Profile{
+				ObjectRoot:        models.ObjectRoot{AggregateID: link.UserID, ResourceOwner: link.ResourceOwner},
+				FirstName:         user.GetFirstName(),
+				LastName:          user.GetLastName(),
+				NickName:          user.GetNickname(),
+				DisplayName:       user.GetDisplayName(),
+				PreferredLanguage: user.GetPreferredLanguage(),
+				Gender:            existing.Human.Gender,
+			})
+			if err != nil {
+				return changed, err
+			}
+		}
+	}
+	metadataChanged, err := s.syncMetadata(ctx, user, link)
+	return changed || metadataChanged, err
+}
+
+// deactivatedBySync checks if the user was deactivated by the sync,
+// users deactivated by an administrator must not be reactivated
+func (s *userSync) deactivatedBySync(ctx context.Context, userID string) (bool, error) {
+	editor, err := s.queries.UserDeactivatedBy(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return editor == SyncUserID, nil
+}
+
+// syncMetadata sets the changed attributes as metadata and removes the metadata of attributes without value
+func (s *userSync) syncMetadata(ctx context.Context, user *ldap.DirectoryUser, link *query.IDPUserLink) (bool, error) {
+	if len(s.config.MetadataAttributes) == 0 {
+		return false, nil
+	}
+	existing, err := s.queries.SearchUserMetadata(ctx, false, link.UserID, &query.UserMetadataSearchQueries{}, false)
+	if err != nil {
+		return false, err
+	}
+	set, remove := metadataChanges(existing.Metadata, mapUserToMetadata(user, s.config.MetadataAttributes), s.config.MetadataAttributes)
+	if len(set) == 0 && len(remove) == 0 {
+		return false, nil
+	}
+	if s.report.DryRun {
+		return true, nil
+	}
+	if len(set) > 0 {
+		if _, err = s.commands.BulkSetUserMetadata(ctx, link.UserID, link.ResourceOwner, set...); err != nil {
+			return true, err
+		}
+	}
+	if len(remove) > 0 {
+		if _, err = s.commands.BulkRemoveUserMetadata(ctx, link.UserID, link.ResourceOwner, remove...); err != nil {
+			return true, err
+		}
+	}
+	return true, nil
+}
+
+// syncGrants adds, changes and removes the grants of the projects of the group rules,
+// grants of other projects are left untouched
+func (s *userSync) syncGrants(ctx context.Context, user *ldap.DirectoryUser, userID string) {
+	if s.config.GroupsAttribute == "" || len(s.config.GroupRules) == 0 {
+		return
+	}
+	change := &domain.LDAPSyncChange{
+		Action:         domain.LDAPSyncActionChangeGrants,
+		ExternalUserID: user.GetID(),
+		UserID:         userID,
+		Username:       username(user),
+	}
+	changed, err := s.changeGrants(ctx, userID, desiredRoles(s.config.GroupRules, user.GetAttributeValues(s.config.GroupsAttribute)))
+	if err != nil {
+		change.Error = err.Error()
+	}
+	if changed || err != nil {
+		s.report.Add(change)
+	}
+}
+
+func (s *userSync) changeGrants(ctx context.Context, userID string, desired map[string][]string) (changed bool, err error) {
+	existing := make(map[string]*query.UserGrant)
+	if userID != "" {
+		existing, err = s.userGrants(ctx, userID)
+		if err != nil {
+			return false, err
+		}
+	}
+	for _, projectID := range ruleProjects(s.config.GroupRules) {
+		roles, wanted := desired[projectID]
+		grant, granted := existing[projectID]
+		switch {
+		case wanted && !granted:
+			changed = true
+			if !s.report.DryRun {
+				_, err = s.commands.AddUserGrant(ctx, &domain.UserGrant{
+					UserID:    userID,
+					ProjectID: projectID,
+					RoleKeys:  roles,
+				}, s.config.OrgID)
+			}
+		case wanted && granted && !sameRoles(roles, grant.Roles):
+			changed = true
+			if !s.report.DryRun {
+				_, err = s.commands.ChangeUserGrant(ctx, &domain.UserGrant{
+					ObjectRoot: models.ObjectRoot{AggregateID: grant.ID},
+					UserID:     userID,
+					ProjectID:  projectID,
+					RoleKeys:   roles,
+				}, grant.ResourceOwner)
+			}
+		case !wanted && granted:
+			changed = true
+			if !s.report.DryRun {
+				_, err = s.commands.RemoveUserGrant(ctx, grant.ID, grant.ResourceOwner)
+			}
+		}
+		if err != nil {
+			return changed, err
+		}
+	}
+	return changed, nil
+}
+
+// userGrants returns the grants of the user in the org of the sync mapped by project
+func (s *userSync) userGrants(ctx context.Context, userID string) (map[string]*query.UserGrant, error) {
+	userQuery, err := query.NewUserGrantUserIDSearchQuery(userID)
+	if err != nil {
+		return nil, err
+	}
+	ownerQuery, err := query.NewUserGrantResourceOwnerSearchQuery(s.config.OrgID)
+	if err != nil {
+		return nil, err
+	}
+	grants, err := s.queries.UserGrants(ctx, &query.UserGrantsQueries{Queries: []query.SearchQuery{userQuery, ownerQuery}}, false, false)
+	if err != nil {
+		return nil, err
+	}
+	grantsByProject := make(map[string]*query.UserGrant, len(grants.UserGrants))
+	for _, grant := range grants.UserGrants {
+		grantsByProject[grant.ProjectID] = grant
+	}
+	return grantsByProject, nil
+}
+
+func (s *userSync) deactivateUser(ctx context.Context, link *query.IDPUserLink) {
+	existing, err := s.queries.GetUserByID(ctx, false, link.UserID, false)
+	if err != nil {
+		s.report.Add(&domain.LDAPSyncChange{
+			Action:         domain.LDAPSyncActionDeactivate,
+			ExternalUserID: link.ProvidedUserID,
+			UserID:         link.UserID,
+			Username:       link.ProvidedUsername,
+			Error:          err.Error(),
+		})
+		return
+	}
+	if existing.State == domain.UserStateInactive {
+		return
+	}
+	change := &domain.LDAPSyncChange{
+		Action:         domain.LDAPSyncActionDeactivate,
+		ExternalUserID: link.ProvidedUserID,
+		UserID:         link.UserID,
+		Username:       existing.Username,
+	}
+	defer s.report.Add(change)
+	if s.report.DryRun {
+		return
+	}
+	if _, err = s.commands.DeactivateUser(ctx, link.UserID, link.ResourceOwner); err != nil {
+		change.Error = err.Error()
+	}
+}
+
+func username(user *ldap.DirectoryUser) string {
+	if user.GetPreferredUsername() != "" {
+		return user.GetPreferredUsername()
+	}
+	return user.GetID()
+}
+
+func mapUserToHuman(user *ldap.DirectoryUser) *domain.Human {
+	human := &domain.Human{
+		Username: username(user),
+		Profile: &domain.Profile{
+			FirstName:         user.GetFirstName(),
+			LastName:          user.GetLastName(),
+			NickName:          user.GetNickname(),
+			DisplayName:       user.GetDisplayName(),
+			PreferredLanguage: user.GetPreferredLanguage(),
+		},
+		Email: &domain.Email{
+			EmailAddress:    user.GetEmail(),
+			IsEmailVerified: user.IsEmailVerified(),
+		},
+	}
+	if user.GetPhone() != "" {
+		human.Phone = &domain.Phone{
+			PhoneNumber:     user.GetPhone(),
+			IsPhoneVerified: user.IsPhoneVerified(),
+		}
+	}
+	return human
+}
+
+func mapUserToMetadata(user *ldap.DirectoryUser, attributes []string) []*domain.Metadata {
+	metadata := make([]*domain.Metadata, 0, len(attributes))
+	for _, attribute := range attributes {
+		values := user.GetAttributeValues(attribute)
+		if len(values) == 0 {
+			continue
+		}
+		metadata = append(metadata, &domain.Metadata{
+			Key:   attribute,
+			Value: []byte(strings.Join(values, metadataValueSeparator)),
+		})
+	}
+	return metadata
+}
@@ -0,0 +1,55 @@
+package idpsync
+
+import (
+	"context"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+const LDAPSyncLockName = "projections.idp_ldap_sync_worker"
+
+// worker periodically runs the due LDAP synchronizations of all instances
+type worker struct {
+	*crdb.PeriodicWorker
+	syncer    *Syncer
+	queries   *query.Queries
+	bulkLimit uint64
+}
+
+func newWorker(
+	config crdb.StatementHandlerConfig,
+	syncer *Syncer,
+	queries *query.Queries,
+) *worker {
+	w := new(worker)
+	w.PeriodicWorker = crdb.NewPeriodicWorker(config.Client.DB, config.LockTable, LDAPSyncLockName, config.RequeueEvery, w.runDue)
+	w.syncer = syncer
+	w.queries = queries
+	w.bulkLimit = config.BulkLimit
+	return w
+}
+
+// runDue runs the due syncs, ctx is canceled as soon as the lock of the worker is lost
+func (w *worker) runDue(ctx context.Context) {
+	syncs, err := w.queries.DueIDPLDAPSyncs(ctx, w.bulkLimit)
+	if err != nil {
+		logging.WithFields("worker", LDAPSyncLockName).WithError(err).Error("unable to query due syncs")
+		return
+	}
+	for _, sync := range syncs.Syncs {
+		if ctx.Err() != nil {
+			return
+		}
+		report, err := w.syncer.Run(authz.WithInstanceID(ctx, sync.InstanceID), sync.ResourceOwner, sync.IDPID, false)
+		logging.WithFields("worker", LDAPSyncLockName, "instance", sync.InstanceID, "idp", sync.IDPID).OnError(err).Warn("sync failed")
+		if err == nil {
+			logging.WithFields("worker", LDAPSyncLockName, "instance", sync.InstanceID, "idp", sync.IDPID,
+				"created", report.Created, "updated", report.Updated, "deactivated", report.Deactivated, "failed", report.Failed,
+			).Info("sync succeeded")
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package httpapi
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/notification/channels"
+	"github.com/zitadel/zitadel/internal/notification/messages"
+)
+
+const requestTimeout = 10 * time.Second
+
+var _ channels.NotificationChannel = (*Email)(nil)
+
+// Email sends emails by calling the HTTP API of an email provider
+type Email struct {
+	ctx    context.Context
+	config Config
+}
+
+func InitChannel(ctx context.Context, config Config) (*Email, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	logging.WithFields("provider", config.ID).Debug("successfully initialized http api email channel")
+	return &Email{
+		ctx:    ctx,
+		config: config,
+	}, nil
+}
+
+func (email *Email) HandleMessage(message channels.Message) error {
+	emailMsg, ok := message.(*messages.Email)
+	if !ok {
+		return caos_errs.ThrowInternal(nil, "HTTPAPI-Ms5kd", "message is not EmailMessage")
+	}
+	if emailMsg.Content == "" || emailMsg.Subject == "" || len(emailMsg.Recipients) == 0 {
+		return caos_errs.ThrowInternalf(nil, "HTTPAPI-Ms8wq", "subject, recipients and content must be set but got subject %s, recipients length %d and content length %d", emailMsg.Subject, len(emailMsg.Recipients), len(emailMsg.Content))
+	}
+	emailMsg.SenderEmail = email.config.SenderAddress
+	emailMsg.SenderName = email.config.SenderName
+
+	body, err := email.config.Body(&BodyData{
+		From:     emailMsg.SenderEmail,
+		FromName: emailMsg.SenderName,
+		To:       emailMsg.Recipients,
+		CC:       nonNil(emailMsg.CC),
+		BCC:      nonNil(emailMsg.BCC),
+		Subject:  emailMsg.Subject,
+		Content:  emailMsg.Content,
+	})
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(email.ctx, requestTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, email.config.method(), email.config.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return caos_errs.ThrowInternal(err, "HTTPAPI-Rq3vb", "could not create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range email.config.Headers {
+		req.Header.Set(key, value)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return caos_errs.ThrowUnavailable(err, "HTTPAPI-Rq7mc", "could not call email provider")
+	}
+	if err = resp.Body.Close(); err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return caos_errs.ThrowUnavailable(fmt.Errorf("calling %s returned %s", email.config.Endpoint, resp.Status), "HTTPAPI-Rq2lp", "email provider didn't return a success status")
+	}
+	logging.WithFields("provider", email.config.ID, "recipients", len(emailMsg.Recipients)).Debug("email sent through http api")
+	return nil
+}
+
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
@@ -0,0 +1,120 @@
+package httpapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"text/template"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+// DefaultBodyTemplate is used if no body template is configured
+const DefaultBodyTemplate = `{"from":{"email":{{json .From}},"name":{{json .FromName}}},"to":{{json .To}},"cc":{{json .CC}},"bcc":{{json .BCC}},"subject":{{json .Subject}},"html":{{json .Content}}}`
+
+type Config struct {
+	ID          string
+	Description string
+	Priority    int32
+
+	SenderAddress string
+	SenderName    string
+
+	Endpoint string
+	Method   string
+	// Headers are added to the request, e.g. the Authorization header of the API
+	Headers map[string]string
+	// BodyTemplate is the text/template of the JSON body,
+	// values are escaped by the json function, e.g. {{json .Subject}}
+	BodyTemplate string
+}
+
+// BodyData is passed to the body template
+type BodyData struct {
+	From     string
+	FromName string
+	To       []string
+	CC       []string
+	BCC      []string
+	Subject  string
+	Content  string
+}
+
+var exampleBodyData = &BodyData{
+	From:     "zitadel@example.com",
+	FromName: "ZITADEL",
+	To:       []string{"john.doe@example.com"},
+	CC:       []string{},
+	BCC:      []string{},
+	Subject:  "Subject",
+	Content:  "<html><body>\"Content\"</body></html>",
+}
+
+func (c *Config) Validate() error {
+	if strings.TrimSpace(c.SenderAddress) == "" {
+		return errors.ThrowInvalidArgument(nil, "HTTPAPI-Sd3kq", "Errors.EmailProvider.SenderAddressInvalid")
+	}
+	endpoint, err := url.Parse(c.Endpoint)
+	if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+		return errors.ThrowInvalidArgument(err, "HTTPAPI-Ep8vn", "Errors.EmailProvider.EndpointInvalid")
+	}
+	if c.method() != http.MethodPost && c.method() != http.MethodPut {
+		return errors.ThrowInvalidArgument(nil, "HTTPAPI-Mt4xz", "Errors.EmailProvider.MethodInvalid")
+	}
+	if _, err = c.Body(exampleBodyData); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Body renders the JSON body of the request
+func (c *Config) Body(data *BodyData) ([]byte, error) {
+	tmpl, err := parseBodyTemplate(c.bodyTemplate())
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "HTTPAPI-Bt6kw", "Errors.EmailProvider.BodyTemplateInvalid")
+	}
+	body := new(bytes.Buffer)
+	if err = tmpl.Execute(body, data); err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "HTTPAPI-Bt2rp", "Errors.EmailProvider.BodyTemplateInvalid")
+	}
+	if !json.Valid(body.Bytes()) {
+		return nil, errors.ThrowInvalidArgument(nil, "HTTPAPI-Bt9sm", "Errors.EmailProvider.BodyTemplateInvalid")
+	}
+	return body.Bytes(), nil
+}
+
+func (c *Config) method() string {
+	if c.Method == "" {
+		return http.MethodPost
+	}
+	return strings.ToUpper(c.Method)
+}
+
+func (c *Config) bodyTemplate() string {
+	if c.BodyTemplate == "" {
+		return DefaultBodyTemplate
+	}
+	return c.BodyTemplate
+}
+
+func parseBodyTemplate(body string) (*template.Template, error) {
+	return template.New("body").
+		Option("missingkey=error").
+		Funcs(template.FuncMap{
+			"json": jsonValue,
+		}).
+		Parse(body)
+}
+
+// jsonValue encodes the value without escaping HTML, as the content of emails is HTML
+func jsonValue(v interface{}) (string, error) {
+	value := new(bytes.Buffer)
+	encoder := json.NewEncoder(value)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(v); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(value.String(), "\n"), nil
+}
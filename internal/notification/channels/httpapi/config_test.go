@@ -0,0 +1,59 @@
+package httpapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+func TestConfig_Body(t *testing.T) {
+	data := &BodyData{
+		From:     "zitadel@example.com",
+		FromName: "ZITADEL",
+		To:       []string{"john.doe@example.com"},
+		CC:       []string{},
+		BCC:      []string{},
+		Subject:  `Say "hi"`,
+		Content:  "<p>line\nbreak</p>",
+	}
+	tests := []struct {
+		name         string
+		bodyTemplate string
+		want         string
+		err          func(error) bool
+	}{
+		{
+			name: "default template",
+			want: `{"from":{"email":"zitadel@example.com","name":"ZITADEL"},"to":["john.doe@example.com"],"cc":[],"bcc":[],"subject":"Say \"hi\"","html":"<p>line\nbreak</p>"}`,
+		},
+		{
+			name:         "custom template",
+			bodyTemplate: `{"personalizations":[{"to":[{"email":{{json (index .To 0)}}}]}],"subject":{{json .Subject}}}`,
+			want:         `{"personalizations":[{"to":[{"email":"john.doe@example.com"}]}],"subject":"Say \"hi\""}`,
+		},
+		{
+			name:         "unescaped value, invalid json",
+			bodyTemplate: `{"subject":"{{.Subject}}"}`,
+			err:          caos_errs.IsErrorInvalidArgument,
+		},
+		{
+			name:         "unknown field",
+			bodyTemplate: `{"subject":{{json .Title}}}`,
+			err:          caos_errs.IsErrorInvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Config{BodyTemplate: tt.bodyTemplate}
+			got, err := c.Body(data)
+			if tt.err != nil {
+				assert.True(t, tt.err(err), "got wrong err: %v", err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
@@ -5,6 +5,8 @@ type Config struct {
 	Tls      bool
 	From     string
 	FromName string
+	// Priority orders the SMTP config and the HTTP API email providers, lower priorities are used first
+	Priority int32
 }
 
 type SMTP struct {
@@ -0,0 +1,225 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/zitadel/logging"
+	"gopkg.in/square/go-jose.v2"
+
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/id"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+const (
+	BackChannelLogoutNotificationsProjectionTable = "projections.notifications_back_channel_logout"
+
+	backChannelLogoutEvent = "http://schemas.openid.net/event/backchannel-logout"
+	logoutTokenType        = "logout+jwt"
+	logoutTokenLifetime    = 2 * time.Minute
+	// backChannelLogoutRetryPeriod defines how long failed logout notifications will be retried
+	backChannelLogoutRetryPeriod = time.Hour
+	backChannelLogoutTimeout     = 5 * time.Second
+)
+
+type backChannelLogoutNotifier struct {
+	crdb.StatementHandler
+	commands    *command.Commands
+	queries     *NotificationQueries
+	idGenerator id.Generator
+}
+
+func NewBackChannelLogoutNotifier(
+	ctx context.Context,
+	config crdb.StatementHandlerConfig,
+	commands *command.Commands,
+	queries *NotificationQueries,
+) *backChannelLogoutNotifier {
+	p := new(backChannelLogoutNotifier)
+	config.ProjectionName = BackChannelLogoutNotificationsProjectionTable
+	config.Reducers = p.reducers()
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	p.commands = commands
+	p.queries = queries
+	p.idGenerator = id.SonyFlakeGenerator()
+	projection.NotificationsBackChannelLogoutProjection = p
+	return p
+}
+
+func (u *backChannelLogoutNotifier) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: user.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  user.UserV1SignedOutType,
+					Reduce: u.reduceSignedOut,
+				},
+				{
+					Event:  user.HumanSignedOutType,
+					Reduce: u.reduceSignedOut,
+				},
+			},
+		},
+	}
+}
+
+func (u *backChannelLogoutNotifier) reduceSignedOut(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanSignedOutEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Gg3ql", "reduce.wrong.event.type %s", user.HumanSignedOutType)
+	}
+	if e.CreationDate().Add(backChannelLogoutRetryPeriod).Before(time.Now().UTC()) {
+		return crdb.NewNoOpStatement(e), nil
+	}
+	ctx := HandlerContext(event.Aggregate())
+	clientIDs, err := u.queries.SessionClientIDs(ctx, e.Aggregate().ID, e.UserAgentID, e.Sequence())
+	if err != nil {
+		return nil, err
+	}
+	if len(clientIDs) == 0 {
+		return crdb.NewNoOpStatement(e), nil
+	}
+	ctx, issuer, err := u.queries.Origin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// all clients are notified before a failure is returned, so an unreachable client doesn't block the others.
+	// The event is retried by the handler and the clients already notified are skipped by their sent event
+	var failed error
+	for _, clientID := range clientIDs {
+		if err = u.notifyClient(ctx, e, issuer, clientID); err != nil {
+			logging.WithFields("clientID", clientID, "instance", e.Aggregate().InstanceID).WithError(err).Warn("back-channel logout failed")
+			if failed == nil {
+				failed = err
+			}
+		}
+	}
+	if failed != nil {
+		return nil, failed
+	}
+	return crdb.NewNoOpStatement(e), nil
+}
+
+func (u *backChannelLogoutNotifier) notifyClient(ctx context.Context, e *user.HumanSignedOutEvent, issuer, clientID string) error {
+	app, err := u.queries.AppByOIDCClientID(ctx, clientID, false)
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if app.OIDCConfig.BackChannelLogoutURI == "" {
+		return nil
+	}
+	alreadyHandled, err := u.queries.IsAlreadyHandled(ctx, e, map[string]interface{}{"userAgentID": e.UserAgentID, "clientID": clientID}, user.HumanBackChannelLogoutSentType)
+	if err != nil || alreadyHandled {
+		return err
+	}
+	tokenID, err := u.idGenerator.Next()
+	if err != nil {
+		return err
+	}
+	token, err := u.queries.signLogoutToken(ctx, &logoutTokenClaims{
+		Issuer:     issuer,
+		Subject:    e.Aggregate().ID,
+		Audience:   []string{clientID},
+		IssuedAt:   time.Now().Unix(),
+		Expiration: time.Now().Add(logoutTokenLifetime).Unix(),
+		JWTID:      tokenID,
+		Events:     map[string]struct{}{backChannelLogoutEvent: {}},
+		SessionID:  e.UserAgentID,
+	})
+	if err != nil {
+		return err
+	}
+	if err = sendLogoutToken(ctx, app.OIDCConfig.BackChannelLogoutURI, token); err != nil {
+		return err
+	}
+	return u.commands.HumanBackChannelLogoutSent(ctx, e.Aggregate().ResourceOwner, e.Aggregate().ID, e.UserAgentID, clientID)
+}
+
+// logoutTokenClaims as specified in OpenID Connect Back-Channel Logout 1.0
+type logoutTokenClaims struct {
+	Issuer     string              `json:"iss"`
+	Subject    string              `json:"sub"`
+	Audience   []string            `json:"aud"`
+	IssuedAt   int64               `json:"iat"`
+	Expiration int64               `json:"exp"`
+	JWTID      string              `json:"jti"`
+	Events     map[string]struct{} `json:"events"`
+	SessionID  string              `json:"sid,omitempty"`
+}
+
+// signLogoutToken signs the claims with the current OIDC signing key of the instance
+func (n *NotificationQueries) signLogoutToken(ctx context.Context, claims *logoutTokenClaims) (string, error) {
+	keys, err := n.ActivePrivateSigningKey(ctx, time.Now())
+	if err != nil {
+		return "", err
+	}
+	if len(keys.Keys) == 0 {
+		return "", errors.ThrowPreconditionFailed(nil, "HANDL-Wqr2g", "Errors.Internal")
+	}
+	key := keys.Keys[len(keys.Keys)-1]
+	keyData, err := crypto.Decrypt(key.Key(), n.KeysCrypto)
+	if err != nil {
+		return "", err
+	}
+	privateKey, err := crypto.BytesToPrivateKey(keyData)
+	if err != nil {
+		return "", err
+	}
+	signer, err := jose.NewSigner(
+		jose.SigningKey{
+			Algorithm: jose.SignatureAlgorithm(key.Algorithm()),
+			Key:       &jose.JSONWebKey{Key: privateKey, KeyID: key.ID()},
+		},
+		(&jose.SignerOptions{}).WithType(logoutTokenType),
+	)
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	signature, err := signer.Sign(payload)
+	if err != nil {
+		return "", err
+	}
+	return signature.CompactSerialize()
+}
+
+func sendLogoutToken(ctx context.Context, logoutURI, token string) error {
+	ctx, cancel := context.WithTimeout(ctx, backChannelLogoutTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, logoutURI, strings.NewReader(url.Values{"logout_token": {token}}.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	if err = resp.Body.Close(); err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.ThrowUnknown(fmt.Errorf("calling url %s returned %s", logoutURI, resp.Status), "HANDL-Ld0sw", "back-channel logout didn't return a success status")
+	}
+	return nil
+}
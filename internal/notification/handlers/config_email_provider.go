@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/notification/channels/httpapi"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+// GetEmailProviders reads the active HTTP API email providers of the instance ordered by their priority
+func (n *NotificationQueries) GetEmailProviders(ctx context.Context) ([]*httpapi.Config, error) {
+	providers, err := n.ActiveEmailProviders(ctx)
+	if err != nil {
+		return nil, err
+	}
+	configs := make([]*httpapi.Config, 0, len(providers.Providers))
+	for _, provider := range providers.Providers {
+		if provider.HTTPConfig == nil {
+			continue
+		}
+		config, err := n.emailProviderToConfig(provider)
+		if err != nil {
+			return nil, err
+		}
+		configs = append(configs, config)
+	}
+	return configs, nil
+}
+
+// GetEmailProviderByID reads the HTTP API email provider regardless of its state
+func (n *NotificationQueries) GetEmailProviderByID(ctx context.Context, id string) (*httpapi.Config, error) {
+	provider, err := n.EmailProviderByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if provider.HTTPConfig == nil {
+		return nil, errors.ThrowNotFound(nil, "HANDL-Ep5gq", "Errors.EmailProvider.NotFound")
+	}
+	return n.emailProviderToConfig(provider)
+}
+
+func (n *NotificationQueries) emailProviderToConfig(provider *query.EmailProvider) (*httpapi.Config, error) {
+	var headers map[string]string
+	if provider.HTTPConfig.Headers != nil {
+		decrypted, err := crypto.Decrypt(provider.HTTPConfig.Headers, n.SMTPPasswordCrypto)
+		if err != nil {
+			return nil, err
+		}
+		if err = json.Unmarshal(decrypted, &headers); err != nil {
+			return nil, errors.ThrowInternal(err, "HANDL-Ep8dz", "Errors.Internal")
+		}
+	}
+	return &httpapi.Config{
+		ID:            provider.ID,
+		Description:   provider.Description,
+		Priority:      provider.Priority,
+		SenderAddress: provider.SenderAddress,
+		SenderName:    provider.SenderName,
+		Endpoint:      provider.HTTPConfig.Endpoint,
+		Method:        provider.HTTPConfig.Method,
+		Headers:       headers,
+		BodyTemplate:  provider.HTTPConfig.BodyTemplate,
+	}, nil
+}
@@ -27,5 +27,6 @@ func (n *NotificationQueries) GetSMTPConfig(ctx context.Context) (*smtp.Config,
 			User:     config.User,
 			Password: password,
 		},
+		Priority: config.Priority,
 	}, nil
 }
@@ -0,0 +1,29 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+// MailTemplateByOrgAndMessageType returns the mail template for emails of the message type.
+// The template of the message type (of the organisation or else of the instance) is preferred over the mail template.
+func (n *NotificationQueries) MailTemplateByOrgAndMessageType(ctx context.Context, orgID, messageType string) (*query.MailTemplate, error) {
+	messageTemplate, err := n.MessageMailTemplateByOrg(ctx, orgID, messageType, false)
+	if errors.IsNotFound(err) {
+		return n.MailTemplateByOrg(ctx, orgID, false)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &query.MailTemplate{
+		AggregateID:  messageTemplate.AggregateID,
+		Sequence:     messageTemplate.Sequence,
+		CreationDate: messageTemplate.CreationDate,
+		ChangeDate:   messageTemplate.ChangeDate,
+		State:        messageTemplate.State,
+		Template:     messageTemplate.Template,
+		IsDefault:    messageTemplate.IsDefault,
+	}, nil
+}
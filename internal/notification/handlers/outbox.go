@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/notification/types"
+)
+
+const NotificationOutboxLockName = "projections.notification_outbox"
+
+// outboxWorker periodically delivers the due messages of the notification outbox
+type outboxWorker struct {
+	*crdb.PeriodicWorker
+	commands  *command.Commands
+	queries   *NotificationQueries
+	bulkLimit uint64
+	metricSuccessfulDeliveriesEmail,
+	metricFailedDeliveriesEmail,
+	metricSuccessfulDeliveriesSMS,
+	metricFailedDeliveriesSMS string
+}
+
+func NewOutboxWorker(
+	config crdb.StatementHandlerConfig,
+	commands *command.Commands,
+	queries *NotificationQueries,
+	metricSuccessfulDeliveriesEmail,
+	metricFailedDeliveriesEmail,
+	metricSuccessfulDeliveriesSMS,
+	metricFailedDeliveriesSMS string,
+) *outboxWorker {
+	w := new(outboxWorker)
+	w.PeriodicWorker = crdb.NewPeriodicWorker(config.Client.DB, config.LockTable, NotificationOutboxLockName, config.RequeueEvery, w.deliverDue)
+	w.commands = commands
+	w.queries = queries
+	w.bulkLimit = config.BulkLimit
+	w.metricSuccessfulDeliveriesEmail = metricSuccessfulDeliveriesEmail
+	w.metricFailedDeliveriesEmail = metricFailedDeliveriesEmail
+	w.metricSuccessfulDeliveriesSMS = metricSuccessfulDeliveriesSMS
+	w.metricFailedDeliveriesSMS = metricFailedDeliveriesSMS
+	return w
+}
+
+// deliverDue delivers the due messages, it's run while the worker holds the lock
+func (w *outboxWorker) deliverDue(ctx context.Context) {
+	messages, err := w.queries.DueNotificationMessages(ctx, w.bulkLimit)
+	if err != nil {
+		logging.WithFields("worker", NotificationOutboxLockName).WithError(err).Error("unable to query due messages")
+		return
+	}
+	for _, message := range messages.Messages {
+		if ctx.Err() != nil {
+			return
+		}
+		messageCtx := HandlerContext(eventstore.Aggregate{InstanceID: message.InstanceID, ResourceOwner: message.ResourceOwner})
+		err = w.commands.DeliverNotificationMessage(messageCtx, message.ResourceOwner, message.ID, w.send)
+		logging.WithFields("worker", NotificationOutboxLockName, "instance", message.InstanceID, "message", message.ID).OnError(err).Warn("unable to deliver message")
+	}
+}
+
+func (w *outboxWorker) send(ctx context.Context, message *command.NotificationMessage) error {
+	switch message.Channel {
+	case domain.NotificationTypeEmail:
+		return types.DeliverEmail(
+			ctx,
+			message,
+			w.queries.GetSMTPConfig,
+			w.queries.GetEmailProviders,
+			w.queries.GetFileSystemProvider,
+			w.queries.GetLogProvider,
+			w.metricSuccessfulDeliveriesEmail,
+			w.metricFailedDeliveriesEmail,
+		)
+	case domain.NotificationTypeSms:
+		return types.DeliverSMS(
+			ctx,
+			message,
+			w.queries.GetTwilioConfig,
+			w.queries.GetFileSystemProvider,
+			w.queries.GetLogProvider,
+			w.metricSuccessfulDeliveriesSMS,
+			w.metricFailedDeliveriesSMS,
+		)
+	default:
+		return errors.ThrowUnimplemented(nil, "HANDL-Ob4kw", "Errors.Notification.Channels.NotPresent")
+	}
+}
@@ -18,6 +18,7 @@ type NotificationQueries struct {
 	UserDataCrypto     crypto.EncryptionAlgorithm
 	SMTPPasswordCrypto crypto.EncryptionAlgorithm
 	SMSTokenCrypto     crypto.EncryptionAlgorithm
+	KeysCrypto         crypto.EncryptionAlgorithm
 	statikDir          http.FileSystem
 }
 
@@ -30,6 +31,7 @@ func NewNotificationQueries(
 	userDataCrypto crypto.EncryptionAlgorithm,
 	smtpPasswordCrypto crypto.EncryptionAlgorithm,
 	smsTokenCrypto crypto.EncryptionAlgorithm,
+	keysCrypto crypto.EncryptionAlgorithm,
 	statikDir http.FileSystem,
 ) *NotificationQueries {
 	return &NotificationQueries{
@@ -41,6 +43,7 @@ func NewNotificationQueries(
 		UserDataCrypto:     userDataCrypto,
 		SMTPPasswordCrypto: smtpPasswordCrypto,
 		SMSTokenCrypto:     smsTokenCrypto,
+		KeysCrypto:         keysCrypto,
 		statikDir:          statikDir,
 	}
 }
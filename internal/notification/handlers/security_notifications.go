@@ -0,0 +1,250 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/notification/types"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+const (
+	credentialTypePersonalAccessToken = "PersonalAccessToken"
+	credentialTypeKey                 = "Key"
+)
+
+func (u *userNotifier) reduceNewDeviceLogin(event eventstore.Event) (*handler.Statement, error) {
+	var info *user.AuthRequestInfo
+	switch e := event.(type) {
+	case *user.HumanPasswordCheckSucceededEvent:
+		info = e.AuthRequestInfo
+	case *user.HumanPasswordlessCheckSucceededEvent:
+		info = e.AuthRequestInfo
+	case *user.UserIDPCheckSucceededEvent:
+		info = e.AuthRequestInfo
+	default:
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Nd8vq", "reduce.wrong.event.type %v", []eventstore.EventType{user.HumanPasswordCheckSucceededType, user.HumanPasswordlessTokenCheckSucceededType, user.UserIDPLoginCheckSucceededType})
+	}
+	return u.reduceSecurityNotification(event, domain.NewDeviceLoginMessageType, func(ctx context.Context) (*query.NotifyUser, map[string]interface{}, error) {
+		if info == nil {
+			return nil, nil, nil
+		}
+		isNew, err := u.queries.IsNewUserAgent(ctx, event, info.UserAgentID)
+		if err != nil || !isNew {
+			return nil, nil, err
+		}
+		notifyUser, err := u.queries.GetNotifyUserByID(ctx, true, event.Aggregate().ID, false)
+		if err != nil {
+			return nil, nil, err
+		}
+		args := map[string]interface{}{
+			"UserAgent": "",
+			"RemoteIP":  "",
+		}
+		if info.BrowserInfo != nil {
+			args["UserAgent"] = info.UserAgent
+		}
+		if info.BrowserInfo != nil && info.RemoteIP != nil {
+			args["RemoteIP"] = info.RemoteIP.String()
+		}
+		return notifyUser, args, nil
+	})
+}
+
+func (u *userNotifier) reduceMFAAdded(event eventstore.Event) (*handler.Statement, error) {
+	var mfaType string
+	switch event.(type) {
+	case *user.HumanOTPVerifiedEvent:
+		mfaType = "OTP"
+	case *user.HumanU2FVerifiedEvent:
+		mfaType = "U2F"
+	case *user.HumanPasswordlessVerifiedEvent:
+		mfaType = "Passwordless"
+	case *user.HumanOTPSMSAddedEvent:
+		mfaType = "OTP SMS"
+	case *user.HumanOTPEmailAddedEvent:
+		mfaType = "OTP Email"
+	default:
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Ma3wz", "reduce.wrong.event.type %v", []eventstore.EventType{user.HumanMFAOTPVerifiedType, user.HumanU2FTokenVerifiedType, user.HumanPasswordlessTokenVerifiedType, user.HumanMFAOTPSMSAddedType, user.HumanMFAOTPEmailAddedType})
+	}
+	return u.reduceSecurityNotification(event, domain.MFAAddedMessageType, u.aggregateUserRecipient(event, map[string]interface{}{"MFAType": mfaType}))
+}
+
+func (u *userNotifier) reduceMFARemoved(event eventstore.Event) (*handler.Statement, error) {
+	var mfaType string
+	switch event.(type) {
+	case *user.HumanOTPRemovedEvent:
+		mfaType = "OTP"
+	case *user.HumanU2FRemovedEvent:
+		mfaType = "U2F"
+	case *user.HumanPasswordlessRemovedEvent:
+		mfaType = "Passwordless"
+	case *user.HumanOTPSMSRemovedEvent:
+		mfaType = "OTP SMS"
+	case *user.HumanOTPEmailRemovedEvent:
+		mfaType = "OTP Email"
+	default:
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Mr5qx", "reduce.wrong.event.type %v", []eventstore.EventType{user.HumanMFAOTPRemovedType, user.HumanU2FTokenRemovedType, user.HumanPasswordlessTokenRemovedType, user.HumanMFAOTPSMSRemovedType, user.HumanMFAOTPEmailRemovedType})
+	}
+	return u.reduceSecurityNotification(event, domain.MFARemovedMessageType, u.aggregateUserRecipient(event, map[string]interface{}{"MFAType": mfaType}))
+}
+
+// reduceEmailChanged sends the notification to the previous email address,
+// the new one is verified separately by reduceEmailCodeAdded
+func (u *userNotifier) reduceEmailChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanEmailChangedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Ec6tp", "reduce.wrong.event.type %s", user.HumanEmailChangedType)
+	}
+	return u.reduceSecurityNotification(event, domain.EmailChangedMessageType, func(ctx context.Context) (*query.NotifyUser, map[string]interface{}, error) {
+		previousEmail, err := u.queries.PreviousVerifiedEmail(ctx, event)
+		if err != nil || previousEmail == "" {
+			return nil, nil, err
+		}
+		notifyUser, err := u.queries.GetNotifyUserByID(ctx, true, e.Aggregate().ID, false)
+		if err != nil {
+			return nil, nil, err
+		}
+		previousEmailUser := *notifyUser
+		previousEmailUser.VerifiedEmail = previousEmail
+		return &previousEmailUser, map[string]interface{}{"NewEmail": string(e.EmailAddress)}, nil
+	})
+}
+
+// reduceMachineCredentialAdded notifies the human user, who created the credential of the service user
+func (u *userNotifier) reduceMachineCredentialAdded(event eventstore.Event) (*handler.Statement, error) {
+	var credentialType string
+	switch event.(type) {
+	case *user.PersonalAccessTokenAddedEvent:
+		credentialType = credentialTypePersonalAccessToken
+	case *user.MachineKeyAddedEvent:
+		credentialType = credentialTypeKey
+	default:
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Mc2vb", "reduce.wrong.event.type %v", []eventstore.EventType{user.PersonalAccessTokenAddedType, user.MachineKeyAddedEventType})
+	}
+	return u.reduceSecurityNotification(event, domain.MachineCredentialAddedMessageType, func(ctx context.Context) (*query.NotifyUser, map[string]interface{}, error) {
+		if event.EditorUser() == "" || event.EditorUser() == event.Aggregate().ID {
+			return nil, nil, nil
+		}
+		creator, err := u.queries.GetNotifyUserByID(ctx, true, event.EditorUser(), false)
+		if errors.IsNotFound(err) {
+			return nil, nil, nil
+		}
+		if err != nil || creator.Type != domain.UserTypeHuman {
+			return nil, nil, err
+		}
+		machine, err := u.queries.GetNotifyUserByID(ctx, true, event.Aggregate().ID, false)
+		if err != nil {
+			return nil, nil, err
+		}
+		return creator, map[string]interface{}{
+			"MachineUsername": machine.Username,
+			"CredentialType":  credentialType,
+		}, nil
+	})
+}
+
+func (u *userNotifier) reduceUserLocked(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.UserLockedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Ul9sk", "reduce.wrong.event.type %s", user.UserLockedType)
+	}
+	if !e.LockedByPolicy {
+		return crdb.NewNoOpStatement(e), nil
+	}
+	return u.reduceSecurityNotification(event, domain.UserLockedMessageType, u.aggregateUserRecipient(event, nil))
+}
+
+func (u *userNotifier) aggregateUserRecipient(event eventstore.Event, args map[string]interface{}) func(context.Context) (*query.NotifyUser, map[string]interface{}, error) {
+	return func(ctx context.Context) (*query.NotifyUser, map[string]interface{}, error) {
+		notifyUser, err := u.queries.GetNotifyUserByID(ctx, true, event.Aggregate().ID, false)
+		if err != nil {
+			return nil, nil, err
+		}
+		return notifyUser, args, nil
+	}
+}
+
+// reduceSecurityNotification sends the security notification of the messageType,
+// if the notification policy of the organisation of the event enables them.
+// recipient returns the user to notify and the arguments of the message,
+// no notification is sent if no user is returned.
+func (u *userNotifier) reduceSecurityNotification(
+	event eventstore.Event,
+	messageType string,
+	recipient func(ctx context.Context) (*query.NotifyUser, map[string]interface{}, error),
+) (*handler.Statement, error) {
+	ctx := HandlerContext(event.Aggregate())
+	alreadyHandled, err := u.queries.IsAlreadyHandled(ctx, event,
+		map[string]interface{}{
+			"messageType":     messageType,
+			"triggerSequence": event.Sequence(),
+		},
+		user.UserSecurityNotificationSentType)
+	if err != nil {
+		return nil, err
+	}
+	if alreadyHandled {
+		return crdb.NewNoOpStatement(event), nil
+	}
+
+	notificationPolicy, err := u.queries.NotificationPolicyByOrg(ctx, false, event.Aggregate().ResourceOwner, false)
+	if errors.IsNotFound(err) {
+		return crdb.NewNoOpStatement(event), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !notificationPolicy.SecurityNotifications {
+		return crdb.NewNoOpStatement(event), nil
+	}
+
+	notifyUser, args, err := recipient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if notifyUser == nil || notifyUser.VerifiedEmail == "" {
+		return crdb.NewNoOpStatement(event), nil
+	}
+
+	colors, err := u.queries.ActiveLabelPolicyByOrg(ctx, notifyUser.ResourceOwner, false)
+	if err != nil {
+		return nil, err
+	}
+	template, err := u.queries.MailTemplateByOrgAndMessageType(ctx, notifyUser.ResourceOwner, messageType)
+	if err != nil {
+		return nil, err
+	}
+	translator, err := u.queries.GetTranslatorWithOrgTexts(ctx, notifyUser.ResourceOwner, messageType)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, origin, err := u.queries.Origin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = types.SendEmail(
+		ctx,
+		string(template.Template),
+		translator,
+		notifyUser,
+		colors,
+		u.assetsPrefix(ctx),
+		event,
+		u.commands.QueueNotificationMessage,
+	).SendSecurityNotification(notifyUser, origin, messageType, args)
+	if err != nil {
+		return nil, err
+	}
+	err = u.commands.UserSecurityNotificationSent(ctx, event.Aggregate().ResourceOwner, event.Aggregate().ID, messageType, event.Sequence())
+	if err != nil {
+		return nil, err
+	}
+	return crdb.NewNoOpStatement(event), nil
+}
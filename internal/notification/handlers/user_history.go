@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+var loginSucceededEventTypes = []eventstore.EventType{
+	user.UserV1PasswordCheckSucceededType,
+	user.HumanPasswordCheckSucceededType,
+	user.HumanPasswordlessTokenCheckSucceededType,
+	user.UserIDPLoginCheckSucceededType,
+}
+
+// IsNewUserAgent checks if the user of the event logged in before, but never on the user agent.
+// The first login of a user is therefore not reported as new user agent.
+func (n *NotificationQueries) IsNewUserAgent(ctx context.Context, event eventstore.Event, userAgentID string) (bool, error) {
+	if userAgentID == "" {
+		return false, nil
+	}
+	knownAgentLogins, err := n.es.Filter(ctx, n.previousUserEventsQuery(event, 1, map[string]interface{}{"userAgentID": userAgentID}, loginSucceededEventTypes...))
+	if err != nil || len(knownAgentLogins) > 0 {
+		return false, err
+	}
+	logins, err := n.es.Filter(ctx, n.previousUserEventsQuery(event, 1, nil, loginSucceededEventTypes...))
+	if err != nil {
+		return false, err
+	}
+	return len(logins) > 0, nil
+}
+
+// PreviousVerifiedEmail returns the email address of the user of the event,
+// if it was verified before the event
+func (n *NotificationQueries) PreviousVerifiedEmail(ctx context.Context, event eventstore.Event) (string, error) {
+	events, err := n.es.Filter(ctx, n.previousUserEventsQuery(event, 0, nil,
+		user.UserV1AddedType,
+		user.UserV1RegisteredType,
+		user.UserV1EmailChangedType,
+		user.UserV1EmailVerifiedType,
+		user.HumanAddedType,
+		user.HumanRegisteredType,
+		user.HumanEmailChangedType,
+		user.HumanEmailVerifiedType,
+	).OrderAsc())
+	if err != nil {
+		return "", err
+	}
+	var email string
+	var verified bool
+	for _, previous := range events {
+		switch e := previous.(type) {
+		case *user.HumanAddedEvent:
+			email, verified = string(e.EmailAddress), false
+		case *user.HumanRegisteredEvent:
+			email, verified = string(e.EmailAddress), false
+		case *user.HumanEmailChangedEvent:
+			email, verified = string(e.EmailAddress), false
+		case *user.HumanEmailVerifiedEvent:
+			verified = true
+		}
+	}
+	if !verified {
+		return "", nil
+	}
+	return email, nil
+}
+
+func (n *NotificationQueries) previousUserEventsQuery(event eventstore.Event, limit uint64, data map[string]interface{}, eventTypes ...eventstore.EventType) *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		InstanceID(event.Aggregate().InstanceID).
+		Limit(limit).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(event.Aggregate().ID).
+		SequenceLess(event.Sequence()).
+		EventTypes(eventTypes...).
+		EventData(data).
+		Builder()
+}
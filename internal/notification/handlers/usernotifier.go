@@ -25,10 +25,6 @@ type userNotifier struct {
 	commands     *command.Commands
 	queries      *NotificationQueries
 	assetsPrefix func(context.Context) string
-	metricSuccessfulDeliveriesEmail,
-	metricFailedDeliveriesEmail,
-	metricSuccessfulDeliveriesSMS,
-	metricFailedDeliveriesSMS string
 }
 
 func NewUserNotifier(
@@ -37,10 +33,6 @@ func NewUserNotifier(
 	commands *command.Commands,
 	queries *NotificationQueries,
 	assetsPrefix func(context.Context) string,
-	metricSuccessfulDeliveriesEmail,
-	metricFailedDeliveriesEmail,
-	metricSuccessfulDeliveriesSMS,
-	metricFailedDeliveriesSMS string,
 ) *userNotifier {
 	p := new(userNotifier)
 	config.ProjectionName = UserNotificationsProjectionTable
@@ -49,10 +41,6 @@ func NewUserNotifier(
 	p.commands = commands
 	p.queries = queries
 	p.assetsPrefix = assetsPrefix
-	p.metricSuccessfulDeliveriesEmail = metricSuccessfulDeliveriesEmail
-	p.metricFailedDeliveriesEmail = metricFailedDeliveriesEmail
-	p.metricSuccessfulDeliveriesSMS = metricSuccessfulDeliveriesSMS
-	p.metricFailedDeliveriesSMS = metricFailedDeliveriesSMS
 	projection.NotificationsProjection = p
 	return p
 }
@@ -106,6 +94,86 @@ func (u *userNotifier) reducers() []handler.AggregateReducer {
 					Event:  user.HumanPasswordChangedType,
 					Reduce: u.reducePasswordChanged,
 				},
+				{
+					Event:  user.HumanMFAOTPSMSCodeAddedType,
+					Reduce: u.reduceOTPSMSCodeAdded,
+				},
+				{
+					Event:  user.HumanMFAOTPEmailCodeAddedType,
+					Reduce: u.reduceOTPEmailCodeAdded,
+				},
+				{
+					Event:  user.HumanMFARecoveryCodeCheckSucceededType,
+					Reduce: u.reduceRecoveryCodeUsed,
+				},
+				{
+					Event:  user.HumanPasswordCheckSucceededType,
+					Reduce: u.reduceNewDeviceLogin,
+				},
+				{
+					Event:  user.HumanPasswordlessTokenCheckSucceededType,
+					Reduce: u.reduceNewDeviceLogin,
+				},
+				{
+					Event:  user.UserIDPLoginCheckSucceededType,
+					Reduce: u.reduceNewDeviceLogin,
+				},
+				{
+					Event:  user.HumanMFAOTPVerifiedType,
+					Reduce: u.reduceMFAAdded,
+				},
+				{
+					Event:  user.HumanU2FTokenVerifiedType,
+					Reduce: u.reduceMFAAdded,
+				},
+				{
+					Event:  user.HumanPasswordlessTokenVerifiedType,
+					Reduce: u.reduceMFAAdded,
+				},
+				{
+					Event:  user.HumanMFAOTPSMSAddedType,
+					Reduce: u.reduceMFAAdded,
+				},
+				{
+					Event:  user.HumanMFAOTPEmailAddedType,
+					Reduce: u.reduceMFAAdded,
+				},
+				{
+					Event:  user.HumanMFAOTPRemovedType,
+					Reduce: u.reduceMFARemoved,
+				},
+				{
+					Event:  user.HumanU2FTokenRemovedType,
+					Reduce: u.reduceMFARemoved,
+				},
+				{
+					Event:  user.HumanPasswordlessTokenRemovedType,
+					Reduce: u.reduceMFARemoved,
+				},
+				{
+					Event:  user.HumanMFAOTPSMSRemovedType,
+					Reduce: u.reduceMFARemoved,
+				},
+				{
+					Event:  user.HumanMFAOTPEmailRemovedType,
+					Reduce: u.reduceMFARemoved,
+				},
+				{
+					Event:  user.HumanEmailChangedType,
+					Reduce: u.reduceEmailChanged,
+				},
+				{
+					Event:  user.PersonalAccessTokenAddedType,
+					Reduce: u.reduceMachineCredentialAdded,
+				},
+				{
+					Event:  user.MachineKeyAddedEventType,
+					Reduce: u.reduceMachineCredentialAdded,
+				},
+				{
+					Event:  user.UserLockedType,
+					Reduce: u.reduceUserLocked,
+				},
 			},
 		},
 	}
@@ -135,7 +203,7 @@ func (u *userNotifier) reduceInitCodeAdded(event eventstore.Event) (*handler.Sta
 		return nil, err
 	}
 
-	template, err := u.queries.MailTemplateByOrg(ctx, e.Aggregate().ResourceOwner, false)
+	template, err := u.queries.MailTemplateByOrgAndMessageType(ctx, e.Aggregate().ResourceOwner, domain.InitCodeMessageType)
 	if err != nil {
 		return nil, err
 	}
@@ -158,14 +226,10 @@ func (u *userNotifier) reduceInitCodeAdded(event eventstore.Event) (*handler.Sta
 		string(template.Template),
 		translator,
 		notifyUser,
-		u.queries.GetSMTPConfig,
-		u.queries.GetFileSystemProvider,
-		u.queries.GetLogProvider,
 		colors,
 		u.assetsPrefix(ctx),
 		e,
-		u.metricSuccessfulDeliveriesEmail,
-		u.metricFailedDeliveriesEmail,
+		u.commands.QueueNotificationMessage,
 	).SendUserInitCode(notifyUser, origin, code)
 	if err != nil {
 		return nil, err
@@ -204,7 +268,7 @@ func (u *userNotifier) reduceEmailCodeAdded(event eventstore.Event) (*handler.St
 		return nil, err
 	}
 
-	template, err := u.queries.MailTemplateByOrg(ctx, e.Aggregate().ResourceOwner, false)
+	template, err := u.queries.MailTemplateByOrgAndMessageType(ctx, e.Aggregate().ResourceOwner, domain.VerifyEmailMessageType)
 	if err != nil {
 		return nil, err
 	}
@@ -227,14 +291,10 @@ func (u *userNotifier) reduceEmailCodeAdded(event eventstore.Event) (*handler.St
 		string(template.Template),
 		translator,
 		notifyUser,
-		u.queries.GetSMTPConfig,
-		u.queries.GetFileSystemProvider,
-		u.queries.GetLogProvider,
 		colors,
 		u.assetsPrefix(ctx),
 		e,
-		u.metricSuccessfulDeliveriesEmail,
-		u.metricFailedDeliveriesEmail,
+		u.commands.QueueNotificationMessage,
 	).SendEmailVerificationCode(notifyUser, origin, code, e.URLTemplate)
 	if err != nil {
 		return nil, err
@@ -270,7 +330,7 @@ func (u *userNotifier) reducePasswordCodeAdded(event eventstore.Event) (*handler
 		return nil, err
 	}
 
-	template, err := u.queries.MailTemplateByOrg(ctx, e.Aggregate().ResourceOwner, false)
+	template, err := u.queries.MailTemplateByOrgAndMessageType(ctx, e.Aggregate().ResourceOwner, domain.PasswordResetMessageType)
 	if err != nil {
 		return nil, err
 	}
@@ -293,28 +353,20 @@ func (u *userNotifier) reducePasswordCodeAdded(event eventstore.Event) (*handler
 		string(template.Template),
 		translator,
 		notifyUser,
-		u.queries.GetSMTPConfig,
-		u.queries.GetFileSystemProvider,
-		u.queries.GetLogProvider,
 		colors,
 		u.assetsPrefix(ctx),
 		e,
-		u.metricSuccessfulDeliveriesEmail,
-		u.metricFailedDeliveriesEmail,
+		u.commands.QueueNotificationMessage,
 	)
 	if e.NotificationType == domain.NotificationTypeSms {
 		notify = types.SendSMSTwilio(
 			ctx,
 			translator,
 			notifyUser,
-			u.queries.GetTwilioConfig,
-			u.queries.GetFileSystemProvider,
-			u.queries.GetLogProvider,
 			colors,
 			u.assetsPrefix(ctx),
 			e,
-			u.metricSuccessfulDeliveriesSMS,
-			u.metricFailedDeliveriesSMS,
+			u.commands.QueueNotificationMessage,
 		)
 	}
 	err = notify.SendPasswordCode(notifyUser, origin, code)
@@ -347,7 +399,7 @@ func (u *userNotifier) reduceDomainClaimed(event eventstore.Event) (*handler.Sta
 		return nil, err
 	}
 
-	template, err := u.queries.MailTemplateByOrg(ctx, e.Aggregate().ResourceOwner, false)
+	template, err := u.queries.MailTemplateByOrgAndMessageType(ctx, e.Aggregate().ResourceOwner, domain.DomainClaimedMessageType)
 	if err != nil {
 		return nil, err
 	}
@@ -370,14 +422,10 @@ func (u *userNotifier) reduceDomainClaimed(event eventstore.Event) (*handler.Sta
 		string(template.Template),
 		translator,
 		notifyUser,
-		u.queries.GetSMTPConfig,
-		u.queries.GetFileSystemProvider,
-		u.queries.GetLogProvider,
 		colors,
 		u.assetsPrefix(ctx),
 		e,
-		u.metricSuccessfulDeliveriesEmail,
-		u.metricFailedDeliveriesEmail,
+		u.commands.QueueNotificationMessage,
 	).SendDomainClaimed(notifyUser, origin, e.UserName)
 	if err != nil {
 		return nil, err
@@ -411,7 +459,7 @@ func (u *userNotifier) reducePasswordlessCodeRequested(event eventstore.Event) (
 		return nil, err
 	}
 
-	template, err := u.queries.MailTemplateByOrg(ctx, e.Aggregate().ResourceOwner, false)
+	template, err := u.queries.MailTemplateByOrgAndMessageType(ctx, e.Aggregate().ResourceOwner, domain.PasswordlessRegistrationMessageType)
 	if err != nil {
 		return nil, err
 	}
@@ -434,14 +482,10 @@ func (u *userNotifier) reducePasswordlessCodeRequested(event eventstore.Event) (
 		string(template.Template),
 		translator,
 		notifyUser,
-		u.queries.GetSMTPConfig,
-		u.queries.GetFileSystemProvider,
-		u.queries.GetLogProvider,
 		colors,
 		u.assetsPrefix(ctx),
 		e,
-		u.metricSuccessfulDeliveriesEmail,
-		u.metricFailedDeliveriesEmail,
+		u.commands.QueueNotificationMessage,
 	).SendPasswordlessRegistrationLink(notifyUser, origin, code, e.ID)
 	if err != nil {
 		return nil, err
@@ -481,7 +525,7 @@ func (u *userNotifier) reducePasswordChanged(event eventstore.Event) (*handler.S
 			return nil, err
 		}
 
-		template, err := u.queries.MailTemplateByOrg(ctx, e.Aggregate().ResourceOwner, false)
+		template, err := u.queries.MailTemplateByOrgAndMessageType(ctx, e.Aggregate().ResourceOwner, domain.PasswordChangeMessageType)
 		if err != nil {
 			return nil, err
 		}
@@ -504,14 +548,10 @@ func (u *userNotifier) reducePasswordChanged(event eventstore.Event) (*handler.S
 			string(template.Template),
 			translator,
 			notifyUser,
-			u.queries.GetSMTPConfig,
-			u.queries.GetFileSystemProvider,
-			u.queries.GetLogProvider,
 			colors,
 			u.assetsPrefix(ctx),
 			e,
-			u.metricSuccessfulDeliveriesEmail,
-			u.metricFailedDeliveriesEmail,
+			u.commands.QueueNotificationMessage,
 		).SendPasswordChange(notifyUser, origin)
 		if err != nil {
 			return nil, err
@@ -565,14 +605,10 @@ func (u *userNotifier) reducePhoneCodeAdded(event eventstore.Event) (*handler.St
 		ctx,
 		translator,
 		notifyUser,
-		u.queries.GetTwilioConfig,
-		u.queries.GetFileSystemProvider,
-		u.queries.GetLogProvider,
 		colors,
 		u.assetsPrefix(ctx),
 		e,
-		u.metricSuccessfulDeliveriesSMS,
-		u.metricFailedDeliveriesSMS,
+		u.commands.QueueNotificationMessage,
 	).SendPhoneVerificationCode(notifyUser, origin, code)
 	if err != nil {
 		return nil, err
@@ -584,6 +620,179 @@ func (u *userNotifier) reducePhoneCodeAdded(event eventstore.Event) (*handler.St
 	return crdb.NewNoOpStatement(e), nil
 }
 
+func (u *userNotifier) reduceOTPSMSCodeAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanOTPSMSCodeAddedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Ds5tq", "reduce.wrong.event.type %s", user.HumanMFAOTPSMSCodeAddedType)
+	}
+	ctx := HandlerContext(event.Aggregate())
+	alreadyHandled, err := u.checkIfCodeAlreadyHandledOrExpired(ctx, event, e.Expiry, nil,
+		user.HumanMFAOTPSMSCodeAddedType, user.HumanMFAOTPSMSCodeSentType)
+	if err != nil {
+		return nil, err
+	}
+	if alreadyHandled {
+		return crdb.NewNoOpStatement(e), nil
+	}
+	code, err := crypto.DecryptString(e.Code, u.queries.UserDataCrypto)
+	if err != nil {
+		return nil, err
+	}
+	colors, err := u.queries.ActiveLabelPolicyByOrg(ctx, e.Aggregate().ResourceOwner, false)
+	if err != nil {
+		return nil, err
+	}
+
+	notifyUser, err := u.queries.GetNotifyUserByID(ctx, true, e.Aggregate().ID, false)
+	if err != nil {
+		return nil, err
+	}
+	translator, err := u.queries.GetTranslatorWithOrgTexts(ctx, notifyUser.ResourceOwner, domain.VerifySMSOTPMessageType)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, origin, err := u.queries.Origin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = types.SendSMSTwilio(
+		ctx,
+		translator,
+		notifyUser,
+		colors,
+		u.assetsPrefix(ctx),
+		e,
+		u.commands.QueueNotificationMessage,
+	).SendOTPSMSCode(notifyUser, origin, code)
+	if err != nil {
+		return nil, err
+	}
+	err = u.commands.HumanOTPSMSCodeSent(ctx, e.Aggregate().ID, e.Aggregate().ResourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	return crdb.NewNoOpStatement(e), nil
+}
+
+func (u *userNotifier) reduceOTPEmailCodeAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanOTPEmailCodeAddedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Jt9ea", "reduce.wrong.event.type %s", user.HumanMFAOTPEmailCodeAddedType)
+	}
+	ctx := HandlerContext(event.Aggregate())
+	alreadyHandled, err := u.checkIfCodeAlreadyHandledOrExpired(ctx, event, e.Expiry, nil,
+		user.HumanMFAOTPEmailCodeAddedType, user.HumanMFAOTPEmailCodeSentType)
+	if err != nil {
+		return nil, err
+	}
+	if alreadyHandled {
+		return crdb.NewNoOpStatement(e), nil
+	}
+	code, err := crypto.DecryptString(e.Code, u.queries.UserDataCrypto)
+	if err != nil {
+		return nil, err
+	}
+	colors, err := u.queries.ActiveLabelPolicyByOrg(ctx, e.Aggregate().ResourceOwner, false)
+	if err != nil {
+		return nil, err
+	}
+
+	template, err := u.queries.MailTemplateByOrgAndMessageType(ctx, e.Aggregate().ResourceOwner, domain.VerifyEmailOTPMessageType)
+	if err != nil {
+		return nil, err
+	}
+
+	notifyUser, err := u.queries.GetNotifyUserByID(ctx, true, e.Aggregate().ID, false)
+	if err != nil {
+		return nil, err
+	}
+	translator, err := u.queries.GetTranslatorWithOrgTexts(ctx, notifyUser.ResourceOwner, domain.VerifyEmailOTPMessageType)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, origin, err := u.queries.Origin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = types.SendEmail(
+		ctx,
+		string(template.Template),
+		translator,
+		notifyUser,
+		colors,
+		u.assetsPrefix(ctx),
+		e,
+		u.commands.QueueNotificationMessage,
+	).SendOTPEmailCode(notifyUser, origin, code)
+	if err != nil {
+		return nil, err
+	}
+	err = u.commands.HumanOTPEmailCodeSent(ctx, e.Aggregate().ID, e.Aggregate().ResourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	return crdb.NewNoOpStatement(e), nil
+}
+
+func (u *userNotifier) reduceRecoveryCodeUsed(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanRecoveryCodeCheckSucceededEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Rn4xk", "reduce.wrong.event.type %s", user.HumanMFARecoveryCodeCheckSucceededType)
+	}
+	ctx := HandlerContext(event.Aggregate())
+	alreadyHandled, err := u.queries.IsAlreadyHandled(ctx, event, map[string]interface{}{"codeIndex": e.CodeIndex},
+		user.HumanMFARecoveryCodeUsedNotificationSentType)
+	if err != nil {
+		return nil, err
+	}
+	if alreadyHandled {
+		return crdb.NewNoOpStatement(e), nil
+	}
+	colors, err := u.queries.ActiveLabelPolicyByOrg(ctx, e.Aggregate().ResourceOwner, false)
+	if err != nil {
+		return nil, err
+	}
+
+	template, err := u.queries.MailTemplateByOrgAndMessageType(ctx, e.Aggregate().ResourceOwner, domain.RecoveryCodeUsedMessageType)
+	if err != nil {
+		return nil, err
+	}
+
+	notifyUser, err := u.queries.GetNotifyUserByID(ctx, true, e.Aggregate().ID, false)
+	if err != nil {
+		return nil, err
+	}
+	translator, err := u.queries.GetTranslatorWithOrgTexts(ctx, notifyUser.ResourceOwner, domain.RecoveryCodeUsedMessageType)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, origin, err := u.queries.Origin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = types.SendEmail(
+		ctx,
+		string(template.Template),
+		translator,
+		notifyUser,
+		colors,
+		u.assetsPrefix(ctx),
+		e,
+		u.commands.QueueNotificationMessage,
+	).SendRecoveryCodeUsed(notifyUser, origin)
+	if err != nil {
+		return nil, err
+	}
+	err = u.commands.HumanRecoveryCodeUsedNotificationSent(ctx, e.Aggregate().ID, e.Aggregate().ResourceOwner, e.CodeIndex)
+	if err != nil {
+		return nil, err
+	}
+	return crdb.NewNoOpStatement(e), nil
+}
+
 func (u *userNotifier) checkIfCodeAlreadyHandledOrExpired(ctx context.Context, event eventstore.Event, expiry time.Duration, data map[string]interface{}, eventTypes ...eventstore.EventType) (bool, error) {
 	if event.CreationDate().Add(expiry).Before(time.Now().UTC()) {
 		return true, nil
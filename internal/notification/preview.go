@@ -0,0 +1,148 @@
+package notification
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	statik_fs "github.com/rakyll/statik/fs"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/notification/handlers"
+	"github.com/zitadel/zitadel/internal/notification/templates"
+	"github.com/zitadel/zitadel/internal/notification/types"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+const (
+	previewCode   = "123456"
+	previewCodeID = "preview"
+)
+
+var (
+	previewStatikDir     http.FileSystem
+	previewStatikDirErr  error
+	previewStatikDirOnce sync.Once
+)
+
+// PreviewMessage renders the email of the message type as it's sent to users of the organisation,
+// an example user with the preferred language is used as recipient.
+// If a template is passed, it's rendered instead of the stored template of the message type.
+func PreviewMessage(
+	ctx context.Context,
+	queries *query.Queries,
+	origin,
+	assetsPrefix,
+	orgID,
+	messageType string,
+	lang language.Tag,
+	template *domain.MessageMailTemplate,
+) (*types.EmailPreview, error) {
+	if !domain.IsMailMessageType(messageType) {
+		return nil, errors.ThrowInvalidArgument(nil, "NOTIF-Pv3kd", "Errors.Org.MailTemplate.MessageTemplate.Invalid")
+	}
+	previewStatikDirOnce.Do(func() {
+		previewStatikDir, previewStatikDirErr = statik_fs.NewWithNamespace("notification")
+	})
+	if previewStatikDirErr != nil {
+		return nil, errors.ThrowInternal(previewStatikDirErr, "NOTIF-Pv8wq", "Errors.Internal")
+	}
+	notificationQueries := handlers.NewNotificationQueries(queries, nil, 0, false, "", nil, nil, nil, nil, previewStatikDir)
+
+	mailhtml, err := previewMailTemplate(ctx, notificationQueries, orgID, messageType, template)
+	if err != nil {
+		return nil, err
+	}
+	colors, err := queries.ActiveLabelPolicyByOrg(ctx, orgID, false)
+	if err != nil {
+		return nil, err
+	}
+	translator, err := notificationQueries.GetTranslatorWithOrgTexts(ctx, orgID, messageType)
+	if err != nil {
+		return nil, err
+	}
+
+	user := previewUser(orgID, lang)
+	preview := new(types.EmailPreview)
+	notify := types.PreviewEmail(mailhtml, translator, user, colors, assetsPrefix, preview)
+	if err = sendPreview(notify, user, origin, messageType); err != nil {
+		return nil, err
+	}
+	return preview, nil
+}
+
+func previewMailTemplate(ctx context.Context, queries *handlers.NotificationQueries, orgID, messageType string, template *domain.MessageMailTemplate) (string, error) {
+	if template == nil {
+		mailTemplate, err := queries.MailTemplateByOrgAndMessageType(ctx, orgID, messageType)
+		if err != nil {
+			return "", err
+		}
+		return string(mailTemplate.Template), nil
+	}
+	html, err := templates.CompileMessageTemplate(template.Format, template.Source)
+	if err != nil {
+		return "", err
+	}
+	return string(html), nil
+}
+
+func previewUser(orgID string, lang language.Tag) *query.NotifyUser {
+	return &query.NotifyUser{
+		ID:                 "preview",
+		ResourceOwner:      orgID,
+		State:              domain.UserStateActive,
+		Type:               domain.UserTypeHuman,
+		Username:           "john.doe",
+		LoginNames:         []string{"john.doe@example.com"},
+		PreferredLoginName: "john.doe@example.com",
+		FirstName:          "John",
+		LastName:           "Doe",
+		NickName:           "John",
+		DisplayName:        "John Doe",
+		PreferredLanguage:  lang,
+		LastEmail:          "john.doe@example.com",
+		VerifiedEmail:      "john.doe@example.com",
+		PasswordSet:        true,
+	}
+}
+
+func sendPreview(notify types.Notify, user *query.NotifyUser, origin, messageType string) error {
+	switch messageType {
+	case domain.InitCodeMessageType:
+		return notify.SendUserInitCode(user, origin, previewCode)
+	case domain.VerifyEmailMessageType:
+		return notify.SendEmailVerificationCode(user, origin, previewCode, "")
+	case domain.PasswordResetMessageType:
+		return notify.SendPasswordCode(user, origin, previewCode)
+	case domain.DomainClaimedMessageType:
+		return notify.SendDomainClaimed(user, origin, "john.doe@temporary.example.com")
+	case domain.PasswordlessRegistrationMessageType:
+		return notify.SendPasswordlessRegistrationLink(user, origin, previewCode, previewCodeID)
+	case domain.PasswordChangeMessageType:
+		return notify.SendPasswordChange(user, origin)
+	case domain.VerifyEmailOTPMessageType:
+		return notify.SendOTPEmailCode(user, origin, previewCode)
+	case domain.RecoveryCodeUsedMessageType:
+		return notify.SendRecoveryCodeUsed(user, origin)
+	case domain.NewDeviceLoginMessageType:
+		return notify.SendSecurityNotification(user, origin, messageType, map[string]interface{}{
+			"UserAgent": "Mozilla/5.0 (X11; Linux x86_64) Firefox/115.0",
+			"RemoteIP":  "192.0.2.1",
+		})
+	case domain.MFAAddedMessageType,
+		domain.MFARemovedMessageType:
+		return notify.SendSecurityNotification(user, origin, messageType, map[string]interface{}{"MFAType": "U2F"})
+	case domain.EmailChangedMessageType:
+		return notify.SendSecurityNotification(user, origin, messageType, map[string]interface{}{"NewEmail": "john.doe@new.example.com"})
+	case domain.MachineCredentialAddedMessageType:
+		return notify.SendSecurityNotification(user, origin, messageType, map[string]interface{}{
+			"MachineUsername": "service-account",
+			"CredentialType":  "Key",
+		})
+	case domain.UserLockedMessageType:
+		return notify.SendSecurityNotification(user, origin, messageType, nil)
+	}
+	return errors.ThrowInvalidArgument(nil, "NOTIF-Pv6ny", "Errors.Org.MailTemplate.MessageTemplate.Invalid")
+}
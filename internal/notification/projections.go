@@ -29,6 +29,8 @@ func Start(
 	ctx context.Context,
 	userHandlerCustomConfig projection.CustomConfig,
 	quotaHandlerCustomConfig projection.CustomConfig,
+	backChannelLogoutHandlerCustomConfig projection.CustomConfig,
+	outboxHandlerCustomConfig projection.CustomConfig,
 	externalPort uint16,
 	externalSecure bool,
 	commands *command.Commands,
@@ -38,7 +40,8 @@ func Start(
 	fileSystemPath string,
 	userEncryption,
 	smtpEncryption,
-	smsEncryption crypto.EncryptionAlgorithm,
+	smsEncryption,
+	keysEncryption crypto.EncryptionAlgorithm,
 ) {
 	statikFS, err := statik_fs.NewWithNamespace("notification")
 	logging.OnError(err).Panic("unable to start listener")
@@ -54,18 +57,23 @@ func Start(
 	logging.WithFields("metric", metricSuccessfulDeliveriesJSON).OnError(err).Panic("unable to register counter")
 	err = metrics.RegisterCounter(metricFailedDeliveriesJSON, "Failed JSON message deliveries")
 	logging.WithFields("metric", metricFailedDeliveriesJSON).OnError(err).Panic("unable to register counter")
-	q := handlers.NewNotificationQueries(queries, es, externalPort, externalSecure, fileSystemPath, userEncryption, smtpEncryption, smsEncryption, statikFS)
+	q := handlers.NewNotificationQueries(queries, es, externalPort, externalSecure, fileSystemPath, userEncryption, smtpEncryption, smsEncryption, keysEncryption, statikFS)
 	handlers.NewUserNotifier(
 		ctx,
 		projection.ApplyCustomConfig(userHandlerCustomConfig),
 		commands,
 		q,
 		assetsPrefix,
+	).Start()
+	handlers.NewOutboxWorker(
+		projection.ApplyCustomConfig(outboxHandlerCustomConfig),
+		commands,
+		q,
 		metricSuccessfulDeliveriesEmail,
 		metricFailedDeliveriesEmail,
 		metricSuccessfulDeliveriesSMS,
 		metricFailedDeliveriesSMS,
-	).Start()
+	).Start(ctx)
 	handlers.NewQuotaNotifier(
 		ctx,
 		projection.ApplyCustomConfig(quotaHandlerCustomConfig),
@@ -74,4 +82,10 @@ func Start(
 		metricSuccessfulDeliveriesJSON,
 		metricFailedDeliveriesJSON,
 	).Start()
+	handlers.NewBackChannelLogoutNotifier(
+		ctx,
+		projection.ApplyCustomConfig(backChannelLogoutHandlerCustomConfig),
+		commands,
+		q,
+	).Start()
 }
@@ -2,44 +2,124 @@ package senders
 
 import (
 	"context"
+	"sort"
 
 	"github.com/zitadel/logging"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/notification/channels"
 	"github.com/zitadel/zitadel/internal/notification/channels/fs"
+	"github.com/zitadel/zitadel/internal/notification/channels/httpapi"
 	"github.com/zitadel/zitadel/internal/notification/channels/instrumenting"
 	"github.com/zitadel/zitadel/internal/notification/channels/log"
 	"github.com/zitadel/zitadel/internal/notification/channels/smtp"
 )
 
-const smtpSpanName = "smtp.NotificationChannel"
+const (
+	smtpSpanName    = "smtp.NotificationChannel"
+	httpAPISpanName = "httpapi.NotificationChannel"
+)
 
+// EmailChannels chains the email providers and the debug channels.
+// The SMTP config and the active HTTP API providers are tried ordered by their priority,
+// the SMTP config is used first on the same priority.
 func EmailChannels(
 	ctx context.Context,
 	emailConfig func(ctx context.Context) (*smtp.Config, error),
+	getEmailProviders func(ctx context.Context) ([]*httpapi.Config, error),
 	getFileSystemProvider func(ctx context.Context) (*fs.Config, error),
 	getLogProvider func(ctx context.Context) (*log.Config, error),
 	successMetricName,
 	failureMetricName string,
 ) (chain *Chain, err error) {
 	channels := make([]channels.NotificationChannel, 0, 3)
-	p, err := smtp.InitChannel(ctx, emailConfig)
+	providers := emailProviderChannels(ctx, emailConfig, getEmailProviders, successMetricName, failureMetricName)
+	if providers.Len() > 0 {
+		channels = append(channels, providers)
+	}
+	channels = append(channels, debugChannels(ctx, getFileSystemProvider, getLogProvider)...)
+	return chainChannels(channels...), nil
+}
+
+// emailProvider is either the SMTP config or an HTTP API provider
+type emailProvider struct {
+	priority int32
+	smtp     *smtp.Config
+	httpAPI  *httpapi.Config
+}
+
+// orderEmailProviders orders the SMTP config and the HTTP API providers by their priority,
+// the SMTP config is used first on the same priority
+func orderEmailProviders(smtpConfig *smtp.Config, httpAPIConfigs []*httpapi.Config) []*emailProvider {
+	providers := make([]*emailProvider, 0, len(httpAPIConfigs)+1)
+	if smtpConfig != nil {
+		providers = append(providers, &emailProvider{priority: smtpConfig.Priority, smtp: smtpConfig})
+	}
+	for _, config := range httpAPIConfigs {
+		providers = append(providers, &emailProvider{priority: config.Priority, httpAPI: config})
+	}
+	sort.SliceStable(providers, func(i, j int) bool {
+		return providers[i].priority < providers[j].priority
+	})
+	return providers
+}
+
+func emailProviderChannels(
+	ctx context.Context,
+	emailConfig func(ctx context.Context) (*smtp.Config, error),
+	getEmailProviders func(ctx context.Context) ([]*httpapi.Config, error),
+	successMetricName,
+	failureMetricName string,
+) *Failover {
+	smtpConfig, err := emailConfig(ctx)
 	logging.WithFields(
 		"instance", authz.GetInstance(ctx).InstanceID(),
-	).OnError(err).Debug("initializing SMTP channel failed")
-	if err == nil {
-		channels = append(
-			channels,
+	).OnError(err).Debug("reading SMTP config failed")
+	configs, err := getEmailProviders(ctx)
+	logging.WithFields(
+		"instance", authz.GetInstance(ctx).InstanceID(),
+	).OnError(err).Debug("reading email providers failed")
+
+	providers := make([]channels.NotificationChannel, 0, len(configs)+1)
+	for _, provider := range orderEmailProviders(smtpConfig, configs) {
+		if provider.smtp != nil {
+			p, err := smtp.InitChannel(ctx, func(context.Context) (*smtp.Config, error) { return provider.smtp, nil })
+			logging.WithFields(
+				"instance", authz.GetInstance(ctx).InstanceID(),
+			).OnError(err).Debug("initializing SMTP channel failed")
+			if err != nil {
+				continue
+			}
+			providers = append(
+				providers,
+				instrumenting.Wrap(
+					ctx,
+					p,
+					smtpSpanName,
+					successMetricName,
+					failureMetricName,
+				),
+			)
+			continue
+		}
+		p, err := httpapi.InitChannel(ctx, *provider.httpAPI)
+		logging.WithFields(
+			"instance", authz.GetInstance(ctx).InstanceID(),
+			"provider", provider.httpAPI.ID,
+		).OnError(err).Debug("initializing HTTP API email channel failed")
+		if err != nil {
+			continue
+		}
+		providers = append(
+			providers,
 			instrumenting.Wrap(
 				ctx,
 				p,
-				smtpSpanName,
+				httpAPISpanName,
 				successMetricName,
 				failureMetricName,
 			),
 		)
 	}
-	channels = append(channels, debugChannels(ctx, getFileSystemProvider, getLogProvider)...)
-	return chainChannels(channels...), nil
+	return failoverChannels(providers...)
 }
@@ -0,0 +1,54 @@
+package senders
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/notification/channels/httpapi"
+	"github.com/zitadel/zitadel/internal/notification/channels/smtp"
+)
+
+func Test_orderEmailProviders(t *testing.T) {
+	tests := []struct {
+		name       string
+		smtpConfig *smtp.Config
+		httpAPI    []*httpapi.Config
+		want       []string
+	}{
+		{
+			name: "no providers",
+			want: []string{},
+		},
+		{
+			name:       "smtp only",
+			smtpConfig: &smtp.Config{},
+			want:       []string{"smtp"},
+		},
+		{
+			name:       "smtp first on same priority",
+			smtpConfig: &smtp.Config{Priority: 1},
+			httpAPI:    []*httpapi.Config{{ID: "http1", Priority: 1}, {ID: "http2", Priority: 2}},
+			want:       []string{"smtp", "http1", "http2"},
+		},
+		{
+			name:       "http provider primary",
+			smtpConfig: &smtp.Config{Priority: 2},
+			httpAPI:    []*httpapi.Config{{ID: "http1", Priority: 1}, {ID: "http2", Priority: 3}},
+			want:       []string{"http1", "smtp", "http2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := make([]string, 0, len(tt.want))
+			for _, provider := range orderEmailProviders(tt.smtpConfig, tt.httpAPI) {
+				if provider.smtp != nil {
+					got = append(got, "smtp")
+					continue
+				}
+				got = append(got, provider.httpAPI.ID)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
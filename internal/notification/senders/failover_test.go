@@ -0,0 +1,73 @@
+package senders
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/notification/channels"
+	"github.com/zitadel/zitadel/internal/notification/messages"
+)
+
+func TestFailover_HandleMessage(t *testing.T) {
+	errSend := errors.New("send failed")
+	type res struct {
+		err   error
+		calls []int
+	}
+	tests := []struct {
+		name    string
+		results []error
+		res     res
+	}{
+		{
+			name:    "no channels",
+			results: nil,
+			res: res{
+				err:   nil,
+				calls: nil,
+			},
+		},
+		{
+			name:    "primary succeeds",
+			results: []error{nil, nil},
+			res: res{
+				err:   nil,
+				calls: []int{0},
+			},
+		},
+		{
+			name:    "primary fails, secondary succeeds",
+			results: []error{errSend, nil, nil},
+			res: res{
+				err:   nil,
+				calls: []int{0, 1},
+			},
+		},
+		{
+			name:    "all fail",
+			results: []error{errors.New("first"), errSend},
+			res: res{
+				err:   errSend,
+				calls: []int{0, 1},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []int
+			chans := make([]channels.NotificationChannel, len(tt.results))
+			for i, result := range tt.results {
+				i, result := i, result
+				chans[i] = channels.HandleMessageFunc(func(channels.Message) error {
+					calls = append(calls, i)
+					return result
+				})
+			}
+			err := failoverChannels(chans...).HandleMessage(&messages.Email{})
+			assert.ErrorIs(t, err, tt.res.err)
+			assert.Equal(t, tt.res.calls, calls)
+		})
+	}
+}
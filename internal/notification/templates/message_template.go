@@ -0,0 +1,62 @@
+package templates
+
+import (
+	"html/template"
+	"io"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+// CompileMessageTemplate returns the HTML template of the source, MJML is compiled to HTML.
+// The template is validated by rendering it with example data.
+func CompileMessageTemplate(format domain.MessageTemplateFormat, source []byte) ([]byte, error) {
+	var html string
+	switch format {
+	case domain.MessageTemplateFormatHTML:
+		html = string(source)
+	case domain.MessageTemplateFormatMJML:
+		compiled, err := CompileMJML(string(source))
+		if err != nil {
+			return nil, err
+		}
+		html = compiled
+	default:
+		return nil, errors.ThrowInvalidArgument(nil, "TMPL-Fm2kq", "Errors.MailTemplate.FormatInvalid")
+	}
+	if err := ValidateTemplate(html); err != nil {
+		return nil, err
+	}
+	return []byte(html), nil
+}
+
+// ValidateTemplate checks if the mail template can be rendered with TemplateData
+func ValidateTemplate(html string) error {
+	tmpl, err := template.New("tmpl").Parse(html)
+	if err != nil {
+		return errors.ThrowInvalidArgument(err, "TMPL-Vh4rt", "Errors.MailTemplate.HTMLInvalid")
+	}
+	if err = tmpl.Execute(io.Discard, exampleTemplateData); err != nil {
+		return errors.ThrowInvalidArgument(err, "TMPL-Vx7nd", "Errors.MailTemplate.HTMLInvalid")
+	}
+	return nil
+}
+
+var exampleTemplateData = &TemplateData{
+	Title:           "Title",
+	PreHeader:       "PreHeader",
+	Subject:         "Subject",
+	Greeting:        "Hello",
+	Text:            "Text",
+	URL:             "https://example.com",
+	ButtonText:      "Button",
+	PrimaryColor:    DefaultPrimaryColor,
+	BackgroundColor: DefaultBackgroundColor,
+	FontColor:       DefaultFontColor,
+	LogoURL:         "https://example.com/logo.png",
+	FontURL:         "https://example.com/font.ttf",
+	FontFaceFamily:  "font",
+	FontFamily:      DefaultFontFamily,
+	IncludeFooter:   true,
+	FooterText:      "Footer",
+}
@@ -0,0 +1,91 @@
+package templates
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+func TestCompileMessageTemplate(t *testing.T) {
+	type args struct {
+		format domain.MessageTemplateFormat
+		source []byte
+	}
+	type res struct {
+		want []byte
+		err  func(error) bool
+	}
+	tests := []struct {
+		name string
+		args args
+		res  res
+	}{
+		{
+			name: "format unspecified, invalid argument error",
+			args: args{
+				format: domain.MessageTemplateFormatUnspecified,
+				source: []byte("<p>{{.Text}}</p>"),
+			},
+			res: res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "html not parsable, invalid argument error",
+			args: args{
+				format: domain.MessageTemplateFormatHTML,
+				source: []byte("<p>{{.Text</p>"),
+			},
+			res: res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "html with unknown field, invalid argument error",
+			args: args{
+				format: domain.MessageTemplateFormatHTML,
+				source: []byte("<p>{{.Unknown}}</p>"),
+			},
+			res: res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "mjml invalid, invalid argument error",
+			args: args{
+				format: domain.MessageTemplateFormatMJML,
+				source: []byte("<p>{{.Text}}</p>"),
+			},
+			res: res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "html, ok",
+			args: args{
+				format: domain.MessageTemplateFormatHTML,
+				source: []byte("<p>{{.Text}}</p>"),
+			},
+			res: res{
+				want: []byte("<p>{{.Text}}</p>"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CompileMessageTemplate(tt.args.format, tt.args.source)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
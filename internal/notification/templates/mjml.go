@@ -0,0 +1,795 @@
+package templates
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+// CompileMJML compiles the MJML document to the HTML of an email.
+// Only the subset of MJML used by the default templates is supported:
+// mjml, mj-head, mj-attributes (incl. mj-all and mj-class), mj-font, mj-style, mj-title, mj-preview, mj-breakpoint,
+// mj-body, mj-wrapper, mj-section, mj-group, mj-column, mj-text, mj-button, mj-image, mj-divider, mj-spacer and mj-raw.
+// Go template actions (e.g. {{.Greeting}}) are kept, so the result can be parsed with GetParsedTemplate.
+func CompileMJML(mjml string) (string, error) {
+	document, err := parseMJML(mjml)
+	if err != nil {
+		return "", err
+	}
+	compiler := newMJMLCompiler()
+	return compiler.compile(document)
+}
+
+const (
+	mjmlDefaultBodyWidth  = 600
+	mjmlDefaultBreakpoint = "480px"
+	mjmlDefaultFontFamily = "Ubuntu, Helvetica, Arial, sans-serif"
+)
+
+type mjmlElement struct {
+	// ending elements contain HTML, which is copied to the result
+	ending   bool
+	children []string
+	defaults map[string]string
+}
+
+var mjmlContentElements = []string{"mj-text", "mj-button", "mj-image", "mj-divider", "mj-spacer", "mj-raw"}
+
+var mjmlElements = map[string]mjmlElement{
+	"mjml":          {children: []string{"mj-head", "mj-body"}},
+	"mj-head":       {children: []string{"mj-attributes", "mj-font", "mj-style", "mj-title", "mj-preview", "mj-breakpoint", "mj-raw"}},
+	"mj-attributes": {},
+	"mj-all":        {},
+	"mj-class":      {},
+	"mj-font":       {},
+	"mj-breakpoint": {},
+	"mj-style":      {ending: true},
+	"mj-title":      {ending: true},
+	"mj-preview":    {ending: true},
+	"mj-raw":        {ending: true},
+	"mj-body": {
+		children: []string{"mj-wrapper", "mj-section", "mj-raw"},
+		defaults: map[string]string{"width": "600px"},
+	},
+	"mj-wrapper": {
+		children: []string{"mj-section", "mj-raw"},
+		defaults: map[string]string{"direction": "ltr", "padding": "20px 0", "text-align": "center"},
+	},
+	"mj-section": {
+		children: []string{"mj-column", "mj-group", "mj-raw"},
+		defaults: map[string]string{"direction": "ltr", "padding": "20px 0", "text-align": "center"},
+	},
+	"mj-group": {
+		children: []string{"mj-column", "mj-raw"},
+		defaults: map[string]string{"direction": "ltr"},
+	},
+	"mj-column": {
+		children: mjmlContentElements,
+		defaults: map[string]string{"direction": "ltr", "vertical-align": "top"},
+	},
+	"mj-text": {
+		ending: true,
+		defaults: map[string]string{
+			"align":       "left",
+			"color":       "#000000",
+			"font-family": mjmlDefaultFontFamily,
+			"font-size":   "13px",
+			"line-height": "1",
+			"padding":     "10px 25px",
+		},
+	},
+	"mj-button": {
+		ending: true,
+		defaults: map[string]string{
+			"align":            "center",
+			"background-color": "#414141",
+			"border":           "none",
+			"border-radius":    "3px",
+			"color":            "#ffffff",
+			"font-family":      mjmlDefaultFontFamily,
+			"font-size":        "13px",
+			"font-weight":      "normal",
+			"inner-padding":    "10px 25px",
+			"line-height":      "120%",
+			"padding":          "10px 25px",
+			"target":           "_blank",
+			"text-decoration":  "none",
+			"text-transform":   "none",
+			"vertical-align":   "middle",
+		},
+	},
+	"mj-image": {
+		defaults: map[string]string{
+			"align":   "center",
+			"alt":     "",
+			"height":  "auto",
+			"padding": "10px 25px",
+			"target":  "_blank",
+		},
+	},
+	"mj-divider": {
+		defaults: map[string]string{
+			"align":        "center",
+			"border-color": "#000000",
+			"border-style": "solid",
+			"border-width": "4px",
+			"padding":      "10px 25px",
+			"width":        "100%",
+		},
+	},
+	"mj-spacer": {
+		defaults: map[string]string{"height": "20px"},
+	},
+}
+
+// mjmlNode is an element of a MJML document,
+// text between elements (e.g. Go template actions) is represented as node without name
+type mjmlNode struct {
+	name     string
+	attrs    map[string]string
+	children []*mjmlNode
+	content  string
+}
+
+func parseMJML(mjml string) (*mjmlNode, error) {
+	decoder := xml.NewDecoder(strings.NewReader(mjml))
+	decoder.Strict = false
+	decoder.AutoClose = xml.HTMLAutoClose
+	decoder.Entity = xml.HTMLEntity
+
+	document := &mjmlNode{}
+	stack := []*mjmlNode{document}
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.ThrowInvalidArgument(err, "TMPL-Mj3pf", "Errors.MailTemplate.MJMLInvalid")
+		}
+		parent := stack[len(stack)-1]
+		switch t := token.(type) {
+		case xml.StartElement:
+			node, err := newMJMLNode(parent, t)
+			if err != nil {
+				return nil, err
+			}
+			parent.children = append(parent.children, node)
+			if !mjmlElements[node.name].ending {
+				stack = append(stack, node)
+				continue
+			}
+			start := decoder.InputOffset()
+			if err = decoder.Skip(); err != nil {
+				return nil, errors.ThrowInvalidArgument(err, "TMPL-Mj8sk", "Errors.MailTemplate.MJMLInvalid")
+			}
+			content := mjml[start:decoder.InputOffset()]
+			if end := strings.LastIndex(content, "</"); end >= 0 {
+				node.content = content[:end]
+			}
+		case xml.EndElement:
+			if len(stack) > 1 {
+				stack = stack[:len(stack)-1]
+			}
+		case xml.CharData:
+			if parent == document {
+				if strings.TrimSpace(string(t)) != "" {
+					return nil, errors.ThrowInvalidArgument(nil, "TMPL-Mj2vt", "Errors.MailTemplate.MJMLInvalid")
+				}
+				continue
+			}
+			parent.children = append(parent.children, &mjmlNode{content: string(t)})
+		}
+	}
+	if len(document.children) != 1 || document.children[0].name != "mjml" {
+		return nil, errors.ThrowInvalidArgument(nil, "TMPL-Mj6rw", "Errors.MailTemplate.MJMLInvalid")
+	}
+	if document.children[0].child("mj-body") == nil {
+		return nil, errors.ThrowInvalidArgument(nil, "TMPL-Mj0bd", "Errors.MailTemplate.MJMLInvalid")
+	}
+	return document.children[0], nil
+}
+
+func newMJMLNode(parent *mjmlNode, start xml.StartElement) (*mjmlNode, error) {
+	name := start.Name.Local
+	if start.Name.Space != "" {
+		name = start.Name.Space + ":" + name
+	}
+	if _, ok := mjmlElements[name]; !ok {
+		return nil, errors.ThrowInvalidArgument(fmt.Errorf("unknown element %s", name), "TMPL-Mj4uk", "Errors.MailTemplate.MJMLInvalid")
+	}
+	if !mjmlIsAllowedChild(parent, name) {
+		return nil, errors.ThrowInvalidArgument(fmt.Errorf("element %s not allowed in %s", name, parent.name), "TMPL-Mj9cp", "Errors.MailTemplate.MJMLInvalid")
+	}
+	node := &mjmlNode{
+		name:  name,
+		attrs: make(map[string]string, len(start.Attr)),
+	}
+	for _, attr := range start.Attr {
+		node.attrs[attr.Name.Local] = attr.Value
+	}
+	return node, nil
+}
+
+func mjmlIsAllowedChild(parent *mjmlNode, name string) bool {
+	switch parent.name {
+	case "":
+		return name == "mjml"
+	case "mj-attributes":
+		return true
+	}
+	for _, child := range mjmlElements[parent.name].children {
+		if child == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (n *mjmlNode) child(name string) *mjmlNode {
+	for _, child := range n.children {
+		if child.name == name {
+			return child
+		}
+	}
+	return nil
+}
+
+type mjmlCompiler struct {
+	defaults      map[string]map[string]string
+	classes       map[string]map[string]string
+	title         string
+	preview       string
+	breakpoint    string
+	fonts         []string
+	styles        []string
+	headRaw       []string
+	columnClasses map[string]string
+}
+
+func newMJMLCompiler() *mjmlCompiler {
+	return &mjmlCompiler{
+		defaults:      make(map[string]map[string]string),
+		classes:       make(map[string]map[string]string),
+		breakpoint:    mjmlDefaultBreakpoint,
+		columnClasses: make(map[string]string),
+	}
+}
+
+func (c *mjmlCompiler) compile(mjml *mjmlNode) (string, error) {
+	if head := mjml.child("mj-head"); head != nil {
+		c.readHead(head)
+	}
+	body := mjml.child("mj-body")
+	width := pixels(c.attr(body, "width"), mjmlDefaultBodyWidth)
+
+	content := new(strings.Builder)
+	for _, child := range body.children {
+		c.renderBodyChild(content, child, width)
+	}
+
+	html := new(strings.Builder)
+	html.WriteString(`<!doctype html>
+<html xmlns="http://www.w3.org/1999/xhtml" xmlns:v="urn:schemas-microsoft-com:vml" xmlns:o="urn:schemas-microsoft-com:office:office">
+<head>
+<title>` + c.title + `</title>
+<meta http-equiv="X-UA-Compatible" content="IE=edge">
+<meta http-equiv="Content-Type" content="text/html; charset=UTF-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<style type="text/css">
+#outlook a { padding:0; }
+body { margin:0;padding:0;-webkit-text-size-adjust:100%;-ms-text-size-adjust:100%; }
+table, td { border-collapse:collapse;mso-table-lspace:0pt;mso-table-rspace:0pt; }
+img { border:0;height:auto;line-height:100%; outline:none;text-decoration:none;-ms-interpolation-mode:bicubic; }
+p { display:block;margin:13px 0; }
+</style>
+`)
+	for _, font := range c.fonts {
+		html.WriteString(`<link href="` + font + `" rel="stylesheet" type="text/css">` + "\n")
+	}
+	if len(c.columnClasses) > 0 {
+		html.WriteString("<style type=\"text/css\">\n@media only screen and (min-width:" + c.breakpoint + ") {\n")
+		classes := make([]string, 0, len(c.columnClasses))
+		for class := range c.columnClasses {
+			classes = append(classes, class)
+		}
+		sort.Strings(classes)
+		for _, class := range classes {
+			width := c.columnClasses[class]
+			html.WriteString("." + class + " { width:" + width + " !important; max-width: " + width + "; }\n")
+		}
+		html.WriteString("}\n</style>\n")
+	}
+	for _, style := range c.styles {
+		html.WriteString("<style type=\"text/css\">" + style + "</style>\n")
+	}
+	for _, raw := range c.headRaw {
+		html.WriteString(raw + "\n")
+	}
+	html.WriteString("</head>\n")
+	html.WriteString(`<body style="word-spacing:normal;` + styleProperty("background-color", c.attr(body, "background-color")) + `">` + "\n")
+	if c.preview != "" {
+		html.WriteString(`<div style="display:none;font-size:1px;color:#ffffff;line-height:1px;max-height:0px;max-width:0px;opacity:0;overflow:hidden;">` + c.preview + "</div>\n")
+	}
+	if background := styleProperty("background-color", c.attr(body, "background-color")); background != "" {
+		html.WriteString(`<div style="` + background + `">` + "\n")
+	} else {
+		html.WriteString("<div>\n")
+	}
+	html.WriteString(content.String())
+	html.WriteString("</div>\n</body>\n</html>\n")
+	return html.String(), nil
+}
+
+func (c *mjmlCompiler) readHead(head *mjmlNode) {
+	for _, child := range head.children {
+		switch child.name {
+		case "mj-attributes":
+			c.readAttributes(child)
+		case "mj-font":
+			if child.attrs["href"] != "" {
+				c.fonts = append(c.fonts, escapeAttr(child.attrs["href"]))
+			}
+		case "mj-style":
+			c.styles = append(c.styles, child.content)
+		case "mj-title":
+			c.title = child.content
+		case "mj-preview":
+			c.preview = child.content
+		case "mj-breakpoint":
+			if width := child.attrs["width"]; width != "" {
+				c.breakpoint = width
+			}
+		case "mj-raw":
+			c.headRaw = append(c.headRaw, child.content)
+		}
+	}
+}
+
+func (c *mjmlCompiler) readAttributes(attributes *mjmlNode) {
+	for _, child := range attributes.children {
+		switch child.name {
+		case "":
+			continue
+		case "mj-font":
+			if child.attrs["href"] != "" {
+				c.fonts = append(c.fonts, escapeAttr(child.attrs["href"]))
+			}
+		case "mj-class":
+			name := child.attrs["name"]
+			if c.classes[name] == nil {
+				c.classes[name] = make(map[string]string)
+			}
+			for key, value := range child.attrs {
+				if key != "name" {
+					c.classes[name][key] = value
+				}
+			}
+		default:
+			if c.defaults[child.name] == nil {
+				c.defaults[child.name] = make(map[string]string)
+			}
+			for key, value := range child.attrs {
+				c.defaults[child.name][key] = value
+			}
+		}
+	}
+}
+
+// attr returns the value of the attribute of the node in the following order:
+// attribute of the element, mj-class, mj-attributes of the element, mj-all, default of the element
+func (c *mjmlCompiler) attr(n *mjmlNode, key string) string {
+	if value, ok := n.attrs[key]; ok {
+		return value
+	}
+	for _, class := range strings.Fields(n.attrs["mj-class"]) {
+		if value, ok := c.classes[class][key]; ok {
+			return value
+		}
+	}
+	if value, ok := c.defaults[n.name][key]; ok {
+		return value
+	}
+	if value, ok := c.defaults["mj-all"][key]; ok {
+		return value
+	}
+	return mjmlElements[n.name].defaults[key]
+}
+
+func (c *mjmlCompiler) padding(n *mjmlNode) string {
+	return styleProperty("padding", c.attr(n, "padding")) +
+		styleProperty("padding-top", c.attr(n, "padding-top")) +
+		styleProperty("padding-right", c.attr(n, "padding-right")) +
+		styleProperty("padding-bottom", c.attr(n, "padding-bottom")) +
+		styleProperty("padding-left", c.attr(n, "padding-left"))
+}
+
+// horizontalPadding returns the sum of the left and right padding in pixels
+func (c *mjmlCompiler) horizontalPadding(n *mjmlNode) int {
+	var left, right int
+	values := strings.Fields(c.attr(n, "padding"))
+	switch len(values) {
+	case 1:
+		left, right = pixels(values[0], 0), pixels(values[0], 0)
+	case 2, 3:
+		left, right = pixels(values[1], 0), pixels(values[1], 0)
+	case 4:
+		right, left = pixels(values[1], 0), pixels(values[3], 0)
+	}
+	if value := c.attr(n, "padding-left"); value != "" {
+		left = pixels(value, 0)
+	}
+	if value := c.attr(n, "padding-right"); value != "" {
+		right = pixels(value, 0)
+	}
+	return left + right
+}
+
+func (c *mjmlCompiler) renderBodyChild(b *strings.Builder, n *mjmlNode, width int) {
+	switch n.name {
+	case "":
+		writeText(b, n.content)
+	case "mj-raw":
+		b.WriteString(n.content + "\n")
+	case "mj-wrapper":
+		c.renderSection(b, n, width, func(inner int) {
+			for _, child := range n.children {
+				c.renderBodyChild(b, child, inner)
+			}
+		})
+	case "mj-section":
+		c.renderSection(b, n, width, func(inner int) {
+			c.renderColumns(b, n, inner)
+		})
+	}
+}
+
+func (c *mjmlCompiler) renderSection(b *strings.Builder, n *mjmlNode, width int, renderChildren func(inner int)) {
+	background := styleProperty("background", c.attr(n, "background-color")) + styleProperty("background-color", c.attr(n, "background-color"))
+	radius := styleProperty("border-radius", c.attr(n, "border-radius"))
+	fullWidth := c.attr(n, "full-width") == "full-width"
+	if fullWidth {
+		b.WriteString(`<table align="center" border="0" cellpadding="0" cellspacing="0" role="presentation" style="` + background + `width:100%;` + radius + `"><tbody><tr><td>` + "\n")
+		background = ""
+	}
+	b.WriteString(`<div` + c.cssClass(n) + ` style="margin:0px auto;` + background + radius + `max-width:` + strconv.Itoa(width) + `px;">` + "\n")
+	b.WriteString(`<table align="center" border="0" cellpadding="0" cellspacing="0" role="presentation" style="` + background + `width:100%;` + radius + `"><tbody><tr>` + "\n")
+	b.WriteString(`<td style="` + styleProperty("direction", c.attr(n, "direction")) + `font-size:0px;` + c.padding(n) + styleProperty("text-align", c.attr(n, "text-align")) + `">` + "\n")
+	renderChildren(width - c.horizontalPadding(n))
+	b.WriteString("</td>\n</tr></tbody></table>\n</div>\n")
+	if fullWidth {
+		b.WriteString("</td></tr></tbody></table>\n")
+	}
+}
+
+// renderColumns renders the columns and groups of the section,
+// columns without width share the remaining width equally
+func (c *mjmlCompiler) renderColumns(b *strings.Builder, section *mjmlNode, width int) {
+	widths := c.columnWidths(section, width)
+	for _, child := range section.children {
+		switch child.name {
+		case "":
+			writeText(b, child.content)
+		case "mj-raw":
+			b.WriteString(child.content + "\n")
+		case "mj-column":
+			c.renderColumn(b, child, widths[child], width, false)
+		case "mj-group":
+			c.renderGroup(b, child, widths[child], width)
+		}
+	}
+}
+
+type mjmlWidth struct {
+	value  float64
+	isUnit bool // value is in pixels instead of percent
+}
+
+func (w mjmlWidth) pixels(parent int) int {
+	if w.isUnit {
+		return int(w.value)
+	}
+	return int(float64(parent) * w.value / 100)
+}
+
+func (w mjmlWidth) String() string {
+	if w.isUnit {
+		return strconv.FormatFloat(w.value, 'f', -1, 64) + "px"
+	}
+	return strconv.FormatFloat(w.value, 'f', -1, 64) + "%"
+}
+
+func (w mjmlWidth) class() string {
+	if w.isUnit {
+		return "mj-column-px-" + strings.ReplaceAll(strconv.FormatFloat(w.value, 'f', -1, 64), ".", "-")
+	}
+	return "mj-column-per-" + strings.ReplaceAll(strconv.FormatFloat(w.value, 'f', -1, 64), ".", "-")
+}
+
+func (c *mjmlCompiler) columnWidths(parent *mjmlNode, width int) map[*mjmlNode]mjmlWidth {
+	widths := make(map[*mjmlNode]mjmlWidth)
+	var unspecified []*mjmlNode
+	var used float64
+	for _, child := range parent.children {
+		if child.name != "mj-column" && child.name != "mj-group" {
+			continue
+		}
+		value := c.attr(child, "width")
+		switch {
+		case strings.HasSuffix(value, "%"):
+			percent, err := strconv.ParseFloat(strings.TrimSuffix(value, "%"), 64)
+			if err != nil {
+				unspecified = append(unspecified, child)
+				continue
+			}
+			widths[child] = mjmlWidth{value: percent}
+			used += percent
+		case strings.HasSuffix(value, "px"):
+			px := pixels(value, 0)
+			widths[child] = mjmlWidth{value: float64(px), isUnit: true}
+			if width > 0 {
+				used += float64(px) * 100 / float64(width)
+			}
+		default:
+			unspecified = append(unspecified, child)
+		}
+	}
+	if len(unspecified) == 0 {
+		return widths
+	}
+	remaining := 100 - used
+	if remaining < 0 {
+		remaining = 0
+	}
+	share := float64(int(remaining/float64(len(unspecified))*100)) / 100
+	for _, child := range unspecified {
+		widths[child] = mjmlWidth{value: share}
+	}
+	return widths
+}
+
+// renderColumn renders the column with its content elements,
+// columns inside a group keep their width on small screens
+func (c *mjmlCompiler) renderColumn(b *strings.Builder, n *mjmlNode, width mjmlWidth, parentWidth int, inGroup bool) {
+	class := width.class()
+	style := "width:100%;"
+	if inGroup {
+		style = "width:" + width.String() + ";"
+	} else {
+		c.columnClasses[class] = width.String()
+	}
+	verticalAlign := styleProperty("vertical-align", c.attr(n, "vertical-align"))
+	b.WriteString(`<div class="` + class + ` mj-outlook-group-fix` + c.cssClassNames(n) + `" style="font-size:0px;text-align:left;` + styleProperty("direction", c.attr(n, "direction")) + `display:inline-block;` + verticalAlign + style + `">` + "\n")
+
+	columnWidth := width.pixels(parentWidth)
+	padding := c.padding(n)
+	if padding != "" {
+		columnWidth -= c.horizontalPadding(n)
+		b.WriteString(`<table border="0" cellpadding="0" cellspacing="0" role="presentation" width="100%"><tbody><tr><td style="` + padding + verticalAlign + `">` + "\n")
+	}
+	b.WriteString(`<table border="0" cellpadding="0" cellspacing="0" role="presentation" style="` +
+		styleProperty("background-color", c.attr(n, "background-color")) +
+		styleProperty("border-radius", c.attr(n, "border-radius")) +
+		verticalAlign + `" width="100%"><tbody>` + "\n")
+	for _, child := range n.children {
+		c.renderContent(b, child, columnWidth)
+	}
+	b.WriteString("</tbody></table>\n")
+	if padding != "" {
+		b.WriteString("</td></tr></tbody></table>\n")
+	}
+	b.WriteString("</div>\n")
+}
+
+func (c *mjmlCompiler) renderGroup(b *strings.Builder, n *mjmlNode, width mjmlWidth, parentWidth int) {
+	class := width.class()
+	c.columnClasses[class] = width.String()
+	b.WriteString(`<div class="` + class + ` mj-outlook-group-fix` + c.cssClassNames(n) + `" style="font-size:0;line-height:0;text-align:left;display:inline-block;width:100%;` +
+		styleProperty("direction", c.attr(n, "direction")) + styleProperty("background-color", c.attr(n, "background-color")) + `">` + "\n")
+	groupWidth := width.pixels(parentWidth)
+	widths := c.columnWidths(n, groupWidth)
+	for _, child := range n.children {
+		switch child.name {
+		case "":
+			writeText(b, child.content)
+		case "mj-raw":
+			b.WriteString(child.content + "\n")
+		case "mj-column":
+			c.renderColumn(b, child, widths[child], groupWidth, true)
+		}
+	}
+	b.WriteString("</div>\n")
+}
+
+func (c *mjmlCompiler) renderContent(b *strings.Builder, n *mjmlNode, width int) {
+	switch n.name {
+	case "":
+		writeText(b, n.content)
+		return
+	case "mj-raw":
+		b.WriteString(n.content + "\n")
+		return
+	case "mj-spacer":
+		height := c.attr(n, "height")
+		b.WriteString(`<tr><td` + c.cssClass(n) + ` style="font-size:0px;` + c.padding(n) + `word-break:break-word;">` +
+			`<div style="` + styleProperty("height", height) + styleProperty("line-height", height) + `">&#8202;</div></td></tr>` + "\n")
+		return
+	}
+	b.WriteString(`<tr><td align="` + escapeAttr(c.attr(n, "align")) + `"` + c.cssClass(n) + ` style="font-size:0px;` + c.padding(n) + `word-break:break-word;">` + "\n")
+	inner := width - c.horizontalPadding(n)
+	switch n.name {
+	case "mj-text":
+		c.renderText(b, n)
+	case "mj-button":
+		c.renderButton(b, n)
+	case "mj-image":
+		c.renderImage(b, n, inner)
+	case "mj-divider":
+		c.renderDivider(b, n)
+	}
+	b.WriteString("</td></tr>\n")
+}
+
+func (c *mjmlCompiler) renderText(b *strings.Builder, n *mjmlNode) {
+	b.WriteString(`<div style="` +
+		styleProperty("font-family", c.attr(n, "font-family")) +
+		styleProperty("font-size", c.attr(n, "font-size")) +
+		styleProperty("font-style", c.attr(n, "font-style")) +
+		styleProperty("font-weight", c.attr(n, "font-weight")) +
+		styleProperty("letter-spacing", c.attr(n, "letter-spacing")) +
+		styleProperty("line-height", c.attr(n, "line-height")) +
+		styleProperty("text-align", c.attr(n, "align")) +
+		styleProperty("text-decoration", c.attr(n, "text-decoration")) +
+		styleProperty("text-transform", c.attr(n, "text-transform")) +
+		styleProperty("color", c.attr(n, "color")) +
+		styleProperty("height", c.attr(n, "height")) +
+		`">` + n.content + "</div>\n")
+}
+
+func (c *mjmlCompiler) renderButton(b *strings.Builder, n *mjmlNode) {
+	background := c.attr(n, "background-color")
+	radius := styleProperty("border-radius", c.attr(n, "border-radius"))
+	innerPadding := c.attr(n, "inner-padding")
+	tag := "p"
+	link := ""
+	if href := c.attr(n, "href"); href != "" {
+		tag = "a"
+		link = ` href="` + escapeAttr(href) + `"` +
+			attribute("rel", c.attr(n, "rel")) +
+			attribute("target", c.attr(n, "target")) +
+			attribute("title", c.attr(n, "title"))
+	}
+	b.WriteString(`<table border="0" cellpadding="0" cellspacing="0" role="presentation" style="border-collapse:separate;` + styleProperty("width", c.attr(n, "width")) + `line-height:100%;"><tbody><tr>` + "\n")
+	b.WriteString(`<td align="center" bgcolor="` + escapeAttr(background) + `" role="presentation" style="` +
+		styleProperty("border", c.attr(n, "border")) + radius + `cursor:auto;` +
+		styleProperty("mso-padding-alt", innerPadding) + styleProperty("background", background) + `" valign="` + escapeAttr(c.attr(n, "vertical-align")) + `">` + "\n")
+	b.WriteString(`<` + tag + link + ` style="display:inline-block;` +
+		styleProperty("width", c.attr(n, "width")) +
+		styleProperty("background", background) +
+		styleProperty("color", c.attr(n, "color")) +
+		styleProperty("font-family", c.attr(n, "font-family")) +
+		styleProperty("font-size", c.attr(n, "font-size")) +
+		styleProperty("font-style", c.attr(n, "font-style")) +
+		styleProperty("font-weight", c.attr(n, "font-weight")) +
+		styleProperty("line-height", c.attr(n, "line-height")) +
+		styleProperty("letter-spacing", c.attr(n, "letter-spacing")) +
+		`margin:0;` +
+		styleProperty("text-decoration", c.attr(n, "text-decoration")) +
+		styleProperty("text-transform", c.attr(n, "text-transform")) +
+		styleProperty("padding", innerPadding) +
+		`mso-padding-alt:0px;` + radius + `">` + n.content + `</` + tag + ">\n")
+	b.WriteString("</td>\n</tr></tbody></table>\n")
+}
+
+func (c *mjmlCompiler) renderImage(b *strings.Builder, n *mjmlNode, width int) {
+	if value := c.attr(n, "width"); value != "" {
+		if px := pixels(value, 0); px > 0 && px < width {
+			width = px
+		}
+	}
+	height := c.attr(n, "height")
+	heightAttr := "auto"
+	if height != "auto" {
+		heightAttr = strconv.Itoa(pixels(height, 0))
+	}
+	image := `<img` + attribute("alt", c.attr(n, "alt")) + ` src="` + escapeAttr(c.attr(n, "src")) + `"` +
+		attribute("title", c.attr(n, "title")) +
+		` height="` + heightAttr + `" style="border:0;` + styleProperty("border-radius", c.attr(n, "border-radius")) +
+		`display:block;outline:none;text-decoration:none;` + styleProperty("height", height) + `width:100%;font-size:13px;" width="` + strconv.Itoa(width) + `">`
+	if href := c.attr(n, "href"); href != "" {
+		image = `<a href="` + escapeAttr(href) + `"` + attribute("rel", c.attr(n, "rel")) + attribute("target", c.attr(n, "target")) + `>` + image + `</a>`
+	}
+	b.WriteString(`<table border="0" cellpadding="0" cellspacing="0" role="presentation" style="border-collapse:collapse;border-spacing:0px;"><tbody><tr>` + "\n")
+	b.WriteString(`<td style="width:` + strconv.Itoa(width) + `px;">` + image + "</td>\n")
+	b.WriteString("</tr></tbody></table>\n")
+}
+
+func (c *mjmlCompiler) renderDivider(b *strings.Builder, n *mjmlNode) {
+	margin := "margin:0px auto;"
+	switch c.attr(n, "align") {
+	case "left":
+		margin = "margin:0px;"
+	case "right":
+		margin = "margin:0px 0px 0px auto;"
+	}
+	b.WriteString(`<p style="border-top:` + escapeAttr(c.attr(n, "border-style")+" "+c.attr(n, "border-width")+" "+c.attr(n, "border-color")) + `;font-size:1px;` + margin + styleProperty("width", c.attr(n, "width")) + `"></p>` + "\n")
+}
+
+func (c *mjmlCompiler) cssClass(n *mjmlNode) string {
+	return attribute("class", c.attr(n, "css-class"))
+}
+
+func (c *mjmlCompiler) cssClassNames(n *mjmlNode) string {
+	if class := c.attr(n, "css-class"); class != "" {
+		return " " + escapeAttr(class)
+	}
+	return ""
+}
+
+func writeText(b *strings.Builder, text string) {
+	if trimmed := strings.TrimSpace(text); trimmed != "" {
+		b.WriteString(escapeText(trimmed) + "\n")
+	}
+}
+
+func styleProperty(key, value string) string {
+	if value == "" {
+		return ""
+	}
+	return key + ":" + escapeAttr(value) + ";"
+}
+
+func attribute(key, value string) string {
+	if value == "" {
+		return ""
+	}
+	return " " + key + `="` + escapeAttr(value) + `"`
+}
+
+// pixels parses values like "600px" or "600" and returns the fallback if the value is not a pixel value
+func pixels(value string, fallback int) int {
+	px, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(value), "px"), 64)
+	if err != nil {
+		return fallback
+	}
+	return int(px)
+}
+
+var (
+	attrReplacer = strings.NewReplacer(`&`, "&amp;", `"`, "&#34;", `<`, "&lt;", `>`, "&gt;")
+	textReplacer = strings.NewReplacer(`&`, "&amp;", `<`, "&lt;", `>`, "&gt;")
+)
+
+func escapeAttr(value string) string {
+	return escapeOutsideActions(value, attrReplacer)
+}
+
+func escapeText(value string) string {
+	return escapeOutsideActions(value, textReplacer)
+}
+
+// escapeOutsideActions escapes the value, but keeps Go template actions as they are
+func escapeOutsideActions(value string, replacer *strings.Replacer) string {
+	b := new(strings.Builder)
+	for {
+		start := strings.Index(value, "{{")
+		if start < 0 {
+			b.WriteString(replacer.Replace(value))
+			return b.String()
+		}
+		end := strings.Index(value[start:], "}}")
+		if end < 0 {
+			b.WriteString(replacer.Replace(value))
+			return b.String()
+		}
+		end += start + 2
+		b.WriteString(replacer.Replace(value[:start]))
+		b.WriteString(value[start:end])
+		value = value[end:]
+	}
+}
@@ -0,0 +1,147 @@
+package templates
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+func TestCompileMJML(t *testing.T) {
+	type res struct {
+		contains []string
+		err      func(error) bool
+	}
+	tests := []struct {
+		name string
+		mjml string
+		res  res
+	}{
+		{
+			name: "no mjml root, invalid argument error",
+			mjml: `<mj-body></mj-body>`,
+			res: res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "no body, invalid argument error",
+			mjml: `<mjml><mj-head></mj-head></mjml>`,
+			res: res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "text outside of root, invalid argument error",
+			mjml: `text<mjml><mj-body></mj-body></mjml>`,
+			res: res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "unknown element, invalid argument error",
+			mjml: `<mjml><mj-body><mj-carousel></mj-carousel></mj-body></mjml>`,
+			res: res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "element not allowed in parent, invalid argument error",
+			mjml: `<mjml><mj-body><mj-text>text</mj-text></mj-body></mjml>`,
+			res: res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "text with html content, ok",
+			mjml: `<mjml><mj-body><mj-section><mj-column><mj-text color="#ff0000">{{.Text}}<br><b>bold &amp; fat</b></mj-text></mj-column></mj-section></mj-body></mjml>`,
+			res: res{
+				contains: []string{
+					`color:#ff0000;">{{.Text}}<br><b>bold &amp; fat</b></div>`,
+					`.mj-column-per-100 { width:100% !important; max-width: 100%; }`,
+				},
+			},
+		},
+		{
+			name: "attributes of head, ok",
+			mjml: `<mjml>
+	<mj-head>
+		<mj-title>{{.Title}}</mj-title>
+		<mj-attributes>
+			<mj-all font-family="Arial" />
+			<mj-class name="red" color="#ff0000" />
+			<mj-text font-size="20px" />
+		</mj-attributes>
+	</mj-head>
+	<mj-body>
+		<mj-section>
+			<mj-column><mj-text mj-class="red">{{.Greeting}}</mj-text></mj-column>
+			<mj-column><mj-text mj-class="red" color="#0000ff" font-family="{{.FontFamily}}">{{.Text}}</mj-text></mj-column>
+		</mj-section>
+	</mj-body>
+</mjml>`,
+			res: res{
+				contains: []string{
+					`<title>{{.Title}}</title>`,
+					`font-family:Arial;font-size:20px;line-height:1;text-align:left;color:#ff0000;">{{.Greeting}}</div>`,
+					`font-family:{{.FontFamily}};font-size:20px;line-height:1;text-align:left;color:#0000ff;">{{.Text}}</div>`,
+					`.mj-column-per-50 { width:50% !important; max-width: 50%; }`,
+				},
+			},
+		},
+		{
+			name: "template actions between elements, ok",
+			mjml: `<mjml><mj-body><mj-section><mj-column>{{if .LogoURL}}<mj-image src="{{.LogoURL}}" width="100px" />{{end}}<mj-button href="{{.URL}}">{{.ButtonText}}</mj-button></mj-column></mj-section></mj-body></mjml>`,
+			res: res{
+				contains: []string{
+					"{{if .LogoURL}}\n<tr>",
+					`<img src="{{.LogoURL}}" height="auto"`,
+					`width="100">`,
+					"{{end}}\n<tr>",
+					`<a href="{{.URL}}" target="_blank"`,
+					`>{{.ButtonText}}</a>`,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CompileMJML(tt.mjml)
+			if tt.res.err == nil {
+				require.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			for _, expected := range tt.res.contains {
+				assert.Contains(t, got, expected)
+			}
+		})
+	}
+}
+
+func TestCompileMJML_defaultTemplate(t *testing.T) {
+	mjml, err := os.ReadFile("../static/templates/template.mjml")
+	require.NoError(t, err)
+
+	html, err := CompileMJML(string(mjml))
+	require.NoError(t, err)
+	require.NoError(t, ValidateTemplate(html))
+
+	got, err := GetParsedTemplate(html, exampleTemplateData)
+	require.NoError(t, err)
+	for _, expected := range []string{
+		exampleTemplateData.Greeting,
+		exampleTemplateData.Text,
+		exampleTemplateData.ButtonText,
+		exampleTemplateData.FooterText,
+		exampleTemplateData.LogoURL,
+		`href="https://example.com"`,
+	} {
+		assert.True(t, strings.Contains(got, expected), "%s not rendered", expected)
+	}
+}
@@ -0,0 +1,35 @@
+package notification
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/notification/channels/httpapi"
+	"github.com/zitadel/zitadel/internal/notification/handlers"
+	"github.com/zitadel/zitadel/internal/notification/messages"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+const (
+	testEmailSubject = "ZITADEL - Test Email"
+	testEmailContent = "<html><body><p>This is a test email sent by ZITADEL to verify the configuration of the email provider.</p></body></html>"
+)
+
+// SendTestEmail sends a test email to the recipient through the email provider,
+// it doesn't fail over to other providers and the provider doesn't need to be active
+func SendTestEmail(ctx context.Context, queries *query.Queries, smtpEncryption crypto.EncryptionAlgorithm, providerID, recipient string) error {
+	notificationQueries := handlers.NewNotificationQueries(queries, nil, 0, false, "", nil, smtpEncryption, nil, nil, nil)
+	config, err := notificationQueries.GetEmailProviderByID(ctx, providerID)
+	if err != nil {
+		return err
+	}
+	channel, err := httpapi.InitChannel(ctx, *config)
+	if err != nil {
+		return err
+	}
+	return channel.HandleMessage(&messages.Email{
+		Recipients: []string{recipient},
+		Subject:    testEmailSubject,
+		Content:    testEmailContent,
+	})
+}
@@ -0,0 +1,42 @@
+package types
+
+import (
+	"html"
+
+	"github.com/zitadel/zitadel/internal/i18n"
+	"github.com/zitadel/zitadel/internal/notification/templates"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+// EmailPreview is the rendered email of a message
+type EmailPreview struct {
+	Subject string
+	Content string
+}
+
+// PreviewEmail returns a Notify, which renders the email into the preview instead of sending it
+func PreviewEmail(
+	mailhtml string,
+	translator *i18n.Translator,
+	user *query.NotifyUser,
+	colors *query.LabelPolicy,
+	assetsPrefix string,
+	preview *EmailPreview,
+) Notify {
+	return func(
+		url string,
+		args map[string]interface{},
+		messageType string,
+		_ bool,
+	) error {
+		args = mapNotifyUserToArgs(user, args)
+		data := GetTemplateData(translator, args, assetsPrefix, url, messageType, user.PreferredLanguage.String(), colors)
+		template, err := templates.GetParsedTemplate(mailhtml, data)
+		if err != nil {
+			return err
+		}
+		preview.Subject = data.Subject
+		preview.Content = html.UnescapeString(template)
+		return nil
+	}
+}
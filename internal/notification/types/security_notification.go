@@ -0,0 +1,15 @@
+package types
+
+import (
+	"github.com/zitadel/zitadel/internal/api/ui/console"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+// SendSecurityNotification sends the security notification of the messageType to the verified email of the user
+func (notify Notify) SendSecurityNotification(user *query.NotifyUser, origin, messageType string, args map[string]interface{}) error {
+	url := console.LoginHintLink(origin, user.PreferredLoginName)
+	if args == nil {
+		args = make(map[string]interface{})
+	}
+	return notify(url, args, messageType, false)
+}
@@ -4,9 +4,12 @@ import (
 	"context"
 	"html"
 
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/notification/channels/fs"
+	"github.com/zitadel/zitadel/internal/notification/channels/httpapi"
 	"github.com/zitadel/zitadel/internal/notification/channels/log"
 	"github.com/zitadel/zitadel/internal/notification/channels/smtp"
 	"github.com/zitadel/zitadel/internal/notification/messages"
@@ -18,29 +21,43 @@ func generateEmail(
 	ctx context.Context,
 	user *query.NotifyUser,
 	subject,
-	content string,
-	smtpConfig func(ctx context.Context) (*smtp.Config, error),
-	getFileSystemProvider func(ctx context.Context) (*fs.Config, error),
-	getLogProvider func(ctx context.Context) (*log.Config, error),
+	content,
+	messageType string,
 	lastEmail bool,
 	triggeringEvent eventstore.Event,
-	successMetricName,
-	failureMetricName string,
+	queue QueueMessage,
 ) error {
-	content = html.UnescapeString(content)
-	message := &messages.Email{
-		Recipients:      []string{user.VerifiedEmail},
-		Subject:         subject,
-		Content:         content,
-		TriggeringEvent: triggeringEvent,
-	}
+	recipient := user.VerifiedEmail
 	if lastEmail {
-		message.Recipients = []string{user.LastEmail}
+		recipient = user.LastEmail
 	}
+	return queue(ctx, &command.NotificationMessage{
+		UserID:          user.ID,
+		ResourceOwner:   user.ResourceOwner,
+		MessageType:     messageType,
+		Channel:         domain.NotificationTypeEmail,
+		Recipient:       recipient,
+		Subject:         subject,
+		Content:         html.UnescapeString(content),
+		TriggeringEvent: triggeringEvent,
+	})
+}
 
+// DeliverEmail sends the queued email through the configured email channels
+func DeliverEmail(
+	ctx context.Context,
+	message *command.NotificationMessage,
+	smtpConfig func(ctx context.Context) (*smtp.Config, error),
+	getEmailProviders func(ctx context.Context) ([]*httpapi.Config, error),
+	getFileSystemProvider func(ctx context.Context) (*fs.Config, error),
+	getLogProvider func(ctx context.Context) (*log.Config, error),
+	successMetricName,
+	failureMetricName string,
+) error {
 	channelChain, err := senders.EmailChannels(
 		ctx,
 		smtpConfig,
+		getEmailProviders,
 		getFileSystemProvider,
 		getLogProvider,
 		successMetricName,
@@ -53,7 +70,12 @@ func generateEmail(
 	if channelChain.Len() == 0 {
 		return errors.ThrowPreconditionFailed(nil, "MAIL-83nof", "Errors.Notification.Channels.NotPresent")
 	}
-	return channelChain.HandleMessage(message)
+	return channelChain.HandleMessage(&messages.Email{
+		Recipients:      []string{message.Recipient},
+		Subject:         message.Subject,
+		Content:         message.Content,
+		TriggeringEvent: message.TriggeringEvent,
+	})
 }
 
 func mapNotifyUserToArgs(user *query.NotifyUser, args map[string]interface{}) map[string]interface{} {
@@ -5,6 +5,8 @@ import (
 
 	"github.com/zitadel/logging"
 
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/notification/channels/fs"
@@ -18,12 +20,34 @@ import (
 func generateSms(
 	ctx context.Context,
 	user *query.NotifyUser,
-	content string,
+	content,
+	messageType string,
+	lastPhone bool,
+	triggeringEvent eventstore.Event,
+	queue QueueMessage,
+) error {
+	recipient := user.VerifiedPhone
+	if lastPhone {
+		recipient = user.LastPhone
+	}
+	return queue(ctx, &command.NotificationMessage{
+		UserID:          user.ID,
+		ResourceOwner:   user.ResourceOwner,
+		MessageType:     messageType,
+		Channel:         domain.NotificationTypeSms,
+		Recipient:       recipient,
+		Content:         content,
+		TriggeringEvent: triggeringEvent,
+	})
+}
+
+// DeliverSMS sends the queued SMS through the configured SMS channels
+func DeliverSMS(
+	ctx context.Context,
+	message *command.NotificationMessage,
 	getTwilioProvider func(ctx context.Context) (*twilio.Config, error),
 	getFileSystemProvider func(ctx context.Context) (*fs.Config, error),
 	getLogProvider func(ctx context.Context) (*log.Config, error),
-	lastPhone bool,
-	triggeringEvent eventstore.Event,
 	successMetricName,
 	failureMetricName string,
 ) error {
@@ -32,15 +56,6 @@ func generateSms(
 	if err == nil {
 		number = twilioConfig.SenderNumber
 	}
-	message := &messages.SMS{
-		SenderPhoneNumber:    number,
-		RecipientPhoneNumber: user.VerifiedPhone,
-		Content:              content,
-		TriggeringEvent:      triggeringEvent,
-	}
-	if lastPhone {
-		message.RecipientPhoneNumber = user.LastPhone
-	}
 
 	channelChain, err := senders.SMSChannels(
 		ctx,
@@ -55,5 +70,10 @@ func generateSms(
 	if channelChain.Len() == 0 {
 		return errors.ThrowPreconditionFailed(nil, "PHONE-w8nfow", "Errors.Notification.Channels.NotPresent")
 	}
-	return channelChain.HandleMessage(message)
+	return channelChain.HandleMessage(&messages.SMS{
+		SenderPhoneNumber:    number,
+		RecipientPhoneNumber: message.Recipient,
+		Content:              message.Content,
+		TriggeringEvent:      message.TriggeringEvent,
+	})
 }
@@ -0,0 +1,23 @@
+package purge
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/query/projection"
+)
+
+type Config struct {
+	// Enabled starts the worker, which purges the removed instances and users
+	Enabled bool
+	// GracePeriod defines how long removed instances and users are kept until they are purged
+	GracePeriod time.Duration
+}
+
+// Start purges the removed instances and users in the background if the purge is enabled
+func Start(ctx context.Context, config Config, customConfig projection.CustomConfig, purger *Purger) {
+	if !config.Enabled {
+		return
+	}
+	newWorker(projection.ApplyCustomConfig(customConfig), purger).Start(ctx)
+}
@@ -0,0 +1,162 @@
+package purge
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/purge"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/static"
+)
+
+// PurgeUserID is set as editor of the purge reports
+const PurgeUserID = "PURGE"
+
+// Purger physically deletes the data of removed instances
+// and erases the personal data of removed users from the eventstore
+// after the grace period has passed
+type Purger struct {
+	eventstore  *eventstore.Eventstore
+	client      *database.DB
+	static      static.Storage
+	gracePeriod time.Duration
+}
+
+func NewPurger(
+	es *eventstore.Eventstore,
+	client *database.DB,
+	static static.Storage,
+	gracePeriod time.Duration,
+) *Purger {
+	return &Purger{
+		eventstore:  es,
+		client:      client,
+		static:      static,
+		gracePeriod: gracePeriod,
+	}
+}
+
+// PurgeInstances deletes the events, projections, views and assets of all instances
+// removed before the grace period and reports the deleted data of every instance
+func (p *Purger) PurgeInstances(ctx context.Context) ([]*purge.InstancePurgedEvent, error) {
+	ctx = purgeContext(ctx)
+	removed, err := p.eventstore.Filter(ctx, eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(instance.AggregateType).
+		EventTypes(instance.InstanceRemovedEventType).
+		Builder())
+	if err != nil {
+		return nil, err
+	}
+	until := time.Now().Add(-p.gracePeriod)
+	reports := make([]*purge.InstancePurgedEvent, 0)
+	for _, event := range removed {
+		if !event.CreationDate().Before(until) {
+			continue
+		}
+		if err = ctx.Err(); err != nil {
+			return reports, err
+		}
+		report, err := p.purgeInstance(ctx, event.Aggregate().InstanceID)
+		if err != nil {
+			return reports, err
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+func (p *Purger) purgeInstance(ctx context.Context, instanceID string) (*purge.InstancePurgedEvent, error) {
+	if err := p.static.RemoveInstanceObjects(ctx, instanceID); err != nil {
+		return nil, err
+	}
+	rows, err := p.deleteInstanceRows(ctx, instanceID)
+	if err != nil {
+		return nil, err
+	}
+	// the events are deleted last, because the removed event of the instance
+	// is needed to repeat a failed purge
+	events, err := p.eventstore.DeleteInstance(ctx, instanceID)
+	if err != nil {
+		return nil, err
+	}
+	report := purge.NewInstancePurgedEvent(ctx, &purge.NewAggregate().Aggregate, instanceID, events, rows)
+	if _, err = p.eventstore.Push(ctx, report); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// ScrubUsers erases the personal data from the events of all users removed before the grace period
+// and reports the users, if any were scrubbed.
+// The users removed before the last report are skipped
+func (p *Purger) ScrubUsers(ctx context.Context) (*purge.UsersScrubbedEvent, error) {
+	ctx = purgeContext(ctx)
+	since, err := p.scrubbedUntil(ctx)
+	if err != nil {
+		return nil, err
+	}
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		EventTypes(user.UserRemovedType)
+	if !since.IsZero() {
+		query = query.CreationDateAfter(since)
+	}
+	removed, err := p.eventstore.Filter(ctx, query.Builder())
+	if err != nil {
+		return nil, err
+	}
+	until := time.Now().Add(-p.gracePeriod)
+	users := make([]*purge.ScrubbedUser, 0)
+	for _, event := range removed {
+		if !event.CreationDate().Before(until) {
+			continue
+		}
+		if err = ctx.Err(); err != nil {
+			return nil, err
+		}
+		scrubbed, err := p.scrubUser(ctx, event.Aggregate().InstanceID, event.Aggregate().ID)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, scrubbed)
+	}
+	if len(users) == 0 {
+		return nil, nil
+	}
+	report := purge.NewUsersScrubbedEvent(ctx, &purge.NewAggregate().Aggregate, until, users)
+	if _, err = p.eventstore.Push(ctx, report); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// scrubbedUntil returns the point in time until which the removed users were scrubbed by the last run
+func (p *Purger) scrubbedUntil(ctx context.Context) (time.Time, error) {
+	events, err := p.eventstore.Filter(ctx, eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		OrderDesc().
+		Limit(1).
+		AddQuery().
+		AggregateTypes(purge.AggregateType).
+		AggregateIDs(purge.AggregateID).
+		EventTypes(purge.UsersScrubbedType).
+		Builder())
+	if err != nil || len(events) == 0 {
+		return time.Time{}, err
+	}
+	if report, ok := events[0].(*purge.UsersScrubbedEvent); ok {
+		return report.Until, nil
+	}
+	return time.Time{}, nil
+}
+
+// purgeContext removes the instance from the context,
+// so the events of all instances are filtered and the reports are pushed to the system
+func purgeContext(ctx context.Context) context.Context {
+	return authz.SetCtxData(authz.WithInstanceID(ctx, ""), authz.CtxData{UserID: PurgeUserID})
+}
@@ -0,0 +1,193 @@
+package purge
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/notification"
+	"github.com/zitadel/zitadel/internal/repository/purge"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+// personalDataFields are the fields of user events which contain personal data or credentials,
+// they are erased from all events of a removed user
+var personalDataFields = map[string]bool{
+	// profile
+	"userName":          true,
+	"firstName":         true,
+	"lastName":          true,
+	"nickName":          true,
+	"displayName":       true,
+	"gender":            true,
+	"email":             true,
+	"phone":             true,
+	"country":           true,
+	"locality":          true,
+	"postalCode":        true,
+	"region":            true,
+	"streetAddress":     true,
+	"name":              true,
+	"description":       true,
+	"webAuthNTokenName": true,
+	// the id of the user at the external identity provider
+	"userId": true,
+	// metadata
+	"value": true,
+	// browser information of the user agent
+	"userAgent":      true,
+	"remoteIP":       true,
+	"acceptLanguage": true,
+	// credentials
+	"secret":       true,
+	"otpSecret":    true,
+	"publicKey":    true,
+	"code":         true,
+	"codes":        true,
+	"clientSecret": true,
+	"refreshToken": true,
+}
+
+// messageDataFields are the fields of notification message events which contain personal data,
+// they are erased from all events of the messages sent to a removed user.
+// The runs of the LDAP synchronization are not scrubbed, their reports only contain counts and no user data
+var messageDataFields = map[string]bool{
+	"userId":    true,
+	"recipient": true,
+	// the encrypted message including the personal data of the template
+	"content": true,
+	// errors of the provider might contain the recipient
+	"error": true,
+}
+
+// scrubUser erases the personal data from all events of the user and the messages sent to the user
+// and removes the avatar of the user from the storage
+func (p *Purger) scrubUser(ctx context.Context, instanceID, userID string) (*purge.ScrubbedUser, error) {
+	events, err := p.eventstore.Filter(authz.WithInstanceID(ctx, instanceID), eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(userID).
+		Builder())
+	if err != nil {
+		return nil, err
+	}
+	scrubbed := &purge.ScrubbedUser{
+		InstanceID: instanceID,
+		UserID:     userID,
+	}
+	for _, event := range events {
+		if avatar, ok := event.(*user.HumanAvatarAddedEvent); ok {
+			err = p.static.RemoveObject(ctx, instanceID, avatar.Aggregate().ResourceOwner, avatar.StoreKey)
+			logging.WithFields("instance", instanceID, "user", userID).OnError(err).Debug("unable to remove avatar")
+		}
+		changed, err := p.scrubEvent(ctx, event, personalDataFields)
+		if err != nil {
+			return nil, err
+		}
+		if changed {
+			scrubbed.Events++
+		}
+	}
+
+	messages, err := p.userMessageEvents(authz.WithInstanceID(ctx, instanceID), userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, event := range messages {
+		changed, err := p.scrubEvent(ctx, event, messageDataFields)
+		if err != nil {
+			return nil, err
+		}
+		if changed {
+			scrubbed.Events++
+		}
+	}
+	return scrubbed, nil
+}
+
+// userMessageEvents returns all events of the notification messages queued for the user, the newest first.
+// The queued events reference the user, so they are scrubbed last and a failed run finds the messages again
+func (p *Purger) userMessageEvents(ctx context.Context, userID string) ([]eventstore.Event, error) {
+	queued, err := p.eventstore.Filter(ctx, eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(notification.AggregateType).
+		EventTypes(notification.MessageQueuedType).
+		EventData(map[string]interface{}{"userId": userID}).
+		Builder())
+	if err != nil || len(queued) == 0 {
+		return nil, err
+	}
+	messageIDs := make([]string, len(queued))
+	for i, event := range queued {
+		messageIDs[i] = event.Aggregate().ID
+	}
+	return p.eventstore.Filter(ctx, eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		OrderDesc().
+		AddQuery().
+		AggregateTypes(notification.AggregateType).
+		AggregateIDs(messageIDs...).
+		Builder())
+}
+
+// scrubEvent erases the fields from the data of the event and returns if any was erased
+func (p *Purger) scrubEvent(ctx context.Context, event eventstore.Event, fields map[string]bool) (bool, error) {
+	data, changed, err := scrubEventData(event.DataAsBytes(), fields)
+	if err != nil || !changed {
+		return false, err
+	}
+	if err = p.eventstore.UpdateEventData(ctx, event, data); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// scrubEventData returns the data of the event without the fields containing personal data
+// and if any personal data was erased
+func scrubEventData(data []byte, fields map[string]bool) ([]byte, bool, error) {
+	if len(data) == 0 {
+		return data, false, nil
+	}
+	var payload interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	// numbers are kept as they are stored
+	decoder.UseNumber()
+	if err := decoder.Decode(&payload); err != nil {
+		return nil, false, errors.ThrowInternal(err, "PURGE-Ke4wd", "unable to unmarshal event data")
+	}
+	if !scrubPersonalData(payload, fields) {
+		return data, false, nil
+	}
+	scrubbed, err := json.Marshal(payload)
+	if err != nil {
+		return nil, false, errors.ThrowInternal(err, "PURGE-Gz7sa", "unable to marshal event data")
+	}
+	return scrubbed, true, nil
+}
+
+// scrubPersonalData sets all personal data fields of the payload to null
+// and returns if any of them was set
+func scrubPersonalData(payload interface{}, fields map[string]bool) (scrubbed bool) {
+	switch value := payload.(type) {
+	case map[string]interface{}:
+		for key, field := range value {
+			if fields[key] {
+				if field != nil {
+					value[key] = nil
+					scrubbed = true
+				}
+				continue
+			}
+			scrubbed = scrubPersonalData(field, fields) || scrubbed
+		}
+	case []interface{}:
+		for _, item := range value {
+			scrubbed = scrubPersonalData(item, fields) || scrubbed
+		}
+	}
+	return scrubbed
+}
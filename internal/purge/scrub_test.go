@@ -0,0 +1,101 @@
+package purge
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_scrubEventData(t *testing.T) {
+	tests := []struct {
+		name        string
+		fields      map[string]bool
+		data        string
+		want        string
+		wantChanged bool
+	}{
+		{
+			name:        "no data",
+			fields:      personalDataFields,
+			data:        "",
+			want:        "",
+			wantChanged: false,
+		},
+		{
+			name:        "no personal data",
+			fields:      personalDataFields,
+			data:        `{"preferredLanguage":"de","changeRequired":true,"expiry":3600000000000}`,
+			want:        `{"preferredLanguage":"de","changeRequired":true,"expiry":3600000000000}`,
+			wantChanged: false,
+		},
+		{
+			name:        "already scrubbed",
+			fields:      personalDataFields,
+			data:        `{"userName":null,"email":null}`,
+			want:        `{"userName":null,"email":null}`,
+			wantChanged: false,
+		},
+		{
+			name:        "human added",
+			fields:      personalDataFields,
+			data:        `{"userName":"gigi","firstName":"Gigi","lastName":"Giraffe","displayName":"Gigi Giraffe","preferredLanguage":"en","gender":1,"email":"gigi@example.com","phone":"+41791234567","country":"CH","locality":"Zurich","postalCode":"8000","region":"ZH","streetAddress":"Main Street 1"}`,
+			want:        `{"userName":null,"firstName":null,"lastName":null,"displayName":null,"preferredLanguage":"en","gender":null,"email":null,"phone":null,"country":null,"locality":null,"postalCode":null,"region":null,"streetAddress":null}`,
+			wantChanged: true,
+		},
+		{
+			name:        "password changed",
+			fields:      personalDataFields,
+			data:        `{"secret":{"CryptoType":1,"Algorithm":"bcrypt","KeyID":"","Crypted":"aGFzaA=="},"changeRequired":false,"userAgentID":"agent"}`,
+			want:        `{"secret":null,"changeRequired":false,"userAgentID":"agent"}`,
+			wantChanged: true,
+		},
+		{
+			name:        "nested external idps",
+			fields:      personalDataFields,
+			data:        `{"userName":"gigi","externalIDPs":[{"idpConfigId":"idp1","userId":"external1"}],"loginMustBeDomain":true}`,
+			want:        `{"userName":null,"externalIDPs":[{"idpConfigId":"idp1","userId":null}],"loginMustBeDomain":true}`,
+			wantChanged: true,
+		},
+		{
+			name:        "browser info",
+			fields:      personalDataFields,
+			data:        `{"userAgentID":"agent","userAgent":"Mozilla/5.0","remoteIP":"127.0.0.1","acceptLanguage":"de-CH"}`,
+			want:        `{"userAgentID":"agent","userAgent":null,"remoteIP":null,"acceptLanguage":null}`,
+			wantChanged: true,
+		},
+		{
+			name:        "message queued",
+			fields:      messageDataFields,
+			data:        `{"userId":"user1","messageType":"InitCode","channel":0,"recipient":"gigi@example.com","content":{"cryptoType":0,"algorithm":"aes","keyID":"key1","crypted":"Y29udGVudA=="},"triggerAggregateId":"user1","triggerEventType":"user.human.initialization.code.added","triggerSequence":5}`,
+			want:        `{"userId":null,"messageType":"InitCode","channel":0,"recipient":null,"content":null,"triggerAggregateId":"user1","triggerEventType":"user.human.initialization.code.added","triggerSequence":5}`,
+			wantChanged: true,
+		},
+		{
+			name:        "message attempt failed",
+			fields:      messageDataFields,
+			data:        `{"attempt":1,"error":"mailbox gigi@example.com unavailable","nextAttempt":"2023-01-01T00:00:00Z"}`,
+			want:        `{"attempt":1,"error":null,"nextAttempt":"2023-01-01T00:00:00Z"}`,
+			wantChanged: true,
+		},
+		{
+			name:        "message sent",
+			fields:      messageDataFields,
+			data:        `{"attempt":1}`,
+			want:        `{"attempt":1}`,
+			wantChanged: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, changed, err := scrubEventData([]byte(tt.data), tt.fields)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantChanged, changed)
+			if tt.want == "" {
+				assert.Empty(t, got)
+				return
+			}
+			assert.JSONEq(t, tt.want, string(got))
+		})
+	}
+}
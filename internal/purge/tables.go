@@ -0,0 +1,76 @@
+package purge
+
+import (
+	"context"
+
+	"github.com/lib/pq"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+// instanceTablesQuery selects all tables of the projections, views and logs with an instance id,
+// the tables of a schema are ordered descending, so dependent tables like users_humans are deleted before users
+const instanceTablesQuery = `SELECT c.table_schema, c.table_name FROM information_schema.columns c
+	JOIN information_schema.tables t ON t.table_schema = c.table_schema AND t.table_name = c.table_name
+	WHERE c.column_name = 'instance_id'
+		AND t.table_type = 'BASE TABLE'
+		AND c.table_schema IN ('projections', 'auth', 'adminapi', 'logstore', 'system')
+	ORDER BY c.table_schema, c.table_name DESC`
+
+type instanceTable struct {
+	schema string
+	name   string
+}
+
+func (t *instanceTable) String() string {
+	return t.schema + "." + t.name
+}
+
+func (t *instanceTable) deleteStmt() string {
+	return "DELETE FROM " + pq.QuoteIdentifier(t.schema) + "." + pq.QuoteIdentifier(t.name) + " WHERE instance_id = $1"
+}
+
+// deleteInstanceRows deletes the rows of the instance from all projection, view and log tables
+// and returns the count of the deleted rows per table
+func (p *Purger) deleteInstanceRows(ctx context.Context, instanceID string) (map[string]int64, error) {
+	tables, err := p.instanceTables(ctx)
+	if err != nil {
+		return nil, err
+	}
+	deleted := make(map[string]int64)
+	for _, table := range tables {
+		result, err := p.client.ExecContext(ctx, table.deleteStmt(), instanceID)
+		if err != nil {
+			return nil, errors.ThrowInternal(err, "PURGE-Jm3xt", "Errors.Internal")
+		}
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return nil, errors.ThrowInternal(err, "PURGE-Fq8vn", "Errors.Internal")
+		}
+		if rows > 0 {
+			deleted[table.String()] = rows
+		}
+	}
+	return deleted, nil
+}
+
+func (p *Purger) instanceTables(ctx context.Context) (_ []*instanceTable, err error) {
+	rows, err := p.client.QueryContext(ctx, instanceTablesQuery)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "PURGE-Wd5kr", "Errors.Internal")
+	}
+	defer rows.Close()
+
+	tables := make([]*instanceTable, 0)
+	for rows.Next() {
+		table := new(instanceTable)
+		if err = rows.Scan(&table.schema, &table.name); err != nil {
+			return nil, errors.ThrowInternal(err, "PURGE-Nc2hb", "Errors.Internal")
+		}
+		tables = append(tables, table)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, errors.ThrowInternal(err, "PURGE-Ty6pq", "Errors.Internal")
+	}
+	return tables, nil
+}
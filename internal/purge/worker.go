@@ -0,0 +1,51 @@
+package purge
+
+import (
+	"context"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+)
+
+const PurgeLockName = "projections.purge_worker"
+
+// worker periodically purges the removed instances and users
+type worker struct {
+	*crdb.PeriodicWorker
+	purger *Purger
+}
+
+func newWorker(
+	config crdb.StatementHandlerConfig,
+	purger *Purger,
+) *worker {
+	w := new(worker)
+	w.PeriodicWorker = crdb.NewPeriodicWorker(config.Client.DB, config.LockTable, PurgeLockName, config.RequeueEvery, w.purge)
+	w.purger = purger
+	return w
+}
+
+// purge purges the removed instances and scrubs the removed users,
+// ctx is canceled as soon as the lock of the worker is lost
+func (w *worker) purge(ctx context.Context) {
+	instances, err := w.purger.PurgeInstances(ctx)
+	for _, instance := range instances {
+		logging.WithFields("worker", PurgeLockName, "instance", instance.InstanceID, "events", instance.Events, "rows", instance.Rows).Info("instance purged")
+	}
+	if err != nil {
+		logging.WithFields("worker", PurgeLockName).WithError(err).Error("unable to purge instances")
+		return
+	}
+
+	users, err := w.purger.ScrubUsers(ctx)
+	if err != nil {
+		logging.WithFields("worker", PurgeLockName).WithError(err).Error("unable to scrub users")
+		return
+	}
+	if users != nil {
+		for _, user := range users.Users {
+			logging.WithFields("worker", PurgeLockName, "instance", user.InstanceID, "user", user.UserID, "events", user.Events).Info("user scrubbed")
+		}
+	}
+}
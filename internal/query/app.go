@@ -40,30 +40,37 @@ type App struct {
 }
 
 type OIDCApp struct {
-	RedirectURIs             database.StringArray
-	ResponseTypes            database.EnumArray[domain.OIDCResponseType]
-	GrantTypes               database.EnumArray[domain.OIDCGrantType]
-	AppType                  domain.OIDCApplicationType
-	ClientID                 string
-	AuthMethodType           domain.OIDCAuthMethodType
-	PostLogoutRedirectURIs   database.StringArray
-	Version                  domain.OIDCVersion
-	ComplianceProblems       database.StringArray
-	IsDevMode                bool
-	AccessTokenType          domain.OIDCTokenType
-	AssertAccessTokenRole    bool
-	AssertIDTokenRole        bool
-	AssertIDTokenUserinfo    bool
-	ClockSkew                time.Duration
-	AdditionalOrigins        database.StringArray
-	AllowedOrigins           database.StringArray
-	SkipNativeAppSuccessPage bool
+	RedirectURIs               database.StringArray
+	ResponseTypes              database.EnumArray[domain.OIDCResponseType]
+	GrantTypes                 database.EnumArray[domain.OIDCGrantType]
+	AppType                    domain.OIDCApplicationType
+	ClientID                   string
+	AuthMethodType             domain.OIDCAuthMethodType
+	PostLogoutRedirectURIs     database.StringArray
+	Version                    domain.OIDCVersion
+	ComplianceProblems         database.StringArray
+	IsDevMode                  bool
+	AccessTokenType            domain.OIDCTokenType
+	AssertAccessTokenRole      bool
+	AssertIDTokenRole          bool
+	AssertIDTokenUserinfo      bool
+	ClockSkew                  time.Duration
+	AdditionalOrigins          database.StringArray
+	AllowedOrigins             database.StringArray
+	SkipNativeAppSuccessPage   bool
+	RefreshTokenReuseDetection bool
+	BackChannelLogoutURI       string
+	FrontChannelLogoutURI      string
+	SenderConstrainedTokens    bool
+	TLSClientAuthSubjectDN     string
+	RequireConsent             bool
 }
 
 type SAMLApp struct {
-	Metadata    []byte
-	MetadataURL string
-	EntityID    string
+	Metadata          []byte
+	MetadataURL       string
+	EntityID          string
+	EncryptAssertions bool
 }
 
 type APIApp struct {
@@ -152,6 +159,10 @@ var (
 		name:  projection.AppSAMLConfigColumnMetadataURL,
 		table: appSAMLConfigsTable,
 	}
+	AppSAMLConfigColumnEncryptAssertions = Column{
+		name:  projection.AppSAMLConfigColumnEncryptAssertions,
+		table: appSAMLConfigsTable,
+	}
 )
 
 var (
@@ -246,6 +257,30 @@ var (
 		name:  projection.AppOIDCConfigColumnSkipNativeAppSuccessPage,
 		table: appOIDCConfigsTable,
 	}
+	AppOIDCConfigColumnRefreshTokenReuseDetection = Column{
+		name:  projection.AppOIDCConfigColumnRefreshTokenReuseDetection,
+		table: appOIDCConfigsTable,
+	}
+	AppOIDCConfigColumnBackChannelLogoutURI = Column{
+		name:  projection.AppOIDCConfigColumnBackChannelLogoutURI,
+		table: appOIDCConfigsTable,
+	}
+	AppOIDCConfigColumnFrontChannelLogoutURI = Column{
+		name:  projection.AppOIDCConfigColumnFrontChannelLogoutURI,
+		table: appOIDCConfigsTable,
+	}
+	AppOIDCConfigColumnSenderConstrainedTokens = Column{
+		name:  projection.AppOIDCConfigColumnSenderConstrainedTokens,
+		table: appOIDCConfigsTable,
+	}
+	AppOIDCConfigColumnTLSClientAuthSubjectDN = Column{
+		name:  projection.AppOIDCConfigColumnTLSClientAuthSubjectDN,
+		table: appOIDCConfigsTable,
+	}
+	AppOIDCConfigColumnRequireConsent = Column{
+		name:  projection.AppOIDCConfigColumnRequireConsent,
+		table: appOIDCConfigsTable,
+	}
 )
 
 func (q *Queries) AppByProjectAndAppID(ctx context.Context, shouldTriggerBulk bool, projectID, appID string, withOwnerRemoved bool) (_ *App, err error) {
@@ -541,11 +576,18 @@ func prepareAppQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder,
 			AppOIDCConfigColumnClockSkew.identifier(),
 			AppOIDCConfigColumnAdditionalOrigins.identifier(),
 			AppOIDCConfigColumnSkipNativeAppSuccessPage.identifier(),
+			AppOIDCConfigColumnRefreshTokenReuseDetection.identifier(),
+			AppOIDCConfigColumnBackChannelLogoutURI.identifier(),
+			AppOIDCConfigColumnFrontChannelLogoutURI.identifier(),
+			AppOIDCConfigColumnSenderConstrainedTokens.identifier(),
+			AppOIDCConfigColumnTLSClientAuthSubjectDN.identifier(),
+			AppOIDCConfigColumnRequireConsent.identifier(),
 
 			AppSAMLConfigColumnAppID.identifier(),
 			AppSAMLConfigColumnEntityID.identifier(),
 			AppSAMLConfigColumnMetadata.identifier(),
 			AppSAMLConfigColumnMetadataURL.identifier(),
+			AppSAMLConfigColumnEncryptAssertions.identifier(),
 		).From(appsTable.identifier()).
 			LeftJoin(join(AppAPIConfigColumnAppID, AppColumnID)).
 			LeftJoin(join(AppOIDCConfigColumnAppID, AppColumnID)).
@@ -590,11 +632,18 @@ func prepareAppQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder,
 				&oidcConfig.clockSkew,
 				&oidcConfig.additionalOrigins,
 				&oidcConfig.skipNativeAppSuccessPage,
+				&oidcConfig.refreshTokenReuseDetection,
+				&oidcConfig.backChannelLogoutURI,
+				&oidcConfig.frontChannelLogoutURI,
+				&oidcConfig.senderConstrainedTokens,
+				&oidcConfig.tlsClientAuthSubjectDN,
+				&oidcConfig.requireConsent,
 
 				&samlConfig.appID,
 				&samlConfig.entityID,
 				&samlConfig.metadata,
 				&samlConfig.metadataURL,
+				&samlConfig.encryptAssertions,
 			)
 
 			if err != nil {
@@ -711,11 +760,18 @@ func prepareAppsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder
 			AppOIDCConfigColumnClockSkew.identifier(),
 			AppOIDCConfigColumnAdditionalOrigins.identifier(),
 			AppOIDCConfigColumnSkipNativeAppSuccessPage.identifier(),
+			AppOIDCConfigColumnRefreshTokenReuseDetection.identifier(),
+			AppOIDCConfigColumnBackChannelLogoutURI.identifier(),
+			AppOIDCConfigColumnFrontChannelLogoutURI.identifier(),
+			AppOIDCConfigColumnSenderConstrainedTokens.identifier(),
+			AppOIDCConfigColumnTLSClientAuthSubjectDN.identifier(),
+			AppOIDCConfigColumnRequireConsent.identifier(),
 
 			AppSAMLConfigColumnAppID.identifier(),
 			AppSAMLConfigColumnEntityID.identifier(),
 			AppSAMLConfigColumnMetadata.identifier(),
 			AppSAMLConfigColumnMetadataURL.identifier(),
+			AppSAMLConfigColumnEncryptAssertions.identifier(),
 			countColumn.identifier(),
 		).From(appsTable.identifier()).
 			LeftJoin(join(AppAPIConfigColumnAppID, AppColumnID)).
@@ -763,11 +819,18 @@ func prepareAppsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder
 					&oidcConfig.clockSkew,
 					&oidcConfig.additionalOrigins,
 					&oidcConfig.skipNativeAppSuccessPage,
+					&oidcConfig.refreshTokenReuseDetection,
+					&oidcConfig.backChannelLogoutURI,
+					&oidcConfig.frontChannelLogoutURI,
+					&oidcConfig.senderConstrainedTokens,
+					&oidcConfig.tlsClientAuthSubjectDN,
+					&oidcConfig.requireConsent,
 
 					&samlConfig.appID,
 					&samlConfig.entityID,
 					&samlConfig.metadata,
 					&samlConfig.metadataURL,
+					&samlConfig.encryptAssertions,
 
 					&apps.Count,
 				)
@@ -818,23 +881,29 @@ func prepareClientIDsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBu
 }
 
 type sqlOIDCConfig struct {
-	appID                    sql.NullString
-	version                  sql.NullInt32
-	clientID                 sql.NullString
-	redirectUris             database.StringArray
-	applicationType          sql.NullInt16
-	authMethodType           sql.NullInt16
-	postLogoutRedirectUris   database.StringArray
-	devMode                  sql.NullBool
-	accessTokenType          sql.NullInt16
-	accessTokenRoleAssertion sql.NullBool
-	iDTokenRoleAssertion     sql.NullBool
-	iDTokenUserinfoAssertion sql.NullBool
-	clockSkew                sql.NullInt64
-	additionalOrigins        database.StringArray
-	responseTypes            database.EnumArray[domain.OIDCResponseType]
-	grantTypes               database.EnumArray[domain.OIDCGrantType]
-	skipNativeAppSuccessPage sql.NullBool
+	appID                      sql.NullString
+	version                    sql.NullInt32
+	clientID                   sql.NullString
+	redirectUris               database.StringArray
+	applicationType            sql.NullInt16
+	authMethodType             sql.NullInt16
+	postLogoutRedirectUris     database.StringArray
+	devMode                    sql.NullBool
+	accessTokenType            sql.NullInt16
+	accessTokenRoleAssertion   sql.NullBool
+	iDTokenRoleAssertion       sql.NullBool
+	iDTokenUserinfoAssertion   sql.NullBool
+	clockSkew                  sql.NullInt64
+	additionalOrigins          database.StringArray
+	responseTypes              database.EnumArray[domain.OIDCResponseType]
+	grantTypes                 database.EnumArray[domain.OIDCGrantType]
+	skipNativeAppSuccessPage   sql.NullBool
+	refreshTokenReuseDetection sql.NullBool
+	backChannelLogoutURI       sql.NullString
+	frontChannelLogoutURI      sql.NullString
+	senderConstrainedTokens    sql.NullBool
+	tlsClientAuthSubjectDN     sql.NullString
+	requireConsent             sql.NullBool
 }
 
 func (c sqlOIDCConfig) set(app *App) {
@@ -842,22 +911,28 @@ func (c sqlOIDCConfig) set(app *App) {
 		return
 	}
 	app.OIDCConfig = &OIDCApp{
-		Version:                  domain.OIDCVersion(c.version.Int32),
-		ClientID:                 c.clientID.String,
-		RedirectURIs:             c.redirectUris,
-		AppType:                  domain.OIDCApplicationType(c.applicationType.Int16),
-		AuthMethodType:           domain.OIDCAuthMethodType(c.authMethodType.Int16),
-		PostLogoutRedirectURIs:   c.postLogoutRedirectUris,
-		IsDevMode:                c.devMode.Bool,
-		AccessTokenType:          domain.OIDCTokenType(c.accessTokenType.Int16),
-		AssertAccessTokenRole:    c.accessTokenRoleAssertion.Bool,
-		AssertIDTokenRole:        c.iDTokenRoleAssertion.Bool,
-		AssertIDTokenUserinfo:    c.iDTokenUserinfoAssertion.Bool,
-		ClockSkew:                time.Duration(c.clockSkew.Int64),
-		AdditionalOrigins:        c.additionalOrigins,
-		ResponseTypes:            c.responseTypes,
-		GrantTypes:               c.grantTypes,
-		SkipNativeAppSuccessPage: c.skipNativeAppSuccessPage.Bool,
+		Version:                    domain.OIDCVersion(c.version.Int32),
+		ClientID:                   c.clientID.String,
+		RedirectURIs:               c.redirectUris,
+		AppType:                    domain.OIDCApplicationType(c.applicationType.Int16),
+		AuthMethodType:             domain.OIDCAuthMethodType(c.authMethodType.Int16),
+		PostLogoutRedirectURIs:     c.postLogoutRedirectUris,
+		IsDevMode:                  c.devMode.Bool,
+		AccessTokenType:            domain.OIDCTokenType(c.accessTokenType.Int16),
+		AssertAccessTokenRole:      c.accessTokenRoleAssertion.Bool,
+		AssertIDTokenRole:          c.iDTokenRoleAssertion.Bool,
+		AssertIDTokenUserinfo:      c.iDTokenUserinfoAssertion.Bool,
+		ClockSkew:                  time.Duration(c.clockSkew.Int64),
+		AdditionalOrigins:          c.additionalOrigins,
+		ResponseTypes:              c.responseTypes,
+		GrantTypes:                 c.grantTypes,
+		SkipNativeAppSuccessPage:   c.skipNativeAppSuccessPage.Bool,
+		RefreshTokenReuseDetection: c.refreshTokenReuseDetection.Bool,
+		BackChannelLogoutURI:       c.backChannelLogoutURI.String,
+		FrontChannelLogoutURI:      c.frontChannelLogoutURI.String,
+		SenderConstrainedTokens:    c.senderConstrainedTokens.Bool,
+		TLSClientAuthSubjectDN:     c.tlsClientAuthSubjectDN.String,
+		RequireConsent:             c.requireConsent.Bool,
 	}
 	compliance := domain.GetOIDCCompliance(app.OIDCConfig.Version, app.OIDCConfig.AppType, app.OIDCConfig.GrantTypes, app.OIDCConfig.ResponseTypes, app.OIDCConfig.AuthMethodType, app.OIDCConfig.RedirectURIs)
 	app.OIDCConfig.ComplianceProblems = compliance.Problems
@@ -868,10 +943,11 @@ func (c sqlOIDCConfig) set(app *App) {
 }
 
 type sqlSAMLConfig struct {
-	appID       sql.NullString
-	entityID    sql.NullString
-	metadataURL sql.NullString
-	metadata    []byte
+	appID             sql.NullString
+	entityID          sql.NullString
+	metadataURL       sql.NullString
+	metadata          []byte
+	encryptAssertions sql.NullBool
 }
 
 func (c sqlSAMLConfig) set(app *App) {
@@ -879,9 +955,10 @@ func (c sqlSAMLConfig) set(app *App) {
 		return
 	}
 	app.SAMLConfig = &SAMLApp{
-		MetadataURL: c.metadataURL.String,
-		Metadata:    c.metadata,
-		EntityID:    c.entityID.String,
+		MetadataURL:       c.metadataURL.String,
+		Metadata:          c.metadata,
+		EntityID:          c.entityID.String,
+		EncryptAssertions: c.encryptAssertions.Bool,
 	}
 }
 
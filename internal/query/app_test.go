@@ -15,98 +15,112 @@ import (
 )
 
 var (
-	expectedAppQuery = regexp.QuoteMeta(`SELECT projections.apps5.id,` +
-		` projections.apps5.name,` +
-		` projections.apps5.project_id,` +
-		` projections.apps5.creation_date,` +
-		` projections.apps5.change_date,` +
-		` projections.apps5.resource_owner,` +
-		` projections.apps5.state,` +
-		` projections.apps5.sequence,` +
+	expectedAppQuery = regexp.QuoteMeta(`SELECT projections.apps10.id,` +
+		` projections.apps10.name,` +
+		` projections.apps10.project_id,` +
+		` projections.apps10.creation_date,` +
+		` projections.apps10.change_date,` +
+		` projections.apps10.resource_owner,` +
+		` projections.apps10.state,` +
+		` projections.apps10.sequence,` +
 		// api config
-		` projections.apps5_api_configs.app_id,` +
-		` projections.apps5_api_configs.client_id,` +
-		` projections.apps5_api_configs.auth_method,` +
+		` projections.apps10_api_configs.app_id,` +
+		` projections.apps10_api_configs.client_id,` +
+		` projections.apps10_api_configs.auth_method,` +
 		// oidc config
-		` projections.apps5_oidc_configs.app_id,` +
-		` projections.apps5_oidc_configs.version,` +
-		` projections.apps5_oidc_configs.client_id,` +
-		` projections.apps5_oidc_configs.redirect_uris,` +
-		` projections.apps5_oidc_configs.response_types,` +
-		` projections.apps5_oidc_configs.grant_types,` +
-		` projections.apps5_oidc_configs.application_type,` +
-		` projections.apps5_oidc_configs.auth_method_type,` +
-		` projections.apps5_oidc_configs.post_logout_redirect_uris,` +
-		` projections.apps5_oidc_configs.is_dev_mode,` +
-		` projections.apps5_oidc_configs.access_token_type,` +
-		` projections.apps5_oidc_configs.access_token_role_assertion,` +
-		` projections.apps5_oidc_configs.id_token_role_assertion,` +
-		` projections.apps5_oidc_configs.id_token_userinfo_assertion,` +
-		` projections.apps5_oidc_configs.clock_skew,` +
-		` projections.apps5_oidc_configs.additional_origins,` +
-		` projections.apps5_oidc_configs.skip_native_app_success_page,` +
+		` projections.apps10_oidc_configs.app_id,` +
+		` projections.apps10_oidc_configs.version,` +
+		` projections.apps10_oidc_configs.client_id,` +
+		` projections.apps10_oidc_configs.redirect_uris,` +
+		` projections.apps10_oidc_configs.response_types,` +
+		` projections.apps10_oidc_configs.grant_types,` +
+		` projections.apps10_oidc_configs.application_type,` +
+		` projections.apps10_oidc_configs.auth_method_type,` +
+		` projections.apps10_oidc_configs.post_logout_redirect_uris,` +
+		` projections.apps10_oidc_configs.is_dev_mode,` +
+		` projections.apps10_oidc_configs.access_token_type,` +
+		` projections.apps10_oidc_configs.access_token_role_assertion,` +
+		` projections.apps10_oidc_configs.id_token_role_assertion,` +
+		` projections.apps10_oidc_configs.id_token_userinfo_assertion,` +
+		` projections.apps10_oidc_configs.clock_skew,` +
+		` projections.apps10_oidc_configs.additional_origins,` +
+		` projections.apps10_oidc_configs.skip_native_app_success_page,` +
+		` projections.apps10_oidc_configs.refresh_token_reuse_detection,` +
+		` projections.apps10_oidc_configs.back_channel_logout_uri,` +
+		` projections.apps10_oidc_configs.front_channel_logout_uri,` +
+		` projections.apps10_oidc_configs.sender_constrained_tokens,` +
+		` projections.apps10_oidc_configs.tls_client_auth_subject_dn,` +
+		` projections.apps10_oidc_configs.require_consent,` +
 		//saml config
-		` projections.apps5_saml_configs.app_id,` +
-		` projections.apps5_saml_configs.entity_id,` +
-		` projections.apps5_saml_configs.metadata,` +
-		` projections.apps5_saml_configs.metadata_url` +
-		` FROM projections.apps5` +
-		` LEFT JOIN projections.apps5_api_configs ON projections.apps5.id = projections.apps5_api_configs.app_id AND projections.apps5.instance_id = projections.apps5_api_configs.instance_id` +
-		` LEFT JOIN projections.apps5_oidc_configs ON projections.apps5.id = projections.apps5_oidc_configs.app_id AND projections.apps5.instance_id = projections.apps5_oidc_configs.instance_id` +
-		` LEFT JOIN projections.apps5_saml_configs ON projections.apps5.id = projections.apps5_saml_configs.app_id AND projections.apps5.instance_id = projections.apps5_saml_configs.instance_id` +
+		` projections.apps10_saml_configs.app_id,` +
+		` projections.apps10_saml_configs.entity_id,` +
+		` projections.apps10_saml_configs.metadata,` +
+		` projections.apps10_saml_configs.metadata_url,` +
+		` projections.apps10_saml_configs.encrypt_assertions` +
+		` FROM projections.apps10` +
+		` LEFT JOIN projections.apps10_api_configs ON projections.apps10.id = projections.apps10_api_configs.app_id AND projections.apps10.instance_id = projections.apps10_api_configs.instance_id` +
+		` LEFT JOIN projections.apps10_oidc_configs ON projections.apps10.id = projections.apps10_oidc_configs.app_id AND projections.apps10.instance_id = projections.apps10_oidc_configs.instance_id` +
+		` LEFT JOIN projections.apps10_saml_configs ON projections.apps10.id = projections.apps10_saml_configs.app_id AND projections.apps10.instance_id = projections.apps10_saml_configs.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms'`)
-	expectedAppsQuery = regexp.QuoteMeta(`SELECT projections.apps5.id,` +
-		` projections.apps5.name,` +
-		` projections.apps5.project_id,` +
-		` projections.apps5.creation_date,` +
-		` projections.apps5.change_date,` +
-		` projections.apps5.resource_owner,` +
-		` projections.apps5.state,` +
-		` projections.apps5.sequence,` +
+	expectedAppsQuery = regexp.QuoteMeta(`SELECT projections.apps10.id,` +
+		` projections.apps10.name,` +
+		` projections.apps10.project_id,` +
+		` projections.apps10.creation_date,` +
+		` projections.apps10.change_date,` +
+		` projections.apps10.resource_owner,` +
+		` projections.apps10.state,` +
+		` projections.apps10.sequence,` +
 		// api config
-		` projections.apps5_api_configs.app_id,` +
-		` projections.apps5_api_configs.client_id,` +
-		` projections.apps5_api_configs.auth_method,` +
+		` projections.apps10_api_configs.app_id,` +
+		` projections.apps10_api_configs.client_id,` +
+		` projections.apps10_api_configs.auth_method,` +
 		// oidc config
-		` projections.apps5_oidc_configs.app_id,` +
-		` projections.apps5_oidc_configs.version,` +
-		` projections.apps5_oidc_configs.client_id,` +
-		` projections.apps5_oidc_configs.redirect_uris,` +
-		` projections.apps5_oidc_configs.response_types,` +
-		` projections.apps5_oidc_configs.grant_types,` +
-		` projections.apps5_oidc_configs.application_type,` +
-		` projections.apps5_oidc_configs.auth_method_type,` +
-		` projections.apps5_oidc_configs.post_logout_redirect_uris,` +
-		` projections.apps5_oidc_configs.is_dev_mode,` +
-		` projections.apps5_oidc_configs.access_token_type,` +
-		` projections.apps5_oidc_configs.access_token_role_assertion,` +
-		` projections.apps5_oidc_configs.id_token_role_assertion,` +
-		` projections.apps5_oidc_configs.id_token_userinfo_assertion,` +
-		` projections.apps5_oidc_configs.clock_skew,` +
-		` projections.apps5_oidc_configs.additional_origins,` +
-		` projections.apps5_oidc_configs.skip_native_app_success_page,` +
+		` projections.apps10_oidc_configs.app_id,` +
+		` projections.apps10_oidc_configs.version,` +
+		` projections.apps10_oidc_configs.client_id,` +
+		` projections.apps10_oidc_configs.redirect_uris,` +
+		` projections.apps10_oidc_configs.response_types,` +
+		` projections.apps10_oidc_configs.grant_types,` +
+		` projections.apps10_oidc_configs.application_type,` +
+		` projections.apps10_oidc_configs.auth_method_type,` +
+		` projections.apps10_oidc_configs.post_logout_redirect_uris,` +
+		` projections.apps10_oidc_configs.is_dev_mode,` +
+		` projections.apps10_oidc_configs.access_token_type,` +
+		` projections.apps10_oidc_configs.access_token_role_assertion,` +
+		` projections.apps10_oidc_configs.id_token_role_assertion,` +
+		` projections.apps10_oidc_configs.id_token_userinfo_assertion,` +
+		` projections.apps10_oidc_configs.clock_skew,` +
+		` projections.apps10_oidc_configs.additional_origins,` +
+		` projections.apps10_oidc_configs.skip_native_app_success_page,` +
+		` projections.apps10_oidc_configs.refresh_token_reuse_detection,` +
+		` projections.apps10_oidc_configs.back_channel_logout_uri,` +
+		` projections.apps10_oidc_configs.front_channel_logout_uri,` +
+		` projections.apps10_oidc_configs.sender_constrained_tokens,` +
+		` projections.apps10_oidc_configs.tls_client_auth_subject_dn,` +
+		` projections.apps10_oidc_configs.require_consent,` +
 		//saml config
-		` projections.apps5_saml_configs.app_id,` +
-		` projections.apps5_saml_configs.entity_id,` +
-		` projections.apps5_saml_configs.metadata,` +
-		` projections.apps5_saml_configs.metadata_url,` +
+		` projections.apps10_saml_configs.app_id,` +
+		` projections.apps10_saml_configs.entity_id,` +
+		` projections.apps10_saml_configs.metadata,` +
+		` projections.apps10_saml_configs.metadata_url,` +
+		` projections.apps10_saml_configs.encrypt_assertions,` +
 		` COUNT(*) OVER ()` +
-		` FROM projections.apps5` +
-		` LEFT JOIN projections.apps5_api_configs ON projections.apps5.id = projections.apps5_api_configs.app_id AND projections.apps5.instance_id = projections.apps5_api_configs.instance_id` +
-		` LEFT JOIN projections.apps5_oidc_configs ON projections.apps5.id = projections.apps5_oidc_configs.app_id AND projections.apps5.instance_id = projections.apps5_oidc_configs.instance_id` +
-		` LEFT JOIN projections.apps5_saml_configs ON projections.apps5.id = projections.apps5_saml_configs.app_id AND projections.apps5.instance_id = projections.apps5_saml_configs.instance_id` +
+		` FROM projections.apps10` +
+		` LEFT JOIN projections.apps10_api_configs ON projections.apps10.id = projections.apps10_api_configs.app_id AND projections.apps10.instance_id = projections.apps10_api_configs.instance_id` +
+		` LEFT JOIN projections.apps10_oidc_configs ON projections.apps10.id = projections.apps10_oidc_configs.app_id AND projections.apps10.instance_id = projections.apps10_oidc_configs.instance_id` +
+		` LEFT JOIN projections.apps10_saml_configs ON projections.apps10.id = projections.apps10_saml_configs.app_id AND projections.apps10.instance_id = projections.apps10_saml_configs.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms'`)
-	expectedAppIDsQuery = regexp.QuoteMeta(`SELECT projections.apps5_api_configs.client_id,` +
-		` projections.apps5_oidc_configs.client_id` +
-		` FROM projections.apps5` +
-		` LEFT JOIN projections.apps5_api_configs ON projections.apps5.id = projections.apps5_api_configs.app_id AND projections.apps5.instance_id = projections.apps5_api_configs.instance_id` +
-		` LEFT JOIN projections.apps5_oidc_configs ON projections.apps5.id = projections.apps5_oidc_configs.app_id AND projections.apps5.instance_id = projections.apps5_oidc_configs.instance_id` +
+	expectedAppIDsQuery = regexp.QuoteMeta(`SELECT projections.apps10_api_configs.client_id,` +
+		` projections.apps10_oidc_configs.client_id` +
+		` FROM projections.apps10` +
+		` LEFT JOIN projections.apps10_api_configs ON projections.apps10.id = projections.apps10_api_configs.app_id AND projections.apps10.instance_id = projections.apps10_api_configs.instance_id` +
+		` LEFT JOIN projections.apps10_oidc_configs ON projections.apps10.id = projections.apps10_oidc_configs.app_id AND projections.apps10.instance_id = projections.apps10_oidc_configs.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms'`)
-	expectedProjectIDByAppQuery = regexp.QuoteMeta(`SELECT projections.apps5.project_id` +
-		` FROM projections.apps5` +
-		` LEFT JOIN projections.apps5_api_configs ON projections.apps5.id = projections.apps5_api_configs.app_id AND projections.apps5.instance_id = projections.apps5_api_configs.instance_id` +
-		` LEFT JOIN projections.apps5_oidc_configs ON projections.apps5.id = projections.apps5_oidc_configs.app_id AND projections.apps5.instance_id = projections.apps5_oidc_configs.instance_id` +
-		` LEFT JOIN projections.apps5_saml_configs ON projections.apps5.id = projections.apps5_saml_configs.app_id AND projections.apps5.instance_id = projections.apps5_saml_configs.instance_id` +
+	expectedProjectIDByAppQuery = regexp.QuoteMeta(`SELECT projections.apps10.project_id` +
+		` FROM projections.apps10` +
+		` LEFT JOIN projections.apps10_api_configs ON projections.apps10.id = projections.apps10_api_configs.app_id AND projections.apps10.instance_id = projections.apps10_api_configs.instance_id` +
+		` LEFT JOIN projections.apps10_oidc_configs ON projections.apps10.id = projections.apps10_oidc_configs.app_id AND projections.apps10.instance_id = projections.apps10_oidc_configs.instance_id` +
+		` LEFT JOIN projections.apps10_saml_configs ON projections.apps10.id = projections.apps10_saml_configs.app_id AND projections.apps10.instance_id = projections.apps10_saml_configs.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms'`)
 	expectedProjectByAppQuery = regexp.QuoteMeta(`SELECT projections.projects3.id,` +
 		` projections.projects3.creation_date,` +
@@ -120,10 +134,10 @@ var (
 		` projections.projects3.has_project_check,` +
 		` projections.projects3.private_labeling_setting` +
 		` FROM projections.projects3` +
-		` JOIN projections.apps5 ON projections.projects3.id = projections.apps5.project_id AND projections.projects3.instance_id = projections.apps5.instance_id` +
-		` LEFT JOIN projections.apps5_api_configs ON projections.apps5.id = projections.apps5_api_configs.app_id AND projections.apps5.instance_id = projections.apps5_api_configs.instance_id` +
-		` LEFT JOIN projections.apps5_oidc_configs ON projections.apps5.id = projections.apps5_oidc_configs.app_id AND projections.apps5.instance_id = projections.apps5_oidc_configs.instance_id` +
-		` LEFT JOIN projections.apps5_saml_configs ON projections.apps5.id = projections.apps5_saml_configs.app_id AND projections.apps5.instance_id = projections.apps5_saml_configs.instance_id` +
+		` JOIN projections.apps10 ON projections.projects3.id = projections.apps10.project_id AND projections.projects3.instance_id = projections.apps10.instance_id` +
+		` LEFT JOIN projections.apps10_api_configs ON projections.apps10.id = projections.apps10_api_configs.app_id AND projections.apps10.instance_id = projections.apps10_api_configs.instance_id` +
+		` LEFT JOIN projections.apps10_oidc_configs ON projections.apps10.id = projections.apps10_oidc_configs.app_id AND projections.apps10.instance_id = projections.apps10_oidc_configs.instance_id` +
+		` LEFT JOIN projections.apps10_saml_configs ON projections.apps10.id = projections.apps10_saml_configs.app_id AND projections.apps10.instance_id = projections.apps10_saml_configs.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms'`)
 
 	appCols = database.StringArray{
@@ -157,11 +171,18 @@ var (
 		"clock_skew",
 		"additional_origins",
 		"skip_native_app_success_page",
+		"refresh_token_reuse_detection",
+		"back_channel_logout_uri",
+		"front_channel_logout_uri",
+		"sender_constrained_tokens",
+		"tls_client_auth_subject_dn",
+		"require_consent",
 		//saml config
 		"app_id",
 		"entity_id",
 		"metadata",
 		"metadata_url",
+		"encrypt_assertions",
 	}
 	appsCols = append(appCols, "count")
 )
@@ -228,11 +249,18 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
 							nil,
 							nil,
+							nil,
 						},
 					},
 				),
@@ -294,11 +322,18 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
 							nil,
 							nil,
+							nil,
 						},
 					},
 				),
@@ -363,11 +398,18 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							"app-id",
 							"https://test.com/saml/metadata",
 							[]byte("<?xml version=\"1.0\"?>\n<md:EntityDescriptor xmlns:md=\"urn:oasis:names:tc:SAML:2.0:metadata\"\n                     validUntil=\"2022-08-26T14:08:16Z\"\n                     cacheDuration=\"PT604800S\"\n                     entityID=\"https://test.com/saml/metadata\">\n    <md:SPSSODescriptor AuthnRequestsSigned=\"false\" WantAssertionsSigned=\"false\" protocolSupportEnumeration=\"urn:oasis:names:tc:SAML:2.0:protocol\">\n        <md:NameIDFormat>urn:oasis:names:tc:SAML:1.1:nameid-format:unspecified</md:NameIDFormat>\n        <md:AssertionConsumerService Binding=\"urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST\"\n                                     Location=\"https://test.com/saml/acs\"\n                                     index=\"1\" />\n        \n    </md:SPSSODescriptor>\n</md:EntityDescriptor>"),
 							"https://test.com/saml/metadata",
+							true,
 						},
 					},
 				),
@@ -387,9 +429,10 @@ func Test_AppsPrepare(t *testing.T) {
 						Name:          "app-name",
 						ProjectID:     "project-id",
 						SAMLConfig: &SAMLApp{
-							Metadata:    []byte("<?xml version=\"1.0\"?>\n<md:EntityDescriptor xmlns:md=\"urn:oasis:names:tc:SAML:2.0:metadata\"\n                     validUntil=\"2022-08-26T14:08:16Z\"\n                     cacheDuration=\"PT604800S\"\n                     entityID=\"https://test.com/saml/metadata\">\n    <md:SPSSODescriptor AuthnRequestsSigned=\"false\" WantAssertionsSigned=\"false\" protocolSupportEnumeration=\"urn:oasis:names:tc:SAML:2.0:protocol\">\n        <md:NameIDFormat>urn:oasis:names:tc:SAML:1.1:nameid-format:unspecified</md:NameIDFormat>\n        <md:AssertionConsumerService Binding=\"urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST\"\n                                     Location=\"https://test.com/saml/acs\"\n                                     index=\"1\" />\n        \n    </md:SPSSODescriptor>\n</md:EntityDescriptor>"),
-							MetadataURL: "https://test.com/saml/metadata",
-							EntityID:    "https://test.com/saml/metadata",
+							Metadata:          []byte("<?xml version=\"1.0\"?>\n<md:EntityDescriptor xmlns:md=\"urn:oasis:names:tc:SAML:2.0:metadata\"\n                     validUntil=\"2022-08-26T14:08:16Z\"\n                     cacheDuration=\"PT604800S\"\n                     entityID=\"https://test.com/saml/metadata\">\n    <md:SPSSODescriptor AuthnRequestsSigned=\"false\" WantAssertionsSigned=\"false\" protocolSupportEnumeration=\"urn:oasis:names:tc:SAML:2.0:protocol\">\n        <md:NameIDFormat>urn:oasis:names:tc:SAML:1.1:nameid-format:unspecified</md:NameIDFormat>\n        <md:AssertionConsumerService Binding=\"urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST\"\n                                     Location=\"https://test.com/saml/acs\"\n                                     index=\"1\" />\n        \n    </md:SPSSODescriptor>\n</md:EntityDescriptor>"),
+							MetadataURL:       "https://test.com/saml/metadata",
+							EntityID:          "https://test.com/saml/metadata",
+							EncryptAssertions: true,
 						},
 					},
 				},
@@ -434,11 +477,18 @@ func Test_AppsPrepare(t *testing.T) {
 							1 * time.Second,
 							database.StringArray{"additional.origin"},
 							false,
+							false,
+							"back-channel",
+							"front-channel",
+							true,
+							"CN=client",
+							false,
 							// saml config
 							nil,
 							nil,
 							nil,
 							nil,
+							nil,
 						},
 					},
 				),
@@ -476,6 +526,10 @@ func Test_AppsPrepare(t *testing.T) {
 							ComplianceProblems:       nil,
 							AllowedOrigins:           database.StringArray{"https://redirect.to", "additional.origin"},
 							SkipNativeAppSuccessPage: false,
+							BackChannelLogoutURI:     "back-channel",
+							FrontChannelLogoutURI:    "front-channel",
+							SenderConstrainedTokens:  true,
+							TLSClientAuthSubjectDN:   "CN=client",
 						},
 					},
 				},
@@ -520,11 +574,18 @@ func Test_AppsPrepare(t *testing.T) {
 							1 * time.Second,
 							database.StringArray{"additional.origin"},
 							false,
+							false,
+							"back-channel",
+							"front-channel",
+							true,
+							"CN=client",
+							false,
 							// saml config
 							nil,
 							nil,
 							nil,
 							nil,
+							nil,
 						},
 					},
 				),
@@ -562,6 +623,10 @@ func Test_AppsPrepare(t *testing.T) {
 							ComplianceProblems:       nil,
 							AllowedOrigins:           database.StringArray{"https://redirect.to", "additional.origin"},
 							SkipNativeAppSuccessPage: false,
+							BackChannelLogoutURI:     "back-channel",
+							FrontChannelLogoutURI:    "front-channel",
+							SenderConstrainedTokens:  true,
+							TLSClientAuthSubjectDN:   "CN=client",
 						},
 					},
 				},
@@ -606,11 +671,18 @@ func Test_AppsPrepare(t *testing.T) {
 							1 * time.Second,
 							database.StringArray{"additional.origin"},
 							false,
+							false,
+							"back-channel",
+							"front-channel",
+							true,
+							"CN=client",
+							false,
 							// saml config
 							nil,
 							nil,
 							nil,
 							nil,
+							nil,
 						},
 					},
 				),
@@ -648,6 +720,10 @@ func Test_AppsPrepare(t *testing.T) {
 							ComplianceProblems:       nil,
 							AllowedOrigins:           database.StringArray{"https://redirect.to", "additional.origin"},
 							SkipNativeAppSuccessPage: false,
+							BackChannelLogoutURI:     "back-channel",
+							FrontChannelLogoutURI:    "front-channel",
+							SenderConstrainedTokens:  true,
+							TLSClientAuthSubjectDN:   "CN=client",
 						},
 					},
 				},
@@ -692,11 +768,18 @@ func Test_AppsPrepare(t *testing.T) {
 							1 * time.Second,
 							database.StringArray{"additional.origin"},
 							false,
+							false,
+							"back-channel",
+							"front-channel",
+							true,
+							"CN=client",
+							false,
 							// saml config
 							nil,
 							nil,
 							nil,
 							nil,
+							nil,
 						},
 					},
 				),
@@ -734,6 +817,10 @@ func Test_AppsPrepare(t *testing.T) {
 							ComplianceProblems:       nil,
 							AllowedOrigins:           database.StringArray{"https://redirect.to", "additional.origin"},
 							SkipNativeAppSuccessPage: false,
+							BackChannelLogoutURI:     "back-channel",
+							FrontChannelLogoutURI:    "front-channel",
+							SenderConstrainedTokens:  true,
+							TLSClientAuthSubjectDN:   "CN=client",
 						},
 					},
 				},
@@ -778,11 +865,18 @@ func Test_AppsPrepare(t *testing.T) {
 							1 * time.Second,
 							database.StringArray{"additional.origin"},
 							false,
+							false,
+							"back-channel",
+							"front-channel",
+							true,
+							"CN=client",
+							false,
 							// saml config
 							nil,
 							nil,
 							nil,
 							nil,
+							nil,
 						},
 					},
 				),
@@ -820,6 +914,10 @@ func Test_AppsPrepare(t *testing.T) {
 							ComplianceProblems:       nil,
 							AllowedOrigins:           database.StringArray{"https://redirect.to", "additional.origin"},
 							SkipNativeAppSuccessPage: false,
+							BackChannelLogoutURI:     "back-channel",
+							FrontChannelLogoutURI:    "front-channel",
+							SenderConstrainedTokens:  true,
+							TLSClientAuthSubjectDN:   "CN=client",
 						},
 					},
 				},
@@ -864,11 +962,18 @@ func Test_AppsPrepare(t *testing.T) {
 							1 * time.Second,
 							database.StringArray{"additional.origin"},
 							true,
+							false,
+							"back-channel",
+							"front-channel",
+							true,
+							"CN=client",
+							false,
 							// saml config
 							nil,
 							nil,
 							nil,
 							nil,
+							nil,
 						},
 					},
 				),
@@ -906,6 +1011,10 @@ func Test_AppsPrepare(t *testing.T) {
 							ComplianceProblems:       nil,
 							AllowedOrigins:           database.StringArray{"https://redirect.to", "additional.origin"},
 							SkipNativeAppSuccessPage: true,
+							BackChannelLogoutURI:     "back-channel",
+							FrontChannelLogoutURI:    "front-channel",
+							SenderConstrainedTokens:  true,
+							TLSClientAuthSubjectDN:   "CN=client",
 						},
 					},
 				},
@@ -950,11 +1059,18 @@ func Test_AppsPrepare(t *testing.T) {
 							1 * time.Second,
 							database.StringArray{"additional.origin"},
 							false,
+							false,
+							"back-channel",
+							"front-channel",
+							true,
+							"CN=client",
+							false,
 							// saml config
 							nil,
 							nil,
 							nil,
 							nil,
+							nil,
 						},
 						{
 							"api-app-id",
@@ -987,11 +1103,18 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
 							nil,
 							nil,
+							nil,
 						},
 						{
 							"saml-app-id",
@@ -1024,11 +1147,18 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							"saml-app-id",
 							"https://test.com/saml/metadata",
 							[]byte("<?xml version=\"1.0\"?>\n<md:EntityDescriptor xmlns:md=\"urn:oasis:names:tc:SAML:2.0:metadata\"\n                     validUntil=\"2022-08-26T14:08:16Z\"\n                     cacheDuration=\"PT604800S\"\n                     entityID=\"https://test.com/saml/metadata\">\n    <md:SPSSODescriptor AuthnRequestsSigned=\"false\" WantAssertionsSigned=\"false\" protocolSupportEnumeration=\"urn:oasis:names:tc:SAML:2.0:protocol\">\n        <md:NameIDFormat>urn:oasis:names:tc:SAML:1.1:nameid-format:unspecified</md:NameIDFormat>\n        <md:AssertionConsumerService Binding=\"urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST\"\n                                     Location=\"https://test.com/saml/acs\"\n                                     index=\"1\" />\n        \n    </md:SPSSODescriptor>\n</md:EntityDescriptor>"),
 							"https://test.com/saml/metadata",
+							true,
 						},
 					},
 				),
@@ -1066,6 +1196,10 @@ func Test_AppsPrepare(t *testing.T) {
 							ComplianceProblems:       nil,
 							AllowedOrigins:           database.StringArray{"https://redirect.to", "additional.origin"},
 							SkipNativeAppSuccessPage: false,
+							BackChannelLogoutURI:     "back-channel",
+							FrontChannelLogoutURI:    "front-channel",
+							SenderConstrainedTokens:  true,
+							TLSClientAuthSubjectDN:   "CN=client",
 						},
 					},
 					{
@@ -1092,9 +1226,10 @@ func Test_AppsPrepare(t *testing.T) {
 						Name:          "app-name",
 						ProjectID:     "project-id",
 						SAMLConfig: &SAMLApp{
-							Metadata:    []byte("<?xml version=\"1.0\"?>\n<md:EntityDescriptor xmlns:md=\"urn:oasis:names:tc:SAML:2.0:metadata\"\n                     validUntil=\"2022-08-26T14:08:16Z\"\n                     cacheDuration=\"PT604800S\"\n                     entityID=\"https://test.com/saml/metadata\">\n    <md:SPSSODescriptor AuthnRequestsSigned=\"false\" WantAssertionsSigned=\"false\" protocolSupportEnumeration=\"urn:oasis:names:tc:SAML:2.0:protocol\">\n        <md:NameIDFormat>urn:oasis:names:tc:SAML:1.1:nameid-format:unspecified</md:NameIDFormat>\n        <md:AssertionConsumerService Binding=\"urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST\"\n                                     Location=\"https://test.com/saml/acs\"\n                                     index=\"1\" />\n        \n    </md:SPSSODescriptor>\n</md:EntityDescriptor>"),
-							MetadataURL: "https://test.com/saml/metadata",
-							EntityID:    "https://test.com/saml/metadata",
+							Metadata:          []byte("<?xml version=\"1.0\"?>\n<md:EntityDescriptor xmlns:md=\"urn:oasis:names:tc:SAML:2.0:metadata\"\n                     validUntil=\"2022-08-26T14:08:16Z\"\n                     cacheDuration=\"PT604800S\"\n                     entityID=\"https://test.com/saml/metadata\">\n    <md:SPSSODescriptor AuthnRequestsSigned=\"false\" WantAssertionsSigned=\"false\" protocolSupportEnumeration=\"urn:oasis:names:tc:SAML:2.0:protocol\">\n        <md:NameIDFormat>urn:oasis:names:tc:SAML:1.1:nameid-format:unspecified</md:NameIDFormat>\n        <md:AssertionConsumerService Binding=\"urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST\"\n                                     Location=\"https://test.com/saml/acs\"\n                                     index=\"1\" />\n        \n    </md:SPSSODescriptor>\n</md:EntityDescriptor>"),
+							MetadataURL:       "https://test.com/saml/metadata",
+							EntityID:          "https://test.com/saml/metadata",
+							EncryptAssertions: true,
 						},
 					},
 				},
@@ -1192,11 +1327,18 @@ func Test_AppPrepare(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
 						// saml config
 						nil,
 						nil,
 						nil,
 						nil,
+						nil,
 					},
 				),
 			},
@@ -1250,11 +1392,18 @@ func Test_AppPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
 							nil,
 							nil,
+							nil,
 						},
 					},
 				),
@@ -1313,11 +1462,18 @@ func Test_AppPrepare(t *testing.T) {
 							1 * time.Second,
 							database.StringArray{"additional.origin"},
 							false,
+							false,
+							"back-channel",
+							"front-channel",
+							true,
+							"CN=client",
+							false,
 							// saml config
 							nil,
 							nil,
 							nil,
 							nil,
+							nil,
 						},
 					},
 				),
@@ -1350,6 +1506,10 @@ func Test_AppPrepare(t *testing.T) {
 					ComplianceProblems:       nil,
 					AllowedOrigins:           database.StringArray{"https://redirect.to", "additional.origin"},
 					SkipNativeAppSuccessPage: false,
+					BackChannelLogoutURI:     "back-channel",
+					FrontChannelLogoutURI:    "front-channel",
+					SenderConstrainedTokens:  true,
+					TLSClientAuthSubjectDN:   "CN=client",
 				},
 			},
 		}, {
@@ -1391,11 +1551,18 @@ func Test_AppPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							"app-id",
 							"https://test.com/saml/metadata",
 							[]byte("<?xml version=\"1.0\"?>\n<md:EntityDescriptor xmlns:md=\"urn:oasis:names:tc:SAML:2.0:metadata\"\n                     validUntil=\"2022-08-26T14:08:16Z\"\n                     cacheDuration=\"PT604800S\"\n                     entityID=\"https://test.com/saml/metadata\">\n    <md:SPSSODescriptor AuthnRequestsSigned=\"false\" WantAssertionsSigned=\"false\" protocolSupportEnumeration=\"urn:oasis:names:tc:SAML:2.0:protocol\">\n        <md:NameIDFormat>urn:oasis:names:tc:SAML:1.1:nameid-format:unspecified</md:NameIDFormat>\n        <md:AssertionConsumerService Binding=\"urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST\"\n                                     Location=\"https://test.com/saml/acs\"\n                                     index=\"1\" />\n        \n    </md:SPSSODescriptor>\n</md:EntityDescriptor>"),
 							"https://test.com/saml/metadata",
+							true,
 						},
 					},
 				),
@@ -1410,9 +1577,10 @@ func Test_AppPrepare(t *testing.T) {
 				Name:          "app-name",
 				ProjectID:     "project-id",
 				SAMLConfig: &SAMLApp{
-					Metadata:    []byte("<?xml version=\"1.0\"?>\n<md:EntityDescriptor xmlns:md=\"urn:oasis:names:tc:SAML:2.0:metadata\"\n                     validUntil=\"2022-08-26T14:08:16Z\"\n                     cacheDuration=\"PT604800S\"\n                     entityID=\"https://test.com/saml/metadata\">\n    <md:SPSSODescriptor AuthnRequestsSigned=\"false\" WantAssertionsSigned=\"false\" protocolSupportEnumeration=\"urn:oasis:names:tc:SAML:2.0:protocol\">\n        <md:NameIDFormat>urn:oasis:names:tc:SAML:1.1:nameid-format:unspecified</md:NameIDFormat>\n        <md:AssertionConsumerService Binding=\"urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST\"\n                                     Location=\"https://test.com/saml/acs\"\n                                     index=\"1\" />\n        \n    </md:SPSSODescriptor>\n</md:EntityDescriptor>"),
-					MetadataURL: "https://test.com/saml/metadata",
-					EntityID:    "https://test.com/saml/metadata",
+					Metadata:          []byte("<?xml version=\"1.0\"?>\n<md:EntityDescriptor xmlns:md=\"urn:oasis:names:tc:SAML:2.0:metadata\"\n                     validUntil=\"2022-08-26T14:08:16Z\"\n                     cacheDuration=\"PT604800S\"\n                     entityID=\"https://test.com/saml/metadata\">\n    <md:SPSSODescriptor AuthnRequestsSigned=\"false\" WantAssertionsSigned=\"false\" protocolSupportEnumeration=\"urn:oasis:names:tc:SAML:2.0:protocol\">\n        <md:NameIDFormat>urn:oasis:names:tc:SAML:1.1:nameid-format:unspecified</md:NameIDFormat>\n        <md:AssertionConsumerService Binding=\"urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST\"\n                                     Location=\"https://test.com/saml/acs\"\n                                     index=\"1\" />\n        \n    </md:SPSSODescriptor>\n</md:EntityDescriptor>"),
+					MetadataURL:       "https://test.com/saml/metadata",
+					EntityID:          "https://test.com/saml/metadata",
+					EncryptAssertions: true,
 				},
 			},
 		},
@@ -1455,11 +1623,18 @@ func Test_AppPrepare(t *testing.T) {
 							1 * time.Second,
 							database.StringArray{"additional.origin"},
 							false,
+							false,
+							"back-channel",
+							"front-channel",
+							true,
+							"CN=client",
+							false,
 							// saml config
 							nil,
 							nil,
 							nil,
 							nil,
+							nil,
 						},
 					},
 				),
@@ -1492,6 +1667,10 @@ func Test_AppPrepare(t *testing.T) {
 					ComplianceProblems:       nil,
 					AllowedOrigins:           database.StringArray{"https://redirect.to", "additional.origin"},
 					SkipNativeAppSuccessPage: false,
+					BackChannelLogoutURI:     "back-channel",
+					FrontChannelLogoutURI:    "front-channel",
+					SenderConstrainedTokens:  true,
+					TLSClientAuthSubjectDN:   "CN=client",
 				},
 			},
 		},
@@ -1534,11 +1713,18 @@ func Test_AppPrepare(t *testing.T) {
 							1 * time.Second,
 							database.StringArray{"additional.origin"},
 							false,
+							false,
+							"back-channel",
+							"front-channel",
+							true,
+							"CN=client",
+							false,
 							// saml config
 							nil,
 							nil,
 							nil,
 							nil,
+							nil,
 						},
 					},
 				),
@@ -1571,6 +1757,10 @@ func Test_AppPrepare(t *testing.T) {
 					ComplianceProblems:       nil,
 					AllowedOrigins:           database.StringArray{"https://redirect.to", "additional.origin"},
 					SkipNativeAppSuccessPage: false,
+					BackChannelLogoutURI:     "back-channel",
+					FrontChannelLogoutURI:    "front-channel",
+					SenderConstrainedTokens:  true,
+					TLSClientAuthSubjectDN:   "CN=client",
 				},
 			},
 		},
@@ -1613,11 +1803,18 @@ func Test_AppPrepare(t *testing.T) {
 							1 * time.Second,
 							database.StringArray{"additional.origin"},
 							false,
+							false,
+							"back-channel",
+							"front-channel",
+							true,
+							"CN=client",
+							false,
 							// saml config
 							nil,
 							nil,
 							nil,
 							nil,
+							nil,
 						},
 					},
 				),
@@ -1650,6 +1847,10 @@ func Test_AppPrepare(t *testing.T) {
 					ComplianceProblems:       nil,
 					AllowedOrigins:           database.StringArray{"https://redirect.to", "additional.origin"},
 					SkipNativeAppSuccessPage: false,
+					BackChannelLogoutURI:     "back-channel",
+					FrontChannelLogoutURI:    "front-channel",
+					SenderConstrainedTokens:  true,
+					TLSClientAuthSubjectDN:   "CN=client",
 				},
 			},
 		},
@@ -1692,11 +1893,18 @@ func Test_AppPrepare(t *testing.T) {
 							1 * time.Second,
 							database.StringArray{"additional.origin"},
 							false,
+							false,
+							"back-channel",
+							"front-channel",
+							true,
+							"CN=client",
+							false,
 							// saml config
 							nil,
 							nil,
 							nil,
 							nil,
+							nil,
 						},
 					},
 				),
@@ -1729,6 +1937,10 @@ func Test_AppPrepare(t *testing.T) {
 					ComplianceProblems:       nil,
 					AllowedOrigins:           database.StringArray{"https://redirect.to", "additional.origin"},
 					SkipNativeAppSuccessPage: false,
+					BackChannelLogoutURI:     "back-channel",
+					FrontChannelLogoutURI:    "front-channel",
+					SenderConstrainedTokens:  true,
+					TLSClientAuthSubjectDN:   "CN=client",
 				},
 			},
 		},
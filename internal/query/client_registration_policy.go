@@ -0,0 +1,101 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	errs "errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query/projection"
+)
+
+var (
+	clientRegistrationPolicyTable = table{
+		name:          projection.ClientRegistrationPolicyProjectionTable,
+		instanceIDCol: projection.ClientRegistrationPolicyColumnInstanceID,
+	}
+	ClientRegistrationPolicyColumnCreationDate = Column{
+		name:  projection.ClientRegistrationPolicyColumnCreationDate,
+		table: clientRegistrationPolicyTable,
+	}
+	ClientRegistrationPolicyColumnChangeDate = Column{
+		name:  projection.ClientRegistrationPolicyColumnChangeDate,
+		table: clientRegistrationPolicyTable,
+	}
+	ClientRegistrationPolicyColumnInstanceID = Column{
+		name:  projection.ClientRegistrationPolicyColumnInstanceID,
+		table: clientRegistrationPolicyTable,
+	}
+	ClientRegistrationPolicyColumnSequence = Column{
+		name:  projection.ClientRegistrationPolicyColumnSequence,
+		table: clientRegistrationPolicyTable,
+	}
+	ClientRegistrationPolicyColumnAllowedGrantTypes = Column{
+		name:  projection.ClientRegistrationPolicyColumnAllowedGrantTypes,
+		table: clientRegistrationPolicyTable,
+	}
+	ClientRegistrationPolicyColumnRedirectURIPatterns = Column{
+		name:  projection.ClientRegistrationPolicyColumnRedirectPatterns,
+		table: clientRegistrationPolicyTable,
+	}
+)
+
+type ClientRegistrationPolicy struct {
+	AggregateID   string
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	ResourceOwner string
+	Sequence      uint64
+
+	AllowedGrantTypes   database.EnumArray[domain.OIDCGrantType]
+	RedirectURIPatterns database.StringArray
+}
+
+func (q *Queries) ClientRegistrationPolicy(ctx context.Context) (*ClientRegistrationPolicy, error) {
+	stmt, scan := prepareClientRegistrationPolicyQuery(ctx, q.client)
+	query, args, err := stmt.Where(sq.Eq{
+		ClientRegistrationPolicyColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Ci3mz", "Errors.Query.SQLStatment")
+	}
+
+	row := q.client.QueryRowContext(ctx, query, args...)
+	return scan(row)
+}
+
+func prepareClientRegistrationPolicyQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Row) (*ClientRegistrationPolicy, error)) {
+	return sq.Select(
+			ClientRegistrationPolicyColumnInstanceID.identifier(),
+			ClientRegistrationPolicyColumnCreationDate.identifier(),
+			ClientRegistrationPolicyColumnChangeDate.identifier(),
+			ClientRegistrationPolicyColumnInstanceID.identifier(),
+			ClientRegistrationPolicyColumnSequence.identifier(),
+			ClientRegistrationPolicyColumnAllowedGrantTypes.identifier(),
+			ClientRegistrationPolicyColumnRedirectURIPatterns.identifier()).
+			From(clientRegistrationPolicyTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*ClientRegistrationPolicy, error) {
+			policy := new(ClientRegistrationPolicy)
+			err := row.Scan(
+				&policy.AggregateID,
+				&policy.CreationDate,
+				&policy.ChangeDate,
+				&policy.ResourceOwner,
+				&policy.Sequence,
+				&policy.AllowedGrantTypes,
+				&policy.RedirectURIPatterns,
+			)
+			if err != nil && !errs.Is(err, sql.ErrNoRows) { // ignore not found errors
+				return nil, errors.ThrowInternal(err, "QUERY-Tk5pw", "Errors.Internal")
+			}
+			return policy, nil
+		}
+}
@@ -73,7 +73,11 @@ func (q *Queries) SearchCurrentSequences(ctx context.Context, queries *CurrentSe
 	return scan(rows)
 }
 
-func (q *Queries) latestSequence(ctx context.Context, projections ...table) (_ *LatestSequence, err error) {
+func (q *Queries) latestSequence(ctx context.Context, projections ...table) (*LatestSequence, error) {
+	return q.latestInstanceSequence(ctx, authz.GetInstance(ctx).InstanceID(), projections...)
+}
+
+func (q *Queries) latestInstanceSequence(ctx context.Context, instanceID string, projections ...table) (_ *LatestSequence, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 
@@ -84,7 +88,7 @@ func (q *Queries) latestSequence(ctx context.Context, projections ...table) (_ *
 	}
 	stmt, args, err := query.
 		Where(or).
-		Where(sq.Eq{CurrentSequenceColInstanceID.identifier(): authz.GetInstance(ctx).InstanceID()}).
+		Where(sq.Eq{CurrentSequenceColInstanceID.identifier(): instanceID}).
 		OrderBy(CurrentSequenceColCurrentSequence.identifier() + " DESC").
 		ToSql()
 	if err != nil {
@@ -305,3 +309,9 @@ var (
 		table: locksTable,
 	}
 )
+
+// LatestProjectionSequence returns the latest sequence processed by the projection
+// in the passed instance
+func (q *Queries) LatestProjectionSequence(ctx context.Context, instanceID, projectionName string) (*LatestSequence, error) {
+	return q.latestInstanceSequence(ctx, instanceID, table{name: projectionName})
+}
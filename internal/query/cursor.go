@@ -0,0 +1,210 @@
+package query
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+type cursorKind string
+
+const (
+	cursorKindNull   cursorKind = ""
+	cursorKindString cursorKind = "s"
+	cursorKindInt    cursorKind = "i"
+	cursorKindUint   cursorKind = "u"
+	cursorKindTime   cursorKind = "t"
+)
+
+// cursor is the position of a result in the results of a search.
+// It consists of the value of the sorting column and the id of the result,
+// which distinguishes results with equal values.
+// The cursor is passed to the clients as opaque token
+type cursor struct {
+	Column string     `json:"c"`
+	Asc    bool       `json:"a,omitempty"`
+	Kind   cursorKind `json:"k,omitempty"`
+	Value  string     `json:"v,omitempty"`
+	ID     string     `json:"i"`
+}
+
+func newCursor(column Column, asc bool, value interface{}, id string) (*cursor, error) {
+	c := &cursor{
+		Column: column.identifier(),
+		Asc:    asc,
+		ID:     id,
+	}
+	if value == nil && column.isOrderByLower {
+		// null texts are ordered like empty texts
+		value = ""
+	}
+	if value == nil {
+		return c, nil
+	}
+	if date, ok := value.(time.Time); ok {
+		c.Kind, c.Value = cursorKindTime, date.UTC().Format(time.RFC3339Nano)
+		return c, nil
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.String:
+		c.Kind, c.Value = cursorKindString, v.String()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		c.Kind, c.Value = cursorKindInt, strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		c.Kind, c.Value = cursorKindUint, strconv.FormatUint(v.Uint(), 10)
+	default:
+		return nil, errors.ThrowInternal(nil, "QUERY-Vz3rc", "Errors.Query.InvalidCursor")
+	}
+	return c, nil
+}
+
+func (c *cursor) encode() (string, error) {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return "", errors.ThrowInternal(err, "QUERY-Lq8wt", "Errors.Query.InvalidCursor")
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodeCursor(token string) (*cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-Hb5xm", "Errors.Query.InvalidCursor")
+	}
+	c := new(cursor)
+	if err = json.Unmarshal(data, c); err != nil || c.ID == "" {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-Pn2fk", "Errors.Query.InvalidCursor")
+	}
+	if _, err = c.value(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *cursor) value() (value interface{}, err error) {
+	switch c.Kind {
+	case cursorKindNull:
+		return nil, nil
+	case cursorKindString:
+		return c.Value, nil
+	case cursorKindInt:
+		value, err = strconv.ParseInt(c.Value, 10, 64)
+	case cursorKindUint:
+		value, err = strconv.ParseUint(c.Value, 10, 64)
+	case cursorKindTime:
+		value, err = time.Parse(time.RFC3339Nano, c.Value)
+	default:
+		err = errors.ThrowInvalidArgument(nil, "QUERY-Rw7dj", "Errors.Query.InvalidCursor")
+	}
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-Ds4gy", "Errors.Query.InvalidCursor")
+	}
+	return value, nil
+}
+
+// comp returns the condition for the results after the cursor
+func (c *cursor) comp(sortingCol, idCol Column) sq.Sqlizer {
+	operator := " < "
+	if c.Asc {
+		operator = " > "
+	}
+	after := sq.Expr(idCol.identifier()+operator+"?", c.ID)
+	if sortingCol.identifier() == idCol.identifier() {
+		return after
+	}
+	value, _ := c.value()
+	placeholder := "?"
+	if sortingCol.isOrderByLower {
+		placeholder = "LOWER(?::TEXT)"
+	}
+	sortingExpr := cursorOrderBy(sortingCol)
+	return sq.Or{
+		sq.Expr(sortingExpr+operator+placeholder, value),
+		sq.And{
+			sq.Expr(sortingExpr+" = "+placeholder, value),
+			after,
+		},
+	}
+}
+
+// cursorOrderBy orders the text columns, which may be null because of joins, like empty text,
+// so the values of the results are always comparable with the value of the cursor
+func cursorOrderBy(col Column) string {
+	if !col.isOrderByLower {
+		return col.identifier()
+	}
+	return "LOWER(COALESCE(" + col.identifier() + ", ''))"
+}
+
+func (req *SearchRequest) cursorSortingColumn(idCol Column) Column {
+	if req.SortingColumn.isZero() {
+		return idCol
+	}
+	return req.SortingColumn
+}
+
+// toCursorQuery adds the request to the query like toQuery,
+// but the results are additionally ordered by the id column, so the search can be continued after a cursor.
+// If the request contains a cursor the offset is ignored.
+// Without cursor and sorting the results are not ordered, like by toQuery, and cannot be continued by a cursor.
+//
+// The cursor is part of the where clause, therefore the total count (COUNT(*) OVER ())
+// of a search continued by a cursor only counts the results after the cursor
+func (req *SearchRequest) toCursorQuery(query sq.SelectBuilder, idCol Column) (sq.SelectBuilder, error) {
+	if !req.isCursorOrdered() {
+		return req.toQuery(query), nil
+	}
+	sortingCol := req.cursorSortingColumn(idCol)
+	if req.Cursor != "" {
+		c, err := decodeCursor(req.Cursor)
+		if err != nil {
+			return query, err
+		}
+		if c.Column != sortingCol.identifier() || c.Asc != req.Asc {
+			return query, errors.ThrowInvalidArgument(nil, "QUERY-Tm6sq", "Errors.Query.InvalidCursor")
+		}
+		query = query.Where(c.comp(sortingCol, idCol))
+	} else if req.Offset > 0 {
+		query = query.Offset(req.Offset)
+	}
+	if req.Limit > 0 {
+		query = query.Limit(req.Limit)
+	}
+
+	direction := " DESC"
+	if req.Asc {
+		direction = ""
+	}
+	query = query.OrderByClause(cursorOrderBy(sortingCol) + direction)
+	if sortingCol.identifier() != idCol.identifier() {
+		query = query.OrderByClause(idCol.identifier() + direction)
+	}
+	return query, nil
+}
+
+// isCursorOrdered returns if the results are ordered, so the search can be continued after a cursor
+func (req *SearchRequest) isCursorOrdered() bool {
+	return req.Cursor != "" || !req.SortingColumn.isZero()
+}
+
+// nextCursor returns the cursor to continue the search after the last result.
+// It is empty if the results did not fill the page, because no further results exist,
+// or if the results are not ordered
+func (req *SearchRequest) nextCursor(results int, idCol Column, value func(Column) interface{}, id string) (string, error) {
+	if !req.isCursorOrdered() || req.Limit == 0 || uint64(results) < req.Limit {
+		return "", nil
+	}
+	sortingCol := req.cursorSortingColumn(idCol)
+	c, err := newCursor(sortingCol, req.Asc, value(sortingCol), id)
+	if err != nil {
+		return "", err
+	}
+	return c.encode()
+}
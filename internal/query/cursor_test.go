@@ -0,0 +1,191 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+var testIDCol = Column{
+	name:  "id",
+	table: testTable,
+}
+
+func testCursor(t *testing.T, column Column, asc bool, value interface{}, id string) string {
+	c, err := newCursor(column, asc, value, id)
+	require.NoError(t, err)
+	token, err := c.encode()
+	require.NoError(t, err)
+	return token
+}
+
+func Test_cursor_encode_decode(t *testing.T) {
+	date := time.Date(2023, 5, 4, 3, 2, 1, 123456000, time.UTC)
+	tests := []struct {
+		name      string
+		column    Column
+		value     interface{}
+		wantValue interface{}
+	}{
+		{
+			name:      "text",
+			column:    testCol,
+			value:     "value",
+			wantValue: "value",
+		},
+		{
+			name:      "null text",
+			column:    testLowerCol,
+			value:     nil,
+			wantValue: "",
+		},
+		{
+			name:      "time",
+			column:    testCol,
+			value:     date,
+			wantValue: date,
+		},
+		{
+			name:      "enum",
+			column:    testCol,
+			value:     int32(3),
+			wantValue: int64(3),
+		},
+		{
+			name:      "sequence",
+			column:    testCol,
+			value:     uint64(42),
+			wantValue: uint64(42),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := decodeCursor(testCursor(t, tt.column, true, tt.value, "id1"))
+			require.NoError(t, err)
+			assert.Equal(t, tt.column.identifier(), c.Column)
+			assert.True(t, c.Asc)
+			assert.Equal(t, "id1", c.ID)
+			value, err := c.value()
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantValue, value)
+		})
+	}
+}
+
+func Test_decodeCursor_invalid(t *testing.T) {
+	for _, token := range []string{"not base64!", "bm90IGpzb24", "e30"} {
+		_, err := decodeCursor(token)
+		assert.True(t, caos_errs.IsErrorInvalidArgument(err), token)
+	}
+}
+
+func TestSearchRequest_toCursorQuery(t *testing.T) {
+	tests := []struct {
+		name     string
+		req      *SearchRequest
+		wantStmt string
+		wantArgs []interface{}
+		wantErr  func(error) bool
+	}{
+		{
+			name: "no sorting column and cursor, not ordered",
+			req: &SearchRequest{
+				Offset: 5,
+				Limit:  10,
+			},
+			wantStmt: "SELECT test_table.test_col FROM test_table LIMIT 10 OFFSET 5",
+		},
+		{
+			name: "sorting column, ordered by id as well",
+			req: &SearchRequest{
+				Limit:         10,
+				SortingColumn: testLowerCol,
+				Asc:           true,
+			},
+			wantStmt: "SELECT test_table.test_col FROM test_table ORDER BY LOWER(COALESCE(test_table.test_lower_col, '')), test_table.id LIMIT 10",
+		},
+		{
+			name: "cursor of id, offset ignored",
+			req: &SearchRequest{
+				Offset: 5,
+				Limit:  10,
+				Cursor: testCursor(t, testIDCol, false, "id1", "id1"),
+			},
+			wantStmt: "SELECT test_table.test_col FROM test_table WHERE test_table.id < ? ORDER BY test_table.id DESC LIMIT 10",
+			wantArgs: []interface{}{"id1"},
+		},
+		{
+			name: "cursor of sorting column",
+			req: &SearchRequest{
+				Limit:         10,
+				SortingColumn: testLowerCol,
+				Asc:           true,
+				Cursor:        testCursor(t, testLowerCol, true, "Value", "id1"),
+			},
+			wantStmt: "SELECT test_table.test_col FROM test_table WHERE (LOWER(COALESCE(test_table.test_lower_col, '')) > LOWER(?::TEXT) OR (LOWER(COALESCE(test_table.test_lower_col, '')) = LOWER(?::TEXT) AND test_table.id > ?)) ORDER BY LOWER(COALESCE(test_table.test_lower_col, '')), test_table.id LIMIT 10",
+			wantArgs: []interface{}{"Value", "Value", "id1"},
+		},
+		{
+			name: "cursor of other sorting column, invalid argument error",
+			req: &SearchRequest{
+				Limit:         10,
+				SortingColumn: testCol,
+				Cursor:        testCursor(t, testLowerCol, false, "value", "id1"),
+			},
+			wantErr: caos_errs.IsErrorInvalidArgument,
+		},
+		{
+			name: "cursor of other direction, invalid argument error",
+			req: &SearchRequest{
+				Limit:  10,
+				Asc:    true,
+				Cursor: testCursor(t, testIDCol, false, "id1", "id1"),
+			},
+			wantErr: caos_errs.IsErrorInvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := tt.req.toCursorQuery(sq.Select(testCol.identifier()).From(testTable.identifier()), testIDCol)
+			if tt.wantErr != nil {
+				assert.True(t, tt.wantErr(err))
+				return
+			}
+			require.NoError(t, err)
+			stmt, args, err := query.ToSql()
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStmt, stmt)
+			assert.Equal(t, tt.wantArgs, args)
+		})
+	}
+}
+
+func TestSearchRequest_nextCursor(t *testing.T) {
+	value := func(col Column) interface{} {
+		return "value"
+	}
+	req := &SearchRequest{Limit: 2, SortingColumn: testLowerCol}
+
+	token, err := req.nextCursor(1, testIDCol, value, "id1")
+	require.NoError(t, err)
+	assert.Empty(t, token, "page not full")
+
+	token, err = req.nextCursor(2, testIDCol, value, "id2")
+	require.NoError(t, err)
+	c, err := decodeCursor(token)
+	require.NoError(t, err)
+	assert.Equal(t, &cursor{Column: testLowerCol.identifier(), Kind: cursorKindString, Value: "value", ID: "id2"}, c)
+
+	token, err = (&SearchRequest{SortingColumn: testLowerCol}).nextCursor(2, testIDCol, value, "id2")
+	require.NoError(t, err)
+	assert.Empty(t, token, "no limit")
+
+	token, err = (&SearchRequest{Limit: 2}).nextCursor(2, testIDCol, value, "id2")
+	require.NoError(t, err)
+	assert.Empty(t, token, "not ordered")
+}
@@ -0,0 +1,352 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	errs "errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+type EmailProviders struct {
+	SearchResponse
+	Providers []*EmailProvider
+}
+
+type EmailProvider struct {
+	AggregateID   string
+	ID            string
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	ResourceOwner string
+	State         domain.EmailProviderState
+	Type          domain.EmailProviderType
+	Sequence      uint64
+	Description   string
+	Priority      int32
+	SenderAddress string
+	SenderName    string
+
+	HTTPConfig *EmailProviderHTTP
+}
+
+type EmailProviderHTTP struct {
+	Endpoint     string
+	Method       string
+	Headers      *crypto.CryptoValue
+	BodyTemplate string
+}
+
+type EmailProvidersSearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+func (q *EmailProvidersSearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+var (
+	emailProvidersTable = table{
+		name:          projection.EmailProviderTable,
+		instanceIDCol: projection.EmailProviderColumnInstanceID,
+	}
+	EmailProviderColumnID = Column{
+		name:  projection.EmailProviderColumnID,
+		table: emailProvidersTable,
+	}
+	EmailProviderColumnAggregateID = Column{
+		name:  projection.EmailProviderColumnAggregateID,
+		table: emailProvidersTable,
+	}
+	EmailProviderColumnCreationDate = Column{
+		name:  projection.EmailProviderColumnCreationDate,
+		table: emailProvidersTable,
+	}
+	EmailProviderColumnChangeDate = Column{
+		name:  projection.EmailProviderColumnChangeDate,
+		table: emailProvidersTable,
+	}
+	EmailProviderColumnResourceOwner = Column{
+		name:  projection.EmailProviderColumnResourceOwner,
+		table: emailProvidersTable,
+	}
+	EmailProviderColumnInstanceID = Column{
+		name:  projection.EmailProviderColumnInstanceID,
+		table: emailProvidersTable,
+	}
+	EmailProviderColumnState = Column{
+		name:  projection.EmailProviderColumnState,
+		table: emailProvidersTable,
+	}
+	EmailProviderColumnType = Column{
+		name:  projection.EmailProviderColumnType,
+		table: emailProvidersTable,
+	}
+	EmailProviderColumnSequence = Column{
+		name:  projection.EmailProviderColumnSequence,
+		table: emailProvidersTable,
+	}
+	EmailProviderColumnDescription = Column{
+		name:  projection.EmailProviderColumnDescription,
+		table: emailProvidersTable,
+	}
+	EmailProviderColumnPriority = Column{
+		name:  projection.EmailProviderColumnPriority,
+		table: emailProvidersTable,
+	}
+	EmailProviderColumnSenderAddress = Column{
+		name:  projection.EmailProviderColumnSenderAddress,
+		table: emailProvidersTable,
+	}
+	EmailProviderColumnSenderName = Column{
+		name:  projection.EmailProviderColumnSenderName,
+		table: emailProvidersTable,
+	}
+)
+
+var (
+	emailProviderHTTPTable = table{
+		name:          projection.EmailProviderHTTPTable,
+		instanceIDCol: projection.EmailProviderHTTPColumnInstanceID,
+	}
+	EmailProviderHTTPColumnProviderID = Column{
+		name:  projection.EmailProviderHTTPColumnProviderID,
+		table: emailProviderHTTPTable,
+	}
+	EmailProviderHTTPColumnEndpoint = Column{
+		name:  projection.EmailProviderHTTPColumnEndpoint,
+		table: emailProviderHTTPTable,
+	}
+	EmailProviderHTTPColumnMethod = Column{
+		name:  projection.EmailProviderHTTPColumnMethod,
+		table: emailProviderHTTPTable,
+	}
+	EmailProviderHTTPColumnHeaders = Column{
+		name:  projection.EmailProviderHTTPColumnHeaders,
+		table: emailProviderHTTPTable,
+	}
+	EmailProviderHTTPColumnBodyTemplate = Column{
+		name:  projection.EmailProviderHTTPColumnBodyTemplate,
+		table: emailProviderHTTPTable,
+	}
+)
+
+func (q *Queries) EmailProviderByID(ctx context.Context, id string) (_ *EmailProvider, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareEmailProviderQuery(ctx, q.client)
+	stmt, args, err := query.Where(
+		sq.Eq{
+			EmailProviderColumnID.identifier():         id,
+			EmailProviderColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+		},
+	).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Ep3nd", "Errors.Query.SQLStatement")
+	}
+
+	row := q.client.QueryRowContext(ctx, stmt, args...)
+	return scan(row)
+}
+
+func (q *Queries) SearchEmailProviders(ctx context.Context, queries *EmailProvidersSearchQueries) (_ *EmailProviders, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareEmailProvidersQuery(ctx, q.client)
+	stmt, args, err := queries.toQuery(query).
+		Where(sq.Eq{
+			EmailProviderColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+		}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-Ep8wq", "Errors.Query.InvalidRequest")
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Ep5mc", "Errors.Internal")
+	}
+	providers, err := scan(rows)
+	if err != nil {
+		return nil, err
+	}
+	providers.LatestSequence, err = q.latestSequence(ctx, emailProvidersTable)
+	return providers, err
+}
+
+// ActiveEmailProviders returns the active providers of the instance ordered by their priority
+func (q *Queries) ActiveEmailProviders(ctx context.Context) (*EmailProviders, error) {
+	stateQuery, err := NewEmailProviderStateQuery(domain.EmailProviderStateActive)
+	if err != nil {
+		return nil, err
+	}
+	return q.SearchEmailProviders(ctx, &EmailProvidersSearchQueries{
+		SearchRequest: SearchRequest{
+			SortingColumn: EmailProviderColumnPriority,
+			Asc:           true,
+		},
+		Queries: []SearchQuery{stateQuery},
+	})
+}
+
+func NewEmailProviderStateQuery(state domain.EmailProviderState) (SearchQuery, error) {
+	return NewNumberQuery(EmailProviderColumnState, state, NumberEquals)
+}
+
+func prepareEmailProviderQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Row) (*EmailProvider, error)) {
+	return sq.Select(
+			EmailProviderColumnID.identifier(),
+			EmailProviderColumnAggregateID.identifier(),
+			EmailProviderColumnCreationDate.identifier(),
+			EmailProviderColumnChangeDate.identifier(),
+			EmailProviderColumnResourceOwner.identifier(),
+			EmailProviderColumnState.identifier(),
+			EmailProviderColumnType.identifier(),
+			EmailProviderColumnSequence.identifier(),
+			EmailProviderColumnDescription.identifier(),
+			EmailProviderColumnPriority.identifier(),
+			EmailProviderColumnSenderAddress.identifier(),
+			EmailProviderColumnSenderName.identifier(),
+
+			EmailProviderHTTPColumnProviderID.identifier(),
+			EmailProviderHTTPColumnEndpoint.identifier(),
+			EmailProviderHTTPColumnMethod.identifier(),
+			EmailProviderHTTPColumnHeaders.identifier(),
+			EmailProviderHTTPColumnBodyTemplate.identifier(),
+		).From(emailProvidersTable.identifier()).
+			LeftJoin(join(EmailProviderHTTPColumnProviderID, EmailProviderColumnID) + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar), func(row *sql.Row) (*EmailProvider, error) {
+			provider := new(EmailProvider)
+			httpConfig := sqlEmailProviderHTTP{}
+
+			err := row.Scan(
+				&provider.ID,
+				&provider.AggregateID,
+				&provider.CreationDate,
+				&provider.ChangeDate,
+				&provider.ResourceOwner,
+				&provider.State,
+				&provider.Type,
+				&provider.Sequence,
+				&provider.Description,
+				&provider.Priority,
+				&provider.SenderAddress,
+				&provider.SenderName,
+
+				&httpConfig.providerID,
+				&httpConfig.endpoint,
+				&httpConfig.method,
+				&httpConfig.headers,
+				&httpConfig.bodyTemplate,
+			)
+			if err != nil {
+				if errs.Is(err, sql.ErrNoRows) {
+					return nil, errors.ThrowNotFound(err, "QUERY-Ep6kz", "Errors.EmailProvider.NotFound")
+				}
+				return nil, errors.ThrowInternal(err, "QUERY-Ep2xs", "Errors.Internal")
+			}
+
+			httpConfig.set(provider)
+
+			return provider, nil
+		}
+}
+
+func prepareEmailProvidersQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) (*EmailProviders, error)) {
+	return sq.Select(
+			EmailProviderColumnID.identifier(),
+			EmailProviderColumnAggregateID.identifier(),
+			EmailProviderColumnCreationDate.identifier(),
+			EmailProviderColumnChangeDate.identifier(),
+			EmailProviderColumnResourceOwner.identifier(),
+			EmailProviderColumnState.identifier(),
+			EmailProviderColumnType.identifier(),
+			EmailProviderColumnSequence.identifier(),
+			EmailProviderColumnDescription.identifier(),
+			EmailProviderColumnPriority.identifier(),
+			EmailProviderColumnSenderAddress.identifier(),
+			EmailProviderColumnSenderName.identifier(),
+
+			EmailProviderHTTPColumnProviderID.identifier(),
+			EmailProviderHTTPColumnEndpoint.identifier(),
+			EmailProviderHTTPColumnMethod.identifier(),
+			EmailProviderHTTPColumnHeaders.identifier(),
+			EmailProviderHTTPColumnBodyTemplate.identifier(),
+			countColumn.identifier(),
+		).From(emailProvidersTable.identifier()).
+			LeftJoin(join(EmailProviderHTTPColumnProviderID, EmailProviderColumnID) + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar), func(rows *sql.Rows) (*EmailProviders, error) {
+			providers := &EmailProviders{Providers: []*EmailProvider{}}
+
+			for rows.Next() {
+				provider := new(EmailProvider)
+				httpConfig := sqlEmailProviderHTTP{}
+
+				err := rows.Scan(
+					&provider.ID,
+					&provider.AggregateID,
+					&provider.CreationDate,
+					&provider.ChangeDate,
+					&provider.ResourceOwner,
+					&provider.State,
+					&provider.Type,
+					&provider.Sequence,
+					&provider.Description,
+					&provider.Priority,
+					&provider.SenderAddress,
+					&provider.SenderName,
+
+					&httpConfig.providerID,
+					&httpConfig.endpoint,
+					&httpConfig.method,
+					&httpConfig.headers,
+					&httpConfig.bodyTemplate,
+					&providers.Count,
+				)
+				if err != nil {
+					return nil, errors.ThrowInternal(err, "QUERY-Ep9hb", "Errors.Internal")
+				}
+
+				httpConfig.set(provider)
+
+				providers.Providers = append(providers.Providers, provider)
+			}
+
+			return providers, nil
+		}
+}
+
+type sqlEmailProviderHTTP struct {
+	providerID   sql.NullString
+	endpoint     sql.NullString
+	method       sql.NullString
+	headers      *crypto.CryptoValue
+	bodyTemplate sql.NullString
+}
+
+func (c sqlEmailProviderHTTP) set(provider *EmailProvider) {
+	if !c.providerID.Valid {
+		return
+	}
+	provider.HTTPConfig = &EmailProviderHTTP{
+		Endpoint:     c.endpoint.String,
+		Method:       c.method.String,
+		Headers:      c.headers,
+		BodyTemplate: c.bodyTemplate.String,
+	}
+}
@@ -0,0 +1,269 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+var (
+	expectedEmailProviderColumns = `SELECT projections.email_providers.id,` +
+		` projections.email_providers.aggregate_id,` +
+		` projections.email_providers.creation_date,` +
+		` projections.email_providers.change_date,` +
+		` projections.email_providers.resource_owner,` +
+		` projections.email_providers.state,` +
+		` projections.email_providers.provider_type,` +
+		` projections.email_providers.sequence,` +
+		` projections.email_providers.description,` +
+		` projections.email_providers.priority,` +
+		` projections.email_providers.sender_address,` +
+		` projections.email_providers.sender_name,` +
+
+		// http config
+		` projections.email_providers_http.provider_id,` +
+		` projections.email_providers_http.endpoint,` +
+		` projections.email_providers_http.method,` +
+		` projections.email_providers_http.headers,` +
+		` projections.email_providers_http.body_template`
+	expectedEmailProviderJoin = ` FROM projections.email_providers` +
+		` LEFT JOIN projections.email_providers_http ON projections.email_providers.id = projections.email_providers_http.provider_id AND projections.email_providers.instance_id = projections.email_providers_http.instance_id` +
+		` AS OF SYSTEM TIME '-1 ms'`
+	expectedEmailProviderQuery  = regexp.QuoteMeta(expectedEmailProviderColumns + expectedEmailProviderJoin)
+	expectedEmailProvidersQuery = regexp.QuoteMeta(expectedEmailProviderColumns + `, COUNT(*) OVER ()` + expectedEmailProviderJoin)
+
+	emailProviderCols = []string{
+		"id",
+		"aggregate_id",
+		"creation_date",
+		"change_date",
+		"resource_owner",
+		"state",
+		"provider_type",
+		"sequence",
+		"description",
+		"priority",
+		"sender_address",
+		"sender_name",
+		// http config
+		"provider_id",
+		"endpoint",
+		"method",
+		"headers",
+		"body_template",
+	}
+	emailProvidersCols = append(emailProviderCols, "count")
+)
+
+func Test_EmailProvidersPrepare(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareEmailProvidersQuery no result",
+			prepare: prepareEmailProvidersQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					expectedEmailProvidersQuery,
+					nil,
+					nil,
+				),
+			},
+			object: &EmailProviders{Providers: []*EmailProvider{}},
+		},
+		{
+			name:    "prepareEmailProvidersQuery http provider",
+			prepare: prepareEmailProvidersQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					expectedEmailProvidersQuery,
+					emailProvidersCols,
+					[][]driver.Value{
+						{
+							"provider-id",
+							"agg-id",
+							testNow,
+							testNow,
+							"ro",
+							domain.EmailProviderStateActive,
+							domain.EmailProviderTypeHTTP,
+							uint64(20211109),
+							"description",
+							int32(1),
+							"zitadel@example.com",
+							"ZITADEL",
+							// http config
+							"provider-id",
+							"https://api.example.com/send",
+							"POST",
+							&crypto.CryptoValue{},
+							"",
+						},
+					},
+				),
+			},
+			object: &EmailProviders{
+				SearchResponse: SearchResponse{
+					Count: 1,
+				},
+				Providers: []*EmailProvider{
+					{
+						ID:            "provider-id",
+						AggregateID:   "agg-id",
+						CreationDate:  testNow,
+						ChangeDate:    testNow,
+						ResourceOwner: "ro",
+						State:         domain.EmailProviderStateActive,
+						Type:          domain.EmailProviderTypeHTTP,
+						Sequence:      20211109,
+						Description:   "description",
+						Priority:      1,
+						SenderAddress: "zitadel@example.com",
+						SenderName:    "ZITADEL",
+						HTTPConfig: &EmailProviderHTTP{
+							Endpoint: "https://api.example.com/send",
+							Method:   "POST",
+							Headers:  &crypto.CryptoValue{},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareEmailProvidersQuery sql err",
+			prepare: prepareEmailProvidersQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					expectedEmailProvidersQuery,
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
+
+func Test_EmailProviderPrepare(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareEmailProviderQuery no result",
+			prepare: prepareEmailProviderQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					expectedEmailProviderQuery,
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !errs.IsNotFound(err) {
+						return fmt.Errorf("err should be zitadel.NotFoundError got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*EmailProvider)(nil),
+		},
+		{
+			name:    "prepareEmailProviderQuery found",
+			prepare: prepareEmailProviderQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					expectedEmailProviderQuery,
+					emailProviderCols,
+					[]driver.Value{
+						"provider-id",
+						"agg-id",
+						testNow,
+						testNow,
+						"ro",
+						domain.EmailProviderStateInactive,
+						domain.EmailProviderTypeHTTP,
+						uint64(20211109),
+						"",
+						int32(0),
+						"zitadel@example.com",
+						"",
+						// http config
+						"provider-id",
+						"https://api.example.com/send",
+						"PUT",
+						nil,
+						`{"subject":{{json .Subject}}}`,
+					},
+				),
+			},
+			object: &EmailProvider{
+				ID:            "provider-id",
+				AggregateID:   "agg-id",
+				CreationDate:  testNow,
+				ChangeDate:    testNow,
+				ResourceOwner: "ro",
+				State:         domain.EmailProviderStateInactive,
+				Type:          domain.EmailProviderTypeHTTP,
+				Sequence:      20211109,
+				SenderAddress: "zitadel@example.com",
+				HTTPConfig: &EmailProviderHTTP{
+					Endpoint:     "https://api.example.com/send",
+					Method:       "PUT",
+					BodyTemplate: `{"subject":{{json .Subject}}}`,
+				},
+			},
+		},
+		{
+			name:    "prepareEmailProviderQuery sql err",
+			prepare: prepareEmailProviderQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					expectedEmailProviderQuery,
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
@@ -0,0 +1,438 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	errs "errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+type IDPLDAPSyncs struct {
+	SearchResponse
+	Syncs []*IDPLDAPSync
+}
+
+type IDPLDAPSync struct {
+	IDPID              string
+	ChangeDate         time.Time
+	Sequence           uint64
+	ResourceOwner      string
+	InstanceID         string
+	Enabled            bool
+	Interval           time.Duration
+	OrgID              string
+	DeactivateMissing  bool
+	DisabledFilter     string
+	MetadataAttributes database.StringArray
+	GroupsAttribute    string
+	GroupRules         []*domain.LDAPSyncGroupRule
+	LastRun            time.Time
+	NextRun            time.Time
+}
+
+type IDPLDAPSyncRuns struct {
+	SearchResponse
+	Runs []*IDPLDAPSyncRun
+}
+
+type IDPLDAPSyncRun struct {
+	IDPID         string
+	InstanceID    string
+	Sequence      uint64
+	CreationDate  time.Time
+	StartedAt     time.Time
+	State         domain.LDAPSyncRunState
+	DryRun        bool
+	Created       uint64
+	Updated       uint64
+	Deactivated   uint64
+	GrantsChanged uint64
+	Failed        uint64
+	Error         string
+}
+
+type IDPLDAPSyncRunSearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+func (q *IDPLDAPSyncRunSearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+var (
+	idpLDAPSyncTable = table{
+		name:          projection.IDPLDAPSyncTable,
+		instanceIDCol: projection.IDPLDAPSyncInstanceIDCol,
+	}
+	IDPLDAPSyncIDPIDCol = Column{
+		name:  projection.IDPLDAPSyncIDPIDCol,
+		table: idpLDAPSyncTable,
+	}
+	IDPLDAPSyncChangeDateCol = Column{
+		name:  projection.IDPLDAPSyncChangeDateCol,
+		table: idpLDAPSyncTable,
+	}
+	IDPLDAPSyncSequenceCol = Column{
+		name:  projection.IDPLDAPSyncSequenceCol,
+		table: idpLDAPSyncTable,
+	}
+	IDPLDAPSyncResourceOwnerCol = Column{
+		name:  projection.IDPLDAPSyncResourceOwnerCol,
+		table: idpLDAPSyncTable,
+	}
+	IDPLDAPSyncInstanceIDCol = Column{
+		name:  projection.IDPLDAPSyncInstanceIDCol,
+		table: idpLDAPSyncTable,
+	}
+	IDPLDAPSyncEnabledCol = Column{
+		name:  projection.IDPLDAPSyncEnabledCol,
+		table: idpLDAPSyncTable,
+	}
+	IDPLDAPSyncIntervalCol = Column{
+		name:  projection.IDPLDAPSyncIntervalCol,
+		table: idpLDAPSyncTable,
+	}
+	IDPLDAPSyncOrgIDCol = Column{
+		name:  projection.IDPLDAPSyncOrgIDCol,
+		table: idpLDAPSyncTable,
+	}
+	IDPLDAPSyncDeactivateMissingCol = Column{
+		name:  projection.IDPLDAPSyncDeactivateMissingCol,
+		table: idpLDAPSyncTable,
+	}
+	IDPLDAPSyncDisabledFilterCol = Column{
+		name:  projection.IDPLDAPSyncDisabledFilterCol,
+		table: idpLDAPSyncTable,
+	}
+	IDPLDAPSyncMetadataAttributesCol = Column{
+		name:  projection.IDPLDAPSyncMetadataAttributesCol,
+		table: idpLDAPSyncTable,
+	}
+	IDPLDAPSyncGroupsAttributeCol = Column{
+		name:  projection.IDPLDAPSyncGroupsAttributeCol,
+		table: idpLDAPSyncTable,
+	}
+	IDPLDAPSyncGroupRulesCol = Column{
+		name:  projection.IDPLDAPSyncGroupRulesCol,
+		table: idpLDAPSyncTable,
+	}
+	IDPLDAPSyncLastRunCol = Column{
+		name:  projection.IDPLDAPSyncLastRunCol,
+		table: idpLDAPSyncTable,
+	}
+	IDPLDAPSyncNextRunCol = Column{
+		name:  projection.IDPLDAPSyncNextRunCol,
+		table: idpLDAPSyncTable,
+	}
+)
+
+var (
+	idpLDAPSyncRunTable = table{
+		name:          projection.IDPLDAPSyncRunTable,
+		instanceIDCol: projection.IDPLDAPSyncRunInstanceIDCol,
+	}
+	IDPLDAPSyncRunIDPIDCol = Column{
+		name:  projection.IDPLDAPSyncRunIDPIDCol,
+		table: idpLDAPSyncRunTable,
+	}
+	IDPLDAPSyncRunInstanceIDCol = Column{
+		name:  projection.IDPLDAPSyncRunInstanceIDCol,
+		table: idpLDAPSyncRunTable,
+	}
+	IDPLDAPSyncRunSequenceCol = Column{
+		name:  projection.IDPLDAPSyncRunSequenceCol,
+		table: idpLDAPSyncRunTable,
+	}
+	IDPLDAPSyncRunCreationDateCol = Column{
+		name:  projection.IDPLDAPSyncRunCreationDateCol,
+		table: idpLDAPSyncRunTable,
+	}
+	IDPLDAPSyncRunStartedAtCol = Column{
+		name:  projection.IDPLDAPSyncRunStartedAtCol,
+		table: idpLDAPSyncRunTable,
+	}
+	IDPLDAPSyncRunStateCol = Column{
+		name:  projection.IDPLDAPSyncRunStateCol,
+		table: idpLDAPSyncRunTable,
+	}
+	IDPLDAPSyncRunDryRunCol = Column{
+		name:  projection.IDPLDAPSyncRunDryRunCol,
+		table: idpLDAPSyncRunTable,
+	}
+	IDPLDAPSyncRunCreatedCol = Column{
+		name:  projection.IDPLDAPSyncRunCreatedCol,
+		table: idpLDAPSyncRunTable,
+	}
+	IDPLDAPSyncRunUpdatedCol = Column{
+		name:  projection.IDPLDAPSyncRunUpdatedCol,
+		table: idpLDAPSyncRunTable,
+	}
+	IDPLDAPSyncRunDeactivatedCol = Column{
+		name:  projection.IDPLDAPSyncRunDeactivatedCol,
+		table: idpLDAPSyncRunTable,
+	}
+	IDPLDAPSyncRunGrantsChangedCol = Column{
+		name:  projection.IDPLDAPSyncRunGrantsChangedCol,
+		table: idpLDAPSyncRunTable,
+	}
+	IDPLDAPSyncRunFailedCol = Column{
+		name:  projection.IDPLDAPSyncRunFailedCol,
+		table: idpLDAPSyncRunTable,
+	}
+	IDPLDAPSyncRunErrorCol = Column{
+		name:  projection.IDPLDAPSyncRunErrorCol,
+		table: idpLDAPSyncRunTable,
+	}
+)
+
+func (q *Queries) IDPLDAPSyncByID(ctx context.Context, shouldTriggerBulk bool, idpID string, queries ...SearchQuery) (_ *IDPLDAPSync, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if shouldTriggerBulk {
+		projection.IDPLDAPSyncProjection.Trigger(ctx)
+	}
+
+	query, scan := prepareIDPLDAPSyncQuery(ctx, q.client)
+	for _, q := range queries {
+		query = q.toQuery(query)
+	}
+	stmt, args, err := query.Where(
+		sq.Eq{
+			IDPLDAPSyncIDPIDCol.identifier():      idpID,
+			IDPLDAPSyncInstanceIDCol.identifier(): authz.GetInstance(ctx).InstanceID(),
+		},
+	).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Ls4fj", "Errors.Query.SQLStatement")
+	}
+
+	row := q.client.QueryRowContext(ctx, stmt, args...)
+	return scan(row)
+}
+
+// DueIDPLDAPSyncs returns the enabled synchronizations of all instances,
+// which are due for their next run, ordered by their due date
+func (q *Queries) DueIDPLDAPSyncs(ctx context.Context, limit uint64) (_ *IDPLDAPSyncs, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareIDPLDAPSyncsQuery(ctx, q.client)
+	stmt, args, err := query.
+		Where(sq.Eq{
+			IDPLDAPSyncEnabledCol.identifier(): true,
+		}).
+		Where(sq.LtOrEq{
+			IDPLDAPSyncNextRunCol.identifier(): time.Now(),
+		}).
+		OrderBy(IDPLDAPSyncNextRunCol.identifier()).
+		Limit(limit).
+		ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Ls8xn", "Errors.Query.SQLStatement")
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Ls1qa", "Errors.Internal")
+	}
+	return scan(rows)
+}
+
+func (q *Queries) SearchIDPLDAPSyncRuns(ctx context.Context, idpID string, queries *IDPLDAPSyncRunSearchQueries) (_ *IDPLDAPSyncRuns, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareIDPLDAPSyncRunsQuery(ctx, q.client)
+	stmt, args, err := queries.toQuery(query).
+		Where(sq.Eq{
+			IDPLDAPSyncRunIDPIDCol.identifier():      idpID,
+			IDPLDAPSyncRunInstanceIDCol.identifier(): authz.GetInstance(ctx).InstanceID(),
+		}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-Ls6rd", "Errors.Query.InvalidRequest")
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Ls3ye", "Errors.Internal")
+	}
+	runs, err := scan(rows)
+	if err != nil {
+		return nil, err
+	}
+	runs.LatestSequence, err = q.latestSequence(ctx, idpLDAPSyncTable)
+	return runs, err
+}
+
+func NewIDPLDAPSyncResourceOwnerSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(IDPLDAPSyncResourceOwnerCol, value, TextEquals)
+}
+
+func NewIDPLDAPSyncRunStateSearchQuery(state domain.LDAPSyncRunState) (SearchQuery, error) {
+	return NewNumberQuery(IDPLDAPSyncRunStateCol, state, NumberEquals)
+}
+
+func NewIDPLDAPSyncRunDryRunSearchQuery(dryRun bool) (SearchQuery, error) {
+	return NewBoolQuery(IDPLDAPSyncRunDryRunCol, dryRun)
+}
+
+func idpLDAPSyncColumns() []string {
+	return []string{
+		IDPLDAPSyncIDPIDCol.identifier(),
+		IDPLDAPSyncChangeDateCol.identifier(),
+		IDPLDAPSyncSequenceCol.identifier(),
+		IDPLDAPSyncResourceOwnerCol.identifier(),
+		IDPLDAPSyncInstanceIDCol.identifier(),
+		IDPLDAPSyncEnabledCol.identifier(),
+		IDPLDAPSyncIntervalCol.identifier(),
+		IDPLDAPSyncOrgIDCol.identifier(),
+		IDPLDAPSyncDeactivateMissingCol.identifier(),
+		IDPLDAPSyncDisabledFilterCol.identifier(),
+		IDPLDAPSyncMetadataAttributesCol.identifier(),
+		IDPLDAPSyncGroupsAttributeCol.identifier(),
+		IDPLDAPSyncGroupRulesCol.identifier(),
+		IDPLDAPSyncLastRunCol.identifier(),
+		IDPLDAPSyncNextRunCol.identifier(),
+	}
+}
+
+type idpLDAPSyncScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanIDPLDAPSync(row idpLDAPSyncScanner, dest ...interface{}) (*IDPLDAPSync, error) {
+	sync := new(IDPLDAPSync)
+	var (
+		interval   int64
+		groupRules []byte
+		lastRun    sql.NullTime
+	)
+	err := row.Scan(append([]interface{}{
+		&sync.IDPID,
+		&sync.ChangeDate,
+		&sync.Sequence,
+		&sync.ResourceOwner,
+		&sync.InstanceID,
+		&sync.Enabled,
+		&interval,
+		&sync.OrgID,
+		&sync.DeactivateMissing,
+		&sync.DisabledFilter,
+		&sync.MetadataAttributes,
+		&sync.GroupsAttribute,
+		&groupRules,
+		&lastRun,
+		&sync.NextRun,
+	}, dest...)...)
+	if err != nil {
+		return nil, err
+	}
+	sync.Interval = time.Duration(interval)
+	sync.LastRun = lastRun.Time
+	if len(groupRules) > 0 {
+		if err := json.Unmarshal(groupRules, &sync.GroupRules); err != nil {
+			return nil, err
+		}
+	}
+	return sync, nil
+}
+
+func prepareIDPLDAPSyncQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Row) (*IDPLDAPSync, error)) {
+	return sq.Select(idpLDAPSyncColumns()...).
+			From(idpLDAPSyncTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar), func(row *sql.Row) (*IDPLDAPSync, error) {
+			sync, err := scanIDPLDAPSync(row)
+			if err != nil {
+				if errs.Is(err, sql.ErrNoRows) {
+					return nil, errors.ThrowNotFound(err, "QUERY-Ls9gu", "Errors.IDPConfig.LDAPSync.NotFound")
+				}
+				return nil, errors.ThrowInternal(err, "QUERY-Ls2ws", "Errors.Internal")
+			}
+			return sync, nil
+		}
+}
+
+func prepareIDPLDAPSyncsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) (*IDPLDAPSyncs, error)) {
+	return sq.Select(append(idpLDAPSyncColumns(), countColumn.identifier())...).
+			From(idpLDAPSyncTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar), func(rows *sql.Rows) (*IDPLDAPSyncs, error) {
+			syncs := &IDPLDAPSyncs{Syncs: []*IDPLDAPSync{}}
+			for rows.Next() {
+				sync, err := scanIDPLDAPSync(rows, &syncs.Count)
+				if err != nil {
+					return nil, errors.ThrowInternal(err, "QUERY-Ls5nb", "Errors.Internal")
+				}
+				syncs.Syncs = append(syncs.Syncs, sync)
+			}
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Ls7kc", "Errors.Query.CloseRows")
+			}
+			return syncs, nil
+		}
+}
+
+func prepareIDPLDAPSyncRunsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) (*IDPLDAPSyncRuns, error)) {
+	return sq.Select(
+			IDPLDAPSyncRunIDPIDCol.identifier(),
+			IDPLDAPSyncRunInstanceIDCol.identifier(),
+			IDPLDAPSyncRunSequenceCol.identifier(),
+			IDPLDAPSyncRunCreationDateCol.identifier(),
+			IDPLDAPSyncRunStartedAtCol.identifier(),
+			IDPLDAPSyncRunStateCol.identifier(),
+			IDPLDAPSyncRunDryRunCol.identifier(),
+			IDPLDAPSyncRunCreatedCol.identifier(),
+			IDPLDAPSyncRunUpdatedCol.identifier(),
+			IDPLDAPSyncRunDeactivatedCol.identifier(),
+			IDPLDAPSyncRunGrantsChangedCol.identifier(),
+			IDPLDAPSyncRunFailedCol.identifier(),
+			IDPLDAPSyncRunErrorCol.identifier(),
+			countColumn.identifier(),
+		).From(idpLDAPSyncRunTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar), func(rows *sql.Rows) (*IDPLDAPSyncRuns, error) {
+			runs := &IDPLDAPSyncRuns{Runs: []*IDPLDAPSyncRun{}}
+			for rows.Next() {
+				run := new(IDPLDAPSyncRun)
+				err := rows.Scan(
+					&run.IDPID,
+					&run.InstanceID,
+					&run.Sequence,
+					&run.CreationDate,
+					&run.StartedAt,
+					&run.State,
+					&run.DryRun,
+					&run.Created,
+					&run.Updated,
+					&run.Deactivated,
+					&run.GrantsChanged,
+					&run.Failed,
+					&run.Error,
+					&runs.Count,
+				)
+				if err != nil {
+					return nil, errors.ThrowInternal(err, "QUERY-Ls4zo", "Errors.Internal")
+				}
+				runs.Runs = append(runs.Runs, run)
+			}
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Ls8pm", "Errors.Query.CloseRows")
+			}
+			return runs, nil
+		}
+}
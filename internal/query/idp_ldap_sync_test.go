@@ -0,0 +1,309 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+var (
+	expectedIDPLDAPSyncColumns = `SELECT projections.idp_ldap_syncs.idp_id,` +
+		` projections.idp_ldap_syncs.change_date,` +
+		` projections.idp_ldap_syncs.sequence,` +
+		` projections.idp_ldap_syncs.resource_owner,` +
+		` projections.idp_ldap_syncs.instance_id,` +
+		` projections.idp_ldap_syncs.enabled,` +
+		` projections.idp_ldap_syncs.sync_interval,` +
+		` projections.idp_ldap_syncs.org_id,` +
+		` projections.idp_ldap_syncs.deactivate_missing,` +
+		` projections.idp_ldap_syncs.disabled_filter,` +
+		` projections.idp_ldap_syncs.metadata_attributes,` +
+		` projections.idp_ldap_syncs.groups_attribute,` +
+		` projections.idp_ldap_syncs.group_rules,` +
+		` projections.idp_ldap_syncs.last_run,` +
+		` projections.idp_ldap_syncs.next_run`
+	expectedIDPLDAPSyncFrom   = ` FROM projections.idp_ldap_syncs AS OF SYSTEM TIME '-1 ms'`
+	expectedIDPLDAPSyncQuery  = regexp.QuoteMeta(expectedIDPLDAPSyncColumns + expectedIDPLDAPSyncFrom)
+	expectedIDPLDAPSyncsQuery = regexp.QuoteMeta(expectedIDPLDAPSyncColumns + `, COUNT(*) OVER ()` + expectedIDPLDAPSyncFrom)
+	idpLDAPSyncCols           = []string{
+		"idp_id",
+		"change_date",
+		"sequence",
+		"resource_owner",
+		"instance_id",
+		"enabled",
+		"sync_interval",
+		"org_id",
+		"deactivate_missing",
+		"disabled_filter",
+		"metadata_attributes",
+		"groups_attribute",
+		"group_rules",
+		"last_run",
+		"next_run",
+	}
+	idpLDAPSyncsCols = append(idpLDAPSyncCols, "count")
+
+	expectedIDPLDAPSyncRunsQuery = regexp.QuoteMeta(`SELECT projections.idp_ldap_syncs_runs.idp_id,` +
+		` projections.idp_ldap_syncs_runs.instance_id,` +
+		` projections.idp_ldap_syncs_runs.sequence,` +
+		` projections.idp_ldap_syncs_runs.creation_date,` +
+		` projections.idp_ldap_syncs_runs.started_at,` +
+		` projections.idp_ldap_syncs_runs.state,` +
+		` projections.idp_ldap_syncs_runs.dry_run,` +
+		` projections.idp_ldap_syncs_runs.created,` +
+		` projections.idp_ldap_syncs_runs.updated,` +
+		` projections.idp_ldap_syncs_runs.deactivated,` +
+		` projections.idp_ldap_syncs_runs.grants_changed,` +
+		` projections.idp_ldap_syncs_runs.failed,` +
+		` projections.idp_ldap_syncs_runs.error,` +
+		` COUNT(*) OVER ()` +
+		` FROM projections.idp_ldap_syncs_runs AS OF SYSTEM TIME '-1 ms'`)
+	idpLDAPSyncRunsCols = []string{
+		"idp_id",
+		"instance_id",
+		"sequence",
+		"creation_date",
+		"started_at",
+		"state",
+		"dry_run",
+		"created",
+		"updated",
+		"deactivated",
+		"grants_changed",
+		"failed",
+		"error",
+		"count",
+	}
+)
+
+func Test_IDPLDAPSyncPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareIDPLDAPSyncQuery no result",
+			prepare: prepareIDPLDAPSyncQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					expectedIDPLDAPSyncQuery,
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !errs.IsNotFound(err) {
+						return fmt.Errorf("err should be zitadel.NotFoundError got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*IDPLDAPSync)(nil),
+		},
+		{
+			name:    "prepareIDPLDAPSyncQuery found",
+			prepare: prepareIDPLDAPSyncQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					expectedIDPLDAPSyncQuery,
+					idpLDAPSyncCols,
+					[]driver.Value{
+						"idp-id",
+						testNow,
+						uint64(20211109),
+						"instance-id",
+						"instance-id",
+						true,
+						int64(time.Hour),
+						"org-id",
+						true,
+						"(disabled=TRUE)",
+						database.StringArray{"department"},
+						"memberOf",
+						[]byte(`[{"group":"cn=admins","projectId":"project-id","roleKeys":["admin"]}]`),
+						testNow,
+						testNow,
+					},
+				),
+			},
+			object: &IDPLDAPSync{
+				IDPID:              "idp-id",
+				ChangeDate:         testNow,
+				Sequence:           20211109,
+				ResourceOwner:      "instance-id",
+				InstanceID:         "instance-id",
+				Enabled:            true,
+				Interval:           time.Hour,
+				OrgID:              "org-id",
+				DeactivateMissing:  true,
+				DisabledFilter:     "(disabled=TRUE)",
+				MetadataAttributes: database.StringArray{"department"},
+				GroupsAttribute:    "memberOf",
+				GroupRules: []*domain.LDAPSyncGroupRule{
+					{Group: "cn=admins", ProjectID: "project-id", RoleKeys: []string{"admin"}},
+				},
+				LastRun: testNow,
+				NextRun: testNow,
+			},
+		},
+		{
+			name:    "prepareIDPLDAPSyncQuery sql err",
+			prepare: prepareIDPLDAPSyncQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					expectedIDPLDAPSyncQuery,
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+		{
+			name:    "prepareIDPLDAPSyncsQuery never run",
+			prepare: prepareIDPLDAPSyncsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					expectedIDPLDAPSyncsQuery,
+					idpLDAPSyncsCols,
+					[][]driver.Value{
+						{
+							"idp-id",
+							testNow,
+							uint64(20211109),
+							"instance-id",
+							"instance-id",
+							true,
+							int64(time.Hour),
+							"org-id",
+							false,
+							"",
+							nil,
+							"",
+							nil,
+							nil,
+							testNow,
+						},
+					},
+				),
+			},
+			object: &IDPLDAPSyncs{
+				SearchResponse: SearchResponse{
+					Count: 1,
+				},
+				Syncs: []*IDPLDAPSync{
+					{
+						IDPID:         "idp-id",
+						ChangeDate:    testNow,
+						Sequence:      20211109,
+						ResourceOwner: "instance-id",
+						InstanceID:    "instance-id",
+						Enabled:       true,
+						Interval:      time.Hour,
+						OrgID:         "org-id",
+						NextRun:       testNow,
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareIDPLDAPSyncRunsQuery no result",
+			prepare: prepareIDPLDAPSyncRunsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					expectedIDPLDAPSyncRunsQuery,
+					nil,
+					nil,
+				),
+			},
+			object: &IDPLDAPSyncRuns{Runs: []*IDPLDAPSyncRun{}},
+		},
+		{
+			name:    "prepareIDPLDAPSyncRunsQuery one result",
+			prepare: prepareIDPLDAPSyncRunsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					expectedIDPLDAPSyncRunsQuery,
+					idpLDAPSyncRunsCols,
+					[][]driver.Value{
+						{
+							"idp-id",
+							"instance-id",
+							uint64(20211109),
+							testNow,
+							testNow,
+							domain.LDAPSyncRunStateSucceeded,
+							true,
+							uint64(2),
+							uint64(3),
+							uint64(1),
+							uint64(4),
+							uint64(0),
+							"",
+						},
+					},
+				),
+			},
+			object: &IDPLDAPSyncRuns{
+				SearchResponse: SearchResponse{
+					Count: 1,
+				},
+				Runs: []*IDPLDAPSyncRun{
+					{
+						IDPID:         "idp-id",
+						InstanceID:    "instance-id",
+						Sequence:      20211109,
+						CreationDate:  testNow,
+						StartedAt:     testNow,
+						State:         domain.LDAPSyncRunStateSucceeded,
+						DryRun:        true,
+						Created:       2,
+						Updated:       3,
+						Deactivated:   1,
+						GrantsChanged: 4,
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareIDPLDAPSyncRunsQuery sql err",
+			prepare: prepareIDPLDAPSyncRunsQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					expectedIDPLDAPSyncRunsQuery,
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	errs "errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+type MessageMailTemplate struct {
+	AggregateID  string
+	Sequence     uint64
+	CreationDate time.Time
+	ChangeDate   time.Time
+	State        domain.PolicyState
+
+	MessageType string
+	Format      domain.MessageTemplateFormat
+	Source      []byte
+	// Template is the compiled HTML of the Source
+	Template  []byte
+	IsDefault bool
+}
+
+var (
+	messageMailTemplateTable = table{
+		name:          projection.MessageMailTemplateTable,
+		instanceIDCol: projection.MessageMailTemplateInstanceIDCol,
+	}
+	MessageMailTemplateColAggregateID = Column{
+		name:  projection.MessageMailTemplateAggregateIDCol,
+		table: messageMailTemplateTable,
+	}
+	MessageMailTemplateColInstanceID = Column{
+		name:  projection.MessageMailTemplateInstanceIDCol,
+		table: messageMailTemplateTable,
+	}
+	MessageMailTemplateColMessageType = Column{
+		name:  projection.MessageMailTemplateMessageTypeCol,
+		table: messageMailTemplateTable,
+	}
+	MessageMailTemplateColSequence = Column{
+		name:  projection.MessageMailTemplateSequenceCol,
+		table: messageMailTemplateTable,
+	}
+	MessageMailTemplateColCreationDate = Column{
+		name:  projection.MessageMailTemplateCreationDateCol,
+		table: messageMailTemplateTable,
+	}
+	MessageMailTemplateColChangeDate = Column{
+		name:  projection.MessageMailTemplateChangeDateCol,
+		table: messageMailTemplateTable,
+	}
+	MessageMailTemplateColState = Column{
+		name:  projection.MessageMailTemplateStateCol,
+		table: messageMailTemplateTable,
+	}
+	MessageMailTemplateColFormat = Column{
+		name:  projection.MessageMailTemplateFormatCol,
+		table: messageMailTemplateTable,
+	}
+	MessageMailTemplateColSource = Column{
+		name:  projection.MessageMailTemplateSourceCol,
+		table: messageMailTemplateTable,
+	}
+	MessageMailTemplateColTemplate = Column{
+		name:  projection.MessageMailTemplateTemplateCol,
+		table: messageMailTemplateTable,
+	}
+	MessageMailTemplateColIsDefault = Column{
+		name:  projection.MessageMailTemplateIsDefaultCol,
+		table: messageMailTemplateTable,
+	}
+	MessageMailTemplateColOwnerRemoved = Column{
+		name:  projection.MessageMailTemplateOwnerRemovedCol,
+		table: messageMailTemplateTable,
+	}
+)
+
+// MessageMailTemplateByOrg returns the mail template of the message type of the organisation
+// or the default template of the instance, if the organisation has none
+func (q *Queries) MessageMailTemplateByOrg(ctx context.Context, orgID, messageType string, withOwnerRemoved bool) (_ *MessageMailTemplate, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	stmt, scan := prepareMessageMailTemplateQuery(ctx, q.client)
+	eq := sq.Eq{
+		MessageMailTemplateColInstanceID.identifier():  authz.GetInstance(ctx).InstanceID(),
+		MessageMailTemplateColMessageType.identifier(): messageType,
+	}
+	if !withOwnerRemoved {
+		eq[MessageMailTemplateColOwnerRemoved.identifier()] = false
+	}
+	query, args, err := stmt.Where(
+		sq.And{
+			eq,
+			sq.Or{
+				sq.Eq{MessageMailTemplateColAggregateID.identifier(): orgID},
+				sq.Eq{MessageMailTemplateColAggregateID.identifier(): authz.GetInstance(ctx).InstanceID()},
+			},
+		}).
+		OrderBy(MessageMailTemplateColIsDefault.identifier()).
+		Limit(1).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Mq2sd", "Errors.Query.SQLStatement")
+	}
+
+	row := q.client.QueryRowContext(ctx, query, args...)
+	return scan(row)
+}
+
+func (q *Queries) DefaultMessageMailTemplate(ctx context.Context, messageType string) (_ *MessageMailTemplate, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	stmt, scan := prepareMessageMailTemplateQuery(ctx, q.client)
+	query, args, err := stmt.Where(sq.Eq{
+		MessageMailTemplateColAggregateID.identifier(): authz.GetInstance(ctx).InstanceID(),
+		MessageMailTemplateColInstanceID.identifier():  authz.GetInstance(ctx).InstanceID(),
+		MessageMailTemplateColMessageType.identifier(): messageType,
+	}).
+		Limit(1).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Mq7fk", "Errors.Query.SQLStatement")
+	}
+
+	row := q.client.QueryRowContext(ctx, query, args...)
+	return scan(row)
+}
+
+func prepareMessageMailTemplateQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Row) (*MessageMailTemplate, error)) {
+	return sq.Select(
+			MessageMailTemplateColAggregateID.identifier(),
+			MessageMailTemplateColSequence.identifier(),
+			MessageMailTemplateColCreationDate.identifier(),
+			MessageMailTemplateColChangeDate.identifier(),
+			MessageMailTemplateColMessageType.identifier(),
+			MessageMailTemplateColFormat.identifier(),
+			MessageMailTemplateColSource.identifier(),
+			MessageMailTemplateColTemplate.identifier(),
+			MessageMailTemplateColIsDefault.identifier(),
+			MessageMailTemplateColState.identifier(),
+		).
+			From(messageMailTemplateTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*MessageMailTemplate, error) {
+			template := new(MessageMailTemplate)
+			err := row.Scan(
+				&template.AggregateID,
+				&template.Sequence,
+				&template.CreationDate,
+				&template.ChangeDate,
+				&template.MessageType,
+				&template.Format,
+				&template.Source,
+				&template.Template,
+				&template.IsDefault,
+				&template.State,
+			)
+			if err != nil {
+				if errs.Is(err, sql.ErrNoRows) {
+					return nil, errors.ThrowNotFound(err, "QUERY-Mq4nb", "Errors.MailTemplate.NotFound")
+				}
+				return nil, errors.ThrowInternal(err, "QUERY-Mq9xe", "Errors.Internal")
+			}
+			return template, nil
+		}
+}
@@ -0,0 +1,128 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+var (
+	messageMailTemplateStmt = regexp.QuoteMeta(`SELECT projections.message_mail_templates.aggregate_id,` +
+		` projections.message_mail_templates.sequence,` +
+		` projections.message_mail_templates.creation_date,` +
+		` projections.message_mail_templates.change_date,` +
+		` projections.message_mail_templates.message_type,` +
+		` projections.message_mail_templates.format,` +
+		` projections.message_mail_templates.source,` +
+		` projections.message_mail_templates.template,` +
+		` projections.message_mail_templates.is_default,` +
+		` projections.message_mail_templates.state` +
+		` FROM projections.message_mail_templates` +
+		` AS OF SYSTEM TIME '-1 ms'`)
+	messageMailTemplateCols = []string{
+		"aggregate_id",
+		"sequence",
+		"creation_date",
+		"change_date",
+		"message_type",
+		"format",
+		"source",
+		"template",
+		"is_default",
+		"state",
+	}
+)
+
+func Test_MessageMailTemplatePrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareMessageMailTemplateQuery no result",
+			prepare: prepareMessageMailTemplateQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					messageMailTemplateStmt,
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !errs.IsNotFound(err) {
+						return fmt.Errorf("err should be NotFoundError got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*MessageMailTemplate)(nil),
+		},
+		{
+			name:    "prepareMessageMailTemplateQuery found",
+			prepare: prepareMessageMailTemplateQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					messageMailTemplateStmt,
+					messageMailTemplateCols,
+					[]driver.Value{
+						"org-id",
+						uint64(20211109),
+						testNow,
+						testNow,
+						domain.InitCodeMessageType,
+						domain.MessageTemplateFormatMJML,
+						[]byte("<mjml></mjml>"),
+						[]byte("<html></html>"),
+						false,
+						domain.PolicyStateActive,
+					},
+				),
+			},
+			object: &MessageMailTemplate{
+				AggregateID:  "org-id",
+				Sequence:     20211109,
+				CreationDate: testNow,
+				ChangeDate:   testNow,
+				State:        domain.PolicyStateActive,
+				MessageType:  domain.InitCodeMessageType,
+				Format:       domain.MessageTemplateFormatMJML,
+				Source:       []byte("<mjml></mjml>"),
+				Template:     []byte("<html></html>"),
+				IsDefault:    false,
+			},
+		},
+		{
+			name:    "prepareMessageMailTemplateQuery sql err",
+			prepare: prepareMessageMailTemplateQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					messageMailTemplateStmt,
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
@@ -31,6 +31,15 @@ type MessageTexts struct {
 	DomainClaimed            MessageText
 	PasswordlessRegistration MessageText
 	PasswordChange           MessageText
+	VerifySMSOTP             MessageText
+	VerifyEmailOTP           MessageText
+	RecoveryCodeUsed         MessageText
+	NewDeviceLogin           MessageText
+	MFAAdded                 MessageText
+	MFARemoved               MessageText
+	EmailChanged             MessageText
+	MachineCredentialAdded   MessageText
+	UserLocked               MessageText
 }
 
 type MessageText struct {
@@ -335,6 +344,24 @@ func (m *MessageTexts) GetMessageTextByType(msgType string) *MessageText {
 		return &m.PasswordlessRegistration
 	case domain.PasswordChangeMessageType:
 		return &m.PasswordChange
+	case domain.VerifySMSOTPMessageType:
+		return &m.VerifySMSOTP
+	case domain.VerifyEmailOTPMessageType:
+		return &m.VerifyEmailOTP
+	case domain.RecoveryCodeUsedMessageType:
+		return &m.RecoveryCodeUsed
+	case domain.NewDeviceLoginMessageType:
+		return &m.NewDeviceLogin
+	case domain.MFAAddedMessageType:
+		return &m.MFAAdded
+	case domain.MFARemovedMessageType:
+		return &m.MFARemoved
+	case domain.EmailChangedMessageType:
+		return &m.EmailChanged
+	case domain.MachineCredentialAddedMessageType:
+		return &m.MachineCredentialAdded
+	case domain.UserLockedMessageType:
+		return &m.UserLocked
 	}
 	return nil
 }
@@ -0,0 +1,311 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	errs "errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+type NotificationMessages struct {
+	SearchResponse
+	Messages []*NotificationMessage
+}
+
+type NotificationMessage struct {
+	ID               string
+	CreationDate     time.Time
+	ChangeDate       time.Time
+	Sequence         uint64
+	ResourceOwner    string
+	InstanceID       string
+	UserID           string
+	MessageType      string
+	Channel          domain.NotificationType
+	Recipient        string
+	TriggerEventType string
+	State            domain.NotificationMessageState
+	Attempts         uint64
+	LastError        string
+	NextAttempt      time.Time
+}
+
+type NotificationMessageSearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+func (q *NotificationMessageSearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+var (
+	notificationMessagesTable = table{
+		name:          projection.NotificationMessageTable,
+		instanceIDCol: projection.NotificationMessageColumnInstanceID,
+	}
+	NotificationMessageColumnID = Column{
+		name:  projection.NotificationMessageColumnID,
+		table: notificationMessagesTable,
+	}
+	NotificationMessageColumnCreationDate = Column{
+		name:  projection.NotificationMessageColumnCreationDate,
+		table: notificationMessagesTable,
+	}
+	NotificationMessageColumnChangeDate = Column{
+		name:  projection.NotificationMessageColumnChangeDate,
+		table: notificationMessagesTable,
+	}
+	NotificationMessageColumnSequence = Column{
+		name:  projection.NotificationMessageColumnSequence,
+		table: notificationMessagesTable,
+	}
+	NotificationMessageColumnResourceOwner = Column{
+		name:  projection.NotificationMessageColumnResourceOwner,
+		table: notificationMessagesTable,
+	}
+	NotificationMessageColumnInstanceID = Column{
+		name:  projection.NotificationMessageColumnInstanceID,
+		table: notificationMessagesTable,
+	}
+	NotificationMessageColumnUserID = Column{
+		name:  projection.NotificationMessageColumnUserID,
+		table: notificationMessagesTable,
+	}
+	NotificationMessageColumnMessageType = Column{
+		name:  projection.NotificationMessageColumnMessageType,
+		table: notificationMessagesTable,
+	}
+	NotificationMessageColumnChannel = Column{
+		name:  projection.NotificationMessageColumnChannel,
+		table: notificationMessagesTable,
+	}
+	NotificationMessageColumnRecipient = Column{
+		name:  projection.NotificationMessageColumnRecipient,
+		table: notificationMessagesTable,
+	}
+	NotificationMessageColumnTriggerEventType = Column{
+		name:  projection.NotificationMessageColumnTriggerEventType,
+		table: notificationMessagesTable,
+	}
+	NotificationMessageColumnState = Column{
+		name:  projection.NotificationMessageColumnState,
+		table: notificationMessagesTable,
+	}
+	NotificationMessageColumnAttempts = Column{
+		name:  projection.NotificationMessageColumnAttempts,
+		table: notificationMessagesTable,
+	}
+	NotificationMessageColumnLastError = Column{
+		name:  projection.NotificationMessageColumnLastError,
+		table: notificationMessagesTable,
+	}
+	NotificationMessageColumnNextAttempt = Column{
+		name:  projection.NotificationMessageColumnNextAttempt,
+		table: notificationMessagesTable,
+	}
+)
+
+func (q *Queries) NotificationMessageByID(ctx context.Context, shouldTriggerBulk bool, id string) (_ *NotificationMessage, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if shouldTriggerBulk {
+		projection.NotificationMessageProjection.Trigger(ctx)
+	}
+
+	query, scan := prepareNotificationMessageQuery(ctx, q.client)
+	stmt, args, err := query.Where(
+		sq.Eq{
+			NotificationMessageColumnID.identifier():         id,
+			NotificationMessageColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+		},
+	).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Nm3vd", "Errors.Query.SQLStatement")
+	}
+
+	row := q.client.QueryRowContext(ctx, stmt, args...)
+	return scan(row)
+}
+
+func (q *Queries) SearchNotificationMessages(ctx context.Context, queries *NotificationMessageSearchQueries) (_ *NotificationMessages, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareNotificationMessagesQuery(ctx, q.client)
+	stmt, args, err := queries.toQuery(query).
+		Where(sq.Eq{
+			NotificationMessageColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+		}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-Nm8ka", "Errors.Query.InvalidRequest")
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Nm5pe", "Errors.Internal")
+	}
+	messages, err := scan(rows)
+	if err != nil {
+		return nil, err
+	}
+	messages.LatestSequence, err = q.latestSequence(ctx, notificationMessagesTable)
+	return messages, err
+}
+
+// DueNotificationMessages returns the pending messages of all instances,
+// which are due for the next delivery attempt, ordered by their due date
+func (q *Queries) DueNotificationMessages(ctx context.Context, limit uint64) (_ *NotificationMessages, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareNotificationMessagesQuery(ctx, q.client)
+	stmt, args, err := query.
+		Where(sq.Eq{
+			NotificationMessageColumnState.identifier(): []domain.NotificationMessageState{
+				domain.NotificationMessageStateQueued,
+				domain.NotificationMessageStateRetrying,
+			},
+		}).
+		Where(sq.LtOrEq{
+			NotificationMessageColumnNextAttempt.identifier(): time.Now(),
+		}).
+		OrderBy(NotificationMessageColumnNextAttempt.identifier()).
+		Limit(limit).
+		ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Nm2wr", "Errors.Query.SQLStatement")
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Nm7dj", "Errors.Internal")
+	}
+	return scan(rows)
+}
+
+func NewNotificationMessageUserIDSearchQuery(userID string) (SearchQuery, error) {
+	return NewTextQuery(NotificationMessageColumnUserID, userID, TextEquals)
+}
+
+func NewNotificationMessageResourceOwnerSearchQuery(resourceOwner string) (SearchQuery, error) {
+	return NewTextQuery(NotificationMessageColumnResourceOwner, resourceOwner, TextEquals)
+}
+
+func NewNotificationMessageStateSearchQuery(state domain.NotificationMessageState) (SearchQuery, error) {
+	return NewNumberQuery(NotificationMessageColumnState, state, NumberEquals)
+}
+
+func prepareNotificationMessageQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Row) (*NotificationMessage, error)) {
+	return sq.Select(
+			NotificationMessageColumnID.identifier(),
+			NotificationMessageColumnCreationDate.identifier(),
+			NotificationMessageColumnChangeDate.identifier(),
+			NotificationMessageColumnSequence.identifier(),
+			NotificationMessageColumnResourceOwner.identifier(),
+			NotificationMessageColumnInstanceID.identifier(),
+			NotificationMessageColumnUserID.identifier(),
+			NotificationMessageColumnMessageType.identifier(),
+			NotificationMessageColumnChannel.identifier(),
+			NotificationMessageColumnRecipient.identifier(),
+			NotificationMessageColumnTriggerEventType.identifier(),
+			NotificationMessageColumnState.identifier(),
+			NotificationMessageColumnAttempts.identifier(),
+			NotificationMessageColumnLastError.identifier(),
+			NotificationMessageColumnNextAttempt.identifier(),
+		).From(notificationMessagesTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar), func(row *sql.Row) (*NotificationMessage, error) {
+			message := new(NotificationMessage)
+			err := row.Scan(
+				&message.ID,
+				&message.CreationDate,
+				&message.ChangeDate,
+				&message.Sequence,
+				&message.ResourceOwner,
+				&message.InstanceID,
+				&message.UserID,
+				&message.MessageType,
+				&message.Channel,
+				&message.Recipient,
+				&message.TriggerEventType,
+				&message.State,
+				&message.Attempts,
+				&message.LastError,
+				&message.NextAttempt,
+			)
+			if err != nil {
+				if errs.Is(err, sql.ErrNoRows) {
+					return nil, errors.ThrowNotFound(err, "QUERY-Nm4hq", "Errors.Notification.Outbox.NotFound")
+				}
+				return nil, errors.ThrowInternal(err, "QUERY-Nm9vc", "Errors.Internal")
+			}
+			return message, nil
+		}
+}
+
+func prepareNotificationMessagesQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) (*NotificationMessages, error)) {
+	return sq.Select(
+			NotificationMessageColumnID.identifier(),
+			NotificationMessageColumnCreationDate.identifier(),
+			NotificationMessageColumnChangeDate.identifier(),
+			NotificationMessageColumnSequence.identifier(),
+			NotificationMessageColumnResourceOwner.identifier(),
+			NotificationMessageColumnInstanceID.identifier(),
+			NotificationMessageColumnUserID.identifier(),
+			NotificationMessageColumnMessageType.identifier(),
+			NotificationMessageColumnChannel.identifier(),
+			NotificationMessageColumnRecipient.identifier(),
+			NotificationMessageColumnTriggerEventType.identifier(),
+			NotificationMessageColumnState.identifier(),
+			NotificationMessageColumnAttempts.identifier(),
+			NotificationMessageColumnLastError.identifier(),
+			NotificationMessageColumnNextAttempt.identifier(),
+			countColumn.identifier(),
+		).From(notificationMessagesTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar), func(rows *sql.Rows) (*NotificationMessages, error) {
+			messages := &NotificationMessages{Messages: []*NotificationMessage{}}
+			for rows.Next() {
+				message := new(NotificationMessage)
+				err := rows.Scan(
+					&message.ID,
+					&message.CreationDate,
+					&message.ChangeDate,
+					&message.Sequence,
+					&message.ResourceOwner,
+					&message.InstanceID,
+					&message.UserID,
+					&message.MessageType,
+					&message.Channel,
+					&message.Recipient,
+					&message.TriggerEventType,
+					&message.State,
+					&message.Attempts,
+					&message.LastError,
+					&message.NextAttempt,
+					&messages.Count,
+				)
+				if err != nil {
+					return nil, errors.ThrowInternal(err, "QUERY-Nm1zt", "Errors.Internal")
+				}
+				messages.Messages = append(messages.Messages, message)
+			}
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Nm6ob", "Errors.Query.CloseRows")
+			}
+			return messages, nil
+		}
+}
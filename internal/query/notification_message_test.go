@@ -0,0 +1,250 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+var (
+	expectedNotificationMessageColumns = `SELECT projections.notification_messages.id,` +
+		` projections.notification_messages.creation_date,` +
+		` projections.notification_messages.change_date,` +
+		` projections.notification_messages.sequence,` +
+		` projections.notification_messages.resource_owner,` +
+		` projections.notification_messages.instance_id,` +
+		` projections.notification_messages.user_id,` +
+		` projections.notification_messages.message_type,` +
+		` projections.notification_messages.channel,` +
+		` projections.notification_messages.recipient,` +
+		` projections.notification_messages.trigger_event_type,` +
+		` projections.notification_messages.state,` +
+		` projections.notification_messages.attempts,` +
+		` projections.notification_messages.last_error,` +
+		` projections.notification_messages.next_attempt`
+	expectedNotificationMessageFrom   = ` FROM projections.notification_messages AS OF SYSTEM TIME '-1 ms'`
+	expectedNotificationMessageQuery  = regexp.QuoteMeta(expectedNotificationMessageColumns + expectedNotificationMessageFrom)
+	expectedNotificationMessagesQuery = regexp.QuoteMeta(expectedNotificationMessageColumns + `, COUNT(*) OVER ()` + expectedNotificationMessageFrom)
+	notificationMessageCols           = []string{
+		"id",
+		"creation_date",
+		"change_date",
+		"sequence",
+		"resource_owner",
+		"instance_id",
+		"user_id",
+		"message_type",
+		"channel",
+		"recipient",
+		"trigger_event_type",
+		"state",
+		"attempts",
+		"last_error",
+		"next_attempt",
+	}
+	notificationMessagesCols = append(notificationMessageCols, "count")
+)
+
+func Test_NotificationMessagesPrepare(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareNotificationMessagesQuery no result",
+			prepare: prepareNotificationMessagesQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					expectedNotificationMessagesQuery,
+					nil,
+					nil,
+				),
+			},
+			object: &NotificationMessages{Messages: []*NotificationMessage{}},
+		},
+		{
+			name:    "prepareNotificationMessagesQuery one result",
+			prepare: prepareNotificationMessagesQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					expectedNotificationMessagesQuery,
+					notificationMessagesCols,
+					[][]driver.Value{
+						{
+							"message-id",
+							testNow,
+							testNow,
+							uint64(20211109),
+							"ro",
+							"instance-id",
+							"user-id",
+							domain.InitCodeMessageType,
+							domain.NotificationTypeEmail,
+							"user@example.com",
+							"user.human.initialization.code.added",
+							domain.NotificationMessageStateRetrying,
+							uint64(2),
+							"unavailable",
+							testNow,
+						},
+					},
+				),
+			},
+			object: &NotificationMessages{
+				SearchResponse: SearchResponse{
+					Count: 1,
+				},
+				Messages: []*NotificationMessage{
+					{
+						ID:               "message-id",
+						CreationDate:     testNow,
+						ChangeDate:       testNow,
+						Sequence:         20211109,
+						ResourceOwner:    "ro",
+						InstanceID:       "instance-id",
+						UserID:           "user-id",
+						MessageType:      domain.InitCodeMessageType,
+						Channel:          domain.NotificationTypeEmail,
+						Recipient:        "user@example.com",
+						TriggerEventType: "user.human.initialization.code.added",
+						State:            domain.NotificationMessageStateRetrying,
+						Attempts:         2,
+						LastError:        "unavailable",
+						NextAttempt:      testNow,
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareNotificationMessagesQuery sql err",
+			prepare: prepareNotificationMessagesQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					expectedNotificationMessagesQuery,
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
+
+func Test_NotificationMessagePrepare(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareNotificationMessageQuery no result",
+			prepare: prepareNotificationMessageQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					expectedNotificationMessageQuery,
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !errs.IsNotFound(err) {
+						return fmt.Errorf("err should be zitadel.NotFoundError got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*NotificationMessage)(nil),
+		},
+		{
+			name:    "prepareNotificationMessageQuery found",
+			prepare: prepareNotificationMessageQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					expectedNotificationMessageQuery,
+					notificationMessageCols,
+					[]driver.Value{
+						"message-id",
+						testNow,
+						testNow,
+						uint64(20211109),
+						"ro",
+						"instance-id",
+						"user-id",
+						domain.VerifySMSOTPMessageType,
+						domain.NotificationTypeSms,
+						"+41791234567",
+						"user.human.mfa.otp.sms.code.added",
+						domain.NotificationMessageStateSent,
+						uint64(1),
+						"",
+						testNow,
+					},
+				),
+			},
+			object: &NotificationMessage{
+				ID:               "message-id",
+				CreationDate:     testNow,
+				ChangeDate:       testNow,
+				Sequence:         20211109,
+				ResourceOwner:    "ro",
+				InstanceID:       "instance-id",
+				UserID:           "user-id",
+				MessageType:      domain.VerifySMSOTPMessageType,
+				Channel:          domain.NotificationTypeSms,
+				Recipient:        "+41791234567",
+				TriggerEventType: "user.human.mfa.otp.sms.code.added",
+				State:            domain.NotificationMessageStateSent,
+				Attempts:         1,
+				NextAttempt:      testNow,
+			},
+		},
+		{
+			name:    "prepareNotificationMessageQuery sql err",
+			prepare: prepareNotificationMessageQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					expectedNotificationMessageQuery,
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
@@ -24,7 +24,8 @@ type NotificationPolicy struct {
 	ResourceOwner string
 	State         domain.PolicyState
 
-	PasswordChange bool
+	PasswordChange        bool
+	SecurityNotifications bool
 
 	IsDefault bool
 }
@@ -62,6 +63,10 @@ var (
 		name:  projection.NotificationPolicyColumnPasswordChange,
 		table: notificationPolicyTable,
 	}
+	NotificationPolicyColSecurityNotifications = Column{
+		name:  projection.NotificationPolicyColumnSecurityNotifications,
+		table: notificationPolicyTable,
+	}
 	NotificationPolicyColIsDefault = Column{
 		name:  projection.NotificationPolicyColumnIsDefault,
 		table: notificationPolicyTable,
@@ -140,6 +145,7 @@ func prepareNotificationPolicyQuery(ctx context.Context, db prepareDatabase) (sq
 			NotificationPolicyColChangeDate.identifier(),
 			NotificationPolicyColResourceOwner.identifier(),
 			NotificationPolicyColPasswordChange.identifier(),
+			NotificationPolicyColSecurityNotifications.identifier(),
 			NotificationPolicyColIsDefault.identifier(),
 			NotificationPolicyColState.identifier(),
 		).
@@ -154,6 +160,7 @@ func prepareNotificationPolicyQuery(ctx context.Context, db prepareDatabase) (sq
 				&policy.ChangeDate,
 				&policy.ResourceOwner,
 				&policy.PasswordChange,
+				&policy.SecurityNotifications,
 				&policy.IsDefault,
 				&policy.State,
 			)
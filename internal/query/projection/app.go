@@ -15,7 +15,7 @@ import (
 )
 
 const (
-	AppProjectionTable = "projections.apps5"
+	AppProjectionTable = "projections.apps10"
 	AppAPITable        = AppProjectionTable + "_" + appAPITableSuffix
 	AppOIDCTable       = AppProjectionTable + "_" + appOIDCTableSuffix
 	AppSAMLTable       = AppProjectionTable + "_" + appSAMLTableSuffix
@@ -38,33 +38,40 @@ const (
 	AppAPIConfigColumnClientSecret = "client_secret"
 	AppAPIConfigColumnAuthMethod   = "auth_method"
 
-	appOIDCTableSuffix                          = "oidc_configs"
-	AppOIDCConfigColumnAppID                    = "app_id"
-	AppOIDCConfigColumnInstanceID               = "instance_id"
-	AppOIDCConfigColumnVersion                  = "version"
-	AppOIDCConfigColumnClientID                 = "client_id"
-	AppOIDCConfigColumnClientSecret             = "client_secret"
-	AppOIDCConfigColumnRedirectUris             = "redirect_uris"
-	AppOIDCConfigColumnResponseTypes            = "response_types"
-	AppOIDCConfigColumnGrantTypes               = "grant_types"
-	AppOIDCConfigColumnApplicationType          = "application_type"
-	AppOIDCConfigColumnAuthMethodType           = "auth_method_type"
-	AppOIDCConfigColumnPostLogoutRedirectUris   = "post_logout_redirect_uris"
-	AppOIDCConfigColumnDevMode                  = "is_dev_mode"
-	AppOIDCConfigColumnAccessTokenType          = "access_token_type"
-	AppOIDCConfigColumnAccessTokenRoleAssertion = "access_token_role_assertion"
-	AppOIDCConfigColumnIDTokenRoleAssertion     = "id_token_role_assertion"
-	AppOIDCConfigColumnIDTokenUserinfoAssertion = "id_token_userinfo_assertion"
-	AppOIDCConfigColumnClockSkew                = "clock_skew"
-	AppOIDCConfigColumnAdditionalOrigins        = "additional_origins"
-	AppOIDCConfigColumnSkipNativeAppSuccessPage = "skip_native_app_success_page"
-
-	appSAMLTableSuffix             = "saml_configs"
-	AppSAMLConfigColumnAppID       = "app_id"
-	AppSAMLConfigColumnInstanceID  = "instance_id"
-	AppSAMLConfigColumnEntityID    = "entity_id"
-	AppSAMLConfigColumnMetadata    = "metadata"
-	AppSAMLConfigColumnMetadataURL = "metadata_url"
+	appOIDCTableSuffix                            = "oidc_configs"
+	AppOIDCConfigColumnAppID                      = "app_id"
+	AppOIDCConfigColumnInstanceID                 = "instance_id"
+	AppOIDCConfigColumnVersion                    = "version"
+	AppOIDCConfigColumnClientID                   = "client_id"
+	AppOIDCConfigColumnClientSecret               = "client_secret"
+	AppOIDCConfigColumnRedirectUris               = "redirect_uris"
+	AppOIDCConfigColumnResponseTypes              = "response_types"
+	AppOIDCConfigColumnGrantTypes                 = "grant_types"
+	AppOIDCConfigColumnApplicationType            = "application_type"
+	AppOIDCConfigColumnAuthMethodType             = "auth_method_type"
+	AppOIDCConfigColumnPostLogoutRedirectUris     = "post_logout_redirect_uris"
+	AppOIDCConfigColumnDevMode                    = "is_dev_mode"
+	AppOIDCConfigColumnAccessTokenType            = "access_token_type"
+	AppOIDCConfigColumnAccessTokenRoleAssertion   = "access_token_role_assertion"
+	AppOIDCConfigColumnIDTokenRoleAssertion       = "id_token_role_assertion"
+	AppOIDCConfigColumnIDTokenUserinfoAssertion   = "id_token_userinfo_assertion"
+	AppOIDCConfigColumnClockSkew                  = "clock_skew"
+	AppOIDCConfigColumnAdditionalOrigins          = "additional_origins"
+	AppOIDCConfigColumnSkipNativeAppSuccessPage   = "skip_native_app_success_page"
+	AppOIDCConfigColumnRefreshTokenReuseDetection = "refresh_token_reuse_detection"
+	AppOIDCConfigColumnBackChannelLogoutURI       = "back_channel_logout_uri"
+	AppOIDCConfigColumnFrontChannelLogoutURI      = "front_channel_logout_uri"
+	AppOIDCConfigColumnSenderConstrainedTokens    = "sender_constrained_tokens"
+	AppOIDCConfigColumnTLSClientAuthSubjectDN     = "tls_client_auth_subject_dn"
+	AppOIDCConfigColumnRequireConsent             = "require_consent"
+
+	appSAMLTableSuffix                   = "saml_configs"
+	AppSAMLConfigColumnAppID             = "app_id"
+	AppSAMLConfigColumnInstanceID        = "instance_id"
+	AppSAMLConfigColumnEntityID          = "entity_id"
+	AppSAMLConfigColumnMetadata          = "metadata"
+	AppSAMLConfigColumnMetadataURL       = "metadata_url"
+	AppSAMLConfigColumnEncryptAssertions = "encrypt_assertions"
 )
 
 type appProjection struct {
@@ -124,6 +131,12 @@ func newAppProjection(ctx context.Context, config crdb.StatementHandlerConfig) *
 			crdb.NewColumn(AppOIDCConfigColumnClockSkew, crdb.ColumnTypeInt64, crdb.Default(0)),
 			crdb.NewColumn(AppOIDCConfigColumnAdditionalOrigins, crdb.ColumnTypeTextArray, crdb.Nullable()),
 			crdb.NewColumn(AppOIDCConfigColumnSkipNativeAppSuccessPage, crdb.ColumnTypeBool, crdb.Default(false)),
+			crdb.NewColumn(AppOIDCConfigColumnRefreshTokenReuseDetection, crdb.ColumnTypeBool, crdb.Default(false)),
+			crdb.NewColumn(AppOIDCConfigColumnBackChannelLogoutURI, crdb.ColumnTypeText, crdb.Default("")),
+			crdb.NewColumn(AppOIDCConfigColumnFrontChannelLogoutURI, crdb.ColumnTypeText, crdb.Default("")),
+			crdb.NewColumn(AppOIDCConfigColumnSenderConstrainedTokens, crdb.ColumnTypeBool, crdb.Default(false)),
+			crdb.NewColumn(AppOIDCConfigColumnTLSClientAuthSubjectDN, crdb.ColumnTypeText, crdb.Default("")),
+			crdb.NewColumn(AppOIDCConfigColumnRequireConsent, crdb.ColumnTypeBool, crdb.Default(false)),
 		},
 			crdb.NewPrimaryKey(AppOIDCConfigColumnInstanceID, AppOIDCConfigColumnAppID),
 			appOIDCTableSuffix,
@@ -136,6 +149,7 @@ func newAppProjection(ctx context.Context, config crdb.StatementHandlerConfig) *
 			crdb.NewColumn(AppSAMLConfigColumnEntityID, crdb.ColumnTypeText),
 			crdb.NewColumn(AppSAMLConfigColumnMetadata, crdb.ColumnTypeBytes),
 			crdb.NewColumn(AppSAMLConfigColumnMetadataURL, crdb.ColumnTypeText),
+			crdb.NewColumn(AppSAMLConfigColumnEncryptAssertions, crdb.ColumnTypeBool, crdb.Default(false)),
 		},
 			crdb.NewPrimaryKey(AppSAMLConfigColumnInstanceID, AppSAMLConfigColumnAppID),
 			appSAMLTableSuffix,
@@ -466,6 +480,12 @@ func (p *appProjection) reduceOIDCConfigAdded(event eventstore.Event) (*handler.
 				handler.NewCol(AppOIDCConfigColumnClockSkew, e.ClockSkew),
 				handler.NewCol(AppOIDCConfigColumnAdditionalOrigins, database.StringArray(e.AdditionalOrigins)),
 				handler.NewCol(AppOIDCConfigColumnSkipNativeAppSuccessPage, e.SkipNativeAppSuccessPage),
+				handler.NewCol(AppOIDCConfigColumnRefreshTokenReuseDetection, e.RefreshTokenReuseDetection),
+				handler.NewCol(AppOIDCConfigColumnBackChannelLogoutURI, e.BackChannelLogoutURI),
+				handler.NewCol(AppOIDCConfigColumnFrontChannelLogoutURI, e.FrontChannelLogoutURI),
+				handler.NewCol(AppOIDCConfigColumnSenderConstrainedTokens, e.SenderConstrainedTokens),
+				handler.NewCol(AppOIDCConfigColumnTLSClientAuthSubjectDN, e.TLSClientAuthSubjectDN),
+				handler.NewCol(AppOIDCConfigColumnRequireConsent, e.RequireConsent),
 			},
 			crdb.WithTableSuffix(appOIDCTableSuffix),
 		),
@@ -534,6 +554,24 @@ func (p *appProjection) reduceOIDCConfigChanged(event eventstore.Event) (*handle
 	if e.SkipNativeAppSuccessPage != nil {
 		cols = append(cols, handler.NewCol(AppOIDCConfigColumnSkipNativeAppSuccessPage, *e.SkipNativeAppSuccessPage))
 	}
+	if e.RefreshTokenReuseDetection != nil {
+		cols = append(cols, handler.NewCol(AppOIDCConfigColumnRefreshTokenReuseDetection, *e.RefreshTokenReuseDetection))
+	}
+	if e.BackChannelLogoutURI != nil {
+		cols = append(cols, handler.NewCol(AppOIDCConfigColumnBackChannelLogoutURI, *e.BackChannelLogoutURI))
+	}
+	if e.FrontChannelLogoutURI != nil {
+		cols = append(cols, handler.NewCol(AppOIDCConfigColumnFrontChannelLogoutURI, *e.FrontChannelLogoutURI))
+	}
+	if e.SenderConstrainedTokens != nil {
+		cols = append(cols, handler.NewCol(AppOIDCConfigColumnSenderConstrainedTokens, *e.SenderConstrainedTokens))
+	}
+	if e.TLSClientAuthSubjectDN != nil {
+		cols = append(cols, handler.NewCol(AppOIDCConfigColumnTLSClientAuthSubjectDN, *e.TLSClientAuthSubjectDN))
+	}
+	if e.RequireConsent != nil {
+		cols = append(cols, handler.NewCol(AppOIDCConfigColumnRequireConsent, *e.RequireConsent))
+	}
 
 	if len(cols) == 0 {
 		return crdb.NewNoOpStatement(e), nil
@@ -626,6 +664,7 @@ func (p *appProjection) reduceSAMLConfigAdded(event eventstore.Event) (*handler.
 				handler.NewCol(AppSAMLConfigColumnEntityID, e.EntityID),
 				handler.NewCol(AppSAMLConfigColumnMetadata, e.Metadata),
 				handler.NewCol(AppSAMLConfigColumnMetadataURL, e.MetadataURL),
+				handler.NewCol(AppSAMLConfigColumnEncryptAssertions, e.EncryptAssertions),
 			},
 			crdb.WithTableSuffix(appSAMLTableSuffix),
 		),
@@ -648,7 +687,7 @@ func (p *appProjection) reduceSAMLConfigChanged(event eventstore.Event) (*handle
 		return nil, errors.ThrowInvalidArgument(nil, "HANDL-GMHU2", "reduce.wrong.event.type")
 	}
 
-	cols := make([]handler.Column, 0, 3)
+	cols := make([]handler.Column, 0, 4)
 	if e.Metadata != nil {
 		cols = append(cols, handler.NewCol(AppSAMLConfigColumnMetadata, e.Metadata))
 	}
@@ -658,6 +697,9 @@ func (p *appProjection) reduceSAMLConfigChanged(event eventstore.Event) (*handle
 	if e.EntityID != "" {
 		cols = append(cols, handler.NewCol(AppSAMLConfigColumnEntityID, e.EntityID))
 	}
+	if e.EncryptAssertions != nil {
+		cols = append(cols, handler.NewCol(AppSAMLConfigColumnEncryptAssertions, *e.EncryptAssertions))
+	}
 
 	if len(cols) == 0 {
 		return crdb.NewNoOpStatement(e), nil
@@ -0,0 +1,91 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+)
+
+const (
+	ClientRegistrationPolicyProjectionTable         = "projections.client_registration_policies"
+	ClientRegistrationPolicyColumnInstanceID        = "instance_id"
+	ClientRegistrationPolicyColumnCreationDate      = "creation_date"
+	ClientRegistrationPolicyColumnChangeDate        = "change_date"
+	ClientRegistrationPolicyColumnSequence          = "sequence"
+	ClientRegistrationPolicyColumnAllowedGrantTypes = "allowed_grant_types"
+	ClientRegistrationPolicyColumnRedirectPatterns  = "redirect_uri_patterns"
+)
+
+type clientRegistrationPolicyProjection struct {
+	crdb.StatementHandler
+}
+
+func newClientRegistrationPolicyProjection(ctx context.Context, config crdb.StatementHandlerConfig) *clientRegistrationPolicyProjection {
+	p := new(clientRegistrationPolicyProjection)
+	config.ProjectionName = ClientRegistrationPolicyProjectionTable
+	config.Reducers = p.reducers()
+	config.InitCheck = crdb.NewTableCheck(
+		crdb.NewTable([]*crdb.Column{
+			crdb.NewColumn(ClientRegistrationPolicyColumnCreationDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(ClientRegistrationPolicyColumnChangeDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(ClientRegistrationPolicyColumnInstanceID, crdb.ColumnTypeText),
+			crdb.NewColumn(ClientRegistrationPolicyColumnSequence, crdb.ColumnTypeInt64),
+			crdb.NewColumn(ClientRegistrationPolicyColumnAllowedGrantTypes, crdb.ColumnTypeEnumArray, crdb.Nullable()),
+			crdb.NewColumn(ClientRegistrationPolicyColumnRedirectPatterns, crdb.ColumnTypeTextArray, crdb.Nullable()),
+		},
+			crdb.NewPrimaryKey(ClientRegistrationPolicyColumnInstanceID),
+		),
+	)
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	return p
+}
+
+func (p *clientRegistrationPolicyProjection) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: instance.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  instance.ClientRegistrationPolicySetEventType,
+					Reduce: p.reduceClientRegistrationPolicySet,
+				},
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(ClientRegistrationPolicyColumnInstanceID),
+				},
+			},
+		},
+	}
+}
+
+func (p *clientRegistrationPolicyProjection) reduceClientRegistrationPolicySet(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*instance.ClientRegistrationPolicySetEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Wp4kx", "reduce.wrong.event.type %s", instance.ClientRegistrationPolicySetEventType)
+	}
+	changes := []handler.Column{
+		handler.NewCol(ClientRegistrationPolicyColumnCreationDate, e.CreationDate()),
+		handler.NewCol(ClientRegistrationPolicyColumnChangeDate, e.CreationDate()),
+		handler.NewCol(ClientRegistrationPolicyColumnInstanceID, e.Aggregate().InstanceID),
+		handler.NewCol(ClientRegistrationPolicyColumnSequence, e.Sequence()),
+	}
+	if e.AllowedGrantTypes != nil {
+		changes = append(changes, handler.NewCol(ClientRegistrationPolicyColumnAllowedGrantTypes, database.EnumArray[domain.OIDCGrantType](*e.AllowedGrantTypes)))
+	}
+	if e.RedirectURIPatterns != nil {
+		changes = append(changes, handler.NewCol(ClientRegistrationPolicyColumnRedirectPatterns, database.StringArray(*e.RedirectURIPatterns)))
+	}
+	return crdb.NewUpsertStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(ClientRegistrationPolicyColumnInstanceID, ""),
+		},
+		changes,
+	), nil
+}
@@ -0,0 +1,295 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+)
+
+const (
+	EmailProviderTable     = "projections.email_providers"
+	EmailProviderHTTPTable = EmailProviderTable + "_" + emailProviderHTTPTableSuffix
+
+	EmailProviderColumnID            = "id"
+	EmailProviderColumnAggregateID   = "aggregate_id"
+	EmailProviderColumnCreationDate  = "creation_date"
+	EmailProviderColumnChangeDate    = "change_date"
+	EmailProviderColumnSequence      = "sequence"
+	EmailProviderColumnState         = "state"
+	EmailProviderColumnType          = "provider_type"
+	EmailProviderColumnResourceOwner = "resource_owner"
+	EmailProviderColumnInstanceID    = "instance_id"
+	EmailProviderColumnDescription   = "description"
+	EmailProviderColumnPriority      = "priority"
+	EmailProviderColumnSenderAddress = "sender_address"
+	EmailProviderColumnSenderName    = "sender_name"
+
+	emailProviderHTTPTableSuffix        = "http"
+	EmailProviderHTTPColumnProviderID   = "provider_id"
+	EmailProviderHTTPColumnInstanceID   = "instance_id"
+	EmailProviderHTTPColumnEndpoint     = "endpoint"
+	EmailProviderHTTPColumnMethod       = "method"
+	EmailProviderHTTPColumnHeaders      = "headers"
+	EmailProviderHTTPColumnBodyTemplate = "body_template"
+)
+
+type emailProviderProjection struct {
+	crdb.StatementHandler
+}
+
+func newEmailProviderProjection(ctx context.Context, config crdb.StatementHandlerConfig) *emailProviderProjection {
+	p := new(emailProviderProjection)
+	config.ProjectionName = EmailProviderTable
+	config.Reducers = p.reducers()
+	config.InitCheck = crdb.NewMultiTableCheck(
+		crdb.NewTable([]*crdb.Column{
+			crdb.NewColumn(EmailProviderColumnID, crdb.ColumnTypeText),
+			crdb.NewColumn(EmailProviderColumnAggregateID, crdb.ColumnTypeText),
+			crdb.NewColumn(EmailProviderColumnCreationDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(EmailProviderColumnChangeDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(EmailProviderColumnSequence, crdb.ColumnTypeInt64),
+			crdb.NewColumn(EmailProviderColumnState, crdb.ColumnTypeEnum),
+			crdb.NewColumn(EmailProviderColumnType, crdb.ColumnTypeEnum),
+			crdb.NewColumn(EmailProviderColumnResourceOwner, crdb.ColumnTypeText),
+			crdb.NewColumn(EmailProviderColumnInstanceID, crdb.ColumnTypeText),
+			crdb.NewColumn(EmailProviderColumnDescription, crdb.ColumnTypeText, crdb.Default("")),
+			crdb.NewColumn(EmailProviderColumnPriority, crdb.ColumnTypeInt64, crdb.Default(0)),
+			crdb.NewColumn(EmailProviderColumnSenderAddress, crdb.ColumnTypeText),
+			crdb.NewColumn(EmailProviderColumnSenderName, crdb.ColumnTypeText, crdb.Default("")),
+		},
+			crdb.NewPrimaryKey(EmailProviderColumnInstanceID, EmailProviderColumnID),
+		),
+		crdb.NewSuffixedTable([]*crdb.Column{
+			crdb.NewColumn(EmailProviderHTTPColumnProviderID, crdb.ColumnTypeText),
+			crdb.NewColumn(EmailProviderHTTPColumnInstanceID, crdb.ColumnTypeText),
+			crdb.NewColumn(EmailProviderHTTPColumnEndpoint, crdb.ColumnTypeText),
+			crdb.NewColumn(EmailProviderHTTPColumnMethod, crdb.ColumnTypeText),
+			crdb.NewColumn(EmailProviderHTTPColumnHeaders, crdb.ColumnTypeJSONB, crdb.Nullable()),
+			crdb.NewColumn(EmailProviderHTTPColumnBodyTemplate, crdb.ColumnTypeText, crdb.Default("")),
+		},
+			crdb.NewPrimaryKey(EmailProviderHTTPColumnInstanceID, EmailProviderHTTPColumnProviderID),
+			emailProviderHTTPTableSuffix,
+			crdb.WithForeignKey(crdb.NewForeignKeyOfPublicKeys()),
+		),
+	)
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	return p
+}
+
+func (p *emailProviderProjection) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: instance.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  instance.EmailProviderHTTPAddedEventType,
+					Reduce: p.reduceHTTPAdded,
+				},
+				{
+					Event:  instance.EmailProviderHTTPChangedEventType,
+					Reduce: p.reduceHTTPChanged,
+				},
+				{
+					Event:  instance.EmailProviderHTTPHeadersChangedEventType,
+					Reduce: p.reduceHTTPHeadersChanged,
+				},
+				{
+					Event:  instance.EmailProviderActivatedEventType,
+					Reduce: p.reduceActivated,
+				},
+				{
+					Event:  instance.EmailProviderDeactivatedEventType,
+					Reduce: p.reduceDeactivated,
+				},
+				{
+					Event:  instance.EmailProviderRemovedEventType,
+					Reduce: p.reduceRemoved,
+				},
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(EmailProviderColumnInstanceID),
+				},
+			},
+		},
+	}
+}
+
+func (p *emailProviderProjection) reduceHTTPAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*instance.EmailProviderHTTPAddedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Ep4sd", "reduce.wrong.event.type %s", instance.EmailProviderHTTPAddedEventType)
+	}
+
+	return crdb.NewMultiStatement(
+		e,
+		crdb.AddCreateStatement(
+			[]handler.Column{
+				handler.NewCol(EmailProviderColumnID, e.ID),
+				handler.NewCol(EmailProviderColumnAggregateID, e.Aggregate().ID),
+				handler.NewCol(EmailProviderColumnCreationDate, e.CreationDate()),
+				handler.NewCol(EmailProviderColumnChangeDate, e.CreationDate()),
+				handler.NewCol(EmailProviderColumnResourceOwner, e.Aggregate().ResourceOwner),
+				handler.NewCol(EmailProviderColumnInstanceID, e.Aggregate().InstanceID),
+				handler.NewCol(EmailProviderColumnState, domain.EmailProviderStateInactive),
+				handler.NewCol(EmailProviderColumnType, domain.EmailProviderTypeHTTP),
+				handler.NewCol(EmailProviderColumnSequence, e.Sequence()),
+				handler.NewCol(EmailProviderColumnDescription, e.Description),
+				handler.NewCol(EmailProviderColumnPriority, e.Priority),
+				handler.NewCol(EmailProviderColumnSenderAddress, e.SenderAddress),
+				handler.NewCol(EmailProviderColumnSenderName, e.SenderName),
+			},
+		),
+		crdb.AddCreateStatement(
+			[]handler.Column{
+				handler.NewCol(EmailProviderHTTPColumnProviderID, e.ID),
+				handler.NewCol(EmailProviderHTTPColumnInstanceID, e.Aggregate().InstanceID),
+				handler.NewCol(EmailProviderHTTPColumnEndpoint, e.Endpoint),
+				handler.NewCol(EmailProviderHTTPColumnMethod, e.Method),
+				handler.NewCol(EmailProviderHTTPColumnHeaders, e.Headers),
+				handler.NewCol(EmailProviderHTTPColumnBodyTemplate, e.BodyTemplate),
+			},
+			crdb.WithTableSuffix(emailProviderHTTPTableSuffix),
+		),
+	), nil
+}
+
+func (p *emailProviderProjection) reduceHTTPChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*instance.EmailProviderHTTPChangedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Ep9vc", "reduce.wrong.event.type %s", instance.EmailProviderHTTPChangedEventType)
+	}
+	columns := []handler.Column{
+		handler.NewCol(EmailProviderColumnChangeDate, e.CreationDate()),
+		handler.NewCol(EmailProviderColumnSequence, e.Sequence()),
+	}
+	if e.Description != nil {
+		columns = append(columns, handler.NewCol(EmailProviderColumnDescription, *e.Description))
+	}
+	if e.Priority != nil {
+		columns = append(columns, handler.NewCol(EmailProviderColumnPriority, *e.Priority))
+	}
+	if e.SenderAddress != nil {
+		columns = append(columns, handler.NewCol(EmailProviderColumnSenderAddress, *e.SenderAddress))
+	}
+	if e.SenderName != nil {
+		columns = append(columns, handler.NewCol(EmailProviderColumnSenderName, *e.SenderName))
+	}
+	httpColumns := make([]handler.Column, 0, 3)
+	if e.Endpoint != nil {
+		httpColumns = append(httpColumns, handler.NewCol(EmailProviderHTTPColumnEndpoint, *e.Endpoint))
+	}
+	if e.Method != nil {
+		httpColumns = append(httpColumns, handler.NewCol(EmailProviderHTTPColumnMethod, *e.Method))
+	}
+	if e.BodyTemplate != nil {
+		httpColumns = append(httpColumns, handler.NewCol(EmailProviderHTTPColumnBodyTemplate, *e.BodyTemplate))
+	}
+
+	stmts := make([]func(eventstore.Event) crdb.Exec, 0, 2)
+	if len(httpColumns) > 0 {
+		stmts = append(stmts, crdb.AddUpdateStatement(
+			httpColumns,
+			[]handler.Condition{
+				handler.NewCond(EmailProviderHTTPColumnProviderID, e.ID),
+				handler.NewCond(EmailProviderHTTPColumnInstanceID, e.Aggregate().InstanceID),
+			},
+			crdb.WithTableSuffix(emailProviderHTTPTableSuffix),
+		))
+	}
+	stmts = append(stmts, crdb.AddUpdateStatement(
+		columns,
+		[]handler.Condition{
+			handler.NewCond(EmailProviderColumnID, e.ID),
+			handler.NewCond(EmailProviderColumnInstanceID, e.Aggregate().InstanceID),
+		},
+	))
+	return crdb.NewMultiStatement(e, stmts...), nil
+}
+
+func (p *emailProviderProjection) reduceHTTPHeadersChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*instance.EmailProviderHTTPHeadersChangedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Ep1kf", "reduce.wrong.event.type %s", instance.EmailProviderHTTPHeadersChangedEventType)
+	}
+
+	return crdb.NewMultiStatement(
+		e,
+		crdb.AddUpdateStatement(
+			[]handler.Column{
+				handler.NewCol(EmailProviderHTTPColumnHeaders, e.Headers),
+			},
+			[]handler.Condition{
+				handler.NewCond(EmailProviderHTTPColumnProviderID, e.ID),
+				handler.NewCond(EmailProviderHTTPColumnInstanceID, e.Aggregate().InstanceID),
+			},
+			crdb.WithTableSuffix(emailProviderHTTPTableSuffix),
+		),
+		crdb.AddUpdateStatement(
+			[]handler.Column{
+				handler.NewCol(EmailProviderColumnChangeDate, e.CreationDate()),
+				handler.NewCol(EmailProviderColumnSequence, e.Sequence()),
+			},
+			[]handler.Condition{
+				handler.NewCond(EmailProviderColumnID, e.ID),
+				handler.NewCond(EmailProviderColumnInstanceID, e.Aggregate().InstanceID),
+			},
+		),
+	), nil
+}
+
+func (p *emailProviderProjection) reduceActivated(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*instance.EmailProviderActivatedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Ep6mz", "reduce.wrong.event.type %s", instance.EmailProviderActivatedEventType)
+	}
+	return crdb.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(EmailProviderColumnState, domain.EmailProviderStateActive),
+			handler.NewCol(EmailProviderColumnChangeDate, e.CreationDate()),
+			handler.NewCol(EmailProviderColumnSequence, e.Sequence()),
+		},
+		[]handler.Condition{
+			handler.NewCond(EmailProviderColumnID, e.ID),
+			handler.NewCond(EmailProviderColumnInstanceID, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *emailProviderProjection) reduceDeactivated(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*instance.EmailProviderDeactivatedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Ep3ru", "reduce.wrong.event.type %s", instance.EmailProviderDeactivatedEventType)
+	}
+	return crdb.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(EmailProviderColumnState, domain.EmailProviderStateInactive),
+			handler.NewCol(EmailProviderColumnChangeDate, e.CreationDate()),
+			handler.NewCol(EmailProviderColumnSequence, e.Sequence()),
+		},
+		[]handler.Condition{
+			handler.NewCond(EmailProviderColumnID, e.ID),
+			handler.NewCond(EmailProviderColumnInstanceID, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *emailProviderProjection) reduceRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*instance.EmailProviderRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Ep7jy", "reduce.wrong.event.type %s", instance.EmailProviderRemovedEventType)
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(EmailProviderColumnID, e.ID),
+			handler.NewCond(EmailProviderColumnInstanceID, e.Aggregate().InstanceID),
+		},
+	), nil
+}
@@ -0,0 +1,211 @@
+package projection
+
+import (
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+)
+
+func TestEmailProviderProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "instance reduceHTTPAdded",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(instance.EmailProviderHTTPAddedEventType),
+					instance.AggregateType,
+					[]byte(`{
+						"id": "id",
+						"description": "description",
+						"priority": 1,
+						"senderAddress": "zitadel@example.com",
+						"senderName": "ZITADEL",
+						"endpoint": "https://api.example.com/send",
+						"method": "POST",
+						"headers": {
+							"cryptoType": 0,
+							"algorithm": "RSA-265",
+							"keyId": "key-id",
+							"crypted": "Y3J5cHRlZA=="
+						},
+						"bodyTemplate": "{}"
+					}`),
+				), instance.EmailProviderHTTPAddedEventMapper),
+			},
+			reduce: (&emailProviderProjection{}).reduceHTTPAdded,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("instance"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.email_providers (id, aggregate_id, creation_date, change_date, resource_owner, instance_id, state, provider_type, sequence, description, priority, sender_address, sender_name) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)",
+							expectedArgs: []interface{}{
+								"id",
+								"agg-id",
+								anyArg{},
+								anyArg{},
+								"ro-id",
+								"instance-id",
+								domain.EmailProviderStateInactive,
+								domain.EmailProviderTypeHTTP,
+								uint64(15),
+								"description",
+								int32(1),
+								"zitadel@example.com",
+								"ZITADEL",
+							},
+						},
+						{
+							expectedStmt: "INSERT INTO projections.email_providers_http (provider_id, instance_id, endpoint, method, headers, body_template) VALUES ($1, $2, $3, $4, $5, $6)",
+							expectedArgs: []interface{}{
+								"id",
+								"instance-id",
+								"https://api.example.com/send",
+								"POST",
+								&crypto.CryptoValue{
+									CryptoType: crypto.TypeEncryption,
+									Algorithm:  "RSA-265",
+									KeyID:      "key-id",
+									Crypted:    []byte("crypted"),
+								},
+								"{}",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance reduceHTTPChanged",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(instance.EmailProviderHTTPChangedEventType),
+					instance.AggregateType,
+					[]byte(`{
+						"id": "id",
+						"priority": 2,
+						"endpoint": "https://backup.example.com/send"
+					}`),
+				), instance.EmailProviderHTTPChangedEventMapper),
+			},
+			reduce: (&emailProviderProjection{}).reduceHTTPChanged,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("instance"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.email_providers_http SET endpoint = $1 WHERE (provider_id = $2) AND (instance_id = $3)",
+							expectedArgs: []interface{}{
+								"https://backup.example.com/send",
+								"id",
+								"instance-id",
+							},
+						},
+						{
+							expectedStmt: "UPDATE projections.email_providers SET (change_date, sequence, priority) = ($1, $2, $3) WHERE (id = $4) AND (instance_id = $5)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								int32(2),
+								"id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance reduceActivated",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(instance.EmailProviderActivatedEventType),
+					instance.AggregateType,
+					[]byte(`{
+						"id": "id"
+					}`),
+				), instance.EmailProviderActivatedEventMapper),
+			},
+			reduce: (&emailProviderProjection{}).reduceActivated,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("instance"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.email_providers SET (state, change_date, sequence) = ($1, $2, $3) WHERE (id = $4) AND (instance_id = $5)",
+							expectedArgs: []interface{}{
+								domain.EmailProviderStateActive,
+								anyArg{},
+								uint64(15),
+								"id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance reduceRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(instance.EmailProviderRemovedEventType),
+					instance.AggregateType,
+					[]byte(`{
+						"id": "id"
+					}`),
+				), instance.EmailProviderRemovedEventMapper),
+			},
+			reduce: (&emailProviderProjection{}).reduceRemoved,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("instance"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.email_providers WHERE (id = $1) AND (instance_id = $2)",
+							expectedArgs: []interface{}{
+								"id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if _, ok := err.(errors.InvalidArgument); !ok {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, EmailProviderTable, tt.want)
+		})
+	}
+}
@@ -0,0 +1,311 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/repository/idpsync"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+const (
+	IDPLDAPSyncTable     = "projections.idp_ldap_syncs"
+	IDPLDAPSyncRunSuffix = "runs"
+	IDPLDAPSyncRunTable  = IDPLDAPSyncTable + "_" + IDPLDAPSyncRunSuffix
+
+	IDPLDAPSyncIDPIDCol              = "idp_id"
+	IDPLDAPSyncChangeDateCol         = "change_date"
+	IDPLDAPSyncSequenceCol           = "sequence"
+	IDPLDAPSyncResourceOwnerCol      = "resource_owner"
+	IDPLDAPSyncInstanceIDCol         = "instance_id"
+	IDPLDAPSyncEnabledCol            = "enabled"
+	IDPLDAPSyncIntervalCol           = "sync_interval"
+	IDPLDAPSyncOrgIDCol              = "org_id"
+	IDPLDAPSyncDeactivateMissingCol  = "deactivate_missing"
+	IDPLDAPSyncDisabledFilterCol     = "disabled_filter"
+	IDPLDAPSyncMetadataAttributesCol = "metadata_attributes"
+	IDPLDAPSyncGroupsAttributeCol    = "groups_attribute"
+	IDPLDAPSyncGroupRulesCol         = "group_rules"
+	IDPLDAPSyncLastRunCol            = "last_run"
+	IDPLDAPSyncNextRunCol            = "next_run"
+
+	IDPLDAPSyncRunIDPIDCol         = "idp_id"
+	IDPLDAPSyncRunInstanceIDCol    = "instance_id"
+	IDPLDAPSyncRunSequenceCol      = "sequence"
+	IDPLDAPSyncRunCreationDateCol  = "creation_date"
+	IDPLDAPSyncRunStartedAtCol     = "started_at"
+	IDPLDAPSyncRunStateCol         = "state"
+	IDPLDAPSyncRunDryRunCol        = "dry_run"
+	IDPLDAPSyncRunCreatedCol       = "created"
+	IDPLDAPSyncRunUpdatedCol       = "updated"
+	IDPLDAPSyncRunDeactivatedCol   = "deactivated"
+	IDPLDAPSyncRunGrantsChangedCol = "grants_changed"
+	IDPLDAPSyncRunFailedCol        = "failed"
+	IDPLDAPSyncRunErrorCol         = "error"
+)
+
+type idpLDAPSyncProjection struct {
+	crdb.StatementHandler
+}
+
+func newIDPLDAPSyncProjection(ctx context.Context, config crdb.StatementHandlerConfig) *idpLDAPSyncProjection {
+	p := new(idpLDAPSyncProjection)
+	config.ProjectionName = IDPLDAPSyncTable
+	config.Reducers = p.reducers()
+	config.InitCheck = crdb.NewMultiTableCheck(
+		crdb.NewTable([]*crdb.Column{
+			crdb.NewColumn(IDPLDAPSyncIDPIDCol, crdb.ColumnTypeText),
+			crdb.NewColumn(IDPLDAPSyncChangeDateCol, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(IDPLDAPSyncSequenceCol, crdb.ColumnTypeInt64),
+			crdb.NewColumn(IDPLDAPSyncResourceOwnerCol, crdb.ColumnTypeText),
+			crdb.NewColumn(IDPLDAPSyncInstanceIDCol, crdb.ColumnTypeText),
+			crdb.NewColumn(IDPLDAPSyncEnabledCol, crdb.ColumnTypeBool, crdb.Default(false)),
+			crdb.NewColumn(IDPLDAPSyncIntervalCol, crdb.ColumnTypeInt64),
+			crdb.NewColumn(IDPLDAPSyncOrgIDCol, crdb.ColumnTypeText),
+			crdb.NewColumn(IDPLDAPSyncDeactivateMissingCol, crdb.ColumnTypeBool, crdb.Default(false)),
+			crdb.NewColumn(IDPLDAPSyncDisabledFilterCol, crdb.ColumnTypeText, crdb.Default("")),
+			crdb.NewColumn(IDPLDAPSyncMetadataAttributesCol, crdb.ColumnTypeTextArray, crdb.Nullable()),
+			crdb.NewColumn(IDPLDAPSyncGroupsAttributeCol, crdb.ColumnTypeText, crdb.Default("")),
+			crdb.NewColumn(IDPLDAPSyncGroupRulesCol, crdb.ColumnTypeJSONB, crdb.Nullable()),
+			crdb.NewColumn(IDPLDAPSyncLastRunCol, crdb.ColumnTypeTimestamp, crdb.Nullable()),
+			crdb.NewColumn(IDPLDAPSyncNextRunCol, crdb.ColumnTypeTimestamp),
+		},
+			crdb.NewPrimaryKey(IDPLDAPSyncInstanceIDCol, IDPLDAPSyncIDPIDCol),
+			crdb.WithIndex(crdb.NewIndex("due", []string{IDPLDAPSyncEnabledCol, IDPLDAPSyncNextRunCol})),
+		),
+		crdb.NewSuffixedTable([]*crdb.Column{
+			crdb.NewColumn(IDPLDAPSyncRunIDPIDCol, crdb.ColumnTypeText),
+			crdb.NewColumn(IDPLDAPSyncRunInstanceIDCol, crdb.ColumnTypeText),
+			crdb.NewColumn(IDPLDAPSyncRunSequenceCol, crdb.ColumnTypeInt64),
+			crdb.NewColumn(IDPLDAPSyncRunCreationDateCol, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(IDPLDAPSyncRunStartedAtCol, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(IDPLDAPSyncRunStateCol, crdb.ColumnTypeEnum),
+			crdb.NewColumn(IDPLDAPSyncRunDryRunCol, crdb.ColumnTypeBool, crdb.Default(false)),
+			crdb.NewColumn(IDPLDAPSyncRunCreatedCol, crdb.ColumnTypeInt64, crdb.Default(0)),
+			crdb.NewColumn(IDPLDAPSyncRunUpdatedCol, crdb.ColumnTypeInt64, crdb.Default(0)),
+			crdb.NewColumn(IDPLDAPSyncRunDeactivatedCol, crdb.ColumnTypeInt64, crdb.Default(0)),
+			crdb.NewColumn(IDPLDAPSyncRunGrantsChangedCol, crdb.ColumnTypeInt64, crdb.Default(0)),
+			crdb.NewColumn(IDPLDAPSyncRunFailedCol, crdb.ColumnTypeInt64, crdb.Default(0)),
+			crdb.NewColumn(IDPLDAPSyncRunErrorCol, crdb.ColumnTypeText, crdb.Default("")),
+		},
+			crdb.NewPrimaryKey(IDPLDAPSyncRunInstanceIDCol, IDPLDAPSyncRunIDPIDCol, IDPLDAPSyncRunSequenceCol),
+			IDPLDAPSyncRunSuffix,
+			crdb.WithForeignKey(crdb.NewForeignKey("sync", []string{IDPLDAPSyncRunInstanceIDCol, IDPLDAPSyncRunIDPIDCol}, nil)),
+		),
+	)
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	return p
+}
+
+func (p *idpLDAPSyncProjection) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: idpsync.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  idpsync.LDAPSyncSetType,
+					Reduce: p.reduceSet,
+				},
+				{
+					Event:  idpsync.LDAPSyncRemovedType,
+					Reduce: p.reduceRemoved,
+				},
+				{
+					Event:  idpsync.LDAPSyncRunSucceededType,
+					Reduce: p.reduceRunSucceeded,
+				},
+				{
+					Event:  idpsync.LDAPSyncRunFailedType,
+					Reduce: p.reduceRunFailed,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  instance.IDPRemovedEventType,
+					Reduce: p.reduceIDPRemoved,
+				},
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(IDPLDAPSyncInstanceIDCol),
+				},
+			},
+		},
+		{
+			Aggregate: org.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  org.IDPRemovedEventType,
+					Reduce: p.reduceIDPRemoved,
+				},
+				{
+					Event:  org.OrgRemovedEventType,
+					Reduce: p.reduceOwnerRemoved,
+				},
+			},
+		},
+	}
+}
+
+func (p *idpLDAPSyncProjection) reduceSet(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*idpsync.LDAPSyncSetEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Ls3bn", "reduce.wrong.event.type %s", idpsync.LDAPSyncSetType)
+	}
+	return crdb.NewUpsertStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(IDPLDAPSyncInstanceIDCol, nil),
+			handler.NewCol(IDPLDAPSyncIDPIDCol, nil),
+		},
+		[]handler.Column{
+			handler.NewCol(IDPLDAPSyncIDPIDCol, e.Aggregate().ID),
+			handler.NewCol(IDPLDAPSyncChangeDateCol, e.CreationDate()),
+			handler.NewCol(IDPLDAPSyncSequenceCol, e.Sequence()),
+			handler.NewCol(IDPLDAPSyncResourceOwnerCol, e.Aggregate().ResourceOwner),
+			handler.NewCol(IDPLDAPSyncInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCol(IDPLDAPSyncEnabledCol, e.Enabled),
+			handler.NewCol(IDPLDAPSyncIntervalCol, e.Interval),
+			handler.NewCol(IDPLDAPSyncOrgIDCol, e.OrgID),
+			handler.NewCol(IDPLDAPSyncDeactivateMissingCol, e.DeactivateMissing),
+			handler.NewCol(IDPLDAPSyncDisabledFilterCol, e.DisabledFilter),
+			handler.NewCol(IDPLDAPSyncMetadataAttributesCol, database.StringArray(e.MetadataAttributes)),
+			handler.NewCol(IDPLDAPSyncGroupsAttributeCol, e.GroupsAttribute),
+			handler.NewJSONCol(IDPLDAPSyncGroupRulesCol, e.GroupRules),
+			// a changed configuration is synced right away
+			handler.NewCol(IDPLDAPSyncNextRunCol, e.CreationDate()),
+		},
+	), nil
+}
+
+func (p *idpLDAPSyncProjection) reduceRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*idpsync.LDAPSyncRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Ls7ve", "reduce.wrong.event.type %s", idpsync.LDAPSyncRemovedType)
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(IDPLDAPSyncIDPIDCol, e.Aggregate().ID),
+			handler.NewCond(IDPLDAPSyncInstanceIDCol, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *idpLDAPSyncProjection) reduceRunSucceeded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*idpsync.LDAPSyncRunSucceededEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Ls5hw", "reduce.wrong.event.type %s", idpsync.LDAPSyncRunSucceededType)
+	}
+	return p.addRun(e, e.DryRun, []handler.Column{
+		handler.NewCol(IDPLDAPSyncRunStartedAtCol, e.StartedAt),
+		handler.NewCol(IDPLDAPSyncRunStateCol, domain.LDAPSyncRunStateSucceeded),
+		handler.NewCol(IDPLDAPSyncRunDryRunCol, e.DryRun),
+		handler.NewCol(IDPLDAPSyncRunCreatedCol, e.Created),
+		handler.NewCol(IDPLDAPSyncRunUpdatedCol, e.Updated),
+		handler.NewCol(IDPLDAPSyncRunDeactivatedCol, e.Deactivated),
+		handler.NewCol(IDPLDAPSyncRunGrantsChangedCol, e.GrantsChanged),
+		handler.NewCol(IDPLDAPSyncRunFailedCol, e.Failed),
+	}), nil
+}
+
+func (p *idpLDAPSyncProjection) reduceRunFailed(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*idpsync.LDAPSyncRunFailedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Ls2jc", "reduce.wrong.event.type %s", idpsync.LDAPSyncRunFailedType)
+	}
+	return p.addRun(e, e.DryRun, []handler.Column{
+		handler.NewCol(IDPLDAPSyncRunStartedAtCol, e.StartedAt),
+		handler.NewCol(IDPLDAPSyncRunStateCol, domain.LDAPSyncRunStateFailed),
+		handler.NewCol(IDPLDAPSyncRunDryRunCol, e.DryRun),
+		handler.NewCol(IDPLDAPSyncRunErrorCol, e.Error),
+	}), nil
+}
+
+// addRun adds the run to the history,
+// only the scheduled (not dry) runs move the next run by the configured interval
+func (p *idpLDAPSyncProjection) addRun(event eventstore.Event, dryRun bool, columns []handler.Column) *handler.Statement {
+	statements := []func(eventstore.Event) crdb.Exec{
+		crdb.AddCreateStatement(
+			append([]handler.Column{
+				handler.NewCol(IDPLDAPSyncRunIDPIDCol, event.Aggregate().ID),
+				handler.NewCol(IDPLDAPSyncRunInstanceIDCol, event.Aggregate().InstanceID),
+				handler.NewCol(IDPLDAPSyncRunSequenceCol, event.Sequence()),
+				handler.NewCol(IDPLDAPSyncRunCreationDateCol, event.CreationDate()),
+			}, columns...),
+			crdb.WithTableSuffix(IDPLDAPSyncRunSuffix),
+		),
+	}
+	syncColumns := []handler.Column{
+		handler.NewCol(IDPLDAPSyncChangeDateCol, event.CreationDate()),
+		handler.NewCol(IDPLDAPSyncSequenceCol, event.Sequence()),
+	}
+	if !dryRun {
+		syncColumns = append(syncColumns,
+			handler.NewCol(IDPLDAPSyncLastRunCol, event.CreationDate()),
+			newNextRunCol(event.CreationDate()),
+		)
+	}
+	statements = append(statements,
+		crdb.AddUpdateStatement(
+			syncColumns,
+			[]handler.Condition{
+				handler.NewCond(IDPLDAPSyncIDPIDCol, event.Aggregate().ID),
+				handler.NewCond(IDPLDAPSyncInstanceIDCol, event.Aggregate().InstanceID),
+			},
+		),
+	)
+	return crdb.NewMultiStatement(event, statements...)
+}
+
+// newNextRunCol computes the next run from the stored interval (in nanoseconds)
+func newNextRunCol(lastRun interface{}) handler.Column {
+	return handler.Column{
+		Name:  IDPLDAPSyncNextRunCol,
+		Value: lastRun,
+		ParameterOpt: func(placeholder string) string {
+			return placeholder + "::TIMESTAMPTZ + (" + IDPLDAPSyncIntervalCol + " / 1000)::INT8 * INTERVAL '1 microsecond'"
+		},
+	}
+}
+
+func (p *idpLDAPSyncProjection) reduceIDPRemoved(event eventstore.Event) (*handler.Statement, error) {
+	var idpID string
+	switch e := event.(type) {
+	case *instance.IDPRemovedEvent:
+		idpID = e.ID
+	case *org.IDPRemovedEvent:
+		idpID = e.ID
+	default:
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Ls6tp", "reduce.wrong.event.type %v", []eventstore.EventType{instance.IDPRemovedEventType, org.IDPRemovedEventType})
+	}
+	return crdb.NewDeleteStatement(
+		event,
+		[]handler.Condition{
+			handler.NewCond(IDPLDAPSyncIDPIDCol, idpID),
+			handler.NewCond(IDPLDAPSyncInstanceIDCol, event.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *idpLDAPSyncProjection) reduceOwnerRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.OrgRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Lt3kq", "reduce.wrong.event.type %s", org.OrgRemovedEventType)
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(IDPLDAPSyncResourceOwnerCol, e.Aggregate().ID),
+			handler.NewCond(IDPLDAPSyncInstanceIDCol, e.Aggregate().InstanceID),
+		},
+	), nil
+}
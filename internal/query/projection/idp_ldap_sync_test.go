@@ -0,0 +1,376 @@
+package projection
+
+import (
+	"testing"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/idpsync"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+func TestIDPLDAPSyncProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "reduceSet",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(idpsync.LDAPSyncSetType),
+					idpsync.AggregateType,
+					[]byte(`{
+						"enabled": true,
+						"interval": 3600000000000,
+						"orgId": "org-id",
+						"deactivateMissing": true,
+						"disabledFilter": "(disabled=TRUE)",
+						"metadataAttributes": ["department"],
+						"groupsAttribute": "memberOf",
+						"groupRules": [{"group": "cn=admins", "projectId": "project-id", "roleKeys": ["admin"]}]
+					}`),
+				), idpsync.LDAPSyncSetEventMapper),
+			},
+			reduce: (&idpLDAPSyncProjection{}).reduceSet,
+			want: wantReduce{
+				aggregateType:    idpsync.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.idp_ldap_syncs (idp_id, change_date, sequence, resource_owner, instance_id, enabled, sync_interval, org_id, deactivate_missing, disabled_filter, metadata_attributes, groups_attribute, group_rules, next_run) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14) ON CONFLICT (instance_id, idp_id) DO UPDATE SET (change_date, sequence, resource_owner, enabled, sync_interval, org_id, deactivate_missing, disabled_filter, metadata_attributes, groups_attribute, group_rules, next_run) = (EXCLUDED.change_date, EXCLUDED.sequence, EXCLUDED.resource_owner, EXCLUDED.enabled, EXCLUDED.sync_interval, EXCLUDED.org_id, EXCLUDED.deactivate_missing, EXCLUDED.disabled_filter, EXCLUDED.metadata_attributes, EXCLUDED.groups_attribute, EXCLUDED.group_rules, EXCLUDED.next_run)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								anyArg{},
+								uint64(15),
+								"ro-id",
+								"instance-id",
+								true,
+								time.Hour,
+								"org-id",
+								true,
+								"(disabled=TRUE)",
+								database.StringArray{"department"},
+								"memberOf",
+								[]byte(`[{"group":"cn=admins","projectId":"project-id","roleKeys":["admin"]}]`),
+								anyArg{},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(idpsync.LDAPSyncRemovedType),
+					idpsync.AggregateType,
+					nil,
+				), idpsync.LDAPSyncRemovedEventMapper),
+			},
+			reduce: (&idpLDAPSyncProjection{}).reduceRemoved,
+			want: wantReduce{
+				aggregateType:    idpsync.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.idp_ldap_syncs WHERE (idp_id = $1) AND (instance_id = $2)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceRunSucceeded",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(idpsync.LDAPSyncRunSucceededType),
+					idpsync.AggregateType,
+					[]byte(`{
+						"startedAt": "2023-06-01T10:00:00Z",
+						"created": 2,
+						"updated": 3,
+						"deactivated": 1,
+						"grantsChanged": 4,
+						"failed": 1
+					}`),
+				), idpsync.LDAPSyncRunSucceededEventMapper),
+			},
+			reduce: (&idpLDAPSyncProjection{}).reduceRunSucceeded,
+			want: wantReduce{
+				aggregateType:    idpsync.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.idp_ldap_syncs_runs (idp_id, instance_id, sequence, creation_date, started_at, state, dry_run, created, updated, deactivated, grants_changed, failed) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"instance-id",
+								uint64(15),
+								anyArg{},
+								time.Date(2023, 6, 1, 10, 0, 0, 0, time.UTC),
+								domain.LDAPSyncRunStateSucceeded,
+								false,
+								uint64(2),
+								uint64(3),
+								uint64(1),
+								uint64(4),
+								uint64(1),
+							},
+						},
+						{
+							expectedStmt: "UPDATE projections.idp_ldap_syncs SET (change_date, sequence, last_run, next_run) = ($1, $2, $3, $4::TIMESTAMPTZ + (sync_interval / 1000)::INT8 * INTERVAL '1 microsecond') WHERE (idp_id = $5) AND (instance_id = $6)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								anyArg{},
+								anyArg{},
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceRunSucceeded dry run",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(idpsync.LDAPSyncRunSucceededType),
+					idpsync.AggregateType,
+					[]byte(`{
+						"dryRun": true,
+						"startedAt": "2023-06-01T10:00:00Z",
+						"created": 2
+					}`),
+				), idpsync.LDAPSyncRunSucceededEventMapper),
+			},
+			reduce: (&idpLDAPSyncProjection{}).reduceRunSucceeded,
+			want: wantReduce{
+				aggregateType:    idpsync.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.idp_ldap_syncs_runs (idp_id, instance_id, sequence, creation_date, started_at, state, dry_run, created, updated, deactivated, grants_changed, failed) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"instance-id",
+								uint64(15),
+								anyArg{},
+								time.Date(2023, 6, 1, 10, 0, 0, 0, time.UTC),
+								domain.LDAPSyncRunStateSucceeded,
+								true,
+								uint64(2),
+								uint64(0),
+								uint64(0),
+								uint64(0),
+								uint64(0),
+							},
+						},
+						{
+							expectedStmt: "UPDATE projections.idp_ldap_syncs SET (change_date, sequence) = ($1, $2) WHERE (idp_id = $3) AND (instance_id = $4)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceRunFailed",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(idpsync.LDAPSyncRunFailedType),
+					idpsync.AggregateType,
+					[]byte(`{
+						"startedAt": "2023-06-01T10:00:00Z",
+						"error": "unreachable"
+					}`),
+				), idpsync.LDAPSyncRunFailedEventMapper),
+			},
+			reduce: (&idpLDAPSyncProjection{}).reduceRunFailed,
+			want: wantReduce{
+				aggregateType:    idpsync.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.idp_ldap_syncs_runs (idp_id, instance_id, sequence, creation_date, started_at, state, dry_run, error) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"instance-id",
+								uint64(15),
+								anyArg{},
+								time.Date(2023, 6, 1, 10, 0, 0, 0, time.UTC),
+								domain.LDAPSyncRunStateFailed,
+								false,
+								"unreachable",
+							},
+						},
+						{
+							expectedStmt: "UPDATE projections.idp_ldap_syncs SET (change_date, sequence, last_run, next_run) = ($1, $2, $3, $4::TIMESTAMPTZ + (sync_interval / 1000)::INT8 * INTERVAL '1 microsecond') WHERE (idp_id = $5) AND (instance_id = $6)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								anyArg{},
+								anyArg{},
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance reduceIDPRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(instance.IDPRemovedEventType),
+					instance.AggregateType,
+					[]byte(`{"id": "idp-id"}`),
+				), instance.IDPRemovedEventMapper),
+			},
+			reduce: (&idpLDAPSyncProjection{}).reduceIDPRemoved,
+			want: wantReduce{
+				aggregateType:    instance.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.idp_ldap_syncs WHERE (idp_id = $1) AND (instance_id = $2)",
+							expectedArgs: []interface{}{
+								"idp-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "org reduceIDPRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(org.IDPRemovedEventType),
+					org.AggregateType,
+					[]byte(`{"id": "idp-id"}`),
+				), org.IDPRemovedEventMapper),
+			},
+			reduce: (&idpLDAPSyncProjection{}).reduceIDPRemoved,
+			want: wantReduce{
+				aggregateType:    org.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.idp_ldap_syncs WHERE (idp_id = $1) AND (instance_id = $2)",
+							expectedArgs: []interface{}{
+								"idp-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "org reduceOwnerRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(org.OrgRemovedEventType),
+					org.AggregateType,
+					[]byte(`{}`),
+				), org.OrgRemovedEventMapper),
+			},
+			reduce: (&idpLDAPSyncProjection{}).reduceOwnerRemoved,
+			want: wantReduce{
+				aggregateType:    org.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.idp_ldap_syncs WHERE (resource_owner = $1) AND (instance_id = $2)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance reduceInstanceRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(instance.InstanceRemovedEventType),
+					instance.AggregateType,
+					nil,
+				), instance.InstanceRemovedEventMapper),
+			},
+			reduce: reduceInstanceRemovedHelper(IDPLDAPSyncInstanceIDCol),
+			want: wantReduce{
+				aggregateType:    instance.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.idp_ldap_syncs WHERE (instance_id = $1)",
+							expectedArgs: []interface{}{
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if !errors.IsErrorInvalidArgument(err) {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, IDPLDAPSyncTable, tt.want)
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/policy"
+)
+
+const (
+	MessageMailTemplateTable = "projections.message_mail_templates"
+
+	MessageMailTemplateAggregateIDCol  = "aggregate_id"
+	MessageMailTemplateInstanceIDCol   = "instance_id"
+	MessageMailTemplateMessageTypeCol  = "message_type"
+	MessageMailTemplateCreationDateCol = "creation_date"
+	MessageMailTemplateChangeDateCol   = "change_date"
+	MessageMailTemplateSequenceCol     = "sequence"
+	MessageMailTemplateStateCol        = "state"
+	MessageMailTemplateIsDefaultCol    = "is_default"
+	MessageMailTemplateFormatCol       = "format"
+	MessageMailTemplateSourceCol       = "source"
+	MessageMailTemplateTemplateCol     = "template"
+	MessageMailTemplateOwnerRemovedCol = "owner_removed"
+)
+
+type messageMailTemplateProjection struct {
+	crdb.StatementHandler
+}
+
+func newMessageMailTemplateProjection(ctx context.Context, config crdb.StatementHandlerConfig) *messageMailTemplateProjection {
+	p := new(messageMailTemplateProjection)
+	config.ProjectionName = MessageMailTemplateTable
+	config.Reducers = p.reducers()
+	config.InitCheck = crdb.NewTableCheck(
+		crdb.NewTable([]*crdb.Column{
+			crdb.NewColumn(MessageMailTemplateAggregateIDCol, crdb.ColumnTypeText),
+			crdb.NewColumn(MessageMailTemplateInstanceIDCol, crdb.ColumnTypeText),
+			crdb.NewColumn(MessageMailTemplateMessageTypeCol, crdb.ColumnTypeText),
+			crdb.NewColumn(MessageMailTemplateCreationDateCol, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(MessageMailTemplateChangeDateCol, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(MessageMailTemplateSequenceCol, crdb.ColumnTypeInt64),
+			crdb.NewColumn(MessageMailTemplateStateCol, crdb.ColumnTypeEnum),
+			crdb.NewColumn(MessageMailTemplateIsDefaultCol, crdb.ColumnTypeBool, crdb.Default(false)),
+			crdb.NewColumn(MessageMailTemplateFormatCol, crdb.ColumnTypeEnum),
+			crdb.NewColumn(MessageMailTemplateSourceCol, crdb.ColumnTypeBytes),
+			crdb.NewColumn(MessageMailTemplateTemplateCol, crdb.ColumnTypeBytes),
+			crdb.NewColumn(MessageMailTemplateOwnerRemovedCol, crdb.ColumnTypeBool, crdb.Default(false)),
+		},
+			crdb.NewPrimaryKey(MessageMailTemplateInstanceIDCol, MessageMailTemplateAggregateIDCol, MessageMailTemplateMessageTypeCol),
+			crdb.WithIndex(crdb.NewIndex("owner_removed", []string{MessageMailTemplateOwnerRemovedCol})),
+		),
+	)
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	return p
+}
+
+func (p *messageMailTemplateProjection) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: org.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  org.MessageMailTemplateSetEventType,
+					Reduce: p.reduceSet,
+				},
+				{
+					Event:  org.MessageMailTemplateRemovedEventType,
+					Reduce: p.reduceRemoved,
+				},
+				{
+					Event:  org.OrgRemovedEventType,
+					Reduce: p.reduceOwnerRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  instance.MessageMailTemplateSetEventType,
+					Reduce: p.reduceSet,
+				},
+				{
+					Event:  instance.MessageMailTemplateRemovedEventType,
+					Reduce: p.reduceRemoved,
+				},
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(MessageMailTemplateInstanceIDCol),
+				},
+			},
+		},
+	}
+}
+
+func (p *messageMailTemplateProjection) reduceSet(event eventstore.Event) (*handler.Statement, error) {
+	var templateEvent policy.MessageMailTemplateSetEvent
+	var isDefault bool
+	switch e := event.(type) {
+	case *org.MessageMailTemplateSetEvent:
+		templateEvent = e.MessageMailTemplateSetEvent
+		isDefault = false
+	case *instance.MessageMailTemplateSetEvent:
+		templateEvent = e.MessageMailTemplateSetEvent
+		isDefault = true
+	default:
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Mm3ks", "reduce.wrong.event.type %v", []eventstore.EventType{org.MessageMailTemplateSetEventType, instance.MessageMailTemplateSetEventType})
+	}
+	return crdb.NewUpsertStatement(
+		&templateEvent,
+		[]handler.Column{
+			handler.NewCol(MessageMailTemplateInstanceIDCol, nil),
+			handler.NewCol(MessageMailTemplateAggregateIDCol, nil),
+			handler.NewCol(MessageMailTemplateMessageTypeCol, nil),
+		},
+		[]handler.Column{
+			handler.NewCol(MessageMailTemplateAggregateIDCol, templateEvent.Aggregate().ID),
+			handler.NewCol(MessageMailTemplateInstanceIDCol, templateEvent.Aggregate().InstanceID),
+			handler.NewCol(MessageMailTemplateMessageTypeCol, templateEvent.MessageType),
+			handler.NewCol(MessageMailTemplateCreationDateCol, templateEvent.CreationDate()),
+			handler.NewCol(MessageMailTemplateChangeDateCol, templateEvent.CreationDate()),
+			handler.NewCol(MessageMailTemplateSequenceCol, templateEvent.Sequence()),
+			handler.NewCol(MessageMailTemplateStateCol, domain.PolicyStateActive),
+			handler.NewCol(MessageMailTemplateIsDefaultCol, isDefault),
+			handler.NewCol(MessageMailTemplateFormatCol, templateEvent.Format),
+			handler.NewCol(MessageMailTemplateSourceCol, templateEvent.Source),
+			handler.NewCol(MessageMailTemplateTemplateCol, templateEvent.Template),
+		}), nil
+}
+
+func (p *messageMailTemplateProjection) reduceRemoved(event eventstore.Event) (*handler.Statement, error) {
+	var templateEvent policy.MessageMailTemplateRemovedEvent
+	switch e := event.(type) {
+	case *org.MessageMailTemplateRemovedEvent:
+		templateEvent = e.MessageMailTemplateRemovedEvent
+	case *instance.MessageMailTemplateRemovedEvent:
+		templateEvent = e.MessageMailTemplateRemovedEvent
+	default:
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Mm8rw", "reduce.wrong.event.type %v", []eventstore.EventType{org.MessageMailTemplateRemovedEventType, instance.MessageMailTemplateRemovedEventType})
+	}
+	return crdb.NewDeleteStatement(
+		&templateEvent,
+		[]handler.Condition{
+			handler.NewCond(MessageMailTemplateAggregateIDCol, templateEvent.Aggregate().ID),
+			handler.NewCond(MessageMailTemplateMessageTypeCol, templateEvent.MessageType),
+			handler.NewCond(MessageMailTemplateInstanceIDCol, templateEvent.Aggregate().InstanceID),
+		}), nil
+}
+
+func (p *messageMailTemplateProjection) reduceOwnerRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.OrgRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Mm5ox", "reduce.wrong.event.type %s", org.OrgRemovedEventType)
+	}
+
+	return crdb.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(MessageMailTemplateChangeDateCol, e.CreationDate()),
+			handler.NewCol(MessageMailTemplateSequenceCol, e.Sequence()),
+			handler.NewCol(MessageMailTemplateOwnerRemovedCol, true),
+		},
+		[]handler.Condition{
+			handler.NewCond(MessageMailTemplateInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCond(MessageMailTemplateAggregateIDCol, e.Aggregate().ID),
+		},
+	), nil
+}
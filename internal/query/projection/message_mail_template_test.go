@@ -0,0 +1,237 @@
+package projection
+
+import (
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+func TestMessageMailTemplateProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "org.reduceSet",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(org.MessageMailTemplateSetEventType),
+					org.AggregateType,
+					[]byte(`{
+						"messageType": "InitCode",
+						"format": 1,
+						"source": "PHRhYmxlPjwvdGFibGU+",
+						"template": "PHRhYmxlPjwvdGFibGU+"
+					}`),
+				), org.MessageMailTemplateSetEventMapper),
+			},
+			reduce: (&messageMailTemplateProjection{}).reduceSet,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("org"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.message_mail_templates (aggregate_id, instance_id, message_type, creation_date, change_date, sequence, state, is_default, format, source, template) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) ON CONFLICT (instance_id, aggregate_id, message_type) DO UPDATE SET (creation_date, change_date, sequence, state, is_default, format, source, template) = (EXCLUDED.creation_date, EXCLUDED.change_date, EXCLUDED.sequence, EXCLUDED.state, EXCLUDED.is_default, EXCLUDED.format, EXCLUDED.source, EXCLUDED.template)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"instance-id",
+								"InitCode",
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								domain.PolicyStateActive,
+								false,
+								domain.MessageTemplateFormatHTML,
+								[]byte("<table></table>"),
+								[]byte("<table></table>"),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "org.reduceRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(org.MessageMailTemplateRemovedEventType),
+					org.AggregateType,
+					[]byte(`{
+						"messageType": "InitCode"
+					}`),
+				), org.MessageMailTemplateRemovedEventMapper),
+			},
+			reduce: (&messageMailTemplateProjection{}).reduceRemoved,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("org"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.message_mail_templates WHERE (aggregate_id = $1) AND (message_type = $2) AND (instance_id = $3)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"InitCode",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "org.reduceOwnerRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(org.OrgRemovedEventType),
+					org.AggregateType,
+					nil,
+				), org.OrgRemovedEventMapper),
+			},
+			reduce: (&messageMailTemplateProjection{}).reduceOwnerRemoved,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("org"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.message_mail_templates SET (change_date, sequence, owner_removed) = ($1, $2, $3) WHERE (instance_id = $4) AND (aggregate_id = $5)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								true,
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance.reduceSet",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(instance.MessageMailTemplateSetEventType),
+					instance.AggregateType,
+					[]byte(`{
+						"messageType": "PasswordReset",
+						"format": 2,
+						"source": "PHRhYmxlPjwvdGFibGU+",
+						"template": "PHRhYmxlPjwvdGFibGU+"
+					}`),
+				), instance.MessageMailTemplateSetEventMapper),
+			},
+			reduce: (&messageMailTemplateProjection{}).reduceSet,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("instance"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.message_mail_templates (aggregate_id, instance_id, message_type, creation_date, change_date, sequence, state, is_default, format, source, template) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) ON CONFLICT (instance_id, aggregate_id, message_type) DO UPDATE SET (creation_date, change_date, sequence, state, is_default, format, source, template) = (EXCLUDED.creation_date, EXCLUDED.change_date, EXCLUDED.sequence, EXCLUDED.state, EXCLUDED.is_default, EXCLUDED.format, EXCLUDED.source, EXCLUDED.template)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"instance-id",
+								"PasswordReset",
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								domain.PolicyStateActive,
+								true,
+								domain.MessageTemplateFormatMJML,
+								[]byte("<table></table>"),
+								[]byte("<table></table>"),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance.reduceRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(instance.MessageMailTemplateRemovedEventType),
+					instance.AggregateType,
+					[]byte(`{
+						"messageType": "PasswordReset"
+					}`),
+				), instance.MessageMailTemplateRemovedEventMapper),
+			},
+			reduce: (&messageMailTemplateProjection{}).reduceRemoved,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("instance"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.message_mail_templates WHERE (aggregate_id = $1) AND (message_type = $2) AND (instance_id = $3)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"PasswordReset",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance reduceInstanceRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(instance.InstanceRemovedEventType),
+					instance.AggregateType,
+					nil,
+				), instance.InstanceRemovedEventMapper),
+			},
+			reduce: reduceInstanceRemovedHelper(MessageMailTemplateInstanceIDCol),
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("instance"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.message_mail_templates WHERE (instance_id = $1)",
+							expectedArgs: []interface{}{
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if _, ok := err.(errors.InvalidArgument); !ok {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, MessageMailTemplateTable, tt.want)
+		})
+	}
+}
@@ -274,7 +274,16 @@ func isMessageTemplate(template string) bool {
 		template == domain.VerifyPhoneMessageType ||
 		template == domain.DomainClaimedMessageType ||
 		template == domain.PasswordlessRegistrationMessageType ||
-		template == domain.PasswordChangeMessageType
+		template == domain.PasswordChangeMessageType ||
+		template == domain.VerifySMSOTPMessageType ||
+		template == domain.VerifyEmailOTPMessageType ||
+		template == domain.RecoveryCodeUsedMessageType ||
+		template == domain.NewDeviceLoginMessageType ||
+		template == domain.MFAAddedMessageType ||
+		template == domain.MFARemovedMessageType ||
+		template == domain.EmailChangedMessageType ||
+		template == domain.MachineCredentialAddedMessageType ||
+		template == domain.UserLockedMessageType
 }
 func isTitle(key string) bool {
 	return key == domain.MessageTitle
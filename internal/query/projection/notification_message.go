@@ -0,0 +1,232 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/notification"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+const (
+	NotificationMessageTable = "projections.notification_messages"
+
+	NotificationMessageColumnID               = "id"
+	NotificationMessageColumnCreationDate     = "creation_date"
+	NotificationMessageColumnChangeDate       = "change_date"
+	NotificationMessageColumnSequence         = "sequence"
+	NotificationMessageColumnResourceOwner    = "resource_owner"
+	NotificationMessageColumnInstanceID       = "instance_id"
+	NotificationMessageColumnUserID           = "user_id"
+	NotificationMessageColumnMessageType      = "message_type"
+	NotificationMessageColumnChannel          = "channel"
+	NotificationMessageColumnRecipient        = "recipient"
+	NotificationMessageColumnTriggerEventType = "trigger_event_type"
+	NotificationMessageColumnState            = "state"
+	NotificationMessageColumnAttempts         = "attempts"
+	NotificationMessageColumnLastError        = "last_error"
+	NotificationMessageColumnNextAttempt      = "next_attempt"
+)
+
+type notificationMessageProjection struct {
+	crdb.StatementHandler
+}
+
+func newNotificationMessageProjection(ctx context.Context, config crdb.StatementHandlerConfig) *notificationMessageProjection {
+	p := new(notificationMessageProjection)
+	config.ProjectionName = NotificationMessageTable
+	config.Reducers = p.reducers()
+	config.InitCheck = crdb.NewTableCheck(
+		crdb.NewTable([]*crdb.Column{
+			crdb.NewColumn(NotificationMessageColumnID, crdb.ColumnTypeText),
+			crdb.NewColumn(NotificationMessageColumnCreationDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(NotificationMessageColumnChangeDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(NotificationMessageColumnSequence, crdb.ColumnTypeInt64),
+			crdb.NewColumn(NotificationMessageColumnResourceOwner, crdb.ColumnTypeText),
+			crdb.NewColumn(NotificationMessageColumnInstanceID, crdb.ColumnTypeText),
+			crdb.NewColumn(NotificationMessageColumnUserID, crdb.ColumnTypeText),
+			crdb.NewColumn(NotificationMessageColumnMessageType, crdb.ColumnTypeText),
+			crdb.NewColumn(NotificationMessageColumnChannel, crdb.ColumnTypeEnum),
+			crdb.NewColumn(NotificationMessageColumnRecipient, crdb.ColumnTypeText),
+			crdb.NewColumn(NotificationMessageColumnTriggerEventType, crdb.ColumnTypeText),
+			crdb.NewColumn(NotificationMessageColumnState, crdb.ColumnTypeEnum),
+			crdb.NewColumn(NotificationMessageColumnAttempts, crdb.ColumnTypeInt64, crdb.Default(0)),
+			crdb.NewColumn(NotificationMessageColumnLastError, crdb.ColumnTypeText, crdb.Default("")),
+			crdb.NewColumn(NotificationMessageColumnNextAttempt, crdb.ColumnTypeTimestamp),
+		},
+			crdb.NewPrimaryKey(NotificationMessageColumnInstanceID, NotificationMessageColumnID),
+			crdb.WithIndex(crdb.NewIndex("user_id", []string{NotificationMessageColumnUserID})),
+			crdb.WithIndex(crdb.NewIndex("due", []string{NotificationMessageColumnState, NotificationMessageColumnNextAttempt})),
+		),
+	)
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	return p
+}
+
+func (p *notificationMessageProjection) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: notification.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  notification.MessageQueuedType,
+					Reduce: p.reduceQueued,
+				},
+				{
+					Event:  notification.MessageSentType,
+					Reduce: p.reduceSent,
+				},
+				{
+					Event:  notification.MessageAttemptFailedType,
+					Reduce: p.reduceAttemptFailed,
+				},
+				{
+					Event:  notification.MessageFailedType,
+					Reduce: p.reduceFailed,
+				},
+				{
+					Event:  notification.MessageResendRequestedType,
+					Reduce: p.reduceResendRequested,
+				},
+				{
+					Event:  notification.MessageDiscardedType,
+					Reduce: p.reduceDiscarded,
+				},
+			},
+		},
+		{
+			Aggregate: user.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  user.UserRemovedType,
+					Reduce: p.reduceUserRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(NotificationMessageColumnInstanceID),
+				},
+			},
+		},
+	}
+}
+
+func (p *notificationMessageProjection) reduceQueued(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*notification.MessageQueuedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Nm4qw", "reduce.wrong.event.type %s", notification.MessageQueuedType)
+	}
+	return crdb.NewCreateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(NotificationMessageColumnID, e.Aggregate().ID),
+			handler.NewCol(NotificationMessageColumnCreationDate, e.CreationDate()),
+			handler.NewCol(NotificationMessageColumnChangeDate, e.CreationDate()),
+			handler.NewCol(NotificationMessageColumnSequence, e.Sequence()),
+			handler.NewCol(NotificationMessageColumnResourceOwner, e.Aggregate().ResourceOwner),
+			handler.NewCol(NotificationMessageColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCol(NotificationMessageColumnUserID, e.UserID),
+			handler.NewCol(NotificationMessageColumnMessageType, e.MessageType),
+			handler.NewCol(NotificationMessageColumnChannel, e.Channel),
+			handler.NewCol(NotificationMessageColumnRecipient, e.Recipient),
+			handler.NewCol(NotificationMessageColumnTriggerEventType, e.TriggerEventType),
+			handler.NewCol(NotificationMessageColumnState, domain.NotificationMessageStateQueued),
+			handler.NewCol(NotificationMessageColumnNextAttempt, e.CreationDate()),
+		},
+	), nil
+}
+
+func (p *notificationMessageProjection) reduceSent(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*notification.MessageSentEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Nm8sd", "reduce.wrong.event.type %s", notification.MessageSentType)
+	}
+	return p.updateMessage(e, []handler.Column{
+		handler.NewCol(NotificationMessageColumnState, domain.NotificationMessageStateSent),
+		handler.NewCol(NotificationMessageColumnAttempts, e.Attempt),
+	}), nil
+}
+
+func (p *notificationMessageProjection) reduceAttemptFailed(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*notification.MessageAttemptFailedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Nm2rf", "reduce.wrong.event.type %s", notification.MessageAttemptFailedType)
+	}
+	return p.updateMessage(e, []handler.Column{
+		handler.NewCol(NotificationMessageColumnState, domain.NotificationMessageStateRetrying),
+		handler.NewCol(NotificationMessageColumnAttempts, e.Attempt),
+		handler.NewCol(NotificationMessageColumnLastError, e.Error),
+		handler.NewCol(NotificationMessageColumnNextAttempt, e.NextAttempt),
+	}), nil
+}
+
+func (p *notificationMessageProjection) reduceFailed(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*notification.MessageFailedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Nm6yh", "reduce.wrong.event.type %s", notification.MessageFailedType)
+	}
+	return p.updateMessage(e, []handler.Column{
+		handler.NewCol(NotificationMessageColumnState, domain.NotificationMessageStateFailed),
+		handler.NewCol(NotificationMessageColumnAttempts, e.Attempt),
+		handler.NewCol(NotificationMessageColumnLastError, e.Error),
+	}), nil
+}
+
+func (p *notificationMessageProjection) reduceResendRequested(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*notification.MessageResendRequestedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Nm1gb", "reduce.wrong.event.type %s", notification.MessageResendRequestedType)
+	}
+	return p.updateMessage(e, []handler.Column{
+		handler.NewCol(NotificationMessageColumnState, domain.NotificationMessageStateQueued),
+		handler.NewCol(NotificationMessageColumnAttempts, 0),
+		handler.NewCol(NotificationMessageColumnNextAttempt, e.CreationDate()),
+	}), nil
+}
+
+func (p *notificationMessageProjection) reduceDiscarded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*notification.MessageDiscardedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Nm9kt", "reduce.wrong.event.type %s", notification.MessageDiscardedType)
+	}
+	return p.updateMessage(e, []handler.Column{
+		handler.NewCol(NotificationMessageColumnState, domain.NotificationMessageStateDiscarded),
+	}), nil
+}
+
+func (p *notificationMessageProjection) reduceUserRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.UserRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Nm3ux", "reduce.wrong.event.type %s", user.UserRemovedType)
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(NotificationMessageColumnUserID, e.Aggregate().ID),
+			handler.NewCond(NotificationMessageColumnInstanceID, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *notificationMessageProjection) updateMessage(event eventstore.Event, columns []handler.Column) *handler.Statement {
+	return crdb.NewUpdateStatement(
+		event,
+		append([]handler.Column{
+			handler.NewCol(NotificationMessageColumnChangeDate, event.CreationDate()),
+			handler.NewCol(NotificationMessageColumnSequence, event.Sequence()),
+		}, columns...),
+		[]handler.Condition{
+			handler.NewCond(NotificationMessageColumnID, event.Aggregate().ID),
+			handler.NewCond(NotificationMessageColumnInstanceID, event.Aggregate().InstanceID),
+		},
+	)
+}
@@ -0,0 +1,223 @@
+package projection
+
+import (
+	"testing"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/notification"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+func TestNotificationMessageProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "reduceQueued",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(notification.MessageQueuedType),
+					notification.AggregateType,
+					[]byte(`{
+						"userId": "user-id",
+						"messageType": "InitCode",
+						"channel": 0,
+						"recipient": "user@example.com",
+						"content": {
+							"cryptoType": 0,
+							"algorithm": "RSA-265",
+							"keyId": "key-id",
+							"crypted": "Y3J5cHRlZA=="
+						},
+						"triggerAggregateId": "user-id",
+						"triggerEventType": "user.human.initialization.code.added",
+						"triggerSequence": 10
+					}`),
+				), notification.MessageQueuedEventMapper),
+			},
+			reduce: (&notificationMessageProjection{}).reduceQueued,
+			want: wantReduce{
+				aggregateType:    notification.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.notification_messages (id, creation_date, change_date, sequence, resource_owner, instance_id, user_id, message_type, channel, recipient, trigger_event_type, state, next_attempt) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								"ro-id",
+								"instance-id",
+								"user-id",
+								"InitCode",
+								domain.NotificationTypeEmail,
+								"user@example.com",
+								eventstore.EventType("user.human.initialization.code.added"),
+								domain.NotificationMessageStateQueued,
+								anyArg{},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceAttemptFailed",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(notification.MessageAttemptFailedType),
+					notification.AggregateType,
+					[]byte(`{
+						"attempt": 2,
+						"error": "unavailable",
+						"nextAttempt": "2023-06-01T10:00:00Z"
+					}`),
+				), notification.MessageAttemptFailedEventMapper),
+			},
+			reduce: (&notificationMessageProjection{}).reduceAttemptFailed,
+			want: wantReduce{
+				aggregateType:    notification.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.notification_messages SET (change_date, sequence, state, attempts, last_error, next_attempt) = ($1, $2, $3, $4, $5, $6) WHERE (id = $7) AND (instance_id = $8)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								domain.NotificationMessageStateRetrying,
+								uint64(2),
+								"unavailable",
+								time.Date(2023, 6, 1, 10, 0, 0, 0, time.UTC),
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceFailed",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(notification.MessageFailedType),
+					notification.AggregateType,
+					[]byte(`{
+						"attempt": 8,
+						"error": "unavailable"
+					}`),
+				), notification.MessageFailedEventMapper),
+			},
+			reduce: (&notificationMessageProjection{}).reduceFailed,
+			want: wantReduce{
+				aggregateType:    notification.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.notification_messages SET (change_date, sequence, state, attempts, last_error) = ($1, $2, $3, $4, $5) WHERE (id = $6) AND (instance_id = $7)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								domain.NotificationMessageStateFailed,
+								uint64(8),
+								"unavailable",
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceResendRequested",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(notification.MessageResendRequestedType),
+					notification.AggregateType,
+					nil,
+				), notification.MessageResendRequestedEventMapper),
+			},
+			reduce: (&notificationMessageProjection{}).reduceResendRequested,
+			want: wantReduce{
+				aggregateType:    notification.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.notification_messages SET (change_date, sequence, state, attempts, next_attempt) = ($1, $2, $3, $4, $5) WHERE (id = $6) AND (instance_id = $7)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								domain.NotificationMessageStateQueued,
+								0,
+								anyArg{},
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "user reduceUserRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(user.UserRemovedType),
+					user.AggregateType,
+					nil,
+				), user.UserRemovedEventMapper),
+			},
+			reduce: (&notificationMessageProjection{}).reduceUserRemoved,
+			want: wantReduce{
+				aggregateType:    user.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.notification_messages WHERE (user_id = $1) AND (instance_id = $2)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if !errors.IsErrorInvalidArgument(err) {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, NotificationMessageTable, tt.want)
+		})
+	}
+}
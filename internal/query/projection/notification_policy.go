@@ -14,18 +14,19 @@ import (
 )
 
 const (
-	NotificationPolicyProjectionTable = "projections.notification_policies"
+	NotificationPolicyProjectionTable = "projections.notification_policies2"
 
-	NotificationPolicyColumnID             = "id"
-	NotificationPolicyColumnCreationDate   = "creation_date"
-	NotificationPolicyColumnChangeDate     = "change_date"
-	NotificationPolicyColumnResourceOwner  = "resource_owner"
-	NotificationPolicyColumnInstanceID     = "instance_id"
-	NotificationPolicyColumnSequence       = "sequence"
-	NotificationPolicyColumnStateCol       = "state"
-	NotificationPolicyColumnIsDefault      = "is_default"
-	NotificationPolicyColumnPasswordChange = "password_change"
-	NotificationPolicyColumnOwnerRemoved   = "owner_removed"
+	NotificationPolicyColumnID                    = "id"
+	NotificationPolicyColumnCreationDate          = "creation_date"
+	NotificationPolicyColumnChangeDate            = "change_date"
+	NotificationPolicyColumnResourceOwner         = "resource_owner"
+	NotificationPolicyColumnInstanceID            = "instance_id"
+	NotificationPolicyColumnSequence              = "sequence"
+	NotificationPolicyColumnStateCol              = "state"
+	NotificationPolicyColumnIsDefault             = "is_default"
+	NotificationPolicyColumnPasswordChange        = "password_change"
+	NotificationPolicyColumnSecurityNotifications = "security_notifications"
+	NotificationPolicyColumnOwnerRemoved          = "owner_removed"
 )
 
 type notificationPolicyProjection struct {
@@ -47,6 +48,7 @@ func newNotificationPolicyProjection(ctx context.Context, config crdb.StatementH
 			crdb.NewColumn(NotificationPolicyColumnStateCol, crdb.ColumnTypeEnum),
 			crdb.NewColumn(NotificationPolicyColumnIsDefault, crdb.ColumnTypeBool),
 			crdb.NewColumn(NotificationPolicyColumnPasswordChange, crdb.ColumnTypeBool),
+			crdb.NewColumn(NotificationPolicyColumnSecurityNotifications, crdb.ColumnTypeBool, crdb.Default(false)),
 			crdb.NewColumn(NotificationPolicyColumnOwnerRemoved, crdb.ColumnTypeBool, crdb.Default(false)),
 		},
 			crdb.NewPrimaryKey(NotificationPolicyColumnInstanceID, NotificationPolicyColumnID),
@@ -121,6 +123,7 @@ func (p *notificationPolicyProjection) reduceAdded(event eventstore.Event) (*han
 			handler.NewCol(NotificationPolicyColumnID, policyEvent.Aggregate().ID),
 			handler.NewCol(NotificationPolicyColumnStateCol, domain.PolicyStateActive),
 			handler.NewCol(NotificationPolicyColumnPasswordChange, policyEvent.PasswordChange),
+			handler.NewCol(NotificationPolicyColumnSecurityNotifications, policyEvent.SecurityNotifications),
 			handler.NewCol(NotificationPolicyColumnIsDefault, isDefault),
 			handler.NewCol(NotificationPolicyColumnResourceOwner, policyEvent.Aggregate().ResourceOwner),
 			handler.NewCol(NotificationPolicyColumnInstanceID, policyEvent.Aggregate().InstanceID),
@@ -144,6 +147,9 @@ func (p *notificationPolicyProjection) reduceChanged(event eventstore.Event) (*h
 	if policyEvent.PasswordChange != nil {
 		cols = append(cols, handler.NewCol(NotificationPolicyColumnPasswordChange, *policyEvent.PasswordChange))
 	}
+	if policyEvent.SecurityNotifications != nil {
+		cols = append(cols, handler.NewCol(NotificationPolicyColumnSecurityNotifications, *policyEvent.SecurityNotifications))
+	}
 	return crdb.NewUpdateStatement(
 		&policyEvent,
 		cols,
@@ -29,7 +29,8 @@ func TestNotificationPolicyProjection_reduces(t *testing.T) {
 					repository.EventType(org.NotificationPolicyAddedEventType),
 					org.AggregateType,
 					[]byte(`{
-						"passwordChange": true
+						"passwordChange": true,
+						"securityNotifications": true
 }`),
 				), org.NotificationPolicyAddedEventMapper),
 			},
@@ -41,7 +42,7 @@ func TestNotificationPolicyProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "INSERT INTO projections.notification_policies (creation_date, change_date, sequence, id, state, password_change, is_default, resource_owner, instance_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)",
+							expectedStmt: "INSERT INTO projections.notification_policies2 (creation_date, change_date, sequence, id, state, password_change, security_notifications, is_default, resource_owner, instance_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)",
 							expectedArgs: []interface{}{
 								anyArg{},
 								anyArg{},
@@ -49,6 +50,7 @@ func TestNotificationPolicyProjection_reduces(t *testing.T) {
 								"agg-id",
 								domain.PolicyStateActive,
 								true,
+								true,
 								false,
 								"ro-id",
 								"instance-id",
@@ -66,7 +68,8 @@ func TestNotificationPolicyProjection_reduces(t *testing.T) {
 					repository.EventType(org.NotificationPolicyChangedEventType),
 					org.AggregateType,
 					[]byte(`{
-						"passwordChange": true
+						"passwordChange": true,
+						"securityNotifications": true
 		}`),
 				), org.NotificationPolicyChangedEventMapper),
 			},
@@ -77,11 +80,12 @@ func TestNotificationPolicyProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "UPDATE projections.notification_policies SET (change_date, sequence, password_change) = ($1, $2, $3) WHERE (id = $4) AND (instance_id = $5)",
+							expectedStmt: "UPDATE projections.notification_policies2 SET (change_date, sequence, password_change, security_notifications) = ($1, $2, $3, $4) WHERE (id = $5) AND (instance_id = $6)",
 							expectedArgs: []interface{}{
 								anyArg{},
 								uint64(15),
 								true,
+								true,
 								"agg-id",
 								"instance-id",
 							},
@@ -107,7 +111,7 @@ func TestNotificationPolicyProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "DELETE FROM projections.notification_policies WHERE (id = $1) AND (instance_id = $2)",
+							expectedStmt: "DELETE FROM projections.notification_policies2 WHERE (id = $1) AND (instance_id = $2)",
 							expectedArgs: []interface{}{
 								"agg-id",
 								"instance-id",
@@ -133,7 +137,7 @@ func TestNotificationPolicyProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "DELETE FROM projections.notification_policies WHERE (instance_id = $1)",
+							expectedStmt: "DELETE FROM projections.notification_policies2 WHERE (instance_id = $1)",
 							expectedArgs: []interface{}{
 								"agg-id",
 							},
@@ -150,7 +154,8 @@ func TestNotificationPolicyProjection_reduces(t *testing.T) {
 					repository.EventType(instance.NotificationPolicyAddedEventType),
 					instance.AggregateType,
 					[]byte(`{
-						"passwordChange": true
+						"passwordChange": true,
+						"securityNotifications": true
 					}`),
 				), instance.NotificationPolicyAddedEventMapper),
 			},
@@ -161,7 +166,7 @@ func TestNotificationPolicyProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "INSERT INTO projections.notification_policies (creation_date, change_date, sequence, id, state, password_change, is_default, resource_owner, instance_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)",
+							expectedStmt: "INSERT INTO projections.notification_policies2 (creation_date, change_date, sequence, id, state, password_change, security_notifications, is_default, resource_owner, instance_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)",
 							expectedArgs: []interface{}{
 								anyArg{},
 								anyArg{},
@@ -170,6 +175,7 @@ func TestNotificationPolicyProjection_reduces(t *testing.T) {
 								domain.PolicyStateActive,
 								true,
 								true,
+								true,
 								"ro-id",
 								"instance-id",
 							},
@@ -186,7 +192,8 @@ func TestNotificationPolicyProjection_reduces(t *testing.T) {
 					repository.EventType(instance.NotificationPolicyChangedEventType),
 					instance.AggregateType,
 					[]byte(`{
-						"passwordChange": true
+						"passwordChange": true,
+						"securityNotifications": true
 					}`),
 				), instance.NotificationPolicyChangedEventMapper),
 			},
@@ -197,11 +204,12 @@ func TestNotificationPolicyProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "UPDATE projections.notification_policies SET (change_date, sequence, password_change) = ($1, $2, $3) WHERE (id = $4) AND (instance_id = $5)",
+							expectedStmt: "UPDATE projections.notification_policies2 SET (change_date, sequence, password_change, security_notifications) = ($1, $2, $3, $4) WHERE (id = $5) AND (instance_id = $6)",
 							expectedArgs: []interface{}{
 								anyArg{},
 								uint64(15),
 								true,
+								true,
 								"agg-id",
 								"instance-id",
 							},
@@ -227,7 +235,7 @@ func TestNotificationPolicyProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "UPDATE projections.notification_policies SET (change_date, sequence, owner_removed) = ($1, $2, $3) WHERE (instance_id = $4) AND (resource_owner = $5)",
+							expectedStmt: "UPDATE projections.notification_policies2 SET (change_date, sequence, owner_removed) = ($1, $2, $3) WHERE (instance_id = $4) AND (resource_owner = $5)",
 							expectedArgs: []interface{}{
 								anyArg{},
 								uint64(15),
@@ -17,55 +17,65 @@ const (
 )
 
 var (
-	projectionConfig                    crdb.StatementHandlerConfig
-	OrgProjection                       *orgProjection
-	OrgMetadataProjection               *orgMetadataProjection
-	ActionProjection                    *actionProjection
-	FlowProjection                      *flowProjection
-	ProjectProjection                   *projectProjection
-	PasswordComplexityProjection        *passwordComplexityProjection
-	PasswordAgeProjection               *passwordAgeProjection
-	LockoutPolicyProjection             *lockoutPolicyProjection
-	PrivacyPolicyProjection             *privacyPolicyProjection
-	DomainPolicyProjection              *domainPolicyProjection
-	LabelPolicyProjection               *labelPolicyProjection
-	ProjectGrantProjection              *projectGrantProjection
-	ProjectRoleProjection               *projectRoleProjection
-	OrgDomainProjection                 *orgDomainProjection
-	LoginPolicyProjection               *loginPolicyProjection
-	IDPProjection                       *idpProjection
-	AppProjection                       *appProjection
-	IDPUserLinkProjection               *idpUserLinkProjection
-	IDPLoginPolicyLinkProjection        *idpLoginPolicyLinkProjection
-	IDPTemplateProjection               *idpTemplateProjection
-	MailTemplateProjection              *mailTemplateProjection
-	MessageTextProjection               *messageTextProjection
-	CustomTextProjection                *customTextProjection
-	UserProjection                      *userProjection
-	LoginNameProjection                 *loginNameProjection
-	OrgMemberProjection                 *orgMemberProjection
-	InstanceDomainProjection            *instanceDomainProjection
-	InstanceMemberProjection            *instanceMemberProjection
-	ProjectMemberProjection             *projectMemberProjection
-	ProjectGrantMemberProjection        *projectGrantMemberProjection
-	AuthNKeyProjection                  *authNKeyProjection
-	PersonalAccessTokenProjection       *personalAccessTokenProjection
-	UserGrantProjection                 *userGrantProjection
-	UserMetadataProjection              *userMetadataProjection
-	UserAuthMethodProjection            *userAuthMethodProjection
-	InstanceProjection                  *instanceProjection
-	SecretGeneratorProjection           *secretGeneratorProjection
-	SMTPConfigProjection                *smtpConfigProjection
-	SMSConfigProjection                 *smsConfigProjection
-	OIDCSettingsProjection              *oidcSettingsProjection
-	DebugNotificationProviderProjection *debugNotificationProviderProjection
-	KeyProjection                       *keyProjection
-	SecurityPolicyProjection            *securityPolicyProjection
-	NotificationPolicyProjection        *notificationPolicyProjection
-	NotificationsProjection             interface{}
-	NotificationsQuotaProjection        interface{}
-	DeviceAuthProjection                *deviceAuthProjection
-	SessionProjection                   *sessionProjection
+	projectionConfig                         crdb.StatementHandlerConfig
+	OrgProjection                            *orgProjection
+	OrgMetadataProjection                    *orgMetadataProjection
+	ActionProjection                         *actionProjection
+	FlowProjection                           *flowProjection
+	ProjectProjection                        *projectProjection
+	PasswordComplexityProjection             *passwordComplexityProjection
+	PasswordAgeProjection                    *passwordAgeProjection
+	LockoutPolicyProjection                  *lockoutPolicyProjection
+	PrivacyPolicyProjection                  *privacyPolicyProjection
+	DomainPolicyProjection                   *domainPolicyProjection
+	LabelPolicyProjection                    *labelPolicyProjection
+	ProjectGrantProjection                   *projectGrantProjection
+	ProjectRoleProjection                    *projectRoleProjection
+	OrgDomainProjection                      *orgDomainProjection
+	LoginPolicyProjection                    *loginPolicyProjection
+	IDPProjection                            *idpProjection
+	AppProjection                            *appProjection
+	IDPUserLinkProjection                    *idpUserLinkProjection
+	IDPLoginPolicyLinkProjection             *idpLoginPolicyLinkProjection
+	IDPTemplateProjection                    *idpTemplateProjection
+	MailTemplateProjection                   *mailTemplateProjection
+	MessageMailTemplateProjection            *messageMailTemplateProjection
+	MessageTextProjection                    *messageTextProjection
+	CustomTextProjection                     *customTextProjection
+	UserProjection                           *userProjection
+	LoginNameProjection                      *loginNameProjection
+	OrgMemberProjection                      *orgMemberProjection
+	InstanceDomainProjection                 *instanceDomainProjection
+	InstanceMemberProjection                 *instanceMemberProjection
+	ProjectMemberProjection                  *projectMemberProjection
+	ProjectGrantMemberProjection             *projectGrantMemberProjection
+	AuthNKeyProjection                       *authNKeyProjection
+	PersonalAccessTokenProjection            *personalAccessTokenProjection
+	UserGrantProjection                      *userGrantProjection
+	UserMetadataProjection                   *userMetadataProjection
+	UserAuthMethodProjection                 *userAuthMethodProjection
+	UserConsentProjection                    *userConsentProjection
+	InstanceProjection                       *instanceProjection
+	SecretGeneratorProjection                *secretGeneratorProjection
+	SMTPConfigProjection                     *smtpConfigProjection
+	SMSConfigProjection                      *smsConfigProjection
+	EmailProviderProjection                  *emailProviderProjection
+	NotificationMessageProjection            *notificationMessageProjection
+	IDPLDAPSyncProjection                    *idpLDAPSyncProjection
+	OIDCSettingsProjection                   *oidcSettingsProjection
+	DebugNotificationProviderProjection      *debugNotificationProviderProjection
+	KeyProjection                            *keyProjection
+	SecurityPolicyProjection                 *securityPolicyProjection
+	ClientRegistrationPolicyProjection       *clientRegistrationPolicyProjection
+	NotificationPolicyProjection             *notificationPolicyProjection
+	NotificationsProjection                  interface{}
+	NotificationsQuotaProjection             interface{}
+	NotificationsBackChannelLogoutProjection interface{}
+	DeviceAuthProjection                     *deviceAuthProjection
+	SessionProjection                        *sessionProjection
+	UserSessionProjection                    *userSessionProjection
+	TokenProjection                          *tokenProjection
+	RefreshTokenProjection                   *refreshTokenProjection
 )
 
 type projection interface {
@@ -118,6 +128,7 @@ func Create(ctx context.Context, sqlClient *database.DB, es *eventstore.Eventsto
 	IDPLoginPolicyLinkProjection = newIDPLoginPolicyLinkProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["idp_login_policy_links"]))
 	IDPTemplateProjection = newIDPTemplateProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["idp_templates"]))
 	MailTemplateProjection = newMailTemplateProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["mail_templates"]))
+	MessageMailTemplateProjection = newMessageMailTemplateProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["message_mail_templates"]))
 	MessageTextProjection = newMessageTextProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["message_texts"]))
 	CustomTextProjection = newCustomTextProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["custom_texts"]))
 	UserProjection = newUserProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["users"]))
@@ -132,17 +143,25 @@ func Create(ctx context.Context, sqlClient *database.DB, es *eventstore.Eventsto
 	UserGrantProjection = newUserGrantProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["user_grants"]))
 	UserMetadataProjection = newUserMetadataProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["user_metadata"]))
 	UserAuthMethodProjection = newUserAuthMethodProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["user_auth_method"]))
+	UserConsentProjection = newUserConsentProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["user_consents"]))
 	InstanceProjection = newInstanceProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["instances"]))
 	SecretGeneratorProjection = newSecretGeneratorProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["secret_generators"]))
 	SMTPConfigProjection = newSMTPConfigProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["smtp_configs"]))
 	SMSConfigProjection = newSMSConfigProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["sms_config"]))
+	EmailProviderProjection = newEmailProviderProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["email_providers"]))
+	NotificationMessageProjection = newNotificationMessageProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["notification_messages"]))
+	IDPLDAPSyncProjection = newIDPLDAPSyncProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["idp_ldap_syncs"]))
 	OIDCSettingsProjection = newOIDCSettingsProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["oidc_settings"]))
 	DebugNotificationProviderProjection = newDebugNotificationProviderProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["debug_notification_provider"]))
 	KeyProjection = newKeyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["keys"]), keyEncryptionAlgorithm, certEncryptionAlgorithm)
 	SecurityPolicyProjection = newSecurityPolicyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["security_policies"]))
+	ClientRegistrationPolicyProjection = newClientRegistrationPolicyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["client_registration_policies"]))
 	NotificationPolicyProjection = newNotificationPolicyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["notification_policies"]))
 	DeviceAuthProjection = newDeviceAuthProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["device_auth"]))
 	SessionProjection = newSessionProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["sessions"]))
+	UserSessionProjection = newUserSessionProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["user_sessions"]))
+	TokenProjection = newTokenProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["tokens"]))
+	RefreshTokenProjection = newRefreshTokenProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["refresh_tokens"]))
 	newProjectionsList()
 	return nil
 }
@@ -215,6 +234,7 @@ func newProjectionsList() {
 		IDPUserLinkProjection,
 		IDPLoginPolicyLinkProjection,
 		MailTemplateProjection,
+		MessageMailTemplateProjection,
 		MessageTextProjection,
 		CustomTextProjection,
 		UserProjection,
@@ -229,16 +249,24 @@ func newProjectionsList() {
 		UserGrantProjection,
 		UserMetadataProjection,
 		UserAuthMethodProjection,
+		UserConsentProjection,
 		InstanceProjection,
 		SecretGeneratorProjection,
 		SMTPConfigProjection,
 		SMSConfigProjection,
+		EmailProviderProjection,
+		NotificationMessageProjection,
+		IDPLDAPSyncProjection,
 		OIDCSettingsProjection,
 		DebugNotificationProviderProjection,
 		KeyProjection,
 		SecurityPolicyProjection,
+		ClientRegistrationPolicyProjection,
 		NotificationPolicyProjection,
 		DeviceAuthProjection,
 		SessionProjection,
+		UserSessionProjection,
+		TokenProjection,
+		RefreshTokenProjection,
 	}
 }
@@ -0,0 +1,95 @@
+package projection
+
+import (
+	"context"
+	"sync"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+)
+
+type rebuildableProjection interface {
+	Name() string
+	Rebuild(ctx context.Context, progress crdb.RebuildProgressFunc) error
+}
+
+// RebuildState is the last reported progress of a rebuild started by StartRebuild
+type RebuildState struct {
+	crdb.RebuildProgress
+	Running bool
+	Err     error
+}
+
+var (
+	rebuildsMu sync.Mutex
+	rebuilds   = make(map[string]*RebuildState)
+)
+
+// Rebuild builds the projection into shadow tables
+// and swaps them into place as soon as they caught up
+func Rebuild(ctx context.Context, projectionName string, progress crdb.RebuildProgressFunc) error {
+	p, err := rebuildable(projectionName)
+	if err != nil {
+		return err
+	}
+	return p.Rebuild(ctx, progress)
+}
+
+// StartRebuild rebuilds the projection in the background,
+// the progress can be checked by GetRebuildState
+func StartRebuild(projectionName string) error {
+	p, err := rebuildable(projectionName)
+	if err != nil {
+		return err
+	}
+	rebuildsMu.Lock()
+	defer rebuildsMu.Unlock()
+	if state, ok := rebuilds[projectionName]; ok && state.Running {
+		return errors.ThrowAlreadyExists(nil, "PROJE-Ht5vf", "Errors.ProjectionName.RebuildRunning")
+	}
+	rebuilds[projectionName] = &RebuildState{
+		RebuildProgress: crdb.RebuildProgress{Projection: projectionName},
+		Running:         true,
+	}
+	go func() {
+		err := p.Rebuild(context.Background(), func(progress crdb.RebuildProgress) {
+			setRebuildState(&RebuildState{RebuildProgress: progress, Running: true})
+		})
+		logging.WithFields("projection", projectionName).OnError(err).Error("rebuild failed")
+		rebuildsMu.Lock()
+		defer rebuildsMu.Unlock()
+		rebuilds[projectionName].Running = false
+		rebuilds[projectionName].Err = err
+	}()
+	return nil
+}
+
+// GetRebuildState returns the progress of the last rebuild of the projection started in this process
+func GetRebuildState(projectionName string) (*RebuildState, error) {
+	rebuildsMu.Lock()
+	defer rebuildsMu.Unlock()
+	state, ok := rebuilds[projectionName]
+	if !ok {
+		return nil, errors.ThrowNotFound(nil, "PROJE-Lw2ds", "Errors.ProjectionName.RebuildNotFound")
+	}
+	copied := *state
+	return &copied, nil
+}
+
+func setRebuildState(state *RebuildState) {
+	rebuildsMu.Lock()
+	defer rebuildsMu.Unlock()
+	rebuilds[state.Projection] = state
+}
+
+func rebuildable(projectionName string) (rebuildableProjection, error) {
+	for _, p := range projections {
+		rebuildable, ok := p.(rebuildableProjection)
+		if ok && rebuildable.Name() == projectionName {
+			return rebuildable, nil
+		}
+	}
+	return nil, errors.ThrowNotFound(nil, "PROJE-Ds9fk", "Errors.ProjectionName.Invalid")
+}
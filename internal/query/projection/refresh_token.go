@@ -0,0 +1,218 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+const (
+	// RefreshTokenProjectionTable contains the refresh tokens of the users
+	// it replaces the auth.refresh_tokens view, which is kept as long as older versions can run during an upgrade
+	RefreshTokenProjectionTable = "projections.refresh_tokens"
+
+	RefreshTokenColumnID                    = "id"
+	RefreshTokenColumnCreationDate          = "creation_date"
+	RefreshTokenColumnChangeDate            = "change_date"
+	RefreshTokenColumnSequence              = "sequence"
+	RefreshTokenColumnResourceOwner         = "resource_owner"
+	RefreshTokenColumnInstanceID            = "instance_id"
+	RefreshTokenColumnToken                 = "token"
+	RefreshTokenColumnUserID                = "user_id"
+	RefreshTokenColumnClientID              = "client_id"
+	RefreshTokenColumnUserAgentID           = "user_agent_id"
+	RefreshTokenColumnAudience              = "audience"
+	RefreshTokenColumnScopes                = "scopes"
+	RefreshTokenColumnAuthMethodsReferences = "amr"
+	RefreshTokenColumnAuthTime              = "auth_time"
+	RefreshTokenColumnIdleExpiration        = "idle_expiration"
+	RefreshTokenColumnExpiration            = "expiration"
+)
+
+type refreshTokenProjection struct {
+	crdb.StatementHandler
+}
+
+func newRefreshTokenProjection(ctx context.Context, config crdb.StatementHandlerConfig) *refreshTokenProjection {
+	p := new(refreshTokenProjection)
+	config.ProjectionName = RefreshTokenProjectionTable
+	config.Reducers = p.reducers()
+	config.InitCheck = crdb.NewTableCheck(
+		crdb.NewTable([]*crdb.Column{
+			crdb.NewColumn(RefreshTokenColumnID, crdb.ColumnTypeText),
+			crdb.NewColumn(RefreshTokenColumnCreationDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(RefreshTokenColumnChangeDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(RefreshTokenColumnSequence, crdb.ColumnTypeInt64),
+			crdb.NewColumn(RefreshTokenColumnResourceOwner, crdb.ColumnTypeText),
+			crdb.NewColumn(RefreshTokenColumnInstanceID, crdb.ColumnTypeText),
+			crdb.NewColumn(RefreshTokenColumnToken, crdb.ColumnTypeText),
+			crdb.NewColumn(RefreshTokenColumnUserID, crdb.ColumnTypeText),
+			crdb.NewColumn(RefreshTokenColumnClientID, crdb.ColumnTypeText),
+			crdb.NewColumn(RefreshTokenColumnUserAgentID, crdb.ColumnTypeText),
+			crdb.NewColumn(RefreshTokenColumnAudience, crdb.ColumnTypeTextArray, crdb.Nullable()),
+			crdb.NewColumn(RefreshTokenColumnScopes, crdb.ColumnTypeTextArray, crdb.Nullable()),
+			crdb.NewColumn(RefreshTokenColumnAuthMethodsReferences, crdb.ColumnTypeTextArray, crdb.Nullable()),
+			crdb.NewColumn(RefreshTokenColumnAuthTime, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(RefreshTokenColumnIdleExpiration, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(RefreshTokenColumnExpiration, crdb.ColumnTypeTimestamp),
+		},
+			crdb.NewPrimaryKey(RefreshTokenColumnInstanceID, RefreshTokenColumnID),
+			crdb.WithIndex(crdb.NewIndex("user_id", []string{RefreshTokenColumnUserID})),
+			crdb.WithIndex(crdb.NewIndex("resource_owner", []string{RefreshTokenColumnResourceOwner})),
+		),
+	)
+
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	return p
+}
+
+func (p *refreshTokenProjection) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: user.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  user.HumanRefreshTokenAddedType,
+					Reduce: p.reduceRefreshTokenAdded,
+				},
+				{
+					Event:  user.HumanRefreshTokenRenewedType,
+					Reduce: p.reduceRefreshTokenRenewed,
+				},
+				{
+					Event:  user.HumanRefreshTokenRemovedType,
+					Reduce: p.reduceRefreshTokenRemoved,
+				},
+				{
+					Event:  user.UserLockedType,
+					Reduce: p.reduceUserTerminated,
+				},
+				{
+					Event:  user.UserDeactivatedType,
+					Reduce: p.reduceUserTerminated,
+				},
+				{
+					Event:  user.UserRemovedType,
+					Reduce: p.reduceUserTerminated,
+				},
+			},
+		},
+		{
+			Aggregate: org.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  org.OrgRemovedEventType,
+					Reduce: p.reduceOwnerRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(RefreshTokenColumnInstanceID),
+				},
+			},
+		},
+	}
+}
+
+func (p *refreshTokenProjection) reduceRefreshTokenAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanRefreshTokenAddedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Rt3mw", "reduce.wrong.event.type %s", user.HumanRefreshTokenAddedType)
+	}
+	return crdb.NewCreateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(RefreshTokenColumnID, e.TokenID),
+			handler.NewCol(RefreshTokenColumnCreationDate, e.CreationDate()),
+			handler.NewCol(RefreshTokenColumnChangeDate, e.CreationDate()),
+			handler.NewCol(RefreshTokenColumnSequence, e.Sequence()),
+			handler.NewCol(RefreshTokenColumnResourceOwner, e.Aggregate().ResourceOwner),
+			handler.NewCol(RefreshTokenColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCol(RefreshTokenColumnToken, e.TokenID),
+			handler.NewCol(RefreshTokenColumnUserID, e.Aggregate().ID),
+			handler.NewCol(RefreshTokenColumnClientID, e.ClientID),
+			handler.NewCol(RefreshTokenColumnUserAgentID, e.UserAgentID),
+			handler.NewCol(RefreshTokenColumnAudience, database.StringArray(e.Audience)),
+			handler.NewCol(RefreshTokenColumnScopes, database.StringArray(e.Scopes)),
+			handler.NewCol(RefreshTokenColumnAuthMethodsReferences, database.StringArray(e.AuthMethodsReferences)),
+			handler.NewCol(RefreshTokenColumnAuthTime, e.AuthTime),
+			handler.NewCol(RefreshTokenColumnIdleExpiration, e.CreationDate().Add(e.IdleExpiration)),
+			handler.NewCol(RefreshTokenColumnExpiration, e.CreationDate().Add(e.Expiration)),
+		},
+	), nil
+}
+
+func (p *refreshTokenProjection) reduceRefreshTokenRenewed(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanRefreshTokenRenewedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Rt8qx", "reduce.wrong.event.type %s", user.HumanRefreshTokenRenewedType)
+	}
+	return crdb.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(RefreshTokenColumnChangeDate, e.CreationDate()),
+			handler.NewCol(RefreshTokenColumnSequence, e.Sequence()),
+			handler.NewCol(RefreshTokenColumnToken, e.RefreshToken),
+			handler.NewCol(RefreshTokenColumnIdleExpiration, e.CreationDate().Add(e.IdleExpiration)),
+		},
+		[]handler.Condition{
+			handler.NewCond(RefreshTokenColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCond(RefreshTokenColumnID, e.TokenID),
+		},
+	), nil
+}
+
+func (p *refreshTokenProjection) reduceRefreshTokenRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanRefreshTokenRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Rt5va", "reduce.wrong.event.type %s", user.HumanRefreshTokenRemovedType)
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(RefreshTokenColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCond(RefreshTokenColumnID, e.TokenID),
+		},
+	), nil
+}
+
+func (p *refreshTokenProjection) reduceUserTerminated(event eventstore.Event) (*handler.Statement, error) {
+	switch event.(type) {
+	case *user.UserLockedEvent,
+		*user.UserDeactivatedEvent,
+		*user.UserRemovedEvent:
+		return crdb.NewDeleteStatement(
+			event,
+			[]handler.Condition{
+				handler.NewCond(RefreshTokenColumnInstanceID, event.Aggregate().InstanceID),
+				handler.NewCond(RefreshTokenColumnUserID, event.Aggregate().ID),
+			},
+		), nil
+	}
+	return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Rt2ke", "reduce.wrong.event.type %v", []eventstore.EventType{user.UserLockedType, user.UserDeactivatedType, user.UserRemovedType})
+}
+
+func (p *refreshTokenProjection) reduceOwnerRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.OrgRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Rt6yn", "reduce.wrong.event.type %s", org.OrgRemovedEventType)
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(RefreshTokenColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCond(RefreshTokenColumnResourceOwner, e.Aggregate().ID),
+		},
+	), nil
+}
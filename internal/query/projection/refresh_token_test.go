@@ -0,0 +1,235 @@
+package projection
+
+import (
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+func TestRefreshTokenProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "reduceRefreshTokenAdded",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(user.HumanRefreshTokenAddedType),
+					user.AggregateType,
+					[]byte(`{
+						"tokenId": "token-id",
+						"clientId": "client-id",
+						"userAgentId": "agent-id",
+						"audience": ["aud"],
+						"scopes": ["openid", "offline_access"],
+						"authMethodReferences": ["pwd"],
+						"authTime": "2023-08-01T00:00:00Z",
+						"idleExpiration": 3600000000000,
+						"expiration": 86400000000000
+					}`),
+				), user.HumanRefreshTokenAddedEventMapper),
+			},
+			reduce: (&refreshTokenProjection{}).reduceRefreshTokenAdded,
+			want: wantReduce{
+				aggregateType:    user.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.refresh_tokens (id, creation_date, change_date, sequence, resource_owner, instance_id, token, user_id, client_id, user_agent_id, audience, scopes, amr, auth_time, idle_expiration, expiration) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)",
+							expectedArgs: []interface{}{
+								"token-id",
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								"ro-id",
+								"instance-id",
+								"token-id",
+								"agg-id",
+								"client-id",
+								"agent-id",
+								database.StringArray{"aud"},
+								database.StringArray{"openid", "offline_access"},
+								database.StringArray{"pwd"},
+								anyArg{},
+								anyArg{},
+								anyArg{},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceRefreshTokenRenewed",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(user.HumanRefreshTokenRenewedType),
+					user.AggregateType,
+					[]byte(`{
+						"tokenId": "token-id",
+						"refreshToken": "renewed",
+						"idleExpiration": 3600000000000
+					}`),
+				), user.HumanRefreshTokenRenewedEventEventMapper),
+			},
+			reduce: (&refreshTokenProjection{}).reduceRefreshTokenRenewed,
+			want: wantReduce{
+				aggregateType:    user.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.refresh_tokens SET (change_date, sequence, token, idle_expiration) = ($1, $2, $3, $4) WHERE (instance_id = $5) AND (id = $6)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								"renewed",
+								anyArg{},
+								"instance-id",
+								"token-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceRefreshTokenRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(user.HumanRefreshTokenRemovedType),
+					user.AggregateType,
+					[]byte(`{
+						"tokenId": "token-id"
+					}`),
+				), user.HumanRefreshTokenRemovedEventEventMapper),
+			},
+			reduce: (&refreshTokenProjection{}).reduceRefreshTokenRemoved,
+			want: wantReduce{
+				aggregateType:    user.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.refresh_tokens WHERE (instance_id = $1) AND (id = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"token-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceUserTerminated user removed",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(user.UserRemovedType),
+					user.AggregateType,
+					nil,
+				), user.UserRemovedEventMapper),
+			},
+			reduce: (&refreshTokenProjection{}).reduceUserTerminated,
+			want: wantReduce{
+				aggregateType:    user.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.refresh_tokens WHERE (instance_id = $1) AND (user_id = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "org reduceOwnerRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(org.OrgRemovedEventType),
+					org.AggregateType,
+					nil,
+				), org.OrgRemovedEventMapper),
+			},
+			reduce: (&refreshTokenProjection{}).reduceOwnerRemoved,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("org"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.refresh_tokens WHERE (instance_id = $1) AND (resource_owner = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance reduceInstanceRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(instance.InstanceRemovedEventType),
+					instance.AggregateType,
+					nil,
+				), instance.InstanceRemovedEventMapper),
+			},
+			reduce: reduceInstanceRemovedHelper(RefreshTokenColumnInstanceID),
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("instance"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.refresh_tokens WHERE (instance_id = $1)",
+							expectedArgs: []interface{}{
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if _, ok := err.(errors.InvalidArgument); !ok {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, RefreshTokenProjectionTable, tt.want)
+		})
+	}
+}
@@ -11,7 +11,7 @@ import (
 )
 
 const (
-	SMTPConfigProjectionTable = "projections.smtp_configs"
+	SMTPConfigProjectionTable = "projections.smtp_configs1"
 
 	SMTPConfigColumnAggregateID   = "aggregate_id"
 	SMTPConfigColumnCreationDate  = "creation_date"
@@ -25,6 +25,7 @@ const (
 	SMTPConfigColumnSMTPHost      = "host"
 	SMTPConfigColumnSMTPUser      = "username"
 	SMTPConfigColumnSMTPPassword  = "password"
+	SMTPConfigColumnPriority      = "priority"
 )
 
 type smtpConfigProjection struct {
@@ -49,6 +50,7 @@ func newSMTPConfigProjection(ctx context.Context, config crdb.StatementHandlerCo
 			crdb.NewColumn(SMTPConfigColumnSMTPHost, crdb.ColumnTypeText),
 			crdb.NewColumn(SMTPConfigColumnSMTPUser, crdb.ColumnTypeText),
 			crdb.NewColumn(SMTPConfigColumnSMTPPassword, crdb.ColumnTypeJSONB, crdb.Nullable()),
+			crdb.NewColumn(SMTPConfigColumnPriority, crdb.ColumnTypeInt64, crdb.Default(0)),
 		},
 			crdb.NewPrimaryKey(SMTPConfigColumnInstanceID, SMTPConfigColumnAggregateID),
 		),
@@ -103,6 +105,7 @@ func (p *smtpConfigProjection) reduceSMTPConfigAdded(event eventstore.Event) (*h
 			handler.NewCol(SMTPConfigColumnSMTPHost, e.Host),
 			handler.NewCol(SMTPConfigColumnSMTPUser, e.User),
 			handler.NewCol(SMTPConfigColumnSMTPPassword, e.Password),
+			handler.NewCol(SMTPConfigColumnPriority, e.Priority),
 		},
 	), nil
 }
@@ -113,7 +116,7 @@ func (p *smtpConfigProjection) reduceSMTPConfigChanged(event eventstore.Event) (
 		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-wl0wd", "reduce.wrong.event.type %s", instance.SMTPConfigChangedEventType)
 	}
 
-	columns := make([]handler.Column, 0, 7)
+	columns := make([]handler.Column, 0, 8)
 	columns = append(columns, handler.NewCol(SMTPConfigColumnChangeDate, e.CreationDate()),
 		handler.NewCol(SMTPConfigColumnSequence, e.Sequence()))
 	if e.TLS != nil {
@@ -131,6 +134,9 @@ func (p *smtpConfigProjection) reduceSMTPConfigChanged(event eventstore.Event) (
 	if e.User != nil {
 		columns = append(columns, handler.NewCol(SMTPConfigColumnSMTPUser, *e.User))
 	}
+	if e.Priority != nil {
+		columns = append(columns, handler.NewCol(SMTPConfigColumnPriority, *e.Priority))
+	}
 	return crdb.NewUpdateStatement(
 		e,
 		columns,
@@ -31,7 +31,8 @@ func TestSMTPConfigProjection_reduces(t *testing.T) {
 						"senderAddress": "sender",
 						"senderName": "name",
 						"host": "host",
-						"user": "user"
+						"user": "user",
+						"priority": 2
 					}`,
 					),
 				), instance.SMTPConfigChangedEventMapper),
@@ -44,7 +45,7 @@ func TestSMTPConfigProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "UPDATE projections.smtp_configs SET (change_date, sequence, tls, sender_address, sender_name, host, username) = ($1, $2, $3, $4, $5, $6, $7) WHERE (aggregate_id = $8) AND (instance_id = $9)",
+							expectedStmt: "UPDATE projections.smtp_configs1 SET (change_date, sequence, tls, sender_address, sender_name, host, username, priority) = ($1, $2, $3, $4, $5, $6, $7, $8) WHERE (aggregate_id = $9) AND (instance_id = $10)",
 							expectedArgs: []interface{}{
 								anyArg{},
 								uint64(15),
@@ -53,6 +54,7 @@ func TestSMTPConfigProjection_reduces(t *testing.T) {
 								"name",
 								"host",
 								"user",
+								int32(2),
 								"agg-id",
 								"instance-id",
 							},
@@ -77,7 +79,8 @@ func TestSMTPConfigProjection_reduces(t *testing.T) {
 							"cryptoType": 0,
 							"algorithm": "RSA-265",
 							"keyId": "key-id"
-						}
+						},
+						"priority": 1
 					}`),
 				), instance.SMTPConfigAddedEventMapper),
 			},
@@ -89,7 +92,7 @@ func TestSMTPConfigProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "INSERT INTO projections.smtp_configs (aggregate_id, creation_date, change_date, resource_owner, instance_id, sequence, tls, sender_address, sender_name, host, username, password) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)",
+							expectedStmt: "INSERT INTO projections.smtp_configs1 (aggregate_id, creation_date, change_date, resource_owner, instance_id, sequence, tls, sender_address, sender_name, host, username, password, priority) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)",
 							expectedArgs: []interface{}{
 								"agg-id",
 								anyArg{},
@@ -103,6 +106,7 @@ func TestSMTPConfigProjection_reduces(t *testing.T) {
 								"host",
 								"user",
 								anyArg{},
+								int32(1),
 							},
 						},
 					},
@@ -132,7 +136,7 @@ func TestSMTPConfigProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "UPDATE projections.smtp_configs SET (change_date, sequence, password) = ($1, $2, $3) WHERE (aggregate_id = $4) AND (instance_id = $5)",
+							expectedStmt: "UPDATE projections.smtp_configs1 SET (change_date, sequence, password) = ($1, $2, $3) WHERE (aggregate_id = $4) AND (instance_id = $5)",
 							expectedArgs: []interface{}{
 								anyArg{},
 								uint64(15),
@@ -162,7 +166,7 @@ func TestSMTPConfigProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "DELETE FROM projections.smtp_configs WHERE (instance_id = $1)",
+							expectedStmt: "DELETE FROM projections.smtp_configs1 WHERE (instance_id = $1)",
 							expectedArgs: []interface{}{
 								"agg-id",
 							},
@@ -0,0 +1,493 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+const (
+	// TokenProjectionTable contains the access tokens and personal access tokens of the users
+	// it replaces the auth.tokens view, which is kept as long as older versions can run during an upgrade
+	TokenProjectionTable = "projections.tokens"
+	TokenClientTable     = TokenProjectionTable + "_" + tokenClientTableSuffix
+
+	TokenColumnID                    = "id"
+	TokenColumnCreationDate          = "creation_date"
+	TokenColumnChangeDate            = "change_date"
+	TokenColumnSequence              = "sequence"
+	TokenColumnResourceOwner         = "resource_owner"
+	TokenColumnInstanceID            = "instance_id"
+	TokenColumnUserID                = "user_id"
+	TokenColumnApplicationID         = "application_id"
+	TokenColumnUserAgentID           = "user_agent_id"
+	TokenColumnAudience              = "audience"
+	TokenColumnScopes                = "scopes"
+	TokenColumnExpiration            = "expiration"
+	TokenColumnPreferredLanguage     = "preferred_language"
+	TokenColumnRefreshTokenID        = "refresh_token_id"
+	TokenColumnIsPAT                 = "is_pat"
+	TokenColumnConfirmationJWKThumb  = "confirmation_jkt"
+	TokenColumnConfirmationX509Thumb = "confirmation_x5t"
+
+	// the clients of the projects are kept to remove the tokens of deactivated or removed applications and projects,
+	// because the token only knows the client id of the application
+	tokenClientTableSuffix         = "clients"
+	TokenClientColumnInstanceID    = "instance_id"
+	TokenClientColumnAppID         = "app_id"
+	TokenClientColumnProjectID     = "project_id"
+	TokenClientColumnClientID      = "client_id"
+	TokenClientColumnResourceOwner = "resource_owner"
+)
+
+type tokenProjection struct {
+	crdb.StatementHandler
+}
+
+func newTokenProjection(ctx context.Context, config crdb.StatementHandlerConfig) *tokenProjection {
+	p := new(tokenProjection)
+	config.ProjectionName = TokenProjectionTable
+	config.Reducers = p.reducers()
+	config.InitCheck = crdb.NewMultiTableCheck(
+		crdb.NewTable([]*crdb.Column{
+			crdb.NewColumn(TokenColumnID, crdb.ColumnTypeText),
+			crdb.NewColumn(TokenColumnCreationDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(TokenColumnChangeDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(TokenColumnSequence, crdb.ColumnTypeInt64),
+			crdb.NewColumn(TokenColumnResourceOwner, crdb.ColumnTypeText),
+			crdb.NewColumn(TokenColumnInstanceID, crdb.ColumnTypeText),
+			crdb.NewColumn(TokenColumnUserID, crdb.ColumnTypeText),
+			crdb.NewColumn(TokenColumnApplicationID, crdb.ColumnTypeText),
+			crdb.NewColumn(TokenColumnUserAgentID, crdb.ColumnTypeText),
+			crdb.NewColumn(TokenColumnAudience, crdb.ColumnTypeTextArray, crdb.Nullable()),
+			crdb.NewColumn(TokenColumnScopes, crdb.ColumnTypeTextArray, crdb.Nullable()),
+			crdb.NewColumn(TokenColumnExpiration, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(TokenColumnPreferredLanguage, crdb.ColumnTypeText),
+			crdb.NewColumn(TokenColumnRefreshTokenID, crdb.ColumnTypeText),
+			crdb.NewColumn(TokenColumnIsPAT, crdb.ColumnTypeBool, crdb.Default(false)),
+			crdb.NewColumn(TokenColumnConfirmationJWKThumb, crdb.ColumnTypeText, crdb.Default("")),
+			crdb.NewColumn(TokenColumnConfirmationX509Thumb, crdb.ColumnTypeText, crdb.Default("")),
+		},
+			crdb.NewPrimaryKey(TokenColumnInstanceID, TokenColumnID),
+			crdb.WithIndex(crdb.NewIndex("user_agent", []string{TokenColumnUserID, TokenColumnUserAgentID})),
+			crdb.WithIndex(crdb.NewIndex("refresh_token_id", []string{TokenColumnRefreshTokenID})),
+			crdb.WithIndex(crdb.NewIndex("application_id", []string{TokenColumnApplicationID})),
+			crdb.WithIndex(crdb.NewIndex("resource_owner", []string{TokenColumnResourceOwner})),
+		),
+		crdb.NewSuffixedTable([]*crdb.Column{
+			crdb.NewColumn(TokenClientColumnInstanceID, crdb.ColumnTypeText),
+			crdb.NewColumn(TokenClientColumnAppID, crdb.ColumnTypeText),
+			crdb.NewColumn(TokenClientColumnProjectID, crdb.ColumnTypeText),
+			crdb.NewColumn(TokenClientColumnClientID, crdb.ColumnTypeText),
+			crdb.NewColumn(TokenClientColumnResourceOwner, crdb.ColumnTypeText),
+		},
+			crdb.NewPrimaryKey(TokenClientColumnInstanceID, TokenClientColumnAppID),
+			tokenClientTableSuffix,
+			crdb.WithIndex(crdb.NewIndex("project_id", []string{TokenClientColumnProjectID})),
+		),
+	)
+
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	return p
+}
+
+func (p *tokenProjection) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: user.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  user.UserTokenAddedType,
+					Reduce: p.reduceTokenAdded,
+				},
+				{
+					Event:  user.PersonalAccessTokenAddedType,
+					Reduce: p.reducePersonalAccessTokenAdded,
+				},
+				{
+					Event:  user.UserV1ProfileChangedType,
+					Reduce: p.reduceProfileChanged,
+				},
+				{
+					Event:  user.HumanProfileChangedType,
+					Reduce: p.reduceProfileChanged,
+				},
+				{
+					Event:  user.UserV1SignedOutType,
+					Reduce: p.reduceSignedOut,
+				},
+				{
+					Event:  user.HumanSignedOutType,
+					Reduce: p.reduceSignedOut,
+				},
+				{
+					Event:  user.UserLockedType,
+					Reduce: p.reduceUserTerminated,
+				},
+				{
+					Event:  user.UserDeactivatedType,
+					Reduce: p.reduceUserTerminated,
+				},
+				{
+					Event:  user.UserRemovedType,
+					Reduce: p.reduceUserTerminated,
+				},
+				{
+					Event:  user.UserTokenRemovedType,
+					Reduce: p.reduceTokenRemoved,
+				},
+				{
+					Event:  user.PersonalAccessTokenRemovedType,
+					Reduce: p.reduceTokenRemoved,
+				},
+				{
+					Event:  user.HumanRefreshTokenRemovedType,
+					Reduce: p.reduceRefreshTokenRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: project.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  project.OIDCConfigAddedType,
+					Reduce: p.reduceOIDCConfigAdded,
+				},
+				{
+					Event:  project.ApplicationDeactivatedType,
+					Reduce: p.reduceApplicationDeactivated,
+				},
+				{
+					Event:  project.ApplicationRemovedType,
+					Reduce: p.reduceApplicationRemoved,
+				},
+				{
+					Event:  project.ProjectDeactivatedType,
+					Reduce: p.reduceProjectDeactivated,
+				},
+				{
+					Event:  project.ProjectRemovedType,
+					Reduce: p.reduceProjectRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: org.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  org.OrgRemovedEventType,
+					Reduce: p.reduceOwnerRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: p.reduceInstanceRemoved,
+				},
+			},
+		},
+	}
+}
+
+func (p *tokenProjection) reduceTokenAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.UserTokenAddedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Tk3ma", "reduce.wrong.event.type %s", user.UserTokenAddedType)
+	}
+	var jwkThumb, x509Thumb string
+	if e.Confirmation != nil {
+		jwkThumb = e.Confirmation.JWKThumbprint
+		x509Thumb = e.Confirmation.X509Thumbprint
+	}
+	return crdb.NewCreateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(TokenColumnID, e.TokenID),
+			handler.NewCol(TokenColumnCreationDate, e.CreationDate()),
+			handler.NewCol(TokenColumnChangeDate, e.CreationDate()),
+			handler.NewCol(TokenColumnSequence, e.Sequence()),
+			handler.NewCol(TokenColumnResourceOwner, e.Aggregate().ResourceOwner),
+			handler.NewCol(TokenColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCol(TokenColumnUserID, e.Aggregate().ID),
+			handler.NewCol(TokenColumnApplicationID, e.ApplicationID),
+			handler.NewCol(TokenColumnUserAgentID, e.UserAgentID),
+			handler.NewCol(TokenColumnAudience, database.StringArray(e.Audience)),
+			handler.NewCol(TokenColumnScopes, database.StringArray(e.Scopes)),
+			handler.NewCol(TokenColumnExpiration, e.Expiration),
+			handler.NewCol(TokenColumnPreferredLanguage, e.PreferredLanguage),
+			handler.NewCol(TokenColumnRefreshTokenID, e.RefreshTokenID),
+			handler.NewCol(TokenColumnIsPAT, false),
+			handler.NewCol(TokenColumnConfirmationJWKThumb, jwkThumb),
+			handler.NewCol(TokenColumnConfirmationX509Thumb, x509Thumb),
+		},
+	), nil
+}
+
+func (p *tokenProjection) reducePersonalAccessTokenAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.PersonalAccessTokenAddedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Tk7pw", "reduce.wrong.event.type %s", user.PersonalAccessTokenAddedType)
+	}
+	return crdb.NewCreateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(TokenColumnID, e.TokenID),
+			handler.NewCol(TokenColumnCreationDate, e.CreationDate()),
+			handler.NewCol(TokenColumnChangeDate, e.CreationDate()),
+			handler.NewCol(TokenColumnSequence, e.Sequence()),
+			handler.NewCol(TokenColumnResourceOwner, e.Aggregate().ResourceOwner),
+			handler.NewCol(TokenColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCol(TokenColumnUserID, e.Aggregate().ID),
+			handler.NewCol(TokenColumnApplicationID, ""),
+			handler.NewCol(TokenColumnUserAgentID, ""),
+			handler.NewCol(TokenColumnAudience, database.StringArray(nil)),
+			handler.NewCol(TokenColumnScopes, database.StringArray(e.Scopes)),
+			handler.NewCol(TokenColumnExpiration, e.Expiration),
+			handler.NewCol(TokenColumnPreferredLanguage, ""),
+			handler.NewCol(TokenColumnRefreshTokenID, ""),
+			handler.NewCol(TokenColumnIsPAT, true),
+		},
+	), nil
+}
+
+func (p *tokenProjection) reduceProfileChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanProfileChangedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Tk2lq", "reduce.wrong.event.type %v", []eventstore.EventType{user.UserV1ProfileChangedType, user.HumanProfileChangedType})
+	}
+	if e.PreferredLanguage == nil {
+		return crdb.NewNoOpStatement(e), nil
+	}
+	return crdb.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(TokenColumnChangeDate, e.CreationDate()),
+			handler.NewCol(TokenColumnSequence, e.Sequence()),
+			handler.NewCol(TokenColumnPreferredLanguage, e.PreferredLanguage.String()),
+		},
+		[]handler.Condition{
+			handler.NewCond(TokenColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCond(TokenColumnUserID, e.Aggregate().ID),
+		},
+	), nil
+}
+
+func (p *tokenProjection) reduceSignedOut(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanSignedOutEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Tk8vr", "reduce.wrong.event.type %v", []eventstore.EventType{user.UserV1SignedOutType, user.HumanSignedOutType})
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(TokenColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCond(TokenColumnUserID, e.Aggregate().ID),
+			handler.NewCond(TokenColumnUserAgentID, e.UserAgentID),
+		},
+	), nil
+}
+
+func (p *tokenProjection) reduceUserTerminated(event eventstore.Event) (*handler.Statement, error) {
+	switch event.(type) {
+	case *user.UserLockedEvent,
+		*user.UserDeactivatedEvent,
+		*user.UserRemovedEvent:
+		return crdb.NewDeleteStatement(
+			event,
+			[]handler.Condition{
+				handler.NewCond(TokenColumnInstanceID, event.Aggregate().InstanceID),
+				handler.NewCond(TokenColumnUserID, event.Aggregate().ID),
+			},
+		), nil
+	}
+	return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Tk5nc", "reduce.wrong.event.type %v", []eventstore.EventType{user.UserLockedType, user.UserDeactivatedType, user.UserRemovedType})
+}
+
+func (p *tokenProjection) reduceTokenRemoved(event eventstore.Event) (*handler.Statement, error) {
+	var tokenID string
+	switch e := event.(type) {
+	case *user.UserTokenRemovedEvent:
+		tokenID = e.TokenID
+	case *user.PersonalAccessTokenRemovedEvent:
+		tokenID = e.TokenID
+	default:
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Tk4dx", "reduce.wrong.event.type %v", []eventstore.EventType{user.UserTokenRemovedType, user.PersonalAccessTokenRemovedType})
+	}
+	return crdb.NewDeleteStatement(
+		event,
+		[]handler.Condition{
+			handler.NewCond(TokenColumnInstanceID, event.Aggregate().InstanceID),
+			handler.NewCond(TokenColumnID, tokenID),
+		},
+	), nil
+}
+
+func (p *tokenProjection) reduceRefreshTokenRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanRefreshTokenRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Tk6bz", "reduce.wrong.event.type %s", user.HumanRefreshTokenRemovedType)
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(TokenColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCond(TokenColumnRefreshTokenID, e.TokenID),
+		},
+	), nil
+}
+
+func (p *tokenProjection) reduceOIDCConfigAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*project.OIDCConfigAddedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Tk9fe", "reduce.wrong.event.type %s", project.OIDCConfigAddedType)
+	}
+	return crdb.NewCreateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(TokenClientColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCol(TokenClientColumnAppID, e.AppID),
+			handler.NewCol(TokenClientColumnProjectID, e.Aggregate().ID),
+			handler.NewCol(TokenClientColumnClientID, e.ClientID),
+			handler.NewCol(TokenClientColumnResourceOwner, e.Aggregate().ResourceOwner),
+		},
+		crdb.WithTableSuffix(tokenClientTableSuffix),
+	), nil
+}
+
+func (p *tokenProjection) reduceApplicationDeactivated(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*project.ApplicationDeactivatedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Tk1hg", "reduce.wrong.event.type %s", project.ApplicationDeactivatedType)
+	}
+	return crdb.NewMultiStatement(
+		e,
+		deleteClientTokens(TokenClientColumnAppID, e.AppID),
+	), nil
+}
+
+func (p *tokenProjection) reduceApplicationRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*project.ApplicationRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Tk3jy", "reduce.wrong.event.type %s", project.ApplicationRemovedType)
+	}
+	return crdb.NewMultiStatement(
+		e,
+		deleteClientTokens(TokenClientColumnAppID, e.AppID),
+		crdb.AddDeleteStatement(
+			[]handler.Condition{
+				handler.NewCond(TokenClientColumnInstanceID, e.Aggregate().InstanceID),
+				handler.NewCond(TokenClientColumnAppID, e.AppID),
+			},
+			crdb.WithTableSuffix(tokenClientTableSuffix),
+		),
+	), nil
+}
+
+func (p *tokenProjection) reduceProjectDeactivated(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*project.ProjectDeactivatedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Tk7ks", "reduce.wrong.event.type %s", project.ProjectDeactivatedType)
+	}
+	return crdb.NewMultiStatement(
+		e,
+		deleteClientTokens(TokenClientColumnProjectID, e.Aggregate().ID),
+	), nil
+}
+
+func (p *tokenProjection) reduceProjectRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*project.ProjectRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Tk2ou", "reduce.wrong.event.type %s", project.ProjectRemovedType)
+	}
+	return crdb.NewMultiStatement(
+		e,
+		deleteClientTokens(TokenClientColumnProjectID, e.Aggregate().ID),
+		crdb.AddDeleteStatement(
+			[]handler.Condition{
+				handler.NewCond(TokenClientColumnInstanceID, e.Aggregate().InstanceID),
+				handler.NewCond(TokenClientColumnProjectID, e.Aggregate().ID),
+			},
+			crdb.WithTableSuffix(tokenClientTableSuffix),
+		),
+	), nil
+}
+
+// deleteClientTokens deletes the tokens issued to the clients of the application or project
+// the client ids are selected from the clients table of the projection
+func deleteClientTokens(clientColumn, id string) func(eventstore.Event) crdb.Exec {
+	return func(event eventstore.Event) crdb.Exec {
+		return func(ex handler.Executer, projectionName string) error {
+			if projectionName == "" {
+				return handler.ErrNoProjection
+			}
+			_, err := ex.Exec("DELETE FROM "+projectionName+
+				" WHERE ("+TokenColumnInstanceID+" = $1) AND "+TokenColumnApplicationID+" IN ("+
+				"SELECT "+TokenClientColumnClientID+" FROM "+projectionName+"_"+tokenClientTableSuffix+
+				" WHERE ("+TokenClientColumnInstanceID+" = $1) AND ("+clientColumn+" = $2))",
+				event.Aggregate().InstanceID, id,
+			)
+			if err != nil {
+				return errors.ThrowInternal(err, "HANDL-Tk8qa", "exec failed")
+			}
+			return nil
+		}
+	}
+}
+
+func (p *tokenProjection) reduceOwnerRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.OrgRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Tk5rw", "reduce.wrong.event.type %s", org.OrgRemovedEventType)
+	}
+	// deletes all tokens including PATs, which is expected for now
+	// if there is an undo of the org deletion in the future,
+	// we will need to have a look on how to handle the deleted PATs
+	return crdb.NewMultiStatement(
+		e,
+		crdb.AddDeleteStatement(
+			[]handler.Condition{
+				handler.NewCond(TokenColumnInstanceID, e.Aggregate().InstanceID),
+				handler.NewCond(TokenColumnResourceOwner, e.Aggregate().ID),
+			},
+		),
+		crdb.AddDeleteStatement(
+			[]handler.Condition{
+				handler.NewCond(TokenClientColumnInstanceID, e.Aggregate().InstanceID),
+				handler.NewCond(TokenClientColumnResourceOwner, e.Aggregate().ID),
+			},
+			crdb.WithTableSuffix(tokenClientTableSuffix),
+		),
+	), nil
+}
+
+func (p *tokenProjection) reduceInstanceRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*instance.InstanceRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Tk6ty", "reduce.wrong.event.type %s", instance.InstanceRemovedEventType)
+	}
+	return crdb.NewMultiStatement(
+		e,
+		crdb.AddDeleteStatement(
+			[]handler.Condition{
+				handler.NewCond(TokenColumnInstanceID, e.Aggregate().ID),
+			},
+		),
+		crdb.AddDeleteStatement(
+			[]handler.Condition{
+				handler.NewCond(TokenClientColumnInstanceID, e.Aggregate().ID),
+			},
+			crdb.WithTableSuffix(tokenClientTableSuffix),
+		),
+	), nil
+}
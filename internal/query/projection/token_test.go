@@ -0,0 +1,530 @@
+package projection
+
+import (
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+func TestTokenProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "reduceTokenAdded",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(user.UserTokenAddedType),
+					user.AggregateType,
+					[]byte(`{
+						"tokenId": "token-id",
+						"applicationId": "client-id",
+						"userAgentId": "agent-id",
+						"refreshTokenID": "refresh-token-id",
+						"audience": ["aud"],
+						"scopes": ["openid"],
+						"expiration": "2023-08-01T00:00:00Z",
+						"preferredLanguage": "en",
+						"confirmation": {"jkt": "jwk-thumb"}
+					}`),
+				), user.UserTokenAddedEventMapper),
+			},
+			reduce: (&tokenProjection{}).reduceTokenAdded,
+			want: wantReduce{
+				aggregateType:    user.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.tokens (id, creation_date, change_date, sequence, resource_owner, instance_id, user_id, application_id, user_agent_id, audience, scopes, expiration, preferred_language, refresh_token_id, is_pat, confirmation_jkt, confirmation_x5t) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)",
+							expectedArgs: []interface{}{
+								"token-id",
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								"ro-id",
+								"instance-id",
+								"agg-id",
+								"client-id",
+								"agent-id",
+								database.StringArray{"aud"},
+								database.StringArray{"openid"},
+								anyArg{},
+								"en",
+								"refresh-token-id",
+								false,
+								"jwk-thumb",
+								"",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reducePersonalAccessTokenAdded",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(user.PersonalAccessTokenAddedType),
+					user.AggregateType,
+					[]byte(`{
+						"tokenId": "token-id",
+						"scopes": ["openid"],
+						"expiration": "2023-08-01T00:00:00Z"
+					}`),
+				), user.PersonalAccessTokenAddedEventMapper),
+			},
+			reduce: (&tokenProjection{}).reducePersonalAccessTokenAdded,
+			want: wantReduce{
+				aggregateType:    user.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.tokens (id, creation_date, change_date, sequence, resource_owner, instance_id, user_id, application_id, user_agent_id, audience, scopes, expiration, preferred_language, refresh_token_id, is_pat) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)",
+							expectedArgs: []interface{}{
+								"token-id",
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								"ro-id",
+								"instance-id",
+								"agg-id",
+								"",
+								"",
+								database.StringArray(nil),
+								database.StringArray{"openid"},
+								anyArg{},
+								"",
+								"",
+								true,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceProfileChanged preferred language",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(user.HumanProfileChangedType),
+					user.AggregateType,
+					[]byte(`{
+						"preferredLanguage": "de"
+					}`),
+				), user.HumanProfileChangedEventMapper),
+			},
+			reduce: (&tokenProjection{}).reduceProfileChanged,
+			want: wantReduce{
+				aggregateType:    user.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.tokens SET (change_date, sequence, preferred_language) = ($1, $2, $3) WHERE (instance_id = $4) AND (user_id = $5)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								"de",
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceProfileChanged without preferred language",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(user.HumanProfileChangedType),
+					user.AggregateType,
+					[]byte(`{
+						"firstName": "first"
+					}`),
+				), user.HumanProfileChangedEventMapper),
+			},
+			reduce: (&tokenProjection{}).reduceProfileChanged,
+			want: wantReduce{
+				aggregateType:    user.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{},
+				},
+			},
+		},
+		{
+			name: "reduceSignedOut",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(user.HumanSignedOutType),
+					user.AggregateType,
+					[]byte(`{
+						"userAgentID": "agent-id"
+					}`),
+				), user.HumanSignedOutEventMapper),
+			},
+			reduce: (&tokenProjection{}).reduceSignedOut,
+			want: wantReduce{
+				aggregateType:    user.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.tokens WHERE (instance_id = $1) AND (user_id = $2) AND (user_agent_id = $3)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+								"agent-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceUserTerminated user locked",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(user.UserLockedType),
+					user.AggregateType,
+					nil,
+				), user.UserLockedEventMapper),
+			},
+			reduce: (&tokenProjection{}).reduceUserTerminated,
+			want: wantReduce{
+				aggregateType:    user.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.tokens WHERE (instance_id = $1) AND (user_id = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceTokenRemoved personal access token",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(user.PersonalAccessTokenRemovedType),
+					user.AggregateType,
+					[]byte(`{
+						"tokenId": "token-id"
+					}`),
+				), user.PersonalAccessTokenRemovedEventMapper),
+			},
+			reduce: (&tokenProjection{}).reduceTokenRemoved,
+			want: wantReduce{
+				aggregateType:    user.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.tokens WHERE (instance_id = $1) AND (id = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"token-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceRefreshTokenRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(user.HumanRefreshTokenRemovedType),
+					user.AggregateType,
+					[]byte(`{
+						"tokenId": "refresh-token-id"
+					}`),
+				), user.HumanRefreshTokenRemovedEventEventMapper),
+			},
+			reduce: (&tokenProjection{}).reduceRefreshTokenRemoved,
+			want: wantReduce{
+				aggregateType:    user.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.tokens WHERE (instance_id = $1) AND (refresh_token_id = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"refresh-token-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceOIDCConfigAdded",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(project.OIDCConfigAddedType),
+					project.AggregateType,
+					[]byte(`{
+						"appId": "app-id",
+						"clientId": "client-id"
+					}`),
+				), project.OIDCConfigAddedEventMapper),
+			},
+			reduce: (&tokenProjection{}).reduceOIDCConfigAdded,
+			want: wantReduce{
+				aggregateType:    project.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.tokens_clients (instance_id, app_id, project_id, client_id, resource_owner) VALUES ($1, $2, $3, $4, $5)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"app-id",
+								"agg-id",
+								"client-id",
+								"ro-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceApplicationDeactivated",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(project.ApplicationDeactivatedType),
+					project.AggregateType,
+					[]byte(`{
+						"appId": "app-id"
+					}`),
+				), project.ApplicationDeactivatedEventMapper),
+			},
+			reduce: (&tokenProjection{}).reduceApplicationDeactivated,
+			want: wantReduce{
+				aggregateType:    project.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.tokens WHERE (instance_id = $1) AND application_id IN (SELECT client_id FROM projections.tokens_clients WHERE (instance_id = $1) AND (app_id = $2))",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"app-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceApplicationRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(project.ApplicationRemovedType),
+					project.AggregateType,
+					[]byte(`{
+						"appId": "app-id"
+					}`),
+				), project.ApplicationRemovedEventMapper),
+			},
+			reduce: (&tokenProjection{}).reduceApplicationRemoved,
+			want: wantReduce{
+				aggregateType:    project.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.tokens WHERE (instance_id = $1) AND application_id IN (SELECT client_id FROM projections.tokens_clients WHERE (instance_id = $1) AND (app_id = $2))",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"app-id",
+							},
+						},
+						{
+							expectedStmt: "DELETE FROM projections.tokens_clients WHERE (instance_id = $1) AND (app_id = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"app-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceProjectDeactivated",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(project.ProjectDeactivatedType),
+					project.AggregateType,
+					nil,
+				), project.ProjectDeactivatedEventMapper),
+			},
+			reduce: (&tokenProjection{}).reduceProjectDeactivated,
+			want: wantReduce{
+				aggregateType:    project.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.tokens WHERE (instance_id = $1) AND application_id IN (SELECT client_id FROM projections.tokens_clients WHERE (instance_id = $1) AND (project_id = $2))",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceProjectRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(project.ProjectRemovedType),
+					project.AggregateType,
+					[]byte(`{"name": "name"}`),
+				), project.ProjectRemovedEventMapper),
+			},
+			reduce: (&tokenProjection{}).reduceProjectRemoved,
+			want: wantReduce{
+				aggregateType:    project.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.tokens WHERE (instance_id = $1) AND application_id IN (SELECT client_id FROM projections.tokens_clients WHERE (instance_id = $1) AND (project_id = $2))",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+							},
+						},
+						{
+							expectedStmt: "DELETE FROM projections.tokens_clients WHERE (instance_id = $1) AND (project_id = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "org reduceOwnerRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(org.OrgRemovedEventType),
+					org.AggregateType,
+					nil,
+				), org.OrgRemovedEventMapper),
+			},
+			reduce: (&tokenProjection{}).reduceOwnerRemoved,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("org"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.tokens WHERE (instance_id = $1) AND (resource_owner = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+							},
+						},
+						{
+							expectedStmt: "DELETE FROM projections.tokens_clients WHERE (instance_id = $1) AND (resource_owner = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance reduceInstanceRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(instance.InstanceRemovedEventType),
+					instance.AggregateType,
+					nil,
+				), instance.InstanceRemovedEventMapper),
+			},
+			reduce: (&tokenProjection{}).reduceInstanceRemoved,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("instance"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.tokens WHERE (instance_id = $1)",
+							expectedArgs: []interface{}{
+								"agg-id",
+							},
+						},
+						{
+							expectedStmt: "DELETE FROM projections.tokens_clients WHERE (instance_id = $1)",
+							expectedArgs: []interface{}{
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if _, ok := err.(errors.InvalidArgument); !ok {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, TokenProjectionTable, tt.want)
+		})
+	}
+}
@@ -97,6 +97,7 @@ func newUserProjection(ctx context.Context, config crdb.StatementHandlerConfig)
 			crdb.WithIndex(crdb.NewIndex("username", []string{UserUsernameCol})),
 			crdb.WithIndex(crdb.NewIndex("resource_owner", []string{UserResourceOwnerCol})),
 			crdb.WithIndex(crdb.NewIndex("owner_removed", []string{UserOwnerRemovedCol})),
+			crdb.WithIndex(crdb.NewIndex("username_trgm", []string{UserUsernameCol}, crdb.Trigram())),
 		),
 		crdb.NewSuffixedTable([]*crdb.Column{
 			crdb.NewColumn(HumanUserIDCol, crdb.ColumnTypeText),
@@ -116,6 +117,12 @@ func newUserProjection(ctx context.Context, config crdb.StatementHandlerConfig)
 			crdb.NewPrimaryKey(HumanUserInstanceIDCol, HumanUserIDCol),
 			UserHumanSuffix,
 			crdb.WithForeignKey(crdb.NewForeignKeyOfPublicKeys()),
+			crdb.WithIndex(crdb.NewIndex("first_name_trgm", []string{HumanFirstNameCol}, crdb.Trigram())),
+			crdb.WithIndex(crdb.NewIndex("last_name_trgm", []string{HumanLastNameCol}, crdb.Trigram())),
+			crdb.WithIndex(crdb.NewIndex("nick_name_trgm", []string{HumanNickNameCol}, crdb.Trigram())),
+			crdb.WithIndex(crdb.NewIndex("display_name_trgm", []string{HumanDisplayNameCol}, crdb.Trigram())),
+			crdb.WithIndex(crdb.NewIndex("email_trgm", []string{HumanEmailCol}, crdb.Trigram())),
+			crdb.WithIndex(crdb.NewIndex("phone_trgm", []string{HumanPhoneCol}, crdb.Trigram())),
 		),
 		crdb.NewSuffixedTable([]*crdb.Column{
 			crdb.NewColumn(MachineUserIDCol, crdb.ColumnTypeText),
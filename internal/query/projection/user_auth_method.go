@@ -77,6 +77,14 @@ func (p *userAuthMethodProjection) reducers() []handler.AggregateReducer {
 					Event:  user.HumanMFAOTPAddedType,
 					Reduce: p.reduceInitAuthMethod,
 				},
+				{
+					Event:  user.HumanMFAOTPSMSAddedType,
+					Reduce: p.reduceInitAuthMethod,
+				},
+				{
+					Event:  user.HumanMFAOTPEmailAddedType,
+					Reduce: p.reduceInitAuthMethod,
+				},
 				{
 					Event:  user.HumanPasswordlessTokenVerifiedType,
 					Reduce: p.reduceActivateEvent,
@@ -101,6 +109,14 @@ func (p *userAuthMethodProjection) reducers() []handler.AggregateReducer {
 					Event:  user.HumanMFAOTPRemovedType,
 					Reduce: p.reduceRemoveAuthMethod,
 				},
+				{
+					Event:  user.HumanMFAOTPSMSRemovedType,
+					Reduce: p.reduceRemoveAuthMethod,
+				},
+				{
+					Event:  user.HumanMFAOTPEmailRemovedType,
+					Reduce: p.reduceRemoveAuthMethod,
+				},
 			},
 		},
 		{
@@ -126,6 +142,7 @@ func (p *userAuthMethodProjection) reducers() []handler.AggregateReducer {
 
 func (p *userAuthMethodProjection) reduceInitAuthMethod(event eventstore.Event) (*handler.Statement, error) {
 	tokenID := ""
+	state := domain.MFAStateNotReady
 	var methodType domain.UserAuthMethodType
 	switch e := event.(type) {
 	case *user.HumanPasswordlessAddedEvent:
@@ -136,6 +153,13 @@ func (p *userAuthMethodProjection) reduceInitAuthMethod(event eventstore.Event)
 		tokenID = e.WebAuthNTokenID
 	case *user.HumanOTPAddedEvent:
 		methodType = domain.UserAuthMethodTypeOTP
+	case *user.HumanOTPSMSAddedEvent:
+		// code based factors need no setup verification
+		methodType = domain.UserAuthMethodTypeOTPSMS
+		state = domain.MFAStateReady
+	case *user.HumanOTPEmailAddedEvent:
+		methodType = domain.UserAuthMethodTypeOTPEmail
+		state = domain.MFAStateReady
 	default:
 		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-f92f", "reduce.wrong.event.type %v", []eventstore.EventType{user.HumanPasswordlessTokenAddedType, user.HumanU2FTokenAddedType})
 	}
@@ -156,7 +180,7 @@ func (p *userAuthMethodProjection) reduceInitAuthMethod(event eventstore.Event)
 			handler.NewCol(UserAuthMethodInstanceIDCol, event.Aggregate().InstanceID),
 			handler.NewCol(UserAuthMethodUserIDCol, event.Aggregate().ID),
 			handler.NewCol(UserAuthMethodSequenceCol, event.Sequence()),
-			handler.NewCol(UserAuthMethodStateCol, domain.MFAStateNotReady),
+			handler.NewCol(UserAuthMethodStateCol, state),
 			handler.NewCol(UserAuthMethodTypeCol, methodType),
 			handler.NewCol(UserAuthMethodNameCol, ""),
 		},
@@ -214,6 +238,10 @@ func (p *userAuthMethodProjection) reduceRemoveAuthMethod(event eventstore.Event
 		tokenID = e.WebAuthNTokenID
 	case *user.HumanOTPRemovedEvent:
 		methodType = domain.UserAuthMethodTypeOTP
+	case *user.HumanOTPSMSRemovedEvent:
+		methodType = domain.UserAuthMethodTypeOTPSMS
+	case *user.HumanOTPEmailRemovedEvent:
+		methodType = domain.UserAuthMethodTypeOTPEmail
 
 	default:
 		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-f92f", "reduce.wrong.event.type %v", []eventstore.EventType{user.HumanPasswordlessTokenAddedType, user.HumanU2FTokenAddedType})
@@ -0,0 +1,178 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+const (
+	UserConsentProjectionTable = "projections.user_consents"
+
+	UserConsentColumnUserID        = "user_id"
+	UserConsentColumnClientID      = "client_id"
+	UserConsentColumnProjectID     = "project_id"
+	UserConsentColumnCreationDate  = "creation_date"
+	UserConsentColumnChangeDate    = "change_date"
+	UserConsentColumnSequence      = "sequence"
+	UserConsentColumnResourceOwner = "resource_owner"
+	UserConsentColumnInstanceID    = "instance_id"
+	UserConsentColumnScopes        = "scopes"
+	UserConsentColumnRoles         = "roles"
+	UserConsentColumnOwnerRemoved  = "owner_removed"
+)
+
+type userConsentProjection struct {
+	crdb.StatementHandler
+}
+
+func newUserConsentProjection(ctx context.Context, config crdb.StatementHandlerConfig) *userConsentProjection {
+	p := new(userConsentProjection)
+	config.ProjectionName = UserConsentProjectionTable
+	config.Reducers = p.reducers()
+	config.InitCheck = crdb.NewTableCheck(
+		crdb.NewTable([]*crdb.Column{
+			crdb.NewColumn(UserConsentColumnUserID, crdb.ColumnTypeText),
+			crdb.NewColumn(UserConsentColumnClientID, crdb.ColumnTypeText),
+			crdb.NewColumn(UserConsentColumnProjectID, crdb.ColumnTypeText),
+			crdb.NewColumn(UserConsentColumnCreationDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(UserConsentColumnChangeDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(UserConsentColumnSequence, crdb.ColumnTypeInt64),
+			crdb.NewColumn(UserConsentColumnResourceOwner, crdb.ColumnTypeText),
+			crdb.NewColumn(UserConsentColumnInstanceID, crdb.ColumnTypeText),
+			crdb.NewColumn(UserConsentColumnScopes, crdb.ColumnTypeTextArray, crdb.Nullable()),
+			crdb.NewColumn(UserConsentColumnRoles, crdb.ColumnTypeTextArray, crdb.Nullable()),
+			crdb.NewColumn(UserConsentColumnOwnerRemoved, crdb.ColumnTypeBool, crdb.Default(false)),
+		},
+			crdb.NewPrimaryKey(UserConsentColumnInstanceID, UserConsentColumnUserID, UserConsentColumnClientID),
+			crdb.WithIndex(crdb.NewIndex("resource_owner", []string{UserConsentColumnResourceOwner})),
+			crdb.WithIndex(crdb.NewIndex("owner_removed", []string{UserConsentColumnOwnerRemoved})),
+		),
+	)
+
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	return p
+}
+
+func (p *userConsentProjection) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: user.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  user.HumanConsentGrantedType,
+					Reduce: p.reduceConsentGranted,
+				},
+				{
+					Event:  user.HumanConsentRevokedType,
+					Reduce: p.reduceConsentRevoked,
+				},
+				{
+					Event:  user.UserRemovedType,
+					Reduce: p.reduceUserRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: org.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  org.OrgRemovedEventType,
+					Reduce: p.reduceOwnerRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(UserConsentColumnInstanceID),
+				},
+			},
+		},
+	}
+}
+
+func (p *userConsentProjection) reduceConsentGranted(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanConsentGrantedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Kf8sa", "reduce.wrong.event.type %s", user.HumanConsentGrantedType)
+	}
+	return crdb.NewUpsertStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(UserConsentColumnInstanceID, nil),
+			handler.NewCol(UserConsentColumnUserID, nil),
+			handler.NewCol(UserConsentColumnClientID, nil),
+		},
+		[]handler.Column{
+			handler.NewCol(UserConsentColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCol(UserConsentColumnUserID, e.Aggregate().ID),
+			handler.NewCol(UserConsentColumnClientID, e.ClientID),
+			handler.NewCol(UserConsentColumnProjectID, e.ProjectID),
+			handler.NewCol(UserConsentColumnResourceOwner, e.Aggregate().ResourceOwner),
+			handler.NewCol(UserConsentColumnCreationDate, e.CreationDate()),
+			handler.NewCol(UserConsentColumnChangeDate, e.CreationDate()),
+			handler.NewCol(UserConsentColumnSequence, e.Sequence()),
+			handler.NewCol(UserConsentColumnScopes, database.StringArray(e.Scopes)),
+			handler.NewCol(UserConsentColumnRoles, database.StringArray(e.Roles)),
+		},
+	), nil
+}
+
+func (p *userConsentProjection) reduceConsentRevoked(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanConsentRevokedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Ud3qn", "reduce.wrong.event.type %s", user.HumanConsentRevokedType)
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(UserConsentColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCond(UserConsentColumnUserID, e.Aggregate().ID),
+			handler.NewCond(UserConsentColumnClientID, e.ClientID),
+		},
+	), nil
+}
+
+func (p *userConsentProjection) reduceUserRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.UserRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Zm5wd", "reduce.wrong.event.type %s", user.UserRemovedType)
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(UserConsentColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCond(UserConsentColumnUserID, e.Aggregate().ID),
+		},
+	), nil
+}
+
+func (p *userConsentProjection) reduceOwnerRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.OrgRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Xq2vb", "reduce.wrong.event.type %s", org.OrgRemovedEventType)
+	}
+
+	return crdb.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(UserConsentColumnChangeDate, e.CreationDate()),
+			handler.NewCol(UserConsentColumnSequence, e.Sequence()),
+			handler.NewCol(UserConsentColumnOwnerRemoved, true),
+		},
+		[]handler.Condition{
+			handler.NewCond(UserConsentColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCond(UserConsentColumnResourceOwner, e.Aggregate().ID),
+		},
+	), nil
+}
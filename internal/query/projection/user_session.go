@@ -0,0 +1,511 @@
+package projection
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+const (
+	// UserSessionProjectionTable contains the sessions of the users on their user agents (login ui)
+	// it replaces the auth.user_sessions view, which is kept as long as older versions can run during an upgrade
+	UserSessionProjectionTable = "projections.user_sessions"
+
+	UserSessionColumnCreationDate                 = "creation_date"
+	UserSessionColumnChangeDate                   = "change_date"
+	UserSessionColumnSequence                     = "sequence"
+	UserSessionColumnResourceOwner                = "resource_owner"
+	UserSessionColumnInstanceID                   = "instance_id"
+	UserSessionColumnState                        = "state"
+	UserSessionColumnUserAgentID                  = "user_agent_id"
+	UserSessionColumnUserID                       = "user_id"
+	UserSessionColumnUserAgent                    = "user_agent"
+	UserSessionColumnRemoteIP                     = "remote_ip"
+	UserSessionColumnSelectedIDPConfigID          = "selected_idp_config_id"
+	UserSessionColumnPasswordVerification         = "password_verification"
+	UserSessionColumnPasswordlessVerification     = "passwordless_verification"
+	UserSessionColumnExternalLoginVerification    = "external_login_verification"
+	UserSessionColumnSecondFactorVerification     = "second_factor_verification"
+	UserSessionColumnSecondFactorVerificationType = "second_factor_verification_type"
+	UserSessionColumnMultiFactorVerification      = "multi_factor_verification"
+	UserSessionColumnMultiFactorVerificationType  = "multi_factor_verification_type"
+)
+
+type userSessionProjection struct {
+	crdb.StatementHandler
+}
+
+func newUserSessionProjection(ctx context.Context, config crdb.StatementHandlerConfig) *userSessionProjection {
+	p := new(userSessionProjection)
+	config.ProjectionName = UserSessionProjectionTable
+	config.Reducers = p.reducers()
+	config.InitCheck = crdb.NewTableCheck(
+		crdb.NewTable([]*crdb.Column{
+			crdb.NewColumn(UserSessionColumnCreationDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(UserSessionColumnChangeDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(UserSessionColumnSequence, crdb.ColumnTypeInt64),
+			crdb.NewColumn(UserSessionColumnResourceOwner, crdb.ColumnTypeText),
+			crdb.NewColumn(UserSessionColumnInstanceID, crdb.ColumnTypeText),
+			crdb.NewColumn(UserSessionColumnState, crdb.ColumnTypeEnum),
+			crdb.NewColumn(UserSessionColumnUserAgentID, crdb.ColumnTypeText),
+			crdb.NewColumn(UserSessionColumnUserID, crdb.ColumnTypeText),
+			crdb.NewColumn(UserSessionColumnUserAgent, crdb.ColumnTypeText),
+			crdb.NewColumn(UserSessionColumnRemoteIP, crdb.ColumnTypeText),
+			crdb.NewColumn(UserSessionColumnSelectedIDPConfigID, crdb.ColumnTypeText),
+			crdb.NewColumn(UserSessionColumnPasswordVerification, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(UserSessionColumnPasswordlessVerification, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(UserSessionColumnExternalLoginVerification, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(UserSessionColumnSecondFactorVerification, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(UserSessionColumnSecondFactorVerificationType, crdb.ColumnTypeEnum),
+			crdb.NewColumn(UserSessionColumnMultiFactorVerification, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(UserSessionColumnMultiFactorVerificationType, crdb.ColumnTypeEnum),
+		},
+			crdb.NewPrimaryKey(UserSessionColumnInstanceID, UserSessionColumnUserAgentID, UserSessionColumnUserID),
+			crdb.WithIndex(crdb.NewIndex("user_id", []string{UserSessionColumnUserID})),
+			crdb.WithIndex(crdb.NewIndex("resource_owner", []string{UserSessionColumnResourceOwner})),
+		),
+	)
+
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	return p
+}
+
+func (p *userSessionProjection) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: user.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  user.UserV1PasswordCheckSucceededType,
+					Reduce: p.reduceSessionChecked,
+				},
+				{
+					Event:  user.UserV1PasswordCheckFailedType,
+					Reduce: p.reduceSessionChecked,
+				},
+				{
+					Event:  user.UserV1MFAOTPCheckSucceededType,
+					Reduce: p.reduceSessionChecked,
+				},
+				{
+					Event:  user.UserV1MFAOTPCheckFailedType,
+					Reduce: p.reduceSessionChecked,
+				},
+				{
+					Event:  user.UserV1SignedOutType,
+					Reduce: p.reduceSessionChecked,
+				},
+				{
+					Event:  user.HumanPasswordCheckSucceededType,
+					Reduce: p.reduceSessionChecked,
+				},
+				{
+					Event:  user.HumanPasswordCheckFailedType,
+					Reduce: p.reduceSessionChecked,
+				},
+				{
+					Event:  user.UserIDPLoginCheckSucceededType,
+					Reduce: p.reduceSessionChecked,
+				},
+				{
+					Event:  user.HumanMFAOTPCheckSucceededType,
+					Reduce: p.reduceSessionChecked,
+				},
+				{
+					Event:  user.HumanMFAOTPCheckFailedType,
+					Reduce: p.reduceSessionChecked,
+				},
+				{
+					Event:  user.HumanU2FTokenCheckSucceededType,
+					Reduce: p.reduceSessionChecked,
+				},
+				{
+					Event:  user.HumanU2FTokenCheckFailedType,
+					Reduce: p.reduceSessionChecked,
+				},
+				{
+					Event:  user.HumanPasswordlessTokenCheckSucceededType,
+					Reduce: p.reduceSessionChecked,
+				},
+				{
+					Event:  user.HumanPasswordlessTokenCheckFailedType,
+					Reduce: p.reduceSessionChecked,
+				},
+				{
+					Event:  user.HumanMFAOTPSMSCheckSucceededType,
+					Reduce: p.reduceSessionChecked,
+				},
+				{
+					Event:  user.HumanMFAOTPSMSCheckFailedType,
+					Reduce: p.reduceSessionChecked,
+				},
+				{
+					Event:  user.HumanMFAOTPEmailCheckSucceededType,
+					Reduce: p.reduceSessionChecked,
+				},
+				{
+					Event:  user.HumanMFAOTPEmailCheckFailedType,
+					Reduce: p.reduceSessionChecked,
+				},
+				{
+					Event:  user.HumanMFARecoveryCodeCheckSucceededType,
+					Reduce: p.reduceSessionChecked,
+				},
+				{
+					Event:  user.HumanMFARecoveryCodeCheckFailedType,
+					Reduce: p.reduceSessionChecked,
+				},
+				{
+					Event:  user.HumanSignedOutType,
+					Reduce: p.reduceSessionChecked,
+				},
+				{
+					Event:  user.UserV1PasswordChangedType,
+					Reduce: p.reducePasswordChanged,
+				},
+				{
+					Event:  user.HumanPasswordChangedType,
+					Reduce: p.reducePasswordChanged,
+				},
+				{
+					Event:  user.UserV1MFAOTPRemovedType,
+					Reduce: p.reduceSecondFactorRemoved,
+				},
+				{
+					Event:  user.HumanMFAOTPRemovedType,
+					Reduce: p.reduceSecondFactorRemoved,
+				},
+				{
+					Event:  user.HumanU2FTokenRemovedType,
+					Reduce: p.reduceSecondFactorRemoved,
+				},
+				{
+					Event:  user.HumanMFAOTPSMSRemovedType,
+					Reduce: p.reduceSecondFactorRemoved,
+				},
+				{
+					Event:  user.HumanMFAOTPEmailRemovedType,
+					Reduce: p.reduceSecondFactorRemoved,
+				},
+				{
+					Event:  user.HumanPasswordlessTokenRemovedType,
+					Reduce: p.reducePasswordlessRemoved,
+				},
+				{
+					Event:  user.UserIDPLinkRemovedType,
+					Reduce: p.reduceIDPLinkRemoved,
+				},
+				{
+					Event:  user.UserIDPLinkCascadeRemovedType,
+					Reduce: p.reduceIDPLinkRemoved,
+				},
+				{
+					Event:  user.UserLockedType,
+					Reduce: p.reduceUserTerminated,
+				},
+				{
+					Event:  user.UserDeactivatedType,
+					Reduce: p.reduceUserTerminated,
+				},
+				{
+					Event:  user.UserRemovedType,
+					Reduce: p.reduceUserRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: org.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  org.OrgRemovedEventType,
+					Reduce: p.reduceOwnerRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(UserSessionColumnInstanceID),
+				},
+			},
+		},
+	}
+}
+
+// userSessionCheck is the payload of the events checking a session of a user agent
+type userSessionCheck struct {
+	UserAgentID         string `json:"userAgentID"`
+	SelectedIDPConfigID string `json:"selectedIDPConfigID"`
+	UserAgent           string `json:"userAgent"`
+	RemoteIP            net.IP `json:"remoteIP"`
+}
+
+func (p *userSessionProjection) reduceSessionChecked(event eventstore.Event) (*handler.Statement, error) {
+	check := new(userSessionCheck)
+	if data := event.DataAsBytes(); len(data) > 0 {
+		if err := json.Unmarshal(data, check); err != nil {
+			return nil, errors.ThrowInvalidArgument(err, "HANDL-Ro5ve", "reduce.wrong.event.data")
+		}
+	}
+	cols, err := userSessionCheckCols(event, check)
+	if err != nil {
+		return nil, err
+	}
+	if check.UserAgentID == "" {
+		return crdb.NewNoOpStatement(event), nil
+	}
+	return crdb.NewMultiStatement(
+		event,
+		crdb.AddCreateIfNotExistsStatement(
+			[]handler.Column{
+				handler.NewCol(UserSessionColumnInstanceID, nil),
+				handler.NewCol(UserSessionColumnUserAgentID, nil),
+				handler.NewCol(UserSessionColumnUserID, nil),
+			},
+			[]handler.Column{
+				handler.NewCol(UserSessionColumnCreationDate, event.CreationDate()),
+				handler.NewCol(UserSessionColumnChangeDate, event.CreationDate()),
+				handler.NewCol(UserSessionColumnSequence, event.Sequence()),
+				handler.NewCol(UserSessionColumnResourceOwner, event.Aggregate().ResourceOwner),
+				handler.NewCol(UserSessionColumnInstanceID, event.Aggregate().InstanceID),
+				handler.NewCol(UserSessionColumnState, domain.UserSessionStateActive),
+				handler.NewCol(UserSessionColumnUserAgentID, check.UserAgentID),
+				handler.NewCol(UserSessionColumnUserID, event.Aggregate().ID),
+				handler.NewCol(UserSessionColumnUserAgent, ""),
+				handler.NewCol(UserSessionColumnRemoteIP, ""),
+				handler.NewCol(UserSessionColumnSelectedIDPConfigID, ""),
+				handler.NewCol(UserSessionColumnPasswordVerification, time.Time{}),
+				handler.NewCol(UserSessionColumnPasswordlessVerification, time.Time{}),
+				handler.NewCol(UserSessionColumnExternalLoginVerification, time.Time{}),
+				handler.NewCol(UserSessionColumnSecondFactorVerification, time.Time{}),
+				handler.NewCol(UserSessionColumnSecondFactorVerificationType, domain.MFALevelNotSetUp),
+				handler.NewCol(UserSessionColumnMultiFactorVerification, time.Time{}),
+				handler.NewCol(UserSessionColumnMultiFactorVerificationType, domain.MFALevelNotSetUp),
+			},
+		),
+		crdb.AddUpdateStatement(
+			append([]handler.Column{
+				handler.NewCol(UserSessionColumnChangeDate, event.CreationDate()),
+				handler.NewCol(UserSessionColumnSequence, event.Sequence()),
+			}, cols...),
+			[]handler.Condition{
+				handler.NewCond(UserSessionColumnInstanceID, event.Aggregate().InstanceID),
+				handler.NewCond(UserSessionColumnUserAgentID, check.UserAgentID),
+				handler.NewCond(UserSessionColumnUserID, event.Aggregate().ID),
+			},
+		),
+	), nil
+}
+
+// userSessionCheckCols returns the columns changed by the check of the session
+func userSessionCheckCols(event eventstore.Event, check *userSessionCheck) ([]handler.Column, error) {
+	switch event.Type() {
+	case user.UserV1PasswordCheckSucceededType,
+		user.HumanPasswordCheckSucceededType:
+		return append(userSessionBrowserInfoCols(check),
+			handler.NewCol(UserSessionColumnPasswordVerification, event.CreationDate()),
+			handler.NewCol(UserSessionColumnState, domain.UserSessionStateActive),
+		), nil
+	case user.UserIDPLoginCheckSucceededType:
+		return append(userSessionBrowserInfoCols(check),
+			handler.NewCol(UserSessionColumnExternalLoginVerification, event.CreationDate()),
+			handler.NewCol(UserSessionColumnSelectedIDPConfigID, check.SelectedIDPConfigID),
+			handler.NewCol(UserSessionColumnState, domain.UserSessionStateActive),
+		), nil
+	case user.HumanPasswordlessTokenCheckSucceededType:
+		return append(userSessionBrowserInfoCols(check),
+			handler.NewCol(UserSessionColumnPasswordlessVerification, event.CreationDate()),
+			handler.NewCol(UserSessionColumnMultiFactorVerification, event.CreationDate()),
+			handler.NewCol(UserSessionColumnMultiFactorVerificationType, domain.MFATypeU2FUserVerification),
+			handler.NewCol(UserSessionColumnState, domain.UserSessionStateActive),
+		), nil
+	case user.HumanPasswordlessTokenCheckFailedType:
+		return []handler.Column{
+			handler.NewCol(UserSessionColumnPasswordlessVerification, time.Time{}),
+			handler.NewCol(UserSessionColumnMultiFactorVerification, time.Time{}),
+		}, nil
+	case user.UserV1PasswordCheckFailedType,
+		user.HumanPasswordCheckFailedType:
+		return []handler.Column{
+			handler.NewCol(UserSessionColumnPasswordVerification, time.Time{}),
+		}, nil
+	case user.UserV1MFAOTPCheckSucceededType,
+		user.HumanMFAOTPCheckSucceededType:
+		return userSessionSecondFactorCols(event, check, domain.MFATypeOTP), nil
+	case user.HumanU2FTokenCheckSucceededType:
+		return userSessionSecondFactorCols(event, check, domain.MFATypeU2F), nil
+	case user.HumanMFAOTPSMSCheckSucceededType:
+		return userSessionSecondFactorCols(event, check, domain.MFATypeOTPSMS), nil
+	case user.HumanMFAOTPEmailCheckSucceededType:
+		return userSessionSecondFactorCols(event, check, domain.MFATypeOTPEmail), nil
+	case user.HumanMFARecoveryCodeCheckSucceededType:
+		return userSessionSecondFactorCols(event, check, domain.MFATypeRecoveryCode), nil
+	case user.UserV1MFAOTPCheckFailedType,
+		user.HumanMFAOTPCheckFailedType,
+		user.HumanU2FTokenCheckFailedType,
+		user.HumanMFAOTPSMSCheckFailedType,
+		user.HumanMFAOTPEmailCheckFailedType,
+		user.HumanMFARecoveryCodeCheckFailedType:
+		return []handler.Column{
+			handler.NewCol(UserSessionColumnSecondFactorVerification, time.Time{}),
+		}, nil
+	case user.UserV1SignedOutType,
+		user.HumanSignedOutType:
+		return userSessionTerminatedCols(), nil
+	}
+	return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Zb5ka", "reduce.wrong.event.type %s", event.Type())
+}
+
+func userSessionSecondFactorCols(event eventstore.Event, check *userSessionCheck, mfaType domain.MFAType) []handler.Column {
+	return append(userSessionBrowserInfoCols(check),
+		handler.NewCol(UserSessionColumnSecondFactorVerification, event.CreationDate()),
+		handler.NewCol(UserSessionColumnSecondFactorVerificationType, mfaType),
+		handler.NewCol(UserSessionColumnState, domain.UserSessionStateActive),
+	)
+}
+
+// userSessionBrowserInfoCols keep the user agent and ip of the last successful check,
+// so the user is able to recognise the device of the session
+func userSessionBrowserInfoCols(check *userSessionCheck) []handler.Column {
+	if check.UserAgent == "" && check.RemoteIP == nil {
+		return nil
+	}
+	cols := []handler.Column{
+		handler.NewCol(UserSessionColumnUserAgent, check.UserAgent),
+	}
+	if check.RemoteIP != nil {
+		cols = append(cols, handler.NewCol(UserSessionColumnRemoteIP, check.RemoteIP.String()))
+	}
+	return cols
+}
+
+func userSessionTerminatedCols() []handler.Column {
+	return []handler.Column{
+		handler.NewCol(UserSessionColumnPasswordlessVerification, time.Time{}),
+		handler.NewCol(UserSessionColumnPasswordVerification, time.Time{}),
+		handler.NewCol(UserSessionColumnSecondFactorVerification, time.Time{}),
+		handler.NewCol(UserSessionColumnSecondFactorVerificationType, domain.MFALevelNotSetUp),
+		handler.NewCol(UserSessionColumnMultiFactorVerification, time.Time{}),
+		handler.NewCol(UserSessionColumnMultiFactorVerificationType, domain.MFALevelNotSetUp),
+		handler.NewCol(UserSessionColumnExternalLoginVerification, time.Time{}),
+		handler.NewCol(UserSessionColumnState, domain.UserSessionStateTerminated),
+	}
+}
+
+// reduceUserSessionsChanged changes the sessions of the user on all user agents
+func reduceUserSessionsChanged(event eventstore.Event, cols ...handler.Column) *handler.Statement {
+	return crdb.NewUpdateStatement(
+		event,
+		append([]handler.Column{
+			handler.NewCol(UserSessionColumnChangeDate, event.CreationDate()),
+			handler.NewCol(UserSessionColumnSequence, event.Sequence()),
+		}, cols...),
+		[]handler.Condition{
+			handler.NewCond(UserSessionColumnInstanceID, event.Aggregate().InstanceID),
+			handler.NewCond(UserSessionColumnUserID, event.Aggregate().ID),
+		},
+	)
+}
+
+func (p *userSessionProjection) reducePasswordChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanPasswordChangedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Ty7wq", "reduce.wrong.event.type %s", user.HumanPasswordChangedType)
+	}
+	// the password verification is only kept on the user agent which changed the password
+	return reduceUserSessionsChanged(e,
+		handler.Column{
+			Name:  UserSessionColumnPasswordVerification,
+			Value: e.UserAgentID,
+			ParameterOpt: func(placeholder string) string {
+				return "CASE WHEN " + UserSessionColumnUserAgentID + " = " + placeholder +
+					" THEN " + UserSessionColumnPasswordVerification + " ELSE '0001-01-01 00:00:00+00'::TIMESTAMPTZ END"
+			},
+		},
+	), nil
+}
+
+func (p *userSessionProjection) reduceSecondFactorRemoved(event eventstore.Event) (*handler.Statement, error) {
+	switch event.(type) {
+	case *user.HumanOTPRemovedEvent,
+		*user.HumanU2FRemovedEvent,
+		*user.HumanOTPSMSRemovedEvent,
+		*user.HumanOTPEmailRemovedEvent:
+		return reduceUserSessionsChanged(event,
+			handler.NewCol(UserSessionColumnSecondFactorVerification, time.Time{}),
+		), nil
+	}
+	return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Pv3sx", "reduce.wrong.event.type %v", []eventstore.EventType{user.HumanMFAOTPRemovedType, user.HumanU2FTokenRemovedType, user.HumanMFAOTPSMSRemovedType, user.HumanMFAOTPEmailRemovedType})
+}
+
+func (p *userSessionProjection) reducePasswordlessRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanPasswordlessRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Bq8mt", "reduce.wrong.event.type %s", user.HumanPasswordlessTokenRemovedType)
+	}
+	return reduceUserSessionsChanged(e,
+		handler.NewCol(UserSessionColumnPasswordlessVerification, time.Time{}),
+		handler.NewCol(UserSessionColumnMultiFactorVerification, time.Time{}),
+	), nil
+}
+
+func (p *userSessionProjection) reduceIDPLinkRemoved(event eventstore.Event) (*handler.Statement, error) {
+	switch event.(type) {
+	case *user.UserIDPLinkRemovedEvent,
+		*user.UserIDPLinkCascadeRemovedEvent:
+		return reduceUserSessionsChanged(event,
+			handler.NewCol(UserSessionColumnExternalLoginVerification, time.Time{}),
+			handler.NewCol(UserSessionColumnSelectedIDPConfigID, ""),
+		), nil
+	}
+	return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Wm2kd", "reduce.wrong.event.type %v", []eventstore.EventType{user.UserIDPLinkRemovedType, user.UserIDPLinkCascadeRemovedType})
+}
+
+func (p *userSessionProjection) reduceUserTerminated(event eventstore.Event) (*handler.Statement, error) {
+	switch event.(type) {
+	case *user.UserLockedEvent,
+		*user.UserDeactivatedEvent:
+		return reduceUserSessionsChanged(event, userSessionTerminatedCols()...), nil
+	}
+	return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Dz4nq", "reduce.wrong.event.type %v", []eventstore.EventType{user.UserLockedType, user.UserDeactivatedType})
+}
+
+func (p *userSessionProjection) reduceUserRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.UserRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Lc6gu", "reduce.wrong.event.type %s", user.UserRemovedType)
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(UserSessionColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCond(UserSessionColumnUserID, e.Aggregate().ID),
+		},
+	), nil
+}
+
+func (p *userSessionProjection) reduceOwnerRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.OrgRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Hy9jf", "reduce.wrong.event.type %s", org.OrgRemovedEventType)
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(UserSessionColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCond(UserSessionColumnResourceOwner, e.Aggregate().ID),
+		},
+	), nil
+}
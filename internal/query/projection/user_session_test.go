@@ -0,0 +1,326 @@
+package projection
+
+import (
+	"testing"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+func TestUserSessionProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "reduceSessionChecked password check succeeded",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(user.HumanPasswordCheckSucceededType),
+					user.AggregateType,
+					[]byte(`{
+						"userAgentID": "agent-id",
+						"userAgent": "browser",
+						"remoteIP": "1.2.3.4"
+					}`),
+				), user.HumanPasswordCheckSucceededEventMapper),
+			},
+			reduce: (&userSessionProjection{}).reduceSessionChecked,
+			want: wantReduce{
+				aggregateType:    user.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.user_sessions (creation_date, change_date, sequence, resource_owner, instance_id, state, user_agent_id, user_id, user_agent, remote_ip, selected_idp_config_id, password_verification, passwordless_verification, external_login_verification, second_factor_verification, second_factor_verification_type, multi_factor_verification, multi_factor_verification_type) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18) ON CONFLICT (instance_id, user_agent_id, user_id) DO NOTHING",
+							expectedArgs: []interface{}{
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								"ro-id",
+								"instance-id",
+								domain.UserSessionStateActive,
+								"agent-id",
+								"agg-id",
+								"",
+								"",
+								"",
+								time.Time{},
+								time.Time{},
+								time.Time{},
+								time.Time{},
+								domain.MFALevelNotSetUp,
+								time.Time{},
+								domain.MFALevelNotSetUp,
+							},
+						},
+						{
+							expectedStmt: "UPDATE projections.user_sessions SET (change_date, sequence, user_agent, remote_ip, password_verification, state) = ($1, $2, $3, $4, $5, $6) WHERE (instance_id = $7) AND (user_agent_id = $8) AND (user_id = $9)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								"browser",
+								"1.2.3.4",
+								anyArg{},
+								domain.UserSessionStateActive,
+								"instance-id",
+								"agent-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceSessionChecked u2f check failed",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(user.HumanU2FTokenCheckFailedType),
+					user.AggregateType,
+					[]byte(`{
+						"userAgentID": "agent-id"
+					}`),
+				), user.HumanU2FCheckFailedEventMapper),
+			},
+			reduce: (&userSessionProjection{}).reduceSessionChecked,
+			want: wantReduce{
+				aggregateType:    user.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.user_sessions (creation_date, change_date, sequence, resource_owner, instance_id, state, user_agent_id, user_id, user_agent, remote_ip, selected_idp_config_id, password_verification, passwordless_verification, external_login_verification, second_factor_verification, second_factor_verification_type, multi_factor_verification, multi_factor_verification_type) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18) ON CONFLICT (instance_id, user_agent_id, user_id) DO NOTHING",
+							expectedArgs: []interface{}{
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								"ro-id",
+								"instance-id",
+								domain.UserSessionStateActive,
+								"agent-id",
+								"agg-id",
+								"",
+								"",
+								"",
+								time.Time{},
+								time.Time{},
+								time.Time{},
+								time.Time{},
+								domain.MFALevelNotSetUp,
+								time.Time{},
+								domain.MFALevelNotSetUp,
+							},
+						},
+						{
+							expectedStmt: "UPDATE projections.user_sessions SET (change_date, sequence, second_factor_verification) = ($1, $2, $3) WHERE (instance_id = $4) AND (user_agent_id = $5) AND (user_id = $6)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								time.Time{},
+								"instance-id",
+								"agent-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceSessionChecked without user agent",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(user.HumanPasswordCheckSucceededType),
+					user.AggregateType,
+					[]byte(`{}`),
+				), user.HumanPasswordCheckSucceededEventMapper),
+			},
+			reduce: (&userSessionProjection{}).reduceSessionChecked,
+			want: wantReduce{
+				aggregateType:    user.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{},
+				},
+			},
+		},
+		{
+			name: "reducePasswordChanged",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(user.HumanPasswordChangedType),
+					user.AggregateType,
+					[]byte(`{
+						"userAgentID": "agent-id"
+					}`),
+				), user.HumanPasswordChangedEventMapper),
+			},
+			reduce: (&userSessionProjection{}).reducePasswordChanged,
+			want: wantReduce{
+				aggregateType:    user.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.user_sessions SET (change_date, sequence, password_verification) = ($1, $2, CASE WHEN user_agent_id = $3 THEN password_verification ELSE '0001-01-01 00:00:00+00'::TIMESTAMPTZ END) WHERE (instance_id = $4) AND (user_id = $5)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								"agent-id",
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceUserTerminated",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(user.UserLockedType),
+					user.AggregateType,
+					nil,
+				), user.UserLockedEventMapper),
+			},
+			reduce: (&userSessionProjection{}).reduceUserTerminated,
+			want: wantReduce{
+				aggregateType:    user.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.user_sessions SET (change_date, sequence, passwordless_verification, password_verification, second_factor_verification, second_factor_verification_type, multi_factor_verification, multi_factor_verification_type, external_login_verification, state) = ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) WHERE (instance_id = $11) AND (user_id = $12)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								time.Time{},
+								time.Time{},
+								time.Time{},
+								domain.MFALevelNotSetUp,
+								time.Time{},
+								domain.MFALevelNotSetUp,
+								time.Time{},
+								domain.UserSessionStateTerminated,
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceUserRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(user.UserRemovedType),
+					user.AggregateType,
+					nil,
+				), user.UserRemovedEventMapper),
+			},
+			reduce: (&userSessionProjection{}).reduceUserRemoved,
+			want: wantReduce{
+				aggregateType:    user.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.user_sessions WHERE (instance_id = $1) AND (user_id = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "org reduceOwnerRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(org.OrgRemovedEventType),
+					org.AggregateType,
+					nil,
+				), org.OrgRemovedEventMapper),
+			},
+			reduce: (&userSessionProjection{}).reduceOwnerRemoved,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("org"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.user_sessions WHERE (instance_id = $1) AND (resource_owner = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance reduceInstanceRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(instance.InstanceRemovedEventType),
+					instance.AggregateType,
+					nil,
+				), instance.InstanceRemovedEventMapper),
+			},
+			reduce: reduceInstanceRemovedHelper(UserSessionColumnInstanceID),
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("instance"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.user_sessions WHERE (instance_id = $1)",
+							expectedArgs: []interface{}{
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if _, ok := err.(errors.InvalidArgument); !ok {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, UserSessionProjectionTable, tt.want)
+		})
+	}
+}
@@ -18,10 +18,13 @@ import (
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/query/projection"
 	"github.com/zitadel/zitadel/internal/repository/action"
+	"github.com/zitadel/zitadel/internal/repository/idpsync"
 	iam_repo "github.com/zitadel/zitadel/internal/repository/instance"
 	"github.com/zitadel/zitadel/internal/repository/keypair"
+	"github.com/zitadel/zitadel/internal/repository/notification"
 	"github.com/zitadel/zitadel/internal/repository/org"
 	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/repository/purge"
 	"github.com/zitadel/zitadel/internal/repository/session"
 	usr_repo "github.com/zitadel/zitadel/internal/repository/user"
 	"github.com/zitadel/zitadel/internal/repository/usergrant"
@@ -76,14 +79,7 @@ func StartQueries(
 		zitadelRoles:                        zitadelRoles,
 		sessionTokenVerifier:                sessionTokenVerifier,
 	}
-	iam_repo.RegisterEventMappers(repo.eventstore)
-	usr_repo.RegisterEventMappers(repo.eventstore)
-	org.RegisterEventMappers(repo.eventstore)
-	project.RegisterEventMappers(repo.eventstore)
-	action.RegisterEventMappers(repo.eventstore)
-	keypair.RegisterEventMappers(repo.eventstore)
-	usergrant.RegisterEventMappers(repo.eventstore)
-	session.RegisterEventMappers(repo.eventstore)
+	RegisterEventMappers(repo.eventstore)
 
 	repo.idpConfigEncryption = idpConfigEncryption
 	repo.multifactors = domain.MultifactorConfigs{
@@ -102,6 +98,21 @@ func StartQueries(
 	return repo, nil
 }
 
+// RegisterEventMappers registers the mappers of all events reduced by the projections
+func RegisterEventMappers(es *eventstore.Eventstore) {
+	iam_repo.RegisterEventMappers(es)
+	usr_repo.RegisterEventMappers(es)
+	org.RegisterEventMappers(es)
+	project.RegisterEventMappers(es)
+	action.RegisterEventMappers(es)
+	keypair.RegisterEventMappers(es)
+	usergrant.RegisterEventMappers(es)
+	session.RegisterEventMappers(es)
+	notification.RegisterEventMappers(es)
+	idpsync.RegisterEventMappers(es)
+	purge.RegisterEventMappers(es)
+}
+
 func (q *Queries) Health(ctx context.Context) error {
 	return q.client.Ping()
 }
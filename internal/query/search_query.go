@@ -11,6 +11,9 @@ import (
 
 type SearchResponse struct {
 	Count uint64
+	// NextCursor continues the search after the last result,
+	// it is only set by searches supporting cursors if further results may exist
+	NextCursor string
 	*LatestSequence
 }
 
@@ -19,6 +22,9 @@ type SearchRequest struct {
 	Limit         uint64
 	SortingColumn Column
 	Asc           bool
+	// Cursor continues the search after the results of the previous page,
+	// it replaces the offset for searches supporting cursors
+	Cursor string
 }
 
 func (req *SearchRequest) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
@@ -35,6 +35,21 @@ type Session struct {
 	Metadata       map[string][]byte
 }
 
+// sortingValue returns the value of the session in the sorting column of a search
+func (s *Session) sortingValue(col Column) interface{} {
+	switch col {
+	case SessionColumnID:
+		return s.ID
+	case SessionColumnCreationDate:
+		return s.CreationDate
+	case SessionColumnChangeDate:
+		return s.ChangeDate
+	case SessionColumnSequence:
+		return s.Sequence
+	}
+	return nil
+}
+
 type SessionUserFactor struct {
 	UserID        string
 	UserCheckedAt time.Time
@@ -51,12 +66,15 @@ type SessionsSearchQueries struct {
 	Queries []SearchQuery
 }
 
-func (q *SessionsSearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
-	query = q.SearchRequest.toQuery(query)
+func (q *SessionsSearchQueries) toQuery(query sq.SelectBuilder) (sq.SelectBuilder, error) {
+	query, err := q.SearchRequest.toCursorQuery(query, SessionColumnID)
+	if err != nil {
+		return query, err
+	}
 	for _, q := range q.Queries {
 		query = q.toQuery(query)
 	}
-	return query
+	return query, nil
 }
 
 var (
@@ -152,7 +170,11 @@ func (q *Queries) SearchSessions(ctx context.Context, queries *SessionsSearchQue
 	defer func() { span.EndWithError(err) }()
 
 	query, scan := prepareSessionsQuery(ctx, q.client)
-	stmt, args, err := queries.toQuery(query).
+	query, err = queries.toQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	stmt, args, err := query.
 		Where(sq.Eq{
 			SessionColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
 		}).ToSql()
@@ -168,6 +190,13 @@ func (q *Queries) SearchSessions(ctx context.Context, queries *SessionsSearchQue
 	if err != nil {
 		return nil, err
 	}
+	if len(sessions.Sessions) > 0 {
+		last := sessions.Sessions[len(sessions.Sessions)-1]
+		sessions.NextCursor, err = queries.nextCursor(len(sessions.Sessions), SessionColumnID, last.sortingValue, last.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
 	sessions.LatestSequence, err = q.latestSequence(ctx, sessionsTable)
 	return sessions, err
 }
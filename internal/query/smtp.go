@@ -69,6 +69,10 @@ var (
 		name:  projection.SMTPConfigColumnSMTPPassword,
 		table: smtpConfigsTable,
 	}
+	SMTPConfigColumnPriority = Column{
+		name:  projection.SMTPConfigColumnPriority,
+		table: smtpConfigsTable,
+	}
 )
 
 type SMTPConfigs struct {
@@ -89,6 +93,7 @@ type SMTPConfig struct {
 	Host          string
 	User          string
 	Password      *crypto.CryptoValue
+	Priority      int32
 }
 
 func (q *Queries) SMTPConfigByAggregateID(ctx context.Context, aggregateID string) (_ *SMTPConfig, err error) {
@@ -122,7 +127,8 @@ func prepareSMTPConfigQuery(ctx context.Context, db prepareDatabase) (sq.SelectB
 			SMTPConfigColumnSenderName.identifier(),
 			SMTPConfigColumnSMTPHost.identifier(),
 			SMTPConfigColumnSMTPUser.identifier(),
-			SMTPConfigColumnSMTPPassword.identifier()).
+			SMTPConfigColumnSMTPPassword.identifier(),
+			SMTPConfigColumnPriority.identifier()).
 			From(smtpConfigsTable.identifier() + db.Timetravel(call.Took(ctx))).
 			PlaceholderFormat(sq.Dollar),
 		func(row *sql.Row) (*SMTPConfig, error) {
@@ -139,6 +145,7 @@ func prepareSMTPConfigQuery(ctx context.Context, db prepareDatabase) (sq.SelectB
 				&config.Host,
 				&config.User,
 				&password,
+				&config.Priority,
 			)
 			if err != nil {
 				if errs.Is(err, sql.ErrNoRows) {
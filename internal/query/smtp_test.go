@@ -13,18 +13,19 @@ import (
 )
 
 var (
-	prepareSMTPConfigStmt = `SELECT projections.smtp_configs.aggregate_id,` +
-		` projections.smtp_configs.creation_date,` +
-		` projections.smtp_configs.change_date,` +
-		` projections.smtp_configs.resource_owner,` +
-		` projections.smtp_configs.sequence,` +
-		` projections.smtp_configs.tls,` +
-		` projections.smtp_configs.sender_address,` +
-		` projections.smtp_configs.sender_name,` +
-		` projections.smtp_configs.host,` +
-		` projections.smtp_configs.username,` +
-		` projections.smtp_configs.password` +
-		` FROM projections.smtp_configs` +
+	prepareSMTPConfigStmt = `SELECT projections.smtp_configs1.aggregate_id,` +
+		` projections.smtp_configs1.creation_date,` +
+		` projections.smtp_configs1.change_date,` +
+		` projections.smtp_configs1.resource_owner,` +
+		` projections.smtp_configs1.sequence,` +
+		` projections.smtp_configs1.tls,` +
+		` projections.smtp_configs1.sender_address,` +
+		` projections.smtp_configs1.sender_name,` +
+		` projections.smtp_configs1.host,` +
+		` projections.smtp_configs1.username,` +
+		` projections.smtp_configs1.password,` +
+		` projections.smtp_configs1.priority` +
+		` FROM projections.smtp_configs1` +
 		` AS OF SYSTEM TIME '-1 ms'`
 	prepareSMTPConfigCols = []string{
 		"aggregate_id",
@@ -38,6 +39,7 @@ var (
 		"smtp_host",
 		"smtp_user",
 		"smtp_password",
+		"priority",
 	}
 )
 
@@ -89,6 +91,7 @@ func Test_SMTPConfigsPrepares(t *testing.T) {
 						"host",
 						"user",
 						&crypto.CryptoValue{},
+						int32(1),
 					},
 				),
 			},
@@ -104,6 +107,7 @@ func Test_SMTPConfigsPrepares(t *testing.T) {
 				Host:          "host",
 				User:          "user",
 				Password:      &crypto.CryptoValue{},
+				Priority:      1,
 			},
 		},
 		{
@@ -39,6 +39,44 @@ type User struct {
 	Machine            *Machine
 }
 
+// sortingValue returns the value of the user in the sorting column of a search
+func (u *User) sortingValue(col Column) interface{} {
+	switch col {
+	case UserIDCol:
+		return u.ID
+	case UserCreationDateCol:
+		return u.CreationDate
+	case UserChangeDateCol:
+		return u.ChangeDate
+	case UserResourceOwnerCol:
+		return u.ResourceOwner
+	case UserSequenceCol:
+		return u.Sequence
+	case UserStateCol:
+		return u.State
+	case UserTypeCol:
+		return u.Type
+	case UserUsernameCol:
+		return u.Username
+	}
+	if u.Human == nil {
+		return nil
+	}
+	switch col {
+	case HumanFirstNameCol:
+		return u.Human.FirstName
+	case HumanLastNameCol:
+		return u.Human.LastName
+	case HumanNickNameCol:
+		return u.Human.NickName
+	case HumanDisplayNameCol:
+		return u.Human.DisplayName
+	case HumanEmailCol:
+		return u.Human.Email
+	}
+	return nil
+}
+
 type Human struct {
 	FirstName         string
 	LastName          string
@@ -528,7 +566,11 @@ func (q *Queries) SearchUsers(ctx context.Context, queries *UserSearchQueries, w
 	if !withOwnerRemoved {
 		addUserWithoutOwnerRemoved(eq)
 	}
-	stmt, args, err := queries.toQuery(query).Where(eq).
+	query, err = queries.toQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	stmt, args, err := query.Where(eq).
 		ToSql()
 	if err != nil {
 		return nil, errors.ThrowInternal(err, "QUERY-Dgbg2", "Errors.Query.SQLStatment")
@@ -542,6 +584,13 @@ func (q *Queries) SearchUsers(ctx context.Context, queries *UserSearchQueries, w
 	if err != nil {
 		return nil, err
 	}
+	if len(users.Users) > 0 && queries.fullTextQuery() == nil {
+		last := users.Users[len(users.Users)-1]
+		users.NextCursor, err = queries.nextCursor(len(users.Users), UserIDCol, last.sortingValue, last.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
 	users.LatestSequence, err = q.latestSequence(ctx, userTable)
 	return users, err
 }
@@ -588,12 +637,23 @@ func (q *Queries) IsUserUnique(ctx context.Context, username, email, resourceOwn
 	return scan(row)
 }
 
-func (q *UserSearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
-	query = q.SearchRequest.toQuery(query)
+func (q *UserSearchQueries) toQuery(query sq.SelectBuilder) (sq.SelectBuilder, error) {
+	if fullText := q.fullTextQuery(); fullText != nil {
+		// the position of a user in a ranked search is not stable, therefore ranked searches are paged by offset
+		if q.Cursor != "" {
+			return query, errors.ThrowInvalidArgument(nil, "QUERY-Xk9pe", "Errors.Query.InvalidCursor")
+		}
+		rank, args := fullText.rank()
+		query = query.OrderByClause(rank, args...)
+	}
+	query, err := q.SearchRequest.toCursorQuery(query, UserIDCol)
+	if err != nil {
+		return query, err
+	}
 	for _, q := range q.Queries {
 		query = q.toQuery(query)
 	}
-	return query
+	return query, nil
 }
 
 func (r *UserSearchQueries) AppendMyResourceOwnerQuery(orgID string) error {
@@ -0,0 +1,254 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	errs "errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+var (
+	userConsentsTable = table{
+		name:          projection.UserConsentProjectionTable,
+		instanceIDCol: projection.UserConsentColumnInstanceID,
+	}
+	UserConsentColumnUserID = Column{
+		name:  projection.UserConsentColumnUserID,
+		table: userConsentsTable,
+	}
+	UserConsentColumnClientID = Column{
+		name:  projection.UserConsentColumnClientID,
+		table: userConsentsTable,
+	}
+	UserConsentColumnProjectID = Column{
+		name:  projection.UserConsentColumnProjectID,
+		table: userConsentsTable,
+	}
+	UserConsentColumnCreationDate = Column{
+		name:  projection.UserConsentColumnCreationDate,
+		table: userConsentsTable,
+	}
+	UserConsentColumnChangeDate = Column{
+		name:  projection.UserConsentColumnChangeDate,
+		table: userConsentsTable,
+	}
+	UserConsentColumnSequence = Column{
+		name:  projection.UserConsentColumnSequence,
+		table: userConsentsTable,
+	}
+	UserConsentColumnResourceOwner = Column{
+		name:  projection.UserConsentColumnResourceOwner,
+		table: userConsentsTable,
+	}
+	UserConsentColumnInstanceID = Column{
+		name:  projection.UserConsentColumnInstanceID,
+		table: userConsentsTable,
+	}
+	UserConsentColumnScopes = Column{
+		name:  projection.UserConsentColumnScopes,
+		table: userConsentsTable,
+	}
+	UserConsentColumnRoles = Column{
+		name:  projection.UserConsentColumnRoles,
+		table: userConsentsTable,
+	}
+	UserConsentColumnOwnerRemoved = Column{
+		name:  projection.UserConsentColumnOwnerRemoved,
+		table: userConsentsTable,
+	}
+)
+
+type UserConsents struct {
+	SearchResponse
+	Consents []*UserConsent
+}
+
+type UserConsent struct {
+	UserID        string
+	ClientID      string
+	ProjectID     string
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	ResourceOwner string
+	Sequence      uint64
+
+	Scopes database.StringArray
+	Roles  database.StringArray
+}
+
+type UserConsentSearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+func (q *Queries) UserConsentByUserAndClientID(ctx context.Context, shouldTriggerBulk bool, userID, clientID string) (_ *UserConsent, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if shouldTriggerBulk {
+		projection.UserConsentProjection.Trigger(ctx)
+	}
+
+	query, scan := prepareUserConsentQuery(ctx, q.client)
+	stmt, args, err := query.Where(sq.Eq{
+		UserConsentColumnUserID.identifier():       userID,
+		UserConsentColumnClientID.identifier():     clientID,
+		UserConsentColumnInstanceID.identifier():   authz.GetInstance(ctx).InstanceID(),
+		UserConsentColumnOwnerRemoved.identifier(): false,
+	}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Wq3nd", "Errors.Query.SQLStatment")
+	}
+
+	row := q.client.QueryRowContext(ctx, stmt, args...)
+	return scan(row)
+}
+
+func (q *Queries) SearchUserConsents(ctx context.Context, queries *UserConsentSearchQueries) (consents *UserConsents, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareUserConsentsQuery(ctx, q.client)
+	stmt, args, err := queries.toQuery(query).Where(sq.Eq{
+		UserConsentColumnInstanceID.identifier():   authz.GetInstance(ctx).InstanceID(),
+		UserConsentColumnOwnerRemoved.identifier(): false,
+	}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-Jf8rs", "Errors.Query.InvalidRequest")
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Pd6xe", "Errors.Internal")
+	}
+	consents, err = scan(rows)
+	if err != nil {
+		return nil, err
+	}
+	consents.LatestSequence, err = q.latestSequence(ctx, userConsentsTable)
+	return consents, err
+}
+
+func NewUserConsentUserIDSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(UserConsentColumnUserID, value, TextEquals)
+}
+
+func NewUserConsentClientIDSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(UserConsentColumnClientID, value, TextEquals)
+}
+
+func NewUserConsentResourceOwnerSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(UserConsentColumnResourceOwner, value, TextEquals)
+}
+
+func (r *UserConsentSearchQueries) AppendMyResourceOwnerQuery(orgID string) error {
+	query, err := NewUserConsentResourceOwnerSearchQuery(orgID)
+	if err != nil {
+		return err
+	}
+	r.Queries = append(r.Queries, query)
+	return nil
+}
+
+func (q *UserConsentSearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+func prepareUserConsentQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Row) (*UserConsent, error)) {
+	return sq.Select(
+			UserConsentColumnUserID.identifier(),
+			UserConsentColumnClientID.identifier(),
+			UserConsentColumnProjectID.identifier(),
+			UserConsentColumnCreationDate.identifier(),
+			UserConsentColumnChangeDate.identifier(),
+			UserConsentColumnResourceOwner.identifier(),
+			UserConsentColumnSequence.identifier(),
+			UserConsentColumnScopes.identifier(),
+			UserConsentColumnRoles.identifier()).
+			From(userConsentsTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*UserConsent, error) {
+			c := new(UserConsent)
+			err := row.Scan(
+				&c.UserID,
+				&c.ClientID,
+				&c.ProjectID,
+				&c.CreationDate,
+				&c.ChangeDate,
+				&c.ResourceOwner,
+				&c.Sequence,
+				&c.Scopes,
+				&c.Roles,
+			)
+			if err != nil {
+				if errs.Is(err, sql.ErrNoRows) {
+					return nil, errors.ThrowNotFound(err, "QUERY-Ay6ch", "Errors.User.Consent.NotFound")
+				}
+				return nil, errors.ThrowInternal(err, "QUERY-Vn9dz", "Errors.Internal")
+			}
+			return c, nil
+		}
+}
+
+func prepareUserConsentsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) (*UserConsents, error)) {
+	return sq.Select(
+			UserConsentColumnUserID.identifier(),
+			UserConsentColumnClientID.identifier(),
+			UserConsentColumnProjectID.identifier(),
+			UserConsentColumnCreationDate.identifier(),
+			UserConsentColumnChangeDate.identifier(),
+			UserConsentColumnResourceOwner.identifier(),
+			UserConsentColumnSequence.identifier(),
+			UserConsentColumnScopes.identifier(),
+			UserConsentColumnRoles.identifier(),
+			countColumn.identifier()).
+			From(userConsentsTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*UserConsents, error) {
+			consents := make([]*UserConsent, 0)
+			var count uint64
+			for rows.Next() {
+				consent := new(UserConsent)
+				err := rows.Scan(
+					&consent.UserID,
+					&consent.ClientID,
+					&consent.ProjectID,
+					&consent.CreationDate,
+					&consent.ChangeDate,
+					&consent.ResourceOwner,
+					&consent.Sequence,
+					&consent.Scopes,
+					&consent.Roles,
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				consents = append(consents, consent)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Rk2ou", "Errors.Query.CloseRows")
+			}
+
+			return &UserConsents{
+				Consents: consents,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
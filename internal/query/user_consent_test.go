@@ -0,0 +1,209 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/database"
+	errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+var (
+	userConsentStmt = regexp.QuoteMeta(
+		"SELECT projections.user_consents.user_id," +
+			" projections.user_consents.client_id," +
+			" projections.user_consents.project_id," +
+			" projections.user_consents.creation_date," +
+			" projections.user_consents.change_date," +
+			" projections.user_consents.resource_owner," +
+			" projections.user_consents.sequence," +
+			" projections.user_consents.scopes," +
+			" projections.user_consents.roles" +
+			" FROM projections.user_consents" +
+			` AS OF SYSTEM TIME '-1 ms'`)
+	userConsentCols = []string{
+		"user_id",
+		"client_id",
+		"project_id",
+		"creation_date",
+		"change_date",
+		"resource_owner",
+		"sequence",
+		"scopes",
+		"roles",
+	}
+	userConsentsStmt = regexp.QuoteMeta(
+		"SELECT projections.user_consents.user_id," +
+			" projections.user_consents.client_id," +
+			" projections.user_consents.project_id," +
+			" projections.user_consents.creation_date," +
+			" projections.user_consents.change_date," +
+			" projections.user_consents.resource_owner," +
+			" projections.user_consents.sequence," +
+			" projections.user_consents.scopes," +
+			" projections.user_consents.roles," +
+			" COUNT(*) OVER ()" +
+			" FROM projections.user_consents" +
+			" AS OF SYSTEM TIME '-1 ms'")
+	userConsentsCols = append(userConsentCols, "count")
+)
+
+func Test_UserConsentPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareUserConsentQuery no result",
+			prepare: prepareUserConsentQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					userConsentStmt,
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !errs.IsNotFound(err) {
+						return fmt.Errorf("err should be zitadel.NotFoundError got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*UserConsent)(nil),
+		},
+		{
+			name:    "prepareUserConsentQuery found",
+			prepare: prepareUserConsentQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					userConsentStmt,
+					userConsentCols,
+					[]driver.Value{
+						"user-id",
+						"client-id",
+						"project-id",
+						testNow,
+						testNow,
+						"ro",
+						uint64(20211202),
+						database.StringArray{"openid"},
+						database.StringArray{"role"},
+					},
+				),
+			},
+			object: &UserConsent{
+				UserID:        "user-id",
+				ClientID:      "client-id",
+				ProjectID:     "project-id",
+				CreationDate:  testNow,
+				ChangeDate:    testNow,
+				ResourceOwner: "ro",
+				Sequence:      20211202,
+				Scopes:        database.StringArray{"openid"},
+				Roles:         database.StringArray{"role"},
+			},
+		},
+		{
+			name:    "prepareUserConsentQuery sql err",
+			prepare: prepareUserConsentQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					userConsentStmt,
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+		{
+			name:    "prepareUserConsentsQuery no result",
+			prepare: prepareUserConsentsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					userConsentsStmt,
+					nil,
+					nil,
+				),
+			},
+			object: &UserConsents{Consents: []*UserConsent{}},
+		},
+		{
+			name:    "prepareUserConsentsQuery one consent",
+			prepare: prepareUserConsentsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					userConsentsStmt,
+					userConsentsCols,
+					[][]driver.Value{
+						{
+							"user-id",
+							"client-id",
+							"project-id",
+							testNow,
+							testNow,
+							"ro",
+							uint64(20211202),
+							database.StringArray{"openid"},
+							database.StringArray{"role"},
+						},
+					},
+				),
+			},
+			object: &UserConsents{
+				SearchResponse: SearchResponse{
+					Count: 1,
+				},
+				Consents: []*UserConsent{
+					{
+						UserID:        "user-id",
+						ClientID:      "client-id",
+						ProjectID:     "project-id",
+						CreationDate:  testNow,
+						ChangeDate:    testNow,
+						ResourceOwner: "ro",
+						Sequence:      20211202,
+						Scopes:        database.StringArray{"openid"},
+						Roles:         database.StringArray{"role"},
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareUserConsentsQuery sql err",
+			prepare: prepareUserConsentsQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					userConsentsStmt,
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
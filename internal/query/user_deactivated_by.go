@@ -0,0 +1,63 @@
+package query
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+// UserDeactivatedBy returns the id of the editor, which deactivated the user.
+// The id is empty if the user was never deactivated or has been reactivated since.
+func (q *Queries) UserDeactivatedBy(ctx context.Context, userID string) (_ string, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	readModel := newUserDeactivatedByReadModel(userID, authz.GetInstance(ctx).InstanceID())
+	if err = q.eventstore.FilterToQueryReducer(ctx, readModel); err != nil {
+		return "", err
+	}
+	return readModel.DeactivatedBy, nil
+}
+
+type userDeactivatedByReadModel struct {
+	eventstore.ReadModel
+
+	DeactivatedBy string
+}
+
+func newUserDeactivatedByReadModel(userID, instanceID string) *userDeactivatedByReadModel {
+	return &userDeactivatedByReadModel{
+		ReadModel: eventstore.ReadModel{
+			AggregateID: userID,
+			InstanceID:  instanceID,
+		},
+	}
+}
+
+func (rm *userDeactivatedByReadModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		InstanceID(rm.InstanceID).
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(rm.AggregateID).
+		EventTypes(
+			user.UserDeactivatedType,
+			user.UserReactivatedType,
+		).
+		Builder()
+}
+
+func (rm *userDeactivatedByReadModel) Reduce() error {
+	for _, event := range rm.Events {
+		switch event.(type) {
+		case *user.UserDeactivatedEvent:
+			rm.DeactivatedBy = event.EditorUser()
+		case *user.UserReactivatedEvent:
+			rm.DeactivatedBy = ""
+		}
+	}
+	return rm.ReadModel.Reduce()
+}
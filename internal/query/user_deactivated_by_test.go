@@ -0,0 +1,62 @@
+package query
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+func Test_userDeactivatedByReadModel_Reduce(t *testing.T) {
+	agg := &user.NewAggregate("user1", "org1").Aggregate
+	editorCtx := func(editorID string) context.Context {
+		return authz.SetCtxData(context.Background(), authz.CtxData{UserID: editorID})
+	}
+	tests := []struct {
+		name   string
+		events []eventstore.Event
+		want   string
+	}{
+		{
+			name: "never deactivated",
+			want: "",
+		},
+		{
+			name: "deactivated",
+			events: []eventstore.Event{
+				user.NewUserDeactivatedEvent(editorCtx("admin1"), agg),
+			},
+			want: "admin1",
+		},
+		{
+			name: "reactivated",
+			events: []eventstore.Event{
+				user.NewUserDeactivatedEvent(editorCtx("admin1"), agg),
+				user.NewUserReactivatedEvent(editorCtx("admin1"), agg),
+			},
+			want: "",
+		},
+		{
+			name: "last deactivation",
+			events: []eventstore.Event{
+				user.NewUserDeactivatedEvent(editorCtx("admin1"), agg),
+				user.NewUserReactivatedEvent(editorCtx("admin1"), agg),
+				user.NewUserDeactivatedEvent(editorCtx("sync"), agg),
+			},
+			want: "sync",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rm := newUserDeactivatedByReadModel("user1", "instance1")
+			rm.AppendEvents(tt.events...)
+			err := rm.Reduce()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, rm.DeactivatedBy)
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package query
+
+import (
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+// userFullTextColumns are searched by UserFullTextQuery.
+// Each of them is covered by a trigram index of the user projection
+var userFullTextColumns = []Column{
+	UserUsernameCol,
+	HumanFirstNameCol,
+	HumanLastNameCol,
+	HumanNickNameCol,
+	HumanDisplayNameCol,
+	HumanEmailCol,
+	HumanPhoneCol,
+}
+
+// UserFullTextQuery searches users by a partial or misspelled text
+// in their username, names, email and phone
+// and in the values of the metadata with one of the MetadataKeys.
+// The results of SearchUsers are ranked by the similarity of the text if it is part of the queries
+type UserFullTextQuery struct {
+	Text         string
+	MetadataKeys []string
+}
+
+func NewUserFullTextQuery(text string, metadataKeys []string) (*UserFullTextQuery, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, errors.ThrowInvalidArgument(nil, "QUERY-Wq3zd", "Errors.Query.InvalidRequest")
+	}
+	return &UserFullTextQuery{
+		Text:         text,
+		MetadataKeys: metadataKeys,
+	}, nil
+}
+
+func (q *UserFullTextQuery) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	return query.Where(q.comp())
+}
+
+// likeEscaper escapes the wildcards of LIKE patterns, backslash is the default escape character
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// comp matches the users containing the text (ILIKE) or having a similar text (%),
+// both are answered by the trigram indexes
+func (q *UserFullTextQuery) comp() sq.Sqlizer {
+	pattern := "%" + likeEscaper.Replace(q.Text) + "%"
+	or := make(sq.Or, 0, len(userFullTextColumns)*2+1)
+	for _, col := range userFullTextColumns {
+		or = append(or,
+			sq.ILike{col.identifier(): pattern},
+			sq.Expr(col.identifier()+" % ?", q.Text),
+		)
+	}
+	if len(q.MetadataKeys) > 0 {
+		or = append(or, q.metadataComp(pattern))
+	}
+	return or
+}
+
+// metadataComp passes the sub select as argument of the expression,
+// so it is only built (and fails) with the whole statement
+func (q *UserFullTextQuery) metadataComp(pattern string) sq.Sqlizer {
+	subSelect := sq.Select(UserMetadataUserIDCol.identifier()).
+		From(userMetadataTable.identifier()).
+		Where(sq.Expr(UserMetadataInstanceIDCol.identifier() + " = " + UserInstanceIDCol.identifier())).
+		Where(sq.Eq{UserMetadataKeyCol.identifier(): q.MetadataKeys}).
+		Where(sq.Expr("encode("+UserMetadataValueCol.identifier()+", 'escape') ILIKE ?", pattern))
+	return sq.Expr(UserIDCol.identifier()+" IN ( ? )", subSelect)
+}
+
+// rank orders the most similar users first
+func (q *UserFullTextQuery) rank() (string, []interface{}) {
+	similarities := make([]string, len(userFullTextColumns))
+	args := make([]interface{}, len(userFullTextColumns))
+	for i, col := range userFullTextColumns {
+		similarities[i] = "similarity(" + col.identifier() + ", ?)"
+		args[i] = q.Text
+	}
+	return "GREATEST(" + strings.Join(similarities, ", ") + ") DESC", args
+}
+
+func (q *UserSearchQueries) fullTextQuery() *UserFullTextQuery {
+	for _, query := range q.Queries {
+		if fullText, ok := query.(*UserFullTextQuery); ok {
+			return fullText
+		}
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package query
+
+import (
+	"testing"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+func TestNewUserFullTextQuery(t *testing.T) {
+	_, err := NewUserFullTextQuery("  ", nil)
+	assert.True(t, caos_errs.IsErrorInvalidArgument(err))
+
+	query, err := NewUserFullTextQuery(" gigi ", []string{"employee_id"})
+	require.NoError(t, err)
+	assert.Equal(t, &UserFullTextQuery{Text: "gigi", MetadataKeys: []string{"employee_id"}}, query)
+}
+
+func TestUserSearchQueries_toQuery_fullText(t *testing.T) {
+	tests := []struct {
+		name     string
+		queries  *UserSearchQueries
+		wantStmt string
+		wantArgs []interface{}
+		wantErr  func(error) bool
+	}{
+		{
+			name: "ranked by similarity",
+			queries: &UserSearchQueries{
+				SearchRequest: SearchRequest{Limit: 10},
+				Queries:       []SearchQuery{&UserFullTextQuery{Text: "gigi"}},
+			},
+			wantStmt: "SELECT projections.users8.id FROM projections.users8 WHERE (" +
+				"projections.users8.username ILIKE ? OR projections.users8.username % ? OR " +
+				"projections.users8_humans.first_name ILIKE ? OR projections.users8_humans.first_name % ? OR " +
+				"projections.users8_humans.last_name ILIKE ? OR projections.users8_humans.last_name % ? OR " +
+				"projections.users8_humans.nick_name ILIKE ? OR projections.users8_humans.nick_name % ? OR " +
+				"projections.users8_humans.display_name ILIKE ? OR projections.users8_humans.display_name % ? OR " +
+				"projections.users8_humans.email ILIKE ? OR projections.users8_humans.email % ? OR " +
+				"projections.users8_humans.phone ILIKE ? OR projections.users8_humans.phone % ?) " +
+				"ORDER BY GREATEST(similarity(projections.users8.username, ?), similarity(projections.users8_humans.first_name, ?), " +
+				"similarity(projections.users8_humans.last_name, ?), similarity(projections.users8_humans.nick_name, ?), " +
+				"similarity(projections.users8_humans.display_name, ?), similarity(projections.users8_humans.email, ?), " +
+				"similarity(projections.users8_humans.phone, ?)) DESC LIMIT 10",
+			wantArgs: []interface{}{
+				"%gigi%", "gigi", "%gigi%", "gigi", "%gigi%", "gigi", "%gigi%", "gigi", "%gigi%", "gigi", "%gigi%", "gigi", "%gigi%", "gigi",
+				"gigi", "gigi", "gigi", "gigi", "gigi", "gigi", "gigi",
+			},
+		},
+		{
+			name: "metadata values",
+			queries: &UserSearchQueries{
+				Queries: []SearchQuery{&UserFullTextQuery{Text: "0815", MetadataKeys: []string{"employee_id"}}},
+			},
+			wantStmt: "SELECT projections.users8.id FROM projections.users8 WHERE (" +
+				"projections.users8.username ILIKE ? OR projections.users8.username % ? OR " +
+				"projections.users8_humans.first_name ILIKE ? OR projections.users8_humans.first_name % ? OR " +
+				"projections.users8_humans.last_name ILIKE ? OR projections.users8_humans.last_name % ? OR " +
+				"projections.users8_humans.nick_name ILIKE ? OR projections.users8_humans.nick_name % ? OR " +
+				"projections.users8_humans.display_name ILIKE ? OR projections.users8_humans.display_name % ? OR " +
+				"projections.users8_humans.email ILIKE ? OR projections.users8_humans.email % ? OR " +
+				"projections.users8_humans.phone ILIKE ? OR projections.users8_humans.phone % ? OR " +
+				"projections.users8.id IN ( SELECT projections.user_metadata4.user_id FROM projections.user_metadata4 " +
+				"WHERE projections.user_metadata4.instance_id = projections.users8.instance_id AND projections.user_metadata4.key IN (?) " +
+				"AND encode(projections.user_metadata4.value, 'escape') ILIKE ? )) " +
+				"ORDER BY GREATEST(similarity(projections.users8.username, ?), similarity(projections.users8_humans.first_name, ?), " +
+				"similarity(projections.users8_humans.last_name, ?), similarity(projections.users8_humans.nick_name, ?), " +
+				"similarity(projections.users8_humans.display_name, ?), similarity(projections.users8_humans.email, ?), " +
+				"similarity(projections.users8_humans.phone, ?)) DESC",
+			wantArgs: []interface{}{
+				"%0815%", "0815", "%0815%", "0815", "%0815%", "0815", "%0815%", "0815", "%0815%", "0815", "%0815%", "0815", "%0815%", "0815",
+				"employee_id", "%0815%",
+				"0815", "0815", "0815", "0815", "0815", "0815", "0815",
+			},
+		},
+		{
+			name: "wildcards escaped",
+			queries: &UserSearchQueries{
+				Queries: []SearchQuery{&UserFullTextQuery{Text: `100%_\`, MetadataKeys: []string{"employee_id"}}},
+			},
+			wantStmt: "SELECT projections.users8.id FROM projections.users8 WHERE (" +
+				"projections.users8.username ILIKE ? OR projections.users8.username % ? OR " +
+				"projections.users8_humans.first_name ILIKE ? OR projections.users8_humans.first_name % ? OR " +
+				"projections.users8_humans.last_name ILIKE ? OR projections.users8_humans.last_name % ? OR " +
+				"projections.users8_humans.nick_name ILIKE ? OR projections.users8_humans.nick_name % ? OR " +
+				"projections.users8_humans.display_name ILIKE ? OR projections.users8_humans.display_name % ? OR " +
+				"projections.users8_humans.email ILIKE ? OR projections.users8_humans.email % ? OR " +
+				"projections.users8_humans.phone ILIKE ? OR projections.users8_humans.phone % ? OR " +
+				"projections.users8.id IN ( SELECT projections.user_metadata4.user_id FROM projections.user_metadata4 " +
+				"WHERE projections.user_metadata4.instance_id = projections.users8.instance_id AND projections.user_metadata4.key IN (?) " +
+				"AND encode(projections.user_metadata4.value, 'escape') ILIKE ? )) " +
+				"ORDER BY GREATEST(similarity(projections.users8.username, ?), similarity(projections.users8_humans.first_name, ?), " +
+				"similarity(projections.users8_humans.last_name, ?), similarity(projections.users8_humans.nick_name, ?), " +
+				"similarity(projections.users8_humans.display_name, ?), similarity(projections.users8_humans.email, ?), " +
+				"similarity(projections.users8_humans.phone, ?)) DESC",
+			wantArgs: []interface{}{
+				`%100\%\_\\%`, `100%_\`, `%100\%\_\\%`, `100%_\`, `%100\%\_\\%`, `100%_\`, `%100\%\_\\%`, `100%_\`,
+				`%100\%\_\\%`, `100%_\`, `%100\%\_\\%`, `100%_\`, `%100\%\_\\%`, `100%_\`,
+				"employee_id", `%100\%\_\\%`,
+				`100%_\`, `100%_\`, `100%_\`, `100%_\`, `100%_\`, `100%_\`, `100%_\`,
+			},
+		},
+		{
+			name: "cursor, invalid argument error",
+			queries: &UserSearchQueries{
+				SearchRequest: SearchRequest{Limit: 10, Cursor: testCursor(t, UserIDCol, false, "id1", "id1")},
+				Queries:       []SearchQuery{&UserFullTextQuery{Text: "gigi"}},
+			},
+			wantErr: caos_errs.IsErrorInvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := tt.queries.toQuery(sq.Select(UserIDCol.identifier()).From(userTable.identifier()))
+			if tt.wantErr != nil {
+				assert.True(t, tt.wantErr(err))
+				return
+			}
+			require.NoError(t, err)
+			stmt, args, err := query.ToSql()
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStmt, stmt)
+			assert.Equal(t, tt.wantArgs, args)
+		})
+	}
+}
@@ -49,6 +49,21 @@ type UserGrant struct {
 	ProjectName string
 }
 
+// sortingValue returns the value of the user grant in the sorting column of a search
+func (g *UserGrant) sortingValue(col Column) interface{} {
+	switch col {
+	case UserGrantID:
+		return g.ID
+	case UserGrantCreationDate:
+		return g.CreationDate
+	case UserGrantChangeDate:
+		return g.ChangeDate
+	case UserGrantSequence:
+		return g.Sequence
+	}
+	return nil
+}
+
 type UserGrants struct {
 	SearchResponse
 	UserGrants []*UserGrant
@@ -59,12 +74,15 @@ type UserGrantsQueries struct {
 	Queries []SearchQuery
 }
 
-func (q *UserGrantsQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
-	query = q.SearchRequest.toQuery(query)
+func (q *UserGrantsQueries) toQuery(query sq.SelectBuilder) (sq.SelectBuilder, error) {
+	query, err := q.SearchRequest.toCursorQuery(query, UserGrantID)
+	if err != nil {
+		return query, err
+	}
 	for _, q := range q.Queries {
 		query = q.toQuery(query)
 	}
-	return query
+	return query, nil
 }
 
 func NewUserGrantUserIDSearchQuery(id string) (SearchQuery, error) {
@@ -270,7 +288,11 @@ func (q *Queries) UserGrants(ctx context.Context, queries *UserGrantsQueries, sh
 	if !withOwnerRemoved {
 		addUserGrantWithoutOwnerRemoved(eq)
 	}
-	stmt, args, err := queries.toQuery(query).Where(eq).ToSql()
+	query, err = queries.toQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	stmt, args, err := query.Where(eq).ToSql()
 	if err != nil {
 		return nil, errors.ThrowInternal(err, "QUERY-wXnQR", "Errors.Query.SQLStatement")
 	}
@@ -288,6 +310,13 @@ func (q *Queries) UserGrants(ctx context.Context, queries *UserGrantsQueries, sh
 	if err != nil {
 		return nil, err
 	}
+	if len(grants.UserGrants) > 0 {
+		last := grants.UserGrants[len(grants.UserGrants)-1]
+		grants.NextCursor, err = queries.nextCursor(len(grants.UserGrants), UserGrantID, last.sortingValue, last.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	grants.LatestSequence = latestSequence
 	return grants, nil
@@ -0,0 +1,106 @@
+package query
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+// SessionClientIDs returns the ids of the (OIDC) clients, which received tokens for the user on the user agent
+// since the user last signed out of it.
+// If maxSequence is set, only events before that sequence are considered.
+func (q *Queries) SessionClientIDs(ctx context.Context, userID, userAgentID string, maxSequence uint64) (_ []string, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	readModel := newSessionClientsReadModel(userID, authz.GetInstance(ctx).InstanceID(), userAgentID, maxSequence)
+	if err = q.eventstore.FilterToQueryReducer(ctx, readModel); err != nil {
+		return nil, err
+	}
+	return readModel.ClientIDs, nil
+}
+
+// SessionSAMLAppIDs returns the ids of the SAML applications, which received a response for the user on the user agent
+// since the user last signed out of it.
+func (q *Queries) SessionSAMLAppIDs(ctx context.Context, userID, userAgentID string) (_ []string, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	readModel := newSessionClientsReadModel(userID, authz.GetInstance(ctx).InstanceID(), userAgentID, 0)
+	if err = q.eventstore.FilterToQueryReducer(ctx, readModel); err != nil {
+		return nil, err
+	}
+	return readModel.SAMLAppIDs, nil
+}
+
+type sessionClientsReadModel struct {
+	eventstore.ReadModel
+	userAgentID string
+	maxSequence uint64
+
+	ClientIDs  []string
+	SAMLAppIDs []string
+}
+
+func newSessionClientsReadModel(userID, instanceID, userAgentID string, maxSequence uint64) *sessionClientsReadModel {
+	return &sessionClientsReadModel{
+		ReadModel: eventstore.ReadModel{
+			AggregateID: userID,
+			InstanceID:  instanceID,
+		},
+		userAgentID: userAgentID,
+		maxSequence: maxSequence,
+	}
+}
+
+func (rm *sessionClientsReadModel) Query() *eventstore.SearchQueryBuilder {
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		InstanceID(rm.InstanceID).
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(rm.AggregateID).
+		EventTypes(
+			user.UserTokenAddedType,
+			user.HumanSignedOutType,
+			user.UserV1SignedOutType,
+		)
+	if rm.maxSequence > 0 {
+		query = query.SequenceLess(rm.maxSequence)
+	}
+	return query.Builder()
+}
+
+func (rm *sessionClientsReadModel) Reduce() error {
+	for _, event := range rm.Events {
+		switch e := event.(type) {
+		case *user.UserTokenAddedEvent:
+			if e.UserAgentID != rm.userAgentID || e.ApplicationID == "" || containsClientID(rm.ClientIDs, e.ApplicationID) {
+				continue
+			}
+			rm.ClientIDs = append(rm.ClientIDs, e.ApplicationID)
+		case *user.HumanSAMLResponseSentEvent:
+			if e.UserAgentID != rm.userAgentID || containsClientID(rm.SAMLAppIDs, e.ApplicationID) {
+				continue
+			}
+			rm.SAMLAppIDs = append(rm.SAMLAppIDs, e.ApplicationID)
+		case *user.HumanSignedOutEvent:
+			if e.UserAgentID == rm.userAgentID {
+				rm.ClientIDs = nil
+				rm.SAMLAppIDs = nil
+			}
+		}
+	}
+	return rm.ReadModel.Reduce()
+}
+
+func containsClientID(clientIDs []string, clientID string) bool {
+	for _, id := range clientIDs {
+		if id == clientID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+package query
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+func Test_sessionClientsReadModel_Reduce(t *testing.T) {
+	agg := &user.NewAggregate("user1", "org1").Aggregate
+	tokenAdded := func(clientID, userAgentID string) eventstore.Event {
+		return user.NewUserTokenAddedEvent(context.Background(), agg, "token", clientID, userAgentID, "en", "", nil, nil, time.Now(), nil)
+	}
+	samlResponseSent := func(appID, userAgentID string) eventstore.Event {
+		return user.NewHumanSAMLResponseSentEvent(context.Background(), agg, userAgentID, appID)
+	}
+	tests := []struct {
+		name     string
+		events   []eventstore.Event
+		want     []string
+		wantSAML []string
+	}{
+		{
+			name: "no tokens",
+			want: nil,
+		},
+		{
+			name: "tokens of user agent",
+			events: []eventstore.Event{
+				tokenAdded("client1", "agent1"),
+				tokenAdded("client2", "agent1"),
+				tokenAdded("client1", "agent1"),
+				tokenAdded("client3", "agent2"),
+			},
+			want: []string{"client1", "client2"},
+		},
+		{
+			name: "tokens before sign out",
+			events: []eventstore.Event{
+				tokenAdded("client1", "agent1"),
+				user.NewHumanSignedOutEvent(context.Background(), agg, "agent2"),
+				tokenAdded("client2", "agent1"),
+				user.NewHumanSignedOutEvent(context.Background(), agg, "agent1"),
+				tokenAdded("client3", "agent1"),
+			},
+			want: []string{"client3"},
+		},
+		{
+			name: "saml responses of user agent",
+			events: []eventstore.Event{
+				samlResponseSent("app1", "agent1"),
+				tokenAdded("client1", "agent1"),
+				samlResponseSent("app1", "agent1"),
+				samlResponseSent("app2", "agent2"),
+				user.NewHumanSignedOutEvent(context.Background(), agg, "agent1"),
+				samlResponseSent("app3", "agent1"),
+			},
+			wantSAML: []string{"app3"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rm := newSessionClientsReadModel("user1", "instance1", "agent1", 0)
+			rm.AppendEvents(tt.events...)
+			err := rm.Reduce()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, rm.ClientIDs)
+			assert.Equal(t, tt.wantSAML, rm.SAMLAppIDs)
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package idpsync
+
+import (
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	AggregateType    = "idp_sync"
+	AggregateVersion = "v1"
+)
+
+type Aggregate struct {
+	eventstore.Aggregate
+}
+
+// NewAggregate returns the sync aggregate of the identity provider,
+// the id of the aggregate is the id of the identity provider
+func NewAggregate(idpID, resourceOwner string) *Aggregate {
+	return &Aggregate{
+		Aggregate: eventstore.Aggregate{
+			Type:          AggregateType,
+			Version:       AggregateVersion,
+			ID:            idpID,
+			ResourceOwner: resourceOwner,
+		},
+	}
+}
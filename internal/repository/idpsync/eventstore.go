@@ -0,0 +1,12 @@
+package idpsync
+
+import (
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+func RegisterEventMappers(es *eventstore.Eventstore) {
+	es.RegisterFilterEventMapper(AggregateType, LDAPSyncSetType, LDAPSyncSetEventMapper).
+		RegisterFilterEventMapper(AggregateType, LDAPSyncRemovedType, LDAPSyncRemovedEventMapper).
+		RegisterFilterEventMapper(AggregateType, LDAPSyncRunSucceededType, LDAPSyncRunSucceededEventMapper).
+		RegisterFilterEventMapper(AggregateType, LDAPSyncRunFailedType, LDAPSyncRunFailedEventMapper)
+}
@@ -0,0 +1,210 @@
+package idpsync
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	ldapSyncEventPrefix      = "idp_sync.ldap."
+	LDAPSyncSetType          = ldapSyncEventPrefix + "set"
+	LDAPSyncRemovedType      = ldapSyncEventPrefix + "removed"
+	LDAPSyncRunSucceededType = ldapSyncEventPrefix + "run.succeeded"
+	LDAPSyncRunFailedType    = ldapSyncEventPrefix + "run.failed"
+)
+
+type LDAPSyncSetEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Enabled            bool                        `json:"enabled"`
+	Interval           time.Duration               `json:"interval"`
+	OrgID              string                      `json:"orgId"`
+	DeactivateMissing  bool                        `json:"deactivateMissing,omitempty"`
+	DisabledFilter     string                      `json:"disabledFilter,omitempty"`
+	MetadataAttributes []string                    `json:"metadataAttributes,omitempty"`
+	GroupsAttribute    string                      `json:"groupsAttribute,omitempty"`
+	GroupRules         []*domain.LDAPSyncGroupRule `json:"groupRules,omitempty"`
+}
+
+func (e *LDAPSyncSetEvent) Data() interface{} {
+	return e
+}
+
+func (e *LDAPSyncSetEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewLDAPSyncSetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	enabled bool,
+	interval time.Duration,
+	orgID string,
+	deactivateMissing bool,
+	disabledFilter string,
+	metadataAttributes []string,
+	groupsAttribute string,
+	groupRules []*domain.LDAPSyncGroupRule,
+) *LDAPSyncSetEvent {
+	return &LDAPSyncSetEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			LDAPSyncSetType,
+		),
+		Enabled:            enabled,
+		Interval:           interval,
+		OrgID:              orgID,
+		DeactivateMissing:  deactivateMissing,
+		DisabledFilter:     disabledFilter,
+		MetadataAttributes: metadataAttributes,
+		GroupsAttribute:    groupsAttribute,
+		GroupRules:         groupRules,
+	}
+}
+
+func LDAPSyncSetEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &LDAPSyncSetEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "IDPSYNC-Ls8dq", "unable to unmarshal ldap sync set")
+	}
+	return e, nil
+}
+
+type LDAPSyncRemovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+}
+
+func (e *LDAPSyncRemovedEvent) Data() interface{} {
+	return nil
+}
+
+func (e *LDAPSyncRemovedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewLDAPSyncRemovedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+) *LDAPSyncRemovedEvent {
+	return &LDAPSyncRemovedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			LDAPSyncRemovedType,
+		),
+	}
+}
+
+func LDAPSyncRemovedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	return &LDAPSyncRemovedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}, nil
+}
+
+type LDAPSyncRunSucceededEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	DryRun        bool      `json:"dryRun,omitempty"`
+	StartedAt     time.Time `json:"startedAt"`
+	Created       uint64    `json:"created,omitempty"`
+	Updated       uint64    `json:"updated,omitempty"`
+	Deactivated   uint64    `json:"deactivated,omitempty"`
+	GrantsChanged uint64    `json:"grantsChanged,omitempty"`
+	Failed        uint64    `json:"failed,omitempty"`
+}
+
+func (e *LDAPSyncRunSucceededEvent) Data() interface{} {
+	return e
+}
+
+func (e *LDAPSyncRunSucceededEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewLDAPSyncRunSucceededEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	report *domain.LDAPSyncReport,
+) *LDAPSyncRunSucceededEvent {
+	return &LDAPSyncRunSucceededEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			LDAPSyncRunSucceededType,
+		),
+		DryRun:        report.DryRun,
+		StartedAt:     report.StartedAt,
+		Created:       report.Created,
+		Updated:       report.Updated,
+		Deactivated:   report.Deactivated,
+		GrantsChanged: report.GrantsChanged,
+		Failed:        report.Failed,
+	}
+}
+
+func LDAPSyncRunSucceededEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &LDAPSyncRunSucceededEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "IDPSYNC-Lr4mz", "unable to unmarshal ldap sync run succeeded")
+	}
+	return e, nil
+}
+
+type LDAPSyncRunFailedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	DryRun    bool      `json:"dryRun,omitempty"`
+	StartedAt time.Time `json:"startedAt"`
+	Error     string    `json:"error"`
+}
+
+func (e *LDAPSyncRunFailedEvent) Data() interface{} {
+	return e
+}
+
+func (e *LDAPSyncRunFailedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewLDAPSyncRunFailedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	dryRun bool,
+	startedAt time.Time,
+	err string,
+) *LDAPSyncRunFailedEvent {
+	return &LDAPSyncRunFailedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			LDAPSyncRunFailedType,
+		),
+		DryRun:    dryRun,
+		StartedAt: startedAt,
+		Error:     err,
+	}
+}
+
+func LDAPSyncRunFailedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &LDAPSyncRunFailedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "IDPSYNC-Lf7ka", "unable to unmarshal ldap sync run failed")
+	}
+	return e, nil
+}
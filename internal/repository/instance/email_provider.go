@@ -0,0 +1,351 @@
+package instance
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	emailProviderPrefix                      = "email.provider."
+	emailProviderHTTPPrefix                  = emailProviderPrefix + "http."
+	EmailProviderHTTPAddedEventType          = instanceEventTypePrefix + emailProviderHTTPPrefix + "added"
+	EmailProviderHTTPChangedEventType        = instanceEventTypePrefix + emailProviderHTTPPrefix + "changed"
+	EmailProviderHTTPHeadersChangedEventType = instanceEventTypePrefix + emailProviderHTTPPrefix + "headers.changed"
+	EmailProviderActivatedEventType          = instanceEventTypePrefix + emailProviderPrefix + "activated"
+	EmailProviderDeactivatedEventType        = instanceEventTypePrefix + emailProviderPrefix + "deactivated"
+	EmailProviderRemovedEventType            = instanceEventTypePrefix + emailProviderPrefix + "removed"
+)
+
+type EmailProviderHTTPAddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ID            string              `json:"id,omitempty"`
+	Description   string              `json:"description,omitempty"`
+	Priority      int32               `json:"priority,omitempty"`
+	SenderAddress string              `json:"senderAddress,omitempty"`
+	SenderName    string              `json:"senderName,omitempty"`
+	Endpoint      string              `json:"endpoint,omitempty"`
+	Method        string              `json:"method,omitempty"`
+	Headers       *crypto.CryptoValue `json:"headers,omitempty"`
+	BodyTemplate  string              `json:"bodyTemplate,omitempty"`
+}
+
+func NewEmailProviderHTTPAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id,
+	description string,
+	priority int32,
+	senderAddress,
+	senderName,
+	endpoint,
+	method string,
+	headers *crypto.CryptoValue,
+	bodyTemplate string,
+) *EmailProviderHTTPAddedEvent {
+	return &EmailProviderHTTPAddedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			EmailProviderHTTPAddedEventType,
+		),
+		ID:            id,
+		Description:   description,
+		Priority:      priority,
+		SenderAddress: senderAddress,
+		SenderName:    senderName,
+		Endpoint:      endpoint,
+		Method:        method,
+		Headers:       headers,
+		BodyTemplate:  bodyTemplate,
+	}
+}
+
+func (e *EmailProviderHTTPAddedEvent) Data() interface{} {
+	return e
+}
+
+func (e *EmailProviderHTTPAddedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func EmailProviderHTTPAddedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	providerAdded := &EmailProviderHTTPAddedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, providerAdded)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "IAM-Ep3ka", "unable to unmarshal email provider http added")
+	}
+
+	return providerAdded, nil
+}
+
+type EmailProviderHTTPChangedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ID            string  `json:"id,omitempty"`
+	Description   *string `json:"description,omitempty"`
+	Priority      *int32  `json:"priority,omitempty"`
+	SenderAddress *string `json:"senderAddress,omitempty"`
+	SenderName    *string `json:"senderName,omitempty"`
+	Endpoint      *string `json:"endpoint,omitempty"`
+	Method        *string `json:"method,omitempty"`
+	BodyTemplate  *string `json:"bodyTemplate,omitempty"`
+}
+
+func NewEmailProviderHTTPChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+	changes []EmailProviderHTTPChanges,
+) (*EmailProviderHTTPChangedEvent, error) {
+	if len(changes) == 0 {
+		return nil, errors.ThrowPreconditionFailed(nil, "IAM-Ep8cn", "Errors.NoChangesFound")
+	}
+	changeEvent := &EmailProviderHTTPChangedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			EmailProviderHTTPChangedEventType,
+		),
+		ID: id,
+	}
+	for _, change := range changes {
+		change(changeEvent)
+	}
+	return changeEvent, nil
+}
+
+type EmailProviderHTTPChanges func(event *EmailProviderHTTPChangedEvent)
+
+func ChangeEmailProviderHTTPDescription(description string) func(event *EmailProviderHTTPChangedEvent) {
+	return func(e *EmailProviderHTTPChangedEvent) {
+		e.Description = &description
+	}
+}
+
+func ChangeEmailProviderHTTPPriority(priority int32) func(event *EmailProviderHTTPChangedEvent) {
+	return func(e *EmailProviderHTTPChangedEvent) {
+		e.Priority = &priority
+	}
+}
+
+func ChangeEmailProviderHTTPSenderAddress(senderAddress string) func(event *EmailProviderHTTPChangedEvent) {
+	return func(e *EmailProviderHTTPChangedEvent) {
+		e.SenderAddress = &senderAddress
+	}
+}
+
+func ChangeEmailProviderHTTPSenderName(senderName string) func(event *EmailProviderHTTPChangedEvent) {
+	return func(e *EmailProviderHTTPChangedEvent) {
+		e.SenderName = &senderName
+	}
+}
+
+func ChangeEmailProviderHTTPEndpoint(endpoint string) func(event *EmailProviderHTTPChangedEvent) {
+	return func(e *EmailProviderHTTPChangedEvent) {
+		e.Endpoint = &endpoint
+	}
+}
+
+func ChangeEmailProviderHTTPMethod(method string) func(event *EmailProviderHTTPChangedEvent) {
+	return func(e *EmailProviderHTTPChangedEvent) {
+		e.Method = &method
+	}
+}
+
+func ChangeEmailProviderHTTPBodyTemplate(bodyTemplate string) func(event *EmailProviderHTTPChangedEvent) {
+	return func(e *EmailProviderHTTPChangedEvent) {
+		e.BodyTemplate = &bodyTemplate
+	}
+}
+
+func (e *EmailProviderHTTPChangedEvent) Data() interface{} {
+	return e
+}
+
+func (e *EmailProviderHTTPChangedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func EmailProviderHTTPChangedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	providerChanged := &EmailProviderHTTPChangedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, providerChanged)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "IAM-Ep6wd", "unable to unmarshal email provider http changed")
+	}
+
+	return providerChanged, nil
+}
+
+type EmailProviderHTTPHeadersChangedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ID      string              `json:"id,omitempty"`
+	Headers *crypto.CryptoValue `json:"headers,omitempty"`
+}
+
+func NewEmailProviderHTTPHeadersChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+	headers *crypto.CryptoValue,
+) *EmailProviderHTTPHeadersChangedEvent {
+	return &EmailProviderHTTPHeadersChangedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			EmailProviderHTTPHeadersChangedEventType,
+		),
+		ID:      id,
+		Headers: headers,
+	}
+}
+
+func (e *EmailProviderHTTPHeadersChangedEvent) Data() interface{} {
+	return e
+}
+
+func (e *EmailProviderHTTPHeadersChangedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func EmailProviderHTTPHeadersChangedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	headersChanged := &EmailProviderHTTPHeadersChangedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, headersChanged)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "IAM-Ep2hs", "unable to unmarshal email provider http headers changed")
+	}
+
+	return headersChanged, nil
+}
+
+type EmailProviderActivatedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+	ID                   string `json:"id,omitempty"`
+}
+
+func NewEmailProviderActivatedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+) *EmailProviderActivatedEvent {
+	return &EmailProviderActivatedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			EmailProviderActivatedEventType,
+		),
+		ID: id,
+	}
+}
+
+func (e *EmailProviderActivatedEvent) Data() interface{} {
+	return e
+}
+
+func (e *EmailProviderActivatedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func EmailProviderActivatedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	providerActivated := &EmailProviderActivatedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, providerActivated)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "IAM-Ep5nv", "unable to unmarshal email provider activated")
+	}
+
+	return providerActivated, nil
+}
+
+type EmailProviderDeactivatedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+	ID                   string `json:"id,omitempty"`
+}
+
+func NewEmailProviderDeactivatedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+) *EmailProviderDeactivatedEvent {
+	return &EmailProviderDeactivatedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			EmailProviderDeactivatedEventType,
+		),
+		ID: id,
+	}
+}
+
+func (e *EmailProviderDeactivatedEvent) Data() interface{} {
+	return e
+}
+
+func (e *EmailProviderDeactivatedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func EmailProviderDeactivatedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	providerDeactivated := &EmailProviderDeactivatedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, providerDeactivated)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "IAM-Ep9tb", "unable to unmarshal email provider deactivated")
+	}
+
+	return providerDeactivated, nil
+}
+
+type EmailProviderRemovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+	ID                   string `json:"id,omitempty"`
+}
+
+func NewEmailProviderRemovedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+) *EmailProviderRemovedEvent {
+	return &EmailProviderRemovedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			EmailProviderRemovedEventType,
+		),
+		ID: id,
+	}
+}
+
+func (e *EmailProviderRemovedEvent) Data() interface{} {
+	return e
+}
+
+func (e *EmailProviderRemovedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func EmailProviderRemovedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	providerRemoved := &EmailProviderRemovedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, providerRemoved)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "IAM-Ep4rx", "unable to unmarshal email provider removed")
+	}
+
+	return providerRemoved, nil
+}
@@ -22,6 +22,12 @@ func RegisterEventMappers(es *eventstore.Eventstore) {
 		RegisterFilterEventMapper(AggregateType, SMSConfigActivatedEventType, SMSConfigActivatedEventMapper).
 		RegisterFilterEventMapper(AggregateType, SMSConfigDeactivatedEventType, SMSConfigDeactivatedEventMapper).
 		RegisterFilterEventMapper(AggregateType, SMSConfigRemovedEventType, SMSConfigRemovedEventMapper).
+		RegisterFilterEventMapper(AggregateType, EmailProviderHTTPAddedEventType, EmailProviderHTTPAddedEventMapper).
+		RegisterFilterEventMapper(AggregateType, EmailProviderHTTPChangedEventType, EmailProviderHTTPChangedEventMapper).
+		RegisterFilterEventMapper(AggregateType, EmailProviderHTTPHeadersChangedEventType, EmailProviderHTTPHeadersChangedEventMapper).
+		RegisterFilterEventMapper(AggregateType, EmailProviderActivatedEventType, EmailProviderActivatedEventMapper).
+		RegisterFilterEventMapper(AggregateType, EmailProviderDeactivatedEventType, EmailProviderDeactivatedEventMapper).
+		RegisterFilterEventMapper(AggregateType, EmailProviderRemovedEventType, EmailProviderRemovedEventMapper).
 		RegisterFilterEventMapper(AggregateType, DebugNotificationProviderFileAddedEventType, DebugNotificationProviderFileAddedEventMapper).
 		RegisterFilterEventMapper(AggregateType, DebugNotificationProviderFileChangedEventType, DebugNotificationProviderFileChangedEventMapper).
 		RegisterFilterEventMapper(AggregateType, DebugNotificationProviderFileRemovedEventType, DebugNotificationProviderFileRemovedEventMapper).
@@ -31,6 +37,7 @@ func RegisterEventMappers(es *eventstore.Eventstore) {
 		RegisterFilterEventMapper(AggregateType, OIDCSettingsAddedEventType, OIDCSettingsAddedEventMapper).
 		RegisterFilterEventMapper(AggregateType, OIDCSettingsChangedEventType, OIDCSettingsChangedEventMapper).
 		RegisterFilterEventMapper(AggregateType, SecurityPolicySetEventType, SecurityPolicySetEventMapper).
+		RegisterFilterEventMapper(AggregateType, ClientRegistrationPolicySetEventType, ClientRegistrationPolicySetEventMapper).
 		RegisterFilterEventMapper(AggregateType, LabelPolicyAddedEventType, LabelPolicyAddedEventMapper).
 		RegisterFilterEventMapper(AggregateType, LabelPolicyChangedEventType, LabelPolicyChangedEventMapper).
 		RegisterFilterEventMapper(AggregateType, LabelPolicyActivatedEventType, LabelPolicyActivatedEventMapper).
@@ -100,6 +107,8 @@ func RegisterEventMappers(es *eventstore.Eventstore) {
 		RegisterFilterEventMapper(AggregateType, LoginPolicyMultiFactorRemovedEventType, MultiFactorRemovedEventMapper).
 		RegisterFilterEventMapper(AggregateType, MailTemplateAddedEventType, MailTemplateAddedEventMapper).
 		RegisterFilterEventMapper(AggregateType, MailTemplateChangedEventType, MailTemplateChangedEventMapper).
+		RegisterFilterEventMapper(AggregateType, MessageMailTemplateSetEventType, MessageMailTemplateSetEventMapper).
+		RegisterFilterEventMapper(AggregateType, MessageMailTemplateRemovedEventType, MessageMailTemplateRemovedEventMapper).
 		RegisterFilterEventMapper(AggregateType, MailTextAddedEventType, MailTextAddedEventMapper).
 		RegisterFilterEventMapper(AggregateType, MailTextChangedEventType, MailTextChangedEventMapper).
 		RegisterFilterEventMapper(AggregateType, CustomTextSetEventType, CustomTextSetEventMapper).
@@ -0,0 +1,84 @@
+package instance
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	clientRegistrationPolicyPrefix       = "policy.client_registration."
+	ClientRegistrationPolicySetEventType = instanceEventTypePrefix + clientRegistrationPolicyPrefix + "set"
+)
+
+type ClientRegistrationPolicySetEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	AllowedGrantTypes   *[]domain.OIDCGrantType `json:"allowedGrantTypes,omitempty"`
+	RedirectURIPatterns *[]string               `json:"redirectUriPatterns,omitempty"`
+}
+
+func NewClientRegistrationPolicySetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	changes []ClientRegistrationPolicyChanges,
+) (*ClientRegistrationPolicySetEvent, error) {
+	if len(changes) == 0 {
+		return nil, errors.ThrowPreconditionFailed(nil, "POLICY-Uf3ns", "Errors.NoChangesFound")
+	}
+	event := &ClientRegistrationPolicySetEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			ClientRegistrationPolicySetEventType,
+		),
+	}
+	for _, change := range changes {
+		change(event)
+	}
+	return event, nil
+}
+
+type ClientRegistrationPolicyChanges func(event *ClientRegistrationPolicySetEvent)
+
+func ChangeClientRegistrationPolicyAllowedGrantTypes(grantTypes []domain.OIDCGrantType) func(event *ClientRegistrationPolicySetEvent) {
+	return func(e *ClientRegistrationPolicySetEvent) {
+		if len(grantTypes) == 0 {
+			grantTypes = []domain.OIDCGrantType{}
+		}
+		e.AllowedGrantTypes = &grantTypes
+	}
+}
+
+func ChangeClientRegistrationPolicyRedirectURIPatterns(patterns []string) func(event *ClientRegistrationPolicySetEvent) {
+	return func(e *ClientRegistrationPolicySetEvent) {
+		if len(patterns) == 0 {
+			patterns = []string{}
+		}
+		e.RedirectURIPatterns = &patterns
+	}
+}
+
+func (e *ClientRegistrationPolicySetEvent) Data() interface{} {
+	return e
+}
+
+func (e *ClientRegistrationPolicySetEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func ClientRegistrationPolicySetEventMapper(event *repository.Event) (eventstore.Event, error) {
+	policySet := &ClientRegistrationPolicySetEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, policySet)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "IAM-Ne4xb", "unable to unmarshal client registration policy set")
+	}
+
+	return policySet, nil
+}
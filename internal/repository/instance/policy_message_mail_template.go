@@ -0,0 +1,73 @@
+package instance
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/policy"
+)
+
+var (
+	MessageMailTemplateSetEventType     = instanceEventTypePrefix + policy.MessageMailTemplatePolicySetEventType
+	MessageMailTemplateRemovedEventType = instanceEventTypePrefix + policy.MessageMailTemplatePolicyRemovedEventType
+)
+
+type MessageMailTemplateSetEvent struct {
+	policy.MessageMailTemplateSetEvent
+}
+
+func NewMessageMailTemplateSetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	messageType string,
+	format domain.MessageTemplateFormat,
+	source,
+	template []byte,
+) *MessageMailTemplateSetEvent {
+	return &MessageMailTemplateSetEvent{
+		MessageMailTemplateSetEvent: *policy.NewMessageMailTemplateSetEvent(
+			eventstore.NewBaseEventForPush(ctx, aggregate, MessageMailTemplateSetEventType),
+			messageType,
+			format,
+			source,
+			template,
+		),
+	}
+}
+
+func MessageMailTemplateSetEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e, err := policy.MessageMailTemplateSetEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MessageMailTemplateSetEvent{MessageMailTemplateSetEvent: *e.(*policy.MessageMailTemplateSetEvent)}, nil
+}
+
+type MessageMailTemplateRemovedEvent struct {
+	policy.MessageMailTemplateRemovedEvent
+}
+
+func NewMessageMailTemplateRemovedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	messageType string,
+) *MessageMailTemplateRemovedEvent {
+	return &MessageMailTemplateRemovedEvent{
+		MessageMailTemplateRemovedEvent: *policy.NewMessageMailTemplateRemovedEvent(
+			eventstore.NewBaseEventForPush(ctx, aggregate, MessageMailTemplateRemovedEventType),
+			messageType,
+		),
+	}
+}
+
+func MessageMailTemplateRemovedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e, err := policy.MessageMailTemplateRemovedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MessageMailTemplateRemovedEvent{MessageMailTemplateRemovedEvent: *e.(*policy.MessageMailTemplateRemovedEvent)}, nil
+}
@@ -20,7 +20,8 @@ type NotificationPolicyAddedEvent struct {
 func NewNotificationPolicyAddedEvent(
 	ctx context.Context,
 	aggregate *eventstore.Aggregate,
-	passwordChange bool,
+	passwordChange,
+	securityNotifications bool,
 ) *NotificationPolicyAddedEvent {
 	return &NotificationPolicyAddedEvent{
 		NotificationPolicyAddedEvent: *policy.NewNotificationPolicyAddedEvent(
@@ -28,7 +29,8 @@ func NewNotificationPolicyAddedEvent(
 				ctx,
 				aggregate,
 				NotificationPolicyAddedEventType),
-			passwordChange),
+			passwordChange,
+			securityNotifications),
 	}
 }
 
@@ -27,6 +27,7 @@ type SMTPConfigAddedEvent struct {
 	Host          string              `json:"host,omitempty"`
 	User          string              `json:"user,omitempty"`
 	Password      *crypto.CryptoValue `json:"password,omitempty"`
+	Priority      int32               `json:"priority,omitempty"`
 }
 
 func NewSMTPConfigAddedEvent(
@@ -38,6 +39,7 @@ func NewSMTPConfigAddedEvent(
 	host,
 	user string,
 	password *crypto.CryptoValue,
+	priority int32,
 ) *SMTPConfigAddedEvent {
 	return &SMTPConfigAddedEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
@@ -51,6 +53,7 @@ func NewSMTPConfigAddedEvent(
 		Host:          host,
 		User:          user,
 		Password:      password,
+		Priority:      priority,
 	}
 }
 
@@ -82,6 +85,7 @@ type SMTPConfigChangedEvent struct {
 	TLS         *bool   `json:"tls,omitempty"`
 	Host        *string `json:"host,omitempty"`
 	User        *string `json:"user,omitempty"`
+	Priority    *int32  `json:"priority,omitempty"`
 }
 
 func (e *SMTPConfigChangedEvent) Data() interface{} {
@@ -145,6 +149,12 @@ func ChangeSMTPConfigSMTPUser(smtpUser string) func(event *SMTPConfigChangedEven
 	}
 }
 
+func ChangeSMTPConfigPriority(priority int32) func(event *SMTPConfigChangedEvent) {
+	return func(e *SMTPConfigChangedEvent) {
+		e.Priority = &priority
+	}
+}
+
 func SMTPConfigChangedEventMapper(event *repository.Event) (eventstore.Event, error) {
 	e := &SMTPConfigChangedEvent{
 		BaseEvent: *eventstore.BaseEventFromRepo(event),
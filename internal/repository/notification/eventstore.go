@@ -0,0 +1,14 @@
+package notification
+
+import (
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+func RegisterEventMappers(es *eventstore.Eventstore) {
+	es.RegisterFilterEventMapper(AggregateType, MessageQueuedType, MessageQueuedEventMapper).
+		RegisterFilterEventMapper(AggregateType, MessageSentType, MessageSentEventMapper).
+		RegisterFilterEventMapper(AggregateType, MessageAttemptFailedType, MessageAttemptFailedEventMapper).
+		RegisterFilterEventMapper(AggregateType, MessageFailedType, MessageFailedEventMapper).
+		RegisterFilterEventMapper(AggregateType, MessageResendRequestedType, MessageResendRequestedEventMapper).
+		RegisterFilterEventMapper(AggregateType, MessageDiscardedType, MessageDiscardedEventMapper)
+}
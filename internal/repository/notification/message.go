@@ -0,0 +1,289 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	UniqueMessageTriggerType   = "notification_message_trigger"
+	messageEventPrefix         = "notification.message."
+	MessageQueuedType          = messageEventPrefix + "queued"
+	MessageSentType            = messageEventPrefix + "sent"
+	MessageAttemptFailedType   = messageEventPrefix + "attempt.failed"
+	MessageFailedType          = messageEventPrefix + "failed"
+	MessageResendRequestedType = messageEventPrefix + "resend.requested"
+	MessageDiscardedType       = messageEventPrefix + "discarded"
+)
+
+// NewAddMessageTriggerUniqueConstraint prevents the same message being queued twice for the triggering event,
+// e.g. if the notification handler reduces the event again
+func NewAddMessageTriggerUniqueConstraint(triggerAggregateID string, triggerSequence uint64, messageType string) *eventstore.EventUniqueConstraint {
+	return eventstore.NewAddEventUniqueConstraint(
+		UniqueMessageTriggerType,
+		triggerAggregateID+":"+strconv.FormatUint(triggerSequence, 10)+":"+messageType,
+		"Errors.Notification.Outbox.AlreadyQueued",
+	)
+}
+
+type MessageQueuedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	UserID             string                  `json:"userId"`
+	MessageType        string                  `json:"messageType"`
+	Channel            domain.NotificationType `json:"channel"`
+	Recipient          string                  `json:"recipient"`
+	Content            *crypto.CryptoValue     `json:"content"`
+	TriggerAggregateID string                  `json:"triggerAggregateId"`
+	TriggerEventType   eventstore.EventType    `json:"triggerEventType"`
+	TriggerSequence    uint64                  `json:"triggerSequence"`
+}
+
+func (e *MessageQueuedEvent) Data() interface{} {
+	return e
+}
+
+func (e *MessageQueuedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return []*eventstore.EventUniqueConstraint{NewAddMessageTriggerUniqueConstraint(e.TriggerAggregateID, e.TriggerSequence, e.MessageType)}
+}
+
+func NewMessageQueuedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	userID,
+	messageType string,
+	channel domain.NotificationType,
+	recipient string,
+	content *crypto.CryptoValue,
+	triggeringEvent eventstore.Event,
+) *MessageQueuedEvent {
+	return &MessageQueuedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			MessageQueuedType,
+		),
+		UserID:             userID,
+		MessageType:        messageType,
+		Channel:            channel,
+		Recipient:          recipient,
+		Content:            content,
+		TriggerAggregateID: triggeringEvent.Aggregate().ID,
+		TriggerEventType:   triggeringEvent.Type(),
+		TriggerSequence:    triggeringEvent.Sequence(),
+	}
+}
+
+func MessageQueuedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &MessageQueuedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "NOTIF-Qw3ds", "unable to unmarshal notification message queued")
+	}
+	return e, nil
+}
+
+type MessageSentEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Attempt uint64 `json:"attempt"`
+}
+
+func (e *MessageSentEvent) Data() interface{} {
+	return e
+}
+
+func (e *MessageSentEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewMessageSentEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	attempt uint64,
+) *MessageSentEvent {
+	return &MessageSentEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			MessageSentType,
+		),
+		Attempt: attempt,
+	}
+}
+
+func MessageSentEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &MessageSentEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "NOTIF-Ms9vb", "unable to unmarshal notification message sent")
+	}
+	return e, nil
+}
+
+// MessageAttemptFailedEvent is pushed if a delivery attempt failed,
+// the message is retried at NextAttempt
+type MessageAttemptFailedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Attempt     uint64    `json:"attempt"`
+	Error       string    `json:"error"`
+	NextAttempt time.Time `json:"nextAttempt"`
+}
+
+func (e *MessageAttemptFailedEvent) Data() interface{} {
+	return e
+}
+
+func (e *MessageAttemptFailedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewMessageAttemptFailedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	attempt uint64,
+	err string,
+	nextAttempt time.Time,
+) *MessageAttemptFailedEvent {
+	return &MessageAttemptFailedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			MessageAttemptFailedType,
+		),
+		Attempt:     attempt,
+		Error:       err,
+		NextAttempt: nextAttempt,
+	}
+}
+
+func MessageAttemptFailedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &MessageAttemptFailedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "NOTIF-Af4rt", "unable to unmarshal notification message attempt failed")
+	}
+	return e, nil
+}
+
+// MessageFailedEvent is pushed if the last delivery attempt failed,
+// the message is dead lettered and only delivered again if a resend is requested
+type MessageFailedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Attempt uint64 `json:"attempt"`
+	Error   string `json:"error"`
+}
+
+func (e *MessageFailedEvent) Data() interface{} {
+	return e
+}
+
+func (e *MessageFailedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewMessageFailedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	attempt uint64,
+	err string,
+) *MessageFailedEvent {
+	return &MessageFailedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			MessageFailedType,
+		),
+		Attempt: attempt,
+		Error:   err,
+	}
+}
+
+func MessageFailedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &MessageFailedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "NOTIF-Fd2kl", "unable to unmarshal notification message failed")
+	}
+	return e, nil
+}
+
+type MessageResendRequestedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+}
+
+func (e *MessageResendRequestedEvent) Data() interface{} {
+	return nil
+}
+
+func (e *MessageResendRequestedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewMessageResendRequestedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+) *MessageResendRequestedEvent {
+	return &MessageResendRequestedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			MessageResendRequestedType,
+		),
+	}
+}
+
+func MessageResendRequestedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	return &MessageResendRequestedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}, nil
+}
+
+type MessageDiscardedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+}
+
+func (e *MessageDiscardedEvent) Data() interface{} {
+	return nil
+}
+
+func (e *MessageDiscardedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewMessageDiscardedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+) *MessageDiscardedEvent {
+	return &MessageDiscardedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			MessageDiscardedType,
+		),
+	}
+}
+
+func MessageDiscardedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	return &MessageDiscardedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}, nil
+}
@@ -64,6 +64,8 @@ func RegisterEventMappers(es *eventstore.Eventstore) {
 		RegisterFilterEventMapper(AggregateType, MailTemplateAddedEventType, MailTemplateAddedEventMapper).
 		RegisterFilterEventMapper(AggregateType, MailTemplateChangedEventType, MailTemplateChangedEventMapper).
 		RegisterFilterEventMapper(AggregateType, MailTemplateRemovedEventType, MailTemplateRemovedEventMapper).
+		RegisterFilterEventMapper(AggregateType, MessageMailTemplateSetEventType, MessageMailTemplateSetEventMapper).
+		RegisterFilterEventMapper(AggregateType, MessageMailTemplateRemovedEventType, MessageMailTemplateRemovedEventMapper).
 		RegisterFilterEventMapper(AggregateType, MailTextAddedEventType, MailTextAddedEventMapper).
 		RegisterFilterEventMapper(AggregateType, MailTextChangedEventType, MailTextChangedEventMapper).
 		RegisterFilterEventMapper(AggregateType, MailTextRemovedEventType, MailTextRemovedEventMapper).
@@ -21,7 +21,8 @@ type NotificationPolicyAddedEvent struct {
 func NewNotificationPolicyAddedEvent(
 	ctx context.Context,
 	aggregate *eventstore.Aggregate,
-	passwordChange bool,
+	passwordChange,
+	securityNotifications bool,
 ) *NotificationPolicyAddedEvent {
 	return &NotificationPolicyAddedEvent{
 		NotificationPolicyAddedEvent: *policy.NewNotificationPolicyAddedEvent(
@@ -30,6 +31,7 @@ func NewNotificationPolicyAddedEvent(
 				aggregate,
 				NotificationPolicyAddedEventType),
 			passwordChange,
+			securityNotifications,
 		),
 	}
 }
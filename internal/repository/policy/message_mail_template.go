@@ -0,0 +1,100 @@
+package policy
+
+import (
+	"encoding/json"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	messageMailTemplatePolicyPrefix           = mailTemplatePolicyPrefix + "message."
+	MessageMailTemplatePolicySetEventType     = messageMailTemplatePolicyPrefix + "set"
+	MessageMailTemplatePolicyRemovedEventType = messageMailTemplatePolicyPrefix + "removed"
+)
+
+type MessageMailTemplateSetEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	MessageType string                       `json:"messageType,omitempty"`
+	Format      domain.MessageTemplateFormat `json:"format,omitempty"`
+	Source      []byte                       `json:"source,omitempty"`
+	// Template is the compiled and validated HTML of the Source
+	Template []byte `json:"template,omitempty"`
+}
+
+func (e *MessageMailTemplateSetEvent) Data() interface{} {
+	return e
+}
+
+func (e *MessageMailTemplateSetEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewMessageMailTemplateSetEvent(
+	base *eventstore.BaseEvent,
+	messageType string,
+	format domain.MessageTemplateFormat,
+	source,
+	template []byte,
+) *MessageMailTemplateSetEvent {
+	return &MessageMailTemplateSetEvent{
+		BaseEvent:   *base,
+		MessageType: messageType,
+		Format:      format,
+		Source:      source,
+		Template:    template,
+	}
+}
+
+func MessageMailTemplateSetEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &MessageMailTemplateSetEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "POLIC-Mt4sx", "unable to unmarshal message mail template")
+	}
+
+	return e, nil
+}
+
+type MessageMailTemplateRemovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	MessageType string `json:"messageType,omitempty"`
+}
+
+func (e *MessageMailTemplateRemovedEvent) Data() interface{} {
+	return e
+}
+
+func (e *MessageMailTemplateRemovedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewMessageMailTemplateRemovedEvent(
+	base *eventstore.BaseEvent,
+	messageType string,
+) *MessageMailTemplateRemovedEvent {
+	return &MessageMailTemplateRemovedEvent{
+		BaseEvent:   *base,
+		MessageType: messageType,
+	}
+}
+
+func MessageMailTemplateRemovedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &MessageMailTemplateRemovedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "POLIC-Mr8wq", "unable to unmarshal message mail template")
+	}
+
+	return e, nil
+}
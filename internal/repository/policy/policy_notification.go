@@ -17,7 +17,8 @@ const (
 type NotificationPolicyAddedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
-	PasswordChange bool `json:"passwordChange,omitempty"`
+	PasswordChange        bool `json:"passwordChange,omitempty"`
+	SecurityNotifications bool `json:"securityNotifications,omitempty"`
 }
 
 func (e *NotificationPolicyAddedEvent) Data() interface{} {
@@ -30,11 +31,13 @@ func (e *NotificationPolicyAddedEvent) UniqueConstraints() []*eventstore.EventUn
 
 func NewNotificationPolicyAddedEvent(
 	base *eventstore.BaseEvent,
-	passwordChange bool,
+	passwordChange,
+	securityNotifications bool,
 ) *NotificationPolicyAddedEvent {
 	return &NotificationPolicyAddedEvent{
-		BaseEvent:      *base,
-		PasswordChange: passwordChange,
+		BaseEvent:             *base,
+		PasswordChange:        passwordChange,
+		SecurityNotifications: securityNotifications,
 	}
 }
 
@@ -54,7 +57,8 @@ func NotificationPolicyAddedEventMapper(event *repository.Event) (eventstore.Eve
 type NotificationPolicyChangedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
-	PasswordChange *bool `json:"passwordChange,omitempty"`
+	PasswordChange        *bool `json:"passwordChange,omitempty"`
+	SecurityNotifications *bool `json:"securityNotifications,omitempty"`
 }
 
 func (e *NotificationPolicyChangedEvent) Data() interface{} {
@@ -89,6 +93,12 @@ func ChangePasswordChange(passwordChange bool) func(*NotificationPolicyChangedEv
 	}
 }
 
+func ChangeSecurityNotifications(securityNotifications bool) func(*NotificationPolicyChangedEvent) {
+	return func(e *NotificationPolicyChangedEvent) {
+		e.SecurityNotifications = &securityNotifications
+	}
+}
+
 func NotificationPolicyChangedEventMapper(event *repository.Event) (eventstore.Event, error) {
 	e := &NotificationPolicyChangedEvent{
 		BaseEvent: *eventstore.BaseEventFromRepo(event),
@@ -0,0 +1,108 @@
+package project
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	initialAccessTokenEventTypePrefix = projectEventTypePrefix + "initial_access_token."
+	InitialAccessTokenAddedType       = initialAccessTokenEventTypePrefix + "added"
+	InitialAccessTokenRemovedType     = initialAccessTokenEventTypePrefix + "removed"
+)
+
+type InitialAccessTokenAddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	TokenID    string              `json:"tokenId"`
+	Token      *crypto.CryptoValue `json:"token,omitempty"`
+	Expiration time.Time           `json:"expiration,omitempty"`
+}
+
+func (e *InitialAccessTokenAddedEvent) Data() interface{} {
+	return e
+}
+
+func (e *InitialAccessTokenAddedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewInitialAccessTokenAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	tokenID string,
+	token *crypto.CryptoValue,
+	expiration time.Time,
+) *InitialAccessTokenAddedEvent {
+	return &InitialAccessTokenAddedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			InitialAccessTokenAddedType,
+		),
+		TokenID:    tokenID,
+		Token:      token,
+		Expiration: expiration,
+	}
+}
+
+func InitialAccessTokenAddedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &InitialAccessTokenAddedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "PROJECT-Hd82k", "unable to unmarshal initial access token")
+	}
+
+	return e, nil
+}
+
+type InitialAccessTokenRemovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	TokenID string `json:"tokenId"`
+}
+
+func (e *InitialAccessTokenRemovedEvent) Data() interface{} {
+	return e
+}
+
+func (e *InitialAccessTokenRemovedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewInitialAccessTokenRemovedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	tokenID string,
+) *InitialAccessTokenRemovedEvent {
+	return &InitialAccessTokenRemovedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			InitialAccessTokenRemovedType,
+		),
+		TokenID: tokenID,
+	}
+}
+
+func InitialAccessTokenRemovedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &InitialAccessTokenRemovedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "PROJECT-Ow2ns", "unable to unmarshal initial access token")
+	}
+
+	return e, nil
+}
@@ -16,6 +16,7 @@ const (
 	OIDCConfigAddedType                = applicationEventTypePrefix + "config.oidc.added"
 	OIDCConfigChangedType              = applicationEventTypePrefix + "config.oidc.changed"
 	OIDCConfigSecretChangedType        = applicationEventTypePrefix + "config.oidc.secret.changed"
+	OIDCConfigRegistrationTokenSetType = applicationEventTypePrefix + "config.oidc.registration.token.set"
 	OIDCClientSecretCheckSucceededType = applicationEventTypePrefix + "oidc.secret.check.succeeded"
 	OIDCClientSecretCheckFailedType    = applicationEventTypePrefix + "oidc.secret.check.failed"
 )
@@ -23,24 +24,30 @@ const (
 type OIDCConfigAddedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
-	Version                  domain.OIDCVersion         `json:"oidcVersion,omitempty"`
-	AppID                    string                     `json:"appId"`
-	ClientID                 string                     `json:"clientId,omitempty"`
-	ClientSecret             *crypto.CryptoValue        `json:"clientSecret,omitempty"`
-	RedirectUris             []string                   `json:"redirectUris,omitempty"`
-	ResponseTypes            []domain.OIDCResponseType  `json:"responseTypes,omitempty"`
-	GrantTypes               []domain.OIDCGrantType     `json:"grantTypes,omitempty"`
-	ApplicationType          domain.OIDCApplicationType `json:"applicationType,omitempty"`
-	AuthMethodType           domain.OIDCAuthMethodType  `json:"authMethodType,omitempty"`
-	PostLogoutRedirectUris   []string                   `json:"postLogoutRedirectUris,omitempty"`
-	DevMode                  bool                       `json:"devMode,omitempty"`
-	AccessTokenType          domain.OIDCTokenType       `json:"accessTokenType,omitempty"`
-	AccessTokenRoleAssertion bool                       `json:"accessTokenRoleAssertion,omitempty"`
-	IDTokenRoleAssertion     bool                       `json:"idTokenRoleAssertion,omitempty"`
-	IDTokenUserinfoAssertion bool                       `json:"idTokenUserinfoAssertion,omitempty"`
-	ClockSkew                time.Duration              `json:"clockSkew,omitempty"`
-	AdditionalOrigins        []string                   `json:"additionalOrigins,omitempty"`
-	SkipNativeAppSuccessPage bool                       `json:"skipNativeAppSuccessPage,omitempty"`
+	Version                    domain.OIDCVersion         `json:"oidcVersion,omitempty"`
+	AppID                      string                     `json:"appId"`
+	ClientID                   string                     `json:"clientId,omitempty"`
+	ClientSecret               *crypto.CryptoValue        `json:"clientSecret,omitempty"`
+	RedirectUris               []string                   `json:"redirectUris,omitempty"`
+	ResponseTypes              []domain.OIDCResponseType  `json:"responseTypes,omitempty"`
+	GrantTypes                 []domain.OIDCGrantType     `json:"grantTypes,omitempty"`
+	ApplicationType            domain.OIDCApplicationType `json:"applicationType,omitempty"`
+	AuthMethodType             domain.OIDCAuthMethodType  `json:"authMethodType,omitempty"`
+	PostLogoutRedirectUris     []string                   `json:"postLogoutRedirectUris,omitempty"`
+	DevMode                    bool                       `json:"devMode,omitempty"`
+	AccessTokenType            domain.OIDCTokenType       `json:"accessTokenType,omitempty"`
+	AccessTokenRoleAssertion   bool                       `json:"accessTokenRoleAssertion,omitempty"`
+	IDTokenRoleAssertion       bool                       `json:"idTokenRoleAssertion,omitempty"`
+	IDTokenUserinfoAssertion   bool                       `json:"idTokenUserinfoAssertion,omitempty"`
+	ClockSkew                  time.Duration              `json:"clockSkew,omitempty"`
+	AdditionalOrigins          []string                   `json:"additionalOrigins,omitempty"`
+	SkipNativeAppSuccessPage   bool                       `json:"skipNativeAppSuccessPage,omitempty"`
+	RefreshTokenReuseDetection bool                       `json:"refreshTokenReuseDetection,omitempty"`
+	BackChannelLogoutURI       string                     `json:"backChannelLogoutUri,omitempty"`
+	FrontChannelLogoutURI      string                     `json:"frontChannelLogoutUri,omitempty"`
+	SenderConstrainedTokens    bool                       `json:"senderConstrainedTokens,omitempty"`
+	TLSClientAuthSubjectDN     string                     `json:"tlsClientAuthSubjectDn,omitempty"`
+	RequireConsent             bool                       `json:"requireConsent,omitempty"`
 }
 
 func (e *OIDCConfigAddedEvent) Data() interface{} {
@@ -72,6 +79,12 @@ func NewOIDCConfigAddedEvent(
 	clockSkew time.Duration,
 	additionalOrigins []string,
 	skipNativeAppSuccessPage bool,
+	refreshTokenReuseDetection bool,
+	backChannelLogoutURI string,
+	frontChannelLogoutURI string,
+	senderConstrainedTokens bool,
+	tlsClientAuthSubjectDN string,
+	requireConsent bool,
 ) *OIDCConfigAddedEvent {
 	return &OIDCConfigAddedEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
@@ -79,24 +92,30 @@ func NewOIDCConfigAddedEvent(
 			aggregate,
 			OIDCConfigAddedType,
 		),
-		Version:                  version,
-		AppID:                    appID,
-		ClientID:                 clientID,
-		ClientSecret:             clientSecret,
-		RedirectUris:             redirectUris,
-		ResponseTypes:            responseTypes,
-		GrantTypes:               grantTypes,
-		ApplicationType:          applicationType,
-		AuthMethodType:           authMethodType,
-		PostLogoutRedirectUris:   postLogoutRedirectUris,
-		DevMode:                  devMode,
-		AccessTokenType:          accessTokenType,
-		AccessTokenRoleAssertion: accessTokenRoleAssertion,
-		IDTokenRoleAssertion:     idTokenRoleAssertion,
-		IDTokenUserinfoAssertion: idTokenUserinfoAssertion,
-		ClockSkew:                clockSkew,
-		AdditionalOrigins:        additionalOrigins,
-		SkipNativeAppSuccessPage: skipNativeAppSuccessPage,
+		Version:                    version,
+		AppID:                      appID,
+		ClientID:                   clientID,
+		ClientSecret:               clientSecret,
+		RedirectUris:               redirectUris,
+		ResponseTypes:              responseTypes,
+		GrantTypes:                 grantTypes,
+		ApplicationType:            applicationType,
+		AuthMethodType:             authMethodType,
+		PostLogoutRedirectUris:     postLogoutRedirectUris,
+		DevMode:                    devMode,
+		AccessTokenType:            accessTokenType,
+		AccessTokenRoleAssertion:   accessTokenRoleAssertion,
+		IDTokenRoleAssertion:       idTokenRoleAssertion,
+		IDTokenUserinfoAssertion:   idTokenUserinfoAssertion,
+		ClockSkew:                  clockSkew,
+		AdditionalOrigins:          additionalOrigins,
+		SkipNativeAppSuccessPage:   skipNativeAppSuccessPage,
+		RefreshTokenReuseDetection: refreshTokenReuseDetection,
+		BackChannelLogoutURI:       backChannelLogoutURI,
+		FrontChannelLogoutURI:      frontChannelLogoutURI,
+		SenderConstrainedTokens:    senderConstrainedTokens,
+		TLSClientAuthSubjectDN:     tlsClientAuthSubjectDN,
+		RequireConsent:             requireConsent,
 	}
 }
 
@@ -182,7 +201,18 @@ func (e *OIDCConfigAddedEvent) Validate(cmd eventstore.Command) bool {
 			return false
 		}
 	}
-	return e.SkipNativeAppSuccessPage == c.SkipNativeAppSuccessPage
+	if e.SkipNativeAppSuccessPage != c.SkipNativeAppSuccessPage {
+		return false
+	}
+	if e.RefreshTokenReuseDetection != c.RefreshTokenReuseDetection {
+		return false
+	}
+	if e.BackChannelLogoutURI != c.BackChannelLogoutURI || e.FrontChannelLogoutURI != c.FrontChannelLogoutURI {
+		return false
+	}
+	return e.SenderConstrainedTokens == c.SenderConstrainedTokens &&
+		e.TLSClientAuthSubjectDN == c.TLSClientAuthSubjectDN &&
+		e.RequireConsent == c.RequireConsent
 }
 
 func OIDCConfigAddedEventMapper(event *repository.Event) (eventstore.Event, error) {
@@ -201,22 +231,28 @@ func OIDCConfigAddedEventMapper(event *repository.Event) (eventstore.Event, erro
 type OIDCConfigChangedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
-	Version                  *domain.OIDCVersion         `json:"oidcVersion,omitempty"`
-	AppID                    string                      `json:"appId"`
-	RedirectUris             *[]string                   `json:"redirectUris,omitempty"`
-	ResponseTypes            *[]domain.OIDCResponseType  `json:"responseTypes,omitempty"`
-	GrantTypes               *[]domain.OIDCGrantType     `json:"grantTypes,omitempty"`
-	ApplicationType          *domain.OIDCApplicationType `json:"applicationType,omitempty"`
-	AuthMethodType           *domain.OIDCAuthMethodType  `json:"authMethodType,omitempty"`
-	PostLogoutRedirectUris   *[]string                   `json:"postLogoutRedirectUris,omitempty"`
-	DevMode                  *bool                       `json:"devMode,omitempty"`
-	AccessTokenType          *domain.OIDCTokenType       `json:"accessTokenType,omitempty"`
-	AccessTokenRoleAssertion *bool                       `json:"accessTokenRoleAssertion,omitempty"`
-	IDTokenRoleAssertion     *bool                       `json:"idTokenRoleAssertion,omitempty"`
-	IDTokenUserinfoAssertion *bool                       `json:"idTokenUserinfoAssertion,omitempty"`
-	ClockSkew                *time.Duration              `json:"clockSkew,omitempty"`
-	AdditionalOrigins        *[]string                   `json:"additionalOrigins,omitempty"`
-	SkipNativeAppSuccessPage *bool                       `json:"skipNativeAppSuccessPage,omitempty"`
+	Version                    *domain.OIDCVersion         `json:"oidcVersion,omitempty"`
+	AppID                      string                      `json:"appId"`
+	RedirectUris               *[]string                   `json:"redirectUris,omitempty"`
+	ResponseTypes              *[]domain.OIDCResponseType  `json:"responseTypes,omitempty"`
+	GrantTypes                 *[]domain.OIDCGrantType     `json:"grantTypes,omitempty"`
+	ApplicationType            *domain.OIDCApplicationType `json:"applicationType,omitempty"`
+	AuthMethodType             *domain.OIDCAuthMethodType  `json:"authMethodType,omitempty"`
+	PostLogoutRedirectUris     *[]string                   `json:"postLogoutRedirectUris,omitempty"`
+	DevMode                    *bool                       `json:"devMode,omitempty"`
+	AccessTokenType            *domain.OIDCTokenType       `json:"accessTokenType,omitempty"`
+	AccessTokenRoleAssertion   *bool                       `json:"accessTokenRoleAssertion,omitempty"`
+	IDTokenRoleAssertion       *bool                       `json:"idTokenRoleAssertion,omitempty"`
+	IDTokenUserinfoAssertion   *bool                       `json:"idTokenUserinfoAssertion,omitempty"`
+	ClockSkew                  *time.Duration              `json:"clockSkew,omitempty"`
+	AdditionalOrigins          *[]string                   `json:"additionalOrigins,omitempty"`
+	SkipNativeAppSuccessPage   *bool                       `json:"skipNativeAppSuccessPage,omitempty"`
+	RefreshTokenReuseDetection *bool                       `json:"refreshTokenReuseDetection,omitempty"`
+	BackChannelLogoutURI       *string                     `json:"backChannelLogoutUri,omitempty"`
+	FrontChannelLogoutURI      *string                     `json:"frontChannelLogoutUri,omitempty"`
+	SenderConstrainedTokens    *bool                       `json:"senderConstrainedTokens,omitempty"`
+	TLSClientAuthSubjectDN     *string                     `json:"tlsClientAuthSubjectDn,omitempty"`
+	RequireConsent             *bool                       `json:"requireConsent,omitempty"`
 }
 
 func (e *OIDCConfigChangedEvent) Data() interface{} {
@@ -343,6 +379,42 @@ func ChangeSkipNativeAppSuccessPage(skipNativeAppSuccessPage bool) func(event *O
 	}
 }
 
+func ChangeRefreshTokenReuseDetection(refreshTokenReuseDetection bool) func(event *OIDCConfigChangedEvent) {
+	return func(e *OIDCConfigChangedEvent) {
+		e.RefreshTokenReuseDetection = &refreshTokenReuseDetection
+	}
+}
+
+func ChangeBackChannelLogoutURI(backChannelLogoutURI string) func(event *OIDCConfigChangedEvent) {
+	return func(e *OIDCConfigChangedEvent) {
+		e.BackChannelLogoutURI = &backChannelLogoutURI
+	}
+}
+
+func ChangeFrontChannelLogoutURI(frontChannelLogoutURI string) func(event *OIDCConfigChangedEvent) {
+	return func(e *OIDCConfigChangedEvent) {
+		e.FrontChannelLogoutURI = &frontChannelLogoutURI
+	}
+}
+
+func ChangeSenderConstrainedTokens(senderConstrainedTokens bool) func(event *OIDCConfigChangedEvent) {
+	return func(e *OIDCConfigChangedEvent) {
+		e.SenderConstrainedTokens = &senderConstrainedTokens
+	}
+}
+
+func ChangeTLSClientAuthSubjectDN(tlsClientAuthSubjectDN string) func(event *OIDCConfigChangedEvent) {
+	return func(e *OIDCConfigChangedEvent) {
+		e.TLSClientAuthSubjectDN = &tlsClientAuthSubjectDN
+	}
+}
+
+func ChangeRequireConsent(requireConsent bool) func(event *OIDCConfigChangedEvent) {
+	return func(e *OIDCConfigChangedEvent) {
+		e.RequireConsent = &requireConsent
+	}
+}
+
 func OIDCConfigChangedEventMapper(event *repository.Event) (eventstore.Event, error) {
 	e := &OIDCConfigChangedEvent{
 		BaseEvent: *eventstore.BaseEventFromRepo(event),
@@ -401,6 +473,51 @@ func OIDCConfigSecretChangedEventMapper(event *repository.Event) (eventstore.Eve
 	return e, nil
 }
 
+type OIDCConfigRegistrationTokenSetEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	AppID             string              `json:"appId"`
+	RegistrationToken *crypto.CryptoValue `json:"registrationToken,omitempty"`
+}
+
+func (e *OIDCConfigRegistrationTokenSetEvent) Data() interface{} {
+	return e
+}
+
+func (e *OIDCConfigRegistrationTokenSetEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewOIDCConfigRegistrationTokenSetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	appID string,
+	registrationToken *crypto.CryptoValue,
+) *OIDCConfigRegistrationTokenSetEvent {
+	return &OIDCConfigRegistrationTokenSetEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			OIDCConfigRegistrationTokenSetType,
+		),
+		AppID:             appID,
+		RegistrationToken: registrationToken,
+	}
+}
+
+func OIDCConfigRegistrationTokenSetEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &OIDCConfigRegistrationTokenSetEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "OIDC-Rk7ve", "unable to unmarshal oidc config")
+	}
+
+	return e, nil
+}
+
 type OIDCConfigSecretCheckSucceededEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
@@ -18,10 +18,11 @@ const (
 type SAMLConfigAddedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
-	AppID       string `json:"appId"`
-	EntityID    string `json:"entityId"`
-	Metadata    []byte `json:"metadata,omitempty"`
-	MetadataURL string `json:"metadata_url,omitempty"`
+	AppID             string `json:"appId"`
+	EntityID          string `json:"entityId"`
+	Metadata          []byte `json:"metadata,omitempty"`
+	MetadataURL       string `json:"metadata_url,omitempty"`
+	EncryptAssertions bool   `json:"encryptAssertions,omitempty"`
 }
 
 func (e *SAMLConfigAddedEvent) Data() interface{} {
@@ -52,6 +53,7 @@ func NewSAMLConfigAddedEvent(
 	entityID string,
 	metadata []byte,
 	metadataURL string,
+	encryptAssertions bool,
 ) *SAMLConfigAddedEvent {
 	return &SAMLConfigAddedEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
@@ -59,10 +61,11 @@ func NewSAMLConfigAddedEvent(
 			aggregate,
 			SAMLConfigAddedType,
 		),
-		AppID:       appID,
-		EntityID:    entityID,
-		Metadata:    metadata,
-		MetadataURL: metadataURL,
+		AppID:             appID,
+		EntityID:          entityID,
+		Metadata:          metadata,
+		MetadataURL:       metadataURL,
+		EncryptAssertions: encryptAssertions,
 	}
 }
 
@@ -82,11 +85,12 @@ func SAMLConfigAddedEventMapper(event *repository.Event) (eventstore.Event, erro
 type SAMLConfigChangedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
-	AppID       string  `json:"appId"`
-	EntityID    string  `json:"entityId"`
-	Metadata    []byte  `json:"metadata,omitempty"`
-	MetadataURL *string `json:"metadata_url,omitempty"`
-	oldEntityID string
+	AppID             string  `json:"appId"`
+	EntityID          string  `json:"entityId"`
+	Metadata          []byte  `json:"metadata,omitempty"`
+	MetadataURL       *string `json:"metadata_url,omitempty"`
+	EncryptAssertions *bool   `json:"encryptAssertions,omitempty"`
+	oldEntityID       string
 }
 
 func (e *SAMLConfigChangedEvent) Data() interface{} {
@@ -143,6 +147,12 @@ func ChangeMetadataURL(metadataURL string) func(event *SAMLConfigChangedEvent) {
 	}
 }
 
+func ChangeEncryptAssertions(encryptAssertions bool) func(event *SAMLConfigChangedEvent) {
+	return func(e *SAMLConfigChangedEvent) {
+		e.EncryptAssertions = &encryptAssertions
+	}
+}
+
 func ChangeEntityID(entityID string) func(event *SAMLConfigChangedEvent) {
 	return func(e *SAMLConfigChangedEvent) {
 		e.EntityID = entityID
@@ -0,0 +1,29 @@
+package purge
+
+import (
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	AggregateType    = "purge"
+	AggregateVersion = "v1"
+	// AggregateID is the id of the single purge aggregate of the system
+	AggregateID = "purge"
+)
+
+type Aggregate struct {
+	eventstore.Aggregate
+}
+
+// NewAggregate returns the purge aggregate,
+// it is not part of an instance because the purged instances no longer exist
+func NewAggregate() *Aggregate {
+	return &Aggregate{
+		Aggregate: eventstore.Aggregate{
+			Type:          AggregateType,
+			Version:       AggregateVersion,
+			ID:            AggregateID,
+			ResourceOwner: AggregateID,
+		},
+	}
+}
@@ -0,0 +1,10 @@
+package purge
+
+import (
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+func RegisterEventMappers(es *eventstore.Eventstore) {
+	es.RegisterFilterEventMapper(AggregateType, InstancePurgedType, InstancePurgedEventMapper).
+		RegisterFilterEventMapper(AggregateType, UsersScrubbedType, UsersScrubbedEventMapper)
+}
@@ -0,0 +1,118 @@
+package purge
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	eventTypePrefix    = "purge."
+	InstancePurgedType = eventTypePrefix + "instance.purged"
+	UsersScrubbedType  = eventTypePrefix + "users.scrubbed"
+)
+
+// InstancePurgedEvent reports the physical deletion of a removed instance
+type InstancePurgedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	InstanceID string `json:"instanceId"`
+	// Events is the count of the deleted events
+	Events int64 `json:"events"`
+	// Rows is the count of the deleted rows per projection and view table
+	Rows map[string]int64 `json:"rows,omitempty"`
+}
+
+func (e *InstancePurgedEvent) Data() interface{} {
+	return e
+}
+
+func (e *InstancePurgedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewInstancePurgedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	instanceID string,
+	events int64,
+	rows map[string]int64,
+) *InstancePurgedEvent {
+	return &InstancePurgedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			InstancePurgedType,
+		),
+		InstanceID: instanceID,
+		Events:     events,
+		Rows:       rows,
+	}
+}
+
+func InstancePurgedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &InstancePurgedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "PURGE-Xq4cw", "unable to unmarshal instance purged")
+	}
+	return e, nil
+}
+
+// UsersScrubbedEvent reports the users whose personal data was erased from their events.
+// All users removed before Until are scrubbed
+type UsersScrubbedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Until time.Time       `json:"until"`
+	Users []*ScrubbedUser `json:"users,omitempty"`
+}
+
+type ScrubbedUser struct {
+	InstanceID string `json:"instanceId"`
+	UserID     string `json:"userId"`
+	// Events is the count of the rewritten events of the user
+	Events int `json:"events"`
+}
+
+func (e *UsersScrubbedEvent) Data() interface{} {
+	return e
+}
+
+func (e *UsersScrubbedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewUsersScrubbedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	until time.Time,
+	users []*ScrubbedUser,
+) *UsersScrubbedEvent {
+	return &UsersScrubbedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			UsersScrubbedType,
+		),
+		Until: until,
+		Users: users,
+	}
+}
+
+func UsersScrubbedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &UsersScrubbedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "PURGE-Vb7mz", "unable to unmarshal users scrubbed")
+	}
+	return e, nil
+}
@@ -56,6 +56,8 @@ func RegisterEventMappers(es *eventstore.Eventstore) {
 		RegisterFilterEventMapper(AggregateType, HumanInitializedCheckSucceededType, HumanInitializedCheckSucceededEventMapper).
 		RegisterFilterEventMapper(AggregateType, HumanInitializedCheckFailedType, HumanInitializedCheckFailedEventMapper).
 		RegisterFilterEventMapper(AggregateType, HumanSignedOutType, HumanSignedOutEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanBackChannelLogoutSentType, HumanBackChannelLogoutSentEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanSAMLResponseSentType, HumanSAMLResponseSentEventMapper).
 		RegisterFilterEventMapper(AggregateType, HumanPasswordChangedType, HumanPasswordChangedEventMapper).
 		RegisterFilterEventMapper(AggregateType, HumanPasswordCodeAddedType, HumanPasswordCodeAddedEventMapper).
 		RegisterFilterEventMapper(AggregateType, HumanPasswordCodeSentType, HumanPasswordCodeSentEventMapper).
@@ -87,6 +89,23 @@ func RegisterEventMappers(es *eventstore.Eventstore) {
 		RegisterFilterEventMapper(AggregateType, HumanMFAOTPRemovedType, HumanOTPRemovedEventMapper).
 		RegisterFilterEventMapper(AggregateType, HumanMFAOTPCheckSucceededType, HumanOTPCheckSucceededEventMapper).
 		RegisterFilterEventMapper(AggregateType, HumanMFAOTPCheckFailedType, HumanOTPCheckFailedEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanMFAOTPSMSAddedType, HumanOTPSMSAddedEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanMFAOTPSMSRemovedType, HumanOTPSMSRemovedEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanMFAOTPSMSCodeAddedType, HumanOTPSMSCodeAddedEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanMFAOTPSMSCodeSentType, HumanOTPSMSCodeSentEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanMFAOTPSMSCheckSucceededType, HumanOTPSMSCheckSucceededEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanMFAOTPSMSCheckFailedType, HumanOTPSMSCheckFailedEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanMFAOTPEmailAddedType, HumanOTPEmailAddedEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanMFAOTPEmailRemovedType, HumanOTPEmailRemovedEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanMFAOTPEmailCodeAddedType, HumanOTPEmailCodeAddedEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanMFAOTPEmailCodeSentType, HumanOTPEmailCodeSentEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanMFAOTPEmailCheckSucceededType, HumanOTPEmailCheckSucceededEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanMFAOTPEmailCheckFailedType, HumanOTPEmailCheckFailedEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanMFARecoveryCodesGeneratedType, HumanRecoveryCodesGeneratedEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanMFARecoveryCodeCheckSucceededType, HumanRecoveryCodeCheckSucceededEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanMFARecoveryCodeCheckFailedType, HumanRecoveryCodeCheckFailedEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanMFARecoveryCodeUsedNotificationSentType, HumanRecoveryCodeUsedNotificationSentEventMapper).
+		RegisterFilterEventMapper(AggregateType, UserSecurityNotificationSentType, UserSecurityNotificationSentEventMapper).
 		RegisterFilterEventMapper(AggregateType, HumanU2FTokenAddedType, HumanU2FAddedEventMapper).
 		RegisterFilterEventMapper(AggregateType, HumanU2FTokenVerifiedType, HumanU2FVerifiedEventMapper).
 		RegisterFilterEventMapper(AggregateType, HumanU2FTokenSignCountChangedType, HumanU2FSignCountChangedEventMapper).
@@ -109,6 +128,9 @@ func RegisterEventMappers(es *eventstore.Eventstore) {
 		RegisterFilterEventMapper(AggregateType, HumanRefreshTokenAddedType, HumanRefreshTokenAddedEventMapper).
 		RegisterFilterEventMapper(AggregateType, HumanRefreshTokenRenewedType, HumanRefreshTokenRenewedEventEventMapper).
 		RegisterFilterEventMapper(AggregateType, HumanRefreshTokenRemovedType, HumanRefreshTokenRemovedEventEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanRefreshTokenReusedType, HumanRefreshTokenReusedEventEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanConsentGrantedType, HumanConsentGrantedEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanConsentRevokedType, HumanConsentRevokedEventMapper).
 		RegisterFilterEventMapper(AggregateType, MachineAddedEventType, MachineAddedEventMapper).
 		RegisterFilterEventMapper(AggregateType, MachineChangedEventType, MachineChangedEventMapper).
 		RegisterFilterEventMapper(AggregateType, MachineKeyAddedEventType, MachineKeyAddedEventMapper).
@@ -23,6 +23,8 @@ const (
 	HumanInitializedCheckSucceededType = humanEventPrefix + "initialization.check.succeeded"
 	HumanInitializedCheckFailedType    = humanEventPrefix + "initialization.check.failed"
 	HumanSignedOutType                 = humanEventPrefix + "signed.out"
+	HumanBackChannelLogoutSentType     = humanEventPrefix + "back.channel.logout.sent"
+	HumanSAMLResponseSentType          = humanEventPrefix + "saml.response.sent"
 )
 
 type HumanAddedEvent struct {
@@ -400,3 +402,91 @@ func HumanSignedOutEventMapper(event *repository.Event) (eventstore.Event, error
 
 	return signedOut, nil
 }
+
+type HumanBackChannelLogoutSentEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	UserAgentID string `json:"userAgentID"`
+	ClientID    string `json:"clientID"`
+}
+
+func (e *HumanBackChannelLogoutSentEvent) Data() interface{} {
+	return e
+}
+
+func (e *HumanBackChannelLogoutSentEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewHumanBackChannelLogoutSentEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	userAgentID,
+	clientID string,
+) *HumanBackChannelLogoutSentEvent {
+	return &HumanBackChannelLogoutSentEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanBackChannelLogoutSentType,
+		),
+		UserAgentID: userAgentID,
+		ClientID:    clientID,
+	}
+}
+
+func HumanBackChannelLogoutSentEventMapper(event *repository.Event) (eventstore.Event, error) {
+	logoutSent := &HumanBackChannelLogoutSentEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, logoutSent)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Tk2vb", "unable to unmarshal human back-channel logout sent")
+	}
+
+	return logoutSent, nil
+}
+
+type HumanSAMLResponseSentEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	UserAgentID   string `json:"userAgentID"`
+	ApplicationID string `json:"applicationID"`
+}
+
+func (e *HumanSAMLResponseSentEvent) Data() interface{} {
+	return e
+}
+
+func (e *HumanSAMLResponseSentEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewHumanSAMLResponseSentEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	userAgentID,
+	applicationID string,
+) *HumanSAMLResponseSentEvent {
+	return &HumanSAMLResponseSentEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanSAMLResponseSentType,
+		),
+		UserAgentID:   userAgentID,
+		ApplicationID: applicationID,
+	}
+}
+
+func HumanSAMLResponseSentEventMapper(event *repository.Event) (eventstore.Event, error) {
+	responseSent := &HumanSAMLResponseSentEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, responseSent)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Sm4qa", "unable to unmarshal human saml response sent")
+	}
+
+	return responseSent, nil
+}
@@ -0,0 +1,105 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	consentEventPrefix      = humanEventPrefix + "consent."
+	HumanConsentGrantedType = consentEventPrefix + "granted"
+	HumanConsentRevokedType = consentEventPrefix + "revoked"
+)
+
+type HumanConsentGrantedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ClientID  string   `json:"clientId"`
+	ProjectID string   `json:"projectId"`
+	Scopes    []string `json:"scopes,omitempty"`
+	Roles     []string `json:"roles,omitempty"`
+}
+
+func (e *HumanConsentGrantedEvent) Data() interface{} {
+	return e
+}
+
+func (e *HumanConsentGrantedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewHumanConsentGrantedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	clientID,
+	projectID string,
+	scopes,
+	roles []string,
+) *HumanConsentGrantedEvent {
+	return &HumanConsentGrantedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanConsentGrantedType,
+		),
+		ClientID:  clientID,
+		ProjectID: projectID,
+		Scopes:    scopes,
+		Roles:     roles,
+	}
+}
+
+func HumanConsentGrantedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	consentGranted := &HumanConsentGrantedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, consentGranted)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Rk3pa", "unable to unmarshal human consent granted")
+	}
+	return consentGranted, nil
+}
+
+type HumanConsentRevokedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ClientID string `json:"clientId"`
+}
+
+func (e *HumanConsentRevokedEvent) Data() interface{} {
+	return e
+}
+
+func (e *HumanConsentRevokedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewHumanConsentRevokedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	clientID string,
+) *HumanConsentRevokedEvent {
+	return &HumanConsentRevokedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanConsentRevokedType,
+		),
+		ClientID: clientID,
+	}
+}
+
+func HumanConsentRevokedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	consentRevoked := &HumanConsentRevokedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, consentRevoked)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Vw8ne", "unable to unmarshal human consent revoked")
+	}
+	return consentRevoked, nil
+}
@@ -0,0 +1,183 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	recoveryCodeEventPrefix                      = mfaEventPrefix + "recoverycode."
+	HumanMFARecoveryCodesGeneratedType           = recoveryCodeEventPrefix + "generated"
+	HumanMFARecoveryCodeCheckSucceededType       = recoveryCodeEventPrefix + "check.succeeded"
+	HumanMFARecoveryCodeCheckFailedType          = recoveryCodeEventPrefix + "check.failed"
+	HumanMFARecoveryCodeUsedNotificationSentType = recoveryCodeEventPrefix + "notification.sent"
+)
+
+type HumanRecoveryCodesGeneratedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	// Codes are the hashed recovery codes, previously generated codes are invalidated
+	Codes []*crypto.CryptoValue `json:"codes,omitempty"`
+}
+
+func (e *HumanRecoveryCodesGeneratedEvent) Data() interface{} {
+	return e
+}
+
+func (e *HumanRecoveryCodesGeneratedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewHumanRecoveryCodesGeneratedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	codes []*crypto.CryptoValue,
+) *HumanRecoveryCodesGeneratedEvent {
+	return &HumanRecoveryCodesGeneratedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanMFARecoveryCodesGeneratedType,
+		),
+		Codes: codes,
+	}
+}
+
+func HumanRecoveryCodesGeneratedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	generated := &HumanRecoveryCodesGeneratedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, generated)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Rk2wd", "unable to unmarshal human recovery codes generated")
+	}
+	return generated, nil
+}
+
+type HumanRecoveryCodeCheckSucceededEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	// CodeIndex is the position of the used code in the generated set
+	CodeIndex int `json:"codeIndex"`
+	*AuthRequestInfo
+}
+
+func (e *HumanRecoveryCodeCheckSucceededEvent) Data() interface{} {
+	return e
+}
+
+func (e *HumanRecoveryCodeCheckSucceededEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewHumanRecoveryCodeCheckSucceededEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	codeIndex int,
+	info *AuthRequestInfo,
+) *HumanRecoveryCodeCheckSucceededEvent {
+	return &HumanRecoveryCodeCheckSucceededEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanMFARecoveryCodeCheckSucceededType,
+		),
+		CodeIndex:       codeIndex,
+		AuthRequestInfo: info,
+	}
+}
+
+func HumanRecoveryCodeCheckSucceededEventMapper(event *repository.Event) (eventstore.Event, error) {
+	checkSucceeded := &HumanRecoveryCodeCheckSucceededEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, checkSucceeded)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Mb7fe", "unable to unmarshal human recovery code check succeeded")
+	}
+	return checkSucceeded, nil
+}
+
+type HumanRecoveryCodeCheckFailedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+	*AuthRequestInfo
+}
+
+func (e *HumanRecoveryCodeCheckFailedEvent) Data() interface{} {
+	return e
+}
+
+func (e *HumanRecoveryCodeCheckFailedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewHumanRecoveryCodeCheckFailedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	info *AuthRequestInfo,
+) *HumanRecoveryCodeCheckFailedEvent {
+	return &HumanRecoveryCodeCheckFailedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanMFARecoveryCodeCheckFailedType,
+		),
+		AuthRequestInfo: info,
+	}
+}
+
+func HumanRecoveryCodeCheckFailedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	checkFailed := &HumanRecoveryCodeCheckFailedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, checkFailed)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Gd3sl", "unable to unmarshal human recovery code check failed")
+	}
+	return checkFailed, nil
+}
+
+type HumanRecoveryCodeUsedNotificationSentEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	CodeIndex int `json:"codeIndex"`
+}
+
+func (e *HumanRecoveryCodeUsedNotificationSentEvent) Data() interface{} {
+	return e
+}
+
+func (e *HumanRecoveryCodeUsedNotificationSentEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewHumanRecoveryCodeUsedNotificationSentEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	codeIndex int,
+) *HumanRecoveryCodeUsedNotificationSentEvent {
+	return &HumanRecoveryCodeUsedNotificationSentEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanMFARecoveryCodeUsedNotificationSentType,
+		),
+		CodeIndex: codeIndex,
+	}
+}
+
+func HumanRecoveryCodeUsedNotificationSentEventMapper(event *repository.Event) (eventstore.Event, error) {
+	sent := &HumanRecoveryCodeUsedNotificationSentEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, sent)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Wq5ya", "unable to unmarshal human recovery code used notification sent")
+	}
+	return sent, nil
+}
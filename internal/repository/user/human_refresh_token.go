@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"time"
 
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
 
 	"github.com/zitadel/zitadel/internal/errors"
@@ -16,6 +17,7 @@ const (
 	HumanRefreshTokenAddedType   = refreshTokenEventPrefix + "added"
 	HumanRefreshTokenRenewedType = refreshTokenEventPrefix + "renewed"
 	HumanRefreshTokenRemovedType = refreshTokenEventPrefix + "removed"
+	HumanRefreshTokenReusedType  = refreshTokenEventPrefix + "reused"
 )
 
 type HumanRefreshTokenAddedEvent struct {
@@ -31,6 +33,9 @@ type HumanRefreshTokenAddedEvent struct {
 	IdleExpiration        time.Duration `json:"idleExpiration"`
 	Expiration            time.Duration `json:"expiration"`
 	PreferredLanguage     string        `json:"preferredLanguage"`
+	ReuseDetection        bool          `json:"reuseDetection,omitempty"`
+
+	Confirmation *domain.TokenConfirmation `json:"confirmation,omitempty"`
 }
 
 func (e *HumanRefreshTokenAddedEvent) Data() interface{} {
@@ -58,6 +63,8 @@ func NewHumanRefreshTokenAddedEvent(
 	authTime time.Time,
 	idleExpiration,
 	expiration time.Duration,
+	reuseDetection bool,
+	confirmation *domain.TokenConfirmation,
 ) *HumanRefreshTokenAddedEvent {
 	return &HumanRefreshTokenAddedEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
@@ -75,6 +82,8 @@ func NewHumanRefreshTokenAddedEvent(
 		IdleExpiration:        idleExpiration,
 		Expiration:            expiration,
 		PreferredLanguage:     preferredLanguage,
+		ReuseDetection:        reuseDetection,
+		Confirmation:          confirmation,
 	}
 }
 
@@ -185,3 +194,56 @@ func HumanRefreshTokenRemovedEventEventMapper(event *repository.Event) (eventsto
 
 	return tokenAdded, nil
 }
+
+// HumanRefreshTokenReusedEvent is pushed if an already renewed refresh token is used again.
+// It is always followed by a HumanRefreshTokenRemovedEvent, which revokes the token (family).
+type HumanRefreshTokenReusedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	TokenID     string `json:"tokenId"`
+	ClientID    string `json:"clientId"`
+	UserAgentID string `json:"userAgentId"`
+}
+
+func (e *HumanRefreshTokenReusedEvent) Data() interface{} {
+	return e
+}
+
+func (e *HumanRefreshTokenReusedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func (e *HumanRefreshTokenReusedEvent) Assets() []*eventstore.Asset {
+	return nil
+}
+
+func NewHumanRefreshTokenReusedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	tokenID,
+	clientID,
+	userAgentID string,
+) *HumanRefreshTokenReusedEvent {
+	return &HumanRefreshTokenReusedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanRefreshTokenReusedType,
+		),
+		TokenID:     tokenID,
+		ClientID:    clientID,
+		UserAgentID: userAgentID,
+	}
+}
+
+func HumanRefreshTokenReusedEventEventMapper(event *repository.Event) (eventstore.Event, error) {
+	tokenReused := &HumanRefreshTokenReusedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, tokenReused)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Fhe3q", "unable to unmarshal refresh token reused")
+	}
+
+	return tokenReused, nil
+}
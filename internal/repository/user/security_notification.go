@@ -0,0 +1,59 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	UserSecurityNotificationSentType = userEventTypePrefix + "security.notification.sent"
+)
+
+// UserSecurityNotificationSentEvent marks the security notification of type MessageType,
+// triggered by the event with TriggerSequence on the same user, as sent
+type UserSecurityNotificationSentEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	MessageType     string `json:"messageType"`
+	TriggerSequence uint64 `json:"triggerSequence"`
+}
+
+func (e *UserSecurityNotificationSentEvent) Data() interface{} {
+	return e
+}
+
+func (e *UserSecurityNotificationSentEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewUserSecurityNotificationSentEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	messageType string,
+	triggerSequence uint64,
+) *UserSecurityNotificationSentEvent {
+	return &UserSecurityNotificationSentEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			UserSecurityNotificationSentType,
+		),
+		MessageType:     messageType,
+		TriggerSequence: triggerSequence,
+	}
+}
+
+func UserSecurityNotificationSentEventMapper(event *repository.Event) (eventstore.Event, error) {
+	sent := &UserSecurityNotificationSentEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, sent)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Sn7fq", "unable to unmarshal user security notification sent")
+	}
+	return sent, nil
+}
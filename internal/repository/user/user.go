@@ -50,30 +50,43 @@ func NewRemoveUsernameUniqueConstraint(userName, resourceOwner string, userLogin
 
 type UserLockedEvent struct {
 	eventstore.BaseEvent `json:"-"`
+
+	// LockedByPolicy is set if the user was locked by the lockout policy
+	// because of too many failed attempts
+	LockedByPolicy bool `json:"lockedByPolicy,omitempty"`
 }
 
 func (e *UserLockedEvent) Data() interface{} {
-	return nil
+	return e
 }
 
 func (e *UserLockedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
 	return nil
 }
 
-func NewUserLockedEvent(ctx context.Context, aggregate *eventstore.Aggregate) *UserLockedEvent {
+func NewUserLockedEvent(ctx context.Context, aggregate *eventstore.Aggregate, lockedByPolicy bool) *UserLockedEvent {
 	return &UserLockedEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
 			ctx,
 			aggregate,
 			UserLockedType,
 		),
+		LockedByPolicy: lockedByPolicy,
 	}
 }
 
 func UserLockedEventMapper(event *repository.Event) (eventstore.Event, error) {
-	return &UserLockedEvent{
+	locked := &UserLockedEvent{
 		BaseEvent: *eventstore.BaseEventFromRepo(event),
-	}, nil
+	}
+	if len(event.Data) == 0 {
+		return locked, nil
+	}
+	err := json.Unmarshal(event.Data, locked)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Lk4nw", "unable to unmarshal user locked")
+	}
+	return locked, nil
 }
 
 type UserUnlockedEvent struct {
@@ -219,6 +232,8 @@ type UserTokenAddedEvent struct {
 	Scopes            []string  `json:"scopes"`
 	Expiration        time.Time `json:"expiration"`
 	PreferredLanguage string    `json:"preferredLanguage"`
+
+	Confirmation *domain.TokenConfirmation `json:"confirmation,omitempty"`
 }
 
 func (e *UserTokenAddedEvent) Data() interface{} {
@@ -240,6 +255,7 @@ func NewUserTokenAddedEvent(
 	audience,
 	scopes []string,
 	expiration time.Time,
+	confirmation *domain.TokenConfirmation,
 ) *UserTokenAddedEvent {
 	return &UserTokenAddedEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
@@ -255,6 +271,7 @@ func NewUserTokenAddedEvent(
 		Scopes:            scopes,
 		Expiration:        expiration,
 		PreferredLanguage: preferredLanguage,
+		Confirmation:      confirmation,
 	}
 }
 
@@ -41,6 +41,23 @@ func (m *MockStorage) ExpectPutObjectError() *MockStorage {
 	return m
 }
 
+func (m *MockStorage) ExpectGetObject(data []byte, contentType string) *MockStorage {
+	m.EXPECT().
+		GetObject(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, instanceID, resourceOwner, name string) ([]byte, func() (*static.Asset, error), error) {
+			return data, func() (*static.Asset, error) {
+				return &static.Asset{
+					InstanceID:    instanceID,
+					ResourceOwner: resourceOwner,
+					Name:          name,
+					Size:          int64(len(data)),
+					ContentType:   contentType,
+				}, nil
+			}, nil
+		})
+	return m
+}
+
 func (m *MockStorage) ExpectRemoveObjectNoError() *MockStorage {
 	m.EXPECT().
 		RemoveObject(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
@@ -24,6 +24,7 @@ type ObjectType int32
 const (
 	ObjectTypeUserAvatar ObjectType = iota
 	ObjectTypeStyling
+	ObjectTypeInstanceArchive
 )
 
 func (o ObjectType) String() string {
@@ -32,6 +33,8 @@ func (o ObjectType) String() string {
 		return "0"
 	case ObjectTypeStyling:
 		return "1"
+	case ObjectTypeInstanceArchive:
+		return "2"
 	default:
 		return ""
 	}
@@ -22,6 +22,7 @@ type TokenView struct {
 	PreferredLanguage string
 	RefreshTokenID    string
 	IsPAT             bool
+	Confirmation      *domain.TokenConfirmation
 }
 
 type TokenSearchRequest struct {
@@ -18,6 +18,8 @@ type UserSessionView struct {
 	LoginName                    string
 	DisplayName                  string
 	AvatarKey                    string
+	UserAgent                    string
+	RemoteIP                     string
 	SelectedIDPConfigID          string
 	PasswordVerification         time.Time
 	PasswordlessVerification     time.Time
@@ -29,6 +31,49 @@ type UserSessionView struct {
 	Sequence                     uint64
 }
 
+type UserSessionFactor int32
+
+const (
+	UserSessionFactorPassword UserSessionFactor = iota + 1
+	UserSessionFactorPasswordless
+	UserSessionFactorIDP
+	UserSessionFactorOTP
+	UserSessionFactorU2F
+	UserSessionFactorOTPSMS
+	UserSessionFactorOTPEmail
+	UserSessionFactorRecoveryCode
+)
+
+// Factors returns the factors the user is currently authenticated with in the session
+func (s *UserSessionView) Factors() []UserSessionFactor {
+	factors := make([]UserSessionFactor, 0, 3)
+	if !s.PasswordVerification.IsZero() {
+		factors = append(factors, UserSessionFactorPassword)
+	}
+	if !s.PasswordlessVerification.IsZero() {
+		factors = append(factors, UserSessionFactorPasswordless)
+	}
+	if !s.ExternalLoginVerification.IsZero() {
+		factors = append(factors, UserSessionFactorIDP)
+	}
+	if s.SecondFactorVerification.IsZero() {
+		return factors
+	}
+	switch s.SecondFactorVerificationType {
+	case domain.MFATypeOTP:
+		factors = append(factors, UserSessionFactorOTP)
+	case domain.MFATypeU2F:
+		factors = append(factors, UserSessionFactorU2F)
+	case domain.MFATypeOTPSMS:
+		factors = append(factors, UserSessionFactorOTPSMS)
+	case domain.MFATypeOTPEmail:
+		factors = append(factors, UserSessionFactorOTPEmail)
+	case domain.MFATypeRecoveryCode:
+		factors = append(factors, UserSessionFactorRecoveryCode)
+	}
+	return factors
+}
+
 type UserSessionSearchRequest struct {
 	Offset        uint64
 	Limit         uint64
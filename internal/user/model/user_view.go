@@ -49,6 +49,9 @@ type HumanView struct {
 	Region                   string
 	StreetAddress            string
 	OTPState                 MFAState
+	OTPSMSAdded              bool
+	OTPEmailAdded            bool
+	RecoveryCodesRemaining   int32
 	U2FTokens                []*WebAuthNView
 	PasswordlessTokens       []*WebAuthNView
 	MFAMaxSetUp              domain.MFALevel
@@ -189,10 +192,22 @@ func (u *UserView) MFATypesAllowed(level domain.MFALevel, policy *domain.LoginPo
 					if u.IsU2FReady() {
 						types = append(types, domain.MFATypeU2F)
 					}
+				case domain.SecondFactorTypeOTPSMS:
+					if u.OTPSMSAdded && u.IsPhoneVerified {
+						types = append(types, domain.MFATypeOTPSMS)
+					}
+				case domain.SecondFactorTypeOTPEmail:
+					if u.OTPEmailAdded && u.IsEmailVerified {
+						types = append(types, domain.MFATypeOTPEmail)
+					}
 				}
 			}
 		}
-		//PLANNED: add sms
+	}
+	// recovery codes are only a fallback for users with a second factor set up
+	// they are put first, so they are never preselected
+	if len(types) > 0 && u.HumanView != nil && u.RecoveryCodesRemaining > 0 {
+		types = append([]domain.MFAType{domain.MFATypeRecoveryCode}, types...)
 	}
 	return types, required
 }
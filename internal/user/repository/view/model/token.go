@@ -7,6 +7,7 @@ import (
 	"github.com/zitadel/logging"
 
 	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
 	caos_errs "github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	es_models "github.com/zitadel/zitadel/internal/eventstore/v1/models"
@@ -42,6 +43,10 @@ type TokenView struct {
 	IsPAT             bool                 `json:"-" gorm:"is_pat"`
 	Deactivated       bool                 `json:"-" gorm:"-"`
 	InstanceID        string               `json:"instanceID" gorm:"column:instance_id;primary_key"`
+
+	Confirmation          *domain.TokenConfirmation `json:"confirmation,omitempty" gorm:"-"`
+	ConfirmationJWKThumb  string                    `json:"-" gorm:"column:confirmation_jkt"`
+	ConfirmationX509Thumb string                    `json:"-" gorm:"column:confirmation_x5t"`
 }
 
 func TokenViewToModel(token *TokenView) *usr_model.TokenView {
@@ -60,9 +65,21 @@ func TokenViewToModel(token *TokenView) *usr_model.TokenView {
 		PreferredLanguage: token.PreferredLanguage,
 		RefreshTokenID:    token.RefreshTokenID,
 		IsPAT:             token.IsPAT,
+		Confirmation:      token.confirmation(),
 	}
 }
 
+func (t *TokenView) confirmation() *domain.TokenConfirmation {
+	confirmation := &domain.TokenConfirmation{
+		JWKThumbprint:  t.ConfirmationJWKThumb,
+		X509Thumbprint: t.ConfirmationX509Thumb,
+	}
+	if confirmation.IsEmpty() {
+		return nil
+	}
+	return confirmation
+}
+
 func (t *TokenView) AppendEventIfMyToken(event *es_models.Event) (err error) {
 	view := new(TokenView)
 	switch eventstore.EventType(event.Type) {
@@ -137,6 +154,10 @@ func (t *TokenView) setData(event *es_models.Event) error {
 		logging.Log("EVEN-3Gm9s").WithError(err).Error("could not unmarshal event data")
 		return caos_errs.ThrowInternal(err, "MODEL-5Gms9", "could not unmarshal event")
 	}
+	if t.Confirmation != nil {
+		t.ConfirmationJWKThumb = t.Confirmation.JWKThumbprint
+		t.ConfirmationX509Thumb = t.Confirmation.X509Thumbprint
+	}
 	return nil
 }
 
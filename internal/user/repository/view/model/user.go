@@ -89,6 +89,9 @@ type HumanView struct {
 	Region                   string         `json:"region" gorm:"column:region"`
 	StreetAddress            string         `json:"streetAddress" gorm:"column:street_address"`
 	OTPState                 int32          `json:"-" gorm:"column:otp_state"`
+	OTPSMSAdded              bool           `json:"-" gorm:"column:otp_sms_added"`
+	OTPEmailAdded            bool           `json:"-" gorm:"column:otp_email_added"`
+	RecoveryCodesRemaining   int32          `json:"-" gorm:"column:recovery_codes_remaining"`
 	U2FTokens                WebAuthNTokens `json:"-" gorm:"column:u2f_tokens"`
 	MFAMaxSetUp              int32          `json:"-" gorm:"column:mfa_max_set_up"`
 	MFAInitSkipped           time.Time      `json:"-" gorm:"column:mfa_init_skipped"`
@@ -178,6 +181,9 @@ func UserToModel(user *UserView) *model.UserView {
 			Region:                   user.Region,
 			StreetAddress:            user.StreetAddress,
 			OTPState:                 model.MFAState(user.OTPState),
+			OTPSMSAdded:              user.OTPSMSAdded,
+			OTPEmailAdded:            user.OTPEmailAdded,
+			RecoveryCodesRemaining:   user.RecoveryCodesRemaining,
 			MFAMaxSetUp:              domain.MFALevel(user.MFAMaxSetUp),
 			MFAInitSkipped:           user.MFAInitSkipped,
 			InitRequired:             user.InitRequired,
@@ -326,6 +332,30 @@ func (u *UserView) AppendEvent(event *models.Event) (err error) {
 	case user.UserV1MFAOTPRemovedType,
 		user.HumanMFAOTPRemovedType:
 		u.OTPState = int32(model.MFAStateUnspecified)
+	case user.HumanMFAOTPSMSAddedType:
+		if u.HumanView == nil {
+			logging.WithFields("sequence", event.Sequence, "instance", event.InstanceID).Warn("event is ignored because human not exists")
+			return errors.ThrowInvalidArgument(nil, "MODEL-Fh3bq", "event ignored: human not exists")
+		}
+		u.OTPSMSAdded = true
+		u.MFAInitSkipped = time.Time{}
+	case user.HumanMFAOTPSMSRemovedType:
+		u.OTPSMSAdded = false
+	case user.HumanMFAOTPEmailAddedType:
+		if u.HumanView == nil {
+			logging.WithFields("sequence", event.Sequence, "instance", event.InstanceID).Warn("event is ignored because human not exists")
+			return errors.ThrowInvalidArgument(nil, "MODEL-Wc8ak", "event ignored: human not exists")
+		}
+		u.OTPEmailAdded = true
+		u.MFAInitSkipped = time.Time{}
+	case user.HumanMFAOTPEmailRemovedType:
+		u.OTPEmailAdded = false
+	case user.HumanMFARecoveryCodesGeneratedType:
+		err = u.setRecoveryCodesRemaining(event)
+	case user.HumanMFARecoveryCodeCheckSucceededType:
+		if u.RecoveryCodesRemaining > 0 {
+			u.RecoveryCodesRemaining--
+		}
 	case user.HumanU2FTokenAddedType:
 		err = u.addU2FToken(event)
 	case user.HumanU2FTokenVerifiedType:
@@ -480,6 +510,18 @@ func (u *UserView) removeU2FToken(event *models.Event) error {
 	return nil
 }
 
+func (u *UserView) setRecoveryCodesRemaining(event *models.Event) error {
+	generated := new(struct {
+		Codes []json.RawMessage `json:"codes"`
+	})
+	err := json.Unmarshal(event.Data, generated)
+	if err != nil {
+		return errors.ThrowInternal(err, "MODEL-Xe5kb", "could not unmarshal data")
+	}
+	u.RecoveryCodesRemaining = int32(len(generated.Codes))
+	return nil
+}
+
 func webAuthNViewFromEvent(event *models.Event) (*WebAuthNView, error) {
 	token := new(WebAuthNView)
 	err := json.Unmarshal(event.Data, token)
@@ -520,7 +562,7 @@ func (u *UserView) ComputeMFAMaxSetUp() {
 			return
 		}
 	}
-	if u.OTPState == int32(model.MFAStateReady) {
+	if u.OTPState == int32(model.MFAStateReady) || u.OTPSMSAdded || u.OTPEmailAdded {
 		u.MFAMaxSetUp = int32(domain.MFALevelSecondFactor)
 		return
 	}
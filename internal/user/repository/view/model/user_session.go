@@ -35,6 +35,8 @@ type UserSessionView struct {
 	LoginName                    string    `json:"-" gorm:"column:login_name"`
 	DisplayName                  string    `json:"-" gorm:"column:user_display_name"`
 	AvatarKey                    string    `json:"-" gorm:"column:avatar_key"`
+	UserAgent                    string    `json:"-" gorm:"column:user_agent"`
+	RemoteIP                     string    `json:"-" gorm:"column:remote_ip"`
 	SelectedIDPConfigID          string    `json:"selectedIDPConfigID" gorm:"column:selected_idp_config_id"`
 	PasswordVerification         time.Time `json:"-" gorm:"column:password_verification"`
 	PasswordlessVerification     time.Time `json:"-" gorm:"column:passwordless_verification"`
@@ -68,6 +70,8 @@ func UserSessionToModel(userSession *UserSessionView) *model.UserSessionView {
 		LoginName:                    userSession.LoginName,
 		DisplayName:                  userSession.DisplayName,
 		AvatarKey:                    userSession.AvatarKey,
+		UserAgent:                    userSession.UserAgent,
+		RemoteIP:                     userSession.RemoteIP,
 		SelectedIDPConfigID:          userSession.SelectedIDPConfigID,
 		PasswordVerification:         userSession.PasswordVerification,
 		PasswordlessVerification:     userSession.PasswordlessVerification,
@@ -96,6 +100,7 @@ func (v *UserSessionView) AppendEvent(event *models.Event) error {
 		user.HumanPasswordCheckSucceededType:
 		v.PasswordVerification = event.CreationDate
 		v.State = int32(domain.UserSessionStateActive)
+		return v.setBrowserInfo(event)
 	case user.UserIDPLoginCheckSucceededType:
 		data := new(es_model.AuthRequest)
 		err := data.SetData(event)
@@ -105,11 +110,13 @@ func (v *UserSessionView) AppendEvent(event *models.Event) error {
 		v.ExternalLoginVerification = event.CreationDate
 		v.SelectedIDPConfigID = data.SelectedIDPConfigID
 		v.State = int32(domain.UserSessionStateActive)
+		v.applyBrowserInfo(data.BrowserInfo)
 	case user.HumanPasswordlessTokenCheckSucceededType:
 		v.PasswordlessVerification = event.CreationDate
 		v.MultiFactorVerification = event.CreationDate
 		v.MultiFactorVerificationType = int32(domain.MFATypeU2FUserVerification)
 		v.State = int32(domain.UserSessionStateActive)
+		return v.setBrowserInfo(event)
 	case user.HumanPasswordlessTokenCheckFailedType,
 		user.HumanPasswordlessTokenRemovedType:
 		v.PasswordlessVerification = time.Time{}
@@ -139,12 +146,18 @@ func (v *UserSessionView) AppendEvent(event *models.Event) error {
 	case user.UserV1MFAOTPCheckSucceededType,
 		user.HumanMFAOTPCheckSucceededType:
 		v.setSecondFactorVerification(event.CreationDate, domain.MFATypeOTP)
+		return v.setBrowserInfo(event)
 	case user.UserV1MFAOTPCheckFailedType,
 		user.UserV1MFAOTPRemovedType,
 		user.HumanMFAOTPCheckFailedType,
 		user.HumanMFAOTPRemovedType,
 		user.HumanU2FTokenCheckFailedType,
-		user.HumanU2FTokenRemovedType:
+		user.HumanU2FTokenRemovedType,
+		user.HumanMFAOTPSMSCheckFailedType,
+		user.HumanMFAOTPSMSRemovedType,
+		user.HumanMFAOTPEmailCheckFailedType,
+		user.HumanMFAOTPEmailRemovedType,
+		user.HumanMFARecoveryCodeCheckFailedType:
 		v.SecondFactorVerification = time.Time{}
 	case user.HumanU2FTokenVerifiedType:
 		data := new(es_model.WebAuthNVerify)
@@ -157,6 +170,16 @@ func (v *UserSessionView) AppendEvent(event *models.Event) error {
 		}
 	case user.HumanU2FTokenCheckSucceededType:
 		v.setSecondFactorVerification(event.CreationDate, domain.MFATypeU2F)
+		return v.setBrowserInfo(event)
+	case user.HumanMFAOTPSMSCheckSucceededType:
+		v.setSecondFactorVerification(event.CreationDate, domain.MFATypeOTPSMS)
+		return v.setBrowserInfo(event)
+	case user.HumanMFAOTPEmailCheckSucceededType:
+		v.setSecondFactorVerification(event.CreationDate, domain.MFATypeOTPEmail)
+		return v.setBrowserInfo(event)
+	case user.HumanMFARecoveryCodeCheckSucceededType:
+		v.setSecondFactorVerification(event.CreationDate, domain.MFATypeRecoveryCode)
+		return v.setBrowserInfo(event)
 	case user.UserV1SignedOutType,
 		user.HumanSignedOutType,
 		user.UserLockedType,
@@ -190,6 +213,30 @@ func (v *UserSessionView) setSecondFactorVerification(verificationTime time.Time
 	v.State = int32(domain.UserSessionStateActive)
 }
 
+// setBrowserInfo keeps the user agent and ip of the last successful check,
+// so the user is able to recognise the device of the session
+func (v *UserSessionView) setBrowserInfo(event *models.Event) error {
+	if len(event.Data) == 0 {
+		return nil
+	}
+	data := new(es_model.AuthRequest)
+	if err := data.SetData(event); err != nil {
+		return err
+	}
+	v.applyBrowserInfo(data.BrowserInfo)
+	return nil
+}
+
+func (v *UserSessionView) applyBrowserInfo(info *es_model.BrowserInfo) {
+	if info == nil {
+		return
+	}
+	v.UserAgent = info.UserAgent
+	if info.RemoteIP != nil {
+		v.RemoteIP = info.RemoteIP.String()
+	}
+}
+
 func avatarKeyFromEvent(event *models.Event) (string, error) {
 	data := make(map[string]string)
 	if err := json.Unmarshal(event.Data, &data); err != nil {
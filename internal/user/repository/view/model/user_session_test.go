@@ -2,6 +2,7 @@ package model
 
 import (
 	"encoding/json"
+	"net"
 	"testing"
 	"time"
 
@@ -43,6 +44,27 @@ func TestAppendEvent(t *testing.T) {
 			},
 			result: &UserSessionView{ChangeDate: now(), PasswordVerification: now()},
 		},
+		{
+			name: "append human password check succeeded event with browser info",
+			args: args{
+				event: &es_models.Event{
+					CreationDate: now(),
+					Type:         es_models.EventType(user.HumanPasswordCheckSucceededType),
+					Data: func() []byte {
+						d, _ := json.Marshal(&es_model.AuthRequest{
+							UserAgentID: "id",
+							BrowserInfo: &es_model.BrowserInfo{
+								UserAgent: "Mozilla/5.0",
+								RemoteIP:  net.ParseIP("127.0.0.1"),
+							},
+						})
+						return d
+					}(),
+				},
+				userView: &UserSessionView{UserAgentID: "id"},
+			},
+			result: &UserSessionView{UserAgentID: "id", ChangeDate: now(), PasswordVerification: now(), UserAgent: "Mozilla/5.0", RemoteIP: "127.0.0.1"},
+		},
 		{
 			name: "append user password check failed event",
 			args: args{
@@ -144,6 +144,30 @@ func UsersByOrgID(db *gorm.DB, table, orgID, instanceID string) ([]*model.UserVi
 	return users, err
 }
 
+func UsersByIDs(db *gorm.DB, table string, userIDs []string, instanceID string) ([]*model.UserView, error) {
+	users := make([]*model.UserView, 0, len(userIDs))
+	userIDsQuery := &usr_model.UserSearchQuery{
+		Key:    usr_model.UserSearchKeyUserID,
+		Method: domain.SearchMethodIsOneOf,
+		Value:  userIDs,
+	}
+	instanceIDQuery := &usr_model.UserSearchQuery{
+		Key:    usr_model.UserSearchKeyInstanceID,
+		Method: domain.SearchMethodEquals,
+		Value:  instanceID,
+	}
+	ownerRemovedQuery := &usr_model.UserSearchQuery{
+		Key:    usr_model.UserSearchOwnerRemoved,
+		Method: domain.SearchMethodEquals,
+		Value:  false,
+	}
+	query := repository.PrepareSearchQuery(table, model.UserSearchRequest{
+		Queries: []*usr_model.UserSearchQuery{userIDsQuery, instanceIDQuery, ownerRemovedQuery},
+	})
+	_, err := query(db, &users)
+	return users, err
+}
+
 func UserIDsByDomain(db *gorm.DB, table, orgDomain, instanceID string) ([]string, error) {
 	type id struct {
 		Id string
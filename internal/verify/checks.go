@@ -0,0 +1,235 @@
+package verify
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+// replayer is the handler of the projection
+type replayer interface {
+	Trigger(ctx context.Context, instances ...string) error
+	EventTypes() []eventstore.EventType
+	Replay(tx *sql.Tx, events ...eventstore.Event) error
+}
+
+// check describes how the rows of a projection are derived from the events of an aggregate type
+type check struct {
+	name          string
+	table         string
+	aggregateType eventstore.AggregateType
+	eventTypes    []eventstore.EventType
+	// columns are compared besides the id
+	columns []string
+	// ownerRemovedColumn is set to true if the organisation of the resource owner is removed
+	ownerRemovedColumn string
+	reduce             func(*readModel, eventstore.Event)
+	replayer           func() replayer
+}
+
+var checks = []*check{
+	{
+		name:          "users",
+		table:         projection.UserTable,
+		aggregateType: user.AggregateType,
+		eventTypes: []eventstore.EventType{
+			user.UserV1AddedType,
+			user.HumanAddedType,
+			user.UserV1RegisteredType,
+			user.HumanRegisteredType,
+			user.MachineAddedEventType,
+			user.UserV1InitialCodeAddedType,
+			user.HumanInitialCodeAddedType,
+			user.UserV1InitializedCheckSucceededType,
+			user.HumanInitializedCheckSucceededType,
+			user.UserLockedType,
+			user.UserUnlockedType,
+			user.UserDeactivatedType,
+			user.UserReactivatedType,
+			user.UserRemovedType,
+			user.UserUserNameChangedType,
+			user.UserDomainClaimedType,
+		},
+		columns: []string{
+			projection.UserResourceOwnerCol,
+			projection.UserStateCol,
+			projection.UserTypeCol,
+			projection.UserUsernameCol,
+			projection.UserOwnerRemovedCol,
+		},
+		ownerRemovedColumn: projection.UserOwnerRemovedCol,
+		reduce:             reduceUser,
+		replayer: func() replayer {
+			if projection.UserProjection == nil {
+				return nil
+			}
+			return projection.UserProjection
+		},
+	},
+	{
+		name:          "orgs",
+		table:         projection.OrgProjectionTable,
+		aggregateType: org.AggregateType,
+		eventTypes: []eventstore.EventType{
+			org.OrgAddedEventType,
+			org.OrgChangedEventType,
+			org.OrgDeactivatedEventType,
+			org.OrgReactivatedEventType,
+			org.OrgRemovedEventType,
+			org.OrgDomainPrimarySetEventType,
+		},
+		columns: []string{
+			projection.OrgColumnResourceOwner,
+			projection.OrgColumnState,
+			projection.OrgColumnName,
+			projection.OrgColumnDomain,
+		},
+		reduce: reduceOrg,
+		replayer: func() replayer {
+			if projection.OrgProjection == nil {
+				return nil
+			}
+			return projection.OrgProjection
+		},
+	},
+	{
+		name:          "projects",
+		table:         projection.ProjectProjectionTable,
+		aggregateType: project.AggregateType,
+		eventTypes: []eventstore.EventType{
+			project.ProjectAddedType,
+			project.ProjectChangedType,
+			project.ProjectDeactivatedType,
+			project.ProjectReactivatedType,
+			project.ProjectRemovedType,
+		},
+		columns: []string{
+			projection.ProjectColumnResourceOwner,
+			projection.ProjectColumnState,
+			projection.ProjectColumnName,
+			projection.ProjectColumnOwnerRemoved,
+		},
+		ownerRemovedColumn: projection.ProjectColumnOwnerRemoved,
+		reduce:             reduceProject,
+		replayer: func() replayer {
+			if projection.ProjectProjection == nil {
+				return nil
+			}
+			return projection.ProjectProjection
+		},
+	},
+}
+
+func (c *check) rowsStmt(instanceID, aggregateID string) (string, []interface{}) {
+	stmt := "SELECT id, " + strings.Join(c.columns, ", ") + " FROM " + c.table + " WHERE instance_id = $1"
+	args := []interface{}{instanceID}
+	if aggregateID != "" {
+		stmt += " AND id = $2"
+		args = append(args, aggregateID)
+	}
+	return stmt, args
+}
+
+func (c *check) deleteStmt() string {
+	return "DELETE FROM " + c.table + " WHERE instance_id = $1 AND id = $2"
+}
+
+func (c *check) setOwnerRemoved(models map[string]*readModel, removedOrgs map[string]eventstore.Event) {
+	if c.ownerRemovedColumn == "" {
+		return
+	}
+	for _, model := range models {
+		if model.exists {
+			model.columns[c.ownerRemovedColumn] = strconv.FormatBool(removedOrgs[model.resourceOwner] != nil)
+		}
+	}
+}
+
+func (m *readModel) add(event eventstore.Event, resourceOwnerColumn string) {
+	m.exists = true
+	m.resourceOwner = event.Aggregate().ResourceOwner
+	m.columns[resourceOwnerColumn] = m.resourceOwner
+}
+
+func reduceUser(m *readModel, event eventstore.Event) {
+	switch e := event.(type) {
+	case *user.HumanAddedEvent:
+		m.addUser(e, e.UserName, domain.UserTypeHuman)
+	case *user.HumanRegisteredEvent:
+		m.addUser(e, e.UserName, domain.UserTypeHuman)
+	case *user.MachineAddedEvent:
+		m.addUser(e, e.UserName, domain.UserTypeMachine)
+	case *user.HumanInitialCodeAddedEvent:
+		m.columns[projection.UserStateCol] = enum(int32(domain.UserStateInitial))
+	case *user.HumanInitializedCheckSucceededEvent,
+		*user.UserUnlockedEvent,
+		*user.UserReactivatedEvent:
+		m.columns[projection.UserStateCol] = enum(int32(domain.UserStateActive))
+	case *user.UserLockedEvent:
+		m.columns[projection.UserStateCol] = enum(int32(domain.UserStateLocked))
+	case *user.UserDeactivatedEvent:
+		m.columns[projection.UserStateCol] = enum(int32(domain.UserStateInactive))
+	case *user.UserRemovedEvent:
+		m.exists = false
+	case *user.UsernameChangedEvent:
+		m.columns[projection.UserUsernameCol] = e.UserName
+	case *user.DomainClaimedEvent:
+		m.columns[projection.UserUsernameCol] = e.UserName
+	}
+}
+
+func (m *readModel) addUser(event eventstore.Event, username string, userType domain.UserType) {
+	m.add(event, projection.UserResourceOwnerCol)
+	m.columns[projection.UserStateCol] = enum(int32(domain.UserStateActive))
+	m.columns[projection.UserTypeCol] = enum(int32(userType))
+	m.columns[projection.UserUsernameCol] = username
+}
+
+func reduceOrg(m *readModel, event eventstore.Event) {
+	switch e := event.(type) {
+	case *org.OrgAddedEvent:
+		m.add(e, projection.OrgColumnResourceOwner)
+		m.columns[projection.OrgColumnState] = enum(int32(domain.OrgStateActive))
+		m.columns[projection.OrgColumnName] = e.Name
+	case *org.OrgChangedEvent:
+		if e.Name != "" {
+			m.columns[projection.OrgColumnName] = e.Name
+		}
+	case *org.OrgDeactivatedEvent:
+		m.columns[projection.OrgColumnState] = enum(int32(domain.OrgStateInactive))
+	case *org.OrgReactivatedEvent:
+		m.columns[projection.OrgColumnState] = enum(int32(domain.OrgStateActive))
+	case *org.OrgRemovedEvent:
+		// the projection keeps removed organisations
+		m.columns[projection.OrgColumnState] = enum(int32(domain.OrgStateRemoved))
+	case *org.DomainPrimarySetEvent:
+		m.columns[projection.OrgColumnDomain] = e.Domain
+	}
+}
+
+func reduceProject(m *readModel, event eventstore.Event) {
+	switch e := event.(type) {
+	case *project.ProjectAddedEvent:
+		m.add(e, projection.ProjectColumnResourceOwner)
+		m.columns[projection.ProjectColumnState] = enum(int32(domain.ProjectStateActive))
+		m.columns[projection.ProjectColumnName] = e.Name
+	case *project.ProjectChangeEvent:
+		if e.Name != nil {
+			m.columns[projection.ProjectColumnName] = *e.Name
+		}
+	case *project.ProjectDeactivatedEvent:
+		m.columns[projection.ProjectColumnState] = enum(int32(domain.ProjectStateInactive))
+	case *project.ProjectReactivatedEvent:
+		m.columns[projection.ProjectColumnState] = enum(int32(domain.ProjectStateActive))
+	case *project.ProjectRemovedEvent:
+		m.exists = false
+	}
+}
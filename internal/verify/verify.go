@@ -0,0 +1,428 @@
+package verify
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+// DifferenceType describes how a row of a projection differs from the replayed events
+type DifferenceType int32
+
+const (
+	// DifferenceMissing means the events describe a row which does not exist in the projection
+	DifferenceMissing DifferenceType = iota
+	// DifferenceUnexpected means the projection contains a row which is not described by the events
+	DifferenceUnexpected
+	// DifferenceColumn means a column of the row does not match the events
+	DifferenceColumn
+)
+
+func (t DifferenceType) String() string {
+	switch t {
+	case DifferenceMissing:
+		return "missing"
+	case DifferenceUnexpected:
+		return "unexpected"
+	case DifferenceColumn:
+		return "column"
+	default:
+		return "unknown"
+	}
+}
+
+// Difference is a mismatch between the events of an aggregate and its row in a projection
+type Difference struct {
+	Type        DifferenceType
+	Projection  string
+	InstanceID  string
+	AggregateID string
+	Column      string
+	Expected    string
+	Actual      string
+	Repaired    bool
+}
+
+func (d *Difference) String() string {
+	s := fmt.Sprintf("%s instance %s aggregate %s: %s", d.Projection, d.InstanceID, d.AggregateID, d.Type)
+	if d.Type == DifferenceColumn {
+		s += fmt.Sprintf(" %s expected %q actual %q", d.Column, d.Expected, d.Actual)
+	}
+	if d.Repaired {
+		s += " (repaired)"
+	}
+	return s
+}
+
+// Query selects what is verified
+// empty Projections verifies all supported projections
+// empty InstanceIDs verifies all instances which are not removed
+type Query struct {
+	Projections []string
+	InstanceIDs []string
+	AggregateID string
+	Repair      bool
+}
+
+// Result summarises the verification of a projection of an instance
+type Result struct {
+	Projection  string
+	InstanceID  string
+	Aggregates  int
+	Differences int
+	Repaired    int
+}
+
+// Verifier replays the events of aggregates into in-memory read models
+// and compares them with the rows of the projections
+type Verifier struct {
+	es     *eventstore.Eventstore
+	client *database.DB
+}
+
+func NewVerifier(es *eventstore.Eventstore, client *database.DB) *Verifier {
+	return &Verifier{
+		es:     es,
+		client: client,
+	}
+}
+
+// Projections returns the names of the projections which can be verified
+func Projections() []string {
+	names := make([]string, len(checks))
+	for i, c := range checks {
+		names[i] = c.name
+	}
+	return names
+}
+
+// Verify compares the selected projections per instance and calls report for every difference found
+// if Repair is set the rows of the aggregates with differences are rebuilt from their events
+func (v *Verifier) Verify(ctx context.Context, query *Query, report func(*Difference)) ([]*Result, error) {
+	selected, err := selectChecks(query.Projections)
+	if err != nil {
+		return nil, err
+	}
+	instanceIDs := query.InstanceIDs
+	if len(instanceIDs) == 0 {
+		instanceIDs, err = v.instanceIDs(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+	results := make([]*Result, 0, len(instanceIDs)*len(selected))
+	for _, instanceID := range instanceIDs {
+		removedOrgs, err := v.removedOrgs(ctx, instanceID)
+		if err != nil {
+			return results, err
+		}
+		for _, c := range selected {
+			result, err := v.verify(ctx, c, instanceID, query, removedOrgs, report)
+			if err != nil {
+				return results, err
+			}
+			results = append(results, result)
+		}
+	}
+	return results, nil
+}
+
+func (v *Verifier) verify(ctx context.Context, c *check, instanceID string, query *Query, removedOrgs map[string]eventstore.Event, report func(*Difference)) (*Result, error) {
+	r := c.replayer()
+	if r == nil {
+		return nil, errors.ThrowPreconditionFailed(nil, "VERIF-Pk2sx", "projection not started")
+	}
+	// the rows are only compared after the projection reduced the events known so far
+	if err := r.Trigger(ctx, instanceID); err != nil {
+		return nil, err
+	}
+	models, err := v.replay(ctx, c, instanceID, query.AggregateID)
+	if err != nil {
+		return nil, err
+	}
+	c.setOwnerRemoved(models, removedOrgs)
+	rows, err := v.rows(ctx, c, instanceID, query.AggregateID)
+	if err != nil {
+		return nil, err
+	}
+	differences := compare(c, instanceID, models, rows)
+	result := &Result{
+		Projection:  c.name,
+		InstanceID:  instanceID,
+		Aggregates:  len(models),
+		Differences: len(differences),
+	}
+	repaired := make(map[string]bool)
+	for _, difference := range differences {
+		if query.Repair {
+			if _, ok := repaired[difference.AggregateID]; !ok {
+				var removedOrg eventstore.Event
+				if model := models[difference.AggregateID]; model != nil && c.ownerRemovedColumn != "" {
+					removedOrg = removedOrgs[model.resourceOwner]
+				}
+				err = v.repair(ctx, c, r, instanceID, difference.AggregateID, removedOrg)
+				logging.WithFields("projection", c.name, "instance", instanceID, "aggregate", difference.AggregateID).OnError(err).Warn("unable to repair aggregate")
+				repaired[difference.AggregateID] = err == nil
+				if err == nil {
+					result.Repaired++
+				}
+			}
+			difference.Repaired = repaired[difference.AggregateID]
+		}
+		if report != nil {
+			report(difference)
+		}
+	}
+	return result, nil
+}
+
+// replay reduces the events of the aggregates of the check into read models
+func (v *Verifier) replay(ctx context.Context, c *check, instanceID, aggregateID string) (map[string]*readModel, error) {
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		InstanceID(instanceID).
+		AggregateTypes(c.aggregateType).
+		EventTypes(c.eventTypes...)
+	if aggregateID != "" {
+		query = query.AggregateIDs(aggregateID)
+	}
+	events, err := v.es.Filter(ctx, query.Builder())
+	if err != nil {
+		return nil, err
+	}
+	models := make(map[string]*readModel)
+	for _, event := range events {
+		id := event.Aggregate().ID
+		model, ok := models[id]
+		if !ok {
+			model = newReadModel()
+			models[id] = model
+		}
+		c.reduce(model, event)
+	}
+	return models, nil
+}
+
+func (v *Verifier) rows(ctx context.Context, c *check, instanceID, aggregateID string) (map[string]map[string]string, error) {
+	stmt, args := c.rowsStmt(instanceID, aggregateID)
+	rows, err := v.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "VERIF-Qw3ra", "unable to query projection")
+	}
+	defer rows.Close()
+
+	result := make(map[string]map[string]string)
+	for rows.Next() {
+		values := make([]sql.NullString, len(c.columns)+1)
+		dest := make([]interface{}, len(values))
+		for i := range values {
+			dest[i] = &values[i]
+		}
+		if err = rows.Scan(dest...); err != nil {
+			return nil, errors.ThrowInternal(err, "VERIF-Hd8ws", "unable to scan projection")
+		}
+		row := make(map[string]string, len(c.columns))
+		for i, column := range c.columns {
+			row[column] = values[i+1].String
+		}
+		result[values[0].String] = row
+	}
+	if err = rows.Err(); err != nil {
+		return nil, errors.ThrowInternal(err, "VERIF-Uw2bd", "unable to scan projection")
+	}
+	return result, nil
+}
+
+// repair deletes the row of the aggregate and reduces its events into the projection again.
+// The deletion cascades to the suffixed tables of the projection,
+// therefore all events of the aggregate reduced by the projection are replayed,
+// not only the ones the check compares
+func (v *Verifier) repair(ctx context.Context, c *check, r replayer, instanceID, aggregateID string, removedOrg eventstore.Event) (err error) {
+	events, err := v.es.Filter(ctx, eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		InstanceID(instanceID).
+		AggregateTypes(c.aggregateType).
+		AggregateIDs(aggregateID).
+		EventTypes(r.EventTypes()...).
+		Builder())
+	if err != nil {
+		return err
+	}
+	if removedOrg != nil {
+		events = append(events, removedOrg)
+	}
+	tx, err := v.client.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.ThrowInternal(err, "VERIF-Bt4ok", "unable to begin transaction")
+	}
+	defer func() {
+		if err != nil {
+			rollbackErr := tx.Rollback()
+			logging.OnError(rollbackErr).Debug("rollback failed")
+		}
+	}()
+	if _, err = tx.ExecContext(ctx, c.deleteStmt(), instanceID, aggregateID); err != nil {
+		return errors.ThrowInternal(err, "VERIF-Dl3pe", "unable to delete row")
+	}
+	if err = r.Replay(tx, events...); err != nil {
+		return err
+	}
+	if err = tx.Commit(); err != nil {
+		return errors.ThrowInternal(err, "VERIF-Cm8ys", "unable to commit repair")
+	}
+	return nil
+}
+
+// instanceIDs returns all instances which are not removed
+func (v *Verifier) instanceIDs(ctx context.Context) ([]string, error) {
+	instanceIDs, err := v.es.InstanceIDs(ctx, eventstore.NewSearchQueryBuilder(eventstore.ColumnsInstanceIDs).
+		AddQuery().
+		ExcludedInstanceID("").
+		Builder())
+	if err != nil {
+		return nil, err
+	}
+	removed, err := v.es.InstanceIDs(ctx, eventstore.NewSearchQueryBuilder(eventstore.ColumnsInstanceIDs).
+		AddQuery().
+		ExcludedInstanceID("").
+		AggregateTypes(instance.AggregateType).
+		EventTypes(instance.InstanceRemovedEventType).
+		Builder())
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(instanceIDs))
+	for _, id := range instanceIDs {
+		if !contains(removed, id) {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// removedOrgs returns the removed events of the removed organisations of the instance
+func (v *Verifier) removedOrgs(ctx context.Context, instanceID string) (map[string]eventstore.Event, error) {
+	events, err := v.es.Filter(ctx, eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		InstanceID(instanceID).
+		AggregateTypes(org.AggregateType).
+		EventTypes(org.OrgRemovedEventType).
+		Builder())
+	if err != nil {
+		return nil, err
+	}
+	removed := make(map[string]eventstore.Event, len(events))
+	for _, event := range events {
+		removed[event.Aggregate().ID] = event
+	}
+	return removed, nil
+}
+
+// compare returns the differences between the read models and the rows ordered by aggregate id
+func compare(c *check, instanceID string, models map[string]*readModel, rows map[string]map[string]string) []*Difference {
+	ids := make([]string, 0, len(models)+len(rows))
+	for id := range models {
+		ids = append(ids, id)
+	}
+	for id := range rows {
+		if _, ok := models[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	differences := make([]*Difference, 0)
+	for _, id := range ids {
+		model, row := models[id], rows[id]
+		exists := model != nil && model.exists
+		newDifference := func(typ DifferenceType) *Difference {
+			return &Difference{
+				Type:        typ,
+				Projection:  c.name,
+				InstanceID:  instanceID,
+				AggregateID: id,
+			}
+		}
+		switch {
+		case !exists && row == nil:
+			continue
+		case exists && row == nil:
+			differences = append(differences, newDifference(DifferenceMissing))
+		case !exists:
+			differences = append(differences, newDifference(DifferenceUnexpected))
+		default:
+			for _, column := range c.columns {
+				if model.columns[column] == row[column] {
+					continue
+				}
+				difference := newDifference(DifferenceColumn)
+				difference.Column = column
+				difference.Expected = model.columns[column]
+				difference.Actual = row[column]
+				differences = append(differences, difference)
+			}
+		}
+	}
+	return differences
+}
+
+// readModel is the in-memory state of an aggregate
+// the columns are formatted the way the database returns them as text
+type readModel struct {
+	exists        bool
+	resourceOwner string
+	columns       map[string]string
+}
+
+func newReadModel() *readModel {
+	return &readModel{columns: make(map[string]string)}
+}
+
+// enum formats enum values like the database returns them as text
+func enum(value int32) string {
+	return strconv.FormatInt(int64(value), 10)
+}
+
+func selectChecks(names []string) ([]*check, error) {
+	if len(names) == 0 {
+		return checks, nil
+	}
+	selected := make([]*check, 0, len(names))
+	for _, name := range names {
+		c := checkByName(name)
+		if c == nil {
+			return nil, errors.ThrowInvalidArgumentf(nil, "VERIF-Nv9ab", "projection %s cannot be verified, supported projections are %s", name, strings.Join(Projections(), ", "))
+		}
+		selected = append(selected, c)
+	}
+	return selected, nil
+}
+
+func checkByName(name string) *check {
+	for _, c := range checks {
+		if c.name == name || c.table == name {
+			return c
+		}
+	}
+	return nil
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,138 @@
+package verify
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+func Test_reduce(t *testing.T) {
+	ctx := context.Background()
+	userAgg := &user.NewAggregate("user1", "org1").Aggregate
+	orgAgg := &org.NewAggregate("org1").Aggregate
+	tests := []struct {
+		name       string
+		check      string
+		events     []eventstore.Event
+		wantExists bool
+		want       map[string]string
+	}{
+		{
+			name:  "machine locked and renamed",
+			check: "users",
+			events: []eventstore.Event{
+				user.NewMachineAddedEvent(ctx, userAgg, "machine", "name", "", false, domain.OIDCTokenTypeBearer),
+				user.NewUserLockedEvent(ctx, userAgg, false),
+				user.NewUsernameChangedEvent(ctx, userAgg, "machine", "renamed", false),
+			},
+			wantExists: true,
+			want: map[string]string{
+				"resource_owner": "org1",
+				"state":          "4",
+				"type":           "2",
+				"username":       "renamed",
+			},
+		},
+		{
+			name:  "user removed",
+			check: "users",
+			events: []eventstore.Event{
+				user.NewMachineAddedEvent(ctx, userAgg, "machine", "name", "", false, domain.OIDCTokenTypeBearer),
+				user.NewUserRemovedEvent(ctx, userAgg, "machine", nil, false),
+			},
+			wantExists: false,
+		},
+		{
+			name:  "org removed keeps row",
+			check: "orgs",
+			events: []eventstore.Event{
+				org.NewOrgAddedEvent(ctx, orgAgg, "org"),
+				org.NewOrgDeactivatedEvent(ctx, orgAgg),
+				org.NewOrgRemovedEvent(ctx, orgAgg, "org", nil, false, nil, nil, nil),
+			},
+			wantExists: true,
+			want: map[string]string{
+				"resource_owner": "org1",
+				"org_state":      "3",
+				"name":           "org",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := checkByName(tt.check)
+			model := newReadModel()
+			for _, event := range tt.events {
+				c.reduce(model, event)
+			}
+			assert.Equal(t, tt.wantExists, model.exists)
+			for column, value := range tt.want {
+				assert.Equal(t, value, model.columns[column], column)
+			}
+		})
+	}
+}
+
+func Test_compare(t *testing.T) {
+	c := checkByName("projects")
+	existing := func(columns map[string]string) *readModel {
+		return &readModel{exists: true, resourceOwner: "org1", columns: columns}
+	}
+	models := map[string]*readModel{
+		"equal":     existing(map[string]string{"resource_owner": "org1", "state": "1", "name": "p", "owner_removed": "false"}),
+		"missing":   existing(map[string]string{"resource_owner": "org1", "state": "1", "name": "p", "owner_removed": "false"}),
+		"changed":   existing(map[string]string{"resource_owner": "org1", "state": "2", "name": "new", "owner_removed": "false"}),
+		"removed":   {resourceOwner: "org1", columns: map[string]string{}},
+		"unchanged": {resourceOwner: "org1", columns: map[string]string{}},
+	}
+	rows := map[string]map[string]string{
+		"equal":      {"resource_owner": "org1", "state": "1", "name": "p", "owner_removed": "false"},
+		"changed":    {"resource_owner": "org1", "state": "1", "name": "old", "owner_removed": "false"},
+		"removed":    {"resource_owner": "org1", "state": "1", "name": "p", "owner_removed": "false"},
+		"unexpected": {"resource_owner": "org1", "state": "1", "name": "p", "owner_removed": "false"},
+	}
+	want := []*Difference{
+		{Type: DifferenceColumn, Projection: "projects", InstanceID: "instance", AggregateID: "changed", Column: "state", Expected: "2", Actual: "1"},
+		{Type: DifferenceColumn, Projection: "projects", InstanceID: "instance", AggregateID: "changed", Column: "name", Expected: "new", Actual: "old"},
+		{Type: DifferenceMissing, Projection: "projects", InstanceID: "instance", AggregateID: "missing"},
+		{Type: DifferenceUnexpected, Projection: "projects", InstanceID: "instance", AggregateID: "removed"},
+		{Type: DifferenceUnexpected, Projection: "projects", InstanceID: "instance", AggregateID: "unexpected"},
+	}
+	assert.Equal(t, want, compare(c, "instance", models, rows))
+}
+
+func Test_setOwnerRemoved(t *testing.T) {
+	removed := &readModel{exists: true, resourceOwner: "removed", columns: map[string]string{}}
+	active := &readModel{exists: true, resourceOwner: "active", columns: map[string]string{}}
+	checkByName("users").setOwnerRemoved(
+		map[string]*readModel{"1": removed, "2": active},
+		map[string]eventstore.Event{"removed": org.NewOrgRemovedEvent(context.Background(), &org.NewAggregate("removed").Aggregate, "org", nil, false, nil, nil, nil)},
+	)
+	assert.Equal(t, "true", removed.columns["owner_removed"])
+	assert.Equal(t, "false", active.columns["owner_removed"])
+}
+
+func Test_selectChecks(t *testing.T) {
+	all, err := selectChecks(nil)
+	assert.NoError(t, err)
+	assert.Len(t, all, len(checks))
+
+	selected, err := selectChecks([]string{"projections.users8"})
+	assert.NoError(t, err)
+	assert.Equal(t, "users", selected[0].name)
+
+	_, err = selectChecks([]string{"unknown"})
+	assert.Error(t, err)
+}
+
+func Test_rowsStmt(t *testing.T) {
+	stmt, args := checkByName("orgs").rowsStmt("instance", "org1")
+	assert.Equal(t, "SELECT id, resource_owner, org_state, name, primary_domain FROM projections.orgs WHERE instance_id = $1 AND id = $2", stmt)
+	assert.Equal(t, []interface{}{"instance", "org1"}, args)
+}